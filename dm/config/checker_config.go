@@ -13,6 +13,8 @@ var (
 	DefaultBackoffMax              = 5 * time.Minute
 	DefaultBackoffJitter           = true
 	DefaultBackoffFactor   float64 = 2
+
+	DefaultMaxNoProgressResumes = 10
 )
 
 // Duration is used to hold a time.Duration field
@@ -48,6 +50,12 @@ type CheckerConfig struct {
 	CheckEnable     bool     `yaml:"check-enable" toml:"check-enable" json:"check-enable"`
 	BackoffRollback Duration `yaml:"backoff-rollback" toml:"backoff-rollback" json:"backoff-rollback"`
 	BackoffMax      Duration `yaml:"backoff-max" toml:"backoff-max" json:"backoff-max"`
+	// MaxNoProgressResumes is the max number of auto resumes of a subtask from the same checkpoint, the subtask won't
+	// be auto resumed anymore after that because it makes no forward progress. 0 means no limit.
+	MaxNoProgressResumes int `yaml:"max-no-progress-resumes" toml:"max-no-progress-resumes" json:"max-no-progress-resumes"`
+	// PauseOnUpstreamDown makes the checker probe the upstream of the source once in each round, and pause all running
+	// subtasks together when it's down, then resume them together when it's up again.
+	PauseOnUpstreamDown bool `yaml:"pause-on-upstream-down" toml:"pause-on-upstream-down" json:"pause-on-upstream-down"`
 	// unexpose config
 	CheckInterval Duration `yaml:"check-interval" toml:"check-interval" json:"-"`
 	BackoffMin    Duration `yaml:"backoff-min" toml:"backoff-min" json:"-"`
@@ -0,0 +1,59 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// TransformFunc is the built-in function to transform values of a column.
+type TransformFunc string
+
+// built-in transform functions.
+const (
+	// TransformHash replaces the value with the hex encoded SHA-256 of it, the same values are still the same.
+	TransformHash TransformFunc = "hash"
+	// TransformMask replaces each character of the value with `*`, the length is kept.
+	TransformMask TransformFunc = "mask"
+	// TransformNullify replaces the value with NULL.
+	TransformNullify TransformFunc = "nullify"
+)
+
+// ColumnTransform represents a transformation applied to values of a column of the matched tables when replicating
+// binlog events, e.g. hashing columns of PII.
+// NOTE: data dumped and loaded in full mode are NOT transformed, and the downstream column should be able to store
+// the transformed value, e.g. a string column for `hash`. `mask` and `nullify` should not be applied to columns of
+// primary/unique keys, otherwise rows of UPDATE and DELETE can't be found in downstream.
+type ColumnTransform struct {
+	SchemaPattern string        `yaml:"schema-pattern" toml:"schema-pattern" json:"schema-pattern"`
+	TablePattern  string        `yaml:"table-pattern" toml:"table-pattern" json:"table-pattern"`
+	Column        string        `yaml:"column" toml:"column" json:"column"`
+	Func          TransformFunc `yaml:"func" toml:"func" json:"func"`
+}
+
+// Valid checks validity of the column transform.
+func (t *ColumnTransform) Valid() error {
+	if len(t.SchemaPattern) == 0 {
+		return terror.ErrConfigInvalidColumnTransform.Generate(t, "schema-pattern should not be empty")
+	}
+	if len(t.Column) == 0 {
+		return terror.ErrConfigInvalidColumnTransform.Generate(t, "column should not be empty")
+	}
+	switch t.Func {
+	case TransformHash, TransformMask, TransformNullify:
+	default:
+		return terror.ErrConfigInvalidColumnTransform.Generate(t, "unsupported func")
+	}
+	return nil
+}
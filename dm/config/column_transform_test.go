@@ -0,0 +1,41 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+func (t *testConfig) TestColumnTransformValid(c *C) {
+	transform := &ColumnTransform{SchemaPattern: "db*", TablePattern: "tbl", Column: "email", Func: TransformMask}
+	c.Assert(transform.Valid(), IsNil)
+	// only schema pattern is fine.
+	transform.TablePattern = ""
+	c.Assert(transform.Valid(), IsNil)
+
+	cases := []ColumnTransform{
+		{TablePattern: "tbl", Column: "email", Func: TransformHash},
+		{SchemaPattern: "db", TablePattern: "tbl", Func: TransformHash},
+		{SchemaPattern: "db", TablePattern: "tbl", Column: "email"},
+		{SchemaPattern: "db", TablePattern: "tbl", Column: "email", Func: "upper"},
+	}
+	for _, cs := range cases {
+		c.Assert(terror.ErrConfigInvalidColumnTransform.Equal(cs.Valid()), IsTrue)
+	}
+
+	cfg := &SubTaskConfig{Name: "test", SourceID: "mysql-replica-01", ColumnTransforms: []*ColumnTransform{&cases[3]}}
+	c.Assert(terror.ErrConfigInvalidColumnTransform.Equal(cfg.Adjust(false)), IsTrue)
+}
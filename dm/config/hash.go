@@ -0,0 +1,102 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// passwordKey is the key of passwords in JSON represent of configs, passwords are not hashed because the same
+// password may be encrypted or plaintext.
+const passwordKey = "password"
+
+// sourceRuntimeKeys are keys of the source config adjusted by DM-worker at runtime, they are not hashed.
+var sourceRuntimeKeys = []string{"relay-binlog-name", "relay-binlog-gtid"}
+
+// Hash returns a stable hash of the source config, passwords and values adjusted at runtime are not hashed.
+func (c *SourceConfig) Hash() (string, error) {
+	return hashJSON(c, sourceRuntimeKeys...)
+}
+
+// Hash returns a stable hash of the subtask config, passwords are not hashed.
+func (c *SubTaskConfig) Hash() (string, error) {
+	return hashJSON(c)
+}
+
+// WorkerConfigHash returns a stable hash of the source config and the subtask configs of a DM-worker, the order of
+// subtask configs doesn't matter. tools can compare it with the hash returned by DM-worker to detect config drift.
+func WorkerConfigHash(source *SourceConfig, subTasks []*SubTaskConfig) (string, error) {
+	sourceHash, err := source.Hash()
+	if err != nil {
+		return "", err
+	}
+	subTaskHashes := make([]string, 0, len(subTasks))
+	for _, cfg := range subTasks {
+		h, err := cfg.Hash()
+		if err != nil {
+			return "", err
+		}
+		subTaskHashes = append(subTaskHashes, fmt.Sprintf("%s:%s", cfg.Name, h))
+	}
+	sort.Strings(subTaskHashes)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "source:%s\n", sourceHash)
+	for _, subTaskHash := range subTaskHashes {
+		fmt.Fprintf(h, "subtask:%s\n", subTaskHash)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashJSON hashes the JSON represent of v, keys of objects are sorted so the hash is deterministic.
+func hashJSON(v interface{}, excludedKeys ...string) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", terror.ErrConfigHash.Delegate(err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return "", terror.ErrConfigHash.Delegate(err)
+	}
+	for _, k := range excludedKeys {
+		delete(m, k)
+	}
+	removePasswords(m)
+	// encoding/json sorts the keys of maps.
+	if data, err = json.Marshal(m); err != nil {
+		return "", terror.ErrConfigHash.Delegate(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func removePasswords(v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		delete(vv, passwordKey)
+		for _, sub := range vv {
+			removePasswords(sub)
+		}
+	case []interface{}:
+		for _, sub := range vv {
+			removePasswords(sub)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	. "github.com/pingcap/check"
+)
+
+func (t *testConfig) TestConfigHash(c *C) {
+	source := NewSourceConfig()
+	c.Assert(source.LoadFromFile(sourceSampleFile), IsNil)
+	hash, err := source.Hash()
+	c.Assert(err, IsNil)
+	c.Assert(hash, HasLen, 64)
+
+	// same config loaded again, or only password and runtime values changed.
+	source2 := NewSourceConfig()
+	c.Assert(source2.LoadFromFile(sourceSampleFile), IsNil)
+	source2.From.Password = "another-password"
+	source2.RelayBinLogName = "mysql-bin.000100"
+	hash2, err := source2.Hash()
+	c.Assert(err, IsNil)
+	c.Assert(hash2, Equals, hash)
+
+	source2.From.Host = "another-host"
+	hash2, err = source2.Hash()
+	c.Assert(err, IsNil)
+	c.Assert(hash2, Not(Equals), hash)
+
+	task1 := &SubTaskConfig{Name: "task1", From: DBConfig{Host: "127.0.0.1", Password: "123456"}}
+	task2 := &SubTaskConfig{Name: "task2", To: DBConfig{Host: "127.0.0.1"}}
+	workerHash, err := WorkerConfigHash(source, []*SubTaskConfig{task1, task2})
+	c.Assert(err, IsNil)
+	workerHash2, err := WorkerConfigHash(source, []*SubTaskConfig{task2, task1})
+	c.Assert(err, IsNil)
+	c.Assert(workerHash2, Equals, workerHash)
+
+	task1.From.Password = ""
+	workerHash2, err = WorkerConfigHash(source, []*SubTaskConfig{task1, task2})
+	c.Assert(err, IsNil)
+	c.Assert(workerHash2, Equals, workerHash)
+
+	workerHash2, err = WorkerConfigHash(source, []*SubTaskConfig{task1})
+	c.Assert(err, IsNil)
+	c.Assert(workerHash2, Not(Equals), workerHash)
+	workerHash2, err = WorkerConfigHash(source, nil)
+	c.Assert(err, IsNil)
+	c.Assert(workerHash2, Not(Equals), workerHash)
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// RedactionLevel represents how much information should be masked when a config is converted to string.
+type RedactionLevel int32
+
+// redaction levels.
+const (
+	// RedactionNone masks nothing.
+	RedactionNone RedactionLevel = iota
+	// RedactionConnection masks the connection details (host, user and password) of upstream and downstream.
+	RedactionConnection
+	// RedactionAll masks the connection details and schema/table names in rules.
+	RedactionAll
+)
+
+// redactedValue is the placeholder of masked values.
+const redactedValue = "?"
+
+var (
+	redactionLevel int32
+
+	connectionKeys = map[string]struct{}{
+		"host":     {},
+		"user":     {},
+		"password": {},
+	}
+	schemaTableKeys = map[string]struct{}{
+		"schema-pattern": {},
+		"table-pattern":  {},
+		"target-schema":  {},
+		"target-table":   {},
+		"do-dbs":         {},
+		"ignore-dbs":     {},
+		"db-name":        {},
+		"tbl-name":       {},
+	}
+)
+
+func (l RedactionLevel) String() string {
+	switch l {
+	case RedactionNone:
+		return "none"
+	case RedactionConnection:
+		return "connection"
+	case RedactionAll:
+		return "all"
+	default:
+		return "unknown"
+	}
+}
+
+// SetRedactionLevel sets the redaction level used by `String` of configs.
+// the level is process-wide, so it affects all configs in the same process.
+func SetRedactionLevel(level RedactionLevel) {
+	atomic.StoreInt32(&redactionLevel, int32(level))
+}
+
+// GetRedactionLevel returns the current redaction level.
+func GetRedactionLevel() RedactionLevel {
+	return RedactionLevel(atomic.LoadInt32(&redactionLevel))
+}
+
+// redactJSON masks the values in JSON represent of a config according to the current redaction level.
+// if any error occurred, the original data is returned.
+func redactJSON(data []byte) []byte {
+	return RedactJSON(data, GetRedactionLevel())
+}
+
+// RedactJSON masks the values in JSON represent of a config according to the specified redaction level.
+// if any error occurred, the original data is returned.
+func RedactJSON(data []byte, level RedactionLevel) []byte {
+	if level == RedactionNone {
+		return data
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+	v = redactValue(v, level)
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redactValue(v interface{}, level RedactionLevel) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, sub := range vv {
+			if needRedact(k, level) {
+				vv[k] = maskValue(sub)
+			} else {
+				vv[k] = redactValue(sub, level)
+			}
+		}
+	case []interface{}:
+		for i, sub := range vv {
+			vv[i] = redactValue(sub, level)
+		}
+	}
+	return v
+}
+
+func needRedact(key string, level RedactionLevel) bool {
+	if _, ok := connectionKeys[key]; ok {
+		return true
+	}
+	if level >= RedactionAll {
+		_, ok := schemaTableKeys[key]
+		return ok
+	}
+	return false
+}
+
+// maskValue masks a string value or each string in an array, and keeps empty ones so we know if they are set.
+func maskValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case string:
+		if len(vv) > 0 {
+			return redactedValue
+		}
+	case []interface{}:
+		for i, sub := range vv {
+			vv[i] = maskValue(sub)
+		}
+	}
+	return v
+}
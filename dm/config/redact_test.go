@@ -0,0 +1,81 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+
+	. "github.com/pingcap/check"
+	router "github.com/pingcap/tidb-tools/pkg/table-router"
+)
+
+func (t *testConfig) TestRedaction(c *C) {
+	defer SetRedactionLevel(RedactionNone)
+
+	cfg := &SubTaskConfig{
+		Name: "test-task",
+		From: DBConfig{
+			Host:     "upstream-host",
+			Port:     3306,
+			User:     "upstream-user",
+			Password: "123456",
+		},
+		To: DBConfig{
+			Host: "downstream-host",
+			Port: 4000,
+			User: "downstream-user",
+		},
+		RouteRules: []*router.TableRule{
+			{SchemaPattern: "secret_db", TablePattern: "secret_tbl", TargetSchema: "target_db"},
+		},
+	}
+
+	// nothing masked by default
+	c.Assert(GetRedactionLevel(), Equals, RedactionNone)
+	str := cfg.String()
+	c.Assert(str, Matches, ".*upstream-host.*")
+	c.Assert(str, Matches, ".*downstream-user.*")
+	c.Assert(str, Matches, ".*secret_db.*")
+
+	// connection details masked
+	SetRedactionLevel(RedactionConnection)
+	for _, str = range []string{cfg.String(), cfg.From.String()} {
+		c.Assert(strings.Contains(str, "upstream-host"), IsFalse)
+		c.Assert(strings.Contains(str, "upstream-user"), IsFalse)
+		c.Assert(strings.Contains(str, "123456"), IsFalse)
+		c.Assert(str, Matches, ".*3306.*")
+	}
+	str = cfg.String()
+	c.Assert(strings.Contains(str, "downstream-host"), IsFalse)
+	c.Assert(str, Matches, ".*secret_db.*")
+	c.Assert(str, Matches, ".*test-task.*")
+
+	// schema and table names masked
+	SetRedactionLevel(RedactionAll)
+	str = cfg.String()
+	c.Assert(strings.Contains(str, "upstream-host"), IsFalse)
+	c.Assert(strings.Contains(str, "secret_db"), IsFalse)
+	c.Assert(strings.Contains(str, "secret_tbl"), IsFalse)
+	c.Assert(strings.Contains(str, "target_db"), IsFalse)
+	c.Assert(str, Matches, ".*test-task.*")
+
+	// the config itself is not changed
+	c.Assert(cfg.From.Host, Equals, "upstream-host")
+	c.Assert(cfg.RouteRules[0].SchemaPattern, Equals, "secret_db")
+
+	srcCfg := &SourceConfig{SourceID: "mysql-replica-01", From: cfg.From}
+	str = srcCfg.String()
+	c.Assert(strings.Contains(str, "upstream-host"), IsFalse)
+	c.Assert(str, Matches, ".*mysql-replica-01.*")
+}
@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 	"math"
 	"math/rand"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/siddontang/go-mysql/mysql"
@@ -26,6 +28,11 @@ const (
 	// the default base(min) server id generated by random
 	defaultBaseServerID = math.MaxUint32 / 10
 	defaultRelayDir     = "relay-dir"
+
+	defaultRelayReconnectRetries = 3
+	defaultRelayReconnectBackoff = 5 * time.Second
+
+	defaultStatusPrintInterval = 5 * time.Second
 )
 
 var getAllServerIDFunc = utils.GetAllServerID
@@ -52,11 +59,27 @@ type SourceConfig struct {
 	Charset     string `yaml:"charset" toml:"charset" json:"charset"`
 
 	EnableRelay bool `yaml:"enable-relay" toml:"enable-relay" json:"enable-relay"`
+	// the HTTPS address set by `relay-files-addr` of the DM-worker which pulls relay log of the same source, like
+	// `https://127.0.0.1:8264`.
+	// if specified, relay log is fetched from that DM-worker rather than pulled from upstream.
+	RelaySource string `yaml:"relay-source" toml:"relay-source" json:"relay-source"`
+	// only relay row events of tables needed by subtasks (the union of their block-allow lists) to save the disk,
+	// DDLs and other events are always relayed.
+	PartialRelay bool `yaml:"partial-relay" toml:"partial-relay" json:"partial-relay"`
 	// relay synchronous starting point (if specified)
 	RelayBinLogName string `yaml:"relay-binlog-name" toml:"relay-binlog-name" json:"relay-binlog-name"`
 	RelayBinlogGTID string `yaml:"relay-binlog-gtid" toml:"relay-binlog-gtid" json:"relay-binlog-gtid"`
+	// the max number of retries to reconnect upstream and restart relay from the relayed position when it's
+	// disconnected, the backoff between retries is doubled each time. 0 means the relay stops with an error at once.
+	RelayReconnectRetries int      `yaml:"relay-reconnect-retries" toml:"relay-reconnect-retries" json:"relay-reconnect-retries"`
+	RelayReconnectBackoff Duration `yaml:"relay-reconnect-backoff" toml:"relay-reconnect-backoff" json:"relay-reconnect-backoff"`
 	// only use when worker bound source, do not marsh it
 	UUIDSuffix int `yaml:"-" toml:"-" json:"-"`
+	// the security config of DM-worker to fetch relay log from `relay-source`, only use when worker bound source, do not marsh it
+	RelaySourceSecurity Security `yaml:"-" toml:"-" json:"-"`
+
+	// the interval of printing the runtime status of subtasks to the debug log, 0 or negative means not printing.
+	StatusPrintInterval Duration `yaml:"status-print-interval" toml:"status-print-interval" json:"status-print-interval"`
 
 	SourceID string   `yaml:"source-id" toml:"source-id" json:"source-id"`
 	From     DBConfig `yaml:"from" toml:"from" json:"from"`
@@ -67,6 +90,10 @@ type SourceConfig struct {
 	// config items for task status checker
 	Checker CheckerConfig `yaml:"checker" toml:"checker" json:"checker"`
 
+	// default retry policy for write conflicts in downstream of subtasks, can be overwritten by task config
+	ConflictRetryCount    int `yaml:"conflict-retry-count" toml:"conflict-retry-count" json:"conflict-retry-count"`
+	ConflictRetryInterval int `yaml:"conflict-retry-interval" toml:"conflict-retry-interval" json:"conflict-retry-interval"` // in seconds
+
 	// id of the worker on which this task run
 	ServerID uint32 `yaml:"server-id" toml:"server-id" json:"server-id"`
 
@@ -75,11 +102,24 @@ type SourceConfig struct {
 
 	CaseSensitive bool                  `yaml:"case-sensitive" toml:"case-sensitive" json:"case-sensitive"`
 	Filters       []*bf.BinlogEventRule `yaml:"filters" toml:"filters" json:"filters"`
+
+	// coarse toggles to ignore all events of the type from the source, they're applied ahead of `filters`
+	IgnoreDDL    bool `yaml:"ignore-ddl" toml:"ignore-ddl" json:"ignore-ddl"`
+	IgnoreDelete bool `yaml:"ignore-delete" toml:"ignore-delete" json:"ignore-delete"`
+	IgnoreInsert bool `yaml:"ignore-insert" toml:"ignore-insert" json:"ignore-insert"`
+	IgnoreUpdate bool `yaml:"ignore-update" toml:"ignore-update" json:"ignore-update"`
+
+	// column transforms applied to all subtasks of the source, a transform of the same column in task config has
+	// higher priority
+	ColumnTransforms []*ColumnTransform `yaml:"column-transforms" toml:"column-transforms" json:"column-transforms"`
 }
 
 // NewSourceConfig creates a new base config for upstream MySQL/MariaDB source.
 func NewSourceConfig() *SourceConfig {
 	c := &SourceConfig{
+		RelayReconnectRetries: defaultRelayReconnectRetries,
+		RelayReconnectBackoff: Duration{defaultRelayReconnectBackoff},
+		StatusPrintInterval:   Duration{defaultStatusPrintInterval},
 		Purge: PurgeConfig{
 			Interval:    60 * 60,
 			Expires:     0,
@@ -89,12 +129,40 @@ func NewSourceConfig() *SourceConfig {
 			CheckEnable:     true,
 			BackoffRollback: Duration{DefaultBackoffRollback},
 			BackoffMax:      Duration{DefaultBackoffMax},
+
+			MaxNoProgressResumes: DefaultMaxNoProgressResumes,
 		},
 	}
 	c.adjust()
 	return c
 }
 
+// IgnoredEventsRule returns the binlog event filter rule which ignores events of all tables for the types toggled
+// by `ignore-ddl`, `ignore-delete`, `ignore-insert` and `ignore-update`, or nil if no types are toggled.
+func (c *SourceConfig) IgnoredEventsRule() *bf.BinlogEventRule {
+	var events []bf.EventType
+	if c.IgnoreDDL {
+		events = append(events, bf.AllDDL)
+	}
+	if c.IgnoreDelete {
+		events = append(events, bf.DeleteEvent)
+	}
+	if c.IgnoreInsert {
+		events = append(events, bf.InsertEvent)
+	}
+	if c.IgnoreUpdate {
+		events = append(events, bf.UpdateEvent)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return &bf.BinlogEventRule{
+		SchemaPattern: "*",
+		Events:        events,
+		Action:        bf.Ignore,
+	}
+}
+
 // Clone clones a config
 func (c *SourceConfig) Clone() *SourceConfig {
 	clone := &SourceConfig{}
@@ -159,7 +227,7 @@ func (c *SourceConfig) String() string {
 	if err != nil {
 		log.L().Error("fail to marshal config to json", log.ShortError(err))
 	}
-	return string(cfg)
+	return string(redactJSON(cfg))
 }
 
 func (c *SourceConfig) adjust() {
@@ -190,6 +258,19 @@ func (c *SourceConfig) Verify() error {
 			}
 		}
 	}
+	if len(c.RelaySource) > 0 {
+		if !c.EnableRelay {
+			return terror.ErrConfigInvalidRelaySource.Generate(c.RelaySource, "enable-relay should be true")
+		}
+		u, err2 := url.Parse(c.RelaySource)
+		if err2 != nil {
+			return terror.ErrConfigInvalidRelaySource.Delegate(err2, c.RelaySource, "can't parse it")
+		}
+		// relay log files are only served with TLS client certificate authentication.
+		if u.Scheme != "https" || len(u.Host) == 0 {
+			return terror.ErrConfigInvalidRelaySource.Generate(c.RelaySource, "it should be an HTTPS address")
+		}
+	}
 
 	c.DecryptPassword()
 
@@ -77,6 +77,7 @@ func (t *testConfig) TestConfig(c *C) {
 	clone1.From.Session = map[string]string{}
 	clone1.Tracer = map[string]interface{}{}
 	clone1.Filters = []*bf.BinlogEventRule{}
+	clone1.ColumnTransforms = []*ColumnTransform{}
 	clone2 := cfg.DecryptPassword()
 	c.Assert(clone2, DeepEquals, clone1)
 
@@ -180,6 +181,41 @@ func (t *testConfig) TestConfigVerify(c *C) {
 			},
 			".*relay-binlog-gtid 9afe121c-40c2-11e9-9ec7-0242ac110002:1-rtc:.*",
 		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.RelaySource = "https://127.0.0.1:8264"
+				return cfg
+			},
+			".*invalid relay-source https://127.0.0.1:8264, enable-relay should be true.*",
+		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.EnableRelay = true
+				cfg.RelaySource = "127.0.0.1:8262"
+				return cfg
+			},
+			".*invalid relay-source 127.0.0.1:8262.*",
+		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.EnableRelay = true
+				cfg.RelaySource = "http://127.0.0.1:8264"
+				return cfg
+			},
+			".*invalid relay-source http://127.0.0.1:8264, it should be an HTTPS address.*",
+		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.EnableRelay = true
+				cfg.RelaySource = "https://127.0.0.1:8264"
+				return cfg
+			},
+			"",
+		},
 		{
 			func() *SourceConfig {
 				cfg := newConfig()
@@ -246,6 +282,30 @@ func subtestFlavor(c *C, cfg *SourceConfig, sqlInfo, expectedFlavor, expectedErr
 	}
 }
 
+func (t *testConfig) TestIgnoredEventsRule(c *C) {
+	cfg := NewSourceConfig()
+	c.Assert(cfg.IgnoredEventsRule(), IsNil)
+
+	cfg.IgnoreDDL = true
+	cfg.IgnoreUpdate = true
+	c.Assert(cfg.IgnoredEventsRule(), DeepEquals, &bf.BinlogEventRule{
+		SchemaPattern: "*",
+		Events:        []bf.EventType{bf.AllDDL, bf.UpdateEvent},
+		Action:        bf.Ignore,
+	})
+}
+
+func (t *testConfig) TestStatusPrintInterval(c *C) {
+	cfg := NewSourceConfig()
+	c.Assert(cfg.StatusPrintInterval.Duration, Equals, defaultStatusPrintInterval)
+
+	c.Assert(cfg.ParseYaml("status-print-interval: 1m"), IsNil)
+	c.Assert(cfg.StatusPrintInterval.Duration, Equals, time.Minute)
+	// disable printing.
+	c.Assert(cfg.ParseYaml("status-print-interval: 0s"), IsNil)
+	c.Assert(cfg.StatusPrintInterval.Duration, Equals, time.Duration(0))
+}
+
 func (t *testConfig) TestAdjustFlavor(c *C) {
 	cfg := NewSourceConfig()
 	c.Assert(cfg.LoadFromFile(sourceSampleFile), IsNil)
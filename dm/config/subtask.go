@@ -99,7 +99,7 @@ func (db *DBConfig) String() string {
 	if err != nil {
 		log.L().Error("fail to marshal config to json", log.ShortError(err))
 	}
-	return string(cfg)
+	return string(redactJSON(cfg))
 }
 
 // Toml returns TOML format representation of config
@@ -166,6 +166,7 @@ type SubTaskConfig struct {
 	RouteRules         []*router.TableRule   `toml:"route-rules" json:"route-rules"`
 	FilterRules        []*bf.BinlogEventRule `toml:"filter-rules" json:"filter-rules"`
 	ColumnMappingRules []*column.Rule        `toml:"mapping-rule" json:"mapping-rule"`
+	ColumnTransforms   []*ColumnTransform    `toml:"column-transforms" json:"column-transforms"`
 
 	// black-white-list is deprecated, use block-allow-list instead
 	BWList *filter.Rules `toml:"black-white-list" json:"black-white-list"`
@@ -188,6 +189,11 @@ type SubTaskConfig struct {
 
 	CleanDumpFile bool `toml:"clean-dump-file" json:"clean-dump-file"`
 
+	// names of other subtasks in the same source, this subtask will not enter sync unit until they finished load unit
+	Dependencies []string `toml:"dependencies" json:"dependencies"`
+	// how this subtask shares the resources of the DM-worker with others
+	PriorityClass string `toml:"priority-class" json:"priority-class"`
+
 	// deprecated, will auto discover SQL mode
 	EnableANSIQuotes bool `toml:"ansi-quotes" json:"ansi-quotes"`
 
@@ -211,13 +217,13 @@ func (c *SubTaskConfig) SetFlagSet(flagSet *flag.FlagSet) {
 	c.flagSet = flagSet
 }
 
-// String returns the config's json string
+// String returns the config's json string, some values may be masked according to the redaction level.
 func (c *SubTaskConfig) String() string {
 	cfg, err := json.Marshal(c)
 	if err != nil {
 		log.L().Error("marshal subtask config to json", zap.String("task", c.Name), log.ShortError(err))
 	}
-	return string(cfg)
+	return string(redactJSON(cfg))
 }
 
 // Toml returns TOML format representation of config
@@ -301,6 +307,51 @@ func (c *SubTaskConfig) Adjust(verifyDecryptPassword bool) error {
 	if c.SyncerConfig.CheckpointFlushInterval == 0 {
 		c.SyncerConfig.CheckpointFlushInterval = defaultCheckpointFlushInterval
 	}
+	switch c.SyncerConfig.UnsupportedDDLPolicy {
+	case "":
+		c.SyncerConfig.UnsupportedDDLPolicy = UnsupportedDDLPause
+	case UnsupportedDDLPause, UnsupportedDDLSkip, UnsupportedDDLApplyVerbatim:
+	default:
+		return terror.ErrConfigUnsupportedDDLPolicyNotSupport.Generate(c.SyncerConfig.UnsupportedDDLPolicy)
+	}
+	switch c.SyncerConfig.DuplicateKeyPolicy {
+	case "":
+		c.SyncerConfig.DuplicateKeyPolicy = DuplicateKeyPause
+	case DuplicateKeyPause, DuplicateKeySkip, DuplicateKeyOverwrite:
+	default:
+		return terror.ErrConfigDuplicateKeyPolicyNotSupport.Generate(c.SyncerConfig.DuplicateKeyPolicy)
+	}
+	switch c.SyncerConfig.WriteMode {
+	case "":
+		c.SyncerConfig.WriteMode = WriteModeTransactional
+	case WriteModeTransactional, WriteModeBulk:
+	default:
+		return terror.ErrConfigWriteModeNotSupport.Generate(c.SyncerConfig.WriteMode)
+	}
+	switch c.PriorityClass {
+	case "":
+		c.PriorityClass = PriorityNormal
+	case PriorityHigh, PriorityNormal, PriorityLow:
+	default:
+		return terror.ErrConfigPriorityClassNotSupport.Generate(c.PriorityClass)
+	}
+	if c.SyncerConfig.DDLBatchSize == 0 {
+		c.SyncerConfig.DDLBatchSize = defaultDDLBatchSize
+	}
+	if c.SyncerConfig.DDLBatchSize < MinDDLBatchSize || c.SyncerConfig.DDLBatchSize > MaxDDLBatchSize {
+		return terror.ErrConfigInvalidDDLBatchSize.Generate(c.SyncerConfig.DDLBatchSize, MinDDLBatchSize, MaxDDLBatchSize)
+	}
+	for _, tb := range c.SyncerConfig.FreezeOnDDL {
+		if tb == nil || tb.Schema == "" || tb.Name == "" {
+			return terror.ErrConfigInvalidFreezeOnDDL.Generate(tb)
+		}
+	}
+
+	for _, transform := range c.ColumnTransforms {
+		if err := transform.Valid(); err != nil {
+			return err
+		}
+	}
 
 	c.From.Adjust()
 	c.To.Adjust()
@@ -141,6 +141,54 @@ func (t *testConfig) TestSubTaskAdjustFail(c *C) {
 			},
 			"\\[.*\\], Message: invalid timezone string: my-house.*",
 		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SyncerConfig.UnsupportedDDLPolicy = "ignore"
+				return cfg
+			},
+			"\\[.*\\], Message: unsupported DDL policy ignore not supported.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SyncerConfig.DuplicateKeyPolicy = "ignore"
+				return cfg
+			},
+			"\\[.*\\], Message: duplicate key policy ignore not supported.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SyncerConfig.WriteMode = "async"
+				return cfg
+			},
+			"\\[.*\\], Message: write mode async not supported.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.PriorityClass = "urgent"
+				return cfg
+			},
+			"\\[.*\\], Message: priority class urgent not supported.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SyncerConfig.FreezeOnDDL = []*filter.Table{{Schema: "db"}}
+				return cfg
+			},
+			"\\[.*\\], Message: invalid freeze-on-ddl pattern `db`, .*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SyncerConfig.DDLBatchSize = 1000
+				return cfg
+			},
+			"\\[.*\\], Message: ddl-batch-size 1000 is out of range \\[1, 100\\].*",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -50,6 +50,51 @@ const (
 	tidbTxnOptimistic = "optimistic"
 )
 
+// policies of handling DDLs which can't be parsed and translated to the downstream.
+const (
+	// UnsupportedDDLPause pauses the subtask, this is the default policy.
+	UnsupportedDDLPause = "pause"
+	// UnsupportedDDLSkip skips the DDL and records it in the status.
+	UnsupportedDDLSkip = "skip"
+	// UnsupportedDDLApplyVerbatim executes the original DDL in the downstream without routing the table names.
+	UnsupportedDDLApplyVerbatim = "apply-verbatim"
+)
+
+// policies of handling duplicate-key errors of DMLs written into the downstream when safe mode is off.
+const (
+	// DuplicateKeyPause pauses the subtask, this is the default policy.
+	DuplicateKeyPause = "pause"
+	// DuplicateKeySkip skips the DML and records the duplicate key in the status.
+	DuplicateKeySkip = "skip"
+	// DuplicateKeyOverwrite replaces the existing row with the inserted one and records the duplicate key in the status.
+	DuplicateKeyOverwrite = "overwrite"
+)
+
+// modes of writing DMLs into the downstream.
+const (
+	// WriteModeTransactional executes each batch of DMLs in a transaction, this is the default mode.
+	WriteModeTransactional = "transactional"
+	// WriteModeBulk executes DMLs one by one without transactions for higher throughput, a failed batch may be
+	// partially written, and the upstream transactions are not atomic in the downstream.
+	WriteModeBulk = "bulk"
+)
+
+// priority classes of subtasks sharing the resources of a DM-worker.
+const (
+	// PriorityHigh subtasks get all resources in config under contention.
+	PriorityHigh = "high"
+	// PriorityNormal is the default priority class.
+	PriorityNormal = "normal"
+	// PriorityLow subtasks give way to higher priority ones under contention.
+	PriorityLow = "low"
+)
+
+// range of the number of DDL events applied to the downstream as one batch.
+const (
+	MinDDLBatchSize = 1
+	MaxDDLBatchSize = 100
+)
+
 // default config item values
 var (
 	// TaskConfig
@@ -71,6 +116,7 @@ var (
 	defaultBatch                   = 100
 	defaultQueueSize               = 1024 // do not give too large default value to avoid OOM
 	defaultCheckpointFlushInterval = 30   // in seconds
+	defaultDDLBatchSize            = 1
 
 	// TargetDBConfig
 	defaultSessionCfg = []struct {
@@ -110,6 +156,8 @@ type MySQLInstance struct {
 	ColumnMappingRules []string `yaml:"column-mapping-rules"`
 	RouteRules         []string `yaml:"route-rules"`
 
+	ColumnTransformRules []string `yaml:"column-transform-rules"`
+
 	// black-white-list is deprecated, use block-allow-list instead
 	BWListName string `yaml:"black-white-list"`
 	BAListName string `yaml:"block-allow-list"`
@@ -234,6 +282,46 @@ type SyncerConfig struct {
 	// checkpoint flush interval in seconds.
 	CheckpointFlushInterval int `yaml:"checkpoint-flush-interval" toml:"checkpoint-flush-interval" json:"checkpoint-flush-interval"`
 
+	// retry policy for write conflicts in downstream, 0 means using the value in source config or the default value.
+	ConflictRetryCount    int `yaml:"conflict-retry-count" toml:"conflict-retry-count" json:"conflict-retry-count"`
+	ConflictRetryInterval int `yaml:"conflict-retry-interval" toml:"conflict-retry-interval" json:"conflict-retry-interval"` // in seconds
+
+	// how to handle DDLs which can't be parsed and translated to the downstream, `pause` by default.
+	UnsupportedDDLPolicy string `yaml:"unsupported-ddl-policy" toml:"unsupported-ddl-policy" json:"unsupported-ddl-policy"`
+
+	// how to handle duplicate-key errors of DMLs when safe mode is off, `pause` by default.
+	// `overwrite` only works for INSERTs, the subtask is paused for other DMLs.
+	DuplicateKeyPolicy string `yaml:"duplicate-key-policy" toml:"duplicate-key-policy" json:"duplicate-key-policy"`
+
+	// how to write DMLs into the downstream, `transactional` by default.
+	// `bulk` gives higher throughput, but the upstream transactions are not atomic in the downstream.
+	WriteMode string `yaml:"write-mode" toml:"write-mode" json:"write-mode"`
+
+	// whether to remove the corrupted rows of the checkpoint table when starting, instead of failing to start.
+	// the corrupted checkpoints fall back to the meta (if the global checkpoint is removed) or the global checkpoint.
+	ResetCorruptedCheckpoint bool `yaml:"reset-corrupted-checkpoint" toml:"reset-corrupted-checkpoint" json:"reset-corrupted-checkpoint"`
+
+	// whether to correct the replication lag with the clock skew between upstream and the worker, it's measured
+	// when the sync unit starts and each time the time skew is queried.
+	CorrectClockSkew bool `yaml:"correct-clock-skew" toml:"correct-clock-skew" json:"correct-clock-skew"`
+
+	// whether to pause the subtask with an error when the binlog events after the checkpoint have been purged in
+	// upstream in GTID mode, instead of skipping them by `auto-fix-gtid` of source config, which is ignored if it's set.
+	StrictGTID bool `yaml:"strict-gtid" toml:"strict-gtid" json:"strict-gtid"`
+
+	// whether to switch to GTID-based replication once caught up with upstream when replicating by binlog positions,
+	// the GTID set of the checkpoint is got from upstream and the subtask keeps in GTID mode after restarting.
+	// it's ignored if `enable-gtid` of source config is set.
+	SwitchToGTID bool `yaml:"switch-to-gtid" toml:"switch-to-gtid" json:"switch-to-gtid"`
+
+	// the DDLs on the upstream tables matching any of the patterns pause the subtask to be reviewed before they're
+	// replicated, then they're applied by resuming the subtask, or skipped or replaced by `handle-error`.
+	FreezeOnDDL []*filter.Table `yaml:"freeze-on-ddl" toml:"freeze-on-ddl" json:"freeze-on-ddl"`
+
+	// the max number of consecutive DDL events applied to the downstream as one batch, 1 (no batching) by default.
+	// only the DDLs which are safe to replay are batched, see `isBatchableDDL` in syncer for details.
+	DDLBatchSize int `yaml:"ddl-batch-size" toml:"ddl-batch-size" json:"ddl-batch-size"`
+
 	// deprecated
 	MaxRetry int `yaml:"max-retry" toml:"max-retry" json:"max-retry"`
 
@@ -252,6 +340,10 @@ func defaultSyncerConfig() SyncerConfig {
 		Batch:                   defaultBatch,
 		QueueSize:               defaultQueueSize,
 		CheckpointFlushInterval: defaultCheckpointFlushInterval,
+		UnsupportedDDLPolicy:    UnsupportedDDLPause,
+		DuplicateKeyPolicy:      DuplicateKeyPause,
+		WriteMode:               WriteModeTransactional,
+		DDLBatchSize:            defaultDDLBatchSize,
 	}
 }
 
@@ -301,6 +393,8 @@ type TaskConfig struct {
 	Filters        map[string]*bf.BinlogEventRule `yaml:"filters" toml:"filters" json:"filters"`
 	ColumnMappings map[string]*column.Rule        `yaml:"column-mappings" toml:"column-mappings" json:"column-mappings"`
 
+	ColumnTransforms map[string]*ColumnTransform `yaml:"column-transforms" toml:"column-transforms" json:"column-transforms"`
+
 	// black-white-list is deprecated, use block-allow-list instead
 	BWList map[string]*filter.Rules `yaml:"black-white-list" toml:"black-white-list" json:"black-white-list"`
 	BAList map[string]*filter.Rules `yaml:"block-allow-list" toml:"block-allow-list" json:"block-allow-list"`
@@ -310,6 +404,10 @@ type TaskConfig struct {
 	Syncers   map[string]*SyncerConfig   `yaml:"syncers" toml:"syncers" json:"syncers"`
 
 	CleanDumpFile bool `yaml:"clean-dump-file" toml:"clean-dump-file" json:"clean-dump-file"`
+	// names of other tasks, this task will not enter sync unit until they finished load unit
+	Dependencies []string `yaml:"dependencies" toml:"dependencies" json:"dependencies"`
+	// how the subtasks of this task share the resources of DM-workers with others, `normal` by default
+	PriorityClass string `yaml:"priority-class" toml:"priority-class" json:"priority-class"`
 	// deprecated
 	EnableANSIQuotes bool `yaml:"ansi-quotes" toml:"ansi-quotes" json:"ansi-quotes"`
 
@@ -330,6 +428,7 @@ func NewTaskConfig() *TaskConfig {
 		Routes:                  make(map[string]*router.TableRule),
 		Filters:                 make(map[string]*bf.BinlogEventRule),
 		ColumnMappings:          make(map[string]*column.Rule),
+		ColumnTransforms:        make(map[string]*ColumnTransform),
 		BWList:                  make(map[string]*filter.Rules),
 		BAList:                  make(map[string]*filter.Rules),
 		Mydumpers:               make(map[string]*MydumperConfig),
@@ -420,7 +519,7 @@ func (c *TaskConfig) adjust() error {
 
 	iids := make(map[string]int) // source-id -> instance-index
 	globalConfigReferCount := map[string]int{}
-	prefixs := []string{"RouteRules", "FilterRules", "ColumnMappingRules", "Mydumper", "Loader", "Syncer"}
+	prefixs := []string{"RouteRules", "FilterRules", "ColumnMappingRules", "Mydumper", "Loader", "Syncer", "ColumnTransformRules"}
 	duplicateErrorStrings := make([]string, 0)
 	for i, inst := range c.MySQLInstances {
 		if err := inst.VerifyAndAdjust(); err != nil {
@@ -464,6 +563,12 @@ func (c *TaskConfig) adjust() error {
 			}
 			globalConfigReferCount[prefixs[2]+name]++
 		}
+		for _, name := range inst.ColumnTransformRules {
+			if _, ok := c.ColumnTransforms[name]; !ok {
+				return terror.ErrConfigColumnTransformNotFound.Generate(i, name)
+			}
+			globalConfigReferCount[prefixs[6]+name]++
+		}
 
 		// only when BAList is empty use BWList
 		if len(c.BAList) == 0 && len(c.BWList) != 0 {
@@ -573,6 +678,11 @@ func (c *TaskConfig) adjust() error {
 			unusedConfigs = append(unusedConfigs, columnMapping)
 		}
 	}
+	for columnTransform := range c.ColumnTransforms {
+		if globalConfigReferCount[prefixs[6]+columnTransform] == 0 {
+			unusedConfigs = append(unusedConfigs, columnTransform)
+		}
+	}
 	for mydumper := range c.Mydumpers {
 		if globalConfigReferCount[prefixs[3]+mydumper] == 0 {
 			unusedConfigs = append(unusedConfigs, mydumper)
@@ -660,6 +770,10 @@ func (c *TaskConfig) SubTaskConfigs(sources map[string]DBConfig) ([]*SubTaskConf
 			cfg.ColumnMappingRules[j] = c.ColumnMappings[name]
 		}
 
+		for _, name := range inst.ColumnTransformRules {
+			cfg.ColumnTransforms = append(cfg.ColumnTransforms, c.ColumnTransforms[name])
+		}
+
 		cfg.BAList = c.BAList[inst.BAListName]
 
 		cfg.MydumperConfig = *inst.Mydumper
@@ -667,6 +781,8 @@ func (c *TaskConfig) SubTaskConfigs(sources map[string]DBConfig) ([]*SubTaskConf
 		cfg.SyncerConfig = *inst.Syncer
 
 		cfg.CleanDumpFile = c.CleanDumpFile
+		cfg.Dependencies = c.Dependencies
+		cfg.PriorityClass = c.PriorityClass
 
 		err = cfg.Adjust(true)
 		if err != nil {
@@ -715,11 +831,14 @@ func FromSubTaskConfigs(stCfgs ...*SubTaskConfig) *TaskConfig {
 	c.TargetDB = &stCfg0.To // just ref
 	c.OnlineDDLScheme = stCfg0.OnlineDDLScheme
 	c.CleanDumpFile = stCfg0.CleanDumpFile
+	c.Dependencies = stCfg0.Dependencies
+	c.PriorityClass = stCfg0.PriorityClass
 	c.MySQLInstances = make([]*MySQLInstance, 0, len(stCfgs))
 	c.BAList = make(map[string]*filter.Rules)
 	c.Routes = make(map[string]*router.TableRule)
 	c.Filters = make(map[string]*bf.BinlogEventRule)
 	c.ColumnMappings = make(map[string]*column.Rule)
+	c.ColumnTransforms = make(map[string]*ColumnTransform)
 	c.Mydumpers = make(map[string]*MydumperConfig)
 	c.Loaders = make(map[string]*LoaderConfig)
 	c.Syncers = make(map[string]*SyncerConfig)
@@ -731,8 +850,9 @@ func FromSubTaskConfigs(stCfgs ...*SubTaskConfig) *TaskConfig {
 	loadMap := make(map[string]string, len(stCfgs))
 	syncMap := make(map[string]string, len(stCfgs))
 	cmMap := make(map[string]string, len(stCfgs))
-	var baListIdx, routeIdx, filterIdx, dumpIdx, loadIdx, syncIdx, cmIdx int
-	var baListName, routeName, filterName, dumpName, loadName, syncName, cmName string
+	ctMap := make(map[string]string, len(stCfgs))
+	var baListIdx, routeIdx, filterIdx, dumpIdx, loadIdx, syncIdx, cmIdx, ctIdx int
+	var baListName, routeName, filterName, dumpName, loadName, syncName, cmName, ctName string
 
 	// NOTE:
 	// - we choose to ref global configs for instances now.
@@ -770,16 +890,24 @@ func FromSubTaskConfigs(stCfgs ...*SubTaskConfig) *TaskConfig {
 			c.ColumnMappings[cmName] = rule
 		}
 
+		var ctNames []string
+		for _, transform := range stCfg.ColumnTransforms {
+			ctName, ctIdx = getGenerateName(transform, ctIdx, "ct", ctMap)
+			ctNames = append(ctNames, ctName)
+			c.ColumnTransforms[ctName] = transform
+		}
+
 		c.MySQLInstances = append(c.MySQLInstances, &MySQLInstance{
-			SourceID:           stCfg.SourceID,
-			Meta:               stCfg.Meta,
-			FilterRules:        filterNames,
-			ColumnMappingRules: cmNames,
-			RouteRules:         routeNames,
-			BAListName:         baListName,
-			MydumperConfigName: dumpName,
-			LoaderConfigName:   loadName,
-			SyncerConfigName:   syncName,
+			SourceID:             stCfg.SourceID,
+			Meta:                 stCfg.Meta,
+			FilterRules:          filterNames,
+			ColumnMappingRules:   cmNames,
+			RouteRules:           routeNames,
+			ColumnTransformRules: ctNames,
+			BAListName:           baListName,
+			MydumperConfigName:   dumpName,
+			LoaderConfigName:     loadName,
+			SyncerConfigName:     syncName,
 		})
 	}
 	return c
@@ -595,6 +595,12 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 			SQLPattern:    []string{"^DROP\\s+PROCEDURE", "^CREATE\\s+PROCEDURE"},
 			Action:        bf.Ignore,
 		}
+		columnTransform1 = ColumnTransform{
+			SchemaPattern: "db*",
+			TablePattern:  "tbl*",
+			Column:        "email",
+			Func:          TransformHash,
+		}
 		baList1 = filter.Rules{
 			DoDBs: []string{"db1", "db2"},
 			DoTables: []*filter.Table{
@@ -660,9 +666,10 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 				Security:         &security,
 				RawDBCfg:         &rawDBCfg,
 			},
-			RouteRules:  []*router.TableRule{&routeRule2, &routeRule1, &routeRule3},
-			FilterRules: []*bf.BinlogEventRule{&filterRule1, &filterRule2},
-			BAList:      &baList1,
+			RouteRules:       []*router.TableRule{&routeRule2, &routeRule1, &routeRule3},
+			FilterRules:      []*bf.BinlogEventRule{&filterRule1, &filterRule2},
+			ColumnTransforms: []*ColumnTransform{&columnTransform1},
+			BAList:           &baList1,
 			MydumperConfig: MydumperConfig{
 				MydumperPath:  "",
 				Threads:       16,
@@ -682,6 +689,10 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 				Batch:                   100,
 				QueueSize:               512,
 				CheckpointFlushInterval: 15,
+				UnsupportedDDLPolicy:    UnsupportedDDLPause,
+				DuplicateKeyPolicy:      DuplicateKeyPause,
+				WriteMode:               WriteModeTransactional,
+				DDLBatchSize:            defaultDDLBatchSize,
 				MaxRetry:                10,
 				AutoFixGTID:             true,
 				EnableGTID:              true,
@@ -690,6 +701,7 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 			},
 			CleanDumpFile:    true,
 			EnableANSIQuotes: true,
+			PriorityClass:    PriorityNormal,
 		}
 	)
 
@@ -722,40 +734,42 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 		TargetDB:                &stCfg1.To,
 		MySQLInstances: []*MySQLInstance{
 			{
-				SourceID:           source1,
-				Meta:               stCfg1.Meta,
-				FilterRules:        []string{"filter-01", "filter-02"},
-				ColumnMappingRules: []string{},
-				RouteRules:         []string{"route-01", "route-02", "route-03"},
-				BWListName:         "",
-				BAListName:         "balist-01",
-				MydumperConfigName: "dump-01",
-				Mydumper:           nil,
-				MydumperThread:     0,
-				LoaderConfigName:   "load-01",
-				Loader:             nil,
-				LoaderThread:       0,
-				SyncerConfigName:   "sync-01",
-				Syncer:             nil,
-				SyncerThread:       0,
+				SourceID:             source1,
+				Meta:                 stCfg1.Meta,
+				FilterRules:          []string{"filter-01", "filter-02"},
+				ColumnMappingRules:   []string{},
+				RouteRules:           []string{"route-01", "route-02", "route-03"},
+				ColumnTransformRules: []string{"ct-01"},
+				BWListName:           "",
+				BAListName:           "balist-01",
+				MydumperConfigName:   "dump-01",
+				Mydumper:             nil,
+				MydumperThread:       0,
+				LoaderConfigName:     "load-01",
+				Loader:               nil,
+				LoaderThread:         0,
+				SyncerConfigName:     "sync-01",
+				Syncer:               nil,
+				SyncerThread:         0,
 			},
 			{
-				SourceID:           source2,
-				Meta:               stCfg2.Meta,
-				FilterRules:        []string{"filter-01", "filter-02"},
-				ColumnMappingRules: []string{},
-				RouteRules:         []string{"route-01", "route-02", "route-04"},
-				BWListName:         "",
-				BAListName:         "balist-02",
-				MydumperConfigName: "dump-01",
-				Mydumper:           nil,
-				MydumperThread:     0,
-				LoaderConfigName:   "load-01",
-				Loader:             nil,
-				LoaderThread:       0,
-				SyncerConfigName:   "sync-01",
-				Syncer:             nil,
-				SyncerThread:       0,
+				SourceID:             source2,
+				Meta:                 stCfg2.Meta,
+				FilterRules:          []string{"filter-01", "filter-02"},
+				ColumnMappingRules:   []string{},
+				RouteRules:           []string{"route-01", "route-02", "route-04"},
+				ColumnTransformRules: []string{"ct-01"},
+				BWListName:           "",
+				BAListName:           "balist-02",
+				MydumperConfigName:   "dump-01",
+				Mydumper:             nil,
+				MydumperThread:       0,
+				LoaderConfigName:     "load-01",
+				Loader:               nil,
+				LoaderThread:         0,
+				SyncerConfigName:     "sync-01",
+				Syncer:               nil,
+				SyncerThread:         0,
 			},
 		},
 		OnlineDDLScheme: onlineDDLScheme,
@@ -770,7 +784,10 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 			"filter-02": &filterRule2,
 		},
 		ColumnMappings: nil,
-		BWList:         nil,
+		ColumnTransforms: map[string]*ColumnTransform{
+			"ct-01": &columnTransform1,
+		},
+		BWList: nil,
 		BAList: map[string]*filter.Rules{
 			"balist-01": &baList1,
 			"balist-02": &baList2,
@@ -785,6 +802,7 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 			"sync-01": &stCfg1.SyncerConfig,
 		},
 		CleanDumpFile: stCfg1.CleanDumpFile,
+		PriorityClass: stCfg1.PriorityClass,
 	}
 
 	c.Assert(WordCount(cfg.String()), DeepEquals, WordCount(cfg2.String())) // since rules are unordered, so use WordCount to compare
@@ -599,12 +599,18 @@ func (m *DumpStatus) XXX_DiscardUnknown() {
 var xxx_messageInfo_DumpStatus proto.InternalMessageInfo
 
 // LoadStatus represents status for load unit
+// finishedTables: tables finished before, skipped in this round of restoring
+// resumedTables: tables partially restored before, resumed from checkpoint
+// freshTables: tables restored from the beginning
 type LoadStatus struct {
 	FinishedBytes  int64  `protobuf:"varint,1,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
 	TotalBytes     int64  `protobuf:"varint,2,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
 	Progress       string `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
 	MetaBinlog     string `protobuf:"bytes,4,opt,name=metaBinlog,proto3" json:"metaBinlog,omitempty"`
 	MetaBinlogGTID string `protobuf:"bytes,5,opt,name=metaBinlogGTID,proto3" json:"metaBinlogGTID,omitempty"`
+	FinishedTables int64  `protobuf:"varint,6,opt,name=finishedTables,proto3" json:"finishedTables,omitempty"`
+	ResumedTables  int64  `protobuf:"varint,7,opt,name=resumedTables,proto3" json:"resumedTables,omitempty"`
+	FreshTables    int64  `protobuf:"varint,8,opt,name=freshTables,proto3" json:"freshTables,omitempty"`
 }
 
 func (m *LoadStatus) Reset()         { *m = LoadStatus{} }
@@ -675,6 +681,27 @@ func (m *LoadStatus) GetMetaBinlogGTID() string {
 	return ""
 }
 
+func (m *LoadStatus) GetFinishedTables() int64 {
+	if m != nil {
+		return m.FinishedTables
+	}
+	return 0
+}
+
+func (m *LoadStatus) GetResumedTables() int64 {
+	if m != nil {
+		return m.ResumedTables
+	}
+	return 0
+}
+
+func (m *LoadStatus) GetFreshTables() int64 {
+	if m != nil {
+		return m.FreshTables
+	}
+	return 0
+}
+
 // ShardingGroup represents a DDL sharding group, this is used by SyncStatus, and is differ from ShardingGroup in syncer pkg
 // target: target table name
 // DDL: in syncing DDL
@@ -759,17 +786,26 @@ func (m *ShardingGroup) GetUnsynced() []string {
 
 // SyncStatus represents status for sync unit
 type SyncStatus struct {
-	TotalEvents      int64            `protobuf:"varint,1,opt,name=totalEvents,proto3" json:"totalEvents,omitempty"`
-	TotalTps         int64            `protobuf:"varint,2,opt,name=totalTps,proto3" json:"totalTps,omitempty"`
-	RecentTps        int64            `protobuf:"varint,3,opt,name=recentTps,proto3" json:"recentTps,omitempty"`
-	MasterBinlog     string           `protobuf:"bytes,4,opt,name=masterBinlog,proto3" json:"masterBinlog,omitempty"`
-	MasterBinlogGtid string           `protobuf:"bytes,5,opt,name=masterBinlogGtid,proto3" json:"masterBinlogGtid,omitempty"`
-	SyncerBinlog     string           `protobuf:"bytes,6,opt,name=syncerBinlog,proto3" json:"syncerBinlog,omitempty"`
-	SyncerBinlogGtid string           `protobuf:"bytes,7,opt,name=syncerBinlogGtid,proto3" json:"syncerBinlogGtid,omitempty"`
-	BlockingDDLs     []string         `protobuf:"bytes,8,rep,name=blockingDDLs,proto3" json:"blockingDDLs,omitempty"`
-	UnresolvedGroups []*ShardingGroup `protobuf:"bytes,9,rep,name=unresolvedGroups,proto3" json:"unresolvedGroups,omitempty"`
-	Synced           bool             `protobuf:"varint,10,opt,name=synced,proto3" json:"synced,omitempty"`
-	BinlogType       string           `protobuf:"bytes,11,opt,name=binlogType,proto3" json:"binlogType,omitempty"`
+	TotalEvents            int64              `protobuf:"varint,1,opt,name=totalEvents,proto3" json:"totalEvents,omitempty"`
+	TotalTps               int64              `protobuf:"varint,2,opt,name=totalTps,proto3" json:"totalTps,omitempty"`
+	RecentTps              int64              `protobuf:"varint,3,opt,name=recentTps,proto3" json:"recentTps,omitempty"`
+	MasterBinlog           string             `protobuf:"bytes,4,opt,name=masterBinlog,proto3" json:"masterBinlog,omitempty"`
+	MasterBinlogGtid       string             `protobuf:"bytes,5,opt,name=masterBinlogGtid,proto3" json:"masterBinlogGtid,omitempty"`
+	SyncerBinlog           string             `protobuf:"bytes,6,opt,name=syncerBinlog,proto3" json:"syncerBinlog,omitempty"`
+	SyncerBinlogGtid       string             `protobuf:"bytes,7,opt,name=syncerBinlogGtid,proto3" json:"syncerBinlogGtid,omitempty"`
+	BlockingDDLs           []string           `protobuf:"bytes,8,rep,name=blockingDDLs,proto3" json:"blockingDDLs,omitempty"`
+	UnresolvedGroups       []*ShardingGroup   `protobuf:"bytes,9,rep,name=unresolvedGroups,proto3" json:"unresolvedGroups,omitempty"`
+	Synced                 bool               `protobuf:"varint,10,opt,name=synced,proto3" json:"synced,omitempty"`
+	BinlogType             string             `protobuf:"bytes,11,opt,name=binlogType,proto3" json:"binlogType,omitempty"`
+	BatchSize              int32              `protobuf:"varint,12,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+	ColumnTransforms       []*ColumnTransform `protobuf:"bytes,13,rep,name=columnTransforms,proto3" json:"columnTransforms,omitempty"`
+	GtidMode               string             `protobuf:"bytes,14,opt,name=gtidMode,proto3" json:"gtidMode,omitempty"`
+	WriteMode              string             `protobuf:"bytes,15,opt,name=writeMode,proto3" json:"writeMode,omitempty"`
+	TotalConflictRetries   int64              `protobuf:"varint,16,opt,name=totalConflictRetries,proto3" json:"totalConflictRetries,omitempty"`
+	RecentConflictRetries  int64              `protobuf:"varint,17,opt,name=recentConflictRetries,proto3" json:"recentConflictRetries,omitempty"`
+	SkippedUnsupportedDDLs []*SkippedDDL      `protobuf:"bytes,18,rep,name=skippedUnsupportedDDLs,proto3" json:"skippedUnsupportedDDLs,omitempty"`
+	BufferedJobCount       int64              `protobuf:"varint,19,opt,name=bufferedJobCount,proto3" json:"bufferedJobCount,omitempty"`
+	StuckBinlog            string             `protobuf:"bytes,20,opt,name=stuckBinlog,proto3" json:"stuckBinlog,omitempty"`
 }
 
 func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
@@ -882,6 +918,208 @@ func (m *SyncStatus) GetBinlogType() string {
 	return ""
 }
 
+func (m *SyncStatus) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetColumnTransforms() []*ColumnTransform {
+	if m != nil {
+		return m.ColumnTransforms
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetGtidMode() string {
+	if m != nil {
+		return m.GtidMode
+	}
+	return ""
+}
+
+func (m *SyncStatus) GetWriteMode() string {
+	if m != nil {
+		return m.WriteMode
+	}
+	return ""
+}
+
+func (m *SyncStatus) GetTotalConflictRetries() int64 {
+	if m != nil {
+		return m.TotalConflictRetries
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetRecentConflictRetries() int64 {
+	if m != nil {
+		return m.RecentConflictRetries
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetSkippedUnsupportedDDLs() []*SkippedDDL {
+	if m != nil {
+		return m.SkippedUnsupportedDDLs
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetBufferedJobCount() int64 {
+	if m != nil {
+		return m.BufferedJobCount
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetStuckBinlog() string {
+	if m != nil {
+		return m.StuckBinlog
+	}
+	return ""
+}
+
+// SkippedDDL represents an unsupported DDL skipped by sync unit
+// time: the time of skipping, the number of seconds elapsed since January 1, 1970 UTC
+type SkippedDDL struct {
+	Schema    string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Statement string `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
+	Location  string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Time      int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *SkippedDDL) Reset()         { *m = SkippedDDL{} }
+func (m *SkippedDDL) String() string { return proto.CompactTextString(m) }
+func (*SkippedDDL) ProtoMessage()    {}
+func (*SkippedDDL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+}
+func (m *SkippedDDL) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SkippedDDL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SkippedDDL.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SkippedDDL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SkippedDDL.Merge(m, src)
+}
+func (m *SkippedDDL) XXX_Size() int {
+	return m.Size()
+}
+func (m *SkippedDDL) XXX_DiscardUnknown() {
+	xxx_messageInfo_SkippedDDL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SkippedDDL proto.InternalMessageInfo
+
+func (m *SkippedDDL) GetSchema() string {
+	if m != nil {
+		return m.Schema
+	}
+	return ""
+}
+
+func (m *SkippedDDL) GetStatement() string {
+	if m != nil {
+		return m.Statement
+	}
+	return ""
+}
+
+func (m *SkippedDDL) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *SkippedDDL) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+// ColumnTransform represents a transform applied to a column by sync unit
+type ColumnTransform struct {
+	SchemaPattern string `protobuf:"bytes,1,opt,name=schemaPattern,proto3" json:"schemaPattern,omitempty"`
+	TablePattern  string `protobuf:"bytes,2,opt,name=tablePattern,proto3" json:"tablePattern,omitempty"`
+	Column        string `protobuf:"bytes,3,opt,name=column,proto3" json:"column,omitempty"`
+	Func          string `protobuf:"bytes,4,opt,name=func,proto3" json:"func,omitempty"`
+}
+
+func (m *ColumnTransform) Reset()         { *m = ColumnTransform{} }
+func (m *ColumnTransform) String() string { return proto.CompactTextString(m) }
+func (*ColumnTransform) ProtoMessage()    {}
+func (*ColumnTransform) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+}
+func (m *ColumnTransform) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ColumnTransform) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ColumnTransform.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ColumnTransform) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnTransform.Merge(m, src)
+}
+func (m *ColumnTransform) XXX_Size() int {
+	return m.Size()
+}
+func (m *ColumnTransform) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnTransform.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnTransform proto.InternalMessageInfo
+
+func (m *ColumnTransform) GetSchemaPattern() string {
+	if m != nil {
+		return m.SchemaPattern
+	}
+	return ""
+}
+
+func (m *ColumnTransform) GetTablePattern() string {
+	if m != nil {
+		return m.TablePattern
+	}
+	return ""
+}
+
+func (m *ColumnTransform) GetColumn() string {
+	if m != nil {
+		return m.Column
+	}
+	return ""
+}
+
+func (m *ColumnTransform) GetFunc() string {
+	if m != nil {
+		return m.Func
+	}
+	return ""
+}
+
 // SourceStatus represents status for source runing on dm-worker
 type SourceStatus struct {
 	Source      string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
@@ -894,7 +1132,7 @@ func (m *SourceStatus) Reset()         { *m = SourceStatus{} }
 func (m *SourceStatus) String() string { return proto.CompactTextString(m) }
 func (*SourceStatus) ProtoMessage()    {}
 func (*SourceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+	return fileDescriptor_51a1b9e17fd67b10, []int{10}
 }
 func (m *SourceStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -961,13 +1199,14 @@ type RelayStatus struct {
 	RelayCatchUpMaster bool           `protobuf:"varint,6,opt,name=relayCatchUpMaster,proto3" json:"relayCatchUpMaster,omitempty"`
 	Stage              Stage          `protobuf:"varint,7,opt,name=stage,proto3,enum=pb.Stage" json:"stage,omitempty"`
 	Result             *ProcessResult `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	RelaySource        string         `protobuf:"bytes,9,opt,name=relaySource,proto3" json:"relaySource,omitempty"`
 }
 
 func (m *RelayStatus) Reset()         { *m = RelayStatus{} }
 func (m *RelayStatus) String() string { return proto.CompactTextString(m) }
 func (*RelayStatus) ProtoMessage()    {}
 func (*RelayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+	return fileDescriptor_51a1b9e17fd67b10, []int{11}
 }
 func (m *RelayStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1052,6 +1291,13 @@ func (m *RelayStatus) GetResult() *ProcessResult {
 	return nil
 }
 
+func (m *RelayStatus) GetRelaySource() string {
+	if m != nil {
+		return m.RelaySource
+	}
+	return ""
+}
+
 // SubTaskStatus represents status for a sub task
 // name: sub task'name, when starting a sub task the name should be unique
 // stage: sub task's current stage
@@ -1062,6 +1308,7 @@ func (m *RelayStatus) GetResult() *ProcessResult {
 // status: current unit's statistics
 //         for Load, includes total bytes, progress, etc.
 //         for Sync, includes TPS, binlog meta, etc.
+// resourceUsage: the latest sampled resource usage of the sub task, empty if not sampled yet
 type SubTaskStatus struct {
 	Name                string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Stage               Stage          `protobuf:"varint,2,opt,name=stage,proto3,enum=pb.Stage" json:"stage,omitempty"`
@@ -1074,14 +1321,22 @@ type SubTaskStatus struct {
 	//	*SubTaskStatus_Dump
 	//	*SubTaskStatus_Load
 	//	*SubTaskStatus_Sync
-	Status isSubTaskStatus_Status `protobuf_oneof:"status"`
+	Status              isSubTaskStatus_Status `protobuf_oneof:"status"`
+	ResourceUsage       *ResourceUsage         `protobuf:"bytes,11,opt,name=resourceUsage,proto3" json:"resourceUsage,omitempty"`
+	MaxAllowedLag       int64                  `protobuf:"varint,12,opt,name=maxAllowedLag,proto3" json:"maxAllowedLag,omitempty"`
+	ReplicationLag      int64                  `protobuf:"varint,13,opt,name=replicationLag,proto3" json:"replicationLag,omitempty"`
+	DumpThreads         int32                  `protobuf:"varint,14,opt,name=dumpThreads,proto3" json:"dumpThreads,omitempty"`
+	DumpChunkRows       uint64                 `protobuf:"varint,15,opt,name=dumpChunkRows,proto3" json:"dumpChunkRows,omitempty"`
+	InMaintenance       bool                   `protobuf:"varint,16,opt,name=inMaintenance,proto3" json:"inMaintenance,omitempty"`
+	ErrorClassification *ErrorClassification   `protobuf:"bytes,17,opt,name=errorClassification,proto3" json:"errorClassification,omitempty"`
+	PriorityClass       string                 `protobuf:"bytes,18,opt,name=priorityClass,proto3" json:"priorityClass,omitempty"`
 }
 
 func (m *SubTaskStatus) Reset()         { *m = SubTaskStatus{} }
 func (m *SubTaskStatus) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatus) ProtoMessage()    {}
 func (*SubTaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{10}
+	return fileDescriptor_51a1b9e17fd67b10, []int{12}
 }
 func (m *SubTaskStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1215,6 +1470,62 @@ func (m *SubTaskStatus) GetSync() *SyncStatus {
 	return nil
 }
 
+func (m *SubTaskStatus) GetResourceUsage() *ResourceUsage {
+	if m != nil {
+		return m.ResourceUsage
+	}
+	return nil
+}
+
+func (m *SubTaskStatus) GetMaxAllowedLag() int64 {
+	if m != nil {
+		return m.MaxAllowedLag
+	}
+	return 0
+}
+
+func (m *SubTaskStatus) GetReplicationLag() int64 {
+	if m != nil {
+		return m.ReplicationLag
+	}
+	return 0
+}
+
+func (m *SubTaskStatus) GetDumpThreads() int32 {
+	if m != nil {
+		return m.DumpThreads
+	}
+	return 0
+}
+
+func (m *SubTaskStatus) GetDumpChunkRows() uint64 {
+	if m != nil {
+		return m.DumpChunkRows
+	}
+	return 0
+}
+
+func (m *SubTaskStatus) GetInMaintenance() bool {
+	if m != nil {
+		return m.InMaintenance
+	}
+	return false
+}
+
+func (m *SubTaskStatus) GetErrorClassification() *ErrorClassification {
+	if m != nil {
+		return m.ErrorClassification
+	}
+	return nil
+}
+
+func (m *SubTaskStatus) GetPriorityClass() string {
+	if m != nil {
+		return m.PriorityClass
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SubTaskStatus) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1226,23 +1537,29 @@ func (*SubTaskStatus) XXX_OneofWrappers() []interface{} {
 	}
 }
 
-// SubTaskStatusList used for internal jsonpb marshal
-type SubTaskStatusList struct {
-	Status []*SubTaskStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+// ErrorClassification represents the decision on a DB error met by a sub task
+// class: `retryable`, `permanent` or `pause`
+// classifier: the name of the registered classifier decided the class, or `default` for the default rules
+// time: the time of classifying, the number of seconds elapsed since January 1, 1970 UTC
+type ErrorClassification struct {
+	Error      string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Class      string `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Classifier string `protobuf:"bytes,3,opt,name=classifier,proto3" json:"classifier,omitempty"`
+	Time       int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
-func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
-func (*SubTaskStatusList) ProtoMessage()    {}
-func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{11}
+func (m *ErrorClassification) Reset()         { *m = ErrorClassification{} }
+func (m *ErrorClassification) String() string { return proto.CompactTextString(m) }
+func (*ErrorClassification) ProtoMessage()    {}
+func (*ErrorClassification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{13}
 }
-func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
+func (m *ErrorClassification) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SubTaskStatusList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ErrorClassification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SubTaskStatusList.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ErrorClassification.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1252,43 +1569,69 @@ func (m *SubTaskStatusList) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *SubTaskStatusList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SubTaskStatusList.Merge(m, src)
+func (m *ErrorClassification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorClassification.Merge(m, src)
 }
-func (m *SubTaskStatusList) XXX_Size() int {
+func (m *ErrorClassification) XXX_Size() int {
 	return m.Size()
 }
-func (m *SubTaskStatusList) XXX_DiscardUnknown() {
-	xxx_messageInfo_SubTaskStatusList.DiscardUnknown(m)
+func (m *ErrorClassification) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorClassification.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SubTaskStatusList proto.InternalMessageInfo
+var xxx_messageInfo_ErrorClassification proto.InternalMessageInfo
 
-func (m *SubTaskStatusList) GetStatus() []*SubTaskStatus {
+func (m *ErrorClassification) GetError() string {
 	if m != nil {
-		return m.Status
+		return m.Error
 	}
-	return nil
+	return ""
 }
 
-// CheckError represents error for check unit
-// adds fields later
-type CheckError struct {
-	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+func (m *ErrorClassification) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
 }
 
-func (m *CheckError) Reset()         { *m = CheckError{} }
-func (m *CheckError) String() string { return proto.CompactTextString(m) }
-func (*CheckError) ProtoMessage()    {}
-func (*CheckError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{12}
+func (m *ErrorClassification) GetClassifier() string {
+	if m != nil {
+		return m.Classifier
+	}
+	return ""
 }
-func (m *CheckError) XXX_Unmarshal(b []byte) error {
+
+func (m *ErrorClassification) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+// ResourceUsage represents the resource usage of a sub task when sampled
+// goroutines: number of goroutines created by the units of the sub task
+// memoryBytes: NOT the memory allocated by the sub task, but the heap and stacks in use of the process
+//              shared in proportion to the number of goroutines, because go can't account memory by goroutine
+// sampleTime: the time of sampling, the number of seconds elapsed since January 1, 1970 UTC
+type ResourceUsage struct {
+	Goroutines  int64  `protobuf:"varint,1,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	MemoryBytes uint64 `protobuf:"varint,2,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	SampleTime  int64  `protobuf:"varint,3,opt,name=sampleTime,proto3" json:"sampleTime,omitempty"`
+}
+
+func (m *ResourceUsage) Reset()         { *m = ResourceUsage{} }
+func (m *ResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsage) ProtoMessage()    {}
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{14}
+}
+func (m *ResourceUsage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CheckError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CheckError.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResourceUsage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1298,19 +1641,124 @@ func (m *CheckError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *CheckError) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckError.Merge(m, src)
+func (m *ResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsage.Merge(m, src)
 }
-func (m *CheckError) XXX_Size() int {
+func (m *ResourceUsage) XXX_Size() int {
 	return m.Size()
 }
-func (m *CheckError) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckError.DiscardUnknown(m)
+func (m *ResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckError proto.InternalMessageInfo
+var xxx_messageInfo_ResourceUsage proto.InternalMessageInfo
 
-func (m *CheckError) GetMsg() string {
+func (m *ResourceUsage) GetGoroutines() int64 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetMemoryBytes() uint64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetSampleTime() int64 {
+	if m != nil {
+		return m.SampleTime
+	}
+	return 0
+}
+
+// SubTaskStatusList used for internal jsonpb marshal
+type SubTaskStatusList struct {
+	Status []*SubTaskStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
+func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
+func (*SubTaskStatusList) ProtoMessage()    {}
+func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{15}
+}
+func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubTaskStatusList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubTaskStatusList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubTaskStatusList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubTaskStatusList.Merge(m, src)
+}
+func (m *SubTaskStatusList) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubTaskStatusList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubTaskStatusList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubTaskStatusList proto.InternalMessageInfo
+
+func (m *SubTaskStatusList) GetStatus() []*SubTaskStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// CheckError represents error for check unit
+// adds fields later
+type CheckError struct {
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *CheckError) Reset()         { *m = CheckError{} }
+func (m *CheckError) String() string { return proto.CompactTextString(m) }
+func (*CheckError) ProtoMessage()    {}
+func (*CheckError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+}
+func (m *CheckError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckError.Merge(m, src)
+}
+func (m *CheckError) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckError) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckError proto.InternalMessageInfo
+
+func (m *CheckError) GetMsg() string {
 	if m != nil {
 		return m.Msg
 	}
@@ -1327,7 +1775,7 @@ func (m *DumpError) Reset()         { *m = DumpError{} }
 func (m *DumpError) String() string { return proto.CompactTextString(m) }
 func (*DumpError) ProtoMessage()    {}
 func (*DumpError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{13}
+	return fileDescriptor_51a1b9e17fd67b10, []int{17}
 }
 func (m *DumpError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1372,7 +1820,7 @@ func (m *LoadError) Reset()         { *m = LoadError{} }
 func (m *LoadError) String() string { return proto.CompactTextString(m) }
 func (*LoadError) ProtoMessage()    {}
 func (*LoadError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{14}
+	return fileDescriptor_51a1b9e17fd67b10, []int{18}
 }
 func (m *LoadError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1419,7 +1867,7 @@ func (m *SyncSQLError) Reset()         { *m = SyncSQLError{} }
 func (m *SyncSQLError) String() string { return proto.CompactTextString(m) }
 func (*SyncSQLError) ProtoMessage()    {}
 func (*SyncSQLError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{15}
+	return fileDescriptor_51a1b9e17fd67b10, []int{19}
 }
 func (m *SyncSQLError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1478,7 +1926,7 @@ func (m *SyncError) Reset()         { *m = SyncError{} }
 func (m *SyncError) String() string { return proto.CompactTextString(m) }
 func (*SyncError) ProtoMessage()    {}
 func (*SyncError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+	return fileDescriptor_51a1b9e17fd67b10, []int{20}
 }
 func (m *SyncError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1526,7 +1974,7 @@ func (m *SourceError) Reset()         { *m = SourceError{} }
 func (m *SourceError) String() string { return proto.CompactTextString(m) }
 func (*SourceError) ProtoMessage()    {}
 func (*SourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{17}
+	return fileDescriptor_51a1b9e17fd67b10, []int{21}
 }
 func (m *SourceError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1592,7 +2040,7 @@ func (m *RelayError) Reset()         { *m = RelayError{} }
 func (m *RelayError) String() string { return proto.CompactTextString(m) }
 func (*RelayError) ProtoMessage()    {}
 func (*RelayError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{18}
+	return fileDescriptor_51a1b9e17fd67b10, []int{22}
 }
 func (m *RelayError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1651,7 +2099,7 @@ func (m *SubTaskError) Reset()         { *m = SubTaskError{} }
 func (m *SubTaskError) String() string { return proto.CompactTextString(m) }
 func (*SubTaskError) ProtoMessage()    {}
 func (*SubTaskError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{19}
+	return fileDescriptor_51a1b9e17fd67b10, []int{23}
 }
 func (m *SubTaskError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1791,7 +2239,7 @@ func (m *SubTaskErrorList) Reset()         { *m = SubTaskErrorList{} }
 func (m *SubTaskErrorList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskErrorList) ProtoMessage()    {}
 func (*SubTaskErrorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{20}
+	return fileDescriptor_51a1b9e17fd67b10, []int{24}
 }
 func (m *SubTaskErrorList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1841,7 +2289,7 @@ func (m *ProcessResult) Reset()         { *m = ProcessResult{} }
 func (m *ProcessResult) String() string { return proto.CompactTextString(m) }
 func (*ProcessResult) ProtoMessage()    {}
 func (*ProcessResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{21}
+	return fileDescriptor_51a1b9e17fd67b10, []int{25}
 }
 func (m *ProcessResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1908,7 +2356,7 @@ func (m *ProcessError) Reset()         { *m = ProcessError{} }
 func (m *ProcessError) String() string { return proto.CompactTextString(m) }
 func (*ProcessError) ProtoMessage()    {}
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{22}
+	return fileDescriptor_51a1b9e17fd67b10, []int{26}
 }
 func (m *ProcessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1991,18 +2439,22 @@ func (m *ProcessError) GetWorkaround() string {
 // time: whether purge relay log files before this time, the number of seconds elapsed since January 1, 1970 UTC
 // filename: whether purge relay log files before this filename
 // subDir: specify relay sub directory for @filename
+// dryRun: only return the relay log files which would be purged, without purging them
+// gtidSet: whether purge relay log files whose GTIDs are all contained in this GTID set
 type PurgeRelayRequest struct {
 	Inactive bool   `protobuf:"varint,1,opt,name=inactive,proto3" json:"inactive,omitempty"`
 	Time     int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
 	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
 	SubDir   string `protobuf:"bytes,4,opt,name=subDir,proto3" json:"subDir,omitempty"`
+	DryRun   bool   `protobuf:"varint,5,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	GtidSet  string `protobuf:"bytes,6,opt,name=gtidSet,proto3" json:"gtidSet,omitempty"`
 }
 
 func (m *PurgeRelayRequest) Reset()         { *m = PurgeRelayRequest{} }
 func (m *PurgeRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*PurgeRelayRequest) ProtoMessage()    {}
 func (*PurgeRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{23}
+	return fileDescriptor_51a1b9e17fd67b10, []int{27}
 }
 func (m *PurgeRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2059,6 +2511,20 @@ func (m *PurgeRelayRequest) GetSubDir() string {
 	return ""
 }
 
+func (m *PurgeRelayRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *PurgeRelayRequest) GetGtidSet() string {
+	if m != nil {
+		return m.GtidSet
+	}
+	return ""
+}
+
 type OperateWorkerSchemaRequest struct {
 	Op       SchemaOp `protobuf:"varint,1,opt,name=op,proto3,enum=pb.SchemaOp" json:"op,omitempty"`
 	Task     string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
@@ -2074,7 +2540,7 @@ func (m *OperateWorkerSchemaRequest) Reset()         { *m = OperateWorkerSchemaR
 func (m *OperateWorkerSchemaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateWorkerSchemaRequest) ProtoMessage()    {}
 func (*OperateWorkerSchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{24}
+	return fileDescriptor_51a1b9e17fd67b10, []int{28}
 }
 func (m *OperateWorkerSchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2171,7 +2637,7 @@ func (m *V1SubTaskMeta) Reset()         { *m = V1SubTaskMeta{} }
 func (m *V1SubTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*V1SubTaskMeta) ProtoMessage()    {}
 func (*V1SubTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{25}
+	return fileDescriptor_51a1b9e17fd67b10, []int{29}
 }
 func (m *V1SubTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2236,7 +2702,7 @@ func (m *OperateV1MetaRequest) Reset()         { *m = OperateV1MetaRequest{} }
 func (m *OperateV1MetaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaRequest) ProtoMessage()    {}
 func (*OperateV1MetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{26}
+	return fileDescriptor_51a1b9e17fd67b10, []int{30}
 }
 func (m *OperateV1MetaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2282,7 +2748,7 @@ func (m *OperateV1MetaResponse) Reset()         { *m = OperateV1MetaResponse{} }
 func (m *OperateV1MetaResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaResponse) ProtoMessage()    {}
 func (*OperateV1MetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{27}
+	return fileDescriptor_51a1b9e17fd67b10, []int{31}
 }
 func (m *OperateV1MetaResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2343,7 +2809,7 @@ func (m *HandleWorkerErrorRequest) Reset()         { *m = HandleWorkerErrorReque
 func (m *HandleWorkerErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleWorkerErrorRequest) ProtoMessage()    {}
 func (*HandleWorkerErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{28}
+	return fileDescriptor_51a1b9e17fd67b10, []int{32}
 }
 func (m *HandleWorkerErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2407,7 +2873,7 @@ func (m *GetWorkerCfgRequest) Reset()         { *m = GetWorkerCfgRequest{} }
 func (m *GetWorkerCfgRequest) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgRequest) ProtoMessage()    {}
 func (*GetWorkerCfgRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{29}
+	return fileDescriptor_51a1b9e17fd67b10, []int{33}
 }
 func (m *GetWorkerCfgRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2444,7 +2910,7 @@ func (m *GetWorkerCfgResponse) Reset()         { *m = GetWorkerCfgResponse{} }
 func (m *GetWorkerCfgResponse) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgResponse) ProtoMessage()    {}
 func (*GetWorkerCfgResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{30}
+	return fileDescriptor_51a1b9e17fd67b10, []int{34}
 }
 func (m *GetWorkerCfgResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2496,9 +2962,13 @@ func init() {
 	proto.RegisterType((*LoadStatus)(nil), "pb.LoadStatus")
 	proto.RegisterType((*ShardingGroup)(nil), "pb.ShardingGroup")
 	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
+	proto.RegisterType((*SkippedDDL)(nil), "pb.SkippedDDL")
+	proto.RegisterType((*ColumnTransform)(nil), "pb.ColumnTransform")
 	proto.RegisterType((*SourceStatus)(nil), "pb.SourceStatus")
 	proto.RegisterType((*RelayStatus)(nil), "pb.RelayStatus")
 	proto.RegisterType((*SubTaskStatus)(nil), "pb.SubTaskStatus")
+	proto.RegisterType((*ErrorClassification)(nil), "pb.ErrorClassification")
+	proto.RegisterType((*ResourceUsage)(nil), "pb.ResourceUsage")
 	proto.RegisterType((*SubTaskStatusList)(nil), "pb.SubTaskStatusList")
 	proto.RegisterType((*CheckError)(nil), "pb.CheckError")
 	proto.RegisterType((*DumpError)(nil), "pb.DumpError")
@@ -2525,131 +2995,165 @@ func init() {
 func init() { proto.RegisterFile("dmworker.proto", fileDescriptor_51a1b9e17fd67b10) }
 
 var fileDescriptor_51a1b9e17fd67b10 = []byte{
-	// 1980 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x41, 0x73, 0xdc, 0x4a,
-	0x11, 0x5e, 0xad, 0x76, 0xd7, 0xbb, 0xbd, 0x6b, 0x47, 0x99, 0x38, 0x8f, 0xc5, 0x84, 0xc5, 0xa5,
-	0xbc, 0x0a, 0xc6, 0x07, 0x17, 0x31, 0x8f, 0x7a, 0xd4, 0xab, 0x02, 0x42, 0xec, 0x3c, 0xe7, 0x81,
-	0x83, 0x13, 0x39, 0x79, 0x1c, 0xa9, 0x59, 0x69, 0xbc, 0x56, 0x59, 0x2b, 0x29, 0xd2, 0xc8, 0xae,
-	0x3d, 0xf0, 0x1b, 0xe0, 0xc2, 0x81, 0x2a, 0x6e, 0x14, 0xd7, 0x77, 0xe4, 0x27, 0x00, 0xc7, 0x57,
-	0x9c, 0x28, 0x4e, 0x54, 0xf2, 0x37, 0x38, 0x50, 0xdd, 0x33, 0x92, 0x46, 0xf6, 0x6e, 0x42, 0x0e,
-	0xdc, 0xd4, 0x5f, 0xf7, 0xf4, 0xf4, 0xf4, 0x7c, 0xdd, 0xbd, 0xb3, 0xb0, 0x11, 0xcc, 0xaf, 0x92,
-	0xec, 0x42, 0x64, 0x7b, 0x69, 0x96, 0xc8, 0x84, 0xb5, 0xd3, 0xa9, 0xbb, 0x03, 0xec, 0x45, 0x21,
-	0xb2, 0xc5, 0xa9, 0xe4, 0xb2, 0xc8, 0x3d, 0xf1, 0xba, 0x10, 0xb9, 0x64, 0x0c, 0x3a, 0x31, 0x9f,
-	0x8b, 0xb1, 0xb5, 0x6d, 0xed, 0x0c, 0x3c, 0xfa, 0x76, 0x53, 0xd8, 0x3c, 0x48, 0xe6, 0xf3, 0x24,
-	0xfe, 0x15, 0xf9, 0xf0, 0x44, 0x9e, 0x26, 0x71, 0x2e, 0xd8, 0x47, 0xd0, 0xcb, 0x44, 0x5e, 0x44,
-	0x92, 0xac, 0xfb, 0x9e, 0x96, 0x98, 0x03, 0xf6, 0x3c, 0x9f, 0x8d, 0xdb, 0xe4, 0x02, 0x3f, 0xd1,
-	0x32, 0x4f, 0x8a, 0xcc, 0x17, 0x63, 0x9b, 0x40, 0x2d, 0x21, 0xae, 0xe2, 0x1a, 0x77, 0x14, 0xae,
-	0x24, 0xf7, 0x2b, 0x0b, 0xee, 0x34, 0x82, 0xfb, 0xe0, 0x1d, 0x3f, 0x81, 0x91, 0xda, 0x43, 0x79,
-	0xa0, 0x7d, 0x87, 0xfb, 0xce, 0x5e, 0x3a, 0xdd, 0x3b, 0x35, 0x70, 0xaf, 0x61, 0xc5, 0x3e, 0x85,
-	0xf5, 0xbc, 0x98, 0xbe, 0xe4, 0xf9, 0x85, 0x5e, 0xd6, 0xd9, 0xb6, 0x77, 0x86, 0xfb, 0xb7, 0x69,
-	0x99, 0xa9, 0xf0, 0x9a, 0x76, 0xee, 0x9f, 0x2d, 0x18, 0x1e, 0x9c, 0x0b, 0x5f, 0xcb, 0x18, 0x68,
-	0xca, 0xf3, 0x5c, 0x04, 0x65, 0xa0, 0x4a, 0x62, 0x9b, 0xd0, 0x95, 0x89, 0xe4, 0x11, 0x85, 0xda,
-	0xf5, 0x94, 0xc0, 0x26, 0x00, 0x79, 0xe1, 0xfb, 0x22, 0xcf, 0xcf, 0x8a, 0x88, 0x42, 0xed, 0x7a,
-	0x06, 0x82, 0xde, 0xce, 0x78, 0x18, 0x89, 0x80, 0xd2, 0xd4, 0xf5, 0xb4, 0xc4, 0xc6, 0xb0, 0x76,
-	0xc5, 0xb3, 0x38, 0x8c, 0x67, 0xe3, 0x2e, 0x29, 0x4a, 0x11, 0x57, 0x04, 0x42, 0xf2, 0x30, 0x1a,
-	0xf7, 0xb6, 0xad, 0x9d, 0x91, 0xa7, 0x25, 0x77, 0x04, 0x70, 0x58, 0xcc, 0x53, 0x1d, 0xf5, 0x5f,
-	0x2c, 0x80, 0xe3, 0x84, 0x07, 0x3a, 0xe8, 0x8f, 0x61, 0xfd, 0x2c, 0x8c, 0xc3, 0xfc, 0x5c, 0x04,
-	0x8f, 0x17, 0x52, 0xe4, 0x14, 0xbb, 0xed, 0x35, 0x41, 0x0c, 0x96, 0xa2, 0x56, 0x26, 0x6d, 0x32,
-	0x31, 0x10, 0xb6, 0x05, 0xfd, 0x34, 0x4b, 0x66, 0x99, 0xc8, 0x73, 0x7d, 0xdb, 0x95, 0x8c, 0x6b,
-	0xe7, 0x42, 0xf2, 0xc7, 0x61, 0x1c, 0x25, 0x33, 0x7d, 0xe7, 0x06, 0xc2, 0x1e, 0xc0, 0x46, 0x2d,
-	0x1d, 0xbd, 0xfc, 0xe2, 0x90, 0xce, 0x35, 0xf0, 0xae, 0xa1, 0xee, 0xef, 0x2d, 0x58, 0x3f, 0x3d,
-	0xe7, 0x59, 0x10, 0xc6, 0xb3, 0xa3, 0x2c, 0x29, 0x52, 0x3c, 0xb0, 0xe4, 0xd9, 0x4c, 0x48, 0xcd,
-	0x5c, 0x2d, 0x21, 0x9f, 0x0f, 0x0f, 0x8f, 0x31, 0x4e, 0x1b, 0xf9, 0x8c, 0xdf, 0xea, 0x9c, 0x59,
-	0x2e, 0x8f, 0x13, 0x9f, 0xcb, 0x30, 0x89, 0x75, 0x98, 0x4d, 0x90, 0x38, 0xbb, 0x88, 0x7d, 0x4a,
-	0xba, 0x4d, 0x9c, 0x25, 0x09, 0xcf, 0x57, 0xc4, 0x5a, 0xd3, 0x25, 0x4d, 0x25, 0xbb, 0x7f, 0xb2,
-	0x01, 0x4e, 0x17, 0xb1, 0xaf, 0x13, 0xba, 0x0d, 0x43, 0x4a, 0xcc, 0x93, 0x4b, 0x11, 0xcb, 0x32,
-	0x9d, 0x26, 0x84, 0xce, 0x48, 0x7c, 0x99, 0x96, 0xa9, 0xac, 0x64, 0x76, 0x0f, 0x06, 0x99, 0xf0,
-	0x45, 0x2c, 0x51, 0x69, 0x93, 0xb2, 0x06, 0x98, 0x0b, 0xa3, 0x39, 0xcf, 0xa5, 0xc8, 0x1a, 0xc9,
-	0x6c, 0x60, 0x6c, 0x17, 0x1c, 0x53, 0x3e, 0x92, 0x61, 0xa0, 0x13, 0x7a, 0x03, 0x47, 0x7f, 0x74,
-	0x88, 0xd2, 0x5f, 0x4f, 0xf9, 0x33, 0x31, 0xf4, 0x67, 0xca, 0xe4, 0x6f, 0x4d, 0xf9, 0xbb, 0x8e,
-	0xa3, 0xbf, 0x69, 0x94, 0xf8, 0x17, 0x61, 0x3c, 0xa3, 0x0b, 0xe8, 0x53, 0xaa, 0x1a, 0x18, 0xfb,
-	0x31, 0x38, 0x45, 0x9c, 0x89, 0x3c, 0x89, 0x2e, 0x45, 0x40, 0xf7, 0x98, 0x8f, 0x07, 0x46, 0xc5,
-	0x99, 0x37, 0xec, 0xdd, 0x30, 0x35, 0x6e, 0x08, 0x54, 0x91, 0xe9, 0x1b, 0x9a, 0x00, 0x4c, 0x29,
-	0x90, 0x97, 0x8b, 0x54, 0x8c, 0x87, 0x8a, 0x65, 0x35, 0xe2, 0xfe, 0xd1, 0x82, 0x91, 0xd9, 0x04,
-	0x8c, 0xf6, 0x64, 0xad, 0x68, 0x4f, 0x6d, 0xb3, 0x3d, 0xb1, 0xef, 0x55, 0x6d, 0x48, 0xb5, 0x15,
-	0x8a, 0xf6, 0x79, 0x96, 0x60, 0xbd, 0x7a, 0xa4, 0xa8, 0x3a, 0xd3, 0x43, 0x18, 0x66, 0x22, 0xe2,
-	0x8b, 0xaa, 0x9f, 0xa0, 0xfd, 0x2d, 0xb4, 0xf7, 0x6a, 0xd8, 0x33, 0x6d, 0xdc, 0xbf, 0xb5, 0x61,
-	0x68, 0x28, 0x6f, 0xdc, 0xb4, 0xf5, 0x3f, 0xde, 0x74, 0x7b, 0xc5, 0x4d, 0x6f, 0x97, 0x21, 0x15,
-	0xd3, 0xc3, 0x30, 0xd3, 0xe4, 0x37, 0xa1, 0xca, 0xa2, 0x41, 0x2d, 0x13, 0x62, 0x3b, 0x70, 0xcb,
-	0x10, 0x0d, 0x62, 0x5d, 0x87, 0xd9, 0x1e, 0x30, 0x82, 0x0e, 0xb8, 0xf4, 0xcf, 0x5f, 0xa5, 0xcf,
-	0x28, 0x1a, 0x62, 0x57, 0xdf, 0x5b, 0xa2, 0x61, 0xdf, 0x81, 0x6e, 0x2e, 0xf9, 0x4c, 0x10, 0xb1,
-	0x36, 0xf6, 0x07, 0x44, 0x04, 0x04, 0x3c, 0x85, 0x1b, 0xc9, 0xef, 0xbf, 0x27, 0xf9, 0xee, 0x7f,
-	0xda, 0xb0, 0xde, 0x68, 0xdb, 0xcb, 0xc6, 0x5b, 0xbd, 0x63, 0x7b, 0xc5, 0x8e, 0xdb, 0xd0, 0x29,
-	0xe2, 0x50, 0x5d, 0xf6, 0xc6, 0xfe, 0x08, 0xf5, 0xaf, 0xe2, 0x50, 0x22, 0x97, 0x3c, 0xd2, 0x18,
-	0x31, 0x75, 0xde, 0x47, 0x88, 0xef, 0xc3, 0x9d, 0x9a, 0xc8, 0x87, 0x87, 0xc7, 0xc7, 0x89, 0x7f,
-	0x51, 0xf5, 0xb9, 0x65, 0x2a, 0xc6, 0xd4, 0x70, 0xa3, 0x82, 0x7c, 0xda, 0x52, 0xe3, 0xed, 0xbb,
-	0xd0, 0xf5, 0x71, 0xdc, 0x50, 0x96, 0x34, 0xa1, 0x8c, 0xf9, 0xf3, 0xb4, 0xe5, 0x29, 0x3d, 0xfb,
-	0x18, 0x3a, 0x41, 0x31, 0x4f, 0x75, 0xae, 0x36, 0xd0, 0xae, 0x1e, 0x00, 0x4f, 0x5b, 0x1e, 0x69,
-	0xd1, 0x2a, 0x4a, 0x78, 0x30, 0x1e, 0xd4, 0x56, 0xf5, 0x5c, 0x40, 0x2b, 0xd4, 0xa2, 0x15, 0x56,
-	0x18, 0x55, 0x9b, 0xb6, 0xaa, 0x9b, 0x1d, 0x5a, 0xa1, 0xf6, 0x71, 0x1f, 0x7a, 0xb9, 0x22, 0xf2,
-	0x4f, 0xe0, 0x76, 0x23, 0xfb, 0xc7, 0x61, 0x4e, 0xa9, 0x52, 0xea, 0xb1, 0xb5, 0x6a, 0xb6, 0x96,
-	0xeb, 0x27, 0x00, 0x74, 0xa6, 0x27, 0x59, 0x96, 0x64, 0xe5, 0x8c, 0xb7, 0xaa, 0x19, 0xef, 0x7e,
-	0x1b, 0x06, 0x78, 0x96, 0x77, 0xa8, 0xf1, 0x10, 0xab, 0xd4, 0x29, 0x8c, 0x28, 0xfa, 0x17, 0xc7,
-	0x2b, 0x2c, 0xd8, 0x3e, 0x6c, 0xaa, 0x41, 0xab, 0xe8, 0xfc, 0x3c, 0xc9, 0x43, 0x1a, 0x17, 0xaa,
-	0xb0, 0x96, 0xea, 0xb0, 0xa1, 0x0b, 0x74, 0x77, 0xfa, 0xe2, 0xb8, 0x9c, 0x7e, 0xa5, 0xec, 0xfe,
-	0x10, 0x06, 0xb8, 0xa3, 0xda, 0x6e, 0x07, 0x7a, 0xa4, 0x28, 0xf3, 0xe0, 0x54, 0xe9, 0xd4, 0x01,
-	0x79, 0x5a, 0xef, 0xfe, 0xd6, 0x82, 0xa1, 0x6a, 0x57, 0x6a, 0xe5, 0x87, 0x76, 0xab, 0xed, 0xc6,
-	0xf2, 0xb2, 0xde, 0x4d, 0x8f, 0x7b, 0x00, 0xd4, 0x70, 0x94, 0x41, 0xa7, 0xbe, 0xde, 0x1a, 0xf5,
-	0x0c, 0x0b, 0xbc, 0x98, 0x5a, 0x5a, 0x92, 0xda, 0x3f, 0xb4, 0x61, 0xa4, 0xaf, 0x54, 0x99, 0xfc,
-	0x9f, 0xca, 0x4e, 0x57, 0x46, 0xc7, 0xac, 0x8c, 0x07, 0x65, 0x65, 0x74, 0xeb, 0x63, 0xd4, 0x2c,
-	0xaa, 0x0b, 0xe3, 0xbe, 0x2e, 0x8c, 0x1e, 0x99, 0xad, 0x97, 0x85, 0x51, 0x5a, 0xa9, 0xba, 0xb8,
-	0xaf, 0xeb, 0x62, 0xad, 0x36, 0xaa, 0x28, 0x55, 0x95, 0xc5, 0x7d, 0x5d, 0x16, 0xfd, 0xda, 0xa8,
-	0xba, 0xe6, 0xaa, 0x2a, 0xd6, 0xa0, 0x4b, 0xd7, 0xe9, 0x7e, 0x06, 0x8e, 0x99, 0x1a, 0xaa, 0x89,
-	0x07, 0x5a, 0xd9, 0xa0, 0x82, 0x61, 0xe4, 0xe9, 0xb5, 0xaf, 0x61, 0xbd, 0xd1, 0x54, 0x70, 0xd2,
-	0x85, 0xf9, 0x01, 0x8f, 0x7d, 0x11, 0x55, 0x3f, 0x35, 0x0d, 0xc4, 0x20, 0x59, 0xbb, 0xf6, 0xac,
-	0x5d, 0x34, 0x48, 0x66, 0xfc, 0x60, 0xb4, 0x1b, 0x3f, 0x18, 0xff, 0x61, 0xc1, 0xc8, 0x5c, 0x80,
-	0xbf, 0x39, 0x9f, 0x64, 0xd9, 0x41, 0x12, 0xa8, 0xdb, 0xec, 0x7a, 0xa5, 0x88, 0xd4, 0xc7, 0xcf,
-	0x88, 0xe7, 0xb9, 0x66, 0x60, 0x25, 0x6b, 0xdd, 0xa9, 0x9f, 0xa4, 0xe5, 0x13, 0xa0, 0x92, 0xb5,
-	0xee, 0x58, 0x5c, 0x8a, 0x48, 0x8f, 0x9a, 0x4a, 0xc6, 0xdd, 0x9e, 0x89, 0x3c, 0x47, 0x9a, 0xa8,
-	0x0e, 0x59, 0x8a, 0xb8, 0xca, 0xe3, 0x57, 0x07, 0xbc, 0xc8, 0x85, 0xfe, 0xad, 0x52, 0xc9, 0x98,
-	0x16, 0x7c, 0xaa, 0xf0, 0x2c, 0x29, 0xe2, 0xf2, 0x17, 0x8a, 0x81, 0xb8, 0x57, 0x70, 0xfb, 0x79,
-	0x91, 0xcd, 0x04, 0x91, 0xb8, 0x7c, 0xf9, 0x6c, 0x41, 0x3f, 0x8c, 0xb9, 0x2f, 0xc3, 0x4b, 0xa1,
-	0x33, 0x59, 0xc9, 0xc8, 0x5f, 0x19, 0xce, 0x85, 0xfe, 0x89, 0x46, 0xdf, 0x68, 0x7f, 0x16, 0x46,
-	0x82, 0x78, 0xad, 0x8f, 0x54, 0xca, 0x54, 0xa2, 0x6a, 0xba, 0xea, 0x77, 0x8d, 0x92, 0xdc, 0x7f,
-	0x59, 0xb0, 0x75, 0x92, 0x8a, 0x8c, 0x4b, 0xa1, 0xde, 0x52, 0xa7, 0xfe, 0xb9, 0x98, 0xf3, 0x32,
-	0x84, 0x7b, 0xd0, 0x4e, 0x52, 0xda, 0x5c, 0xf3, 0x5d, 0xa9, 0x4f, 0x52, 0xaf, 0x9d, 0xa4, 0x14,
-	0x04, 0xcf, 0x2f, 0x74, 0x6e, 0xe9, 0x7b, 0xe5, 0xc3, 0x6a, 0x0b, 0xfa, 0x01, 0x97, 0x7c, 0xca,
-	0x73, 0x51, 0xe6, 0xb4, 0x94, 0xe9, 0x0d, 0xc2, 0xa7, 0x51, 0x99, 0x51, 0x25, 0x90, 0x27, 0xda,
-	0x4d, 0x67, 0x53, 0x4b, 0x68, 0x7d, 0x16, 0x15, 0xf9, 0x39, 0xa5, 0xb1, 0xef, 0x29, 0x01, 0x63,
-	0xa9, 0x38, 0xdf, 0x57, 0x14, 0x77, 0x25, 0xac, 0x7f, 0xf9, 0x50, 0xd3, 0xf6, 0x99, 0x90, 0x9c,
-	0x6d, 0x19, 0xc7, 0x01, 0x3c, 0x0e, 0x6a, 0xf4, 0x61, 0xde, 0x5b, 0xfd, 0x65, 0xcb, 0xb0, 0x8d,
-	0x96, 0x51, 0x66, 0xa0, 0x43, 0x14, 0xa5, 0x6f, 0xf7, 0x13, 0xd8, 0xd4, 0x19, 0xfd, 0xf2, 0x21,
-	0xee, 0xba, 0x32, 0x97, 0x4a, 0xad, 0xb6, 0x77, 0xff, 0x6a, 0xc1, 0xdd, 0x6b, 0xcb, 0x3e, 0xf8,
-	0x89, 0xf9, 0x29, 0x74, 0xf0, 0x59, 0x32, 0xb6, 0xa9, 0xb4, 0xee, 0xe3, 0x1e, 0x4b, 0x5d, 0xee,
-	0xa1, 0xf0, 0x24, 0x96, 0xd9, 0xc2, 0xa3, 0x05, 0x5b, 0x3f, 0x87, 0x41, 0x05, 0xa1, 0xdf, 0x0b,
-	0xb1, 0x28, 0xbb, 0xe7, 0x85, 0x58, 0xe0, 0x6c, 0xbf, 0xe4, 0x51, 0xa1, 0x52, 0xa3, 0x07, 0x64,
-	0x23, 0xb1, 0x9e, 0xd2, 0x7f, 0xd6, 0xfe, 0x91, 0xe5, 0xfe, 0x06, 0xc6, 0x4f, 0x79, 0x1c, 0x44,
-	0x9a, 0x4f, 0xaa, 0xa8, 0x75, 0x0a, 0xbe, 0x65, 0xa4, 0x60, 0x88, 0x5e, 0x48, 0xfb, 0x0e, 0x36,
-	0xdd, 0x83, 0xc1, 0xb4, 0x1c, 0x67, 0x3a, 0xf1, 0x35, 0x40, 0x77, 0xfe, 0x3a, 0xca, 0xf5, 0x73,
-	0x88, 0xbe, 0xdd, 0xbb, 0x70, 0xe7, 0x48, 0x48, 0xb5, 0xf7, 0xc1, 0xd9, 0x4c, 0xef, 0xec, 0xee,
-	0xc0, 0x66, 0x13, 0xd6, 0xc9, 0x75, 0xc0, 0xf6, 0xcf, 0xaa, 0x51, 0xe1, 0x9f, 0xcd, 0x76, 0x7f,
-	0x0d, 0x3d, 0xc5, 0x0a, 0xb6, 0x0e, 0x83, 0x2f, 0xe2, 0x4b, 0x1e, 0x85, 0xc1, 0x49, 0xea, 0xb4,
-	0x58, 0x1f, 0x3a, 0xa7, 0x32, 0x49, 0x1d, 0x8b, 0x0d, 0xa0, 0xfb, 0x1c, 0xcb, 0xda, 0x69, 0x33,
-	0x80, 0x1e, 0x76, 0xbe, 0xb9, 0x70, 0x6c, 0x84, 0x4f, 0x25, 0xcf, 0xa4, 0xd3, 0x41, 0xf8, 0x55,
-	0x1a, 0x70, 0x29, 0x9c, 0x2e, 0xdb, 0x00, 0xf8, 0x59, 0x21, 0x13, 0x6d, 0xd6, 0xdb, 0x7d, 0x4d,
-	0x66, 0x33, 0xdc, 0x7b, 0xa4, 0xfd, 0x93, 0xec, 0xb4, 0xd8, 0x1a, 0xd8, 0xbf, 0x14, 0x57, 0x8e,
-	0xc5, 0x86, 0xb0, 0xe6, 0x15, 0x31, 0x3e, 0x9c, 0xd5, 0x1e, 0xb4, 0x5d, 0xe0, 0xd8, 0xa8, 0xc0,
-	0x20, 0x52, 0x11, 0x38, 0x1d, 0x36, 0x82, 0xfe, 0xe7, 0xfa, 0x25, 0xec, 0x74, 0x51, 0x85, 0x66,
-	0xb8, 0xa6, 0x87, 0x2a, 0xda, 0x10, 0xa5, 0xb5, 0xdd, 0x13, 0xe8, 0x97, 0x83, 0x8a, 0xdd, 0x82,
-	0xa1, 0xde, 0x15, 0x21, 0xa7, 0x85, 0x61, 0xd3, 0x38, 0x72, 0x2c, 0x3c, 0x22, 0x8e, 0x1c, 0xa7,
-	0x8d, 0x5f, 0x38, 0x57, 0x1c, 0x9b, 0x8e, 0xbd, 0x88, 0x7d, 0xa7, 0x83, 0x86, 0xd4, 0x9f, 0x9c,
-	0x60, 0xf7, 0x19, 0xac, 0xd1, 0xe7, 0x09, 0x5e, 0xdb, 0x86, 0xf6, 0xa7, 0x11, 0xa7, 0x85, 0x99,
-	0xc3, 0x28, 0x95, 0xb5, 0x85, 0x19, 0xa0, 0x03, 0x28, 0xb9, 0x8d, 0x21, 0xa8, 0x6c, 0x28, 0xc0,
-	0xc6, 0xf8, 0xca, 0xc6, 0xc2, 0xee, 0xc0, 0xad, 0x32, 0x2b, 0x1a, 0x52, 0x0e, 0x8f, 0x84, 0x54,
-	0x80, 0x63, 0x91, 0xff, 0x4a, 0x6c, 0x63, 0x22, 0x3d, 0x31, 0x4f, 0x2e, 0x85, 0x46, 0xec, 0xdd,
-	0x47, 0xd0, 0x2f, 0xab, 0xcb, 0x70, 0x58, 0x42, 0x95, 0x43, 0x05, 0x38, 0x56, 0xed, 0x41, 0x23,
-	0xed, 0xdd, 0x47, 0x34, 0x55, 0x90, 0x9c, 0xc6, 0x09, 0x35, 0xa2, 0xc9, 0x70, 0x11, 0xa6, 0xfa,
-	0xaa, 0x44, 0x1a, 0x71, 0xbf, 0xa2, 0xc3, 0xa5, 0xc8, 0xa4, 0x63, 0xef, 0x7f, 0x65, 0x43, 0x4f,
-	0x11, 0x8e, 0x3d, 0x82, 0xa1, 0xf1, 0xe7, 0x11, 0xfb, 0x08, 0xa9, 0x7f, 0xf3, 0xaf, 0xae, 0xad,
-	0x6f, 0xdc, 0xc0, 0x15, 0x4b, 0xdd, 0x16, 0xfb, 0x29, 0x40, 0x3d, 0x20, 0xd8, 0x5d, 0x9a, 0x9a,
-	0xd7, 0x07, 0xc6, 0xd6, 0x98, 0x7e, 0x5a, 0x2c, 0xf9, 0x63, 0xcc, 0x6d, 0xb1, 0x5f, 0xc0, 0xba,
-	0xee, 0x05, 0x2a, 0x49, 0x6c, 0x62, 0xb4, 0x87, 0x25, 0xad, 0xff, 0x9d, 0xce, 0x3e, 0xaf, 0x9c,
-	0xa9, 0x7c, 0xb1, 0xf1, 0x92, 0x5e, 0xa3, 0xdc, 0x7c, 0x73, 0x65, 0x17, 0x72, 0x5b, 0xec, 0x08,
-	0x86, 0xaa, 0x57, 0xa8, 0x49, 0x7e, 0x0f, 0x6d, 0x57, 0x35, 0x8f, 0x77, 0x06, 0x74, 0x00, 0x23,
-	0xb3, 0xbc, 0x19, 0x65, 0x72, 0x49, 0x1f, 0x50, 0x4e, 0x96, 0x75, 0x02, 0xb7, 0xf5, 0x78, 0xfc,
-	0xf7, 0x37, 0x13, 0xeb, 0xeb, 0x37, 0x13, 0xeb, 0xdf, 0x6f, 0x26, 0xd6, 0xef, 0xde, 0x4e, 0x5a,
-	0x5f, 0xbf, 0x9d, 0xb4, 0xfe, 0xf9, 0x76, 0xd2, 0x9a, 0xf6, 0xe8, 0x4f, 0xca, 0x1f, 0xfc, 0x37,
-	0x00, 0x00, 0xff, 0xff, 0xd6, 0xde, 0xda, 0x8c, 0xb6, 0x14, 0x00, 0x00,
+	// 2514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcf, 0x73, 0xe4, 0x46,
+	0xf5, 0x1f, 0xcd, 0x2f, 0xcf, 0xbc, 0x19, 0xdb, 0xda, 0xb6, 0x37, 0xd1, 0xd7, 0xdf, 0x60, 0x5c,
+	0x4a, 0x2a, 0x18, 0x1f, 0x5c, 0xc4, 0x84, 0x0a, 0x95, 0x2a, 0x48, 0xb2, 0xe3, 0x8d, 0x37, 0xc1,
+	0x66, 0x37, 0xb2, 0x37, 0x1c, 0x29, 0x8d, 0xd4, 0x33, 0x56, 0x59, 0x23, 0x69, 0xd5, 0x2d, 0x9b,
+	0xa1, 0x8a, 0x33, 0x47, 0xb8, 0x70, 0xa0, 0x8a, 0x2b, 0x54, 0x71, 0xc9, 0x5f, 0xc0, 0x99, 0xe2,
+	0x98, 0xe2, 0x44, 0x71, 0xa2, 0x36, 0x27, 0xfe, 0x0b, 0xea, 0xbd, 0x6e, 0x49, 0x2d, 0x7b, 0xbc,
+	0x21, 0x07, 0x6e, 0xf3, 0x3e, 0xef, 0xf5, 0xeb, 0xd7, 0xef, 0x67, 0xab, 0x07, 0x36, 0xc2, 0xc5,
+	0x4d, 0x9a, 0x5f, 0xf1, 0xfc, 0x30, 0xcb, 0x53, 0x99, 0xb2, 0x76, 0x36, 0x75, 0xf7, 0x81, 0x7d,
+	0x56, 0xf0, 0x7c, 0x79, 0x2e, 0x7d, 0x59, 0x08, 0x8f, 0xbf, 0x28, 0xb8, 0x90, 0x8c, 0x41, 0x37,
+	0xf1, 0x17, 0xdc, 0xb1, 0xf6, 0xac, 0xfd, 0xa1, 0x47, 0xbf, 0xdd, 0x0c, 0xb6, 0x27, 0xe9, 0x62,
+	0x91, 0x26, 0x3f, 0x23, 0x1d, 0x1e, 0x17, 0x59, 0x9a, 0x08, 0xce, 0x5e, 0x83, 0x7e, 0xce, 0x45,
+	0x11, 0x4b, 0x92, 0x1e, 0x78, 0x9a, 0x62, 0x36, 0x74, 0x16, 0x62, 0xee, 0xb4, 0x49, 0x05, 0xfe,
+	0x44, 0x49, 0x91, 0x16, 0x79, 0xc0, 0x9d, 0x0e, 0x81, 0x9a, 0x42, 0x5c, 0xd9, 0xe5, 0x74, 0x15,
+	0xae, 0x28, 0xf7, 0x0b, 0x0b, 0xb6, 0x1a, 0xc6, 0x7d, 0xe3, 0x1d, 0xdf, 0x85, 0xb1, 0xda, 0x43,
+	0x69, 0xa0, 0x7d, 0x47, 0x47, 0xf6, 0x61, 0x36, 0x3d, 0x3c, 0x37, 0x70, 0xaf, 0x21, 0xc5, 0xde,
+	0x83, 0x75, 0x51, 0x4c, 0x2f, 0x7c, 0x71, 0xa5, 0x97, 0x75, 0xf7, 0x3a, 0xfb, 0xa3, 0xa3, 0x07,
+	0xb4, 0xcc, 0x64, 0x78, 0x4d, 0x39, 0xf7, 0x8f, 0x16, 0x8c, 0x26, 0x97, 0x3c, 0xd0, 0x34, 0x1a,
+	0x9a, 0xf9, 0x42, 0xf0, 0xb0, 0x34, 0x54, 0x51, 0x6c, 0x1b, 0x7a, 0x32, 0x95, 0x7e, 0x4c, 0xa6,
+	0xf6, 0x3c, 0x45, 0xb0, 0x5d, 0x00, 0x51, 0x04, 0x01, 0x17, 0x62, 0x56, 0xc4, 0x64, 0x6a, 0xcf,
+	0x33, 0x10, 0xd4, 0x36, 0xf3, 0xa3, 0x98, 0x87, 0xe4, 0xa6, 0x9e, 0xa7, 0x29, 0xe6, 0xc0, 0xda,
+	0x8d, 0x9f, 0x27, 0x51, 0x32, 0x77, 0x7a, 0xc4, 0x28, 0x49, 0x5c, 0x11, 0x72, 0xe9, 0x47, 0xb1,
+	0xd3, 0xdf, 0xb3, 0xf6, 0xc7, 0x9e, 0xa6, 0xdc, 0x31, 0xc0, 0x71, 0xb1, 0xc8, 0xb4, 0xd5, 0x7f,
+	0x6a, 0x03, 0x9c, 0xa6, 0x7e, 0xa8, 0x8d, 0x7e, 0x0b, 0xd6, 0x67, 0x51, 0x12, 0x89, 0x4b, 0x1e,
+	0x3e, 0x5a, 0x4a, 0x2e, 0xc8, 0xf6, 0x8e, 0xd7, 0x04, 0xd1, 0x58, 0xb2, 0x5a, 0x89, 0xb4, 0x49,
+	0xc4, 0x40, 0xd8, 0x0e, 0x0c, 0xb2, 0x3c, 0x9d, 0xe7, 0x5c, 0x08, 0x1d, 0xed, 0x8a, 0xc6, 0xb5,
+	0x0b, 0x2e, 0xfd, 0x47, 0x51, 0x12, 0xa7, 0x73, 0x1d, 0x73, 0x03, 0x61, 0x6f, 0xc3, 0x46, 0x4d,
+	0x9d, 0x5c, 0x7c, 0x72, 0x4c, 0xe7, 0x1a, 0x7a, 0xb7, 0x50, 0x94, 0x2b, 0x8d, 0xba, 0xf0, 0xa7,
+	0x31, 0x17, 0x74, 0xcc, 0x8e, 0x77, 0x0b, 0xc5, 0x13, 0x61, 0x86, 0x2c, 0x2a, 0xb1, 0x35, 0x75,
+	0xa2, 0x06, 0xc8, 0xf6, 0x60, 0x34, 0xcb, 0xb9, 0xb8, 0xd4, 0x32, 0x03, 0x92, 0x31, 0x21, 0xf7,
+	0x77, 0x16, 0xac, 0x9f, 0x5f, 0xfa, 0x79, 0x18, 0x25, 0xf3, 0x93, 0x3c, 0x2d, 0x32, 0x74, 0xb0,
+	0xf4, 0xf3, 0x39, 0x97, 0xba, 0x52, 0x34, 0x85, 0xf5, 0x73, 0x7c, 0x7c, 0x8a, 0x7e, 0xe9, 0x60,
+	0xfd, 0xe0, 0x6f, 0xe5, 0xd7, 0x5c, 0xc8, 0xd3, 0x34, 0xf0, 0x65, 0x94, 0x26, 0xda, 0x2d, 0x4d,
+	0x90, 0x6a, 0x64, 0x99, 0x04, 0x14, 0xe4, 0x0e, 0xd5, 0x08, 0x51, 0xe8, 0xcf, 0x22, 0xd1, 0x9c,
+	0x1e, 0x71, 0x2a, 0xda, 0xfd, 0x4b, 0x1f, 0xe0, 0x7c, 0x99, 0x04, 0x3a, 0x80, 0x7b, 0x30, 0xa2,
+	0x40, 0x3c, 0xbe, 0xe6, 0x89, 0x2c, 0xc3, 0x67, 0x42, 0xa8, 0x8c, 0xc8, 0x8b, 0xac, 0x0c, 0x5d,
+	0x45, 0xb3, 0x37, 0x60, 0x98, 0xf3, 0x80, 0x27, 0x12, 0x99, 0x1d, 0x62, 0xd6, 0x00, 0x73, 0x61,
+	0xbc, 0xf0, 0x85, 0xe4, 0x79, 0x23, 0x78, 0x0d, 0x8c, 0x1d, 0x80, 0x6d, 0xd2, 0x27, 0x32, 0x0a,
+	0x75, 0x00, 0xef, 0xe0, 0xa8, 0x8f, 0x0e, 0x51, 0xea, 0xeb, 0x2b, 0x7d, 0x26, 0x86, 0xfa, 0x4c,
+	0x9a, 0xf4, 0xad, 0x29, 0x7d, 0xb7, 0x71, 0xd4, 0x37, 0x8d, 0xd3, 0xe0, 0x2a, 0x4a, 0xe6, 0x14,
+	0x80, 0x01, 0xb9, 0xaa, 0x81, 0xb1, 0x1f, 0x81, 0x5d, 0x24, 0x39, 0x17, 0x69, 0x7c, 0xcd, 0x43,
+	0x8a, 0xa3, 0x70, 0x86, 0x46, 0x85, 0x9b, 0x11, 0xf6, 0xee, 0x88, 0x1a, 0x11, 0x02, 0x55, 0xd4,
+	0x8a, 0xc2, 0xac, 0x9e, 0x92, 0x21, 0x17, 0xcb, 0x8c, 0x3b, 0x23, 0x95, 0xd5, 0x35, 0x82, 0x8e,
+	0x9d, 0xfa, 0x32, 0xb8, 0x3c, 0x8f, 0x7e, 0xc9, 0x9d, 0x31, 0x15, 0x6a, 0x0d, 0xb0, 0x0f, 0xc0,
+	0x0e, 0xd2, 0xb8, 0x58, 0x24, 0x17, 0xb9, 0x9f, 0x88, 0x59, 0x9a, 0x2f, 0x84, 0xb3, 0x4e, 0x46,
+	0x6d, 0xa1, 0x51, 0x93, 0x26, 0xcf, 0xbb, 0x23, 0x8c, 0x31, 0x9d, 0xcb, 0x28, 0x3c, 0x4b, 0x43,
+	0xee, 0x6c, 0xa8, 0x82, 0x2b, 0x69, 0xdc, 0xfa, 0x26, 0x8f, 0x24, 0x27, 0xe6, 0x26, 0x31, 0x6b,
+	0x80, 0x1d, 0xc1, 0x36, 0x45, 0x7f, 0x92, 0x26, 0xb3, 0x38, 0x0a, 0xa4, 0xc7, 0x65, 0x1e, 0x71,
+	0xe1, 0xd8, 0x14, 0xfc, 0x95, 0x3c, 0xf6, 0x2e, 0x3c, 0x54, 0x49, 0x71, 0x7b, 0xd1, 0x03, 0x5a,
+	0xb4, 0x9a, 0xc9, 0x3e, 0x86, 0xd7, 0xc4, 0x55, 0x94, 0x65, 0x3c, 0x7c, 0x9e, 0x88, 0x22, 0xcb,
+	0xd2, 0x5c, 0xf2, 0x90, 0xe2, 0xc4, 0xe8, 0xa8, 0x1b, 0xe4, 0x7f, 0x25, 0x71, 0x7c, 0x7c, 0xea,
+	0xdd, 0x23, 0x8d, 0x19, 0x31, 0x2d, 0x66, 0x33, 0x9e, 0xf3, 0xf0, 0xd3, 0x74, 0x3a, 0x49, 0x8b,
+	0x44, 0x3a, 0x5b, 0xb4, 0xf1, 0x1d, 0x1c, 0xab, 0x41, 0xc8, 0x22, 0xb8, 0xd2, 0x09, 0xb6, 0x4d,
+	0xa7, 0x37, 0x21, 0x37, 0x07, 0xa8, 0xf7, 0xa4, 0xf0, 0x06, 0x97, 0x7c, 0xe1, 0x97, 0x25, 0xad,
+	0x28, 0xf4, 0xa1, 0x90, 0xbe, 0xe4, 0x0b, 0x9e, 0x48, 0x3d, 0x62, 0x6a, 0x00, 0xbd, 0x1f, 0x37,
+	0xeb, 0xba, 0xa2, 0xb1, 0x19, 0xc8, 0x68, 0xc1, 0xa9, 0x56, 0x3a, 0x1e, 0xfd, 0x76, 0x7f, 0x6d,
+	0xc1, 0xe6, 0xad, 0x98, 0x62, 0x83, 0x50, 0x7b, 0x3d, 0xf3, 0xa5, 0xe4, 0x79, 0xa2, 0x0d, 0x68,
+	0x82, 0x98, 0xe1, 0x12, 0xdb, 0x51, 0x29, 0xa4, 0x4c, 0x69, 0x60, 0x78, 0x06, 0x95, 0x1f, 0xe5,
+	0xa0, 0x55, 0x14, 0x5a, 0x32, 0x2b, 0x92, 0x40, 0x57, 0x2d, 0xfd, 0x76, 0xff, 0x60, 0xc1, 0xd8,
+	0x9c, 0x85, 0xc6, 0x94, 0xb6, 0xee, 0x99, 0xd2, 0x6d, 0x73, 0x4a, 0xb3, 0xef, 0x56, 0xd3, 0x58,
+	0x4d, 0x57, 0x2a, 0xa2, 0x67, 0x79, 0x8a, 0x63, 0xcb, 0x23, 0x46, 0x35, 0xa0, 0xdf, 0x81, 0x51,
+	0xce, 0x63, 0x7f, 0x59, 0x8d, 0x55, 0x94, 0xdf, 0x44, 0x79, 0xaf, 0x86, 0x3d, 0x53, 0xc6, 0xfd,
+	0x77, 0x1b, 0x46, 0x06, 0xf3, 0x4e, 0x03, 0xb2, 0xfe, 0xcb, 0x06, 0xd4, 0xbe, 0xa7, 0x01, 0xed,
+	0x95, 0x26, 0x15, 0xd3, 0xe3, 0x28, 0xd7, 0xfe, 0x32, 0xa1, 0x4a, 0xa2, 0xd1, 0xf1, 0x4c, 0x88,
+	0xed, 0xc3, 0xa6, 0x41, 0x1a, 0xfd, 0xee, 0x36, 0xcc, 0x0e, 0x81, 0x11, 0x34, 0xc1, 0xba, 0x7f,
+	0x9e, 0x9d, 0x91, 0x35, 0xd4, 0xf4, 0x06, 0xde, 0x0a, 0x0e, 0xfb, 0x36, 0xf4, 0x84, 0xf4, 0xe7,
+	0x9c, 0xfa, 0xdd, 0xc6, 0xd1, 0x90, 0xea, 0x03, 0x01, 0x4f, 0xe1, 0x86, 0xf3, 0x07, 0x5f, 0xe7,
+	0xfc, 0xea, 0xa4, 0x2a, 0xb8, 0x43, 0xf3, 0xa4, 0x04, 0xb9, 0x2f, 0x7b, 0xb0, 0xde, 0xb8, 0xdf,
+	0xac, 0xba, 0x07, 0xd6, 0x36, 0xb5, 0xef, 0xb1, 0x69, 0x0f, 0xba, 0x45, 0x12, 0xa9, 0x74, 0xd8,
+	0x38, 0x1a, 0x23, 0xff, 0x79, 0x12, 0x49, 0x6c, 0x82, 0x1e, 0x71, 0x0c, 0xab, 0xbb, 0x5f, 0x67,
+	0xf5, 0xf7, 0x60, 0xab, 0xee, 0xc0, 0xc7, 0xc7, 0xa7, 0xa7, 0x69, 0x70, 0x55, 0x5d, 0x08, 0x56,
+	0xb1, 0x18, 0x53, 0xb7, 0x40, 0x9a, 0x24, 0x4f, 0x5a, 0xea, 0x1e, 0xf8, 0x1d, 0xe8, 0x05, 0x78,
+	0x2f, 0x73, 0xd6, 0xea, 0x94, 0x33, 0x2e, 0x6a, 0x4f, 0x5a, 0x9e, 0xe2, 0xb3, 0xb7, 0xa0, 0x1b,
+	0x16, 0x8b, 0x4c, 0x7b, 0x93, 0xfa, 0x51, 0x7d, 0x53, 0x7a, 0xd2, 0xf2, 0x88, 0x8b, 0x52, 0x71,
+	0xea, 0x87, 0xce, 0xb0, 0x96, 0xaa, 0x2f, 0x50, 0x28, 0x85, 0x5c, 0x94, 0xc2, 0xd1, 0xe0, 0x40,
+	0x2d, 0x55, 0x4f, 0x69, 0x94, 0x42, 0x2e, 0x5e, 0x36, 0xf1, 0x0c, 0x18, 0x80, 0xe7, 0xc2, 0x9f,
+	0xab, 0xc9, 0xa1, 0x5d, 0xe2, 0x99, 0x0c, 0xaf, 0x29, 0x87, 0xed, 0x62, 0xe1, 0xff, 0xe2, 0xa3,
+	0x38, 0x4e, 0x6f, 0x78, 0x78, 0xea, 0xcf, 0x69, 0xa6, 0x74, 0xbc, 0x26, 0x88, 0x77, 0xa4, 0x9c,
+	0x67, 0x71, 0xa4, 0x7a, 0x11, 0x8a, 0xad, 0xab, 0x3b, 0x52, 0x13, 0xc5, 0xec, 0xc0, 0xa3, 0x5d,
+	0x5c, 0xe6, 0xdc, 0x0f, 0x05, 0x4d, 0x90, 0x9e, 0x67, 0x42, 0xb8, 0x1f, 0x92, 0x93, 0xcb, 0x22,
+	0xb9, 0xf2, 0xd2, 0x1b, 0x41, 0x83, 0xa4, 0xeb, 0x35, 0x41, 0x94, 0x8a, 0x92, 0x33, 0x3f, 0x4a,
+	0x24, 0x4f, 0xfc, 0x24, 0xe0, 0x34, 0x45, 0x06, 0x5e, 0x13, 0x64, 0x9f, 0xc0, 0x16, 0xcf, 0xf3,
+	0x34, 0x9f, 0xc4, 0xbe, 0x10, 0xd1, 0x4c, 0xdb, 0x41, 0xc3, 0x63, 0x74, 0xf4, 0x3a, 0x1e, 0xfd,
+	0xf1, 0x5d, 0xb6, 0xb7, 0x6a, 0x0d, 0x6e, 0x98, 0xe5, 0x51, 0x9a, 0x47, 0x72, 0x49, 0x1c, 0x87,
+	0xa9, 0xae, 0xd9, 0x00, 0x1f, 0x0d, 0xa0, 0x2f, 0x54, 0x43, 0x29, 0x60, 0x6b, 0x85, 0x6e, 0xbc,
+	0x92, 0x93, 0x76, 0x9d, 0xea, 0x8a, 0x40, 0x34, 0x20, 0xa5, 0xaa, 0x7d, 0x28, 0x02, 0x27, 0x7d,
+	0xa0, 0x57, 0xf3, 0xb2, 0x65, 0x18, 0xc8, 0xca, 0x86, 0xff, 0x02, 0xd6, 0x1b, 0xd1, 0x44, 0x25,
+	0xf3, 0x34, 0x4f, 0x0b, 0x19, 0x25, 0xd5, 0x1d, 0xdb, 0x40, 0x30, 0x20, 0x0b, 0xbe, 0x48, 0xf3,
+	0x65, 0x7d, 0xc3, 0xee, 0x7a, 0x26, 0x84, 0x1a, 0x84, 0xbf, 0xc8, 0x62, 0x7e, 0x81, 0x9b, 0xa9,
+	0xab, 0x9a, 0x81, 0xb8, 0x3f, 0x86, 0x07, 0x8d, 0x6a, 0x3e, 0x8d, 0x04, 0x95, 0x9e, 0x72, 0x84,
+	0x63, 0xdd, 0xf7, 0x51, 0x53, 0x7a, 0x6a, 0x17, 0x80, 0x6a, 0x84, 0xdc, 0x55, 0x7e, 0x5c, 0x59,
+	0xd5, 0xc7, 0x95, 0xfb, 0x2d, 0x18, 0x62, 0x6d, 0xbc, 0x82, 0x8d, 0x45, 0x71, 0x1f, 0x3b, 0x83,
+	0x31, 0x55, 0xc3, 0x67, 0xa7, 0xf7, 0x48, 0xe0, 0xbd, 0x44, 0x7d, 0xe1, 0xa8, 0x06, 0xfa, 0x2c,
+	0x15, 0x11, 0x65, 0x89, 0x8a, 0xc5, 0x4a, 0x1e, 0xce, 0x61, 0x8a, 0xdc, 0xf9, 0x67, 0xa7, 0xe5,
+	0x1c, 0x2e, 0x69, 0xf7, 0x07, 0x30, 0xc4, 0x1d, 0xd5, 0x76, 0xfb, 0xd0, 0x27, 0x46, 0xe9, 0x07,
+	0xbb, 0x2a, 0x4f, 0x6d, 0x90, 0xa7, 0xf9, 0xee, 0x6f, 0x2c, 0x18, 0xa9, 0x06, 0xa9, 0x56, 0x7e,
+	0xd3, 0xf9, 0xb8, 0xd7, 0x58, 0x5e, 0x4e, 0x18, 0x53, 0xe3, 0x21, 0x00, 0x8d, 0x38, 0x25, 0xd0,
+	0xad, 0xdb, 0x45, 0x8d, 0x7a, 0x86, 0x04, 0x06, 0xa6, 0xa6, 0x56, 0xb8, 0xf6, 0xf7, 0x6d, 0x18,
+	0xeb, 0x90, 0x2a, 0x91, 0xff, 0x51, 0x1b, 0xd7, 0x9d, 0xb6, 0x6b, 0x76, 0xda, 0xb7, 0xcb, 0x4e,
+	0xdb, 0xab, 0x8f, 0x51, 0x67, 0x51, 0xdd, 0x68, 0xdf, 0xd4, 0x8d, 0xb6, 0x4f, 0x62, 0xeb, 0x65,
+	0xa3, 0x2d, 0xa5, 0x88, 0x89, 0x42, 0xd4, 0x67, 0xd7, 0x6a, 0xa1, 0x2a, 0xa5, 0xaa, 0x36, 0xfb,
+	0xa6, 0x6e, 0xb3, 0x83, 0x5a, 0xa8, 0x0a, 0x73, 0xd9, 0x65, 0x1f, 0xad, 0xe9, 0xf2, 0x76, 0xdf,
+	0x07, 0xdb, 0x74, 0x0d, 0xd5, 0xc4, 0xdb, 0x75, 0xed, 0xd7, 0xa9, 0x60, 0x08, 0xe9, 0x6e, 0x80,
+	0x35, 0xdc, 0x18, 0x52, 0x58, 0x81, 0x91, 0x98, 0x60, 0x47, 0x8b, 0xab, 0x6f, 0x7c, 0x03, 0x31,
+	0x92, 0xac, 0x5d, 0x6b, 0xd6, 0x2a, 0x1a, 0x49, 0x66, 0x7c, 0xa9, 0x77, 0x1a, 0x5f, 0xea, 0x7f,
+	0xb7, 0x60, 0x6c, 0x2e, 0xc0, 0x8f, 0xfd, 0xc7, 0x79, 0x3e, 0xc1, 0x8b, 0xbc, 0xa5, 0x3e, 0xf6,
+	0x35, 0x89, 0xa9, 0x8f, 0x3f, 0x8d, 0x76, 0x55, 0xd1, 0x9a, 0x77, 0x1e, 0xa4, 0x59, 0xf9, 0xf6,
+	0x52, 0xd1, 0x9a, 0x77, 0xca, 0xaf, 0x79, 0xac, 0x2f, 0x37, 0x15, 0x8d, 0xbb, 0x9d, 0x71, 0x41,
+	0x63, 0x49, 0x4d, 0xdc, 0x92, 0xc4, 0x55, 0x9e, 0x7f, 0x33, 0xf1, 0x0b, 0xc1, 0xf5, 0x47, 0x5b,
+	0x45, 0xa3, 0x5b, 0xf0, 0x8d, 0xc8, 0xcf, 0xd3, 0x22, 0x29, 0x3f, 0xd5, 0x0c, 0xc4, 0xfd, 0xb3,
+	0x05, 0x0f, 0x9e, 0x15, 0xf9, 0x9c, 0x53, 0x16, 0x97, 0x6f, 0x4e, 0x3b, 0x30, 0x88, 0x12, 0x3f,
+	0x90, 0xd1, 0x35, 0xd7, 0xae, 0xac, 0xe8, 0xaa, 0xa3, 0xb6, 0xeb, 0x8e, 0x8a, 0xf2, 0xb3, 0x28,
+	0xe6, 0x94, 0xd8, 0xfa, 0x4c, 0x25, 0x4d, 0x35, 0xaa, 0x2e, 0x74, 0xfa, 0x45, 0x49, 0x51, 0xe4,
+	0xe6, 0x7c, 0xe9, 0x15, 0x09, 0x1d, 0x67, 0xe0, 0x69, 0x0a, 0xcf, 0x89, 0x1f, 0x4b, 0xe7, 0x5c,
+	0xea, 0xc3, 0x94, 0xa4, 0xfb, 0x4f, 0x0b, 0x76, 0x9e, 0x66, 0x3c, 0xf7, 0x25, 0x57, 0xef, 0x5e,
+	0xe7, 0x74, 0x1b, 0x2f, 0x8d, 0x7e, 0x03, 0xda, 0x69, 0xe6, 0x58, 0x75, 0x89, 0x28, 0xf6, 0xd3,
+	0xcc, 0x6b, 0xa7, 0x19, 0x99, 0xed, 0x8b, 0x2b, 0x1d, 0x0e, 0xfa, 0x7d, 0xef, 0x23, 0xd8, 0x0e,
+	0x0c, 0x42, 0x5f, 0xfa, 0x53, 0x5f, 0xf0, 0x32, 0x0c, 0x25, 0x4d, 0xef, 0x45, 0x78, 0xbf, 0xd7,
+	0x41, 0x50, 0x84, 0xf1, 0xa5, 0xd2, 0x6f, 0x7c, 0xa9, 0x6c, 0x43, 0x6f, 0x16, 0x17, 0xe2, 0x92,
+	0x3c, 0x3f, 0xf0, 0x14, 0x81, 0xb6, 0x54, 0x65, 0x32, 0x50, 0x55, 0xe1, 0x4a, 0x58, 0xff, 0xfc,
+	0x1d, 0x9d, 0xe9, 0x67, 0x5c, 0xfa, 0x6c, 0xc7, 0x38, 0x0e, 0xe0, 0x71, 0x90, 0xa3, 0x0f, 0xf3,
+	0xb5, 0x0d, 0xa3, 0xec, 0x32, 0x1d, 0xa3, 0xcb, 0x94, 0x1e, 0xe8, 0x52, 0x56, 0xd3, 0x6f, 0xf7,
+	0x5d, 0xd8, 0xd6, 0x1e, 0xfd, 0xfc, 0x1d, 0xdc, 0xf5, 0x5e, 0x5f, 0x2a, 0xb6, 0xda, 0xde, 0xfd,
+	0xab, 0x05, 0x0f, 0x6f, 0x2d, 0xfb, 0xc6, 0xcf, 0x81, 0xef, 0x41, 0x17, 0x9f, 0x90, 0x9c, 0x0e,
+	0x55, 0xe3, 0x9b, 0xb8, 0xc7, 0x4a, 0x95, 0x87, 0x48, 0x3c, 0x4e, 0x64, 0xbe, 0xf4, 0x68, 0xc1,
+	0xce, 0xa7, 0x30, 0xac, 0x20, 0xd4, 0x7b, 0xc5, 0x97, 0x65, 0xc3, 0xbd, 0xe2, 0x4b, 0xbc, 0x5e,
+	0x5e, 0xfb, 0x71, 0xa1, 0x5c, 0xa3, 0x67, 0x6a, 0xc3, 0xb1, 0x9e, 0xe2, 0xbf, 0xdf, 0xfe, 0xa1,
+	0xe5, 0xfe, 0x0a, 0x9c, 0x27, 0x7e, 0x12, 0xc6, 0x3a, 0x9f, 0x54, 0x1f, 0xd0, 0x2e, 0xf8, 0x7f,
+	0xc3, 0x05, 0xa3, 0xea, 0x1a, 0xf4, 0x8a, 0x6c, 0xc2, 0x47, 0x85, 0x72, 0x02, 0x6a, 0xc7, 0xd7,
+	0x00, 0xc5, 0xfc, 0x45, 0x2c, 0xf4, 0x53, 0x12, 0xfd, 0x76, 0x1f, 0xc2, 0xd6, 0x09, 0x97, 0x6a,
+	0xef, 0xc9, 0x6c, 0xae, 0x77, 0x76, 0xf7, 0x61, 0xbb, 0x09, 0x6b, 0xe7, 0xda, 0xd0, 0x09, 0x66,
+	0xd5, 0x74, 0x09, 0x66, 0xf3, 0x83, 0x9f, 0x43, 0x5f, 0x65, 0x05, 0x5b, 0x87, 0xe1, 0x27, 0xc9,
+	0xb5, 0x1f, 0x47, 0xe1, 0xd3, 0xcc, 0x6e, 0xb1, 0x01, 0x74, 0xcf, 0x65, 0x9a, 0xd9, 0x16, 0x1b,
+	0x42, 0xef, 0x19, 0x76, 0x02, 0xbb, 0xcd, 0x00, 0xfa, 0x1e, 0x3d, 0xb3, 0xd9, 0x1d, 0x84, 0xcf,
+	0xa5, 0x9f, 0x4b, 0xbb, 0x8b, 0xf0, 0xf3, 0x2c, 0xf4, 0x25, 0xb7, 0x7b, 0x6c, 0x03, 0xe0, 0xa3,
+	0x42, 0xa6, 0x5a, 0xac, 0x7f, 0xf0, 0x82, 0xc4, 0xe6, 0xb8, 0xf7, 0x58, 0xeb, 0x27, 0xda, 0x6e,
+	0xb1, 0x35, 0xe8, 0xfc, 0x94, 0xdf, 0xd8, 0x16, 0x1b, 0xc1, 0x9a, 0x57, 0x24, 0xf8, 0xc8, 0xa9,
+	0xf6, 0xa0, 0xed, 0x42, 0xbb, 0x83, 0x0c, 0x34, 0x22, 0xe3, 0xa1, 0xdd, 0x65, 0x63, 0x18, 0x7c,
+	0xac, 0x9f, 0x02, 0xed, 0x1e, 0xb2, 0x50, 0x0c, 0xd7, 0xf4, 0x91, 0x45, 0x1b, 0x22, 0xb5, 0x76,
+	0xf0, 0x14, 0x06, 0xe5, 0x6c, 0x63, 0x9b, 0x30, 0xd2, 0xbb, 0x22, 0x64, 0xb7, 0xd0, 0x6c, 0x9a,
+	0x60, 0xb6, 0x85, 0x47, 0xc4, 0x29, 0x65, 0xb7, 0xf1, 0x17, 0x8e, 0x22, 0xbb, 0x43, 0xc7, 0x5e,
+	0x26, 0x81, 0xdd, 0x45, 0x41, 0xea, 0x68, 0x76, 0x78, 0x70, 0x06, 0x6b, 0xf4, 0xf3, 0x29, 0x86,
+	0x6d, 0x43, 0xeb, 0xd3, 0x88, 0xdd, 0x42, 0xcf, 0xa1, 0x95, 0x4a, 0xda, 0x42, 0x0f, 0xd0, 0x01,
+	0x14, 0xdd, 0x46, 0x13, 0x94, 0x37, 0x14, 0xd0, 0x41, 0xfb, 0xca, 0xc6, 0xc2, 0xb6, 0x60, 0xb3,
+	0xf4, 0x8a, 0x86, 0x94, 0xc2, 0x13, 0x2e, 0x15, 0x60, 0x5b, 0xa4, 0xbf, 0x22, 0xdb, 0xe8, 0x48,
+	0x8f, 0x2f, 0xd2, 0x6b, 0xae, 0x91, 0xce, 0xc1, 0x87, 0x30, 0x28, 0xab, 0xcb, 0x50, 0x58, 0x42,
+	0x95, 0x42, 0x05, 0xd8, 0x56, 0xad, 0x41, 0x23, 0xed, 0x83, 0x0f, 0x61, 0x4d, 0x27, 0xa7, 0x71,
+	0x42, 0x8d, 0xe8, 0x64, 0xb8, 0x8a, 0x32, 0x1d, 0x2a, 0x9e, 0xc5, 0x7e, 0x50, 0xa5, 0xc3, 0x35,
+	0xcf, 0xa5, 0xdd, 0x39, 0xfa, 0xa2, 0x03, 0x7d, 0x95, 0x70, 0xec, 0x43, 0x18, 0x19, 0x0f, 0xfd,
+	0xec, 0x35, 0x4c, 0xfd, 0xbb, 0x7f, 0x4b, 0xec, 0xbc, 0x7e, 0x07, 0x57, 0x59, 0xea, 0xb6, 0xd8,
+	0x07, 0x00, 0xf5, 0x48, 0x61, 0x0f, 0x69, 0xd0, 0xde, 0x1e, 0x31, 0x3b, 0x8e, 0x7a, 0x4a, 0xbb,
+	0xfb, 0x27, 0x86, 0xdb, 0x62, 0x3f, 0x81, 0x75, 0xdd, 0x0b, 0x94, 0x93, 0xd8, 0xae, 0xd1, 0x1e,
+	0x56, 0xb4, 0xfe, 0x57, 0x2a, 0xfb, 0xb8, 0x52, 0xa6, 0xfc, 0xc5, 0x9c, 0x15, 0xbd, 0x46, 0xa9,
+	0xf9, 0xbf, 0x7b, 0xbb, 0x90, 0xdb, 0x62, 0x27, 0x30, 0x52, 0xbd, 0x42, 0x0d, 0xff, 0x37, 0x50,
+	0xf6, 0xbe, 0xe6, 0xf1, 0x4a, 0x83, 0x26, 0x30, 0x36, 0xcb, 0x9b, 0x91, 0x27, 0x57, 0xf4, 0x81,
+	0x1d, 0xe7, 0x2e, 0xa3, 0x54, 0xf2, 0xc8, 0xf9, 0xdb, 0xcb, 0x5d, 0xeb, 0xcb, 0x97, 0xbb, 0xd6,
+	0xbf, 0x5e, 0xee, 0x5a, 0xbf, 0xfd, 0x6a, 0xb7, 0xf5, 0xe5, 0x57, 0xbb, 0xad, 0x7f, 0x7c, 0xb5,
+	0xdb, 0x9a, 0xf6, 0xe9, 0x0f, 0xa5, 0xef, 0xff, 0x67, 0x00, 0xb7, 0x09, 0xd3, 0x12, 0x62, 0x1a,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3173,6 +3677,21 @@ func (m *LoadStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.FreshTables != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.FreshTables))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ResumedTables != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.ResumedTables))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.FinishedTables != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.FinishedTables))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.MetaBinlogGTID) > 0 {
 		i -= len(m.MetaBinlogGTID)
 		copy(dAtA[i:], m.MetaBinlogGTID)
@@ -3291,6 +3810,85 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.StuckBinlog) > 0 {
+		i -= len(m.StuckBinlog)
+		copy(dAtA[i:], m.StuckBinlog)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.StuckBinlog)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.BufferedJobCount != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.BufferedJobCount))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.SkippedUnsupportedDDLs) > 0 {
+		for iNdEx := len(m.SkippedUnsupportedDDLs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SkippedUnsupportedDDLs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDmworker(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if m.RecentConflictRetries != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.RecentConflictRetries))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.TotalConflictRetries != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.TotalConflictRetries))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.WriteMode) > 0 {
+		i -= len(m.WriteMode)
+		copy(dAtA[i:], m.WriteMode)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.WriteMode)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.GtidMode) > 0 {
+		i -= len(m.GtidMode)
+		copy(dAtA[i:], m.GtidMode)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.GtidMode)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.ColumnTransforms) > 0 {
+		for iNdEx := len(m.ColumnTransforms) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ColumnTransforms[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDmworker(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if m.BatchSize != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.BatchSize))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.BinlogType) > 0 {
 		i -= len(m.BinlogType)
 		copy(dAtA[i:], m.BinlogType)
@@ -3377,7 +3975,7 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
+func (m *SkippedDDL) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3387,58 +3985,46 @@ func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SourceStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *SkippedDDL) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SourceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SkippedDDL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.RelayStatus != nil {
-		{
-			size, err := m.RelayStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintDmworker(dAtA, i, uint64(size))
-		}
+	if m.Time != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Time))
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x20
 	}
-	if m.Result != nil {
-		{
-			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintDmworker(dAtA, i, uint64(size))
-		}
+	if len(m.Location) > 0 {
+		i -= len(m.Location)
+		copy(dAtA[i:], m.Location)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Location)))
 		i--
 		dAtA[i] = 0x1a
 	}
-	if len(m.Worker) > 0 {
-		i -= len(m.Worker)
-		copy(dAtA[i:], m.Worker)
-		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Worker)))
+	if len(m.Statement) > 0 {
+		i -= len(m.Statement)
+		copy(dAtA[i:], m.Statement)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Statement)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Source) > 0 {
-		i -= len(m.Source)
-		copy(dAtA[i:], m.Source)
-		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
+	if len(m.Schema) > 0 {
+		i -= len(m.Schema)
+		copy(dAtA[i:], m.Schema)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Schema)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RelayStatus) Marshal() (dAtA []byte, err error) {
+func (m *ColumnTransform) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3448,44 +4034,163 @@ func (m *RelayStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RelayStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *ColumnTransform) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RelayStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ColumnTransform) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Result != nil {
-		{
-			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintDmworker(dAtA, i, uint64(size))
-		}
+	if len(m.Func) > 0 {
+		i -= len(m.Func)
+		copy(dAtA[i:], m.Func)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Func)))
 		i--
-		dAtA[i] = 0x42
+		dAtA[i] = 0x22
 	}
-	if m.Stage != 0 {
-		i = encodeVarintDmworker(dAtA, i, uint64(m.Stage))
+	if len(m.Column) > 0 {
+		i -= len(m.Column)
+		copy(dAtA[i:], m.Column)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Column)))
 		i--
-		dAtA[i] = 0x38
+		dAtA[i] = 0x1a
 	}
-	if m.RelayCatchUpMaster {
+	if len(m.TablePattern) > 0 {
+		i -= len(m.TablePattern)
+		copy(dAtA[i:], m.TablePattern)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.TablePattern)))
 		i--
-		if m.RelayCatchUpMaster {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+		dAtA[i] = 0x12
+	}
+	if len(m.SchemaPattern) > 0 {
+		i -= len(m.SchemaPattern)
+		copy(dAtA[i:], m.SchemaPattern)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.SchemaPattern)))
 		i--
-		dAtA[i] = 0x30
+		dAtA[i] = 0xa
 	}
-	if len(m.RelayBinlogGtid) > 0 {
+	return len(dAtA) - i, nil
+}
+
+func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SourceStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SourceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RelayStatus != nil {
+		{
+			size, err := m.RelayStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Worker) > 0 {
+		i -= len(m.Worker)
+		copy(dAtA[i:], m.Worker)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Worker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RelayStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelayStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RelayStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RelaySource) > 0 {
+		i -= len(m.RelaySource)
+		copy(dAtA[i:], m.RelaySource)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.RelaySource)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Stage != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Stage))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.RelayCatchUpMaster {
+		i--
+		if m.RelayCatchUpMaster {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.RelayBinlogGtid) > 0 {
 		i -= len(m.RelayBinlogGtid)
 		copy(dAtA[i:], m.RelayBinlogGtid)
 		i = encodeVarintDmworker(dAtA, i, uint64(len(m.RelayBinlogGtid)))
@@ -3543,6 +4248,73 @@ func (m *SubTaskStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PriorityClass) > 0 {
+		i -= len(m.PriorityClass)
+		copy(dAtA[i:], m.PriorityClass)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.PriorityClass)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.ErrorClassification != nil {
+		{
+			size, err := m.ErrorClassification.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.InMaintenance {
+		i--
+		if m.InMaintenance {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.DumpChunkRows != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.DumpChunkRows))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.DumpThreads != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.DumpThreads))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.ReplicationLag != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.ReplicationLag))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.MaxAllowedLag != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.MaxAllowedLag))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.ResourceUsage != nil {
+		{
+			size, err := m.ResourceUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.Status != nil {
 		{
 			size := m.Status.Size()
@@ -3689,6 +4461,93 @@ func (m *SubTaskStatus_Sync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *ErrorClassification) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorClassification) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ErrorClassification) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Classifier) > 0 {
+		i -= len(m.Classifier)
+		copy(dAtA[i:], m.Classifier)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Classifier)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Class) > 0 {
+		i -= len(m.Class)
+		copy(dAtA[i:], m.Class)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Class)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SampleTime != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.SampleTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MemoryBytes != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.MemoryBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Goroutines != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.Goroutines))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SubTaskStatusList) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4311,6 +5170,23 @@ func (m *PurgeRelayRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.GtidSet) > 0 {
+		i -= len(m.GtidSet)
+		copy(dAtA[i:], m.GtidSet)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.GtidSet)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
 	if len(m.SubDir) > 0 {
 		i -= len(m.SubDir)
 		copy(dAtA[i:], m.SubDir)
@@ -4806,6 +5682,15 @@ func (m *LoadStatus) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	if m.FinishedTables != 0 {
+		n += 1 + sovDmworker(uint64(m.FinishedTables))
+	}
+	if m.ResumedTables != 0 {
+		n += 1 + sovDmworker(uint64(m.ResumedTables))
+	}
+	if m.FreshTables != 0 {
+		n += 1 + sovDmworker(uint64(m.FreshTables))
+	}
 	return n
 }
 
@@ -4894,24 +5779,109 @@ func (m *SyncStatus) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	if m.BatchSize != 0 {
+		n += 1 + sovDmworker(uint64(m.BatchSize))
+	}
+	if len(m.ColumnTransforms) > 0 {
+		for _, e := range m.ColumnTransforms {
+			l = e.Size()
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	l = len(m.GtidMode)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.WriteMode)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.TotalConflictRetries != 0 {
+		n += 2 + sovDmworker(uint64(m.TotalConflictRetries))
+	}
+	if m.RecentConflictRetries != 0 {
+		n += 2 + sovDmworker(uint64(m.RecentConflictRetries))
+	}
+	if len(m.SkippedUnsupportedDDLs) > 0 {
+		for _, e := range m.SkippedUnsupportedDDLs {
+			l = e.Size()
+			n += 2 + l + sovDmworker(uint64(l))
+		}
+	}
+	if m.BufferedJobCount != 0 {
+		n += 2 + sovDmworker(uint64(m.BufferedJobCount))
+	}
+	l = len(m.StuckBinlog)
+	if l > 0 {
+		n += 2 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
-func (m *SourceStatus) Size() (n int) {
+func (m *SkippedDDL) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Source)
+	l = len(m.Schema)
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
-	l = len(m.Worker)
+	l = len(m.Statement)
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
-	if m.Result != nil {
+	l = len(m.Location)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovDmworker(uint64(m.Time))
+	}
+	return n
+}
+
+func (m *ColumnTransform) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SchemaPattern)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.TablePattern)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Column)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Func)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	return n
+}
+
+func (m *SourceStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Worker)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.Result != nil {
 		l = m.Result.Size()
 		n += 1 + l + sovDmworker(uint64(l))
 	}
@@ -4958,6 +5928,10 @@ func (m *RelayStatus) Size() (n int) {
 		l = m.Result.Size()
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	l = len(m.RelaySource)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -4988,6 +5962,33 @@ func (m *SubTaskStatus) Size() (n int) {
 	if m.Status != nil {
 		n += m.Status.Size()
 	}
+	if m.ResourceUsage != nil {
+		l = m.ResourceUsage.Size()
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.MaxAllowedLag != 0 {
+		n += 1 + sovDmworker(uint64(m.MaxAllowedLag))
+	}
+	if m.ReplicationLag != 0 {
+		n += 1 + sovDmworker(uint64(m.ReplicationLag))
+	}
+	if m.DumpThreads != 0 {
+		n += 1 + sovDmworker(uint64(m.DumpThreads))
+	}
+	if m.DumpChunkRows != 0 {
+		n += 1 + sovDmworker(uint64(m.DumpChunkRows))
+	}
+	if m.InMaintenance {
+		n += 3
+	}
+	if m.ErrorClassification != nil {
+		l = m.ErrorClassification.Size()
+		n += 2 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.PriorityClass)
+	if l > 0 {
+		n += 2 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -5049,6 +6050,48 @@ func (m *SubTaskStatus_Sync) Size() (n int) {
 	}
 	return n
 }
+func (m *ErrorClassification) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Class)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Classifier)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovDmworker(uint64(m.Time))
+	}
+	return n
+}
+
+func (m *ResourceUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Goroutines != 0 {
+		n += 1 + sovDmworker(uint64(m.Goroutines))
+	}
+	if m.MemoryBytes != 0 {
+		n += 1 + sovDmworker(uint64(m.MemoryBytes))
+	}
+	if m.SampleTime != 0 {
+		n += 1 + sovDmworker(uint64(m.SampleTime))
+	}
+	return n
+}
+
 func (m *SubTaskStatusList) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5350,6 +6393,13 @@ func (m *PurgeRelayRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	if m.DryRun {
+		n += 2
+	}
+	l = len(m.GtidSet)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -6337,6 +7387,63 @@ func (m *LoadStatus) Unmarshal(dAtA []byte) error {
 			}
 			m.MetaBinlogGTID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedTables", wireType)
+			}
+			m.FinishedTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FinishedTables |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumedTables", wireType)
+			}
+			m.ResumedTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResumedTables |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FreshTables", wireType)
+			}
+			m.FreshTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FreshTables |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -6906,64 +8013,30 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 			}
 			m.BinlogType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDmworker(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthDmworker
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthDmworker
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SourceStatus) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDmworker
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchSize", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.BatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SourceStatus: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SourceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnTransforms", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -6973,27 +8046,29 @@ func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthDmworker
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Source = string(dAtA[iNdEx:postIndex])
+			m.ColumnTransforms = append(m.ColumnTransforms, &ColumnTransform{})
+			if err := m.ColumnTransforms[len(m.ColumnTransforms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Worker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GtidMode", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7021,13 +8096,13 @@ func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Worker = string(dAtA[iNdEx:postIndex])
+			m.GtidMode = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteMode", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7037,33 +8112,29 @@ func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDmworker
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Result == nil {
-				m.Result = &ProcessResult{}
-			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.WriteMode = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RelayStatus", wireType)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalConflictRetries", wireType)
 			}
-			var msglen int
+			m.TotalConflictRetries = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7073,28 +8144,115 @@ func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.TotalConflictRetries |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthDmworker
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthDmworker
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecentConflictRetries", wireType)
 			}
-			if m.RelayStatus == nil {
-				m.RelayStatus = &RelayStatus{}
+			m.RecentConflictRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RecentConflictRetries |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if err := m.RelayStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkippedUnsupportedDDLs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SkippedUnsupportedDDLs = append(m.SkippedUnsupportedDDLs, &SkippedDDL{})
+			if err := m.SkippedUnsupportedDDLs[len(m.SkippedUnsupportedDDLs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferedJobCount", wireType)
+			}
+			m.BufferedJobCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BufferedJobCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StuckBinlog", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StuckBinlog = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -7119,7 +8277,7 @@ func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RelayStatus) Unmarshal(dAtA []byte) error {
+func (m *SkippedDDL) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7142,15 +8300,15 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RelayStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: SkippedDDL: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RelayStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SkippedDDL: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlog", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7178,11 +8336,11 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MasterBinlog = string(dAtA[iNdEx:postIndex])
+			m.Schema = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlogGtid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Statement", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7210,11 +8368,11 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MasterBinlogGtid = string(dAtA[iNdEx:postIndex])
+			m.Statement = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RelaySubDir", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7242,13 +8400,13 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RelaySubDir = string(dAtA[iNdEx:postIndex])
+			m.Location = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RelayBinlog", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var stringLen uint64
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7258,27 +8416,67 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Time |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RelayBinlog = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ColumnTransform) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnTransform: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnTransform: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RelayBinlogGtid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaPattern", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7306,13 +8504,13 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RelayBinlogGtid = string(dAtA[iNdEx:postIndex])
+			m.SchemaPattern = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RelayCatchUpMaster", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TablePattern", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7322,17 +8520,29 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.RelayCatchUpMaster = bool(v != 0)
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
 			}
-			m.Stage = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TablePattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7342,16 +8552,29 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Stage |= Stage(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Column = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Func", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7361,27 +8584,23 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDmworker
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Result == nil {
-				m.Result = &ProcessResult{}
-			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Func = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7407,7 +8626,7 @@ func (m *RelayStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
+func (m *SourceStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7430,15 +8649,15 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubTaskStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: SourceStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubTaskStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SourceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7466,13 +8685,13 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Source = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Worker", wireType)
 			}
-			m.Stage = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7482,16 +8701,896 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Stage |= Stage(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Worker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.Unit = 0
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &ProcessResult{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RelayStatus == nil {
+				m.RelayStatus = &RelayStatus{}
+			}
+			if err := m.RelayStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RelayStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RelayStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RelayStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlog", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MasterBinlog = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlogGtid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MasterBinlogGtid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelaySubDir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelaySubDir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayBinlog", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelayBinlog = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayBinlogGtid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelayBinlogGtid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayCatchUpMaster", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RelayCatchUpMaster = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			}
+			m.Stage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Stage |= Stage(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &ProcessResult{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelaySource", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelaySource = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubTaskStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubTaskStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			}
+			m.Stage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Stage |= Stage(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			m.Unit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Unit |= UnitType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &ProcessResult{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnresolvedDDLLockID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnresolvedDDLLockID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = &SubTaskStatus_Msg{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Check", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CheckStatus{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Status = &SubTaskStatus_Check{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dump", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DumpStatus{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Status = &SubTaskStatus_Dump{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Load", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LoadStatus{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Status = &SubTaskStatus_Load{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SyncStatus{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Status = &SubTaskStatus_Sync{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceUsage == nil {
+				m.ResourceUsage = &ResourceUsage{}
+			}
+			if err := m.ResourceUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAllowedLag", wireType)
+			}
+			m.MaxAllowedLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAllowedLag |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationLag", wireType)
+			}
+			m.ReplicationLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationLag |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DumpThreads", wireType)
+			}
+			m.DumpThreads = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DumpThreads |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DumpChunkRows", wireType)
+			}
+			m.DumpChunkRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DumpChunkRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InMaintenance", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7501,14 +9600,15 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Unit |= UnitType(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			m.InMaintenance = bool(v != 0)
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorClassification", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7535,16 +9635,16 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Result == nil {
-				m.Result = &ProcessResult{}
+			if m.ErrorClassification == nil {
+				m.ErrorClassification = &ErrorClassification{}
 			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ErrorClassification.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnresolvedDDLLockID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PriorityClass", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7572,11 +9672,64 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UnresolvedDDLLockID = string(dAtA[iNdEx:postIndex])
+			m.PriorityClass = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ErrorClassification) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ErrorClassification: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ErrorClassification: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7604,13 +9757,13 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = &SubTaskStatus_Msg{string(dAtA[iNdEx:postIndex])}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Check", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Class", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7620,32 +9773,29 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDmworker
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CheckStatus{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Status = &SubTaskStatus_Check{v}
+			m.Class = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dump", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Classifier", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7655,32 +9805,29 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDmworker
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &DumpStatus{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Status = &SubTaskStatus_Dump{v}
+			m.Classifier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Load", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var msglen int
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7690,32 +9837,69 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Time |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthDmworker
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LoadStatus{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
 			}
-			m.Status = &SubTaskStatus_Load{v}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Goroutines", wireType)
+			}
+			m.Goroutines = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -7725,27 +9909,49 @@ func (m *SubTaskStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Goroutines |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthDmworker
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryBytes", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthDmworker
+			m.MemoryBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleTime", wireType)
 			}
-			v := &SyncStatus{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.SampleTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Status = &SubTaskStatus_Sync{v}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -9537,6 +11743,58 @@ func (m *PurgeRelayRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.SubDir = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GtidSet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GtidSet = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -0,0 +1,160 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/siddontang/go/sync2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+// auditBufferSize is the max number of audit events waiting to be sent to sinks.
+var auditBufferSize = 1024
+
+// AuditEvent represents a mutating operation on the worker.
+type AuditEvent struct {
+	Time   time.Time `json:"time"`
+	Caller string    `json:"caller"` // address of the caller, empty if not available
+	Action string    `json:"action"` // name of the operation, like `StartSubTask`
+	Args   string    `json:"args"`   // arguments of the operation, sensitive values are masked
+	Error  string    `json:"error"`  // empty if the operation succeeded
+}
+
+// AuditSink receives audit events of the worker.
+// events are sent to sinks in a separate goroutine, so a slow sink will not block the operations,
+// but events may be dropped if the sinks can't catch up.
+type AuditSink interface {
+	Emit(ev AuditEvent)
+}
+
+type auditor struct {
+	mu      sync.RWMutex
+	sinks   []AuditSink
+	eventCh chan AuditEvent
+	once    sync.Once
+	wg      sync.WaitGroup
+
+	dropped sync2.AtomicInt64
+	l       log.Logger
+}
+
+func newAuditor() *auditor {
+	return &auditor{
+		eventCh: make(chan AuditEvent, auditBufferSize),
+		l:       log.With(zap.String("component", "worker auditor")),
+	}
+}
+
+// register adds a sink, and starts to send events when the first sink is added.
+func (a *auditor) register(sink AuditSink) {
+	a.mu.Lock()
+	a.sinks = append(a.sinks, sink)
+	a.mu.Unlock()
+
+	a.once.Do(func() {
+		a.mu.RLock()
+		eventCh := a.eventCh
+		a.mu.RUnlock()
+		if eventCh == nil {
+			return // already closed
+		}
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.run(eventCh)
+		}()
+	})
+}
+
+func (a *auditor) run(eventCh <-chan AuditEvent) {
+	for ev := range eventCh {
+		a.mu.RLock()
+		sinks := a.sinks
+		a.mu.RUnlock()
+		for _, sink := range sinks {
+			sink.Emit(ev)
+		}
+	}
+}
+
+// emit sends an event to sinks without blocking, the event is dropped if the buffer is full.
+func (a *auditor) emit(ctx context.Context, action string, args string, err error) {
+	if a == nil {
+		return
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.sinks) == 0 || a.eventCh == nil {
+		return
+	}
+
+	ev := AuditEvent{
+		Time:   time.Now(),
+		Caller: auditCaller(ctx),
+		Action: action,
+		Args:   args,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	select {
+	case a.eventCh <- ev:
+	default:
+		a.dropped.Add(1)
+		a.l.Warn("audit event dropped", zap.String("action", action), zap.Int64("dropped", a.dropped.Get()))
+	}
+}
+
+// close stops sending events and waits for the buffered events sent.
+func (a *auditor) close() {
+	a.mu.Lock()
+	if a.eventCh != nil {
+		close(a.eventCh)
+		a.eventCh = nil
+	}
+	a.mu.Unlock()
+	a.wg.Wait()
+}
+
+// auditCaller gets the caller address from the gRPC context.
+func auditCaller(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// auditArgs marshals arguments of an operation, connection details are always masked.
+func auditArgs(args interface{}) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	level := config.GetRedactionLevel()
+	if level < config.RedactionConnection {
+		level = config.RedactionConnection
+	}
+	return string(config.RedactJSON(data, level))
+}
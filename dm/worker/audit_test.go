@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+type testAudit struct{}
+
+var _ = Suite(&testAudit{})
+
+type mockAuditSink struct {
+	sync.Mutex
+	events []AuditEvent
+	block  chan struct{}
+}
+
+func (s *mockAuditSink) Emit(ev AuditEvent) {
+	if s.block != nil {
+		<-s.block
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.events = append(s.events, ev)
+}
+
+func (t *testAudit) TestAuditor(c *C) {
+	a := newAuditor()
+	// no sink registered, nothing buffered
+	a.emit(context.Background(), "StartSubTask", "", nil)
+	c.Assert(a.eventCh, HasLen, 0)
+
+	sink := &mockAuditSink{}
+	a.register(sink)
+
+	cfg := &config.SubTaskConfig{
+		Name: "test-task",
+		From: config.DBConfig{Host: "upstream-host", User: "upstream-user", Password: "123456"},
+	}
+	a.emit(context.Background(), "StartSubTask", auditArgs(map[string]interface{}{"config": cfg}), nil)
+	a.emit(context.Background(), "OperateSubTask", auditArgs(map[string]interface{}{"task": "test-task"}), errors.New("sub task not found"))
+	a.close()
+	// emit after closed is ignored
+	a.emit(context.Background(), "PurgeRelay", "", nil)
+
+	c.Assert(sink.events, HasLen, 2)
+	c.Assert(sink.events[0].Action, Equals, "StartSubTask")
+	c.Assert(sink.events[0].Error, Equals, "")
+	c.Assert(sink.events[0].Args, Matches, ".*test-task.*")
+	c.Assert(strings.Contains(sink.events[0].Args, "upstream-host"), IsFalse)
+	c.Assert(strings.Contains(sink.events[0].Args, "123456"), IsFalse)
+	c.Assert(sink.events[1].Action, Equals, "OperateSubTask")
+	c.Assert(sink.events[1].Error, Equals, "sub task not found")
+	c.Assert(a.dropped.Get(), Equals, int64(0))
+}
+
+func (t *testAudit) TestAuditorDropEvents(c *C) {
+	oldSize := auditBufferSize
+	auditBufferSize = 1
+	defer func() {
+		auditBufferSize = oldSize
+	}()
+
+	a := newAuditor()
+	sink := &mockAuditSink{block: make(chan struct{})}
+	a.register(sink)
+
+	// the first event may be taken by the blocked sink, so at most two are buffered
+	for i := 0; i < 5; i++ {
+		a.emit(context.Background(), "PurgeRelay", "", nil)
+	}
+	c.Assert(a.dropped.Get() >= 3, IsTrue)
+	close(sink.block)
+	a.close()
+	c.Assert(int64(len(sink.events))+a.dropped.Get(), Equals, int64(5))
+}
@@ -38,6 +38,7 @@ var (
 	SampleConfigFile         string
 	defaultKeepAliveTTL      = int64(60)      // 1 minute
 	defaultRelayKeepAliveTTL = int64(60 * 30) // 30 minutes
+	defaultStatusConcurrency = 8
 )
 
 func init() {
@@ -67,6 +68,9 @@ func NewConfig() *Config {
 	fs.StringVar(&cfg.Name, "name", "", "human-readable name for DM-worker member")
 	fs.Int64Var(&cfg.KeepAliveTTL, "keepalive-ttl", defaultKeepAliveTTL, "dm-worker's TTL for keepalive with etcd (in seconds)")
 	fs.Int64Var(&cfg.RelayKeepAliveTTL, "relay-keepalive-ttl", defaultRelayKeepAliveTTL, "dm-worker's TTL for keepalive with etcd when handle relay enabled sources (in seconds)")
+	fs.BoolVar(&cfg.SeparateWatchClient, "separate-watch-client", false, "whether to use a dedicated etcd client for watching, so watch streams do not interfere with other requests")
+	fs.IntVar(&cfg.StatusConcurrency, "status-concurrency", defaultStatusConcurrency, "max number of subtasks collecting status concurrently")
+	fs.StringVar(&cfg.RelayFilesAddr, "relay-files-addr", "", "address to serve relay log files for DM-workers with `relay-source`, requires TLS client certificate authentication")
 
 	fs.StringVar(&cfg.SSLCA, "ssl-ca", "", "path of file that contains list of trusted SSL CAs for connection")
 	fs.StringVar(&cfg.SSLCert, "ssl-cert", "", "path of file that contains X509 certificate in PEM format for connection")
@@ -95,6 +99,16 @@ type Config struct {
 	KeepAliveTTL      int64 `toml:"keepalive-ttl" json:"keepalive-ttl"`
 	RelayKeepAliveTTL int64 `toml:"relay-keepalive-ttl" json:"relay-keepalive-ttl"`
 
+	// use a dedicated etcd client for long-lived watches
+	SeparateWatchClient bool `toml:"separate-watch-client" json:"separate-watch-client"`
+
+	// max number of subtasks collecting status concurrently
+	StatusConcurrency int `toml:"status-concurrency" json:"status-concurrency"`
+
+	// address to serve relay log files for the DM-workers whose `relay-source` is the current DM-worker,
+	// relay log files are not served if empty. TLS client certificate authentication must be configured.
+	RelayFilesAddr string `toml:"relay-files-addr" json:"relay-files-addr"`
+
 	// tls config
 	config.Security
 
@@ -211,6 +225,19 @@ func (c *Config) adjust() error {
 		c.Join = utils.WrapSchemes(c.Join, c.SSLCA != "")
 	}
 
+	if c.StatusConcurrency <= 0 {
+		c.StatusConcurrency = defaultStatusConcurrency
+	}
+
+	if c.RelayFilesAddr != "" {
+		if _, _, err = net.SplitHostPort(c.RelayFilesAddr); err != nil {
+			return terror.ErrWorkerHostPortNotValid.Delegate(err, c.RelayFilesAddr)
+		}
+		if c.SSLCA == "" || c.SSLCert == "" || c.SSLKey == "" {
+			return terror.ErrWorkerRelayFilesNeedTLS.Generate(c.RelayFilesAddr)
+		}
+	}
+
 	return nil
 }
 
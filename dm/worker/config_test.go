@@ -47,3 +47,22 @@ func (t *testConfigSuite) TestAdjustAddr(c *check.C) {
 	c.Assert(cfg.adjust(), check.IsNil)
 	c.Assert(cfg.AdvertiseAddr, check.Equals, cfg.WorkerAddr)
 }
+
+func (t *testConfigSuite) TestAdjustRelayFilesAddr(c *check.C) {
+	cfg := NewConfig()
+	c.Assert(cfg.configFromFile(defaultConfigFile), check.IsNil)
+	c.Assert(cfg.adjust(), check.IsNil)
+	c.Assert(cfg.RelayFilesAddr, check.Equals, "")
+
+	// invalid `relay-files-addr`
+	cfg.RelayFilesAddr = "127.0.0.1"
+	c.Assert(terror.ErrWorkerHostPortNotValid.Equal(cfg.adjust()), check.IsTrue)
+
+	// TLS client certificate authentication is required.
+	cfg.RelayFilesAddr = "127.0.0.1:8264"
+	c.Assert(terror.ErrWorkerRelayFilesNeedTLS.Equal(cfg.adjust()), check.IsTrue)
+	cfg.SSLCA, cfg.SSLCert = "ca.pem", "dm.pem"
+	c.Assert(terror.ErrWorkerRelayFilesNeedTLS.Equal(cfg.adjust()), check.IsTrue)
+	cfg.SSLKey = "dm.key"
+	c.Assert(cfg.adjust(), check.IsNil)
+}
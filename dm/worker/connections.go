@@ -0,0 +1,66 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"sort"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// ConnectionInfo represents a connection held by the worker to upstream or downstream.
+type ConnectionInfo struct {
+	// SubTask is the name of the subtask holding the connection, it's empty for the relay.
+	SubTask string `json:"subtask,omitempty"`
+	Unit    string `json:"unit"`
+	conn.ConnInfo
+}
+
+// ListActiveConnections lists the connections currently held by the relay and the current units of subtasks, tagged by
+// the subtask and purpose, it's useful to find the subtasks exhausting the connections of upstream or downstream.
+func (w *Worker) ListActiveConnections() ([]ConnectionInfo, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	var conns []ConnectionInfo
+	if cl, ok := w.relayHolder.(connectionLister); ok {
+		for _, info := range cl.ActiveConnections() {
+			conns = append(conns, ConnectionInfo{Unit: pb.UnitType_Relay.String(), ConnInfo: info})
+		}
+	}
+
+	sts := w.subTaskHolder.getAllSubTasks()
+	names := make([]string, 0, len(sts))
+	for name := range sts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		st := sts[name]
+		u := st.CurrUnit()
+		if u == nil {
+			continue
+		}
+		for _, info := range st.ActiveConnections() {
+			conns = append(conns, ConnectionInfo{SubTask: name, Unit: u.Type().String(), ConnInfo: info})
+		}
+	}
+	return conns, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// GetConsistentSnapshotPosition returns the minimum upstream location all subtasks in the sync phase have flushed
+// their checkpoints past, which means the changes before it have been applied to downstream by all of them, and
+// whether each subtask of the worker is at or past it. the subtasks not in the sync phase are never at it, and the
+// location is nil if no subtask is in the sync phase. combined with PauseAtPosition, downstream can be compared
+// against upstream at a point consistent across all subtasks of the worker.
+func (w *Worker) GetConsistentSnapshotPosition(ctx context.Context) (*binlog.Location, map[string]bool, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	sts := w.subTaskHolder.getAllSubTasks()
+	locations := make(map[string]binlog.Location, len(sts))
+	// compare by GTID sets only if all subtasks replicate in GTID mode.
+	cmpGTID := true
+	for name, st := range sts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if location, ok := st.syncFlushedLocation(); ok {
+			locations[name] = location
+			cmpGTID = cmpGTID && st.cfg.EnableGTID
+		}
+	}
+
+	var minLocation *binlog.Location
+	for _, location := range locations {
+		if minLocation == nil || binlog.CompareLocation(location, *minLocation, cmpGTID) < 0 {
+			location := location
+			minLocation = &location
+		}
+	}
+	reached := make(map[string]bool, len(sts))
+	for name := range sts {
+		location, ok := locations[name]
+		reached[name] = ok && binlog.CompareLocation(location, *minLocation, cmpGTID) >= 0
+	}
+	return minLocation, reached, nil
+}
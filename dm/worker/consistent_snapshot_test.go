@@ -0,0 +1,69 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/syncer"
+)
+
+type testConsistentSnapshot struct{}
+
+var _ = Suite(&testConsistentSnapshot{})
+
+func (t *testConsistentSnapshot) TestGetConsistentSnapshotPosition(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	ctx := context.Background()
+
+	location, reached, err := w.GetConsistentSnapshotPosition(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(location, IsNil)
+	c.Assert(reached, HasLen, 0)
+
+	loadCfg := &config.SubTaskConfig{Name: "test-load"}
+	loadST := NewSubTaskWithStage(loadCfg, pb.Stage_Running, nil)
+	loadST.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	w.subTaskHolder.recordSubTask(loadST)
+	location, reached, err = w.GetConsistentSnapshotPosition(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(location, IsNil)
+	c.Assert(reached, DeepEquals, map[string]bool{"test-load": false})
+
+	syncCfg := &config.SubTaskConfig{Name: "test-sync", Flavor: mysql.MySQLFlavor}
+	syncST := NewSubTaskWithStage(syncCfg, pb.Stage_Running, nil)
+	syncST.setCurrUnit(syncer.NewSyncer(syncCfg, nil))
+	w.subTaskHolder.recordSubTask(syncST)
+	location, reached, err = w.GetConsistentSnapshotPosition(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(location, NotNil)
+	c.Assert(binlog.CompareLocation(*location, binlog.NewLocation(mysql.MySQLFlavor), false), Equals, 0)
+	c.Assert(reached, DeepEquals, map[string]bool{"test-load": false, "test-sync": true})
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = w.GetConsistentSnapshotPosition(canceledCtx)
+	c.Assert(err, Equals, context.Canceled)
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+// dbProviderSetter is implemented by the units (and the relay) opening DB connections by a conn.DBProvider.
+type dbProviderSetter interface {
+	SetDBProvider(dbProvider conn.DBProvider)
+}
+
+// SetDBProvider sets the provider to create the DBs of upstream and downstream instead of conn.DefaultDBProvider,
+// e.g. to use mocked DBs in tests or route connections through a proxy. it's applied to the relay enabled and the
+// subtasks started later, nil means conn.DefaultDBProvider. the dump unit still connects to upstream by itself.
+func (w *Worker) SetDBProvider(dbProvider conn.DBProvider) {
+	w.Lock()
+	defer w.Unlock()
+	w.dbProvider = dbProvider
+}
+
+// setDBProvider sets the provider applied to the units opening DB connections when they're created.
+func (st *SubTask) setDBProvider(dbProvider conn.DBProvider) {
+	st.Lock()
+	defer st.Unlock()
+	st.dbProvider = dbProvider
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"go.etcd.io/etcd/clientv3"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+type testDBProvider struct{}
+
+var _ = Suite(&testDBProvider{})
+
+// failingDBProvider records the configs of the DBs applied and fails to create them.
+type failingDBProvider struct {
+	applied []config.DBConfig
+}
+
+func (p *failingDBProvider) Apply(cfg config.DBConfig) (*conn.BaseDB, error) {
+	p.applied = append(p.applied, cfg)
+	return nil, errors.New("mock DB provider")
+}
+
+func (t *testDBProvider) TestUnitsUseDBProvider(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name:   "test-db-provider",
+		Mode:   config.ModeAll,
+		Flavor: "mysql",
+		From:   config.DBConfig{Host: "127.0.0.1", Port: 3306},
+		To:     config.DBConfig{Host: "127.0.0.1", Port: 4000},
+	}
+	units := createRealUnits(cfg, nil)
+	c.Assert(units, HasLen, 3)
+	// the dump unit connects to upstream by itself.
+	_, ok := units[0].(dbProviderSetter)
+	c.Assert(ok, IsFalse)
+
+	for _, u := range units[1:] {
+		provider := &failingDBProvider{}
+		u.(dbProviderSetter).SetDBProvider(provider)
+		err := u.Init(context.Background())
+		c.Assert(err, ErrorMatches, ".*mock DB provider.*")
+		c.Assert(provider.applied, Not(HasLen), 0)
+	}
+}
+
+// providerMockUnit is a MockUnit recording the DB provider set.
+type providerMockUnit struct {
+	*MockUnit
+	dbProvider conn.DBProvider
+}
+
+func (u *providerMockUnit) SetDBProvider(dbProvider conn.DBProvider) {
+	u.dbProvider = dbProvider
+}
+
+func (t *testDBProvider) TestSubTaskDBProvider(c *C) {
+	w := &Worker{}
+	provider := &failingDBProvider{}
+	w.SetDBProvider(provider)
+	c.Assert(w.dbProvider, Equals, provider)
+
+	defer func() {
+		createUnits = createRealUnits
+	}()
+	mockDumper := NewMockUnit(pb.UnitType_Dump)
+	mockLoader := &providerMockUnit{MockUnit: NewMockUnit(pb.UnitType_Load)}
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{mockDumper, mockLoader}
+	}
+
+	// not set by default.
+	st := NewSubTask(&config.SubTaskConfig{Name: "test-db-provider", Mode: config.ModeFull}, nil)
+	c.Assert(st.Init(), IsNil)
+	c.Assert(mockLoader.dbProvider, IsNil)
+
+	st = NewSubTask(&config.SubTaskConfig{Name: "test-db-provider", Mode: config.ModeFull}, nil)
+	st.setDBProvider(w.dbProvider)
+	c.Assert(st.Init(), IsNil)
+	c.Assert(mockLoader.dbProvider, Equals, provider)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/syncer"
+)
+
+// Drain pauses all running subtasks at safe points, so the worker can be closed without leaving a half-applied
+// transaction batch in downstream. the sync units finish the current transactions and flush the checkpoints before
+// paused, and the other units are paused directly. it waits until ctx is done at most, and returns an error of the
+// subtasks not paused at safe points in time, they're still paused immediately in the background.
+func (w *Worker) Drain(ctx context.Context) (err error) {
+	failed := make(map[string]string) // task name -> reason
+	defer func() {
+		w.auditor.emit(ctx, "Drain", auditArgs(map[string]interface{}{"failed": failed}), err)
+	}()
+
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	sts := w.subTaskHolder.getAllSubTasks()
+	w.RUnlock()
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	timeout := defaultPauseAtTxnBoundaryTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	type drainResult struct {
+		name string
+		err  error
+	}
+	// don't hold the lock when waiting for the transaction boundaries.
+	resultCh := make(chan drainResult, len(sts))
+	pending := make(map[string]struct{})
+	for name, st := range sts {
+		if st.Stage() != pb.Stage_Running {
+			continue
+		}
+		pending[name] = struct{}{}
+		go func(name string, st *SubTask) {
+			resultCh <- drainResult{name: name, err: drainSubTask(st, timeout)}
+		}(name, st)
+	}
+	w.l.Info("drain sub tasks", zap.Int("count", len(pending)), zap.Duration("timeout", timeout))
+
+	for len(pending) > 0 {
+		select {
+		case r := <-resultCh:
+			delete(pending, r.name)
+			if r.err != nil {
+				failed[r.name] = r.err.Error()
+			}
+		case <-ctx.Done():
+			for name := range pending {
+				failed[name] = ctx.Err().Error()
+			}
+			pending = nil
+		}
+	}
+
+	if len(failed) > 0 {
+		w.l.Warn("some sub tasks are not paused at safe points", zap.Any("failed", failed))
+		return terror.ErrWorkerDrainFailed.Generate(failed)
+	}
+	w.l.Info("all sub tasks are drained")
+	return nil
+}
+
+// drainSubTask pauses the subtask at a safe point, it returns an error if the sync unit doesn't reach a transaction
+// boundary in time.
+func drainSubTask(st *SubTask, timeout time.Duration) error {
+	if _, ok := st.CurrUnit().(*syncer.Syncer); !ok {
+		return st.Pause()
+	}
+	atBoundary, err := st.PauseAtTxnBoundary(timeout)
+	if err != nil {
+		return err
+	}
+	if !atBoundary {
+		return errors.New("transaction boundary is not reached in time, paused immediately")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testDrain struct{}
+
+var _ = Suite(&testDrain{})
+
+func (t *testDrain) TestDrain(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	sts := make(map[string]*SubTask)
+	for name, stage := range map[string]pb.Stage{"task-a": pb.Stage_Running, "task-b": pb.Stage_Running, "task-c": pb.Stage_Paused} {
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: name}, stage, nil)
+		st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		st.initialized.Set(true)
+		w.subTaskHolder.recordSubTask(st)
+		sts[name] = st
+	}
+
+	// ctx is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Assert(w.Drain(ctx), Equals, context.Canceled)
+	c.Assert(sts["task-a"].Stage(), Equals, pb.Stage_Running)
+
+	// the running subtasks are paused, and the paused ones are skipped.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Assert(w.Drain(ctx), IsNil)
+	for _, st := range sts {
+		c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	}
+
+	w.closed.Set(closedTrue)
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(w.Drain(ctx)), IsTrue)
+	for _, st := range sts {
+		st.Close()
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// redactedPassword replaces the passwords in the effective config.
+const redactedPassword = "******"
+
+// ConfigProvenance represents where the value of a config item came from.
+type ConfigProvenance string
+
+// provenances of config items.
+const (
+	ConfigFromTask   ConfigProvenance = "task"
+	ConfigFromSource ConfigProvenance = "source"
+)
+
+// EffectiveConfig represents the config a subtask runs with, after the config items of the source config are copied
+// and merged into the subtask config.
+type EffectiveConfig struct {
+	// Config is the effective config with passwords redacted.
+	Config *config.SubTaskConfig `json:"config"`
+	// FromPasswordSet and ToPasswordSet are whether the decrypted passwords of upstream and downstream are not empty.
+	FromPasswordSet bool `json:"from-password-set"`
+	ToPasswordSet   bool `json:"to-password-set"`
+	// Provenance is where the values came from, keyed by the names of config items, and the items in filter rules and
+	// column transforms are keyed by their indexes like `filter-rules[0]`. items not listed are from the task config.
+	Provenance map[string]ConfigProvenance `json:"provenance"`
+}
+
+// GetEffectiveConfig returns the effective config of the subtask, the config items copied from the source config are
+// marked in the provenance.
+func (w *Worker) GetEffectiveConfig(name string) (*EffectiveConfig, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	st.RLock()
+	cfg, err := st.cfg.Clone()
+	st.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return newEffectiveConfig(cfg, w.cfg), nil
+}
+
+// newEffectiveConfig redacts the passwords of cfg and resolves the provenance of its config items, it follows the
+// rules of copyConfigFromSource, and a merged item equal to the one in source config is regarded as from the source.
+func newEffectiveConfig(cfg *config.SubTaskConfig, sourceCfg *config.SourceConfig) *EffectiveConfig {
+	ec := &EffectiveConfig{
+		Config:          cfg,
+		FromPasswordSet: len(cfg.From.Password) > 0,
+		ToPasswordSet:   len(cfg.To.Password) > 0,
+		Provenance: map[string]ConfigProvenance{
+			"from":                    ConfigFromSource,
+			"flavor":                  ConfigFromSource,
+			"server-id":               ConfigFromSource,
+			"relay-dir":               ConfigFromSource,
+			"enable-gtid":             ConfigFromSource,
+			"use-relay":               ConfigFromSource,
+			"auto-fix-gtid":           ConfigFromSource,
+			"conflict-retry-count":    ConfigFromTask,
+			"conflict-retry-interval": ConfigFromTask,
+			"case-sensitive":          ConfigFromTask,
+		},
+	}
+	if ec.FromPasswordSet {
+		cfg.From.Password = redactedPassword
+	}
+	if ec.ToPasswordSet {
+		cfg.To.Password = redactedPassword
+	}
+
+	if cfg.ConflictRetryCount == sourceCfg.ConflictRetryCount {
+		ec.Provenance["conflict-retry-count"] = ConfigFromSource
+	}
+	if cfg.ConflictRetryInterval == sourceCfg.ConflictRetryInterval {
+		ec.Provenance["conflict-retry-interval"] = ConfigFromSource
+	}
+	if sourceCfg.CaseSensitive {
+		ec.Provenance["case-sensitive"] = ConfigFromSource
+	}
+	if cfg.StrictGTID {
+		// `strict-gtid` of the task overrides `auto-fix-gtid`.
+		ec.Provenance["auto-fix-gtid"] = ConfigFromTask
+	}
+
+	ignoredRule := sourceCfg.IgnoredEventsRule()
+	for i, rule := range cfg.FilterRules {
+		provenance := ConfigFromTask
+		// the rule for events ignored by source config is put ahead of others.
+		if i == 0 && ignoredRule != nil && rule.SchemaPattern == ignoredRule.SchemaPattern && rule.TablePattern == ignoredRule.TablePattern {
+			provenance = ConfigFromSource
+		}
+		for _, sourceRule := range sourceCfg.Filters {
+			if sameFilterRule(rule, sourceRule) {
+				provenance = ConfigFromSource
+				break
+			}
+		}
+		ec.Provenance[fmt.Sprintf("filter-rules[%d]", i)] = provenance
+	}
+	for i, transform := range cfg.ColumnTransforms {
+		provenance := ConfigFromTask
+		for _, sourceTransform := range sourceCfg.ColumnTransforms {
+			if *transform == *sourceTransform {
+				provenance = ConfigFromSource
+				break
+			}
+		}
+		ec.Provenance[fmt.Sprintf("column-transforms[%d]", i)] = provenance
+	}
+	return ec
+}
+
+// sameFilterRule returns whether the filter rules have the same patterns, events, SQL patterns and action, a nil slice
+// is the same as an empty one because the config may be encoded and decoded.
+func sameFilterRule(rule1, rule2 *bf.BinlogEventRule) bool {
+	return rule1.SchemaPattern == rule2.SchemaPattern && rule1.TablePattern == rule2.TablePattern &&
+		rule1.Action == rule2.Action && fmt.Sprint(rule1.Events) == fmt.Sprint(rule2.Events) &&
+		fmt.Sprint(rule1.SQLPattern) == fmt.Sprint(rule2.SQLPattern)
+}
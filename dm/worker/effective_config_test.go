@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testEffectiveConfig struct{}
+
+var _ = Suite(&testEffectiveConfig{})
+
+func (t *testEffectiveConfig) TestGetEffectiveConfig(c *C) {
+	sourceCfg := &config.SourceConfig{
+		SourceID:              "mysql-replica-01",
+		Flavor:                "mysql",
+		ServerID:              101,
+		ConflictRetryCount:    5,
+		ConflictRetryInterval: 10,
+		IgnoreDDL:             true,
+		Filters: []*bf.BinlogEventRule{
+			{SchemaPattern: "db1", TablePattern: "tbl", Events: []bf.EventType{bf.DeleteEvent}, Action: bf.Ignore},
+		},
+		ColumnTransforms: []*config.ColumnTransform{
+			{SchemaPattern: "db1", TablePattern: "tbl", Column: "c1", Func: config.TransformNullify},
+		},
+	}
+	sourceCfg.From.Password = "123456"
+	w := &Worker{
+		cfg:           sourceCfg,
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+
+	cfg := &config.SubTaskConfig{
+		Name:     "task1",
+		SourceID: "mysql-replica-01",
+		FilterRules: []*bf.BinlogEventRule{
+			{SchemaPattern: "db2", Events: []bf.EventType{bf.AllDDL}, Action: bf.Ignore},
+		},
+		ColumnTransforms: []*config.ColumnTransform{
+			{SchemaPattern: "db2", TablePattern: "tbl", Column: "c1", Func: config.TransformNullify},
+		},
+	}
+	cfg.ConflictRetryCount = 3
+	cfg.To.Password = "654321"
+	c.Assert(copyConfigFromSource(cfg, sourceCfg), IsNil)
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(cfg, pb.Stage_Running, nil))
+
+	_, err := w.GetEffectiveConfig("not-exist")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+
+	ec, err := w.GetEffectiveConfig("task1")
+	c.Assert(err, IsNil)
+	c.Assert(ec.FromPasswordSet, IsTrue)
+	c.Assert(ec.ToPasswordSet, IsTrue)
+	c.Assert(ec.Config.From.Password, Equals, redactedPassword)
+	c.Assert(ec.Config.To.Password, Equals, redactedPassword)
+	// the config of the subtask is not changed.
+	c.Assert(cfg.From.Password, Equals, "123456")
+
+	c.Assert(ec.Config.ServerID, Equals, uint32(101))
+	c.Assert(ec.Provenance["server-id"], Equals, ConfigFromSource)
+	c.Assert(ec.Config.ConflictRetryCount, Equals, 3)
+	c.Assert(ec.Provenance["conflict-retry-count"], Equals, ConfigFromTask)
+	c.Assert(ec.Config.ConflictRetryInterval, Equals, 10)
+	c.Assert(ec.Provenance["conflict-retry-interval"], Equals, ConfigFromSource)
+	c.Assert(ec.Provenance["case-sensitive"], Equals, ConfigFromTask)
+
+	// the ignored events rule of source, the rule of task, the rule of source.
+	c.Assert(ec.Config.FilterRules, HasLen, 3)
+	c.Assert(ec.Provenance["filter-rules[0]"], Equals, ConfigFromSource)
+	c.Assert(ec.Provenance["filter-rules[1]"], Equals, ConfigFromTask)
+	c.Assert(ec.Provenance["filter-rules[2]"], Equals, ConfigFromSource)
+	c.Assert(ec.Config.ColumnTransforms, HasLen, 2)
+	c.Assert(ec.Provenance["column-transforms[0]"], Equals, ConfigFromTask)
+	c.Assert(ec.Provenance["column-transforms[1]"], Equals, ConfigFromSource)
+}
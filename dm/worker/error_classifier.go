@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/retry"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// errorClassifiersSetter is implemented by the units deciding whether the DB errors are retryable by retry.Classifiers.
+type errorClassifiersSetter interface {
+	SetErrorClassifiers(classifiers *retry.Classifiers)
+}
+
+// RegisterErrorClassifier registers a classifier consulted by the load and sync units to decide whether a DB error of
+// downstream is retryable, permanent or should pause the subtask without auto resuming, before the default rules.
+// e.g. to retry the environment-specific transient errors of a proxy. the classifiers are consulted in the order
+// registered, and it takes effect on the running subtasks too.
+func (w *Worker) RegisterErrorClassifier(classifier retry.ErrorClassifier) {
+	w.Lock()
+	defer w.Unlock()
+	if w.errClassifiers == nil {
+		w.errClassifiers = retry.NewClassifiers()
+	}
+	w.errClassifiers.Register(classifier)
+}
+
+// GetErrorClassifications returns the recent DB errors met by the subtasks, and the classifiers decided them.
+func (w *Worker) GetErrorClassifications() ([]retry.Classification, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	return w.errClassifiers.Recent(), nil
+}
+
+// latestErrorClassification returns the latest classification of the DB errors met by the subtask, or nil if none.
+func (st *SubTask) latestErrorClassification() *pb.ErrorClassification {
+	st.RLock()
+	classifiers := st.errClassifiers
+	st.RUnlock()
+	recent := classifiers.Recent()
+	for i := len(recent) - 1; i >= 0; i-- {
+		if cls := recent[i]; cls.Task == st.cfg.Name {
+			return &pb.ErrorClassification{
+				Error:      cls.Error,
+				Class:      string(cls.Class),
+				Classifier: cls.Classifier,
+				Time:       cls.Time.Unix(),
+			}
+		}
+	}
+	return nil
+}
+
+// setErrorClassifiers sets the classifiers applied to the units when they're created.
+func (st *SubTask) setErrorClassifiers(classifiers *retry.Classifiers) {
+	st.Lock()
+	defer st.Unlock()
+	st.errClassifiers = classifiers
+}
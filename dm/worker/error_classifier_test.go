@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
+)
+
+type testErrorClassifier struct{}
+
+var _ = Suite(&testErrorClassifier{})
+
+type proxyClassifier struct{}
+
+func (proxyClassifier) Name() string {
+	return "proxy"
+}
+
+func (proxyClassifier) Classify(error) retry.ErrorClass {
+	return retry.ErrClassRetryable
+}
+
+func (t *testErrorClassifier) TestRegisterErrorClassifier(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	classifications, err := w.GetErrorClassifications()
+	c.Assert(err, IsNil)
+	c.Assert(classifications, HasLen, 0)
+
+	w.RegisterErrorClassifier(proxyClassifier{})
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-error-classifier"}, pb.Stage_Running, nil)
+	st.setErrorClassifiers(w.errClassifiers)
+	w.subTaskHolder.recordSubTask(st)
+
+	// the classifiers are shared by the worker and its subtasks.
+	cls := st.errClassifiers.Classify("test-error-classifier", errors.New("proxy error"))
+	c.Assert(cls.Class, Equals, retry.ErrClassRetryable)
+	classifications, err = w.GetErrorClassifications()
+	c.Assert(err, IsNil)
+	c.Assert(classifications, HasLen, 1)
+	c.Assert(classifications[0].Task, Equals, "test-error-classifier")
+	c.Assert(classifications[0].Classifier, Equals, "proxy")
+
+	// the latest classification of the subtask is shown in its status.
+	st.errClassifiers.Classify("other-task", errors.New("other error"))
+	status := w.Status(context.Background(), "test-error-classifier")
+	c.Assert(status, HasLen, 1)
+	c.Assert(status[0].ErrorClassification, NotNil)
+	c.Assert(status[0].ErrorClassification.Error, Equals, "proxy error")
+	c.Assert(status[0].ErrorClassification.Class, Equals, string(retry.ErrClassRetryable))
+	c.Assert(status[0].ErrorClassification.Classifier, Equals, "proxy")
+	c.Assert(NewSubTask(&config.SubTaskConfig{Name: "no-error"}, nil).latestErrorClassification(), IsNil)
+}
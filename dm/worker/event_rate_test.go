@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/syncer"
+)
+
+type testEventRate struct{}
+
+var _ = Suite(&testEventRate{})
+
+func (t *testEventRate) TestGetEventRateHistory(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	_, err := w.GetEventRateHistory("not-exist", time.Minute)
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+
+	cfg := &config.SubTaskConfig{Name: "test-event-rate"}
+	st := NewSubTaskWithStage(cfg, pb.Stage_Running, nil)
+	w.subTaskHolder.recordSubTask(st)
+	_, err = w.GetEventRateHistory("test-event-rate", time.Minute)
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(err), IsTrue)
+
+	st.units = []unit.Unit{syncer.NewSyncer(cfg, nil)}
+	samples, err := w.GetEventRateHistory("test-event-rate", time.Minute)
+	c.Assert(err, IsNil)
+	c.Assert(samples, HasLen, 0)
+}
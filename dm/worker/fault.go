@@ -0,0 +1,166 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// FaultKind is the kind of fault which can be injected into DM-worker.
+type FaultKind string
+
+// kinds of fault.
+const (
+	// FaultSubTaskFail makes the subtask `Task` fail its next `Count` runs or resumes.
+	FaultSubTaskFail FaultKind = "subtask-fail"
+	// FaultEtcdWatchDisconnect disconnects the etcd watches of subtask stage and relay stage, then they are re-watched.
+	FaultEtcdWatchDisconnect FaultKind = "etcd-watch-disconnect"
+	// FaultCheckpointFlushDelay delays the next `Count` checkpoint flushes of all subtasks by `Delay`.
+	FaultCheckpointFlushDelay FaultKind = "checkpoint-flush-delay"
+)
+
+// delayFlushCheckpointFailpoint is the failpoint in syncer to delay flushing checkpoint.
+const delayFlushCheckpointFailpoint = "github.com/pingcap/dm/syncer/DelayFlushCheckpoint"
+
+// FaultSpec represents a fault injected into DM-worker for chaos testing.
+type FaultSpec struct {
+	Kind  FaultKind     `json:"kind"`
+	Task  string        `json:"task,omitempty"`
+	Count int           `json:"count,omitempty"`
+	Delay time.Duration `json:"delay,omitempty"`
+}
+
+func (f FaultSpec) validate() error {
+	switch f.Kind {
+	case FaultSubTaskFail:
+		if len(f.Task) == 0 {
+			return terror.ErrWorkerInvalidFaultSpec.Generate(f, "task should not be empty")
+		}
+		if f.Count <= 0 {
+			return terror.ErrWorkerInvalidFaultSpec.Generate(f, "count should be positive")
+		}
+	case FaultEtcdWatchDisconnect:
+	case FaultCheckpointFlushDelay:
+		if f.Count <= 0 {
+			return terror.ErrWorkerInvalidFaultSpec.Generate(f, "count should be positive")
+		}
+		if f.Delay < time.Millisecond {
+			return terror.ErrWorkerInvalidFaultSpec.Generate(f, "delay should be at least 1ms")
+		}
+	default:
+		return terror.ErrWorkerInvalidFaultSpec.Generate(f, "unknown kind")
+	}
+	return nil
+}
+
+// faultInjectionEnabled returns whether fault injection is enabled, it's disabled unless DM-worker is built with
+// failpoint enabled and the failpoint is activated explicitly, so it can't be used in production builds.
+func faultInjectionEnabled() bool {
+	enabled := false
+	failpoint.Inject("FaultInjection", func() {
+		enabled = true
+	})
+	return enabled
+}
+
+// watchFaults is used to simulate disconnecting etcd watches.
+type watchFaults struct {
+	sync.Mutex
+	disconnected chan struct{}
+}
+
+// disconnectedCh returns a channel which is closed when the watches are disconnected.
+func (wf *watchFaults) disconnectedCh() <-chan struct{} {
+	wf.Lock()
+	defer wf.Unlock()
+	if wf.disconnected == nil {
+		wf.disconnected = make(chan struct{})
+	}
+	return wf.disconnected
+}
+
+func (wf *watchFaults) disconnect() {
+	wf.Lock()
+	defer wf.Unlock()
+	if wf.disconnected != nil {
+		close(wf.disconnected)
+	}
+	wf.disconnected = make(chan struct{})
+}
+
+// injectFailures makes the subtask fail its next n runs or resumes.
+func (st *SubTask) injectFailures(n int) {
+	st.injectedFailures.Set(int32(n))
+}
+
+// consumeInjectedFailure returns an error if the subtask should fail now.
+func (st *SubTask) consumeInjectedFailure() error {
+	for {
+		n := st.injectedFailures.Get()
+		if n <= 0 {
+			return nil
+		}
+		if st.injectedFailures.CompareAndSwap(n, n-1) {
+			return terror.ErrWorkerInjectedFault.Generate(fmt.Sprintf("subtask %s fails, %d failures remain", st.cfg.Name, n-1))
+		}
+	}
+}
+
+// InjectFault injects a fault into DM-worker, to exercise the paths of observing, retrying and auto-resuming in chaos testing.
+// it's only available when DM-worker is built with failpoint enabled and failpoint
+// `github.com/pingcap/dm/dm/worker/FaultInjection` is activated, e.g. by
+// `GO_FAILPOINTS="github.com/pingcap/dm/dm/worker/FaultInjection=return(true)"`.
+func (w *Worker) InjectFault(spec FaultSpec) (err error) {
+	w.RLock()
+	defer w.RUnlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "InjectFault", auditArgs(spec), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if err = spec.validate(); err != nil {
+		return err
+	}
+	if !faultInjectionEnabled() {
+		return terror.ErrWorkerFaultInjectionDisabled.Generate()
+	}
+
+	switch spec.Kind {
+	case FaultSubTaskFail:
+		st := w.subTaskHolder.findSubTask(spec.Task)
+		if st == nil {
+			return terror.ErrWorkerSubTaskNotFound.Generate(spec.Task)
+		}
+		st.injectFailures(spec.Count)
+	case FaultEtcdWatchDisconnect:
+		w.watchFaults.disconnect()
+	case FaultCheckpointFlushDelay:
+		term := fmt.Sprintf("%d*sleep(%d)", spec.Count, spec.Delay.Milliseconds())
+		if err = failpoint.Enable(delayFlushCheckpointFailpoint, term); err != nil {
+			return terror.ErrWorkerInvalidFaultSpec.Delegate(err, spec, "fail to enable failpoint")
+		}
+	}
+	w.l.Warn("fault injected", zap.Reflect("spec", spec))
+	return nil
+}
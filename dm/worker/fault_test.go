@@ -0,0 +1,88 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testFault struct{}
+
+var _ = Suite(&testFault{})
+
+func (t *testFault) TestFaultSpecValidate(c *C) {
+	cases := []struct {
+		spec FaultSpec
+		err  string
+	}{
+		{FaultSpec{Kind: FaultSubTaskFail, Task: "task", Count: 2}, ""},
+		{FaultSpec{Kind: FaultSubTaskFail, Count: 2}, ".*task should not be empty.*"},
+		{FaultSpec{Kind: FaultSubTaskFail, Task: "task"}, ".*count should be positive.*"},
+		{FaultSpec{Kind: FaultEtcdWatchDisconnect}, ""},
+		{FaultSpec{Kind: FaultCheckpointFlushDelay, Count: 1, Delay: time.Second}, ""},
+		{FaultSpec{Kind: FaultCheckpointFlushDelay, Delay: time.Second}, ".*count should be positive.*"},
+		{FaultSpec{Kind: FaultCheckpointFlushDelay, Count: 1}, ".*delay should be at least 1ms.*"},
+		{FaultSpec{Kind: "unknown"}, ".*unknown kind.*"},
+	}
+	for _, cs := range cases {
+		err := cs.spec.validate()
+		if cs.err == "" {
+			c.Assert(err, IsNil)
+		} else {
+			c.Assert(terror.ErrWorkerInvalidFaultSpec.Equal(err), IsTrue)
+			c.Assert(err, ErrorMatches, cs.err)
+		}
+	}
+
+	// disabled without failpoint activated
+	c.Assert(faultInjectionEnabled(), IsFalse)
+}
+
+func (t *testFault) TestInjectedFailures(c *C) {
+	st := NewSubTask(&config.SubTaskConfig{Name: "test-fault"}, nil)
+	c.Assert(st.consumeInjectedFailure(), IsNil)
+
+	st.injectFailures(2)
+	err := st.consumeInjectedFailure()
+	c.Assert(terror.ErrWorkerInjectedFault.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*subtask test-fault fails, 1 failures remain.*")
+	c.Assert(st.consumeInjectedFailure(), ErrorMatches, ".*0 failures remain.*")
+	c.Assert(st.consumeInjectedFailure(), IsNil)
+}
+
+func (t *testFault) TestWatchFaults(c *C) {
+	var wf watchFaults
+	ch1 := wf.disconnectedCh()
+	c.Assert(wf.disconnectedCh(), Equals, ch1)
+
+	wf.disconnect()
+	select {
+	case <-ch1:
+	default:
+		c.Fatal("watches should be disconnected")
+	}
+
+	// watches after disconnected are not affected
+	ch2 := wf.disconnectedCh()
+	select {
+	case <-ch2:
+		c.Fatal("new watches should not be disconnected")
+	default:
+	}
+}
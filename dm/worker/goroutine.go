@@ -0,0 +1,107 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"sort"
+	"sync"
+)
+
+// kinds of goroutines counted for leak detection.
+const (
+	goroutineStatusTicker    = "status-ticker"
+	goroutineResourceSampler = "resource-sampler"
+	goroutineSubTaskObserver = "subtask-stage-observer"
+	goroutineSubTaskWatcher  = "subtask-stage-watcher"
+	goroutineRelayObserver   = "relay-stage-observer"
+	goroutineRelayWatcher    = "relay-stage-watcher"
+	goroutineUnit            = "unit"
+)
+
+// GoroutineStatus represents the number of goroutines running and expected by kind.
+type GoroutineStatus struct {
+	Running  map[string]int `json:"running"`
+	Expected map[string]int `json:"expected"`
+}
+
+// Exceeded returns the kinds which have more running goroutines than expected, sorted by kind.
+// there may be more goroutines than expected for a moment when they are exiting, but goroutines of a kind which keeps
+// exceeding are likely to be leaked.
+func (s *GoroutineStatus) Exceeded() []string {
+	var kinds []string
+	for kind, running := range s.Running {
+		if running > s.Expected[kind] {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// goroutineCounter counts running goroutines by kind, and records the expected number of the long-running ones.
+type goroutineCounter struct {
+	mu       sync.Mutex
+	running  map[string]int
+	expected map[string]int
+}
+
+// add adds delta to the number of running goroutines of the kind.
+func (c *goroutineCounter) add(kind string, delta int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running == nil {
+		c.running = make(map[string]int)
+	}
+	c.running[kind] += delta
+}
+
+// run calls f in a new goroutine which is counted as the kind.
+func (c *goroutineCounter) run(kind string, f func()) {
+	c.add(kind, 1)
+	go func() {
+		defer c.add(kind, -1)
+		f()
+	}()
+}
+
+// expect sets the expected number of running goroutines of the kind.
+func (c *goroutineCounter) expect(kind string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expected == nil {
+		c.expected = make(map[string]int)
+	}
+	c.expected[kind] = n
+}
+
+// status returns the running and expected goroutines, kinds with no running or expected goroutines are omitted.
+func (c *goroutineCounter) status() *GoroutineStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := &GoroutineStatus{
+		Running:  make(map[string]int, len(c.running)),
+		Expected: make(map[string]int, len(c.expected)),
+	}
+	for kind, n := range c.running {
+		if n != 0 {
+			status.Running[kind] = n
+		}
+	}
+	for kind, n := range c.expected {
+		if n != 0 {
+			status.Expected[kind] = n
+		}
+	}
+	return status
+}
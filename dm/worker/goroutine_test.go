@@ -0,0 +1,57 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+type testGoroutine struct{}
+
+var _ = Suite(&testGoroutine{})
+
+func (t *testGoroutine) TestGoroutineCounter(c *C) {
+	var counter goroutineCounter
+	status := counter.status()
+	c.Assert(status.Running, HasLen, 0)
+	c.Assert(status.Expected, HasLen, 0)
+	c.Assert(status.Exceeded(), HasLen, 0)
+
+	counter.expect(goroutineSubTaskWatcher, 1)
+	started := make(chan struct{}, 2)
+	exit := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		counter.run(goroutineSubTaskWatcher, func() {
+			started <- struct{}{}
+			<-exit
+		})
+	}
+	<-started
+	<-started
+	status = counter.status()
+	c.Assert(status.Running, DeepEquals, map[string]int{goroutineSubTaskWatcher: 2})
+	c.Assert(status.Expected, DeepEquals, map[string]int{goroutineSubTaskWatcher: 1})
+	// the watcher goroutine leaked.
+	c.Assert(status.Exceeded(), DeepEquals, []string{goroutineSubTaskWatcher})
+
+	close(exit)
+	c.Assert(utils.WaitSomething(100, 10*time.Millisecond, func() bool {
+		return len(counter.status().Running) == 0
+	}), IsTrue)
+	c.Assert(counter.status().Exceeded(), HasLen, 0)
+}
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/backoff"
 	"github.com/pingcap/dm/pkg/ha"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/terror"
@@ -85,6 +86,8 @@ func (s *Server) JoinMaster(endpoints []string) error {
 
 // KeepAlive attempts to keep the lease of the server alive forever.
 func (s *Server) KeepAlive() {
+	// the arguments are valid, so no error will be returned.
+	bf, _ := backoff.NewBackoff(retryConnectBackoffFactor, false, retryConnectSleepTime, retryConnectMaxSleepTime)
 	for {
 		log.L().Info("start to keepalive with master")
 
@@ -97,13 +100,22 @@ func (s *Server) KeepAlive() {
 		})
 
 		{
+			startTime := time.Now()
 			err1 := ha.KeepAlive(s.kaCtx, s.etcdClient, s.cfg.Name, s.cfg.KeepAliveTTL)
 			log.L().Warn("keepalive with master goroutine paused", zap.Error(err1))
+			// the keepalive has ever been kept for a while, so retry quickly next time.
+			if time.Since(startTime) > time.Duration(s.cfg.KeepAliveTTL)*time.Second {
+				bf.Reset()
+			}
 		}
 
 		failpoint.Label("bypass")
 
-		// TODO: report the error.
+		// the source binding is lost with the lease, then another DM-worker may be bound to the source,
+		// so we must stop handling the source immediately to avoid replicating it twice.
+		if w := s.getWorker(true); w != nil && s.kaCtx.Err() == nil {
+			s.setSourceStatus(w.cfg.SourceID, terror.ErrWorkerSourceBindingLost.Generate(s.cfg.Name), true)
+		}
 		err := s.stopWorker("")
 		if err != nil {
 			log.L().Error("fail to stop worker", zap.Error(err))
@@ -113,8 +125,9 @@ func (s *Server) KeepAlive() {
 		case <-s.kaCtx.Done():
 			log.L().Info("keepalive with master goroutine exited!")
 			return
-		case <-time.After(retryConnectSleepTime):
+		case <-time.After(bf.Current()):
 			// Try to connect master again
+			bf.BoundaryForward()
 		}
 	}
 }
@@ -0,0 +1,185 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// maxMaintenanceErrors is the max number of errors recorded during the maintenance window.
+const maxMaintenanceErrors = 100
+
+// MaintenanceWindow represents a period of planned maintenance of the upstream or downstream.
+type MaintenanceWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Active returns whether t is in the maintenance window.
+func (mw *MaintenanceWindow) Active(t time.Time) bool {
+	return mw != nil && !t.Before(mw.Start) && t.Before(mw.End)
+}
+
+// MaintenanceError represents an error of a subtask happened during the maintenance window.
+type MaintenanceError struct {
+	Task    string    `json:"task"`
+	ErrCode int32     `json:"err-code"`
+	Message string    `json:"message"`
+	Count   int       `json:"count"` // times the subtask paused for the error
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+}
+
+// MaintenanceStatus represents the maintenance window of the worker, and errors happened in it.
+type MaintenanceStatus struct {
+	Window *MaintenanceWindow  `json:"window"` // nil if no window is set
+	Active bool                `json:"active"`
+	Errors []*MaintenanceError `json:"errors"` // errors are flagged as during maintenance, sorted by first time
+}
+
+type maintenanceErrorKey struct {
+	task    string
+	errCode int32
+	message string
+}
+
+// maintenance holds the maintenance window and errors recorded in it.
+type maintenance struct {
+	mu     sync.RWMutex
+	window *MaintenanceWindow
+	errors map[maintenanceErrorKey]*MaintenanceError
+}
+
+func (m *maintenance) set(window *MaintenanceWindow) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.window = window
+	m.errors = make(map[maintenanceErrorKey]*MaintenanceError)
+}
+
+func (m *maintenance) active(t time.Time) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.window.Active(t)
+}
+
+// record records errors of the paused subtask at t, an error is counted again only if the subtask has been resumed
+// after it was recorded last time.
+func (m *maintenance) record(task string, errs []*pb.ProcessError, resumed, t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, err := range errs {
+		key := maintenanceErrorKey{task: task, errCode: err.ErrCode, message: err.Message}
+		if e, ok := m.errors[key]; ok {
+			if e.Last.Before(resumed) {
+				e.Count++
+			}
+			e.Last = t
+			continue
+		}
+		if len(m.errors) >= maxMaintenanceErrors {
+			continue
+		}
+		m.errors[key] = &MaintenanceError{
+			Task:    task,
+			ErrCode: err.ErrCode,
+			Message: err.Message,
+			Count:   1,
+			First:   t,
+			Last:    t,
+		}
+	}
+}
+
+func (m *maintenance) status(t time.Time) *MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status := &MaintenanceStatus{
+		Active: m.window.Active(t),
+		Errors: make([]*MaintenanceError, 0, len(m.errors)),
+	}
+	if m.window != nil {
+		window := *m.window
+		status.Window = &window
+	}
+	for _, e := range m.errors {
+		e2 := *e
+		status.Errors = append(status.Errors, &e2)
+	}
+	sort.Slice(status.Errors, func(i, j int) bool {
+		if status.Errors[i].First.Equal(status.Errors[j].First) {
+			return status.Errors[i].Task < status.Errors[j].Task
+		}
+		return status.Errors[i].First.Before(status.Errors[j].First)
+	})
+	return status
+}
+
+// SetMaintenanceWindow sets a window of planned maintenance of the upstream or downstream. during the window, alerts
+// on errors of units are suppressed (by `dm_worker_maintenance_window_active`), auto-resume uses the max backoff
+// without forwarding it, and errors of subtasks are still recorded but flagged as during maintenance. the window is
+// cleared if both start and end are zero.
+func (w *Worker) SetMaintenanceWindow(start, end time.Time) (err error) {
+	defer func() {
+		w.auditor.emit(context.Background(), "SetMaintenanceWindow", auditArgs(map[string]interface{}{"start": start, "end": end}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	var window *MaintenanceWindow
+	switch {
+	case start.IsZero() && end.IsZero():
+	case start.IsZero() || end.IsZero():
+		return terror.ErrWorkerInvalidMaintenanceWindow.Generate(start, end, "both start and end should be set")
+	case !end.After(start):
+		return terror.ErrWorkerInvalidMaintenanceWindow.Generate(start, end, "end should be after start")
+	default:
+		window = &MaintenanceWindow{Start: start, End: end}
+	}
+	w.maintenance.set(window)
+	w.updateMaintenanceMetric()
+	w.l.Info("set maintenance window", zap.Time("start", start), zap.Time("end", end))
+	return nil
+}
+
+// GetMaintenanceStatus returns the maintenance window, whether it's active now and errors happened in it.
+func (w *Worker) GetMaintenanceStatus() (*MaintenanceStatus, error) {
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	return w.maintenance.status(time.Now()), nil
+}
+
+// inMaintenance returns whether the worker is in the maintenance window now.
+func (w *Worker) inMaintenance() bool {
+	return w.maintenance.active(time.Now())
+}
+
+func (w *Worker) updateMaintenanceMetric() {
+	var active float64
+	if w.inMaintenance() {
+		active = 1
+	}
+	maintenanceActiveGauge.WithLabelValues(w.cfg.SourceID).Set(active)
+}
@@ -23,12 +23,15 @@ import (
 	cpu "github.com/pingcap/tidb-tools/pkg/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/pingcap/dm/dm/common"
+	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dumpling"
 	"github.com/pingcap/dm/loader"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/metricsproxy"
+	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
 	"github.com/pingcap/dm/relay"
 	"github.com/pingcap/dm/syncer"
@@ -57,6 +60,30 @@ var (
 			Help:      "number of different operate error",
 		}, []string{"worker", "type"})
 
+	lagExceededCounter = metricsproxy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "worker",
+			Name:      "lag_threshold_exceeded",
+			Help:      "number of subtasks paused because the replication lag exceeds the max allowed lag",
+		}, []string{"task", "source_id"})
+
+	maintenanceActiveGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "worker",
+			Name:      "maintenance_window_active",
+			Help:      "whether the worker is in a maintenance window, alerts on errors are suppressed if it's 1",
+		}, []string{"source_id"})
+
+	subTaskStageGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "worker",
+			Name:      "subtask_stage_count",
+			Help:      "number of subtasks in each stage",
+		}, []string{"worker", "stage"})
+
 	cpuUsageGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "dm",
@@ -110,6 +137,9 @@ func RegistryMetrics() {
 
 	registry.MustRegister(taskState)
 	registry.MustRegister(opErrCounter)
+	registry.MustRegister(lagExceededCounter)
+	registry.MustRegister(maintenanceActiveGauge)
+	registry.MustRegister(subTaskStageGauge)
 
 	relay.RegisterMetrics(registry)
 	dumpling.RegisterMetrics(registry)
@@ -141,4 +171,65 @@ func InitStatus(lis net.Listener) {
 
 func (st *SubTask) removeLabelValuesWithTaskInMetrics(task string, source string) {
 	taskState.DeleteAllAboutLabels(prometheus.Labels{"task": task, "source_id": source})
+	lagExceededCounter.DeleteAllAboutLabels(prometheus.Labels{"task": task, "source_id": source})
+}
+
+// updateSubTaskStageMetric updates the number of subtasks in each stage, the stages without subtasks are set to 0.
+func (w *Worker) updateSubTaskStageMetric() {
+	counts := make(map[pb.Stage]int)
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		counts[st.Stage()]++
+	}
+	for stage := range pb.Stage_name {
+		subTaskStageGauge.WithLabelValues(w.name, pb.Stage(stage).String()).Set(float64(counts[pb.Stage(stage)]))
+	}
+}
+
+// removeSubTaskStageMetric removes the number of subtasks in each stage when the worker is closed.
+func (w *Worker) removeSubTaskStageMetric() {
+	subTaskStageGauge.DeleteAllAboutLabels(prometheus.Labels{"worker": w.name})
+}
+
+// GatherSubTaskMetrics gathers the metrics registered by RegistryMetrics, and only returns the ones of the subtask,
+// i.e. with the "task" label of it. the metric families without metrics of the subtask are not returned.
+func (w *Worker) GatherSubTaskMetrics(name string) ([]*dto.MetricFamily, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if st := w.subTaskHolder.findSubTask(name); st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return gatherTaskMetrics(prometheus.DefaultGatherer, name)
+}
+
+func gatherTaskMetrics(gatherer prometheus.Gatherer, task string) ([]*dto.MetricFamily, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, terror.ErrWorkerGatherMetrics.Delegate(err, task)
+	}
+
+	taskFamilies := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		var metrics []*dto.Metric
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "task" && label.GetValue() == task {
+					metrics = append(metrics, m)
+					break
+				}
+			}
+		}
+		if len(metrics) > 0 {
+			taskFamilies = append(taskFamilies, &dto.MetricFamily{
+				Name:   family.Name,
+				Help:   family.Help,
+				Type:   family.Type,
+				Metric: metrics,
+			})
+		}
+	}
+	return taskFamilies, nil
 }
@@ -0,0 +1,103 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testMetrics struct{}
+
+var _ = Suite(&testMetrics{})
+
+func (t *testMetrics) TestGatherTaskMetrics(c *C) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(taskState)
+	registry.MustRegister(opErrCounter)
+	registry.MustRegister(lagExceededCounter)
+	defer func() {
+		taskState.DeleteAllAboutLabels(prometheus.Labels{"source_id": "source-gather-metrics"})
+		lagExceededCounter.DeleteAllAboutLabels(prometheus.Labels{"source_id": "source-gather-metrics"})
+	}()
+
+	taskState.WithLabelValues("task-gather-1", "source-gather-metrics").Set(2)
+	taskState.WithLabelValues("task-gather-2", "source-gather-metrics").Set(3)
+	lagExceededCounter.WithLabelValues("task-gather-2", "source-gather-metrics").Inc()
+	opErrCounter.WithLabelValues("worker-gather-metrics", opErrTypeBeforeOp).Inc()
+
+	families, err := gatherTaskMetrics(registry, "task-gather-1")
+	c.Assert(err, IsNil)
+	c.Assert(families, HasLen, 1)
+	c.Assert(families[0].GetName(), Equals, "dm_worker_task_state")
+	c.Assert(families[0].GetMetric(), HasLen, 1)
+	c.Assert(families[0].GetMetric()[0].GetGauge().GetValue(), Equals, float64(2))
+
+	families, err = gatherTaskMetrics(registry, "task-gather-2")
+	c.Assert(err, IsNil)
+	c.Assert(families, HasLen, 2)
+	c.Assert(families[0].GetName(), Equals, "dm_worker_lag_threshold_exceeded")
+	c.Assert(families[0].GetMetric()[0].GetCounter().GetValue(), Equals, float64(1))
+	c.Assert(families[1].GetName(), Equals, "dm_worker_task_state")
+	c.Assert(families[1].GetMetric()[0].GetGauge().GetValue(), Equals, float64(3))
+
+	families, err = gatherTaskMetrics(registry, "task-not-exist")
+	c.Assert(err, IsNil)
+	c.Assert(families, HasLen, 0)
+
+	// only the metrics of existing subtasks are gathered by the worker.
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task-gather-1"}, pb.Stage_Running, nil))
+	_, err = w.GatherSubTaskMetrics("task-gather-1")
+	c.Assert(err, IsNil)
+	_, err = w.GatherSubTaskMetrics("task-gather-2")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+}
+
+func (t *testMetrics) TestSubTaskStageMetric(c *C) {
+	w := &Worker{
+		name:          "worker-stage-metric",
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	defer w.removeSubTaskStageMetric()
+	count := func(stage pb.Stage) float64 {
+		metric := &dto.Metric{}
+		c.Assert(subTaskStageGauge.WithLabelValues(w.name, stage.String()).Write(metric), IsNil)
+		return metric.GetGauge().GetValue()
+	}
+
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task-1"}, pb.Stage_Running, nil))
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task-2"}, pb.Stage_Running, nil))
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task-3"}, pb.Stage_Paused, nil))
+	w.updateSubTaskStageMetric()
+	c.Assert(count(pb.Stage_Running), Equals, float64(2))
+	c.Assert(count(pb.Stage_Paused), Equals, float64(1))
+	c.Assert(count(pb.Stage_Finished), Equals, float64(0))
+
+	// the stages without subtasks are reset.
+	w.subTaskHolder.removeSubTask("task-3")
+	w.updateSubTaskStageMetric()
+	c.Assert(count(pb.Stage_Running), Equals, float64(2))
+	c.Assert(count(pb.Stage_Paused), Equals, float64(0))
+}
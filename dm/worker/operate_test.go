@@ -0,0 +1,143 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testOperateSubTasks struct{}
+
+var _ = Suite(&testOperateSubTasks{})
+
+func (t *testOperateSubTasks) TestOperateSubTasks(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	sts := make(map[string]*SubTask)
+	for _, name := range []string{"task-a", "task-b", "task-c"} {
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: name}, pb.Stage_Running, nil)
+		st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		st.initialized.Set(true)
+		w.subTaskHolder.recordSubTask(st)
+		sts[name] = st
+	}
+
+	// partial failures are reported by task names.
+	errs := w.OperateSubTasks([]string{"task-a", "task-b", "not-exist"}, pb.TaskOp_Pause)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(errs["not-exist"]), IsTrue)
+	c.Assert(sts["task-a"].Stage(), Equals, pb.Stage_Paused)
+	c.Assert(sts["task-b"].Stage(), Equals, pb.Stage_Paused)
+	c.Assert(sts["task-c"].Stage(), Equals, pb.Stage_Running)
+
+	// all subtasks are operated if no names given.
+	errs = w.OperateSubTasks(nil, pb.TaskOp_Pause)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(terror.ErrWorkerNotRunningStage.Equal(errs["task-a"]), IsTrue)
+	c.Assert(terror.ErrWorkerNotRunningStage.Equal(errs["task-b"]), IsTrue)
+	c.Assert(sts["task-c"].Stage(), Equals, pb.Stage_Paused)
+
+	// stopped subtasks are removed from the holder.
+	errs = w.OperateSubTasks([]string{"task-a", "task-c"}, pb.TaskOp_Stop)
+	c.Assert(errs, HasLen, 0)
+	c.Assert(w.subTaskHolder.findSubTask("task-a"), IsNil)
+	c.Assert(w.subTaskHolder.findSubTask("task-c"), IsNil)
+	c.Assert(w.subTaskHolder.findSubTask("task-b"), NotNil)
+
+	w.closed.Set(closedTrue)
+	errs = w.OperateSubTasks(nil, pb.TaskOp_Stop)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(errs["task-b"]), IsTrue)
+	sts["task-b"].Close()
+}
+
+func (t *testOperateSubTasks) TestPauseAllResumeAll(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	sink := &mockAuditSink{}
+	w.auditor = newAuditor()
+	w.auditor.register(sink)
+	stages := map[string]pb.Stage{
+		"task-a": pb.Stage_Running,
+		"task-b": pb.Stage_Paused,
+		"task-c": pb.Stage_New,
+		"task-d": pb.Stage_Running,
+	}
+	sts := make(map[string]*SubTask)
+	for name, stage := range stages {
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: name}, stage, nil)
+		st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		st.initialized.Set(true)
+		defer st.Close()
+		w.subTaskHolder.recordSubTask(st)
+		sts[name] = st
+	}
+	c.Assert(w.RegisterSubTaskOpHook("task-d", func(op pb.TaskOp) error {
+		return errors.New("not ready")
+	}, nil), IsNil)
+
+	// task-b is skipped, task-c is not started and reported without error, task-d failed to pause.
+	err := w.PauseAll(context.Background())
+	c.Assert(terror.ErrWorkerOperateAllFailed.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*Pause failed on subtasks map\\[task-d:.*not ready.*")
+	c.Assert(sts["task-a"].Stage(), Equals, pb.Stage_Paused)
+	c.Assert(sts["task-b"].Stage(), Equals, pb.Stage_Paused)
+	c.Assert(sts["task-c"].Stage(), Equals, pb.Stage_New)
+	c.Assert(sts["task-d"].Stage(), Equals, pb.Stage_Running)
+	w.auditor.close()
+	var allEvent *AuditEvent
+	for i := range sink.events {
+		if sink.events[i].Action == "PauseAll" {
+			allEvent = &sink.events[i]
+		}
+	}
+	c.Assert(allEvent, NotNil)
+	c.Assert(allEvent.Args, Matches, `.*"operated":\["task-a"\].*`)
+	c.Assert(allEvent.Args, Matches, `.*"failed":\{"task-d":".*not ready.*`)
+	w.auditor = nil
+
+	c.Assert(w.RegisterSubTaskOpHook("task-d", nil, nil), IsNil)
+	c.Assert(w.PauseAll(context.Background()), IsNil)
+	c.Assert(sts["task-d"].Stage(), Equals, pb.Stage_Paused)
+
+	c.Assert(w.ResumeAll(context.Background()), IsNil)
+	for _, name := range []string{"task-a", "task-b", "task-d"} {
+		c.Assert(sts[name].Stage(), Equals, pb.Stage_Running)
+	}
+	c.Assert(sts["task-c"].Stage(), Equals, pb.Stage_New)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Assert(w.PauseAll(ctx), Equals, context.Canceled)
+	c.Assert(sts["task-a"].Stage(), Equals, pb.Stage_Running)
+
+	w.closed.Set(closedTrue)
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(w.ResumeAll(context.Background())), IsTrue)
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/relay"
+)
+
+// RelayInfo represents the relay unit of the worker, and the tables relayed if partial relay is enabled.
+type RelayInfo struct {
+	Stage string `json:"stage"`
+	// nil if partial relay is not supported, e.g. fetching relay log from a relay source
+	PartialRelay *relay.PartialRelayStatus `json:"partial-relay"`
+	// tables needed by subtasks but their events were discarded before the subtasks added, task name -> tables
+	Warnings map[string][]string `json:"warnings,omitempty"`
+	// seconds since the relay wrote the last event or started to read binlog, -1 if unknown
+	SecondsSinceLastWrite int64 `json:"seconds-since-last-write"`
+	// the automatic restarts of the relay after upstream disconnected, nil if not supported
+	Reconnects *relay.ReconnectStats `json:"reconnects,omitempty"`
+}
+
+// GetRelayInfo returns the stage of the relay unit, the tables relayed and discarded by partial relay.
+func (w *Worker) GetRelayInfo() (*RelayInfo, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if w.relayHolder == nil {
+		return nil, terror.ErrWorkerRelayDisabled.Generate()
+	}
+
+	info := &RelayInfo{Stage: w.relayHolder.Stage().String(), SecondsSinceLastWrite: -1}
+	if sd, ok := w.relayHolder.(stallDetector); ok {
+		if t := sd.LastWriteTime(); !t.IsZero() {
+			info.SecondsSinceLastWrite = int64(time.Since(t).Seconds())
+		}
+	}
+	if pr, ok := w.relayHolder.(partialRelayer); ok {
+		info.PartialRelay = pr.PartialRelayStatus()
+	}
+	if rc, ok := w.relayHolder.(relayReconnector); ok {
+		stats := rc.ReconnectStats()
+		info.Reconnects = &stats
+	}
+	if len(w.partialRelayWarnings) > 0 {
+		info.Warnings = make(map[string][]string, len(w.partialRelayWarnings))
+		for name, tables := range w.partialRelayWarnings {
+			info.Warnings[name] = append([]string{}, tables...)
+		}
+	}
+	return info, nil
+}
+
+// updateRelayedTables recomputes the tables relayed by partial relay from the subtasks using relay, it should be
+// called with the lock held after subtasks are added, updated or removed.
+func (w *Worker) updateRelayedTables() {
+	cfgs := make([]*config.SubTaskConfig, 0)
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		if st.cfg.UseRelay {
+			cfgs = append(cfgs, st.cfg)
+		}
+	}
+	w.setRelayedTables(cfgs)
+}
+
+func (w *Worker) setRelayedTables(cfgs []*config.SubTaskConfig) {
+	pr, ok := w.relayHolder.(partialRelayer)
+	if !ok || !w.cfg.PartialRelay {
+		return
+	}
+	tables, err := relay.NewRelayedTables(cfgs)
+	if err != nil {
+		// relay all tables rather than missing events.
+		w.l.Error("fail to compute tables for partial relay, relay all tables", zap.Error(err))
+	}
+	pr.SetRelayedTables(tables)
+}
+
+// checkDiscardedTables warns if the subtask to be added needs the tables whose events were discarded by partial
+// relay, the subtask can't replicate them correctly from the relay log.
+func (w *Worker) checkDiscardedTables(cfg *config.SubTaskConfig) {
+	pr, ok := w.relayHolder.(partialRelayer)
+	if !ok || !cfg.UseRelay {
+		return
+	}
+	status := pr.PartialRelayStatus()
+	if status == nil || len(status.DiscardedTables) == 0 {
+		return
+	}
+	f, err := filter.New(cfg.CaseSensitive, cfg.BAList)
+	if err != nil {
+		return // the subtask will fail with this error later.
+	}
+	var tables []string
+	for _, d := range status.DiscardedTables {
+		if f.Match(&filter.Table{Schema: d.Schema, Name: d.Table}) {
+			tables = append(tables, dbutil.TableName(d.Schema, d.Table))
+		}
+	}
+	if len(tables) == 0 {
+		return
+	}
+	w.l.Warn("subtask needs tables whose events were discarded by partial relay",
+		zap.String("task", cfg.Name), zap.Strings("tables", tables))
+	if w.partialRelayWarnings == nil {
+		w.partialRelayWarnings = make(map[string][]string)
+	}
+	w.partialRelayWarnings[cfg.Name] = tables
+}
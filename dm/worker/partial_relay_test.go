@@ -0,0 +1,82 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/relay"
+)
+
+type testPartialRelay struct{}
+
+var _ = Suite(&testPartialRelay{})
+
+type partialRelayHolder struct {
+	RelayHolder
+	tables    *relay.RelayedTables
+	discarded []relay.DiscardedTable
+}
+
+func (h *partialRelayHolder) SetRelayedTables(tables *relay.RelayedTables) {
+	h.tables = tables
+}
+
+func (h *partialRelayHolder) PartialRelayStatus() *relay.PartialRelayStatus {
+	return &relay.PartialRelayStatus{Enabled: true, DiscardedTables: h.discarded}
+}
+
+func (t *testPartialRelay) TestPartialRelay(c *C) {
+	holder := &partialRelayHolder{
+		RelayHolder: NewDummyRelayHolder(nil),
+		discarded:   []relay.DiscardedTable{{Schema: "db2", Table: "tbl"}},
+	}
+	w := &Worker{
+		cfg:           &config.SourceConfig{PartialRelay: true},
+		subTaskHolder: newSubTaskHolder(),
+		relayHolder:   holder,
+		l:             log.L(),
+	}
+
+	// the subtask needs a discarded table.
+	cfg := &config.SubTaskConfig{Name: "task1", UseRelay: true, BAList: &filter.Rules{DoDBs: []string{"db1", "db2"}}}
+	w.checkDiscardedTables(cfg)
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(cfg, pb.Stage_Running, nil))
+	w.updateRelayedTables()
+	c.Assert(holder.tables.Match("db1", "tbl"), IsTrue)
+	c.Assert(holder.tables.Match("db3", "tbl"), IsFalse)
+
+	// the subtask doesn't use relay.
+	cfg2 := &config.SubTaskConfig{Name: "task2", BAList: &filter.Rules{DoDBs: []string{"db3"}}}
+	w.checkDiscardedTables(cfg2)
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(cfg2, pb.Stage_Running, nil))
+	w.updateRelayedTables()
+	c.Assert(holder.tables.Match("db3", "tbl"), IsFalse)
+
+	info, err := w.GetRelayInfo()
+	c.Assert(err, IsNil)
+	c.Assert(info.PartialRelay.Enabled, IsTrue)
+	c.Assert(info.Warnings, DeepEquals, map[string][]string{"task1": {"`db2`.`tbl`"}})
+	c.Assert(info.SecondsSinceLastWrite, Equals, int64(-1))
+
+	// partial relay is disabled.
+	w.cfg.PartialRelay = false
+	w.subTaskHolder.removeSubTask("task1")
+	w.updateRelayedTables()
+	c.Assert(holder.tables, NotNil)
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// PoolStats represents the stats of the connection pools to upstream and downstream.
+type PoolStats struct {
+	Upstream   conn.PoolStats `json:"upstream"`
+	Downstream conn.PoolStats `json:"downstream"`
+}
+
+// poolStatsProvider is implemented by the units holding connection pools, the dump unit is not included because its
+// connections are managed by dumpling.
+type poolStatsProvider interface {
+	PoolStats() (upstream, downstream conn.PoolStats)
+}
+
+// PoolStats returns the stats of the connection pools of the current unit of the subtask.
+func (st *SubTask) PoolStats() PoolStats {
+	var stats PoolStats
+	if p, ok := st.CurrUnit().(poolStatsProvider); ok {
+		stats.Upstream, stats.Downstream = p.PoolStats()
+	}
+	return stats
+}
+
+// GetConnectionPoolStats returns the stats of the connection pools of the subtask to upstream and downstream, or the
+// sum of all subtasks if name is empty. the rising of wait count and duration indicates the pool is undersized.
+func (w *Worker) GetConnectionPoolStats(name string) (*PoolStats, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	if name != "" {
+		st := w.subTaskHolder.findSubTask(name)
+		if st == nil {
+			return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+		}
+		stats := st.PoolStats()
+		return &stats, nil
+	}
+
+	total := &PoolStats{}
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		stats := st.PoolStats()
+		total.Upstream.Add(stats.Upstream)
+		total.Downstream.Add(stats.Downstream)
+	}
+	return total, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testPoolStats struct{}
+
+var _ = Suite(&testPoolStats{})
+
+type mockPoolUnit struct {
+	*MockUnit
+	upstream, downstream conn.PoolStats
+}
+
+func (m *mockPoolUnit) PoolStats() (upstream, downstream conn.PoolStats) {
+	return m.upstream, m.downstream
+}
+
+func (t *testPoolStats) TestGetConnectionPoolStats(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+
+	stats, err := w.GetConnectionPoolStats("")
+	c.Assert(err, IsNil)
+	c.Assert(*stats, Equals, PoolStats{})
+	_, err = w.GetConnectionPoolStats("not-exist")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+
+	// the dump unit has no pool stats.
+	dumpST := NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-dump"}, pb.Stage_Running, nil)
+	dumpST.setCurrUnit(NewMockUnit(pb.UnitType_Dump))
+	w.subTaskHolder.recordSubTask(dumpST)
+	stats, err = w.GetConnectionPoolStats("test-dump")
+	c.Assert(err, IsNil)
+	c.Assert(*stats, Equals, PoolStats{})
+
+	loadUnit := &mockPoolUnit{
+		MockUnit:   NewMockUnit(pb.UnitType_Load),
+		downstream: conn.PoolStats{InUse: 4, Idle: 1, WaitCount: 2, WaitDuration: time.Second},
+	}
+	loadST := NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-load"}, pb.Stage_Running, nil)
+	loadST.setCurrUnit(loadUnit)
+	w.subTaskHolder.recordSubTask(loadST)
+	syncUnit := &mockPoolUnit{
+		MockUnit:   NewMockUnit(pb.UnitType_Sync),
+		upstream:   conn.PoolStats{InUse: 1, Idle: 2},
+		downstream: conn.PoolStats{MaxOpen: 16, InUse: 8, WaitCount: 3, WaitDuration: 2 * time.Second},
+	}
+	syncST := NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-sync"}, pb.Stage_Running, nil)
+	syncST.setCurrUnit(syncUnit)
+	w.subTaskHolder.recordSubTask(syncST)
+
+	stats, err = w.GetConnectionPoolStats("test-sync")
+	c.Assert(err, IsNil)
+	c.Assert(*stats, Equals, PoolStats{Upstream: syncUnit.upstream, Downstream: syncUnit.downstream})
+
+	stats, err = w.GetConnectionPoolStats("")
+	c.Assert(err, IsNil)
+	c.Assert(stats.Upstream, Equals, conn.PoolStats{InUse: 1, Idle: 2})
+	c.Assert(stats.Downstream, Equals, conn.PoolStats{MaxOpen: 16, InUse: 12, Idle: 1, WaitCount: 5, WaitDuration: 3 * time.Second})
+
+	w.closed.Set(closedTrue)
+	_, err = w.GetConnectionPoolStats("")
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(err), IsTrue)
+}
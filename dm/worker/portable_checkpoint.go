@@ -0,0 +1,92 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// ExportCheckpoint exports the flushed checkpoint of the sync unit in the portable format.
+func (st *SubTask) ExportCheckpoint() ([]byte, error) {
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.ExportCheckpoint()
+}
+
+// ImportCheckpoint replaces the checkpoint of the paused sync unit with the one exported in the portable format.
+func (st *SubTask) ImportCheckpoint(ctx context.Context, data []byte) (from, to binlog.Location, err error) {
+	if stage := st.Stage(); stage != pb.Stage_Paused {
+		return from, to, terror.ErrWorkerNotPausedStage.Generate(stage.String())
+	}
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return from, to, err
+	}
+	return syncUnit.ImportCheckpoint(ctx, data)
+}
+
+// ExportSubTaskCheckpoint exports the flushed checkpoint of the subtask in the sync phase, in a versioned JSON format
+// (syncer.PortableCheckpoint) without anything specific to this cluster, to be imported by ImportSubTaskCheckpoint
+// of a subtask in another DM cluster replicating from the same upstream.
+func (w *Worker) ExportSubTaskCheckpoint(name string) ([]byte, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.ExportCheckpoint()
+}
+
+// ImportSubTaskCheckpoint replaces the checkpoint of the paused subtask in the sync phase with the one exported by
+// ExportSubTaskCheckpoint, after validating it's compatible with the upstream of the subtask. the subtask is kept
+// paused, and replicates from the imported checkpoint after resumed, so a migration can be moved between clusters
+// without re-dumping, by starting the subtask in incremental mode and importing the checkpoint before it replicates.
+func (w *Worker) ImportSubTaskCheckpoint(name string, data []byte) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "ImportSubTaskCheckpoint", auditArgs(map[string]interface{}{"task": name, "checkpoint": string(data)}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, utils.DefaultDBTimeout)
+	defer cancel()
+	from, to, err := st.ImportCheckpoint(ctx, data)
+	if err != nil {
+		return err
+	}
+	w.l.Warn("sub task imported checkpoint, it replicates from the imported one after resumed", zap.String("task", name), zap.Stringer("from", from), zap.Stringer("to", to))
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/syncer"
+)
+
+type testPortableCheckpoint struct{}
+
+var _ = Suite(&testPortableCheckpoint{})
+
+func (t *testPortableCheckpoint) TestExportImportSubTaskCheckpoint(c *C) {
+	w := &Worker{subTaskHolder: newSubTaskHolder(), l: log.L(), ctx: context.Background()}
+	w.closed.Set(closedFalse)
+	cfg := &config.SubTaskConfig{Name: "test-portable-checkpoint", Flavor: "mysql"}
+	st := NewSubTaskWithStage(cfg, pb.Stage_Running, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	w.subTaskHolder.recordSubTask(st)
+
+	_, err := w.ExportSubTaskCheckpoint("not-exist")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(w.ImportSubTaskCheckpoint("not-exist", nil)), IsTrue)
+
+	// not in the sync phase.
+	_, err = w.ExportSubTaskCheckpoint(cfg.Name)
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(err), IsTrue)
+	// not paused.
+	c.Assert(terror.ErrWorkerNotPausedStage.Equal(w.ImportSubTaskCheckpoint(cfg.Name, nil)), IsTrue)
+	st.setStage(pb.Stage_Paused)
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(w.ImportSubTaskCheckpoint(cfg.Name, nil)), IsTrue)
+
+	// no checkpoint yet.
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	_, err = w.ExportSubTaskCheckpoint(cfg.Name)
+	c.Assert(terror.ErrSyncerInvalidPortableCheckpoint.Equal(err), IsTrue)
+	// can't be decoded.
+	c.Assert(terror.ErrSyncerInvalidPortableCheckpoint.Equal(w.ImportSubTaskCheckpoint(cfg.Name, []byte("{"))), IsTrue)
+}
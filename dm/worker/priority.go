@@ -0,0 +1,186 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dumpling"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// priorityWeights are the relative weights of the priority classes when subtasks contend for resources.
+var priorityWeights = map[string]int{
+	config.PriorityHigh:   4,
+	config.PriorityNormal: 2,
+	config.PriorityLow:    1,
+}
+
+// poolSizeSetter is implemented by the units whose number of concurrent workers and connections can be set.
+type poolSizeSetter interface {
+	SetPoolSize(poolSize int)
+}
+
+// PriorityClass returns the effective priority class of the subtask, it's the one set at runtime or the one in config.
+func (st *SubTask) PriorityClass() string {
+	if class := st.priorityClass.Get(); class != "" {
+		return class
+	}
+	if st.cfg.PriorityClass != "" {
+		return st.cfg.PriorityClass
+	}
+	return config.PriorityNormal
+}
+
+func (st *SubTask) priorityWeight() int {
+	return priorityWeights[st.PriorityClass()]
+}
+
+// setResourceShare sets the share of the dump threads and the load pool size in config applied to the units when
+// they're created, it's in (0, 1], and 0 means the full share.
+func (st *SubTask) setResourceShare(share float64) {
+	st.Lock()
+	defer st.Unlock()
+	st.resourceShare = share
+}
+
+// applyResourceShare scales down the dump threads and the load pool size of the units by the resource share, the dump
+// concurrency set at runtime is kept as is.
+func (st *SubTask) applyResourceShare() {
+	st.RLock()
+	share, dumpConcurrency := st.resourceShare, st.dumpConcurrency
+	st.RUnlock()
+	if share <= 0 || share >= 1 {
+		return
+	}
+
+	if dumpUnit := st.dumpUnit(); dumpUnit != nil && dumpConcurrency == nil {
+		concurrency := dumpling.ConcurrencyFromConfig(st.cfg)
+		concurrency.Threads = scaleByShare(concurrency.Threads, share)
+		dumpUnit.SetConcurrency(concurrency)
+	}
+	for _, u := range st.units {
+		if setter, ok := u.(poolSizeSetter); ok {
+			setter.SetPoolSize(scaleByShare(st.cfg.PoolSize, share))
+		}
+	}
+	st.l.Info("scale down resources by priority class", zap.String("priority class", st.PriorityClass()), zap.Float64("share", share))
+}
+
+// scaleByShare scales n by share, but keeps it at least 1.
+func scaleByShare(n int, share float64) int {
+	if n <= 0 {
+		return n
+	}
+	if scaled := int(float64(n) * share); scaled > 1 {
+		return scaled
+	}
+	return 1
+}
+
+// resourceShare returns the share of resources of the subtask contending with the others which are dumping or
+// loading, it's the weight of its priority class relative to the highest one of them, so the subtask is only scaled
+// down in favor of higher priority ones, and gets the full share if there is no contention.
+func (w *Worker) resourceShare(st *SubTask) float64 {
+	weight, maxWeight := st.priorityWeight(), st.priorityWeight()
+	for _, other := range w.subTaskHolder.getAllSubTasks() {
+		if other == st || !other.contendingResources() {
+			continue
+		}
+		if otherWeight := other.priorityWeight(); otherWeight > maxWeight {
+			maxWeight = otherWeight
+		}
+	}
+	return float64(weight) / float64(maxWeight)
+}
+
+// contendingResources returns whether the subtask is dumping or loading, or will do it when resumed.
+func (st *SubTask) contendingResources() bool {
+	if st.cfg.Mode == config.ModeIncrement {
+		return false
+	}
+	switch st.Stage() {
+	case pb.Stage_New, pb.Stage_Running, pb.Stage_Paused:
+	default:
+		return false
+	}
+	cu := st.CurrUnit()
+	return cu == nil || cu.Type() != pb.UnitType_Sync
+}
+
+// SetSubTaskPriorityClass sets the priority class of the subtask, the subtasks of higher priority get the dump threads
+// and the load connections in config while the lower priority ones contending with them are scaled down, and their
+// status is collected first. the share of resources is decided when the subtask is started, so it takes effect on
+// the status collection immediately, and on the dump and load units when the subtask is started next time.
+func (w *Worker) SetSubTaskPriorityClass(name, class string) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetSubTaskPriorityClass", auditArgs(map[string]interface{}{"task": name, "priority-class": class}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if _, ok := priorityWeights[class]; !ok {
+		return terror.ErrConfigPriorityClassNotSupport.Generate(class)
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	st.priorityClass.Set(class)
+	w.l.Info("set priority class of sub task", zap.String("task", name), zap.String("priority class", class))
+	return nil
+}
+
+// GetSubTaskPriorityClasses returns the effective priority classes of all subtasks.
+// return map{task name -> priority class}.
+func (w *Worker) GetSubTaskPriorityClasses() (map[string]string, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	sts := w.subTaskHolder.getAllSubTasks()
+	classes := make(map[string]string, len(sts))
+	for name, st := range sts {
+		classes[name] = st.PriorityClass()
+	}
+	return classes, nil
+}
+
+// orderByPriority returns the indexes of names ordered by the priority of the subtasks descending, the subtasks not
+// started are treated as normal priority.
+func orderByPriority(names []string, sts map[string]*SubTask) []int {
+	weights := make([]int, len(names))
+	order := make([]int, len(names))
+	for i, name := range names {
+		order[i] = i
+		weights[i] = priorityWeights[config.PriorityNormal]
+		if st := sts[name]; st != nil {
+			weights[i] = st.priorityWeight()
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return weights[order[i]] > weights[order[j]]
+	})
+	return order
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/dumpling"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testPriority struct{}
+
+var _ = Suite(&testPriority{})
+
+type mockPoolSizeUnit struct {
+	*MockUnit
+	poolSize int
+}
+
+func (m *mockPoolSizeUnit) SetPoolSize(poolSize int) {
+	m.poolSize = poolSize
+}
+
+func newPrioritySubTask(name, mode, class string, stage pb.Stage) *SubTask {
+	cfg := &config.SubTaskConfig{
+		Name:           name,
+		Mode:           mode,
+		PriorityClass:  class,
+		MydumperConfig: config.MydumperConfig{Threads: 8},
+		LoaderConfig:   config.LoaderConfig{PoolSize: 16},
+	}
+	return NewSubTaskWithStage(cfg, stage, nil)
+}
+
+func (t *testPriority) TestResourceShare(c *C) {
+	w := &Worker{subTaskHolder: newSubTaskHolder(), l: log.L()}
+	low := newPrioritySubTask("low", config.ModeAll, config.PriorityLow, pb.Stage_New)
+	w.subTaskHolder.recordSubTask(low)
+	// no contention.
+	c.Assert(w.resourceShare(low), Equals, 1.0)
+
+	normal := newPrioritySubTask("normal", config.ModeFull, "", pb.Stage_Running)
+	w.subTaskHolder.recordSubTask(normal)
+	c.Assert(w.resourceShare(low), Equals, 0.5)
+	c.Assert(w.resourceShare(normal), Equals, 1.0)
+
+	// the ones in sync phase, in incremental mode or finished don't contend.
+	high := newPrioritySubTask("high", config.ModeAll, config.PriorityHigh, pb.Stage_Running)
+	high.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+	w.subTaskHolder.recordSubTask(high)
+	w.subTaskHolder.recordSubTask(newPrioritySubTask("high-incr", config.ModeIncrement, config.PriorityHigh, pb.Stage_Running))
+	w.subTaskHolder.recordSubTask(newPrioritySubTask("high-finished", config.ModeFull, config.PriorityHigh, pb.Stage_Finished))
+	c.Assert(w.resourceShare(low), Equals, 0.5)
+
+	high.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	c.Assert(w.resourceShare(low), Equals, 0.25)
+	c.Assert(w.resourceShare(normal), Equals, 0.5)
+	c.Assert(w.resourceShare(high), Equals, 1.0)
+}
+
+func (t *testPriority) TestApplyResourceShare(c *C) {
+	st := newPrioritySubTask("test-apply-share", config.ModeAll, config.PriorityLow, pb.Stage_New)
+	dumpUnit := dumpling.NewDumpling(st.cfg)
+	loadUnit := &mockPoolSizeUnit{MockUnit: NewMockUnit(pb.UnitType_Load)}
+	st.units = []unit.Unit{dumpUnit, loadUnit}
+
+	// the full share.
+	st.applyResourceShare()
+	c.Assert(dumpUnit.Concurrency(), Equals, dumpling.Concurrency{Threads: 8})
+	c.Assert(loadUnit.poolSize, Equals, 0)
+
+	st.setResourceShare(0.25)
+	st.applyResourceShare()
+	c.Assert(dumpUnit.Concurrency(), Equals, dumpling.Concurrency{Threads: 2})
+	c.Assert(loadUnit.poolSize, Equals, 4)
+
+	// the dump concurrency set at runtime is kept.
+	dumpUnit = dumpling.NewDumpling(st.cfg)
+	st.units[0] = dumpUnit
+	c.Assert(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 6}), IsNil)
+	dumpUnit.SetConcurrency(dumpling.Concurrency{Threads: 6})
+	st.setResourceShare(0.1)
+	st.applyResourceShare()
+	c.Assert(dumpUnit.Concurrency(), Equals, dumpling.Concurrency{Threads: 6})
+	c.Assert(loadUnit.poolSize, Equals, 1)
+
+	c.Assert(scaleByShare(0, 0.5), Equals, 0)
+	c.Assert(scaleByShare(3, 0.5), Equals, 1)
+	c.Assert(scaleByShare(16, 0.5), Equals, 8)
+}
+
+func (t *testPriority) TestSetSubTaskPriorityClass(c *C) {
+	w := &Worker{subTaskHolder: newSubTaskHolder(), l: log.L()}
+	w.closed.Set(closedFalse)
+	w.subTaskHolder.recordSubTask(newPrioritySubTask("a", config.ModeAll, "", pb.Stage_Running))
+	w.subTaskHolder.recordSubTask(newPrioritySubTask("b", config.ModeAll, config.PriorityLow, pb.Stage_Running))
+
+	classes, err := w.GetSubTaskPriorityClasses()
+	c.Assert(err, IsNil)
+	c.Assert(classes, DeepEquals, map[string]string{"a": config.PriorityNormal, "b": config.PriorityLow})
+
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(w.SetSubTaskPriorityClass("not-exist", config.PriorityHigh)), IsTrue)
+	c.Assert(terror.ErrConfigPriorityClassNotSupport.Equal(w.SetSubTaskPriorityClass("a", "urgent")), IsTrue)
+	c.Assert(w.SetSubTaskPriorityClass("b", config.PriorityHigh), IsNil)
+	classes, err = w.GetSubTaskPriorityClasses()
+	c.Assert(err, IsNil)
+	c.Assert(classes, DeepEquals, map[string]string{"a": config.PriorityNormal, "b": config.PriorityHigh})
+
+	// collected in order of priority.
+	w.subTaskHolder.recordSubTask(newPrioritySubTask("c", config.ModeAll, config.PriorityLow, pb.Stage_Running))
+	names := []string{"a", "b", "c", "not-started"}
+	c.Assert(orderByPriority(names, w.subTaskHolder.getAllSubTasks()), DeepEquals, []int{1, 0, 3, 2})
+}
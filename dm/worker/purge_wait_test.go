@@ -0,0 +1,147 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/sync2"
+	"go.etcd.io/etcd/clientv3"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/relay/purger"
+)
+
+type testRelayPurgeWait struct{}
+
+var _ = Suite(&testRelayPurgeWait{})
+
+// purgingPurger is a purger whose purging state is set by tests.
+type purgingPurger struct {
+	purger.Purger
+	purging sync2.AtomicBool
+}
+
+func (p *purgingPurger) Purging() bool {
+	return p.purging.Get()
+}
+
+func (t *testRelayPurgeWait) TestStartSubTaskWaitRelayPurged(c *C) {
+	defer func(interval time.Duration) {
+		waitRelayPurgedInterval = interval
+		createUnits = createRealUnits
+	}(waitRelayPurgedInterval)
+	waitRelayPurgedInterval = 10 * time.Millisecond
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{NewMockUnit(pb.UnitType_Sync)}
+	}
+
+	p := &purgingPurger{}
+	w := &Worker{
+		cfg:           &config.SourceConfig{},
+		subTaskHolder: newSubTaskHolder(),
+		relayPurger:   p,
+		l:             log.L(),
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	defer w.cancel()
+	w.closed.Set(closedFalse)
+
+	// fail at once if not waiting.
+	p.purging.Set(true)
+	c.Assert(w.StartSubTask(&config.SubTaskConfig{Name: "task-1"}, pb.Stage_Paused), IsNil)
+	c.Assert(w.subTaskHolder.findSubTask("task-1").Result().String(), Matches, ".*relay log purger is purging.*")
+
+	// the purging is not finished in time.
+	w.SetRelayPurgeWaitTimeout(50 * time.Millisecond)
+	start := time.Now()
+	c.Assert(w.StartSubTask(&config.SubTaskConfig{Name: "task-2"}, pb.Stage_Paused), IsNil)
+	c.Assert(time.Since(start) >= 50*time.Millisecond, IsTrue)
+	c.Assert(w.subTaskHolder.findSubTask("task-2").Result().String(), Matches, ".*relay log purger is purging.*")
+
+	// the purging is finished when waiting.
+	w.SetRelayPurgeWaitTimeout(time.Minute)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		p.purging.Set(false)
+	}()
+	c.Assert(w.StartSubTask(&config.SubTaskConfig{Name: "task-3"}, pb.Stage_Paused), IsNil)
+	st := w.subTaskHolder.findSubTask("task-3")
+	c.Assert(st.Result(), IsNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+
+	w.subTaskHolder.closeAllSubTasks()
+}
+
+// dryRunPurger is a purger which records whether Do is called and returns fixed results for dry run and GTID.
+type dryRunPurger struct {
+	purger.Purger
+	done bool
+}
+
+func (p *dryRunPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
+	p.done = true
+	return nil
+}
+
+func (p *dryRunPurger) DryRun(ctx context.Context, req *pb.PurgeRelayRequest) (*purger.DryRunResult, error) {
+	return &purger.DryRunResult{DryRun: true, Files: []string{"mysql-bin.000001"}, Dirs: []string{}, Bytes: 100}, nil
+}
+
+func (p *dryRunPurger) PurgeByGTID(ctx context.Context, gs gtid.Set) (*purger.GTIDPurgeResult, error) {
+	return &purger.GTIDPurgeResult{
+		Files:                []string{"mysql-bin.000001"},
+		EarliestRetainedFile: "mysql-bin.000002",
+		EarliestRetainedGTID: gs.String(),
+	}, nil
+}
+
+func (t *testRelayPurgeWait) TestPurgeRelayDryRun(c *C) {
+	p := &dryRunPurger{}
+	w := &Worker{
+		cfg:           &config.SourceConfig{Flavor: mysql.MySQLFlavor},
+		subTaskHolder: newSubTaskHolder(),
+		relayPurger:   p,
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+
+	msg, err := w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{Inactive: true, DryRun: true})
+	c.Assert(err, IsNil)
+	c.Assert(msg, Equals, `{"dry-run":true,"files":["mysql-bin.000001"],"dirs":[],"bytes":100}`)
+	c.Assert(p.done, IsFalse)
+
+	msg, err = w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{Inactive: true})
+	c.Assert(err, IsNil)
+	c.Assert(msg, Equals, "")
+	c.Assert(p.done, IsTrue)
+
+	// purge by GTID set
+	p.done = false
+	gs := "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"
+	msg, err = w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{GtidSet: gs})
+	c.Assert(err, IsNil)
+	c.Assert(msg, Equals, `{"files":["mysql-bin.000001"],"earliest-retained-file":"mysql-bin.000002","earliest-retained-gtid":"`+gs+`"}`)
+	c.Assert(p.done, IsFalse)
+
+	_, err = w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{GtidSet: "invalid-gtid"})
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/loader"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/syncer"
+)
+
+// checkpointRecentFactor decides whether a checkpoint is recent, it should be flushed in
+// checkpointRecentFactor * checkpoint-flush-interval.
+const checkpointRecentFactor = 2
+
+// SubTaskCost represents the resources used by a subtask, to estimate the cost of moving it.
+type SubTaskCost struct {
+	// Throughput is binlog events per second in the sync unit, or bytes per second in the load unit.
+	Throughput int64 `json:"throughput"`
+	// BufferedJobs is the number of jobs buffered in memory by the sync unit, it reflects the memory usage.
+	BufferedJobs int `json:"buffered-jobs"`
+	// Connections is the estimated number of database connections used by the current unit.
+	Connections int `json:"connections"`
+}
+
+// SubTaskRebalanceHint represents whether and how costly a subtask can be moved to another worker.
+type SubTaskRebalanceHint struct {
+	Name       string      `json:"name"`
+	Stage      string      `json:"stage"`
+	Unit       string      `json:"unit"`
+	Cost       SubTaskCost `json:"cost"`
+	SafeToMove bool        `json:"safe-to-move"`
+	Reason     string      `json:"reason,omitempty"` // why it's not safe to move now
+}
+
+// RebalanceHints represents the rebalance hints of all subtasks in the worker.
+type RebalanceHints struct {
+	Source   string                 `json:"source"`
+	SubTasks []SubTaskRebalanceHint `json:"subtasks"` // sorted by name
+}
+
+// GetRebalanceHints returns the cost and whether it's safe to move now of each subtask, to help a scheduler choosing
+// the cheapest and safest subtask to move off this worker. a subtask is safe to move only if it's not in the full
+// mode (the dumped files are on the local disk), not in the middle of a sharding DDL lock, and its checkpoint is
+// flushed recently.
+func (w *Worker) GetRebalanceHints() (*RebalanceHints, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	hints := &RebalanceHints{
+		Source:   w.cfg.SourceID,
+		SubTasks: make([]SubTaskRebalanceHint, 0),
+	}
+	now := time.Now()
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		hints.SubTasks = append(hints.SubTasks, st.rebalanceHint(now))
+	}
+	sort.Slice(hints.SubTasks, func(i, j int) bool {
+		return hints.SubTasks[i].Name < hints.SubTasks[j].Name
+	})
+	return hints, nil
+}
+
+// rebalanceHint returns the rebalance hint of the subtask at now.
+func (st *SubTask) rebalanceHint(now time.Time) SubTaskRebalanceHint {
+	st.RLock()
+	name := st.cfg.Name
+	threads, poolSize, workerCount := st.cfg.Threads, st.cfg.PoolSize, st.cfg.WorkerCount
+	st.RUnlock()
+
+	stage := st.Stage()
+	hint := SubTaskRebalanceHint{
+		Name:       name,
+		Stage:      stage.String(),
+		SafeToMove: true,
+	}
+	cu := st.CurrUnit()
+	if cu == nil || stage == pb.Stage_Finished || stage == pb.Stage_Stopped {
+		// nothing is running.
+		if cu != nil {
+			hint.Unit = cu.Type().String()
+		}
+		return hint
+	}
+	hint.Unit = cu.Type().String()
+
+	running := stage == pb.Stage_Running
+	switch u := cu.(type) {
+	case *loader.Loader:
+		if running {
+			hint.Cost.Throughput = int64(u.ETA().Rate)
+			hint.Cost.Connections = poolSize + 1 // workers and checkpoint
+		}
+		hint.SafeToMove = false
+		hint.Reason = "the dumped files of the load unit are on the local disk"
+	case *syncer.Syncer:
+		if running {
+			hint.Cost.Throughput = u.RecentTPS()
+			hint.Cost.BufferedJobs = u.BufferedJobCount()
+			hint.Cost.Connections = workerCount + 3 // DML workers, DDL, checkpoint and upstream
+		}
+		if pending := u.PendingShardDDLs(); len(pending) > 0 || st.ShardDDLOperation() != nil {
+			hint.SafeToMove = false
+			hint.Reason = "in the middle of a sharding DDL lock"
+			break
+		}
+		// the checkpoint is flushed when a subtask is paused, so it only needs to be recent when running.
+		interval := u.CheckpointFlushInterval() * checkpointRecentFactor
+		if flushed := u.CheckpointFlushedTime(); running && now.Sub(flushed) > interval {
+			hint.SafeToMove = false
+			hint.Reason = fmt.Sprintf("the checkpoint is not flushed in %s", interval)
+		}
+	default:
+		if cu.Type() == pb.UnitType_Dump {
+			if running {
+				hint.Cost.Connections = threads + 1 // dump threads and the consistency lock
+			}
+			hint.SafeToMove = false
+			hint.Reason = "the dump unit can't be resumed on another worker"
+		}
+	}
+	return hint
+}
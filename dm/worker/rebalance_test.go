@@ -0,0 +1,79 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/loader"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/syncer"
+)
+
+type testRebalance struct{}
+
+var _ = Suite(&testRebalance{})
+
+func (t *testRebalance) TestGetRebalanceHints(c *C) {
+	w := &Worker{
+		cfg:           &config.SourceConfig{SourceID: "mysql-replica-01"},
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	addSubTask := func(name string, stage pb.Stage, newUnit func(cfg *config.SubTaskConfig) unit.Unit) {
+		cfg := &config.SubTaskConfig{Name: name, SourceID: "mysql-replica-01"}
+		cfg.PoolSize = 16
+		cfg.WorkerCount = 16
+		st := NewSubTaskWithStage(cfg, stage, nil)
+		if newUnit != nil {
+			st.setCurrUnit(newUnit(cfg))
+		}
+		w.subTaskHolder.recordSubTask(st)
+	}
+	addSubTask("task-dump", pb.Stage_Running, func(cfg *config.SubTaskConfig) unit.Unit { return NewMockUnit(pb.UnitType_Dump) })
+	addSubTask("task-load", pb.Stage_Running, func(cfg *config.SubTaskConfig) unit.Unit { return loader.NewLoader(cfg) })
+	addSubTask("task-sync-running", pb.Stage_Running, func(cfg *config.SubTaskConfig) unit.Unit { return syncer.NewSyncer(cfg, nil) })
+	addSubTask("task-sync-paused", pb.Stage_Paused, func(cfg *config.SubTaskConfig) unit.Unit { return syncer.NewSyncer(cfg, nil) })
+	addSubTask("task-finished", pb.Stage_Finished, func(cfg *config.SubTaskConfig) unit.Unit { return NewMockUnit(pb.UnitType_Load) })
+	addSubTask("task-new", pb.Stage_New, nil)
+
+	hints, err := w.GetRebalanceHints()
+	c.Assert(err, IsNil)
+	c.Assert(hints.Source, Equals, "mysql-replica-01")
+	c.Assert(hints.SubTasks, HasLen, 6)
+	byName := make(map[string]SubTaskRebalanceHint)
+	for i, hint := range hints.SubTasks {
+		if i > 0 {
+			c.Assert(hints.SubTasks[i-1].Name < hint.Name, IsTrue)
+		}
+		byName[hint.Name] = hint
+	}
+
+	c.Assert(byName["task-dump"].SafeToMove, IsFalse)
+	c.Assert(byName["task-dump"].Unit, Equals, pb.UnitType_Dump.String())
+	c.Assert(byName["task-load"].SafeToMove, IsFalse)
+	c.Assert(byName["task-load"].Cost.Connections, Equals, 17)
+	// the checkpoint is not flushed yet.
+	c.Assert(byName["task-sync-running"].SafeToMove, IsFalse)
+	c.Assert(byName["task-sync-running"].Reason, Matches, "the checkpoint is not flushed in .*")
+	c.Assert(byName["task-sync-running"].Cost.Connections, Equals, 19)
+	c.Assert(byName["task-sync-paused"].SafeToMove, IsTrue)
+	c.Assert(byName["task-sync-paused"].Cost, DeepEquals, SubTaskCost{})
+	c.Assert(byName["task-finished"].SafeToMove, IsTrue)
+	c.Assert(byName["task-new"].SafeToMove, IsTrue)
+	c.Assert(byName["task-new"].Unit, Equals, "")
+}
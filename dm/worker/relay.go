@@ -16,14 +16,17 @@ package worker
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
 	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/conn"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/streamer"
 	"github.com/pingcap/dm/pkg/terror"
@@ -78,11 +81,15 @@ type realRelayHolder struct {
 // NewRealRelayHolder creates a new RelayHolder
 func NewRealRelayHolder(sourceCfg *config.SourceConfig) RelayHolder {
 	cfg := relay.FromSourceCfg(sourceCfg)
+	newRelay := relay.NewRelay
+	if len(cfg.RelaySource) > 0 {
+		newRelay = relay.NewRemoteRelay
+	}
 
 	h := &realRelayHolder{
 		cfg:   sourceCfg,
 		stage: pb.Stage_New,
-		relay: relay.NewRelay(cfg),
+		relay: newRelay(cfg),
 		l:     log.With(zap.String("component", "relay holder")),
 	}
 	h.closed.Set(closedTrue)
@@ -290,7 +297,7 @@ func (h *realRelayHolder) Update(ctx context.Context, sourceCfg *config.SourceCo
 
 	stage := h.Stage()
 
-	if stage == pb.Stage_Paused {
+	if stage == pb.Stage_New || stage == pb.Stage_Paused {
 		err := h.relay.Reload(relayCfg)
 		if err != nil {
 			return err
@@ -320,6 +327,95 @@ func (h *realRelayHolder) EarliestActiveRelayLog() *streamer.RelayLogInfo {
 	return h.relay.ActiveRelayLog()
 }
 
+// partialRelayer is implemented by the relay unit (and its holder) supporting partial relay, the relay unit fetching
+// relay log from a relay source doesn't support it.
+type partialRelayer interface {
+	SetRelayedTables(tables *relay.RelayedTables)
+	PartialRelayStatus() *relay.PartialRelayStatus
+}
+
+// SetRelayedTables implements partialRelayer.SetRelayedTables
+func (h *realRelayHolder) SetRelayedTables(tables *relay.RelayedTables) {
+	if pr, ok := h.relay.(partialRelayer); ok {
+		pr.SetRelayedTables(tables)
+	}
+}
+
+// PartialRelayStatus implements partialRelayer.PartialRelayStatus, it returns nil if partial relay is not supported.
+func (h *realRelayHolder) PartialRelayStatus() *relay.PartialRelayStatus {
+	if pr, ok := h.relay.(partialRelayer); ok {
+		return pr.PartialRelayStatus()
+	}
+	return nil
+}
+
+// SetDBProvider implements dbProviderSetter.SetDBProvider.
+func (h *realRelayHolder) SetDBProvider(dbProvider conn.DBProvider) {
+	if setter, ok := h.relay.(dbProviderSetter); ok {
+		setter.SetDBProvider(dbProvider)
+	}
+}
+
+type connectionLister interface {
+	ActiveConnections() []conn.ConnInfo
+}
+
+// ActiveConnections implements connectionLister.ActiveConnections.
+func (h *realRelayHolder) ActiveConnections() []conn.ConnInfo {
+	if cl, ok := h.relay.(connectionLister); ok {
+		return cl.ActiveConnections()
+	}
+	return nil
+}
+
+// stallDetector is implemented by the relay unit (and its holder) reading binlog from upstream.
+type stallDetector interface {
+	LastWriteTime() time.Time
+	DetectStall(ctx context.Context) (*relay.StallReport, error)
+}
+
+// LastWriteTime implements stallDetector.LastWriteTime, it's zero if not supported.
+func (h *realRelayHolder) LastWriteTime() time.Time {
+	if sd, ok := h.relay.(stallDetector); ok {
+		return sd.LastWriteTime()
+	}
+	return time.Time{}
+}
+
+// DetectStall implements stallDetector.DetectStall.
+func (h *realRelayHolder) DetectStall(ctx context.Context) (*relay.StallReport, error) {
+	if sd, ok := h.relay.(stallDetector); ok {
+		return sd.DetectStall(ctx)
+	}
+	return nil, terror.ErrWorkerRelayOperNotSupport.Generate("detect-write-stall")
+}
+
+// relayWriteHead is implemented by the relay unit (and its holder) writing relay log.
+type relayWriteHead interface {
+	WritePos() (string, mysql.Position)
+}
+
+// WritePos implements relayWriteHead.WritePos, the sub directory is empty if not supported.
+func (h *realRelayHolder) WritePos() (string, mysql.Position) {
+	if wh, ok := h.relay.(relayWriteHead); ok {
+		return wh.WritePos()
+	}
+	return "", mysql.Position{}
+}
+
+// relayReconnector is implemented by the relay unit (and its holder) restarting after upstream disconnected.
+type relayReconnector interface {
+	ReconnectStats() relay.ReconnectStats
+}
+
+// ReconnectStats implements relayReconnector.ReconnectStats, it's empty if not supported.
+func (h *realRelayHolder) ReconnectStats() relay.ReconnectStats {
+	if rc, ok := h.relay.(relayReconnector); ok {
+		return rc.ReconnectStats()
+	}
+	return relay.ReconnectStats{}
+}
+
 /******************** dummy relay holder ********************/
 
 type dummyRelayHolder struct {
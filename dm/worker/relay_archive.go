@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/streamer"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// checkRelayArchive checks whether the archived relay log in dir can be replayed from `from` to `to`, the archive should
+// have the same layout as the relay directory, and the relay log files in the sub directories between them should be
+// contiguous and contain both positions.
+func checkRelayArchive(dir string, from, to mysql.Position) error {
+	if !utils.IsDirExists(dir) {
+		return terror.ErrWorkerInvalidRelayArchive.Generate(dir, "it's not a directory")
+	}
+	if binlog.ComparePosition(from, to) >= 0 {
+		return terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("the checkpoint %s is not before the position %s to stop at", from, to))
+	}
+	uuids, err := utils.ParseUUIDIndex(filepath.Join(dir, utils.UUIDIndexFilename))
+	if err != nil {
+		return terror.ErrWorkerInvalidRelayArchive.Delegate(err, dir, "can't read "+utils.UUIDIndexFilename)
+	}
+	if len(uuids) == 0 {
+		return terror.ErrWorkerInvalidRelayArchive.Generate(dir, "no relay sub directory in "+utils.UUIDIndexFilename)
+	}
+
+	fromUUID, _, realFrom, err := binlog.ExtractPos(from, uuids)
+	if err != nil {
+		return terror.ErrWorkerInvalidRelayArchive.Delegate(err, dir, "can't find the sub directory of the checkpoint "+from.String())
+	}
+	toUUID, _, realTo, err := binlog.ExtractPos(to, uuids)
+	if err != nil {
+		return terror.ErrWorkerInvalidRelayArchive.Delegate(err, dir, "can't find the sub directory of the position "+to.String())
+	}
+
+	inRange := false
+	for _, uuid := range uuids {
+		if uuid == fromUUID {
+			inRange = true
+		}
+		if !inRange {
+			continue
+		}
+		files, err2 := collectContiguousBinlogFiles(dir, uuid)
+		if err2 != nil {
+			return err2
+		}
+		if uuid == fromUUID && !containsBinlogFile(files, realFrom.Name) {
+			return terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("the relay log file %s of the checkpoint is not in sub directory %s", realFrom.Name, uuid))
+		}
+		if uuid == toUUID {
+			if !containsBinlogFile(files, realTo.Name) {
+				return terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("the relay log file %s to stop at is not in sub directory %s", realTo.Name, uuid))
+			}
+			if last := files[len(files)-1]; binlog.ConstructFilename(last.BaseName, last.Seq) == realTo.Name {
+				fi, err3 := os.Stat(filepath.Join(dir, uuid, realTo.Name))
+				if err3 != nil {
+					return terror.ErrWorkerInvalidRelayArchive.Delegate(err3, dir, "can't stat "+realTo.Name)
+				}
+				if fi.Size() < int64(realTo.Pos) {
+					return terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("the relay log file %s is shorter than the position %d to stop at", realTo.Name, realTo.Pos))
+				}
+			}
+			return nil
+		}
+	}
+	return terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("the sub directory %s of the position to stop at is before the sub directory %s of the checkpoint", toUUID, fromUUID))
+}
+
+// collectContiguousBinlogFiles collects the relay log files in the sub directory sorted by sequence number, and checks
+// there are no missing files between them.
+func collectContiguousBinlogFiles(dir, uuid string) ([]binlog.Filename, error) {
+	names, err := streamer.CollectAllBinlogFiles(filepath.Join(dir, uuid))
+	if err != nil {
+		return nil, terror.ErrWorkerInvalidRelayArchive.Delegate(err, dir, "can't read sub directory "+uuid)
+	}
+	if len(names) == 0 {
+		return nil, terror.ErrWorkerInvalidRelayArchive.Generate(dir, "no relay log file in sub directory "+uuid)
+	}
+	files := make([]binlog.Filename, 0, len(names))
+	for _, name := range names {
+		// files have been verified by CollectAllBinlogFiles
+		f, _ := binlog.ParseFilename(name)
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].SeqInt64 < files[j].SeqInt64
+	})
+	for i := 1; i < len(files); i++ {
+		if files[i].BaseName != files[0].BaseName {
+			return nil, terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("relay log files %s and %s in sub directory %s have different base names", files[0].BaseName, files[i].BaseName, uuid))
+		}
+		if files[i].SeqInt64 != files[i-1].SeqInt64+1 {
+			return nil, terror.ErrWorkerInvalidRelayArchive.Generate(dir, fmt.Sprintf("relay log files between %s and %s in sub directory %s are missing",
+				binlog.ConstructFilename(files[i-1].BaseName, files[i-1].Seq), binlog.ConstructFilename(files[i].BaseName, files[i].Seq), uuid))
+		}
+	}
+	return files, nil
+}
+
+func containsBinlogFile(files []binlog.Filename, name string) bool {
+	f, err := binlog.ParseFilename(name)
+	if err != nil {
+		return false
+	}
+	first, last := files[0], files[len(files)-1]
+	return f.GreaterThanOrEqualTo(first) && !f.GreaterThan(last)
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+type testRelayArchive struct{}
+
+var _ = Suite(&testRelayArchive{})
+
+// prepareRelayArchive creates an archive with sub directories uuids, and files[i] are the relay log files of uuids[i].
+func prepareRelayArchive(c *C, dir string, uuids []string, files [][]string) {
+	for i, uuid := range uuids {
+		c.Assert(os.MkdirAll(filepath.Join(dir, uuid), 0755), IsNil)
+		for _, name := range files[i] {
+			c.Assert(ioutil.WriteFile(filepath.Join(dir, uuid, name), make([]byte, 1000), 0644), IsNil)
+		}
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, uuid, utils.MetaFilename), nil, 0644), IsNil)
+	}
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, utils.UUIDIndexFilename), []byte(strings.Join(uuids, "\n")+"\n"), 0644), IsNil)
+}
+
+func (t *testRelayArchive) TestCheckRelayArchive(c *C) {
+	uuid1 := "24ecd093-8cec-11e9-aa0d-0242ac170002.000001"
+	uuid2 := "53ea0ed1-9bf8-11e6-8bea-64006a897c73.000002"
+	pos := func(name string, p uint32) mysql.Position {
+		return mysql.Position{Name: name, Pos: p}
+	}
+
+	dir := c.MkDir()
+	prepareRelayArchive(c, dir, []string{uuid1, uuid2}, [][]string{
+		{"mysql-bin.000002", "mysql-bin.000003"},
+		{"mysql-bin.000001", "mysql-bin.000002"},
+	})
+
+	// in one sub directory
+	c.Assert(checkRelayArchive(dir, pos("mysql-bin|000001.000002", 4), pos("mysql-bin|000001.000003", 500)), IsNil)
+	// across sub directories
+	c.Assert(checkRelayArchive(dir, pos("mysql-bin|000001.000002", 4), pos("mysql-bin|000002.000002", 1000)), IsNil)
+	// positions without suffix are in the latest sub directory
+	c.Assert(checkRelayArchive(dir, pos("mysql-bin.000001", 4), pos("mysql-bin.000002", 100)), IsNil)
+
+	err := checkRelayArchive(filepath.Join(dir, "not-exist"), pos("mysql-bin.000001", 4), pos("mysql-bin.000002", 4))
+	c.Assert(err, ErrorMatches, ".*not a directory.*")
+	err = checkRelayArchive(dir, pos("mysql-bin.000002", 4), pos("mysql-bin.000001", 4))
+	c.Assert(err, ErrorMatches, ".*is not before the position.*")
+	err = checkRelayArchive(dir, pos("mysql-bin|000001.000001", 4), pos("mysql-bin|000001.000003", 4))
+	c.Assert(err, ErrorMatches, ".*mysql-bin.000001 of the checkpoint is not in sub directory.*")
+	err = checkRelayArchive(dir, pos("mysql-bin|000001.000002", 4), pos("mysql-bin|000002.000003", 4))
+	c.Assert(err, ErrorMatches, ".*mysql-bin.000003 to stop at is not in sub directory.*")
+	err = checkRelayArchive(dir, pos("mysql-bin|000001.000002", 4), pos("mysql-bin|000001.000003", 2000))
+	c.Assert(err, ErrorMatches, ".*shorter than the position 2000.*")
+	err = checkRelayArchive(dir, pos("mysql-bin|000001.000002", 4), pos("mysql-bin|000003.000001", 4))
+	c.Assert(err, ErrorMatches, ".*can't find the sub directory of the position.*")
+
+	// missing files
+	dir = c.MkDir()
+	prepareRelayArchive(c, dir, []string{uuid1}, [][]string{{"mysql-bin.000001", "mysql-bin.000003"}})
+	err = checkRelayArchive(dir, pos("mysql-bin.000001", 4), pos("mysql-bin.000003", 4))
+	c.Assert(err, ErrorMatches, ".*between mysql-bin.000001 and mysql-bin.000003 .* are missing.*")
+
+	// empty sub directory
+	dir = c.MkDir()
+	prepareRelayArchive(c, dir, []string{uuid1, uuid2}, [][]string{nil, {"mysql-bin.000001"}})
+	err = checkRelayArchive(dir, pos("mysql-bin|000001.000001", 4), pos("mysql-bin|000002.000001", 4))
+	c.Assert(err, ErrorMatches, ".*no relay log file in sub directory "+uuid1+".*")
+
+	// no UUID index
+	dir = c.MkDir()
+	err = checkRelayArchive(dir, pos("mysql-bin.000001", 4), pos("mysql-bin.000002", 4))
+	c.Assert(err, ErrorMatches, ".*no relay sub directory.*")
+}
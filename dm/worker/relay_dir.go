@@ -0,0 +1,156 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// checkNewRelayDir checks whether the relay log in oldDir can be moved to newDir,
+// newDir should be empty or not exist, outside oldDir, writable and have enough space.
+// it returns whether newDir is created by it.
+func checkNewRelayDir(oldDir, newDir string) (created bool, err error) {
+	rel, err := filepath.Rel(oldDir, newDir)
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false, terror.ErrWorkerInvalidRelayDir.Generate(newDir, "it's the same as or inside the current relay directory")
+	}
+
+	if utils.IsDirExists(newDir) {
+		files, err2 := ioutil.ReadDir(newDir)
+		if err2 != nil {
+			return false, terror.ErrWorkerInvalidRelayDir.Delegate(err2, newDir, "can't read it")
+		}
+		if len(files) > 0 {
+			return false, terror.ErrWorkerInvalidRelayDir.Generate(newDir, "it's not empty")
+		}
+	} else {
+		if err2 := os.MkdirAll(newDir, 0755); err2 != nil {
+			return false, terror.ErrWorkerRelayDirNotWritable.Delegate(err2, newDir)
+		}
+		created = true
+	}
+	defer func() {
+		if err != nil {
+			cleanRelayDir(newDir, created)
+		}
+	}()
+
+	f, err := ioutil.TempFile(newDir, ".relay-dir-check")
+	if err != nil {
+		return created, terror.ErrWorkerRelayDirNotWritable.Delegate(err, newDir)
+	}
+	f.Close()
+	if err = os.Remove(f.Name()); err != nil {
+		return created, terror.ErrWorkerRelayDirNotWritable.Delegate(err, newDir)
+	}
+
+	required, err := dirSize(oldDir)
+	if err != nil {
+		return created, terror.ErrWorkerMoveRelayDir.Delegate(err, oldDir, newDir)
+	}
+	size, err := utils.GetStorageSize(newDir)
+	if err != nil {
+		return created, err
+	}
+	if size.Available < uint64(required) {
+		return created, terror.ErrWorkerRelayDirNoEnoughSpace.Generate(newDir, required, size.Available)
+	}
+	return created, nil
+}
+
+// cleanRelayDir removes the files copied into dir, and dir itself if it's created when moving relay log.
+func cleanRelayDir(dir string, created bool) {
+	if created {
+		os.RemoveAll(dir)
+		return
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		os.RemoveAll(filepath.Join(dir, f.Name()))
+	}
+}
+
+// dirSize returns the total size of regular files in dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// copyRelayDir copies all files in srcDir to dstDir with the same structure,
+// so the UUID sub directories and the index file are kept.
+func copyRelayDir(srcDir, dstDir string) error {
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dstDir, rel)
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			return nil // ignore symlinks and other special files
+		}
+	})
+	if err != nil {
+		return terror.ErrWorkerMoveRelayDir.Delegate(err, srcDir, dstDir)
+	}
+	return nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err = out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
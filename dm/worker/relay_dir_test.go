@@ -0,0 +1,197 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/tikv/pd/pkg/tempurl"
+	"go.etcd.io/etcd/clientv3"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/ha"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+type testRelayDir struct{}
+
+var _ = Suite(&testRelayDir{})
+
+func prepareRelayDir(c *C, dir string) {
+	uuid := "24ecd093-8cec-11e9-aa0d-0242ac170002.000001"
+	c.Assert(os.MkdirAll(filepath.Join(dir, uuid), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, utils.UUIDIndexFilename), []byte(uuid+"\n"), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, uuid, "mysql-bin.000001"), []byte("binlog data"), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, uuid, "relay.meta"), []byte("binlog-name = \"mysql-bin.000001\""), 0644), IsNil)
+}
+
+func (t *testRelayDir) TestCheckNewRelayDir(c *C) {
+	oldDir := c.MkDir()
+	prepareRelayDir(c, oldDir)
+
+	// same as or inside the old dir
+	_, err := checkNewRelayDir(oldDir, oldDir)
+	c.Assert(err, ErrorMatches, ".*same as or inside the current relay directory.*")
+	_, err = checkNewRelayDir(oldDir, filepath.Join(oldDir, "sub"))
+	c.Assert(err, ErrorMatches, ".*same as or inside the current relay directory.*")
+
+	// not empty
+	_, err = checkNewRelayDir(oldDir, filepath.Dir(oldDir))
+	c.Assert(err, ErrorMatches, ".*not empty.*")
+
+	// empty dir
+	newDir := c.MkDir()
+	created, err := checkNewRelayDir(oldDir, newDir)
+	c.Assert(err, IsNil)
+	c.Assert(created, IsFalse)
+
+	// not exist
+	newDir = filepath.Join(c.MkDir(), "relay")
+	created, err = checkNewRelayDir(oldDir, newDir)
+	c.Assert(err, IsNil)
+	c.Assert(created, IsTrue)
+	c.Assert(utils.IsDirExists(newDir), IsTrue)
+	files, err := ioutil.ReadDir(newDir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+}
+
+func (t *testRelayDir) TestMoveRelayDir(c *C) {
+	oldDir := filepath.Join(c.MkDir(), "relay")
+	prepareRelayDir(c, oldDir)
+
+	cfg := &config.SourceConfig{RelayDir: oldDir}
+	w := &Worker{
+		cfg:           cfg,
+		subTaskHolder: newSubTaskHolder(),
+		relayHolder:   NewDummyRelayHolder(cfg),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: "relay-task", UseRelay: true, RelayDir: oldDir}, pb.Stage_Paused, nil)
+	w.subTaskHolder.recordSubTask(st)
+
+	// fail to move, nothing changed
+	notEmpty := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(notEmpty, "file"), nil, 0644), IsNil)
+	c.Assert(w.MoveRelayDir(context.Background(), notEmpty), ErrorMatches, ".*not empty.*")
+	c.Assert(w.cfg.RelayDir, Equals, oldDir)
+
+	newDir := filepath.Join(c.MkDir(), "new-relay")
+	c.Assert(w.MoveRelayDir(context.Background(), newDir), IsNil)
+	c.Assert(w.cfg.RelayDir, Equals, newDir)
+	c.Assert(st.cfg.RelayDir, Equals, newDir)
+
+	uuids, err := utils.ParseUUIDIndex(filepath.Join(newDir, utils.UUIDIndexFilename))
+	c.Assert(err, IsNil)
+	c.Assert(uuids, DeepEquals, []string{"24ecd093-8cec-11e9-aa0d-0242ac170002.000001"})
+	data, err := ioutil.ReadFile(filepath.Join(newDir, uuids[0], "mysql-bin.000001"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "binlog data")
+
+	// files in the old dir are kept because the new dir is not persisted without etcd.
+	uuids, err = utils.ParseUUIDIndex(filepath.Join(oldDir, utils.UUIDIndexFilename))
+	c.Assert(err, IsNil)
+	c.Assert(uuids, HasLen, 1)
+}
+
+func (t *testRelayDir) TestMoveRelayDirPersisted(c *C) {
+	masterAddr := tempurl.Alloc()[len("http://"):]
+	ETCD, err := createMockETCD(c.MkDir(), "http://"+masterAddr)
+	c.Assert(err, IsNil)
+	defer ETCD.Close()
+	etcdCli, err := clientv3.New(clientv3.Config{
+		Endpoints:   GetJoinURLs(masterAddr),
+		DialTimeout: dialTimeout,
+	})
+	c.Assert(err, IsNil)
+	defer etcdCli.Close()
+
+	oldDir := filepath.Join(c.MkDir(), "relay")
+	prepareRelayDir(c, oldDir)
+	cfg := &config.SourceConfig{SourceID: "mysql-replica-01", RelayDir: oldDir}
+	cfg.From.Password = "encrypted"
+	_, err = ha.PutSourceCfg(etcdCli, *cfg)
+	c.Assert(err, IsNil)
+
+	w := &Worker{
+		cfg:           cfg.Clone(),
+		subTaskHolder: newSubTaskHolder(),
+		relayHolder:   NewDummyRelayHolder(cfg),
+		etcdClient:    etcdCli,
+		l:             log.L(),
+	}
+	w.cfg.From.Password = "decrypted"
+	w.closed.Set(closedFalse)
+
+	newDir := filepath.Join(c.MkDir(), "new-relay")
+	c.Assert(w.MoveRelayDir(context.Background(), newDir), IsNil)
+	c.Assert(w.cfg.RelayDir, Equals, newDir)
+
+	// the new dir is persisted, and other items are not changed.
+	scm, _, err := ha.GetSourceCfg(etcdCli, cfg.SourceID, 0)
+	c.Assert(err, IsNil)
+	c.Assert(scm[cfg.SourceID].RelayDir, Equals, newDir)
+	c.Assert(scm[cfg.SourceID].From.Password, Equals, "encrypted")
+
+	// files in the old dir are removed after persisted.
+	files, err := ioutil.ReadDir(oldDir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+}
+
+func (t *testRelayDir) TestRelayFiles(c *C) {
+	dir := c.MkDir()
+	prepareRelayDir(c, dir)
+
+	cfg := &config.SourceConfig{RelayDir: dir}
+	w := &Worker{
+		cfg:           cfg,
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+
+	// relay disabled
+	files, err := w.RelayFiles(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(files, NotNil)
+	c.Assert(files, HasLen, 0)
+
+	w.relayHolder = NewDummyRelayHolder(cfg)
+	files, err = w.RelayFiles(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 1)
+	c.Assert(files[0].Name, Equals, "mysql-bin.000001")
+	c.Assert(files[0].UUID, Equals, "24ecd093-8cec-11e9-aa0d-0242ac170002.000001")
+	c.Assert(files[0].UUIDSuffix, Equals, 1)
+	c.Assert(files[0].Size, Equals, int64(len("binlog data")))
+	c.Assert(files[0].ModTime.IsZero(), IsFalse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = w.RelayFiles(ctx)
+	c.Assert(err, Equals, context.Canceled)
+
+	w.closed.Set(closedTrue)
+	_, err = w.RelayFiles(context.Background())
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(err), IsTrue)
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// RelayReadPosition represents the position of relay log a subtask has read to, relative to the relay write head.
+type RelayReadPosition struct {
+	RelaySubDir string `json:"relay-sub-dir"`
+	RelayFile   string `json:"relay-file"`
+	Offset      uint32 `json:"offset"`
+	WriteSubDir string `json:"write-sub-dir"`
+	WriteFile   string `json:"write-file"`
+	WriteOffset uint32 `json:"write-offset"`
+	// the number of relay log files from the file being read to the file being written, -1 if they're in different
+	// sub directories or unknown.
+	FilesBehind int64 `json:"files-behind"`
+	// the bytes not read yet in the file being written, -1 if reading an earlier file.
+	BytesBehind int64 `json:"bytes-behind"`
+	CaughtUp    bool  `json:"caught-up"`
+}
+
+// newRelayReadPosition compares the read position with the write head of relay log.
+func newRelayReadPosition(readSubDir string, readPos mysql.Position, writeSubDir string, writePos mysql.Position) RelayReadPosition {
+	p := RelayReadPosition{
+		RelaySubDir: readSubDir,
+		RelayFile:   readPos.Name,
+		Offset:      readPos.Pos,
+		WriteSubDir: writeSubDir,
+		WriteFile:   writePos.Name,
+		WriteOffset: writePos.Pos,
+		FilesBehind: -1,
+		BytesBehind: -1,
+	}
+	if readSubDir != writeSubDir {
+		return p
+	}
+	readIdx, err1 := binlog.GetFilenameIndex(readPos.Name)
+	writeIdx, err2 := binlog.GetFilenameIndex(writePos.Name)
+	if err1 == nil && err2 == nil {
+		p.FilesBehind = writeIdx - readIdx
+	}
+	if readPos.Name == writePos.Name {
+		p.FilesBehind = 0
+		if writePos.Pos > readPos.Pos {
+			p.BytesBehind = int64(writePos.Pos - readPos.Pos)
+		} else {
+			p.BytesBehind = 0
+		}
+		p.CaughtUp = p.BytesBehind == 0
+	}
+	return p
+}
+
+// GetRelayReaderPositions returns the subtask name -> the position of relay log it has read to, only the subtasks
+// reading binlog from relay log in the sync phase are included. they tell the earliest relay log file still being
+// read, which can't be purged, and how far each reader lags behind the relay unit.
+func (w *Worker) GetRelayReaderPositions() (map[string]RelayReadPosition, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if w.relayHolder == nil {
+		return nil, terror.ErrWorkerRelayDisabled.Generate()
+	}
+	wh, ok := w.relayHolder.(relayWriteHead)
+	if !ok {
+		return nil, terror.ErrWorkerRelayOperNotSupport.Generate("get-relay-reader-positions")
+	}
+	writeSubDir, writePos := wh.WritePos()
+
+	positions := make(map[string]RelayReadPosition)
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		if !st.cfg.UseRelay {
+			continue
+		}
+		readSubDir, readPos, err := st.RelayReadPos()
+		if err != nil {
+			return nil, err
+		}
+		if readSubDir == "" {
+			continue
+		}
+		positions[name] = newRelayReadPosition(readSubDir, readPos, writeSubDir, writePos)
+	}
+	return positions, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testRelayReader struct{}
+
+var _ = Suite(&testRelayReader{})
+
+type writeHeadRelayHolder struct {
+	RelayHolder
+	subDir string
+	pos    mysql.Position
+}
+
+func (h *writeHeadRelayHolder) WritePos() (string, mysql.Position) {
+	return h.subDir, h.pos
+}
+
+func (t *testRelayReader) TestNewRelayReadPosition(c *C) {
+	var (
+		subDir1 = "3ccc475b-2343-11e7-be21-6c0b84d59f30.000001"
+		subDir2 = "53ea0ed1-9bf8-11e6-8bea-64006a897c73.000002"
+		write   = mysql.Position{Name: "mysql-bin.000003", Pos: 1000}
+	)
+
+	p := newRelayReadPosition(subDir2, mysql.Position{Name: "mysql-bin.000003", Pos: 400}, subDir2, write)
+	c.Assert(p.FilesBehind, Equals, int64(0))
+	c.Assert(p.BytesBehind, Equals, int64(600))
+	c.Assert(p.CaughtUp, IsFalse)
+
+	p = newRelayReadPosition(subDir2, write, subDir2, write)
+	c.Assert(p.BytesBehind, Equals, int64(0))
+	c.Assert(p.CaughtUp, IsTrue)
+
+	p = newRelayReadPosition(subDir2, mysql.Position{Name: "mysql-bin.000001", Pos: 4}, subDir2, write)
+	c.Assert(p, DeepEquals, RelayReadPosition{
+		RelaySubDir: subDir2,
+		RelayFile:   "mysql-bin.000001",
+		Offset:      4,
+		WriteSubDir: subDir2,
+		WriteFile:   "mysql-bin.000003",
+		WriteOffset: 1000,
+		FilesBehind: 2,
+		BytesBehind: -1,
+	})
+
+	// in an earlier sub directory.
+	p = newRelayReadPosition(subDir1, write, subDir2, write)
+	c.Assert(p.FilesBehind, Equals, int64(-1))
+	c.Assert(p.BytesBehind, Equals, int64(-1))
+	c.Assert(p.CaughtUp, IsFalse)
+}
+
+func (t *testRelayReader) TestGetRelayReaderPositions(c *C) {
+	w := &Worker{
+		cfg:           &config.SourceConfig{},
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	_, err := w.GetRelayReaderPositions()
+	c.Assert(terror.ErrWorkerRelayDisabled.Equal(err), IsTrue)
+
+	w.relayHolder = NewDummyRelayHolder(nil)
+	_, err = w.GetRelayReaderPositions()
+	c.Assert(terror.ErrWorkerRelayOperNotSupport.Equal(err), IsTrue)
+
+	w.relayHolder = &writeHeadRelayHolder{
+		RelayHolder: NewDummyRelayHolder(nil),
+		subDir:      "53ea0ed1-9bf8-11e6-8bea-64006a897c73.000001",
+		pos:         mysql.Position{Name: "mysql-bin.000003", Pos: 1000},
+	}
+	// the subtasks not in the sync phase or not using relay are not included.
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-relay-reader", UseRelay: true}, pb.Stage_Running, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	w.subTaskHolder.recordSubTask(st)
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "test-no-relay"}, pb.Stage_Running, nil))
+	positions, err := w.GetRelayReaderPositions()
+	c.Assert(err, IsNil)
+	c.Assert(positions, HasLen, 0)
+}
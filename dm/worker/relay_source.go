@@ -0,0 +1,123 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/relay"
+)
+
+// relayFilesHandler serves the relay log files of the current DM-worker for other DM-workers of the same source,
+// which set `relay-source` to the address of this DM-worker to fetch relay log rather than pulling from upstream.
+// it's only served on `relay-files-addr`, and only the clients with a certificate verified by `ssl-ca`
+// (and `cert-allowed-cn`) are served.
+type relayFilesHandler struct {
+	getWorker func() *Worker
+}
+
+func (h *relayFilesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authenticated(req) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	relayDir, err := h.getWorker().relayServingDir()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	relPath := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, relay.RemoteRelayFilesPath), "/")
+	if len(relPath) == 0 {
+		files, err2 := relay.ListRemoteRelayFiles(relayDir)
+		if err2 != nil {
+			http.Error(w, err2.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err2 = json.NewEncoder(w).Encode(files); err2 != nil {
+			log.L().Warn("fail to write relay files response", log.ShortError(err2))
+		}
+		return
+	}
+
+	// only files in the relay directory can be read.
+	if path.Clean("/"+relPath) != "/"+relPath {
+		http.Error(w, "invalid relay file path", http.StatusBadRequest)
+		return
+	}
+	f, err := os.Open(filepath.Join(relayDir, filepath.FromSlash(relPath)))
+	if os.IsNotExist(err) {
+		http.NotFound(w, req)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if fi.IsDir() {
+		http.NotFound(w, req)
+		return
+	}
+	http.ServeContent(w, req, fi.Name(), fi.ModTime(), f)
+}
+
+// authenticated checks whether the client is allowed to read relay log files. the CN of the certificate is verified
+// by the listener already if `cert-allowed-cn` is set, so only check the client certificate is verified here.
+func (h *relayFilesHandler) authenticated(req *http.Request) bool {
+	return req.TLS != nil && len(req.TLS.VerifiedChains) > 0
+}
+
+// relayServingDir returns the relay directory whose files can be served for other DM-workers.
+func (w *Worker) relayServingDir() (string, error) {
+	if w == nil {
+		return "", terror.ErrWorkerNoStart.Generate()
+	}
+	w.RLock()
+	defer w.RUnlock()
+	if w.closed.Get() == closedTrue {
+		return "", terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if w.relayHolder == nil {
+		return "", terror.ErrWorkerRelayDisabled.Generate()
+	}
+	return w.cfg.RelayDir, nil
+}
+
+// GetRelaySource returns the address of the DM-worker from which relay log is fetched,
+// it's empty if relay log is pulled from upstream directly.
+func (w *Worker) GetRelaySource() string {
+	w.RLock()
+	defer w.RUnlock()
+	if w.relayHolder == nil {
+		return ""
+	}
+	return w.cfg.RelaySource
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/relay"
+)
+
+type testRelaySource struct{}
+
+var _ = Suite(&testRelaySource{})
+
+func (t *testRelaySource) TestRelayFilesHandler(c *C) {
+	var (
+		dir  = c.MkDir()
+		uuid = "24ecd093-8cec-11e9-aa0d-0242ac170002.000001"
+		w    *Worker
+		h    = &relayFilesHandler{getWorker: func() *Worker { return w }}
+	)
+	prepareRelayDir(c, dir)
+	get := func(p string, header http.Header) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, relay.RemoteRelayFilesPath+p, nil)
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// no worker started.
+	c.Assert(get("", nil).Code, Equals, http.StatusServiceUnavailable)
+
+	// relay not enabled.
+	cfg := &config.SourceConfig{RelayDir: dir, RelaySource: "https://127.0.0.1:8264"}
+	w = &Worker{cfg: cfg}
+	c.Assert(get("", nil).Code, Equals, http.StatusServiceUnavailable)
+	c.Assert(w.GetRelaySource(), Equals, "")
+
+	w.relayHolder = NewDummyRelayHolder(cfg)
+	c.Assert(w.GetRelaySource(), Equals, cfg.RelaySource)
+	rec := get("", nil)
+	c.Assert(rec.Code, Equals, http.StatusOK)
+	var files []relay.RemoteRelayFile
+	c.Assert(json.Unmarshal(rec.Body.Bytes(), &files), IsNil)
+	c.Assert(files, DeepEquals, []relay.RemoteRelayFile{
+		{Path: "server-uuid.index", Size: int64(len(uuid) + 1)},
+		{Path: uuid + "/relay.meta", Size: 32},
+		{Path: uuid + "/mysql-bin.000001", Size: 11},
+	})
+
+	rec = get("/"+uuid+"/mysql-bin.000001", http.Header{"Range": []string{"bytes=7-10"}})
+	c.Assert(rec.Code, Equals, http.StatusPartialContent)
+	c.Assert(rec.Body.String(), Equals, "data")
+
+	c.Assert(get("/"+uuid+"/mysql-bin.000002", nil).Code, Equals, http.StatusNotFound)
+	c.Assert(get("/"+uuid, nil).Code, Equals, http.StatusNotFound)
+	c.Assert(get("/../"+uuid+"/mysql-bin.000001", nil).Code, Equals, http.StatusBadRequest)
+
+	// only the clients with a verified certificate are served.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, relay.RemoteRelayFilesPath, nil))
+	c.Assert(rec.Code, Equals, http.StatusUnauthorized)
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, relay.RemoteRelayFilesPath, nil)
+	req.TLS = &tls.ConnectionState{}
+	h.ServeHTTP(rec, req)
+	c.Assert(rec.Code, Equals, http.StatusUnauthorized)
+
+	// closed.
+	w.closed.Set(closedTrue)
+	c.Assert(get("", nil).Code, Equals, http.StatusServiceUnavailable)
+}
+
+func (t *testRelaySource) TestRelayStage(c *C) {
+	// relay not enabled.
+	w := &Worker{cfg: &config.SourceConfig{}}
+	c.Assert(w.RelayStage(), Equals, pb.Stage_InvalidStage)
+
+	w.relayHolder = NewDummyRelayHolder(nil)
+	c.Assert(w.RelayStage(), Equals, pb.Stage_New)
+	c.Assert(w.relayHolder.Operate(context.Background(), pb.RelayOp_StopRelay), IsNil)
+	c.Assert(w.RelayStage(), Equals, pb.Stage_Stopped)
+}
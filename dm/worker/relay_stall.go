@@ -0,0 +1,44 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/relay"
+)
+
+// DetectRelayWriteStall compares the relay log with the binlog of upstream, and reports a stall if upstream has new
+// binlog but the relay hasn't written any event for a while without an error, e.g. the connection dropped silently.
+func (w *Worker) DetectRelayWriteStall(ctx context.Context) (*relay.StallReport, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if w.relayHolder == nil {
+		return nil, terror.ErrWorkerRelayDisabled.Generate()
+	}
+	if stage := w.relayHolder.Stage(); stage != pb.Stage_Running {
+		return nil, terror.ErrWorkerRelayStageNotValid.Generate(stage, pb.Stage_Running, "detect-write-stall")
+	}
+	sd, ok := w.relayHolder.(stallDetector)
+	if !ok {
+		return nil, terror.ErrWorkerRelayOperNotSupport.Generate("detect-write-stall")
+	}
+	return sd.DetectStall(ctx)
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/relay"
+)
+
+type testRelayStall struct{}
+
+var _ = Suite(&testRelayStall{})
+
+type stallRelayHolder struct {
+	RelayHolder
+	stage     pb.Stage
+	lastWrite time.Time
+	report    *relay.StallReport
+}
+
+func (h *stallRelayHolder) Stage() pb.Stage {
+	return h.stage
+}
+
+func (h *stallRelayHolder) LastWriteTime() time.Time {
+	return h.lastWrite
+}
+
+func (h *stallRelayHolder) DetectStall(ctx context.Context) (*relay.StallReport, error) {
+	return h.report, nil
+}
+
+func (t *testRelayStall) TestDetectRelayWriteStall(c *C) {
+	w := &Worker{
+		cfg:           &config.SourceConfig{},
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	_, err := w.DetectRelayWriteStall(context.Background())
+	c.Assert(err, ErrorMatches, ".*relay log is not enabled.*")
+
+	holder := &stallRelayHolder{
+		RelayHolder: NewDummyRelayHolder(nil),
+		stage:       pb.Stage_Paused,
+		report:      &relay.StallReport{Stalled: true, Behind: true},
+	}
+	w.relayHolder = holder
+	_, err = w.DetectRelayWriteStall(context.Background())
+	c.Assert(err, ErrorMatches, ".*current stage is Paused, Running required.*")
+
+	holder.stage = pb.Stage_Running
+	report, err := w.DetectRelayWriteStall(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(report, Equals, holder.report)
+
+	info, err := w.GetRelayInfo()
+	c.Assert(err, IsNil)
+	c.Assert(info.SecondsSinceLastWrite, Equals, int64(-1))
+	holder.lastWrite = time.Now().Add(-time.Minute)
+	info, err = w.GetRelayInfo()
+	c.Assert(err, IsNil)
+	c.Assert(info.SecondsSinceLastWrite >= 60, IsTrue)
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// subTaskLabel is the profiler label set on the goroutines processing units of a subtask. the label is inherited by
+// the goroutines created by them, so goroutines could be attributed to subtasks from the goroutine profile.
+const subTaskLabel = "subtask"
+
+// resourceSampleInterval is the interval of sampling the resource usage of subtasks if `status-print-interval` is not
+// positive, otherwise the resource usage is sampled at the same interval as printing status.
+var resourceSampleInterval = 5 * time.Second
+
+var (
+	goroutineProfileTotalRegexp = regexp.MustCompile(`^goroutine profile: total (\d+)$`)
+	goroutineProfileCountRegexp = regexp.MustCompile(`^(\d+) @`)
+	subTaskLabelRegexp          = regexp.MustCompile(`"` + subTaskLabel + `":("(?:[^"\\]|\\.)*")`)
+)
+
+// SubTaskResourceUsage represents the resource usage of a subtask when sampled.
+type SubTaskResourceUsage struct {
+	// Goroutines is the number of goroutines created by the units of the subtask.
+	Goroutines int `json:"goroutines"`
+	// MemoryBytes is a rough estimate of memory used by the subtask. NOTE: it's NOT the memory allocated by the
+	// subtask, but the heap and stacks in use of the whole process shared in proportion to the number of goroutines,
+	// because go can't account allocations by goroutine. a subtask with few goroutines but large buffers is
+	// underestimated, so only use it to compare subtasks roughly.
+	MemoryBytes uint64    `json:"memory-bytes"`
+	SampleTime  time.Time `json:"sample-time"`
+}
+
+// resourceUsages holds the latest sampled resource usage of subtasks.
+type resourceUsages struct {
+	mu     sync.RWMutex
+	usages map[string]SubTaskResourceUsage
+}
+
+func (r *resourceUsages) set(usages map[string]SubTaskResourceUsage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usages = usages
+}
+
+func (r *resourceUsages) get(name string) (SubTaskResourceUsage, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	usage, ok := r.usages[name]
+	return usage, ok
+}
+
+func (r *resourceUsages) all() map[string]SubTaskResourceUsage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	usages := make(map[string]SubTaskResourceUsage, len(r.usages))
+	for name, usage := range r.usages {
+		usages[name] = usage
+	}
+	return usages
+}
+
+// withLabel calls f with the profiler label of the subtask, the goroutines created in f are attributed to the subtask.
+func (st *SubTask) withLabel(ctx context.Context, f func(ctx context.Context)) {
+	pprof.Do(ctx, pprof.Labels(subTaskLabel, st.cfg.Name), f)
+}
+
+// countGoroutinesBySubTask parses the goroutine profile in debug=1 text format, and returns the number of goroutines
+// labeled with each subtask and the total number of goroutines.
+func countGoroutinesBySubTask(r io.Reader) (map[string]int, int, error) {
+	var (
+		counts = make(map[string]int)
+		total  int
+		last   int // count of the last stack
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := goroutineProfileTotalRegexp.FindStringSubmatch(line); m != nil {
+			total, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if m := goroutineProfileCountRegexp.FindStringSubmatch(line); m != nil {
+			last, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if !strings.HasPrefix(line, "# labels: ") {
+			continue
+		}
+		if m := subTaskLabelRegexp.FindStringSubmatch(line); m != nil {
+			name, err := strconv.Unquote(m[1])
+			if err != nil {
+				return nil, 0, err
+			}
+			counts[name] += last
+		}
+	}
+	return counts, total, scanner.Err()
+}
+
+// sampleResourceUsage samples the resource usage of the subtasks. it takes a goroutine profile and reads memory
+// stats, both of which stop the world for a short time in proportion to the number of goroutines, so it's only called
+// once every status interval and skipped if there is no subtask.
+func sampleResourceUsage(names []string) (map[string]SubTaskResourceUsage, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil, err
+	}
+	counts, total, err := countGoroutinesBySubTask(&buf)
+	if err != nil {
+		return nil, err
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	inuse := ms.HeapInuse + ms.StackInuse
+
+	now := time.Now()
+	usages := make(map[string]SubTaskResourceUsage, len(names))
+	for _, name := range names {
+		usage := SubTaskResourceUsage{Goroutines: counts[name], SampleTime: now}
+		if total > 0 {
+			usage.MemoryBytes = inuse / uint64(total) * uint64(usage.Goroutines)
+		}
+		usages[name] = usage
+	}
+	return usages, nil
+}
+
+// runResourceSampler samples the resource usage of all subtasks every interval until the worker is closed.
+func (w *Worker) runResourceSampler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			sts := w.subTaskHolder.getAllSubTasks()
+			if len(sts) == 0 {
+				w.resourceUsages.set(nil)
+				continue
+			}
+			names := make([]string, 0, len(sts))
+			for name := range sts {
+				names = append(names, name)
+			}
+			usages, err := sampleResourceUsage(names)
+			if err != nil {
+				w.l.Warn("fail to sample resource usage of subtasks", log.ShortError(err))
+				continue
+			}
+			w.resourceUsages.set(usages)
+		}
+	}
+}
+
+// GetSubTaskResourceUsage returns the latest sampled resource usage of the subtask, or all subtasks if name is empty.
+// the subtasks started after the latest sampling are not included.
+func (w *Worker) GetSubTaskResourceUsage(name string) (map[string]SubTaskResourceUsage, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	if name == "" {
+		return w.resourceUsages.all(), nil
+	}
+	if w.subTaskHolder.findSubTask(name) == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	usages := make(map[string]SubTaskResourceUsage, 1)
+	if usage, ok := w.resourceUsages.get(name); ok {
+		usages[name] = usage
+	}
+	return usages, nil
+}
+
+// toPB converts the resource usage to the one in status.
+func (u SubTaskResourceUsage) toPB() *pb.ResourceUsage {
+	return &pb.ResourceUsage{
+		Goroutines:  int64(u.Goroutines),
+		MemoryBytes: u.MemoryBytes,
+		SampleTime:  u.SampleTime.Unix(),
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+type testResource struct{}
+
+var _ = Suite(&testResource{})
+
+func (t *testResource) TestCountGoroutinesBySubTask(c *C) {
+	profile := `goroutine profile: total 10
+4 @ 0x43a0c5 0x406b7b
+# labels: {"subtask":"task1"}
+#	0x4732a0	main.f+0x20	/tmp/main.go:10
+
+3 @ 0x43a0c5 0x406b7c
+# labels: {"other":"x", "subtask":"task\"2"}
+#	0x4732a0	main.g+0x20	/tmp/main.go:20
+
+2 @ 0x43a0c5 0x406b7d
+#	0x4732a0	main.h+0x20	/tmp/main.go:30
+
+1 @ 0x43a0c5 0x406b7e
+# labels: {"subtask":"task1"}
+#	0x4732a0	main.i+0x20	/tmp/main.go:40
+`
+	counts, total, err := countGoroutinesBySubTask(strings.NewReader(profile))
+	c.Assert(err, IsNil)
+	c.Assert(total, Equals, 10)
+	c.Assert(counts, DeepEquals, map[string]int{"task1": 5, "task\"2": 3})
+}
+
+func (t *testResource) TestSampleResourceUsage(c *C) {
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: "resource-task"}, pb.Stage_Running, nil)
+
+	// goroutines created by the labeled goroutine are attributed to the subtask.
+	var (
+		started sync.WaitGroup
+		exit    = make(chan struct{})
+		exited  sync.WaitGroup
+	)
+	started.Add(3)
+	exited.Add(3)
+	st.withLabel(context.Background(), func(ctx context.Context) {
+		for i := 0; i < 3; i++ {
+			go func() {
+				defer exited.Done()
+				started.Done()
+				<-exit
+			}()
+		}
+	})
+	started.Wait()
+
+	usages, err := sampleResourceUsage([]string{"resource-task", "idle-task"})
+	close(exit)
+	exited.Wait()
+	c.Assert(err, IsNil)
+	c.Assert(usages, HasLen, 2)
+	c.Assert(usages["resource-task"].Goroutines, Equals, 3)
+	c.Assert(usages["resource-task"].MemoryBytes, Greater, uint64(0))
+	c.Assert(usages["resource-task"].SampleTime.IsZero(), IsFalse)
+	c.Assert(usages["idle-task"].Goroutines, Equals, 0)
+	c.Assert(usages["idle-task"].MemoryBytes, Equals, uint64(0))
+}
+
+func (t *testResource) TestGetSubTaskResourceUsage(c *C) {
+	w := &Worker{subTaskHolder: newSubTaskHolder(), l: log.L()}
+	w.closed.Set(closedFalse)
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task1"}, pb.Stage_Running, nil))
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task2"}, pb.Stage_Running, nil))
+
+	// not sampled yet
+	usages, err := w.GetSubTaskResourceUsage("")
+	c.Assert(err, IsNil)
+	c.Assert(usages, HasLen, 0)
+	usages, err = w.GetSubTaskResourceUsage("task1")
+	c.Assert(err, IsNil)
+	c.Assert(usages, HasLen, 0)
+
+	sampled, err := sampleResourceUsage([]string{"task1", "task2"})
+	c.Assert(err, IsNil)
+	w.resourceUsages.set(sampled)
+	usages, err = w.GetSubTaskResourceUsage("")
+	c.Assert(err, IsNil)
+	c.Assert(usages, DeepEquals, sampled)
+	usages, err = w.GetSubTaskResourceUsage("task1")
+	c.Assert(err, IsNil)
+	c.Assert(usages, DeepEquals, map[string]SubTaskResourceUsage{"task1": sampled["task1"]})
+
+	// also reported in status
+	status := w.Status(context.Background(), "task1")
+	c.Assert(status, HasLen, 1)
+	c.Assert(status[0].ResourceUsage, DeepEquals, &pb.ResourceUsage{
+		Goroutines:  int64(sampled["task1"].Goroutines),
+		MemoryBytes: sampled["task1"].MemoryBytes,
+		SampleTime:  sampled["task1"].SampleTime.Unix(),
+	})
+
+	_, err = w.GetSubTaskResourceUsage("not-exist")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+
+	w.closed.Set(closedTrue)
+	_, err = w.GetSubTaskResourceUsage("")
+	c.Assert(terror.ErrWorkerAlreadyClosed.Equal(err), IsTrue)
+}
+
+func (t *testResource) TestRunResourceSampler(c *C) {
+	w := &Worker{subTaskHolder: newSubTaskHolder(), l: log.L()}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.closed.Set(closedFalse)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.runResourceSampler(10 * time.Millisecond)
+	}()
+
+	// nothing sampled without subtasks
+	time.Sleep(50 * time.Millisecond)
+	c.Assert(w.resourceUsages.all(), HasLen, 0)
+
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(&config.SubTaskConfig{Name: "task1"}, pb.Stage_Running, nil))
+	c.Assert(utils.WaitSomething(100, 10*time.Millisecond, func() bool {
+		_, ok := w.resourceUsages.get("task1")
+		return ok
+	}), IsTrue)
+
+	w.cancel()
+	wg.Wait()
+}
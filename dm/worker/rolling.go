@@ -0,0 +1,160 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// RollingOpProgress represents the progress of a rolling pause or resume of subtasks.
+type RollingOpProgress struct {
+	Op        pb.TaskOp `json:"op"`
+	StartTime time.Time `json:"start-time"`
+	// Total is the number of subtasks when the operation started.
+	Total    int      `json:"total"`
+	Current  string   `json:"current,omitempty"`
+	Operated []string `json:"operated"`
+	// Skipped are the subtasks not in the stage to operate, or removed during the operation.
+	Skipped  []string          `json:"skipped"`
+	Failed   map[string]string `json:"failed"` // task name -> error message
+	Finished bool              `json:"finished"`
+}
+
+func (p *RollingOpProgress) clone() *RollingOpProgress {
+	clone := *p
+	clone.Operated = append([]string(nil), p.Operated...)
+	clone.Skipped = append([]string(nil), p.Skipped...)
+	clone.Failed = make(map[string]string, len(p.Failed))
+	for name, msg := range p.Failed {
+		clone.Failed[name] = msg
+	}
+	return &clone
+}
+
+// rollingOp holds the progress of the running or last rolling operation, only one can run at a time.
+type rollingOp struct {
+	mu       sync.RWMutex
+	progress *RollingOpProgress
+}
+
+// RollingPauseAll pauses the running subtasks one by one in the order of names, and waits delayBetween after each
+// pause to smooth the load of flushing checkpoints on downstream. the subtasks failed to pause are skipped and reported
+// in the returned error after all done, and it returns at once if ctx is canceled. see GetRollingOpProgress for the
+// progress.
+func (w *Worker) RollingPauseAll(ctx context.Context, delayBetween time.Duration) error {
+	return w.rollingOperateAll(ctx, pb.TaskOp_Pause, pb.Stage_Running, delayBetween)
+}
+
+// RollingResumeAll resumes the paused subtasks one by one like RollingPauseAll, so the subtasks don't replay events
+// in safe mode at the same time.
+func (w *Worker) RollingResumeAll(ctx context.Context, delayBetween time.Duration) error {
+	return w.rollingOperateAll(ctx, pb.TaskOp_Resume, pb.Stage_Paused, delayBetween)
+}
+
+// GetRollingOpProgress returns the progress of the running or last rolling operation, nil if none.
+func (w *Worker) GetRollingOpProgress() *RollingOpProgress {
+	w.rollingOp.mu.RLock()
+	defer w.rollingOp.mu.RUnlock()
+	if w.rollingOp.progress == nil {
+		return nil
+	}
+	return w.rollingOp.progress.clone()
+}
+
+func (w *Worker) rollingOperateAll(ctx context.Context, op pb.TaskOp, fromStage pb.Stage, delayBetween time.Duration) error {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	subTasks := w.subTaskHolder.getAllSubTasks()
+	names := make([]string, 0, len(subTasks))
+	for name := range subTasks {
+		names = append(names, name)
+	}
+	w.RUnlock()
+	sort.Strings(names)
+
+	progress := &RollingOpProgress{Op: op, StartTime: time.Now(), Total: len(names), Failed: make(map[string]string)}
+	w.rollingOp.mu.Lock()
+	if w.rollingOp.progress != nil && !w.rollingOp.progress.Finished {
+		w.rollingOp.mu.Unlock()
+		return terror.ErrWorkerRollingOpInProgress.Generate(w.rollingOp.progress.Op)
+	}
+	w.rollingOp.progress = progress
+	w.rollingOp.mu.Unlock()
+	update := func(f func()) {
+		w.rollingOp.mu.Lock()
+		defer w.rollingOp.mu.Unlock()
+		f()
+	}
+	defer update(func() {
+		progress.Current = ""
+		progress.Finished = true
+	})
+
+	w.l.Info("start rolling operation of sub tasks", zap.Stringer("op", op), zap.Int("total", len(names)), zap.Duration("delay between", delayBetween))
+	operated := 0
+	for i, name := range names {
+		st := w.subTaskHolder.findSubTask(name)
+		if st == nil || st.Stage() != fromStage {
+			update(func() { progress.Skipped = append(progress.Skipped, name) })
+			continue
+		}
+
+		if operated > 0 && delayBetween > 0 {
+			select {
+			case <-ctx.Done():
+				w.l.Warn("rolling operation of sub tasks canceled", zap.Stringer("op", op), zap.Int("operated", operated))
+				return ctx.Err()
+			case <-time.After(delayBetween):
+			}
+		}
+
+		update(func() { progress.Current = name })
+		err := w.OperateSubTask(name, op)
+		switch {
+		case err == nil:
+			operated++
+			update(func() { progress.Operated = append(progress.Operated, name) })
+		case terror.ErrWorkerAlreadyClosed.Equal(err):
+			return err
+		case terror.ErrWorkerSubTaskNotFound.Equal(err):
+			update(func() { progress.Skipped = append(progress.Skipped, name) })
+		default:
+			w.l.Warn("fail to operate sub task in rolling operation", zap.String("task", name), zap.Stringer("op", op), zap.Error(err))
+			update(func() { progress.Failed[name] = err.Error() })
+		}
+		w.l.Info("rolling operation of sub tasks in progress", zap.Stringer("op", op), zap.String("task", name), zap.Int("done", i+1), zap.Int("total", len(names)))
+	}
+
+	if len(progress.Failed) > 0 {
+		failed := make([]string, 0, len(progress.Failed))
+		for name := range progress.Failed {
+			failed = append(failed, name)
+		}
+		sort.Strings(failed)
+		return terror.ErrWorkerRollingOpFailed.Generate(op, failed)
+	}
+	w.l.Info("finish rolling operation of sub tasks", zap.Stringer("op", op), zap.Int("operated", operated))
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testRollingOp struct{}
+
+var _ = Suite(&testRollingOp{})
+
+func (t *testRollingOp) TestRollingPauseResumeAll(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	c.Assert(w.GetRollingOpProgress(), IsNil)
+
+	names := []string{"task-c", "task-a", "task-b", "task-d"}
+	subTasks := make(map[string]*SubTask)
+	for _, name := range names {
+		stage := pb.Stage_Running
+		if name == "task-d" {
+			stage = pb.Stage_Paused
+		}
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: name}, stage, nil)
+		st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		st.initialized.Set(true)
+		defer st.Close()
+		w.subTaskHolder.recordSubTask(st)
+		subTasks[name] = st
+	}
+	c.Assert(w.RegisterSubTaskOpHook("task-b", func(op pb.TaskOp) error {
+		return errors.New("not ready")
+	}, nil), IsNil)
+
+	// paused one by one in the order of names, task-d is not running and skipped.
+	delay := 50 * time.Millisecond
+	start := time.Now()
+	err := w.RollingPauseAll(context.Background(), delay)
+	c.Assert(terror.ErrWorkerRollingOpFailed.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*rolling Pause failed on subtasks \\[task-b\\].*")
+	c.Assert(time.Since(start) >= 2*delay, IsTrue)
+	c.Assert(subTasks["task-a"].Stage(), Equals, pb.Stage_Paused)
+	c.Assert(subTasks["task-b"].Stage(), Equals, pb.Stage_Running)
+	c.Assert(subTasks["task-c"].Stage(), Equals, pb.Stage_Paused)
+
+	progress := w.GetRollingOpProgress()
+	c.Assert(progress.Op, Equals, pb.TaskOp_Pause)
+	c.Assert(progress.Total, Equals, 4)
+	c.Assert(progress.Finished, IsTrue)
+	c.Assert(progress.Current, Equals, "")
+	c.Assert(progress.Operated, DeepEquals, []string{"task-a", "task-c"})
+	c.Assert(progress.Skipped, DeepEquals, []string{"task-d"})
+	c.Assert(progress.Failed, HasKey, "task-b")
+
+	// resumed one by one, task-b is running and skipped.
+	c.Assert(w.RegisterSubTaskOpHook("task-b", nil, nil), IsNil)
+	c.Assert(w.RollingResumeAll(context.Background(), 0), IsNil)
+	for _, name := range names {
+		c.Assert(subTasks[name].Stage(), Equals, pb.Stage_Running)
+	}
+	progress = w.GetRollingOpProgress()
+	c.Assert(progress.Op, Equals, pb.TaskOp_Resume)
+	c.Assert(progress.Operated, DeepEquals, []string{"task-a", "task-c", "task-d"})
+	c.Assert(progress.Skipped, DeepEquals, []string{"task-b"})
+	c.Assert(progress.Failed, HasLen, 0)
+
+	// canceled while waiting for the delay.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = w.RollingPauseAll(ctx, time.Hour)
+	c.Assert(err, Equals, context.Canceled)
+	progress = w.GetRollingOpProgress()
+	c.Assert(progress.Finished, IsTrue)
+	c.Assert(progress.Operated, DeepEquals, []string{"task-a"})
+	c.Assert(subTasks["task-b"].Stage(), Equals, pb.Stage_Running)
+
+	// only one rolling operation at a time.
+	w.rollingOp.progress = &RollingOpProgress{Op: pb.TaskOp_Pause}
+	err = w.RollingResumeAll(context.Background(), 0)
+	c.Assert(terror.ErrWorkerRollingOpInProgress.Equal(err), IsTrue)
+}
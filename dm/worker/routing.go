@@ -0,0 +1,194 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	router "github.com/pingcap/tidb-tools/pkg/table-router"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// kinds of routing conflicts.
+const (
+	// RoutingConflictOverlapping means an upstream table matches more than one table-route rule at the same level.
+	RoutingConflictOverlapping = "overlapping"
+	// RoutingConflictNoMergeStrategy means multiple upstream tables are routed to one downstream table, but the
+	// subtask is not in shard mode to merge them.
+	RoutingConflictNoMergeStrategy = "no-merge-strategy"
+	// RoutingConflictTargetSchemaNotExist means the downstream schema routed to doesn't exist.
+	RoutingConflictTargetSchemaNotExist = "target-schema-not-exist"
+)
+
+// RoutingConflict represents a conflicting or dangerous mapping of the table-route rules of a subtask.
+type RoutingConflict struct {
+	Kind string `json:"kind"`
+	// Target is the downstream table, or the downstream schema for RoutingConflictTargetSchemaNotExist.
+	Target       string   `json:"target"`
+	SourceTables []string `json:"source-tables"`
+	Message      string   `json:"message"`
+}
+
+// probeRoutingTables fetches the upstream tables to be migrated and the downstream schemas, it's a variable to be
+// replaced in tests.
+var probeRoutingTables = func(ctx context.Context, dbProvider conn.DBProvider, cfg *config.SubTaskConfig,
+	baList *filter.Filter) (sourceTables map[string][]string, targetSchemas []string, err error) {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	fromDB, err := dbProvider.Apply(cfg.From)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fromDB.Close()
+	if sourceTables, err = utils.FetchAllDoTables(ctx, fromDB.DB, baList); err != nil {
+		return nil, nil, err
+	}
+
+	toDB, err := dbProvider.Apply(cfg.To)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer toDB.Close()
+	if targetSchemas, err = dbutil.GetSchemas(ctx, toDB.DB); err != nil {
+		return nil, nil, terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+	}
+	return sourceTables, targetSchemas, nil
+}
+
+// ValidateRoutingRules routes the upstream tables to be migrated by the table-route rules of the subtask, and reports
+// the tables matching more than one rule, the downstream tables merged from multiple upstream tables without shard
+// mode, and the downstream schemas routed to but not existing. the downstream schemas are created by the load unit
+// in full migration, so they're only checked for incremental tasks or in the sync unit.
+func (w *Worker) ValidateRoutingRules(name string) ([]RoutingConflict, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	st.RLock()
+	cfg, dbProvider := st.cfg, st.dbProvider
+	st.RUnlock()
+	tableRouter, err := router.NewTableRouter(cfg.CaseSensitive, cfg.RouteRules)
+	if err != nil {
+		return nil, terror.ErrSyncerUnitGenTableRouter.Delegate(err)
+	}
+	baList, err := filter.New(cfg.CaseSensitive, cfg.BAList)
+	if err != nil {
+		return nil, terror.ErrSyncerUnitGenBAList.Delegate(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultDBTimeout)
+	defer cancel()
+	sourceTables, targetSchemas, err := probeRoutingTables(ctx, dbProvider, cfg, baList)
+	if err != nil {
+		return nil, err
+	}
+	cu := st.CurrUnit()
+	checkSchemas := cfg.Mode == config.ModeIncrement || (cu != nil && cu.Type() == pb.UnitType_Sync)
+	return checkRoutingConflicts(tableRouter, cfg.ShardMode, sourceTables, checkSchemas, targetSchemas), nil
+}
+
+// checkRoutingConflicts routes sourceTables (schema -> tables) by tableRouter and returns the conflicts sorted by
+// kind and target, the target schemas are only checked if checkSchemas is true.
+func checkRoutingConflicts(tableRouter *router.Table, shardMode string, sourceTables map[string][]string,
+	checkSchemas bool, targetSchemas []string) []RoutingConflict {
+	var conflicts []RoutingConflict
+	// target table -> source tables
+	targets := make(map[string][]string)
+	// target schemas -> source tables routed by rules
+	routedSchemas := make(map[string][]string)
+	for schema, tables := range sourceTables {
+		for _, table := range tables {
+			source := dbutil.TableName(schema, table)
+			targetSchema, targetTable, err := tableRouter.Route(schema, table)
+			if err != nil {
+				conflicts = append(conflicts, RoutingConflict{
+					Kind:         RoutingConflictOverlapping,
+					SourceTables: []string{source},
+					Message:      err.Error(),
+				})
+				continue
+			}
+			if targetSchema == "" {
+				targetSchema, targetTable = schema, table
+			} else {
+				routedSchemas[targetSchema] = append(routedSchemas[targetSchema], source)
+			}
+			if targetTable == "" {
+				targetTable = table
+			}
+			target := dbutil.TableName(targetSchema, targetTable)
+			targets[target] = append(targets[target], source)
+		}
+	}
+
+	if shardMode == "" {
+		for target, sources := range targets {
+			if len(sources) > 1 {
+				sort.Strings(sources)
+				conflicts = append(conflicts, RoutingConflict{
+					Kind:         RoutingConflictNoMergeStrategy,
+					Target:       target,
+					SourceTables: sources,
+					Message:      fmt.Sprintf("%d upstream tables are routed to %s, but shard-mode is not set to merge them", len(sources), target),
+				})
+			}
+		}
+	}
+
+	if checkSchemas {
+		existing := make(map[string]struct{}, len(targetSchemas))
+		for _, schema := range targetSchemas {
+			existing[schema] = struct{}{}
+		}
+		for schema, sources := range routedSchemas {
+			if _, ok := existing[schema]; !ok {
+				sort.Strings(sources)
+				conflicts = append(conflicts, RoutingConflict{
+					Kind:         RoutingConflictTargetSchemaNotExist,
+					Target:       schema,
+					SourceTables: sources,
+					Message:      fmt.Sprintf("downstream schema %s routed to doesn't exist", schema),
+				})
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		if conflicts[i].Target != conflicts[j].Target {
+			return conflicts[i].Target < conflicts[j].Target
+		}
+		return conflicts[i].SourceTables[0] < conflicts[j].SourceTables[0]
+	})
+	return conflicts
+}
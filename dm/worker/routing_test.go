@@ -0,0 +1,87 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	router "github.com/pingcap/tidb-tools/pkg/table-router"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testRouting struct{}
+
+var _ = Suite(&testRouting{})
+
+func (t *testRouting) TestValidateRoutingRules(c *C) {
+	originProbe := probeRoutingTables
+	defer func() {
+		probeRoutingTables = originProbe
+	}()
+	sourceTables := map[string][]string{
+		"shard_1": {"t_1", "t_2", "other"},
+		"shard_2": {"t_1"},
+		"single":  {"tbl"},
+		"overlap": {"tbl"},
+	}
+	probeRoutingTables = func(context.Context, conn.DBProvider, *config.SubTaskConfig, *filter.Filter) (map[string][]string, []string, error) {
+		return sourceTables, []string{"merged", "single"}, nil
+	}
+
+	cfg := &config.SubTaskConfig{
+		Name: "test-routing",
+		Mode: config.ModeIncrement,
+		RouteRules: []*router.TableRule{
+			{SchemaPattern: "shard_*", TablePattern: "t_*", TargetSchema: "merged", TargetTable: "t"},
+			{SchemaPattern: "shard_*", TargetSchema: "merged_not_exist"},
+			{SchemaPattern: "overlap", TablePattern: "tbl", TargetSchema: "single", TargetTable: "tbl"},
+			{SchemaPattern: "overlap", TablePattern: "tb*", TargetSchema: "single", TargetTable: "tbl_2"},
+		},
+	}
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(cfg, pb.Stage_Running, nil))
+
+	conflicts, err := w.ValidateRoutingRules("test-routing")
+	c.Assert(err, IsNil)
+	c.Assert(conflicts, HasLen, 3)
+	c.Assert(conflicts[0].Kind, Equals, RoutingConflictNoMergeStrategy)
+	c.Assert(conflicts[0].Target, Equals, "`merged`.`t`")
+	c.Assert(conflicts[0].SourceTables, DeepEquals, []string{"`shard_1`.`t_1`", "`shard_1`.`t_2`", "`shard_2`.`t_1`"})
+	c.Assert(conflicts[1].Kind, Equals, RoutingConflictOverlapping)
+	c.Assert(conflicts[1].SourceTables, DeepEquals, []string{"`overlap`.`tbl`"})
+	c.Assert(conflicts[2].Kind, Equals, RoutingConflictTargetSchemaNotExist)
+	c.Assert(conflicts[2].Target, Equals, "merged_not_exist")
+	c.Assert(conflicts[2].SourceTables, DeepEquals, []string{"`shard_1`.`other`"})
+
+	// merged in shard mode, and the target schemas are not checked before loaded in full migration.
+	cfg.ShardMode = config.ShardOptimistic
+	cfg.Mode = config.ModeAll
+	conflicts, err = w.ValidateRoutingRules("test-routing")
+	c.Assert(err, IsNil)
+	c.Assert(conflicts, HasLen, 1)
+	c.Assert(conflicts[0].Kind, Equals, RoutingConflictOverlapping)
+
+	_, err = w.ValidateRoutingRules("not-exist")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	router "github.com/pingcap/tidb-tools/pkg/table-router"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// ColumnDiff represents a column different between upstream and downstream, the definition is empty on the side
+// which the column doesn't exist in.
+type ColumnDiff struct {
+	Name       string `json:"name"`
+	Upstream   string `json:"upstream"`
+	Downstream string `json:"downstream"`
+}
+
+// IndexDiff represents an index different between upstream and downstream, the definition is empty on the side
+// which the index doesn't exist in.
+type IndexDiff struct {
+	Name       string `json:"name"`
+	Upstream   string `json:"upstream"`
+	Downstream string `json:"downstream"`
+}
+
+// SchemaDiff represents the differences between the upstream table and the downstream table routed to.
+type SchemaDiff struct {
+	UpstreamTable         string       `json:"upstream-table"`
+	DownstreamTable       string       `json:"downstream-table"`
+	UpstreamCreateTable   string       `json:"upstream-create-table"`
+	DownstreamCreateTable string       `json:"downstream-create-table"`
+	Columns               []ColumnDiff `json:"columns"`
+	Indexes               []IndexDiff  `json:"indexes"`
+}
+
+// Drifted returns whether the schemas of upstream and downstream are different.
+func (d *SchemaDiff) Drifted() bool {
+	return len(d.Columns) > 0 || len(d.Indexes) > 0
+}
+
+// probeCreateTables fetches the CREATE TABLE statements of the upstream table and the downstream table, it's a
+// variable to be replaced in tests.
+var probeCreateTables = func(ctx context.Context, dbProvider conn.DBProvider, cfg *config.SubTaskConfig,
+	upSchema, upTable, downSchema, downTable string) (upstream, downstream string, err error) {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	fromDB, err := dbProvider.Apply(cfg.From)
+	if err != nil {
+		return "", "", err
+	}
+	defer fromDB.Close()
+	if upstream, err = dbutil.GetCreateTableSQL(ctx, fromDB.DB, upSchema, upTable); err != nil {
+		return "", "", terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+	}
+
+	toDB, err := dbProvider.Apply(cfg.To)
+	if err != nil {
+		return "", "", err
+	}
+	defer toDB.Close()
+	if downstream, err = dbutil.GetCreateTableSQL(ctx, toDB.DB, downSchema, downTable); err != nil {
+		return "", "", terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+	}
+	return upstream, downstream, nil
+}
+
+// DiffSchemaWithUpstream compares the upstream table with the downstream table routed to by the table-route rules of
+// the subtask, and reports the columns and indexes different between them. it diagnoses the schema drift caused by
+// DDLs executed in downstream manually or skipped, while OperateSchema only sets the schema tracked by the subtask.
+func (w *Worker) DiffSchemaWithUpstream(ctx context.Context, name, schema, table string) (*SchemaDiff, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	st.RLock()
+	cfg, dbProvider := st.cfg, st.dbProvider
+	st.RUnlock()
+	tableRouter, err := router.NewTableRouter(cfg.CaseSensitive, cfg.RouteRules)
+	if err != nil {
+		return nil, terror.ErrSyncerUnitGenTableRouter.Delegate(err)
+	}
+	targetSchema, targetTable, err := tableRouter.Route(schema, table)
+	if err != nil {
+		return nil, terror.ErrSyncerUnitGenTableRouter.Delegate(err)
+	}
+	if targetSchema == "" {
+		targetSchema = schema
+	}
+	if targetTable == "" {
+		targetTable = table
+	}
+
+	upstream, downstream, err := probeCreateTables(ctx, dbProvider, cfg, schema, table, targetSchema, targetTable)
+	if err != nil {
+		return nil, err
+	}
+	diff := &SchemaDiff{
+		UpstreamTable:         dbutil.TableName(schema, table),
+		DownstreamTable:       dbutil.TableName(targetSchema, targetTable),
+		UpstreamCreateTable:   upstream,
+		DownstreamCreateTable: downstream,
+	}
+	if err = diffCreateTables(diff, upstream, downstream); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// diffCreateTables parses the CREATE TABLE statements and fills the differences of columns and indexes into diff,
+// the names of columns and indexes are case-insensitive.
+func diffCreateTables(diff *SchemaDiff, upstream, downstream string) error {
+	p := parser.New()
+	upTI, err := dbutil.GetTableInfoBySQL(upstream, p)
+	if err != nil {
+		return terror.ErrWorkerParseCreateTable.Delegate(err, diff.UpstreamTable)
+	}
+	downTI, err := dbutil.GetTableInfoBySQL(downstream, p)
+	if err != nil {
+		return terror.ErrWorkerParseCreateTable.Delegate(err, diff.DownstreamTable)
+	}
+
+	upCols, downCols := columnDefs(upTI), columnDefs(downTI)
+	for _, name := range unionKeys(upCols, downCols) {
+		if up, down := upCols[name], downCols[name]; up != down {
+			diff.Columns = append(diff.Columns, ColumnDiff{Name: name, Upstream: up, Downstream: down})
+		}
+	}
+	upIdxs, downIdxs := indexDefs(upTI), indexDefs(downTI)
+	for _, name := range unionKeys(upIdxs, downIdxs) {
+		if up, down := upIdxs[name], downIdxs[name]; up != down {
+			diff.Indexes = append(diff.Indexes, IndexDiff{Name: name, Upstream: up, Downstream: down})
+		}
+	}
+	return nil
+}
+
+// columnDefs returns the lower-cased column name -> the type and nullability of the columns.
+func columnDefs(ti *model.TableInfo) map[string]string {
+	defs := make(map[string]string, len(ti.Columns))
+	for _, col := range ti.Columns {
+		def := col.GetTypeDesc()
+		if mysql.HasNotNullFlag(col.Flag) {
+			def += " NOT NULL"
+		}
+		defs[col.Name.L] = def
+	}
+	return defs
+}
+
+// indexDefs returns the lower-cased index name -> the kind and columns of the indexes, including the integer
+// primary key used as the row handle which isn't in ti.Indices.
+func indexDefs(ti *model.TableInfo) map[string]string {
+	defs := make(map[string]string, len(ti.Indices)+1)
+	if pk := ti.GetPkColInfo(); ti.PKIsHandle && pk != nil {
+		defs["primary"] = "PRIMARY KEY(" + pk.Name.L + ")"
+	}
+	for _, idx := range ti.Indices {
+		kind := "KEY"
+		switch {
+		case idx.Primary:
+			kind = "PRIMARY KEY"
+		case idx.Unique:
+			kind = "UNIQUE KEY"
+		}
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.Name.L)
+		}
+		defs[idx.Name.L] = kind + "(" + strings.Join(cols, ",") + ")"
+	}
+	return defs
+}
+
+func unionKeys(m1, m2 map[string]string) []string {
+	keys := make([]string, 0, len(m1)+len(m2))
+	for k := range m1 {
+		keys = append(keys, k)
+	}
+	for k := range m2 {
+		if _, ok := m1[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
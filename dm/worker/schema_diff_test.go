@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	router "github.com/pingcap/tidb-tools/pkg/table-router"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testSchemaDiff struct{}
+
+var _ = Suite(&testSchemaDiff{})
+
+func (t *testSchemaDiff) TestDiffSchemaWithUpstream(c *C) {
+	originProbe := probeCreateTables
+	defer func() {
+		probeCreateTables = originProbe
+	}()
+	var (
+		upstream   = "CREATE TABLE `t_1` (`id` int(11) NOT NULL, `name` varchar(20), `age` int(11), `ts` datetime, PRIMARY KEY (`id`), UNIQUE KEY `uk_name` (`name`))"
+		downstream = "CREATE TABLE `t` (`ID` int(11) NOT NULL, `name` varchar(40), `age` int(11), `extra` int(11), PRIMARY KEY (`id`), KEY `uk_name` (`name`), KEY `idx_age` (`age`))"
+		probed     []string
+	)
+	probeCreateTables = func(_ context.Context, _ conn.DBProvider, _ *config.SubTaskConfig, upSchema, upTable, downSchema, downTable string) (string, string, error) {
+		probed = []string{upSchema, upTable, downSchema, downTable}
+		return upstream, downstream, nil
+	}
+
+	cfg := &config.SubTaskConfig{
+		Name: "test-schema-diff",
+		RouteRules: []*router.TableRule{
+			{SchemaPattern: "shard_*", TablePattern: "t_*", TargetSchema: "merged", TargetTable: "t"},
+		},
+	}
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.subTaskHolder.recordSubTask(NewSubTaskWithStage(cfg, pb.Stage_Running, nil))
+	ctx := context.Background()
+
+	_, err := w.DiffSchemaWithUpstream(ctx, "not-exist", "shard_1", "t_1")
+	c.Assert(terror.ErrWorkerSubTaskNotFound.Equal(err), IsTrue)
+
+	diff, err := w.DiffSchemaWithUpstream(ctx, "test-schema-diff", "shard_1", "t_1")
+	c.Assert(err, IsNil)
+	c.Assert(probed, DeepEquals, []string{"shard_1", "t_1", "merged", "t"})
+	c.Assert(diff.UpstreamTable, Equals, "`shard_1`.`t_1`")
+	c.Assert(diff.DownstreamTable, Equals, "`merged`.`t`")
+	c.Assert(diff.Drifted(), IsTrue)
+	c.Assert(diff.Columns, DeepEquals, []ColumnDiff{
+		{Name: "extra", Downstream: "int(11)"},
+		{Name: "name", Upstream: "varchar(20)", Downstream: "varchar(40)"},
+		{Name: "ts", Upstream: "datetime"},
+	})
+	c.Assert(diff.Indexes, DeepEquals, []IndexDiff{
+		{Name: "idx_age", Downstream: "KEY(age)"},
+		{Name: "uk_name", Upstream: "UNIQUE KEY(name)", Downstream: "KEY(name)"},
+	})
+
+	// no drift.
+	downstream = upstream
+	diff, err = w.DiffSchemaWithUpstream(ctx, "test-schema-diff", "shard_1", "t_1")
+	c.Assert(err, IsNil)
+	c.Assert(diff.Drifted(), IsFalse)
+
+	// not routed.
+	_, err = w.DiffSchemaWithUpstream(ctx, "test-schema-diff", "single", "tbl")
+	c.Assert(err, IsNil)
+	c.Assert(probed, DeepEquals, []string{"single", "tbl", "single", "tbl"})
+
+	// can't be parsed.
+	downstream = "CREATE TABLE"
+	_, err = w.DiffSchemaWithUpstream(ctx, "test-schema-diff", "shard_1", "t_1")
+	c.Assert(terror.ErrWorkerParseCreateTable.Equal(err), IsTrue)
+}
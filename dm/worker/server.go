@@ -15,7 +15,9 @@ package worker
 
 import (
 	"context"
+	cryptotls "crypto/tls"
 	"net"
+	"net/http"
 	"sync"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
+	"github.com/pingcap/dm/relay"
 	"github.com/pingcap/dm/syncer"
 
 	"github.com/pingcap/errors"
@@ -48,7 +51,10 @@ var (
 	keepaliveTimeout          = 3 * time.Second
 	keepaliveTime             = 3 * time.Second
 	retryGetSourceBoundConfig = 5
+	retryGetRelayStage        = 5
 	retryConnectSleepTime     = time.Second
+	retryConnectMaxSleepTime  = 30 * time.Second
+	retryConnectBackoffFactor = 2.0
 	syncMasterEndpointsTime   = 3 * time.Second
 	getMinLocForSubTaskFunc   = getMinLocForSubTask
 )
@@ -73,6 +79,10 @@ type Server struct {
 	svr        *grpc.Server
 	worker     *Worker
 	etcdClient *clientv3.Client
+	// etcdWatchClient is used for long-lived watches, it's the same as etcdClient if `separate-watch-client` is not set.
+	etcdWatchClient *clientv3.Client
+	// relayFilesSvr serves relay log files for other DM-workers if `relay-files-addr` is set.
+	relayFilesSvr *http.Server
 
 	// relay status will never be put in server.sourceStatus
 	sourceStatus pb.SourceStatus
@@ -103,16 +113,24 @@ func (s *Server) Start() error {
 	}
 	s.rootLis = tls.WrapListener(rootLis)
 
-	s.etcdClient, err = clientv3.New(clientv3.Config{
+	etcdCfg := clientv3.Config{
 		Endpoints:            GetJoinURLs(s.cfg.Join),
 		DialTimeout:          dialTimeout,
 		DialKeepAliveTime:    keepaliveTime,
 		DialKeepAliveTimeout: keepaliveTimeout,
 		TLS:                  tls.TLSConfig(),
-	})
+	}
+	s.etcdClient, err = clientv3.New(etcdCfg)
 	if err != nil {
 		return err
 	}
+	s.etcdWatchClient = s.etcdClient
+	if s.cfg.SeparateWatchClient {
+		s.etcdWatchClient, err = clientv3.New(etcdCfg)
+		if err != nil {
+			return err
+		}
+	}
 
 	s.setWorker(nil, true)
 	bound, sourceCfg, revBound, err := ha.GetSourceBoundConfig(s.etcdClient, s.cfg.Name)
@@ -184,6 +202,12 @@ func (s *Server) Start() error {
 		InitStatus(httpL) // serve status
 	}()
 
+	if len(s.cfg.RelayFilesAddr) > 0 {
+		if err = s.startRelayFilesServer(tls); err != nil {
+			return err
+		}
+	}
+
 	s.closed.Set(false)
 	log.L().Info("listening gRPC API and status request", zap.String("address", s.cfg.WorkerAddr))
 	err = m.Serve()
@@ -193,6 +217,42 @@ func (s *Server) Start() error {
 	return terror.ErrWorkerStartService.Delegate(err)
 }
 
+// startRelayFilesServer starts to serve relay log files on `relay-files-addr` for the DM-workers whose `relay-source`
+// is the current DM-worker. the clients must present a certificate verified by `ssl-ca`, and the TLS config is cloned
+// to not require the client certificate for the gRPC API and status requests.
+func (s *Server) startRelayFilesServer(tls *toolutils.TLS) error {
+	tlsCfg := tls.TLSConfig()
+	if tlsCfg == nil {
+		return terror.ErrWorkerRelayFilesNeedTLS.Generate(s.cfg.RelayFilesAddr)
+	}
+	tlsCfg = tlsCfg.Clone()
+	tlsCfg.ClientAuth = cryptotls.RequireAndVerifyClientCert
+
+	lis, err := net.Listen("tcp", s.cfg.RelayFilesAddr)
+	if err != nil {
+		return terror.ErrWorkerStartService.Delegate(err)
+	}
+
+	relayFiles := &relayFilesHandler{getWorker: func() *Worker {
+		return s.getWorker(true)
+	}}
+	mux := http.NewServeMux()
+	mux.Handle(relay.RemoteRelayFilesPath, relayFiles)
+	mux.Handle(relay.RemoteRelayFilesPath+"/", relayFiles)
+	s.relayFilesSvr = &http.Server{Handler: mux}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		err2 := s.relayFilesSvr.Serve(cryptotls.NewListener(lis, tlsCfg))
+		if err2 != nil && !common.IsErrNetClosing(err2) && err2 != http.ErrServerClosed {
+			log.L().Error("relay files server returned", log.ShortError(err2))
+		}
+	}()
+	log.L().Info("listening relay files request", zap.String("address", s.cfg.RelayFilesAddr))
+	return nil
+}
+
 // worker keepalive with master
 // If worker loses connect from master, it would stop all task and try to connect master again.
 func (s *Server) startKeepAlive() {
@@ -237,6 +297,9 @@ func (s *Server) syncMasterEndpoints(ctx context.Context) {
 		}
 		log.L().Info("will sync endpoints to", zap.Strings("client URLs", clientURLs))
 		s.etcdClient.SetEndpoints(clientURLs...)
+		if s.etcdWatchClient != s.etcdClient {
+			s.etcdWatchClient.SetEndpoints(clientURLs...)
+		}
 		lastClientUrls = make([]string, len(clientURLs))
 		copy(lastClientUrls, clientURLs)
 	}
@@ -266,7 +329,7 @@ func (s *Server) observeSourceBound(ctx context.Context, rev int64) error {
 				close(sourceBoundErrCh)
 				wg.Done()
 			}()
-			ha.WatchSourceBound(ctx1, s.etcdClient, s.cfg.Name, rev+1, sourceBoundCh, sourceBoundErrCh)
+			ha.WatchSourceBound(ctx1, s.etcdWatchClient, s.cfg.Name, rev+1, sourceBoundCh, sourceBoundErrCh)
 		}()
 		err := s.handleSourceBound(ctx1, sourceBoundCh, sourceBoundErrCh)
 		cancel1()
@@ -337,6 +400,12 @@ func (s *Server) doClose() {
 			log.L().Error("fail to close net listener", log.ShortError(err))
 		}
 	}
+	if s.relayFilesSvr != nil {
+		err := s.relayFilesSvr.Close()
+		if err != nil && !common.IsErrNetClosing(err) {
+			log.L().Error("fail to close relay files server", log.ShortError(err))
+		}
+	}
 	if s.svr != nil {
 		// GracefulStop can not cancel active stream RPCs
 		// and the stream RPC may block on Recv or Send
@@ -514,11 +583,15 @@ func (s *Server) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest) (*pb
 		return makeCommonWorkerResponse(terror.ErrWorkerNoStart.Generate()), nil
 	}
 
-	err := w.PurgeRelay(ctx, req)
+	msg, err := w.PurgeRelay(ctx, req)
 	if err != nil {
 		log.L().Error("fail to purge relay", zap.String("request", "PurgeRelay"), zap.Stringer("payload", req), zap.Error(err))
+		return makeCommonWorkerResponse(err), nil
 	}
-	return makeCommonWorkerResponse(err), nil
+	return &pb.CommonWorkerResponse{
+		Result: true,
+		Msg:    msg, // the relay log files would be purged for dry run.
+	}, nil
 }
 
 // OperateSchema operates schema for an upstream table.
@@ -557,10 +630,13 @@ func (s *Server) startWorker(cfg *config.SourceConfig) error {
 		return terror.ErrWorkerAlreadyStart.Generate()
 	}
 
-	w, err := NewWorker(cfg, s.etcdClient, s.cfg.Name)
+	// relay log is fetched from another DM-worker with the same security config as the current one.
+	cfg.RelaySourceSecurity = s.cfg.Security
+	w, err := NewWorkerWithWatchClient(cfg, s.etcdClient, s.etcdWatchClient, s.cfg.Name)
 	if err != nil {
 		return err
 	}
+	w.SetStatusConcurrency(s.cfg.StatusConcurrency)
 	s.setWorker(w, false)
 
 	if cfg.EnableRelay {
@@ -0,0 +1,107 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// serverIDUser is implemented by the units replicating binlog from upstream by a server id.
+type serverIDUser interface {
+	ServerID() (serverID uint32, remote bool)
+	RenewServerID()
+}
+
+// EffectiveServerID returns the server id used by the subtask to replicate binlog from upstream, and whether the
+// subtask is replicating binlog from upstream by it now. it's the server id in config if the sync unit isn't created.
+func (st *SubTask) EffectiveServerID() (uint32, bool) {
+	for _, u := range st.units {
+		if user, ok := u.(serverIDUser); ok {
+			serverID, remote := user.ServerID()
+			return serverID, remote && st.CurrUnit() == u
+		}
+	}
+	return st.cfg.ServerID, false
+}
+
+// GetEffectiveServerID returns the server id used by the subtask to replicate binlog from upstream, which may be
+// different from the server id in config because a random one is used if it's already used by others. it returns
+// 0 if the subtask is not found.
+func (w *Worker) GetEffectiveServerID(name string) uint32 {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return 0
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return 0
+	}
+	serverID, _ := st.EffectiveServerID()
+	return serverID
+}
+
+// pauseServerIDCollisions pauses the running subtasks replicating binlog from the same upstream by the same server id,
+// upstream kills the old replication connection when a new one with the same server id is created, which makes the
+// subtasks fail intermittently. the subtask with the smallest name keeps running, and the others get new random
+// server ids after resuming.
+func (w *Worker) pauseServerIDCollisions() {
+	w.RLock()
+	defer w.RUnlock()
+
+	type serverIDKey struct {
+		upstream string
+		serverID uint32
+	}
+	users := make(map[serverIDKey][]string)
+	sts := w.subTaskHolder.getAllSubTasks()
+	for name, st := range sts {
+		if st.Stage() != pb.Stage_Running {
+			continue
+		}
+		serverID, remote := st.EffectiveServerID()
+		if !remote {
+			continue
+		}
+		key := serverIDKey{upstream: fmt.Sprintf("%s:%d", st.cfg.From.Host, st.cfg.From.Port), serverID: serverID}
+		users[key] = append(users[key], name)
+	}
+
+	for key, names := range users {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		for _, name := range names[1:] {
+			st := sts[name]
+			w.l.Error("server-id is used by multiple subtasks to replicate from the same upstream, pause the subtask",
+				zap.String("task", name), zap.String("other task", names[0]), zap.Uint32("server id", key.serverID), zap.String("upstream", key.upstream))
+			for _, u := range st.units {
+				if user, ok := u.(serverIDUser); ok {
+					user.RenewServerID()
+				}
+			}
+			if err := st.pauseWithError(terror.ErrWorkerServerIDCollision.Generate(key.serverID, name, names[0], key.upstream)); err != nil {
+				w.l.Warn("fail to pause the subtask with colliding server-id", zap.String("task", name), zap.Error(err))
+			}
+		}
+	}
+}
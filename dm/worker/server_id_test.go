@@ -0,0 +1,101 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testServerID struct{}
+
+var _ = Suite(&testServerID{})
+
+type mockServerIDUnit struct {
+	*MockUnit
+	serverID uint32
+	remote   bool
+	renewed  bool
+}
+
+func (m *mockServerIDUnit) ServerID() (uint32, bool) {
+	return m.serverID, m.remote
+}
+
+func (m *mockServerIDUnit) RenewServerID() {
+	m.renewed = true
+}
+
+func (t *testServerID) TestPauseServerIDCollisions(c *C) {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+
+	units := make(map[string]*mockServerIDUnit)
+	addSubTask := func(name string, serverID uint32, remote bool) *SubTask {
+		cfg := &config.SubTaskConfig{Name: name, ServerID: 101}
+		cfg.From.Host, cfg.From.Port = "127.0.0.1", 3306
+		u := &mockServerIDUnit{MockUnit: NewMockUnit(pb.UnitType_Sync), serverID: serverID, remote: remote}
+		st := NewSubTaskWithStage(cfg, pb.Stage_Running, nil)
+		st.units = []unit.Unit{u}
+		st.setCurrUnit(u)
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		w.subTaskHolder.recordSubTask(st)
+		units[name] = u
+		return st
+	}
+	stA := addSubTask("task-a", 1001, true)
+	stB := addSubTask("task-b", 1001, true)
+	stC := addSubTask("task-c", 1001, false) // reading relay log
+	stD := addSubTask("task-d", 1002, true)
+	// the server id in config is used before the sync unit is created.
+	stE := NewSubTaskWithStage(&config.SubTaskConfig{Name: "task-e", ServerID: 101}, pb.Stage_Running, nil)
+	stE.setCurrUnit(NewMockUnit(pb.UnitType_Dump))
+	w.subTaskHolder.recordSubTask(stE)
+
+	c.Assert(w.GetEffectiveServerID("task-a"), Equals, uint32(1001))
+	c.Assert(w.GetEffectiveServerID("task-d"), Equals, uint32(1002))
+	c.Assert(w.GetEffectiveServerID("task-e"), Equals, uint32(101))
+	c.Assert(w.GetEffectiveServerID("not-exist"), Equals, uint32(0))
+
+	// only task-b is paused, and it gets a new server id after resuming.
+	w.pauseServerIDCollisions()
+	c.Assert(stA.Stage(), Equals, pb.Stage_Running)
+	c.Assert(stB.Stage(), Equals, pb.Stage_Paused)
+	c.Assert(stC.Stage(), Equals, pb.Stage_Running)
+	c.Assert(stD.Stage(), Equals, pb.Stage_Running)
+	c.Assert(stE.Stage(), Equals, pb.Stage_Running)
+	c.Assert(stB.Result().Errors, HasLen, 1)
+	c.Assert(stB.Result().Errors[0].ErrCode, Equals, int32(terror.ErrWorkerServerIDCollision.Code()))
+	c.Assert(stB.Result().Errors[0].Message, Matches, ".*server-id 1001 of subtask task-b is also used by subtask task-a.*")
+	c.Assert(isResumableError(stB.Result().Errors[0]), IsTrue)
+	c.Assert(units["task-a"].renewed, IsFalse)
+	c.Assert(units["task-b"].renewed, IsTrue)
+
+	// no more collisions.
+	w.pauseServerIDCollisions()
+	c.Assert(stA.Stage(), Equals, pb.Stage_Running)
+
+	w.closed.Set(closedTrue)
+	c.Assert(w.GetEffectiveServerID("task-a"), Equals, uint32(0))
+}
@@ -129,6 +129,8 @@ func (t *testServer) TestServer(c *C) {
 		createUnits = createRealUnits
 	}()
 
+	// use a dedicated etcd client for watches
+	cfg.SeparateWatchClient = true
 	s := NewServer(cfg)
 	defer s.Close()
 	go func() {
@@ -372,6 +374,7 @@ func (t *testServer) TestWatchSourceBoundEtcdCompact(c *C) {
 		DialKeepAliveTimeout: keepaliveTimeout,
 	})
 	s.etcdClient = etcdCli
+	s.etcdWatchClient = etcdCli
 	s.closed.Set(false)
 	c.Assert(err, IsNil)
 	sourceCfg := loadSourceConfigWithoutPassword(c)
@@ -502,7 +505,11 @@ func (t *testServer) testRetryConnectMaster(c *C, s *Server, ETCD *embed.Etcd, d
 	time.Sleep(6 * time.Second)
 	// When worker server fail to keepalive with etcd, server should close its worker
 	c.Assert(s.getWorker(true), IsNil)
-	c.Assert(s.getSourceStatus(true).Result, IsNil)
+	// and report the source binding is lost
+	result := s.getSourceStatus(true).Result
+	c.Assert(result, NotNil)
+	c.Assert(result.Errors, HasLen, 1)
+	c.Assert(result.Errors[0].ErrCode, Equals, int32(terror.ErrWorkerSourceBindingLost.Code()))
 	ETCD, err := createMockETCD(dir, "http://"+hostName)
 	c.Assert(err, IsNil)
 	time.Sleep(3 * time.Second)
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"go.uber.org/zap"
@@ -25,6 +27,9 @@ import (
 	"github.com/pingcap/dm/dm/pb"
 )
 
+// statusTimedOutMsg is the message of the partial status of a subtask whose unit didn't report status in time.
+const statusTimedOutMsg = "status query timed out"
+
 // Status returns the status of the current sub task
 func (st *SubTask) Status(ctx context.Context) interface{} {
 	if cu := st.CurrUnit(); cu != nil {
@@ -54,8 +59,6 @@ func (w *Worker) Status(ctx context.Context, stName string) []*pb.SubTaskStatus
 		return nil // no sub task started
 	}
 
-	status := make([]*pb.SubTaskStatus, 0, len(sts))
-
 	// return status order by name
 	names := make([]string, 0, len(sts))
 	if len(stName) > 0 {
@@ -67,49 +70,113 @@ func (w *Worker) Status(ctx context.Context, stName string) []*pb.SubTaskStatus
 	}
 	sort.Strings(names)
 
-	for _, name := range names {
-		st, ok := sts[name]
-		var stStatus pb.SubTaskStatus
-		if !ok {
-			stStatus = pb.SubTaskStatus{
-				Name:   name,
-				Status: &pb.SubTaskStatus_Msg{Msg: fmt.Sprintf("no sub task with name %s has started", name)},
+	// collect status of subtasks concurrently, because units may query DB for status.
+	// the higher priority ones are collected first if the concurrency is limited.
+	status := make([]*pb.SubTaskStatus, len(names))
+	concurrency := int(w.statusConcurrency.Get())
+	if concurrency <= 0 {
+		concurrency = defaultStatusConcurrency
+	}
+	// every subtask has its own timeout, so a slow one doesn't eat the time of the others. without an explicit
+	// timeout, the deadline is shared equally by the rounds of collecting.
+	timeout := w.statusTaskTimeout.Get()
+	if deadline, ok := ctx.Deadline(); ok && timeout <= 0 {
+		rounds := (len(names) + concurrency - 1) / concurrency
+		timeout = time.Until(deadline) / time.Duration(rounds)
+	}
+	var (
+		wg            sync.WaitGroup
+		limit         = make(chan struct{}, concurrency)
+		inMaintenance = w.inMaintenance()
+	)
+	for _, i := range orderByPriority(names, sts) {
+		name := names[i]
+		limit <- struct{}{}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			ctx2 := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx2, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
 			}
-		} else {
-			var lockID = ""
-			op := st.ShardDDLOperation()
-			if op != nil {
-				lockID = op.ID
+			status[i] = subTaskStatus(ctx2, name, sts[name])
+			if usage, ok := w.resourceUsages.get(name); ok && sts[name] != nil {
+				status[i].ResourceUsage = usage.toPB()
 			}
-			cu := st.CurrUnit()
+			status[i].InMaintenance = inMaintenance
+		}(i, name)
+	}
+	wg.Wait()
 
-			stStatus = pb.SubTaskStatus{
-				Name:                name,
-				Stage:               st.Stage(),
-				Result:              st.Result(),
-				UnresolvedDDLLockID: lockID,
-			}
+	return status
+}
 
-			if cu != nil {
-				stStatus.Unit = cu.Type()
-				// oneof status
-				us := cu.Status(ctx)
-				switch stStatus.Unit {
-				case pb.UnitType_Check:
-					stStatus.Status = &pb.SubTaskStatus_Check{Check: us.(*pb.CheckStatus)}
-				case pb.UnitType_Dump:
-					stStatus.Status = &pb.SubTaskStatus_Dump{Dump: us.(*pb.DumpStatus)}
-				case pb.UnitType_Load:
-					stStatus.Status = &pb.SubTaskStatus_Load{Load: us.(*pb.LoadStatus)}
-				case pb.UnitType_Sync:
-					stStatus.Status = &pb.SubTaskStatus_Sync{Sync: us.(*pb.SyncStatus)}
-				}
-			}
+// subTaskStatus returns the status of the sub task, st is nil if the sub task has not started. if the current unit
+// doesn't report status before ctx is done, a partial status without the status of the unit is returned.
+func subTaskStatus(ctx context.Context, name string, st *SubTask) *pb.SubTaskStatus {
+	if st == nil {
+		return &pb.SubTaskStatus{
+			Name:   name,
+			Status: &pb.SubTaskStatus_Msg{Msg: fmt.Sprintf("no sub task with name %s has started", name)},
 		}
-		status = append(status, &stStatus)
 	}
 
-	return status
+	var lockID = ""
+	op := st.ShardDDLOperation()
+	if op != nil {
+		lockID = op.ID
+	}
+	cu := st.CurrUnit()
+
+	stStatus := &pb.SubTaskStatus{
+		Name:                name,
+		Stage:               st.Stage(),
+		Result:              st.Result(),
+		UnresolvedDDLLockID: lockID,
+		MaxAllowedLag:       int64(st.MaxAllowedLag() / time.Second),
+		ReplicationLag:      int64(st.ReplicationLag() / time.Second),
+		ErrorClassification: st.latestErrorClassification(),
+		PriorityClass:       st.PriorityClass(),
+	}
+	if concurrency, err := st.DumpConcurrency(); err == nil {
+		stStatus.DumpThreads = int32(concurrency.Threads)
+		stStatus.DumpChunkRows = concurrency.ChunkRows
+	}
+
+	if cu != nil {
+		stStatus.Unit = cu.Type()
+		// the unit may not respect ctx when querying DB, so don't wait for it after ctx is done.
+		usCh := make(chan interface{}, 1)
+		go func() {
+			usCh <- cu.Status(ctx)
+		}()
+		var us interface{}
+		select {
+		case us = <-usCh:
+		case <-ctx.Done():
+			stStatus.Status = &pb.SubTaskStatus_Msg{Msg: statusTimedOutMsg}
+			return stStatus
+		}
+		// oneof status
+		switch stStatus.Unit {
+		case pb.UnitType_Check:
+			stStatus.Status = &pb.SubTaskStatus_Check{Check: us.(*pb.CheckStatus)}
+		case pb.UnitType_Dump:
+			stStatus.Status = &pb.SubTaskStatus_Dump{Dump: us.(*pb.DumpStatus)}
+		case pb.UnitType_Load:
+			stStatus.Status = &pb.SubTaskStatus_Load{Load: us.(*pb.LoadStatus)}
+		case pb.UnitType_Sync:
+			syncStatus := us.(*pb.SyncStatus)
+			syncStatus.StuckBinlog = st.stuckCheckpoint()
+			stStatus.Status = &pb.SubTaskStatus_Sync{Sync: syncStatus}
+		}
+	}
+	return stStatus
 }
 
 // StatusJSON returns the status of the worker as json string
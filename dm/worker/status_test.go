@@ -0,0 +1,191 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dumpling"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+type testStatus struct{}
+
+var _ = Suite(&testStatus{})
+
+// slowStatusUnit takes some time to collect status, like units query DB for status.
+type slowStatusUnit struct {
+	*MockUnit
+	delay time.Duration
+}
+
+func (u *slowStatusUnit) Status(ctx context.Context) interface{} {
+	select {
+	case <-ctx.Done():
+	case <-time.After(u.delay):
+	}
+	return u.MockUnit.Status(ctx)
+}
+
+func newWorkerWithSlowSubTasks(count int, delay time.Duration) *Worker {
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	for i := 0; i < count; i++ {
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: fmt.Sprintf("task-%02d", i)}, pb.Stage_Running, nil)
+		st.setCurrUnit(&slowStatusUnit{MockUnit: NewMockUnit(pb.UnitType_Sync), delay: delay})
+		w.subTaskHolder.recordSubTask(st)
+	}
+	return w
+}
+
+func (t *testStatus) TestConcurrentStatus(c *C) {
+	var (
+		count = 40
+		delay = 50 * time.Millisecond
+		w     = newWorkerWithSlowSubTasks(count, delay)
+	)
+
+	start := time.Now()
+	status := w.Status(context.Background(), "")
+	elapsed := time.Since(start)
+	c.Assert(status, HasLen, count)
+	for i, st := range status {
+		// still ordered by name.
+		c.Assert(st.Name, Equals, fmt.Sprintf("task-%02d", i))
+		c.Assert(st.Stage, Equals, pb.Stage_Running)
+		c.Assert(st.GetSync(), NotNil)
+	}
+	// 40 subtasks with the default concurrency 8 take about 5 * 50ms, far less than 40 * 50ms.
+	c.Assert(elapsed < time.Duration(count/2)*delay, IsTrue)
+
+	// collect one by one.
+	w.SetStatusConcurrency(1)
+	start = time.Now()
+	c.Assert(w.Status(context.Background(), ""), HasLen, count)
+	c.Assert(time.Since(start) >= time.Duration(count)*delay, IsTrue)
+
+	// the timeout is shared by all subtasks.
+	w.SetStatusConcurrency(0)
+	c.Assert(w.statusConcurrency.Get(), Equals, int32(defaultStatusConcurrency))
+	w = newWorkerWithSlowSubTasks(count, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), delay)
+	defer cancel()
+	start = time.Now()
+	status = w.Status(ctx, "")
+	c.Assert(status, HasLen, count)
+	c.Assert(time.Since(start) < 10*delay, IsTrue)
+	for _, st := range status {
+		c.Assert(st.Stage, Equals, pb.Stage_Running)
+		c.Assert(st.Unit, Equals, pb.UnitType_Sync)
+		c.Assert(st.GetMsg(), Equals, statusTimedOutMsg)
+	}
+
+	// not started.
+	status = w.Status(context.Background(), "not-exist")
+	c.Assert(status, HasLen, 1)
+	c.Assert(status[0].GetMsg(), Matches, ".*no sub task with name not-exist has started.*")
+}
+
+func (t *testStatus) BenchmarkStatus(c *C) {
+	w := newWorkerWithSlowSubTasks(40, time.Millisecond)
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		w.Status(context.Background(), "")
+	}
+}
+
+// stuckStatusUnit blocks when collecting status and doesn't respect ctx, like a unit stuck on a lock wait upstream.
+type stuckStatusUnit struct {
+	*MockUnit
+	ch chan struct{}
+}
+
+func (u *stuckStatusUnit) Status(ctx context.Context) interface{} {
+	<-u.ch
+	return u.MockUnit.Status(ctx)
+}
+
+func (t *testStatus) TestStatusTaskTimeout(c *C) {
+	var (
+		count = 10
+		delay = 50 * time.Millisecond
+		w     = newWorkerWithSlowSubTasks(count, time.Millisecond)
+		stuck = &stuckStatusUnit{MockUnit: NewMockUnit(pb.UnitType_Sync), ch: make(chan struct{})}
+	)
+	defer close(stuck.ch)
+	w.subTaskHolder.findSubTask("task-03").setCurrUnit(stuck)
+
+	// the stuck subtask doesn't eat the time of the others.
+	w.SetStatusConcurrency(1)
+	w.SetStatusTaskTimeout(delay)
+	start := time.Now()
+	status := w.Status(context.Background(), "")
+	c.Assert(time.Since(start) < 4*delay, IsTrue)
+	c.Assert(status, HasLen, count)
+	for i, st := range status {
+		c.Assert(st.Stage, Equals, pb.Stage_Running)
+		if i == 3 {
+			c.Assert(st.Unit, Equals, pb.UnitType_Sync)
+			c.Assert(st.GetMsg(), Equals, statusTimedOutMsg)
+		} else {
+			c.Assert(st.GetSync(), NotNil)
+		}
+	}
+
+	// share the deadline equally.
+	w.SetStatusTaskTimeout(0)
+	c.Assert(w.statusTaskTimeout.Get(), Equals, time.Duration(0))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(count)*delay)
+	defer cancel()
+	status = w.Status(ctx, "")
+	c.Assert(status, HasLen, count)
+	c.Assert(status[3].GetMsg(), Equals, statusTimedOutMsg)
+	c.Assert(status[count-1].GetSync(), NotNil)
+}
+
+func (t *testStatus) TestSubTaskStatusFields(c *C) {
+	w := newWorkerWithSlowSubTasks(1, 0)
+	st := w.subTaskHolder.findSubTask("task-00")
+	st.SetMaxAllowedLag(time.Minute)
+	c.Assert(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 8, ChunkRows: 1000}), IsNil)
+
+	status := w.Status(context.Background(), "task-00")
+	c.Assert(status, HasLen, 1)
+	c.Assert(status[0].MaxAllowedLag, Equals, int64(60))
+	c.Assert(status[0].ReplicationLag, Equals, int64(0)) // not a sync unit
+	c.Assert(status[0].DumpThreads, Equals, int32(8))
+	c.Assert(status[0].DumpChunkRows, Equals, uint64(1000))
+	c.Assert(status[0].PriorityClass, Equals, config.PriorityNormal)
+
+	// no dump phase in incremental mode.
+	st.cfg.Mode = config.ModeIncrement
+	st.priorityClass.Set(config.PriorityHigh)
+	status = w.Status(context.Background(), "task-00")
+	c.Assert(status[0].PriorityClass, Equals, config.PriorityHigh)
+	c.Assert(status[0].DumpThreads, Equals, int32(0))
+	c.Assert(status[0].DumpChunkRows, Equals, uint64(0))
+	c.Assert(status[0].InMaintenance, IsFalse)
+
+	w.maintenance.set(&MaintenanceWindow{Start: time.Now().Add(-time.Minute), End: time.Now().Add(time.Minute)})
+	status = w.Status(context.Background(), "task-00")
+	c.Assert(status[0].InMaintenance, IsTrue)
+}
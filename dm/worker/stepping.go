@@ -0,0 +1,105 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// SetStepping enters or exits stepping mode of the sync unit.
+func (st *SubTask) SetStepping(enabled bool) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.SetStepping(enabled)
+	return nil
+}
+
+// Stepping returns whether the sync unit is in stepping mode, it's false if not in the sync unit.
+func (st *SubTask) Stepping() bool {
+	syncUnit, err := st.syncUnit()
+	return err == nil && syncUnit.Stepping()
+}
+
+// Step allows the sync unit to process n more binlog events in stepping mode.
+func (st *SubTask) Step(n int) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	return syncUnit.Step(n)
+}
+
+// EnterSubTaskStepping makes the subtask in the sync unit halt before processing the next binlog event, then it only
+// processes the events allowed by StepSubTask, to inspect the downstream between events for debugging. the subtask
+// is not auto resumed in stepping mode, and it must be exited by ExitSubTaskStepping to replicate normally again.
+func (w *Worker) EnterSubTaskStepping(name string) error {
+	return w.setSubTaskStepping(name, true)
+}
+
+// ExitSubTaskStepping exits stepping mode of the subtask, it continues replicating normally.
+func (w *Worker) ExitSubTaskStepping(name string) error {
+	return w.setSubTaskStepping(name, false)
+}
+
+func (w *Worker) setSubTaskStepping(name string, enabled bool) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetSubTaskStepping", auditArgs(map[string]interface{}{"task": name, "enabled": enabled}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	if err = st.SetStepping(enabled); err != nil {
+		return err
+	}
+	if enabled {
+		w.l.Warn("sub task enters stepping mode, it only processes the binlog events stepped", zap.String("task", name))
+	} else {
+		w.l.Info("sub task exits stepping mode", zap.String("task", name))
+	}
+	return nil
+}
+
+// StepSubTask allows the subtask in stepping mode to process n more binlog events, then it halts again.
+func (w *Worker) StepSubTask(name string, n int) (err error) {
+	w.RLock()
+	defer w.RUnlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "StepSubTask", auditArgs(map[string]interface{}{"task": name, "events": n}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if n <= 0 {
+		return terror.ErrWorkerInvalidStepCount.Generate(n)
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.Step(n)
+}
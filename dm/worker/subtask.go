@@ -15,10 +15,12 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/pingcap/failpoint"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	"github.com/siddontang/go-mysql/mysql"
 	"github.com/siddontang/go/sync2"
 	"go.etcd.io/etcd/clientv3"
@@ -30,8 +32,10 @@ import (
 	"github.com/pingcap/dm/dumpling"
 	"github.com/pingcap/dm/loader"
 	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
 	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
 	"github.com/pingcap/dm/pkg/shardddl/pessimism"
 	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
@@ -41,8 +45,18 @@ import (
 const (
 	// the timout to wait for relay catchup when switching from load unit to sync unit.
 	waitRelayCatchupTimeout = 5 * time.Minute
+	// the interval to check whether dependencies finished load unit when switching from load unit to sync unit.
+	waitDependencyInterval = time.Second
 )
 
+// waitDependencyTimeout is the max time to wait for dependencies finished load unit when switching from load unit
+// to sync unit.
+var waitDependencyTimeout = 30 * time.Minute
+
+// lagCheckGracePeriod is the time for a subtask to catch up after running or resuming, the replication lag isn't
+// checked against the max allowed lag in it, otherwise a subtask resumed after paused for lagging is paused again at once.
+var lagCheckGracePeriod = 5 * time.Minute
+
 // createRealUnits is subtask units initializer
 // it can be used for testing
 var createUnits = createRealUnits
@@ -77,6 +91,34 @@ type SubTask struct {
 	cfg *config.SubTaskConfig
 
 	initialized sync2.AtomicBool
+	// readOnly is applied to the sync unit when it's created
+	readOnly sync2.AtomicBool
+	// syncPaused holds writes of the sync unit like readOnly while relay keeps reading, until the sync is resumed
+	syncPaused sync2.AtomicBool
+	// dumpConcurrency is applied to the dump unit when it's created, nil means using the one in config
+	dumpConcurrency *dumpling.Concurrency
+	// dbProvider is applied to the units opening DB connections when they're created, nil means conn.DefaultDBProvider
+	dbProvider conn.DBProvider
+	// errClassifiers is applied to the units when they're created, nil means the default rules
+	errClassifiers *retry.Classifiers
+	// priorityClass is the priority class set at runtime, empty means using the one in config
+	priorityClass sync2.AtomicString
+	// resourceShare is the share of resources in config applied to the units when they're created, 0 means the full share
+	resourceShare float64
+
+	// goroutines counts goroutines processing units and fetching their results
+	goroutines goroutineCounter
+	// the number of next runs or resumes which should fail, injected for chaos testing
+	injectedFailures sync2.AtomicInt32
+	// the subtask is paused if its replication lag exceeds it, 0 means no limit
+	maxAllowedLag sync2.AtomicDuration
+	// the time in unix nanoseconds when the subtask runs or resumes last time, see lagCheckGracePeriod
+	runningSince sync2.AtomicInt64
+	// the checkpoint the sync unit was auto resumed from too many times without forward progress, see stuckCheckpoint
+	noProgressCheckpoint sync2.AtomicString
+	// dependenciesReady is whether the dependencies have finished load unit, they're only waited before entering
+	// sync unit for the first time.
+	dependenciesReady sync2.AtomicBool
 
 	l log.Logger
 
@@ -129,6 +171,34 @@ func (st *SubTask) Init() error {
 	if len(st.units) < 1 {
 		return terror.ErrWorkerNoAvailUnits.Generate(st.cfg.Name, st.cfg.Mode)
 	}
+	if st.readOnly.Get() || st.syncPaused.Get() {
+		if syncUnit, err := st.syncUnit(); err == nil {
+			syncUnit.SetReadOnly(true)
+		}
+	}
+	st.RLock()
+	dumpConcurrency := st.dumpConcurrency
+	dbProvider := st.dbProvider
+	errClassifiers := st.errClassifiers
+	st.RUnlock()
+	if dumpUnit := st.dumpUnit(); dumpUnit != nil && dumpConcurrency != nil {
+		dumpUnit.SetConcurrency(*dumpConcurrency)
+	}
+	st.applyResourceShare()
+	if dbProvider != nil {
+		for _, u := range st.units {
+			if setter, ok := u.(dbProviderSetter); ok {
+				setter.SetDBProvider(dbProvider)
+			}
+		}
+	}
+	if errClassifiers != nil {
+		for _, u := range st.units {
+			if setter, ok := u.(errorClassifiersSetter); ok {
+				setter.SetErrorClassifiers(errClassifiers)
+			}
+		}
+	}
 
 	initializeUnitSuccess := true
 	// when error occurred, initialized units should be closed
@@ -200,16 +270,18 @@ func (st *SubTask) Run(expectStage pb.Stage) {
 	if expectStage == pb.Stage_Running {
 		st.run()
 	} else {
-		// if not want to run, still need to set the stage.
+		// if not want to run, still need to set the stage. the units are initialized but not processed in Paused
+		// or Finished stage.
 		st.setStage(expectStage)
 	}
 }
 
 func (st *SubTask) run() {
 	st.setStage(pb.Stage_Running)
+	st.runningSince.Set(time.Now().UnixNano())
 	ctx, cancel := context.WithCancel(st.ctx)
 	st.setCurrCtx(ctx, cancel)
-	err := st.unitTransWaitCondition(ctx)
+	err := st.unitTransWaitCondition(ctx, true)
 	if err != nil {
 		st.l.Error("wait condition", log.ShortError(err))
 		st.fail(err)
@@ -217,14 +289,21 @@ func (st *SubTask) run() {
 	} else if ctx.Err() != nil {
 		return
 	}
+	if err = st.consumeInjectedFailure(); err != nil {
+		st.l.Warn("run with injected fault", log.ShortError(err))
+		st.fail(err)
+		return
+	}
 
 	st.setResult(nil) // clear previous result
 	cu := st.CurrUnit()
 	st.l.Info("start to run", zap.Stringer("unit", cu.Type()))
 	pr := make(chan pb.ProcessResult, 1)
 	st.wg.Add(1)
-	go st.fetchResult(pr)
-	go cu.Process(ctx, pr)
+	st.goroutines.run(goroutineUnit, func() { st.fetchResult(pr) })
+	st.goroutines.run(goroutineUnit, func() {
+		st.withLabel(ctx, func(ctx context.Context) { cu.Process(ctx, pr) })
+	})
 }
 
 func (st *SubTask) setCurrCtx(ctx context.Context, cancel context.CancelFunc) {
@@ -481,7 +560,8 @@ func (st *SubTask) Resume() error {
 	ctx, cancel := context.WithCancel(st.ctx)
 	st.setCurrCtx(ctx, cancel)
 	// NOTE: this may block if user resume a task
-	err := st.unitTransWaitCondition(ctx)
+	// the lock of the worker is held when resuming, so don't wait for dependencies which may take a long time.
+	err := st.unitTransWaitCondition(ctx, false)
 	if err != nil {
 		st.l.Error("wait condition", log.ShortError(err))
 		st.setStage(pb.Stage_Paused)
@@ -491,17 +571,30 @@ func (st *SubTask) Resume() error {
 		// that go routine will change the stage, so don't need to set stage to paused here.
 		return nil
 	}
+	if err = st.consumeInjectedFailure(); err != nil {
+		st.l.Warn("resume with injected fault", log.ShortError(err))
+		st.fail(err)
+		return nil
+	}
 
 	st.setResult(nil) // clear previous result
+	// a full resume also resumes the sync paused before.
+	if st.syncPaused.Get() {
+		st.syncPaused.Set(false)
+		st.SetReadOnly(st.readOnly.Get())
+	}
 	cu := st.CurrUnit()
 	st.l.Info("resume with unit", zap.Stringer("unit", cu.Type()))
 
 	pr := make(chan pb.ProcessResult, 1)
 	st.wg.Add(1)
-	go st.fetchResult(pr)
-	go cu.Resume(ctx, pr)
+	st.goroutines.run(goroutineUnit, func() { st.fetchResult(pr) })
+	st.goroutines.run(goroutineUnit, func() {
+		st.withLabel(ctx, func(ctx context.Context) { cu.Resume(ctx, pr) })
+	})
 
 	st.setStage(pb.Stage_Running)
+	st.runningSince.Set(time.Now().UnixNano())
 	return nil
 }
 
@@ -519,6 +612,10 @@ func (st *SubTask) Update(cfg *config.SubTaskConfig) error {
 		}
 	}
 
+	// the block-allow list is also used by partial relay.
+	st.Lock()
+	st.cfg.BAList = cfg.BAList
+	st.Unlock()
 	return nil
 }
 
@@ -536,6 +633,48 @@ func (st *SubTask) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchem
 	return syncUnit.OperateSchema(ctx, req)
 }
 
+// TrackedSchema returns the `CREATE TABLE` statement of the table in the schema tracker of the sync unit.
+func (st *SubTask) TrackedSchema(ctx context.Context, schema, table string) (string, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return "", terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	return syncUnit.TrackedCreateTable(ctx, schema, table)
+}
+
+// BinlogGap returns whether the binlog needed to resume the sync unit is still available in upstream.
+func (st *SubTask) BinlogGap(ctx context.Context) (*syncer.BinlogGapReport, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	return syncUnit.BinlogGap(ctx)
+}
+
+// TimeSkew returns the clock skews of upstream and downstream related to the worker, measured by the sync unit.
+func (st *SubTask) TimeSkew(ctx context.Context) (*syncer.TimeSkew, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	return syncUnit.TimeSkew(ctx)
+}
+
 // UpdateFromConfig updates config for `From`
 func (st *SubTask) UpdateFromConfig(cfg *config.SubTaskConfig) error {
 	st.Lock()
@@ -581,10 +720,710 @@ func (st *SubTask) ShardDDLOperation() *pessimism.Operation {
 	return syncer2.ShardDDLOperation()
 }
 
+// TableRestoreStats returns the statistics of tables restored by the load unit.
+func (st *SubTask) TableRestoreStats() (loader.TableRestoreStats, error) {
+	cu := st.CurrUnit()
+	loadUnit, ok := cu.(*loader.Loader)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return loader.TableRestoreStats{}, terror.ErrWorkerOperLoadUnitOnly.Generate(typ)
+	}
+	return loadUnit.TableRestoreStats(), nil
+}
+
+// LoadETA returns the estimated time of completion of the load unit.
+func (st *SubTask) LoadETA() (*loader.LoadETA, error) {
+	cu := st.CurrUnit()
+	loadUnit, ok := cu.(*loader.Loader)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperLoadUnitOnly.Generate(typ)
+	}
+	return loadUnit.ETA(), nil
+}
+
+// ConflictRetryStats returns the write conflict retries of the sync unit.
+func (st *SubTask) ConflictRetryStats() (syncer.ConflictRetryStats, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return syncer.ConflictRetryStats{}, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	return syncUnit.ConflictRetryStats(), nil
+}
+
+// TargetStats returns the results of executing statements in downstream by the sync unit.
+func (st *SubTask) TargetStats() (*syncer.TargetStats, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	stats := syncUnit.TargetStats()
+	return &stats, nil
+}
+
+// LatencyBreakdown returns the time spent in each stage of the sync pipeline, `Syncing` is false if not in the sync phase.
+func (st *SubTask) LatencyBreakdown() *syncer.LatencyBreakdown {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return &syncer.LatencyBreakdown{}
+	}
+	breakdown := syncUnit.LatencyBreakdown()
+	return &breakdown
+}
+
+// ActiveConnections returns the connections held by the current unit of the subtask, the connections opened by the
+// dump unit are managed by dumpling and not listed.
+func (st *SubTask) ActiveConnections() []conn.ConnInfo {
+	if cl, ok := st.CurrUnit().(connectionLister); ok {
+		return cl.ActiveConnections()
+	}
+	return nil
+}
+
+// GTIDMode returns how the sync unit of the subtask handles GTID, including whether switched by `switch-to-gtid`.
+func (st *SubTask) GTIDMode() syncer.GTIDMode {
+	if syncUnit, err := st.syncUnit(); err == nil {
+		return syncUnit.GTIDMode()
+	}
+	st.RLock()
+	defer st.RUnlock()
+	return syncer.GTIDModeOf(st.cfg)
+}
+
+// GTIDSwitchStatus returns the status of switching to GTID mode by `switch-to-gtid` of the sync unit.
+func (st *SubTask) GTIDSwitchStatus() (*syncer.GTIDSwitchStatus, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	status := syncUnit.GTIDSwitchStatus()
+	return &status, nil
+}
+
+// WriteConflictHotspots returns at most top tables or keys with the most write conflicts in downstream recently,
+// it's empty if the subtask is not in the sync phase.
+func (st *SubTask) WriteConflictHotspots(top int) []syncer.ConflictHotspot {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return []syncer.ConflictHotspot{}
+	}
+	return syncUnit.WriteConflictHotspots(top)
+}
+
+// LagByTable returns at most top target tables with the most lag and DML events in the sync unit, it's empty if not
+// in the sync phase.
+func (st *SubTask) LagByTable(top int) []syncer.TableLag {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return []syncer.TableLag{}
+	}
+	return syncUnit.LagByTable(top)
+}
+
+// FrozenDDL returns the DDL paused by freeze-on-ddl in the sync unit and not reviewed yet, or nil.
+func (st *SubTask) FrozenDDL() *syncer.FrozenDDL {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return nil
+	}
+	return syncUnit.FrozenDDL()
+}
+
+// MemoryBreakdown returns the approximate memory used by components of the sync unit.
+func (st *SubTask) MemoryBreakdown() (*syncer.MemoryBreakdown, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	mb := syncUnit.MemoryBreakdown()
+	return &mb, nil
+}
+
+// QueueDepths returns the fill levels of the queues in the pipeline of the sync unit.
+func (st *SubTask) QueueDepths() (*syncer.QueueDepths, error) {
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return nil, err
+	}
+	qd := syncUnit.QueueDepths()
+	return &qd, nil
+}
+
+// SafeModeRemaining returns whether safe mode of the sync unit is active and when it ends.
+func (st *SubTask) SafeModeRemaining() (*syncer.SafeModeRemaining, error) {
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return nil, err
+	}
+	r := syncUnit.SafeModeRemaining()
+	return &r, nil
+}
+
+// FilterRules returns the binlog event filter rules of the subtask.
+func (st *SubTask) FilterRules() []*bf.BinlogEventRule {
+	st.RLock()
+	defer st.RUnlock()
+	return append([]*bf.BinlogEventRule{}, st.cfg.FilterRules...)
+}
+
+// FilteredEventStats returns the number of events filtered by each binlog event filter rule of the sync unit.
+func (st *SubTask) FilteredEventStats() (map[string]uint64, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.FilteredEventStats(), nil
+}
+
+// ColumnTransforms returns the column transforms of the subtask.
+func (st *SubTask) ColumnTransforms() []*config.ColumnTransform {
+	st.RLock()
+	defer st.RUnlock()
+	return append([]*config.ColumnTransform{}, st.cfg.ColumnTransforms...)
+}
+
+// UpdateRelayDir updates the relay directory of the subtask after the relay log is moved,
+// the subtask should be paused before calling it.
+func (st *SubTask) UpdateRelayDir(relayDir string) {
+	st.Lock()
+	st.cfg.RelayDir = relayDir
+	st.Unlock()
+
+	if syncUnit, err := st.syncUnit(); err == nil {
+		syncUnit.UpdateRelayDir(relayDir)
+	}
+}
+
+// SkippedUnsupportedDDLs returns the recent unsupported DDLs skipped by the sync unit.
+func (st *SubTask) SkippedUnsupportedDDLs() ([]syncer.SkippedDDL, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.SkippedUnsupportedDDLs(), nil
+}
+
+// DuplicateKeyStatus returns the duplicate keys handled by `duplicate-key-policy` of the sync unit.
+func (st *SubTask) DuplicateKeyStatus() (*syncer.DuplicateKeyStatus, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	status := syncUnit.DuplicateKeyStatus()
+	return &status, nil
+}
+
+// DDLHistory returns the recent DDLs applied to downstream by the sync unit, see Syncer.DDLHistory.
+func (st *SubTask) DDLHistory(limit int) ([]syncer.DDLRecord, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.DDLHistory(limit), nil
+}
+
+// EventRateHistory returns the rates of events processed by the sync unit sampled in the recent window.
+func (st *SubTask) EventRateHistory(window time.Duration) ([]syncer.RateSample, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.EventRateHistory(window), nil
+}
+
+// ActiveTransactionCount returns the number of downstream transactions being executed by the sync unit,
+// it's 0 if the subtask is not in the sync phase.
+func (st *SubTask) ActiveTransactionCount() int {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return 0
+	}
+	return syncUnit.ActiveTransactionCount()
+}
+
+// RelayReadPos returns the relay sub directory and the position in it the sync unit has read to,
+// the sub directory is empty if the subtask is not in the sync phase or doesn't read from relay log.
+func (st *SubTask) RelayReadPos() (string, mysql.Position, error) {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return "", mysql.Position{}, nil
+	}
+	return syncUnit.RelayReadPos()
+}
+
+// SetReadOnly sets whether the sync unit holds writes to downstream,
+// it's also applied to the sync unit created later, so it's not an error if the subtask has no sync unit now.
+func (st *SubTask) SetReadOnly(readOnly bool) {
+	st.readOnly.Set(readOnly)
+	if syncUnit, err := st.syncUnit(); err == nil {
+		syncUnit.SetReadOnly(readOnly || st.syncPaused.Get())
+	}
+}
+
+// syncPausedDetail is the detail of the result of the subtask whose sync is paused.
+const syncPausedDetail = "sync paused, writes to downstream are held while relay keeps reading"
+
+// PauseSync holds writes of the sync unit to downstream, while the subtask keeps running and relay keeps reading
+// binlog events from upstream, so the buffered events are applied immediately after the sync resumed.
+func (st *SubTask) PauseSync() error {
+	if stage := st.Stage(); stage != pb.Stage_Running {
+		return terror.ErrWorkerNotRunningStage.Generate(stage.String())
+	}
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	st.syncPaused.Set(true)
+	syncUnit.SetReadOnly(true)
+	st.setResult(&pb.ProcessResult{Detail: []byte(syncPausedDetail)})
+	st.l.Info("pause sync, relay keeps reading")
+	return nil
+}
+
+// ResumeSync releases the writes held by PauseSync.
+func (st *SubTask) ResumeSync() error {
+	if !st.syncPaused.Get() {
+		return terror.ErrWorkerSyncNotPaused.Generate(st.cfg.Name)
+	}
+	st.syncPaused.Set(false)
+	if syncUnit, err := st.syncUnit(); err == nil {
+		syncUnit.SetReadOnly(st.readOnly.Get())
+	}
+	st.clearSyncPausedResult()
+	st.l.Info("resume sync")
+	return nil
+}
+
+// clearSyncPausedResult clears the result set by PauseSync, the result is kept if it's replaced, e.g. by an error.
+func (st *SubTask) clearSyncPausedResult() {
+	st.Lock()
+	defer st.Unlock()
+	if st.result != nil && len(st.result.Errors) == 0 && string(st.result.Detail) == syncPausedDetail {
+		st.result = nil
+	}
+}
+
+// SyncPaused returns whether the sync of the subtask is paused by PauseSync.
+func (st *SubTask) SyncPaused() bool {
+	return st.syncPaused.Get()
+}
+
+// unitGoroutines returns the number of goroutines processing units and fetching their results running and expected,
+// two goroutines are expected when the subtask is running.
+func (st *SubTask) unitGoroutines() (running, expected int) {
+	running = st.goroutines.status().Running[goroutineUnit]
+	if st.Stage() == pb.Stage_Running {
+		expected = 2
+	}
+	return running, expected
+}
+
+// dumpUnit returns the dump unit of the subtask, or nil if there is no dump unit, e.g. the dump phase finished.
+func (st *SubTask) dumpUnit() *dumpling.Dumpling {
+	for _, u := range st.units {
+		if dumpUnit, ok := u.(*dumpling.Dumpling); ok {
+			return dumpUnit
+		}
+	}
+	return nil
+}
+
+// SetDumpConcurrency sets the concurrency of the dump unit, it takes effect when dumping starts next time, so it should
+// be set before dumping, or the subtask should be paused and resumed to dump again with it.
+// it's also applied to the dump unit created later, but it's an error if the dump phase of the subtask has finished.
+func (st *SubTask) SetDumpConcurrency(concurrency dumpling.Concurrency) error {
+	if st.cfg.Mode == config.ModeIncrement {
+		return terror.ErrWorkerDumpConcurrencyNotApplicable.Generate(st.cfg.Name, "there is no dump phase in incremental mode")
+	}
+	dumpUnit := st.dumpUnit()
+	if st.initialized.Get() && (dumpUnit == nil || st.CurrUnit() != unit.Unit(dumpUnit)) {
+		return terror.ErrWorkerDumpConcurrencyNotApplicable.Generate(st.cfg.Name, "the dump phase has finished")
+	}
+
+	st.Lock()
+	st.dumpConcurrency = &concurrency
+	st.Unlock()
+	if dumpUnit != nil {
+		dumpUnit.SetConcurrency(concurrency)
+	}
+	return nil
+}
+
+// DumpConcurrency returns the concurrency of the dump unit, it's the one set at runtime or the one in config.
+func (st *SubTask) DumpConcurrency() (dumpling.Concurrency, error) {
+	if st.cfg.Mode == config.ModeIncrement {
+		return dumpling.Concurrency{}, terror.ErrWorkerDumpConcurrencyNotApplicable.Generate(st.cfg.Name, "there is no dump phase in incremental mode")
+	}
+	if dumpUnit := st.dumpUnit(); dumpUnit != nil {
+		return dumpUnit.Concurrency(), nil
+	}
+
+	st.RLock()
+	defer st.RUnlock()
+	if st.dumpConcurrency != nil {
+		return *st.dumpConcurrency, nil
+	}
+	return dumpling.ConcurrencyFromConfig(st.cfg), nil
+}
+
+// HeartbeatLag returns the replication lag of the sync unit calculated from heartbeat,
+// it's syncer.HeartbeatLagDisabled if heartbeat is not enabled, or syncer.HeartbeatLagUnknown if not in the sync phase.
+func (st *SubTask) HeartbeatLag() time.Duration {
+	if !st.cfg.EnableHeartbeat {
+		return syncer.HeartbeatLagDisabled
+	}
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return syncer.HeartbeatLagUnknown
+	}
+	return syncUnit.HeartbeatLag()
+}
+
+// ReplicationLag returns the replication lag of the sync unit calculated from the timestamp of binlog events,
+// it's 0 if the subtask is not in the sync phase.
+func (st *SubTask) ReplicationLag() time.Duration {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return 0
+	}
+	return syncUnit.ReplicationLag()
+}
+
+// MaxAllowedLag returns the max allowed replication lag of the subtask, 0 means no limit.
+func (st *SubTask) MaxAllowedLag() time.Duration {
+	return st.maxAllowedLag.Get()
+}
+
+// SetMaxAllowedLag sets the max allowed replication lag of the subtask, 0 means no limit.
+func (st *SubTask) SetMaxAllowedLag(lag time.Duration) {
+	st.maxAllowedLag.Set(lag)
+}
+
+// inLagCheckGracePeriod returns whether the subtask runs or resumes within lagCheckGracePeriod.
+func (st *SubTask) inLagCheckGracePeriod() bool {
+	return time.Since(time.Unix(0, st.runningSince.Get())) < lagCheckGracePeriod
+}
+
+// BufferedJobCount returns the number of jobs buffered by the sync unit but not executed in downstream,
+// it's 0 if the subtask is not in the sync phase.
+func (st *SubTask) BufferedJobCount() int {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return 0
+	}
+	return syncUnit.BufferedJobCount()
+}
+
+// PendingShardDDLs returns the sharding DDLs pending to be resolved by the sync unit,
+// it's empty if the subtask is not in the sync phase.
+func (st *SubTask) PendingShardDDLs() []syncer.PendingShardDDL {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return nil
+	}
+	return syncUnit.PendingShardDDLs()
+}
+
+// StartEventSampling starts sampling binlog events processed by the sync unit.
+func (st *SubTask) StartEventSampling(opts syncer.SamplingOpts) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	return syncUnit.StartEventSampling(opts)
+}
+
+// StopEventSampling stops sampling binlog events processed by the sync unit.
+func (st *SubTask) StopEventSampling() error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.StopEventSampling()
+	return nil
+}
+
+// SampledEvents returns the binlog events sampled by the sync unit.
+func (st *SubTask) SampledEvents() ([]syncer.SampledEvent, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return nil, err
+	}
+	return syncUnit.SampledEvents(), nil
+}
+
+// PausedByDownstreamReadOnly returns whether the subtask is paused because the downstream is read-only.
+// the task checker will resume it after the downstream becomes writable.
+func (st *SubTask) PausedByDownstreamReadOnly() bool {
+	if st.Stage() != pb.Stage_Paused {
+		return false
+	}
+	result := st.Result()
+	if result == nil {
+		return false
+	}
+	for _, err := range result.Errors {
+		if isDownstreamReadOnlyError(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncUnit returns the sync unit of the subtask, no matter whether it's the current unit.
+func (st *SubTask) syncUnit() (*syncer.Syncer, error) {
+	for _, u := range st.units {
+		if syncUnit, ok := u.(*syncer.Syncer); ok {
+			return syncUnit, nil
+		}
+	}
+	var typ pb.UnitType
+	if cu := st.CurrUnit(); cu != nil {
+		typ = cu.Type()
+	}
+	return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+}
+
+// currSyncUnit returns the sync unit if it's the current unit of the subtask.
+func (st *SubTask) currSyncUnit() (*syncer.Syncer, error) {
+	cu := st.CurrUnit()
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		var typ pb.UnitType
+		if cu != nil {
+			typ = cu.Type()
+		}
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(typ)
+	}
+	return syncUnit, nil
+}
+
+// syncCheckpoint returns the flushed global checkpoint of the sync unit, it returns false if the subtask is not in the
+// sync phase.
+func (st *SubTask) syncCheckpoint() (string, bool) {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return "", false
+	}
+	return syncUnit.FlushedGlobalPoint().String(), true
+}
+
+// syncFlushedLocation returns the flushed global checkpoint location of the sync unit, it returns false if the subtask
+// is not in the sync phase.
+func (st *SubTask) syncFlushedLocation() (binlog.Location, bool) {
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return binlog.Location{}, false
+	}
+	return syncUnit.FlushedGlobalPoint(), true
+}
+
+// stuckCheckpoint returns the checkpoint the sync unit was auto resumed from too many times without forward progress,
+// it's empty if the checkpoint has moved forward since then, or the subtask is not in the sync phase.
+func (st *SubTask) stuckCheckpoint() string {
+	stuck := st.noProgressCheckpoint.Get()
+	if len(stuck) == 0 {
+		return ""
+	}
+	if checkpoint, ok := st.syncCheckpoint(); !ok || checkpoint != stuck {
+		return ""
+	}
+	return stuck
+}
+
+// CheckpointFlushInterval returns the current checkpoint flush interval of the sync unit.
+func (st *SubTask) CheckpointFlushInterval() (time.Duration, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return 0, err
+	}
+	return syncUnit.CheckpointFlushInterval(), nil
+}
+
+// CheckpointAge returns how long since the sync unit flushed the checkpoint last time, see Syncer.CheckpointAge.
+func (st *SubTask) CheckpointAge(ctx context.Context) (time.Duration, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return 0, err
+	}
+	return syncUnit.CheckpointAge(ctx)
+}
+
+// SetCheckpointFlushInterval changes the checkpoint flush interval of the sync unit.
+func (st *SubTask) SetCheckpointFlushInterval(interval time.Duration) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.SetCheckpointFlushInterval(interval)
+	return nil
+}
+
+// BatchSize returns the batch size of downstream transactions of the sync unit.
+func (st *SubTask) BatchSize() (int, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return 0, err
+	}
+	return syncUnit.BatchSize(), nil
+}
+
+// SetBatchSize changes the batch size of downstream transactions of the sync unit.
+func (st *SubTask) SetBatchSize(n int) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.SetBatchSize(n)
+	return nil
+}
+
+// SetDDLBatchSize changes the max number of DDL events applied to downstream as one batch by the sync unit.
+func (st *SubTask) SetDDLBatchSize(n int) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.SetDDLBatchSize(n)
+	return nil
+}
+
+// WriteMode returns the write mode of downstream of the sync unit.
+func (st *SubTask) WriteMode() (WriteMode, error) {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return "", err
+	}
+	return WriteMode(syncUnit.WriteMode()), nil
+}
+
+// SetWriteMode changes the write mode of downstream of the sync unit.
+func (st *SubTask) SetWriteMode(mode WriteMode) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	syncUnit.SetWriteMode(string(mode))
+	return nil
+}
+
+// FastForward advances the checkpoint of the paused sync unit to the current location of upstream master,
+// events between them are skipped after resumed.
+func (st *SubTask) FastForward(ctx context.Context) (from, to binlog.Location, err error) {
+	if stage := st.Stage(); stage != pb.Stage_Paused {
+		return from, to, terror.ErrWorkerNotPausedStage.Generate(stage.String())
+	}
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return from, to, err
+	}
+	return syncUnit.FastForward(ctx)
+}
+
+// SetRelayArchive makes the paused sync unit read binlog from the archived relay log in dir after resumed, the archive
+// should contain the relay log from the flushed checkpoint to stopAt.
+func (st *SubTask) SetRelayArchive(dir string, stopAt binlog.Location) error {
+	if stage := st.Stage(); stage != pb.Stage_Paused {
+		return terror.ErrWorkerNotPausedStage.Generate(stage.String())
+	}
+	syncUnit, err := st.currSyncUnit()
+	if err != nil {
+		return err
+	}
+	if err := checkRelayArchive(dir, syncUnit.FlushedGlobalPoint().Position, stopAt.Position); err != nil {
+		return err
+	}
+	syncUnit.SetRelayArchive(dir)
+	return nil
+}
+
+// ClearRelayArchive makes the sync unit read binlog as before after resumed.
+func (st *SubTask) ClearRelayArchive() {
+	if syncUnit, err := st.syncUnit(); err == nil {
+		syncUnit.SetRelayArchive("")
+	}
+}
+
+// SetPauseBarrier sets a location for the sync unit to stop at, the subtask is paused after reaching the location,
+// and onPaused is called with the result of pausing. the barrier is cleared if location is nil.
+func (st *SubTask) SetPauseBarrier(location *binlog.Location, onPaused func(err error)) error {
+	syncUnit, err := st.syncUnit()
+	if err != nil {
+		return err
+	}
+	if location == nil {
+		syncUnit.SetPauseBarrier(nil, nil)
+		return nil
+	}
+
+	syncUnit.SetPauseBarrier(location, func(loc binlog.Location) {
+		st.l.Info("reach pause barrier, pausing", zap.Stringer("location", loc))
+		// can't pause in the sync unit's goroutine, because pausing waits for it to return
+		go func() {
+			err2 := st.Pause()
+			if onPaused != nil {
+				onPaused(err2)
+			}
+		}()
+	})
+	return nil
+}
+
+// PauseAtTxnBoundary lets the sync unit finish the current transaction and flush jobs, then pauses the subtask at the
+// transaction boundary, so fewer events are replayed in safe mode after resumed. it falls back to pause immediately
+// if the boundary is not reached within timeout, or the subtask is not in the sync phase.
+// it returns whether the subtask is paused at the transaction boundary.
+func (st *SubTask) PauseAtTxnBoundary(timeout time.Duration) (bool, error) {
+	if st.Stage() != pb.Stage_Running {
+		return false, terror.ErrWorkerNotRunningStage.Generate(st.Stage().String())
+	}
+	syncUnit, ok := st.CurrUnit().(*syncer.Syncer)
+	if !ok {
+		return false, st.Pause()
+	}
+
+	// any location after the current one is a boundary, because the barrier is only checked at transaction boundaries.
+	location := syncUnit.CurrentLocation()
+	reachedCh := make(chan struct{})
+	syncUnit.SetPauseBarrier(&location, func(binlog.Location) {
+		close(reachedCh)
+	})
+	select {
+	case <-reachedCh:
+		return true, st.Pause()
+	case <-time.After(timeout):
+	}
+
+	syncUnit.SetPauseBarrier(nil, nil)
+	st.l.Warn("can't reach the transaction boundary in time, pause immediately", zap.Duration("timeout", timeout))
+	return false, st.Pause()
+}
+
 // unitTransWaitCondition waits when transferring from current unit to next unit.
 // Currently there is only one wait condition
 // from Load unit to Sync unit, wait for relay-log catched up with mydumper binlog position.
-func (st *SubTask) unitTransWaitCondition(subTaskCtx context.Context) error {
+func (st *SubTask) unitTransWaitCondition(subTaskCtx context.Context, waitDeps bool) error {
 	var (
 		gset1 gtid.Set
 		gset2 gtid.Set
@@ -598,6 +1437,10 @@ func (st *SubTask) unitTransWaitCondition(subTaskCtx context.Context) error {
 		st.l.Info("wait condition between two units", zap.Stringer("previous unit", pu.Type()), zap.Stringer("unit", cu.Type()))
 		hub := GetConditionHub()
 
+		if err = st.waitDependencies(subTaskCtx, hub.w, waitDeps); err != nil || subTaskCtx.Err() != nil {
+			return err
+		}
+
 		if hub.w.relayHolder == nil {
 			return nil
 		}
@@ -666,6 +1509,84 @@ func (st *SubTask) unitTransWaitCondition(subTaskCtx context.Context) error {
 	return nil
 }
 
+// waitDependencies waits for all dependencies of the subtask finished load unit before the subtask enters sync unit
+// for the first time, and records which dependency is waiting on in the result. it fails if a dependency is not found,
+// or doesn't finish load unit in waitDependencyTimeout. if block is false, it fails at once if any dependency is not
+// ready, which is used when the lock of the worker is held. it returns nil without waiting more if ctx is done.
+func (st *SubTask) waitDependencies(ctx context.Context, w *Worker, block bool) error {
+	if st.dependenciesReady.Get() {
+		return nil
+	}
+	timeout := waitDependencyTimeout
+	if !block {
+		timeout = 0
+	}
+	ctxWait, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for _, dep := range st.cfg.Dependencies {
+		logged := false
+		for {
+			depSt := w.subTaskHolder.findSubTask(dep)
+			if depSt == nil {
+				return terror.ErrWorkerSubTaskDependencyNotFound.Generate(dep, st.cfg.Name)
+			}
+			if depSt.loadFinished() {
+				break
+			}
+			if !logged {
+				st.l.Info("wait for dependency to finish load unit", zap.String("dependency", dep))
+				st.setResult(&pb.ProcessResult{Detail: []byte(fmt.Sprintf("waiting on dependency %s", dep))})
+				logged = true
+			}
+			select {
+			case <-ctxWait.Done():
+				if ctx.Err() != nil {
+					return nil
+				}
+				return terror.ErrWorkerSubTaskDependencyNotReady.Generate(dep, st.cfg.Name, timeout)
+			case <-time.After(waitDependencyInterval):
+			}
+		}
+	}
+	st.dependenciesReady.Set(true)
+	return nil
+}
+
+// loadFinished returns whether the subtask has finished load unit, so its dependents can enter sync unit.
+func (st *SubTask) loadFinished() bool {
+	cu := st.CurrUnit()
+	if cu == nil {
+		return false
+	}
+	return cu.Type() == pb.UnitType_Sync || (cu.Type() == pb.UnitType_Load && st.Stage() == pb.Stage_Finished)
+}
+
+// pauseWithError pauses the running subtask with err as its result, so it's not treated as paused manually.
+func (st *SubTask) pauseWithError(err error) error {
+	if err2 := st.Pause(); err2 != nil {
+		return err2
+	}
+	st.setResult(&pb.ProcessResult{
+		Errors: []*pb.ProcessError{
+			unit.NewProcessError(err),
+		},
+	})
+	return nil
+}
+
+// addResultError adds the error to the result of the paused subtask, ahead of the errors which paused it.
+func (st *SubTask) addResultError(err error) {
+	st.Lock()
+	defer st.Unlock()
+	result := &pb.ProcessResult{Errors: []*pb.ProcessError{unit.NewProcessError(err)}}
+	if st.result != nil {
+		result.IsCanceled = st.result.IsCanceled
+		result.Detail = st.result.Detail
+		result.Errors = append(result.Errors, st.result.Errors...)
+	}
+	st.result = result
+}
+
 func (st *SubTask) fail(err error) {
 	st.setStage(pb.Stage_Paused)
 	st.setResult(&pb.ProcessResult{
@@ -677,18 +1598,24 @@ func (st *SubTask) fail(err error) {
 
 // HandleError handle error for syncer unit
 func (st *SubTask) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) error {
+	_, err := st.HandleErrorWithResult(ctx, req)
+	return err
+}
+
+// HandleErrorWithResult handles error for syncer unit like HandleError, and returns the details of the operation.
+func (st *SubTask) HandleErrorWithResult(ctx context.Context, req *pb.HandleWorkerErrorRequest) (*syncer.HandleErrorResult, error) {
 	syncUnit, ok := st.currUnit.(*syncer.Syncer)
 	if !ok {
-		return terror.ErrWorkerOperSyncUnitOnly.Generate(st.currUnit.Type())
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(st.currUnit.Type())
 	}
 
-	err := syncUnit.HandleError(ctx, req)
+	result, err := syncUnit.HandleErrorWithResult(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if st.Stage() == pb.Stage_Paused {
 		err = st.Resume()
 	}
-	return err
+	return result, err
 }
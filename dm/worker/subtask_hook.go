@@ -0,0 +1,108 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// subTaskOpHookTimeout is the timeout of running a pre/post-hook of operating a subtask.
+var subTaskOpHookTimeout = 10 * time.Second
+
+// SubTaskOpHook is called with the operation before or after operating a subtask.
+type SubTaskOpHook func(op pb.TaskOp) error
+
+type subTaskOpHook struct {
+	pre  SubTaskOpHook
+	post SubTaskOpHook
+}
+
+// subTaskOpHooks holds the hooks registered for subtasks by subtask name.
+type subTaskOpHooks struct {
+	mu    sync.RWMutex
+	hooks map[string]subTaskOpHook
+}
+
+// set registers the hooks for the subtask, the hooks registered before are replaced.
+// the hooks are removed if both pre and post are nil.
+func (h *subTaskOpHooks) set(name string, pre, post SubTaskOpHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if pre == nil && post == nil {
+		delete(h.hooks, name)
+		return
+	}
+	if h.hooks == nil {
+		h.hooks = make(map[string]subTaskOpHook)
+	}
+	h.hooks[name] = subTaskOpHook{pre: pre, post: post}
+}
+
+func (h *subTaskOpHooks) get(name string) subTaskOpHook {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hooks[name]
+}
+
+// runSubTaskOpHook runs the hook with a timeout, if the hook is hung it keeps running in background
+// but the caller is not blocked anymore.
+func runSubTaskOpHook(hook SubTaskOpHook, kind, name string, op pb.TaskOp) error {
+	if hook == nil {
+		return nil
+	}
+	timeout := subTaskOpHookTimeout
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- hook(op)
+	}()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return terror.ErrWorkerSubTaskOpHookFailed.Delegate(err, kind, op, name)
+		}
+		return nil
+	case <-time.After(timeout):
+		return terror.ErrWorkerSubTaskOpHookTimeout.Generate(kind, op, name, timeout)
+	}
+}
+
+// SubTaskStageObserver is called after a subtask transitions from one stage to another by the expected stage in etcd,
+// at is the time of the transition. from is `pb.Stage_InvalidStage` if the subtask is created by the transition.
+type SubTaskStageObserver func(task string, from, to pb.Stage, at time.Time)
+
+// subTaskStageObservers holds the observers of stage transitions of all subtasks.
+type subTaskStageObservers struct {
+	mu        sync.RWMutex
+	observers []SubTaskStageObserver
+}
+
+func (o *subTaskStageObservers) add(observer SubTaskStageObserver) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.observers = append(o.observers, observer)
+}
+
+// notify calls the observers in the order of registration.
+func (o *subTaskStageObservers) notify(task string, from, to pb.Stage, at time.Time) {
+	o.mu.RLock()
+	observers := o.observers
+	o.mu.RUnlock()
+	for _, observer := range observers {
+		observer(task, from, to, at)
+	}
+}
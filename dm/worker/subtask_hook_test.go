@@ -0,0 +1,175 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/ha"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+type testSubTaskOpHook struct{}
+
+var _ = Suite(&testSubTaskOpHook{})
+
+func (t *testSubTaskOpHook) TestSubTaskOpHook(c *C) {
+	originTimeout := subTaskOpHookTimeout
+	subTaskOpHookTimeout = 100 * time.Millisecond
+	defer func() {
+		subTaskOpHookTimeout = originTimeout
+	}()
+
+	taskName := "test-subtask-op-hook"
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: taskName}, pb.Stage_Running, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+	st.setCurrCtx(context.WithCancel(context.Background()))
+	st.initialized.Set(true)
+	defer st.Close()
+	w.subTaskHolder.recordSubTask(st)
+
+	var preOps, postOps []pb.TaskOp
+	pre := func(op pb.TaskOp) error {
+		preOps = append(preOps, op)
+		return nil
+	}
+	post := func(op pb.TaskOp) error {
+		postOps = append(postOps, op)
+		return nil
+	}
+
+	// failed pre-hook aborts the operation.
+	c.Assert(w.RegisterSubTaskOpHook(taskName, func(op pb.TaskOp) error {
+		return errors.New("not ready")
+	}, post), IsNil)
+	err := w.OperateSubTask(taskName, pb.TaskOp_Pause)
+	c.Assert(terror.ErrWorkerSubTaskOpHookFailed.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*pre-hook of operation Pause on subtask test-subtask-op-hook failed.*not ready.*")
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+	c.Assert(postOps, HasLen, 0)
+
+	// hung pre-hook is timed out.
+	blockCh := make(chan struct{})
+	defer close(blockCh)
+	c.Assert(w.RegisterSubTaskOpHook(taskName, func(op pb.TaskOp) error {
+		<-blockCh
+		return nil
+	}, post), IsNil)
+	start := time.Now()
+	err = w.OperateSubTask(taskName, pb.TaskOp_Pause)
+	c.Assert(terror.ErrWorkerSubTaskOpHookTimeout.Equal(err), IsTrue)
+	c.Assert(time.Since(start) < 10*subTaskOpHookTimeout, IsTrue)
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+	c.Assert(postOps, HasLen, 0)
+
+	// post-hook is called after the operation succeeded.
+	c.Assert(w.RegisterSubTaskOpHook(taskName, pre, post), IsNil)
+	c.Assert(w.OperateSubTask(taskName, pb.TaskOp_Pause), IsNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	c.Assert(preOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause})
+	c.Assert(postOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause})
+
+	// post-hook is not called if the operation failed.
+	c.Assert(w.OperateSubTask(taskName, pb.TaskOp_Pause), NotNil)
+	c.Assert(preOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause, pb.TaskOp_Pause})
+	c.Assert(postOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause})
+
+	// failed post-hook doesn't fail the operation.
+	c.Assert(w.RegisterSubTaskOpHook(taskName, pre, func(op pb.TaskOp) error {
+		return errors.New("notify failed")
+	}), IsNil)
+	c.Assert(w.OperateSubTask(taskName, pb.TaskOp_Resume), IsNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+
+	// hooks are called when pausing by PauseSubTask too.
+	c.Assert(w.RegisterSubTaskOpHook(taskName, pre, post), IsNil)
+	style, err := w.PauseSubTask(taskName, true, time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(style, Equals, PauseImmediately) // not a sync unit
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	c.Assert(preOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause, pb.TaskOp_Pause, pb.TaskOp_Resume, pb.TaskOp_Pause})
+	c.Assert(postOps, DeepEquals, []pb.TaskOp{pb.TaskOp_Pause, pb.TaskOp_Pause})
+	c.Assert(w.OperateSubTask(taskName, pb.TaskOp_Resume), IsNil)
+
+	// hooks are removed.
+	c.Assert(w.RegisterSubTaskOpHook(taskName, nil, nil), IsNil)
+	c.Assert(w.subTaskOpHooks.hooks, HasLen, 0)
+	c.Assert(w.OperateSubTask(taskName, pb.TaskOp_Pause), IsNil)
+	c.Assert(preOps, HasLen, 5)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+}
+
+func (t *testSubTaskOpHook) TestSubTaskStageObserver(c *C) {
+	taskName := "test-subtask-stage-observer"
+	w := &Worker{
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	st := NewSubTaskWithStage(&config.SubTaskConfig{Name: taskName}, pb.Stage_Running, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+	st.setCurrCtx(context.WithCancel(context.Background()))
+	st.initialized.Set(true)
+	w.subTaskHolder.recordSubTask(st)
+
+	type transition struct {
+		task     string
+		from, to pb.Stage
+	}
+	var (
+		transitions []transition
+		count       int
+		start       = time.Now()
+	)
+	w.RegisterSubTaskStageObserver(func(task string, from, to pb.Stage, at time.Time) {
+		// the lock of the worker is not held.
+		c.Assert(w.GetEffectiveServerID(task), Equals, uint32(0))
+		c.Assert(at.Before(start), IsFalse)
+		transitions = append(transitions, transition{task: task, from: from, to: to})
+	})
+	w.RegisterSubTaskStageObserver(func(string, pb.Stage, pb.Stage, time.Time) {
+		count++
+	})
+	w.RegisterSubTaskStageObserver(nil)
+
+	_, err := w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_Paused, "source", taskName), config.SubTaskConfig{})
+	c.Assert(err, IsNil)
+	_, err = w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_Running, "source", taskName), config.SubTaskConfig{})
+	c.Assert(err, IsNil)
+	// failed transitions are not observed.
+	_, err = w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_Running, "source", taskName), config.SubTaskConfig{})
+	c.Assert(err, NotNil)
+	deleted := ha.NewSubTaskStage(pb.Stage_InvalidStage, "source", taskName)
+	deleted.IsDeleted = true
+	_, err = w.operateSubTaskStage(deleted, config.SubTaskConfig{})
+	c.Assert(err, IsNil)
+
+	c.Assert(transitions, DeepEquals, []transition{
+		{task: taskName, from: pb.Stage_Running, to: pb.Stage_Paused},
+		{task: taskName, from: pb.Stage_Paused, to: pb.Stage_Running},
+		{task: taskName, from: pb.Stage_Running, to: pb.Stage_Stopped},
+	})
+	c.Assert(count, Equals, 3)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/dm/dm/unit"
 	"github.com/pingcap/dm/dumpling"
 	"github.com/pingcap/dm/loader"
+	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/syncer"
 
 	. "github.com/pingcap/check"
@@ -540,3 +541,360 @@ func (t *testSubTask) TestSubtaskFastQuit(c *C) {
 	}
 	c.Assert(st.Stage(), Equals, pb.Stage_Stopped)
 }
+
+func (t *testSubTask) TestSubTaskTableRestoreStats(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskTableRestoreStats",
+		Mode: config.ModeFull,
+	}
+	st := NewSubTask(cfg, nil)
+
+	// no unit yet
+	_, err := st.TableRestoreStats()
+	c.Assert(terror.ErrWorkerOperLoadUnitOnly.Equal(err), IsTrue)
+
+	// not load unit
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Dump))
+	_, err = st.TableRestoreStats()
+	c.Assert(terror.ErrWorkerOperLoadUnitOnly.Equal(err), IsTrue)
+
+	st.setCurrUnit(loader.NewLoader(cfg))
+	stats, err := st.TableRestoreStats()
+	c.Assert(err, IsNil)
+	c.Assert(stats, DeepEquals, loader.TableRestoreStats{})
+
+	// the counts are also reported in status
+	status, ok := st.CurrUnit().Status(context.Background()).(*pb.LoadStatus)
+	c.Assert(ok, IsTrue)
+	c.Assert(status.FinishedTables, Equals, stats.Finished)
+	c.Assert(status.ResumedTables, Equals, stats.Resumed)
+	c.Assert(status.FreshTables, Equals, stats.Fresh)
+}
+
+func (t *testSubTask) TestSubTaskLoadETA(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskLoadETA",
+		Mode: config.ModeFull,
+	}
+	st := NewSubTask(cfg, nil)
+
+	// not in load phase
+	_, err := st.LoadETA()
+	c.Assert(terror.ErrWorkerOperLoadUnitOnly.Equal(err), IsTrue)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+	_, err = st.LoadETA()
+	c.Assert(terror.ErrWorkerOperLoadUnitOnly.Equal(err), IsTrue)
+
+	// data files are not scanned yet
+	st.setCurrUnit(loader.NewLoader(cfg))
+	eta, err := st.LoadETA()
+	c.Assert(err, IsNil)
+	c.Assert(eta.Confidence, Equals, loader.ETAConfidenceNone)
+}
+
+func (t *testSubTask) TestSubTaskLatencyBreakdown(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskLatencyBreakdown",
+		Mode: config.ModeAll,
+	}
+	st := NewSubTask(cfg, nil)
+
+	// not in sync phase
+	c.Assert(st.LatencyBreakdown().Syncing, IsFalse)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	c.Assert(*st.LatencyBreakdown(), Equals, syncer.LatencyBreakdown{})
+
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	breakdown := st.LatencyBreakdown()
+	c.Assert(breakdown.Syncing, IsTrue)
+	c.Assert(breakdown.Write, Equals, time.Duration(0))
+}
+
+func (t *testSubTask) TestSubTaskStuckCheckpoint(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name:   "testSubTaskStuckCheckpoint",
+		Mode:   config.ModeAll,
+		Flavor: "mysql",
+	}
+	st := NewSubTask(cfg, nil)
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	checkpoint, ok := st.syncCheckpoint()
+	c.Assert(ok, IsTrue)
+	c.Assert(st.stuckCheckpoint(), Equals, "")
+
+	st.noProgressCheckpoint.Set(checkpoint)
+	c.Assert(st.stuckCheckpoint(), Equals, checkpoint)
+
+	// the checkpoint has moved forward.
+	st.noProgressCheckpoint.Set("(mysql-bin.000001, 4)")
+	c.Assert(st.stuckCheckpoint(), Equals, "")
+}
+
+func (t *testSubTask) TestSubTaskLagThreshold(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskLagThreshold",
+		Mode: config.ModeFull,
+	}
+	st := NewSubTask(cfg, nil)
+	c.Assert(st.MaxAllowedLag(), Equals, time.Duration(0))
+	st.SetMaxAllowedLag(time.Minute)
+	c.Assert(st.MaxAllowedLag(), Equals, time.Minute)
+	c.Assert(st.ReplicationLag(), Equals, time.Duration(0)) // not in sync phase
+
+	mockDumper := NewMockUnit(pb.UnitType_Dump)
+	defer func() {
+		createUnits = createRealUnits
+	}()
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{mockDumper, NewMockUnit(pb.UnitType_Load)}
+	}
+	c.Assert(st.inLagCheckGracePeriod(), IsFalse)
+	st.Run(pb.Stage_Running)
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+	// the lag is not checked just after running.
+	c.Assert(st.inLagCheckGracePeriod(), IsTrue)
+	defer func(period time.Duration) {
+		lagCheckGracePeriod = period
+	}(lagCheckGracePeriod)
+	lagCheckGracePeriod = 0
+	c.Assert(st.inLagCheckGracePeriod(), IsFalse)
+
+	// paused with an unresumable error.
+	c.Assert(st.pauseWithError(terror.ErrWorkerLagThresholdExceeded.Generate(2*time.Minute, cfg.Name, time.Minute)), IsNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	c.Assert(st.Result().IsCanceled, IsFalse)
+	c.Assert(st.Result().Errors, HasLen, 1)
+	c.Assert(st.Result().Errors[0].ErrCode, Equals, int32(terror.ErrWorkerLagThresholdExceeded.Code()))
+	c.Assert(isResumableError(st.Result().Errors[0]), IsFalse)
+
+	// the error of no forward progress is put ahead of the errors which paused the subtask.
+	st.addResultError(terror.ErrWorkerNoForwardProgress.Generate(cfg.Name, "(mysql-bin.000001, 4)", 10))
+	c.Assert(st.Result().Errors, HasLen, 2)
+	c.Assert(st.Result().Errors[0].ErrCode, Equals, int32(terror.ErrWorkerNoForwardProgress.Code()))
+	c.Assert(st.Result().Errors[1].ErrCode, Equals, int32(terror.ErrWorkerLagThresholdExceeded.Code()))
+	_, ok := st.syncCheckpoint()
+	c.Assert(ok, IsFalse) // not in sync phase
+	st.noProgressCheckpoint.Set("(mysql-bin.000001, 4)")
+	c.Assert(st.stuckCheckpoint(), Equals, "")
+
+	// not running.
+	c.Assert(terror.ErrWorkerNotRunningStage.Equal(st.pauseWithError(errors.New("error"))), IsTrue)
+	st.Close()
+}
+
+func (t *testSubTask) TestSubTaskPauseAtTxnBoundary(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskPauseAtTxnBoundary",
+		Mode: config.ModeFull,
+	}
+	st := NewSubTask(cfg, nil)
+	_, err := st.PauseAtTxnBoundary(time.Second)
+	c.Assert(terror.ErrWorkerNotRunningStage.Equal(err), IsTrue)
+
+	defer func() {
+		createUnits = createRealUnits
+	}()
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{NewMockUnit(pb.UnitType_Dump), NewMockUnit(pb.UnitType_Load)}
+	}
+	st.Run(pb.Stage_Running)
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+
+	// paused immediately if not in the sync phase.
+	atBoundary, err := st.PauseAtTxnBoundary(time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(atBoundary, IsFalse)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	st.Close()
+}
+
+func (t *testSubTask) TestSubTaskDumpConcurrency(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name:           "testSubTaskDumpConcurrency",
+		Mode:           config.ModeAll,
+		MydumperConfig: config.MydumperConfig{Threads: 4},
+	}
+	st := NewSubTask(cfg, nil)
+	concurrency, err := st.DumpConcurrency()
+	c.Assert(err, IsNil)
+	c.Assert(concurrency, Equals, dumpling.Concurrency{Threads: 4})
+
+	// set before the dump unit created.
+	c.Assert(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 8, ChunkRows: 100}), IsNil)
+	concurrency, err = st.DumpConcurrency()
+	c.Assert(err, IsNil)
+	c.Assert(concurrency, Equals, dumpling.Concurrency{Threads: 8, ChunkRows: 100})
+
+	// applied to the dump unit.
+	dumpUnit := dumpling.NewDumpling(cfg)
+	st.units = []unit.Unit{dumpUnit, NewMockUnit(pb.UnitType_Load)}
+	st.setCurrUnit(dumpUnit)
+	st.initialized.Set(true)
+	c.Assert(dumpUnit.Concurrency(), Equals, dumpling.Concurrency{Threads: 4})
+	c.Assert(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 16}), IsNil)
+	c.Assert(dumpUnit.Concurrency(), Equals, dumpling.Concurrency{Threads: 16})
+
+	// the dump phase finished.
+	st.units = st.units[1:]
+	st.setCurrUnit(st.units[0])
+	c.Assert(terror.ErrWorkerDumpConcurrencyNotApplicable.Equal(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 8})), IsTrue)
+
+	// no dump phase.
+	st = NewSubTask(&config.SubTaskConfig{Name: "testSubTaskDumpConcurrency", Mode: config.ModeIncrement}, nil)
+	c.Assert(terror.ErrWorkerDumpConcurrencyNotApplicable.Equal(st.SetDumpConcurrency(dumpling.Concurrency{Threads: 8})), IsTrue)
+	_, err = st.DumpConcurrency()
+	c.Assert(terror.ErrWorkerDumpConcurrencyNotApplicable.Equal(err), IsTrue)
+}
+
+func (t *testSubTask) TestSubTaskFastForward(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name: "testSubTaskFastForward",
+		Mode: config.ModeAll,
+	}
+	st := NewSubTask(cfg, nil)
+	_, _, err := st.FastForward(context.Background())
+	c.Assert(terror.ErrWorkerNotPausedStage.Equal(err), IsTrue)
+
+	// only sync unit can be fast-forwarded.
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	st.setStage(pb.Stage_Paused)
+	_, _, err = st.FastForward(context.Background())
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(err), IsTrue)
+}
+
+func (t *testSubTask) TestSubTaskDependencies(c *C) {
+	// no cycle
+	deps := map[string][]string{
+		"task-1": {"task-2", "task-3"},
+		"task-2": {"task-3"},
+	}
+	c.Assert(findDependencyCycle("task-1", deps), IsNil)
+	c.Assert(findDependencyCycle("task-3", deps), IsNil)
+
+	// depend on itself
+	deps["task-3"] = []string{"task-3"}
+	c.Assert(findDependencyCycle("task-3", deps), DeepEquals, []string{"task-3", "task-3"})
+
+	// indirect cycle
+	deps["task-3"] = []string{"task-1"}
+	c.Assert(findDependencyCycle("task-1", deps), DeepEquals, []string{"task-1", "task-2", "task-3", "task-1"})
+
+	// wait for dependency
+	defer func(timeout time.Duration) {
+		waitDependencyTimeout = timeout
+	}(waitDependencyTimeout)
+	w := &Worker{subTaskHolder: newSubTaskHolder()}
+	depSt := NewSubTask(&config.SubTaskConfig{Name: "task-dep", Mode: config.ModeAll}, nil)
+	depSt.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	depSt.setStage(pb.Stage_Running)
+	w.subTaskHolder.recordSubTask(depSt)
+	st := NewSubTask(&config.SubTaskConfig{Name: "task", Mode: config.ModeAll, Dependencies: []string{"task-dep"}}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	c.Assert(st.waitDependencies(ctx, w, true), IsNil)
+	cancel()
+	c.Assert(string(st.Result().Detail), Equals, "waiting on dependency task-dep")
+	c.Assert(st.dependenciesReady.Get(), IsFalse)
+
+	// fail after the timeout, or at once if not blocking.
+	waitDependencyTimeout = 100 * time.Millisecond
+	err := st.waitDependencies(context.Background(), w, true)
+	c.Assert(terror.ErrWorkerSubTaskDependencyNotReady.Equal(err), IsTrue)
+	start := time.Now()
+	err = st.waitDependencies(context.Background(), w, false)
+	c.Assert(terror.ErrWorkerSubTaskDependencyNotReady.Equal(err), IsTrue)
+	c.Assert(time.Since(start), Less, waitDependencyTimeout)
+
+	// dependency not found
+	st2 := NewSubTask(&config.SubTaskConfig{Name: "task-2", Mode: config.ModeAll, Dependencies: []string{"task-not-exist"}}, nil)
+	err = st2.waitDependencies(context.Background(), w, true)
+	c.Assert(terror.ErrWorkerSubTaskDependencyNotFound.Equal(err), IsTrue)
+
+	// dependency switched to sync unit
+	depSt.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+	c.Assert(st.waitDependencies(context.Background(), w, false), IsNil)
+	c.Assert(st.dependenciesReady.Get(), IsTrue)
+
+	// not waited again after the dependencies are ready, even if the dependency is stopped later.
+	w.subTaskHolder.removeSubTask("task-dep")
+	c.Assert(st.waitDependencies(context.Background(), w, false), IsNil)
+
+	// dependency finished load unit in full mode
+	depSt.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	depSt.setStage(pb.Stage_Finished)
+	w.subTaskHolder.recordSubTask(depSt)
+	st3 := NewSubTask(&config.SubTaskConfig{Name: "task-3", Mode: config.ModeAll, Dependencies: []string{"task-dep"}}, nil)
+	c.Assert(st3.waitDependencies(context.Background(), w, false), IsNil)
+}
+
+func (t *testSubTask) TestSubTaskLagByTable(c *C) {
+	cfg := &config.SubTaskConfig{Name: "testSubTaskLagByTable", Mode: config.ModeIncrement}
+	st := NewSubTask(cfg, nil)
+	c.Assert(st.LagByTable(10), HasLen, 0)
+
+	// not in the sync phase.
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	c.Assert(st.LagByTable(10), HasLen, 0)
+
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	c.Assert(st.LagByTable(10), HasLen, 0)
+}
+
+func (t *testSubTask) TestSubTaskPauseSync(c *C) {
+	cfg := &config.SubTaskConfig{Name: "testSubTaskPauseSync", Mode: config.ModeIncrement, UseRelay: true}
+	st := NewSubTaskWithStage(cfg, pb.Stage_Paused, nil)
+	c.Assert(terror.ErrWorkerNotRunningStage.Equal(st.PauseSync()), IsTrue)
+	c.Assert(terror.ErrWorkerSyncNotPaused.Equal(st.ResumeSync()), IsTrue)
+
+	syncUnit := syncer.NewSyncer(cfg, nil)
+	st.units = []unit.Unit{syncUnit}
+	st.setCurrUnit(syncUnit)
+	st.setStage(pb.Stage_Running)
+	c.Assert(st.PauseSync(), IsNil)
+	c.Assert(st.SyncPaused(), IsTrue)
+	c.Assert(syncUnit.IsReadOnly(), IsTrue)
+	c.Assert(string(st.Result().Detail), Equals, syncPausedDetail)
+
+	// clearing read-only mode doesn't resume the paused sync.
+	st.SetReadOnly(false)
+	c.Assert(syncUnit.IsReadOnly(), IsTrue)
+
+	c.Assert(st.ResumeSync(), IsNil)
+	c.Assert(st.SyncPaused(), IsFalse)
+	c.Assert(syncUnit.IsReadOnly(), IsFalse)
+	c.Assert(st.Result(), IsNil)
+	c.Assert(terror.ErrWorkerSyncNotPaused.Equal(st.ResumeSync()), IsTrue)
+
+	// the error occurred when the sync is paused is kept after resumed.
+	c.Assert(st.PauseSync(), IsNil)
+	result := &pb.ProcessResult{Errors: []*pb.ProcessError{unit.NewProcessError(terror.ErrDBBadConn.Generate())}}
+	st.setResult(result)
+	c.Assert(st.ResumeSync(), IsNil)
+	c.Assert(st.Result(), Equals, result)
+}
+
+func (t *testSubTask) TestSubTaskQueueDepths(c *C) {
+	cfg := &config.SubTaskConfig{Name: "testSubTaskQueueDepths", Mode: config.ModeAll}
+	st := NewSubTask(cfg, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	_, err := st.QueueDepths()
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(err), IsTrue)
+
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	qd, err := st.QueueDepths()
+	c.Assert(err, IsNil)
+	c.Assert(qd.DML, HasLen, 0)
+}
+
+func (t *testSubTask) TestSubTaskSafeModeRemaining(c *C) {
+	cfg := &config.SubTaskConfig{Name: "testSubTaskSafeModeRemaining", Mode: config.ModeAll}
+	st := NewSubTask(cfg, nil)
+	st.setCurrUnit(NewMockUnit(pb.UnitType_Load))
+	_, err := st.SafeModeRemaining()
+	c.Assert(terror.ErrWorkerOperSyncUnitOnly.Equal(err), IsTrue)
+
+	st.setCurrUnit(syncer.NewSyncer(cfg, nil))
+	r, err := st.SafeModeRemaining()
+	c.Assert(err, IsNil)
+	c.Assert(r.Active, IsFalse)
+}
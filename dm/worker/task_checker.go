@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/pkg/backoff"
+	"github.com/pingcap/dm/pkg/conn"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/retry"
 	"github.com/pingcap/dm/pkg/terror"
@@ -61,6 +62,9 @@ type ResumeStrategy int
 //	1. update latestPausedTime
 //	2. dispatch auto resume task
 //	3. if step2 successes, update latestResumeTime, forward backoff
+// ResumeWaitWritable:
+//	1. update latestPausedTime
+//	2. probe whether the downstream is writable again, if so dispatch auto resume task, and update latestResumeTime
 const (
 	// When a task is not in paused state, or paused by manually, or we can't get enough information from worker
 	// to determine whether this task is paused because of some error, we will apply ResumeIgnore strategy, and
@@ -75,13 +79,31 @@ const (
 	ResumeNoSense
 	// ResumeDispatch means we will dispatch an auto resume operation in this check round for the paused task
 	ResumeDispatch
+	// When checker detects a task is paused because the downstream is read-only, resuming it will fail until
+	// the downstream becomes writable, so we will apply ResumeWaitWritable strategy, and only dispatch auto resume
+	// after probed the downstream is writable, without forwarding backoff.
+	ResumeWaitWritable
 )
 
 var resumeStrategy2Str = map[ResumeStrategy]string{
-	ResumeIgnore:   "ignore task",
-	ResumeSkip:     "skip task resume",
-	ResumeNoSense:  "resume task makes no sense",
-	ResumeDispatch: "dispatch auto resume",
+	ResumeIgnore:       "ignore task",
+	ResumeSkip:         "skip task resume",
+	ResumeNoSense:      "resume task makes no sense",
+	ResumeDispatch:     "dispatch auto resume",
+	ResumeWaitWritable: "wait for downstream writable",
+}
+
+// probeDownstreamReadOnly checks whether the downstream is read-only, it's a variable to be replaced in tests
+var probeDownstreamReadOnly = func(ctx context.Context, dbProvider conn.DBProvider, cfg config.DBConfig) (bool, error) {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	db, err := dbProvider.Apply(cfg)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+	return utils.IsReadOnly(ctx, db.DB)
 }
 
 // String implements fmt.Stringer interface
@@ -118,6 +140,16 @@ type backoffController struct {
 	// task name -> the latest auto resume time
 	latestResumeTime map[string]time.Time
 
+	// task name -> the time detected task paused because of downstream read-only
+	latestReadOnlyTime map[string]time.Time
+
+	// task name -> the checkpoint the task was auto resumed from and how many times
+	noProgress map[string]*noProgressRecord
+
+	// the time detected the upstream of the source down, and the subtasks paused together because of it
+	upstreamDownTime time.Time
+	upstreamPaused   []string
+
 	latestRelayPausedTime time.Time
 	latestRelayBlockTime  time.Time
 	latestRelayResumeTime time.Time
@@ -127,13 +159,37 @@ type backoffController struct {
 // newBackoffController returns a new backoffController instance
 func newBackoffController() *backoffController {
 	return &backoffController{
-		backoffs:         make(map[string]*backoff.Backoff),
-		latestPausedTime: make(map[string]time.Time),
-		latestBlockTime:  make(map[string]time.Time),
-		latestResumeTime: make(map[string]time.Time),
+		backoffs:           make(map[string]*backoff.Backoff),
+		latestPausedTime:   make(map[string]time.Time),
+		latestBlockTime:    make(map[string]time.Time),
+		latestResumeTime:   make(map[string]time.Time),
+		latestReadOnlyTime: make(map[string]time.Time),
+		noProgress:         make(map[string]*noProgressRecord),
 	}
 }
 
+// noProgressRecord records the auto resumes of a task from the same checkpoint.
+type noProgressRecord struct {
+	checkpoint string
+	resumes    int
+}
+
+// recordResume records an auto resume of the task from the checkpoint, and returns how many times the task has been
+// auto resumed from it before and whether it exceeds the max number, the record is reset once exceeded.
+func (bc *backoffController) recordResume(taskName, checkpoint string, maxResumes int) (int, bool) {
+	record, ok := bc.noProgress[taskName]
+	if !ok || record.checkpoint != checkpoint {
+		record = &noProgressRecord{checkpoint: checkpoint}
+		bc.noProgress[taskName] = record
+	}
+	if maxResumes > 0 && record.resumes >= maxResumes {
+		delete(bc.noProgress, taskName)
+		return record.resumes, true
+	}
+	record.resumes++
+	return record.resumes - 1, false
+}
+
 // realTaskStatusChecker is not thread-safe.
 // It runs asynchronously against DM-worker, and task information may be updated
 // later than DM-worker, but it is acceptable.
@@ -255,6 +311,19 @@ func isResumableError(err *pb.ProcessError) bool {
 	return true
 }
 
+// isDownstreamReadOnlyError checks whether the error is caused by writing to a read-only downstream
+func isDownstreamReadOnlyError(err *pb.ProcessError) bool {
+	if err == nil {
+		return false
+	}
+	for _, msg := range retry.DownstreamReadOnlyErrMsgs {
+		if strings.Contains(strings.ToLower(err.RawCause), strings.ToLower(msg)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (tsc *realTaskStatusChecker) getResumeStrategy(stStatus *pb.SubTaskStatus, duration time.Duration) ResumeStrategy {
 	// task that is not paused or paused manually, just ignore it
 	if stStatus == nil || stStatus.Stage != pb.Stage_Paused || stStatus.Result == nil || stStatus.Result.IsCanceled {
@@ -262,6 +331,11 @@ func (tsc *realTaskStatusChecker) getResumeStrategy(stStatus *pb.SubTaskStatus,
 	}
 
 	// TODO: use different strategies based on the error detail
+	for _, processErr := range stStatus.Result.Errors {
+		if isDownstreamReadOnlyError(processErr) {
+			return ResumeWaitWritable
+		}
+	}
 	for _, processErr := range stStatus.Result.Errors {
 		if !isResumableError(processErr) {
 			failpoint.Inject("TaskCheckInterval", func(_ failpoint.Value) {
@@ -279,6 +353,15 @@ func (tsc *realTaskStatusChecker) getResumeStrategy(stStatus *pb.SubTaskStatus,
 	return ResumeDispatch
 }
 
+// maintenanceBackoff returns the calmer backoff duration used during the maintenance window, the upstream or
+// downstream is expected to be unavailable, so it's no use to resume frequently.
+func (tsc *realTaskStatusChecker) maintenanceBackoff(duration time.Duration) time.Duration {
+	if duration < tsc.cfg.BackoffMax.Duration {
+		return tsc.cfg.BackoffMax.Duration
+	}
+	return duration
+}
+
 func (tsc *realTaskStatusChecker) getRelayResumeStrategy(relayStatus *pb.RelayStatus, duration time.Duration) ResumeStrategy {
 	// relay that is not paused or paused manually, just ignore it
 	if relayStatus == nil || relayStatus.Stage != pb.Stage_Paused || relayStatus.Result == nil || relayStatus.Result.IsCanceled {
@@ -310,6 +393,10 @@ func (tsc *realTaskStatusChecker) checkRelayStatus() {
 	}
 	rbf := tsc.bc.relayBackoff
 	duration := rbf.Current()
+	inMaintenance := tsc.w.inMaintenance()
+	if inMaintenance {
+		duration = tsc.maintenanceBackoff(duration)
+	}
 	strategy := tsc.getRelayResumeStrategy(relayStatus, duration)
 	switch strategy {
 	case ResumeIgnore:
@@ -337,9 +424,11 @@ func (tsc *realTaskStatusChecker) checkRelayStatus() {
 		if err != nil {
 			tsc.l.Error("dispatch auto resume relay failed", zap.Error(err))
 		} else {
-			tsc.l.Info("dispatch auto resume relay")
+			tsc.l.Info("dispatch auto resume relay", zap.Bool("during maintenance", inMaintenance))
 			tsc.bc.latestRelayResumeTime = time.Now()
-			rbf.BoundaryForward()
+			if !inMaintenance {
+				rbf.BoundaryForward()
+			}
 		}
 	}
 }
@@ -357,6 +446,8 @@ func (tsc *realTaskStatusChecker) checkTaskStatus() {
 				delete(tsc.bc.latestPausedTime, taskName)
 				delete(tsc.bc.latestBlockTime, taskName)
 				delete(tsc.bc.latestResumeTime, taskName)
+				delete(tsc.bc.latestReadOnlyTime, taskName)
+				delete(tsc.bc.noProgress, taskName)
 			}
 		}
 	}()
@@ -370,7 +461,17 @@ func (tsc *realTaskStatusChecker) checkTaskStatus() {
 			tsc.bc.latestResumeTime[taskName] = time.Now()
 		}
 		duration := bf.Current()
+		inMaintenance := tsc.w.inMaintenance()
+		if inMaintenance {
+			duration = tsc.maintenanceBackoff(duration)
+		}
 		strategy := tsc.getResumeStrategy(stStatus, duration)
+		if strategy != ResumeWaitWritable {
+			delete(tsc.bc.latestReadOnlyTime, taskName)
+		}
+		if inMaintenance && strategy != ResumeIgnore {
+			tsc.w.maintenance.record(taskName, stStatus.Result.Errors, tsc.bc.latestResumeTime[taskName], time.Now())
+		}
 		switch strategy {
 		case ResumeIgnore:
 			if time.Since(tsc.bc.latestPausedTime[taskName]) > tsc.cfg.BackoffRollback.Duration {
@@ -393,21 +494,93 @@ func (tsc *realTaskStatusChecker) checkTaskStatus() {
 			tsc.bc.latestPausedTime[taskName] = time.Now()
 		case ResumeDispatch:
 			tsc.bc.latestPausedTime[taskName] = time.Now()
+			if st := tsc.w.subTaskHolder.findSubTask(taskName); st != nil && st.Stepping() {
+				tsc.l.Warn("task is in stepping mode, skip auto resume", zap.String("task", taskName))
+				continue
+			}
+			if tsc.noForwardProgress(taskName) {
+				continue
+			}
+			err := tsc.w.OperateSubTask(taskName, pb.TaskOp_AutoResume)
+			if err != nil {
+				tsc.l.Error("dispatch auto resume task failed", zap.String("task", taskName), zap.Error(err))
+			} else {
+				tsc.l.Info("dispatch auto resume task", zap.String("task", taskName), zap.Bool("during maintenance", inMaintenance))
+				tsc.bc.latestResumeTime[taskName] = time.Now()
+				// the backoff is not forwarded during maintenance, so it's not escalated after the window.
+				if !inMaintenance {
+					bf.BoundaryForward()
+				}
+			}
+		case ResumeWaitWritable:
+			tsc.bc.latestPausedTime[taskName] = time.Now()
+			readOnlyTime, ok := tsc.bc.latestReadOnlyTime[taskName]
+			if !ok {
+				readOnlyTime = time.Now()
+				tsc.bc.latestReadOnlyTime[taskName] = readOnlyTime
+				tsc.l.Warn("task paused because downstream is read-only, wait for it writable", zap.String("task", taskName))
+			}
+			if !tsc.isDownstreamWritable(taskName) {
+				continue
+			}
 			err := tsc.w.OperateSubTask(taskName, pb.TaskOp_AutoResume)
 			if err != nil {
 				tsc.l.Error("dispatch auto resume task failed", zap.String("task", taskName), zap.Error(err))
 			} else {
-				tsc.l.Info("dispatch auto resume task", zap.String("task", taskName))
+				tsc.l.Info("downstream is writable, dispatch auto resume task", zap.String("task", taskName), zap.Duration("read-only duration", time.Since(readOnlyTime)))
 				tsc.bc.latestResumeTime[taskName] = time.Now()
-				bf.BoundaryForward()
+				delete(tsc.bc.latestReadOnlyTime, taskName)
 			}
 		}
 	}
 }
 
+// noForwardProgress records the checkpoint of the task before auto resuming it, and if it has been auto resumed from
+// the same checkpoint too many times, it's not auto resumed anymore by adding an un-resumable error to its result.
+func (tsc *realTaskStatusChecker) noForwardProgress(taskName string) bool {
+	st := tsc.w.subTaskHolder.findSubTask(taskName)
+	if st == nil {
+		return false
+	}
+	checkpoint, ok := st.syncCheckpoint()
+	if !ok {
+		return false
+	}
+	resumes, exceeded := tsc.bc.recordResume(taskName, checkpoint, tsc.cfg.MaxNoProgressResumes)
+	if !exceeded {
+		return false
+	}
+	tsc.l.Error("task makes no forward progress after auto resumes, stop auto resuming it", zap.String("task", taskName), zap.String("checkpoint", checkpoint), zap.Int("resumes", resumes))
+	st.addResultError(terror.ErrWorkerNoForwardProgress.Generate(taskName, checkpoint, resumes))
+	st.noProgressCheckpoint.Set(checkpoint)
+	return true
+}
+
+// isDownstreamWritable probes whether the downstream of the subtask is writable.
+func (tsc *realTaskStatusChecker) isDownstreamWritable(taskName string) bool {
+	st := tsc.w.subTaskHolder.findSubTask(taskName)
+	if st == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultDBTimeout)
+	defer cancel()
+	st.RLock()
+	dbProvider := st.dbProvider
+	st.RUnlock()
+	readOnly, err := probeDownstreamReadOnly(ctx, dbProvider, st.cfg.To)
+	if err != nil {
+		tsc.l.Warn("fail to probe whether downstream is read-only", zap.String("task", taskName), zap.Error(err))
+		return false
+	}
+	return !readOnly
+}
+
 func (tsc *realTaskStatusChecker) check() {
 	if tsc.w.cfg.EnableRelay {
 		tsc.checkRelayStatus()
 	}
+	if tsc.cfg.PauseOnUpstreamDown && !tsc.checkUpstreamHealth() {
+		return
+	}
 	tsc.checkTaskStatus()
 }
@@ -14,6 +14,7 @@
 package worker
 
 import (
+	"context"
 	"time"
 
 	"github.com/pingcap/check"
@@ -24,6 +25,7 @@ import (
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/conn"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/terror"
 )
@@ -35,6 +37,7 @@ type testTaskCheckerSuite struct{}
 var (
 	unsupporteModifyColumnError = unit.NewProcessError(terror.ErrDBExecuteFailed.Delegate(&tmysql.SQLError{Code: 1105, Message: "unsupported modify column length 20 is less than origin 40", State: tmysql.DefaultMySQLState}))
 	unknownProcessError         = unit.NewProcessError(errors.New("error mesage"))
+	downstreamReadOnlyError     = unit.NewProcessError(terror.ErrDBExecuteFailed.Delegate(&tmysql.SQLError{Code: 1290, Message: "The MySQL server is running with the --read-only option so it cannot execute this statement", State: tmysql.DefaultMySQLState}))
 )
 
 func (s *testTaskCheckerSuite) TestResumeStrategy(c *check.C) {
@@ -55,6 +58,7 @@ func (s *testTaskCheckerSuite) TestResumeStrategy(c *check.C) {
 		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused}, now, time.Duration(0), 1 * time.Millisecond, ResumeIgnore},
 		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused, Result: &pb.ProcessResult{IsCanceled: true}}, now, time.Duration(0), 1 * time.Millisecond, ResumeIgnore},
 		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused, Result: &pb.ProcessResult{IsCanceled: false, Errors: []*pb.ProcessError{unsupporteModifyColumnError}}}, now, time.Duration(0), 1 * time.Millisecond, ResumeNoSense},
+		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused, Result: &pb.ProcessResult{IsCanceled: false, Errors: []*pb.ProcessError{downstreamReadOnlyError}}}, now, -2 * time.Millisecond, 1 * time.Millisecond, ResumeWaitWritable},
 		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused, Result: &pb.ProcessResult{IsCanceled: false}}, now, time.Duration(0), 1 * time.Second, ResumeSkip},
 		{&pb.SubTaskStatus{Name: taskName, Stage: pb.Stage_Paused, Result: &pb.ProcessResult{IsCanceled: false}}, now, -2 * time.Millisecond, 1 * time.Millisecond, ResumeDispatch},
 	}
@@ -192,6 +196,200 @@ func (s *testTaskCheckerSuite) TestCheck(c *check.C) {
 	}
 }
 
+func (s *testTaskCheckerSuite) TestCheckDownstreamReadOnly(c *check.C) {
+	taskName := "test-read-only-task"
+	readOnly := true
+	oldProbe := probeDownstreamReadOnly
+	probeDownstreamReadOnly = func(context.Context, conn.DBProvider, config.DBConfig) (bool, error) {
+		return readOnly, nil
+	}
+	defer func() {
+		probeDownstreamReadOnly = oldProbe
+	}()
+
+	NewRelayHolder = NewDummyRelayHolder
+	dir := c.MkDir()
+	cfg := loadSourceConfigWithoutPassword(c)
+	cfg.RelayDir = dir
+	cfg.MetaDir = dir
+	w, err := NewWorker(&cfg, nil, "")
+	c.Assert(err, check.IsNil)
+	w.closed.Set(closedFalse)
+
+	tsc := NewRealTaskStatusChecker(config.CheckerConfig{
+		CheckEnable:     true,
+		CheckInterval:   config.Duration{Duration: config.DefaultCheckInterval},
+		BackoffRollback: config.Duration{Duration: 200 * time.Millisecond},
+		BackoffMin:      config.Duration{Duration: 1 * time.Millisecond},
+		BackoffMax:      config.Duration{Duration: 1 * time.Second},
+		BackoffFactor:   config.DefaultBackoffFactor,
+	}, w)
+	c.Assert(tsc.Init(), check.IsNil)
+	rtsc, ok := tsc.(*realTaskStatusChecker)
+	c.Assert(ok, check.IsTrue)
+
+	st := &SubTask{
+		cfg:   &config.SubTaskConfig{Name: taskName},
+		stage: pb.Stage_Paused,
+		result: &pb.ProcessResult{
+			IsCanceled: false,
+			Errors:     []*pb.ProcessError{downstreamReadOnlyError},
+		},
+		l: log.With(zap.String("subtask", taskName)),
+	}
+	rtsc.w.subTaskHolder.recordSubTask(st)
+	c.Assert(w.GetDownstreamReadOnlySubTasks(), check.DeepEquals, []string{taskName})
+
+	// downstream is still read-only, not resumed
+	rtsc.check()
+	latestResumeTime := rtsc.bc.latestResumeTime[taskName]
+	readOnlyTime, ok := rtsc.bc.latestReadOnlyTime[taskName]
+	c.Assert(ok, check.IsTrue)
+	time.Sleep(2 * time.Millisecond)
+	rtsc.check()
+	c.Assert(rtsc.bc.latestResumeTime[taskName], check.Equals, latestResumeTime)
+	c.Assert(rtsc.bc.latestReadOnlyTime[taskName], check.Equals, readOnlyTime)
+	bf := rtsc.bc.backoffs[taskName]
+	c.Assert(bf.Current(), check.Equals, 1*time.Millisecond)
+
+	// downstream becomes writable, resumed without forwarding backoff
+	readOnly = false
+	rtsc.check()
+	c.Assert(latestResumeTime.Before(rtsc.bc.latestResumeTime[taskName]), check.IsTrue)
+	c.Assert(rtsc.bc.latestReadOnlyTime, check.HasLen, 0)
+	c.Assert(bf.Current(), check.Equals, 1*time.Millisecond)
+}
+
+func (s *testTaskCheckerSuite) TestCheckDuringMaintenance(c *check.C) {
+	var (
+		taskName   = "test-maintenance-task"
+		backoffMin = 1 * time.Millisecond
+		backoffMax = 100 * time.Millisecond
+	)
+
+	NewRelayHolder = NewDummyRelayHolder
+	dir := c.MkDir()
+	cfg := loadSourceConfigWithoutPassword(c)
+	cfg.RelayDir = dir
+	cfg.MetaDir = dir
+	w, err := NewWorker(&cfg, nil, "")
+	c.Assert(err, check.IsNil)
+	w.closed.Set(closedFalse)
+
+	tsc := NewRealTaskStatusChecker(config.CheckerConfig{
+		CheckEnable:     true,
+		CheckInterval:   config.Duration{Duration: config.DefaultCheckInterval},
+		BackoffRollback: config.Duration{Duration: 200 * time.Millisecond},
+		BackoffMin:      config.Duration{Duration: backoffMin},
+		BackoffMax:      config.Duration{Duration: backoffMax},
+		BackoffFactor:   config.DefaultBackoffFactor,
+	}, w)
+	c.Assert(tsc.Init(), check.IsNil)
+	rtsc, ok := tsc.(*realTaskStatusChecker)
+	c.Assert(ok, check.IsTrue)
+
+	st := &SubTask{
+		cfg:   &config.SubTaskConfig{Name: taskName},
+		stage: pb.Stage_Paused,
+		result: &pb.ProcessResult{
+			IsCanceled: false,
+			Errors:     []*pb.ProcessError{unknownProcessError},
+		},
+		l: log.With(zap.String("subtask", taskName)),
+	}
+	rtsc.w.subTaskHolder.recordSubTask(st)
+	now := time.Now()
+	err = w.SetMaintenanceWindow(now, time.Time{})
+	c.Assert(terror.ErrWorkerInvalidMaintenanceWindow.Equal(err), check.IsTrue)
+	err = w.SetMaintenanceWindow(now, now.Add(-time.Minute))
+	c.Assert(terror.ErrWorkerInvalidMaintenanceWindow.Equal(err), check.IsTrue)
+	c.Assert(w.SetMaintenanceWindow(now.Add(-time.Minute), now.Add(time.Minute)), check.IsNil)
+
+	// resume in the max backoff during maintenance.
+	rtsc.check()
+	latestResumeTime := rtsc.bc.latestResumeTime[taskName]
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * backoffMin)
+		rtsc.check()
+		c.Assert(rtsc.bc.latestResumeTime[taskName], check.Equals, latestResumeTime)
+	}
+	time.Sleep(backoffMax)
+	rtsc.check()
+	c.Assert(latestResumeTime.Before(rtsc.bc.latestResumeTime[taskName]), check.IsTrue)
+	// backoff is not forwarded.
+	bf := rtsc.bc.backoffs[taskName]
+	c.Assert(bf.Current(), check.Equals, backoffMin)
+
+	// errors are recorded, and counted again after resumed.
+	status, err := w.GetMaintenanceStatus()
+	c.Assert(err, check.IsNil)
+	c.Assert(status.Active, check.IsTrue)
+	c.Assert(status.Errors, check.HasLen, 1)
+	c.Assert(status.Errors[0].Task, check.Equals, taskName)
+	c.Assert(status.Errors[0].Message, check.Equals, unknownProcessError.Message)
+	c.Assert(status.Errors[0].Count, check.Equals, 1)
+	// paused again for the same error.
+	st.setResult(&pb.ProcessResult{Errors: []*pb.ProcessError{unknownProcessError}})
+	st.setStage(pb.Stage_Paused)
+	time.Sleep(2 * backoffMin)
+	rtsc.check()
+	status, err = w.GetMaintenanceStatus()
+	c.Assert(err, check.IsNil)
+	c.Assert(status.Errors[0].Count, check.Equals, 2)
+
+	// normal backoff after the window.
+	c.Assert(w.SetMaintenanceWindow(time.Time{}, time.Time{}), check.IsNil)
+	time.Sleep(2 * backoffMin)
+	rtsc.check()
+	c.Assert(bf.Current() > backoffMin, check.IsTrue)
+	status, err = w.GetMaintenanceStatus()
+	c.Assert(err, check.IsNil)
+	c.Assert(status.Active, check.IsFalse)
+	c.Assert(status.Window, check.IsNil)
+	c.Assert(status.Errors, check.HasLen, 0)
+}
+
+func (s *testTaskCheckerSuite) TestIsDownstreamReadOnlyError(c *check.C) {
+	c.Assert(isDownstreamReadOnlyError(nil), check.IsFalse)
+	c.Assert(isDownstreamReadOnlyError(unknownProcessError), check.IsFalse)
+	c.Assert(isDownstreamReadOnlyError(unsupporteModifyColumnError), check.IsFalse)
+	c.Assert(isDownstreamReadOnlyError(downstreamReadOnlyError), check.IsTrue)
+	c.Assert(isDownstreamReadOnlyError(unit.NewProcessError(terror.ErrDBExecuteFailed.Delegate(
+		&tmysql.SQLError{Code: 1836, Message: "Running in read-only mode", State: tmysql.DefaultMySQLState}))), check.IsTrue)
+}
+
+func (s *testTaskCheckerSuite) TestRecordResume(c *check.C) {
+	bc := newBackoffController()
+	for i := 0; i < 3; i++ {
+		resumes, exceeded := bc.recordResume("task1", "(mysql-bin.000001, 4)", 3)
+		c.Assert(resumes, check.Equals, i)
+		c.Assert(exceeded, check.IsFalse)
+	}
+	// other tasks are independent.
+	_, exceeded := bc.recordResume("task2", "(mysql-bin.000001, 4)", 3)
+	c.Assert(exceeded, check.IsFalse)
+
+	resumes, exceeded := bc.recordResume("task1", "(mysql-bin.000001, 4)", 3)
+	c.Assert(resumes, check.Equals, 3)
+	c.Assert(exceeded, check.IsTrue)
+	// reset after exceeded, e.g. resumed manually.
+	resumes, exceeded = bc.recordResume("task1", "(mysql-bin.000001, 4)", 3)
+	c.Assert(resumes, check.Equals, 0)
+	c.Assert(exceeded, check.IsFalse)
+
+	// the checkpoint advanced.
+	_, _ = bc.recordResume("task1", "(mysql-bin.000001, 4)", 3)
+	resumes, exceeded = bc.recordResume("task1", "(mysql-bin.000001, 1024)", 3)
+	c.Assert(resumes, check.Equals, 0)
+	c.Assert(exceeded, check.IsFalse)
+
+	// no limit.
+	for i := 0; i < 10; i++ {
+		_, exceeded = bc.recordResume("task3", "(mysql-bin.000001, 4)", 0)
+		c.Assert(exceeded, check.IsFalse)
+	}
+}
+
 func (s *testTaskCheckerSuite) TestCheckTaskIndependent(c *check.C) {
 	var (
 		task1                 = "task1"
@@ -305,6 +503,7 @@ func (s *testTaskCheckerSuite) TestIsResumableError(c *check.C) {
 		// unresumable terror codes
 		{terror.ErrSyncUnitDDLWrongSequence.Generate("wrong sequence", "right sequence"), false},
 		{terror.ErrSyncerShardDDLConflict.Generate("conflict DDL"), false},
+		{terror.ErrWorkerNoForwardProgress.Generate("task", "(mysql-bin.000001, 4)", 10), false},
 		// others
 		{nil, true},
 		{errors.New("unknown error"), true},
@@ -316,3 +515,74 @@ func (s *testTaskCheckerSuite) TestIsResumableError(c *check.C) {
 		c.Assert(isResumableError(err), check.Equals, tc.resumable)
 	}
 }
+
+func (s *testTaskCheckerSuite) TestCheckUpstreamDown(c *check.C) {
+	var upstreamErr error
+	oldProbe := probeUpstreamAlive
+	probeUpstreamAlive = func(context.Context, conn.DBProvider, config.DBConfig) error {
+		return upstreamErr
+	}
+	defer func() {
+		probeUpstreamAlive = oldProbe
+	}()
+
+	w := &Worker{
+		cfg:           &config.SourceConfig{SourceID: "source-1"},
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.closed.Set(closedFalse)
+	tsc := NewRealTaskStatusChecker(config.CheckerConfig{
+		CheckEnable:         true,
+		CheckInterval:       config.Duration{Duration: config.DefaultCheckInterval},
+		BackoffRollback:     config.Duration{Duration: 200 * time.Millisecond},
+		BackoffMin:          config.Duration{Duration: 1 * time.Millisecond},
+		BackoffMax:          config.Duration{Duration: 1 * time.Second},
+		BackoffFactor:       config.DefaultBackoffFactor,
+		PauseOnUpstreamDown: true,
+	}, w)
+	c.Assert(tsc.Init(), check.IsNil)
+	rtsc := tsc.(*realTaskStatusChecker)
+
+	subTasks := make(map[string]*SubTask)
+	for _, name := range []string{"task-b", "task-a", "task-failed"} {
+		stage := pb.Stage_Running
+		if name == "task-failed" {
+			stage = pb.Stage_Paused
+		}
+		st := NewSubTaskWithStage(&config.SubTaskConfig{Name: name}, stage, nil)
+		st.setCurrUnit(NewMockUnit(pb.UnitType_Sync))
+		st.setCurrCtx(context.WithCancel(context.Background()))
+		st.initialized.Set(true)
+		defer st.Close()
+		w.subTaskHolder.recordSubTask(st)
+		subTasks[name] = st
+	}
+	subTasks["task-failed"].result = &pb.ProcessResult{Errors: []*pb.ProcessError{unknownProcessError}}
+
+	// upstream is up.
+	c.Assert(rtsc.checkUpstreamHealth(), check.IsTrue)
+	c.Assert(rtsc.bc.upstreamDownTime.IsZero(), check.IsTrue)
+
+	// upstream is down, running subtasks are paused together, and the failed one is not auto resumed.
+	upstreamErr = errors.New("connection refused")
+	rtsc.check()
+	c.Assert(rtsc.bc.upstreamDownTime.IsZero(), check.IsFalse)
+	c.Assert(rtsc.bc.upstreamPaused, check.DeepEquals, []string{"task-a", "task-b"})
+	for _, st := range subTasks {
+		c.Assert(st.Stage(), check.Equals, pb.Stage_Paused)
+	}
+	c.Assert(rtsc.bc.backoffs, check.HasLen, 0)
+	downTime := rtsc.bc.upstreamDownTime
+	rtsc.check()
+	c.Assert(rtsc.bc.upstreamDownTime, check.Equals, downTime)
+
+	// upstream is up again, the paused subtasks are resumed together.
+	upstreamErr = nil
+	c.Assert(rtsc.checkUpstreamHealth(), check.IsTrue)
+	c.Assert(rtsc.bc.upstreamDownTime.IsZero(), check.IsTrue)
+	c.Assert(rtsc.bc.upstreamPaused, check.IsNil)
+	c.Assert(subTasks["task-a"].Stage(), check.Equals, pb.Stage_Running)
+	c.Assert(subTasks["task-b"].Stage(), check.Equals, pb.Stage_Running)
+	c.Assert(subTasks["task-failed"].Stage(), check.Equals, pb.Stage_Paused)
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// probeUpstreamAlive checks whether the upstream can be connected, it's a variable to be replaced in tests.
+var probeUpstreamAlive = func(ctx context.Context, dbProvider conn.DBProvider, cfg config.DBConfig) error {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	db, err := dbProvider.Apply(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.DB.PingContext(ctx)
+}
+
+// checkUpstreamHealth probes the upstream shared by all subtasks of the source once, and returns whether it's up.
+// when it goes down, the running subtasks are paused together with a single event, instead of each of them failing
+// and being auto resumed independently, and they're resumed together when it's up again. the subtasks paused by
+// errors are not auto resumed while it's down.
+func (tsc *realTaskStatusChecker) checkUpstreamHealth() bool {
+	tsc.w.RLock()
+	dbProvider, dbCfg := tsc.w.dbProvider, tsc.w.cfg.GenerateDBConfig()
+	tsc.w.RUnlock()
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultDBTimeout)
+	defer cancel()
+	err := probeUpstreamAlive(ctx, dbProvider, *dbCfg)
+
+	if err != nil {
+		if !tsc.bc.upstreamDownTime.IsZero() {
+			tsc.l.Debug("upstream is still unavailable", zap.Duration("down duration", time.Since(tsc.bc.upstreamDownTime)), log.ShortError(err))
+			return false
+		}
+		tsc.bc.upstreamDownTime = time.Now()
+		tsc.bc.upstreamPaused = tsc.pauseRunningSubTasks()
+		tsc.l.Error("upstream unavailable, pause all subtasks of the source until it's available again",
+			zap.String("source", tsc.w.cfg.SourceID), zap.Strings("paused subtasks", tsc.bc.upstreamPaused), log.ShortError(err))
+		return false
+	}
+
+	if tsc.bc.upstreamDownTime.IsZero() {
+		return true
+	}
+	resumed := make([]string, 0, len(tsc.bc.upstreamPaused))
+	for _, name := range tsc.bc.upstreamPaused {
+		// the subtasks operated by others while upstream is unavailable are not resumed.
+		if st := tsc.w.subTaskHolder.findSubTask(name); st == nil || st.Stage() != pb.Stage_Paused {
+			continue
+		}
+		if err = tsc.w.OperateSubTask(name, pb.TaskOp_Resume); err != nil {
+			tsc.l.Error("fail to resume subtask after upstream available", zap.String("task", name), zap.Error(err))
+			continue
+		}
+		resumed = append(resumed, name)
+	}
+	tsc.l.Info("upstream available again, resume the subtasks paused because of it",
+		zap.String("source", tsc.w.cfg.SourceID), zap.Strings("resumed subtasks", resumed), zap.Duration("down duration", time.Since(tsc.bc.upstreamDownTime)))
+	tsc.bc.upstreamDownTime = time.Time{}
+	tsc.bc.upstreamPaused = nil
+	return true
+}
+
+// pauseRunningSubTasks pauses all running subtasks and returns the names of them.
+func (tsc *realTaskStatusChecker) pauseRunningSubTasks() []string {
+	var paused []string
+	for name, st := range tsc.w.subTaskHolder.getAllSubTasks() {
+		if st.Stage() != pb.Stage_Running {
+			continue
+		}
+		if err := tsc.w.OperateSubTask(name, pb.TaskOp_Pause); err != nil {
+			tsc.l.Error("fail to pause subtask after upstream unavailable", zap.String("task", name), zap.Error(err))
+			continue
+		}
+		paused = append(paused, name)
+	}
+	sort.Strings(paused)
+	return paused
+}
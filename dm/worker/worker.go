@@ -15,7 +15,12 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,17 +29,26 @@ import (
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	"github.com/siddontang/go/sync2"
 	"go.etcd.io/etcd/clientv3"
+	v3rpc "go.etcd.io/etcd/etcdserver/api/v3rpc/rpctypes"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dumpling"
+	"github.com/pingcap/dm/loader"
+	"github.com/pingcap/dm/pkg/backoff"
 	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
 	"github.com/pingcap/dm/pkg/etcdutil"
+	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/ha"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
+	"github.com/pingcap/dm/pkg/streamer"
 	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
 	"github.com/pingcap/dm/relay/purger"
+	"github.com/pingcap/dm/syncer"
 )
 
 var (
@@ -42,6 +56,15 @@ var (
 	closedTrue  int32 = 1
 )
 
+// minCheckpointFlushInterval is the minimum checkpoint flush interval could be set at runtime.
+const minCheckpointFlushInterval = time.Second
+
+// the bounds of batch size of downstream transactions could be set at runtime.
+const (
+	minBatchSize = 1
+	maxBatchSize = 10000
+)
+
 // Worker manages sub tasks and process units for data migration
 type Worker struct {
 	// ensure no other operation can be done when closing (we can use `WatGroup`/`Context` to archive this)
@@ -65,6 +88,50 @@ type Worker struct {
 	taskStatusChecker TaskStatusChecker
 
 	etcdClient *clientv3.Client
+	// etcdWatchClient is used for long-lived watches, it's the same as etcdClient if no dedicated client is provided.
+	etcdWatchClient *clientv3.Client
+
+	auditor *auditor
+
+	// watchFaults is used to simulate disconnecting etcd watches in chaos testing
+	watchFaults watchFaults
+
+	// readOnly is whether writes to downstream of all subtasks are held, it's also applied to subtasks started later
+	readOnly sync2.AtomicBool
+
+	// goroutines counts goroutines of the worker except units of subtasks, see GetGoroutineStatus
+	goroutines goroutineCounter
+	// resourceUsages are the latest sampled resource usage of subtasks, see GetSubTaskResourceUsage
+	resourceUsages resourceUsages
+
+	// statusConcurrency is the max number of subtasks collecting status concurrently, 0 means the default value
+	statusConcurrency sync2.AtomicInt32
+	// statusTaskTimeout is the timeout of collecting status for each subtask, 0 means sharing the deadline equally
+	statusTaskTimeout sync2.AtomicDuration
+
+	// relayPurgeWaitTimeout is the max time to wait for the relay purging finished when starting a subtask,
+	// non-positive means failing the subtask at once, see SetRelayPurgeWaitTimeout
+	relayPurgeWaitTimeout sync2.AtomicDuration
+
+	// dbProvider creates the DBs of upstream and downstream for the relay and subtasks, see SetDBProvider
+	dbProvider conn.DBProvider
+	// errClassifiers decides whether the DB errors met by the subtasks are retryable, see RegisterErrorClassifier
+	errClassifiers *retry.Classifiers
+
+	// subTaskOpHooks are called before and after operating subtasks, see RegisterSubTaskOpHook
+	subTaskOpHooks subTaskOpHooks
+	// subTaskStageObservers are called after subtasks transition stages, see RegisterSubTaskStageObserver
+	subTaskStageObservers subTaskStageObservers
+
+	// maintenance is the window of planned maintenance, see SetMaintenanceWindow
+	maintenance maintenance
+
+	// rollingOp is the progress of rolling pause or resume of subtasks, see RollingPauseAll
+	rollingOp rollingOp
+
+	// partialRelayWarnings are the tables needed by subtasks but discarded by partial relay before the subtasks added,
+	// task name -> tables, see GetRelayInfo
+	partialRelayWarnings map[string][]string
 
 	name string
 }
@@ -72,22 +139,33 @@ type Worker struct {
 // NewWorker creates a new Worker. The functionality of relay and subtask is disabled by default, need call EnableRelay
 // and EnableSubtask later
 func NewWorker(cfg *config.SourceConfig, etcdClient *clientv3.Client, name string) (w *Worker, err error) {
+	return NewWorkerWithWatchClient(cfg, etcdClient, etcdClient, name)
+}
+
+// NewWorkerWithWatchClient creates a new Worker which uses watchClient for watching stages from etcd,
+// and uses etcdClient for other operations. so the watch streams will not interfere with other requests.
+func NewWorkerWithWatchClient(cfg *config.SourceConfig, etcdClient, watchClient *clientv3.Client, name string) (w *Worker, err error) {
 	w = &Worker{
-		cfg:           cfg,
-		subTaskHolder: newSubTaskHolder(),
-		l:             log.With(zap.String("component", "worker controller")),
-		etcdClient:    etcdClient,
-		name:          name,
+		cfg:             cfg,
+		subTaskHolder:   newSubTaskHolder(),
+		l:               log.With(zap.String("component", "worker controller")),
+		etcdClient:      etcdClient,
+		etcdWatchClient: watchClient,
+		auditor:         newAuditor(),
+		errClassifiers:  retry.NewClassifiers(),
+		name:            name,
 	}
 	// keep running until canceled in `Close`.
 	w.ctx, w.cancel = context.WithCancel(context.Background())
 	w.closed.Set(closedTrue)
+	w.relayPurgeWaitTimeout.Set(defaultRelayPurgeWaitTimeout)
 
 	defer func(w2 *Worker) {
 		if err != nil { // when err != nil, `w` will become nil in this func, so we pass `w` in defer.
 			// release resources, NOTE: we need to refactor New/Init/Start/Close for components later.
 			w2.cancel()
 			w2.subTaskHolder.closeAllSubTasks()
+			w2.auditor.close()
 		}
 	}(w)
 
@@ -117,19 +195,46 @@ func (w *Worker) Start() {
 
 	w.wg.Add(1)
 	defer w.wg.Done()
+	w.goroutines.expect(goroutineStatusTicker, 1)
+	w.goroutines.add(goroutineStatusTicker, 1)
+	defer w.goroutines.add(goroutineStatusTicker, -1)
 
 	w.l.Info("start running")
 
+	// the resource usage of subtasks is sampled at the same interval as printing status.
+	sampleInterval := w.cfg.StatusPrintInterval.Duration
+	if sampleInterval <= 0 {
+		sampleInterval = resourceSampleInterval
+	}
+	w.wg.Add(1)
+	w.goroutines.expect(goroutineResourceSampler, 1)
+	w.goroutines.run(goroutineResourceSampler, func() {
+		defer w.wg.Done()
+		w.runResourceSampler(sampleInterval)
+	})
+
 	ticker := time.NewTicker(5 * time.Second)
 	w.closed.Set(closedFalse)
 	defer ticker.Stop()
+	// the runtime status is printed at its own interval, and not printed if the interval is not positive.
+	var printCh <-chan time.Time
+	if interval := w.cfg.StatusPrintInterval.Duration; interval > 0 {
+		printTicker := time.NewTicker(interval)
+		defer printTicker.Stop()
+		printCh = printTicker.C
+	}
 	for {
 		select {
 		case <-w.ctx.Done():
 			w.l.Info("status print process exits!")
 			return
-		case <-ticker.C:
+		case <-printCh:
 			w.l.Debug("runtime status", zap.String("status", w.StatusJSON(w.ctx, "")))
+		case <-ticker.C:
+			w.pauseLaggingSubTasks()
+			w.pauseServerIDCollisions()
+			w.updateMaintenanceMetric()
+			w.updateSubTaskStageMetric()
 		}
 	}
 }
@@ -166,17 +271,34 @@ func (w *Worker) Close() {
 		w.taskStatusChecker.Close()
 	}
 
+	// send all buffered audit events
+	w.auditor.close()
+
+	w.removeSubTaskStageMetric()
+
 	w.closed.Set(closedTrue)
 	w.l.Info("Stop worker")
 }
 
 // EnableRelay enables the functionality of start/watch/handle relay
-func (w *Worker) EnableRelay() error {
+// it does nothing if relay is already enabled, and the relay holder is cleaned up if it fails.
+func (w *Worker) EnableRelay() (err error) {
+	if w.relayHolder != nil {
+		log.L().Info("relay is already enabled")
+		return nil
+	}
+
 	// 1. adjust relay starting position, to the earliest of subtasks
 	_, subTaskCfgs, _, err := w.fetchSubTasksAndAdjust()
 	if err != nil {
 		return err
 	}
+	// the subtasks with mismatched flavor are left unadjusted and never run, don't let them move the position.
+	for name, subTaskCfg := range subTaskCfgs {
+		if subTaskCfg.Flavor != w.cfg.Flavor {
+			delete(subTaskCfgs, name)
+		}
+	}
 
 	dctx, dcancel := context.WithTimeout(w.etcdClient.Ctx(), time.Duration(len(subTaskCfgs))*3*time.Second)
 	defer dcancel()
@@ -202,6 +324,21 @@ func (w *Worker) EnableRelay() error {
 
 	// 2. initial relay holder, the cfg's password need decrypt
 	w.relayHolder = NewRelayHolder(w.cfg)
+	defer func() {
+		if err == nil {
+			return
+		}
+		// clean up the relay holder, so the relay can be enabled again.
+		if w.relayPurger != nil {
+			w.relayPurger.Close()
+			w.relayPurger = nil
+		}
+		w.relayHolder.Close()
+		w.relayHolder = nil
+	}()
+	if setter, ok := w.relayHolder.(dbProviderSetter); ok && w.dbProvider != nil {
+		setter.SetDBProvider(w.dbProvider)
+	}
 	relayPurger, err := w.relayHolder.Init([]purger.PurgeInterceptor{
 		w,
 	})
@@ -209,13 +346,19 @@ func (w *Worker) EnableRelay() error {
 		return err
 	}
 	w.relayPurger = relayPurger
+	cfgs := make([]*config.SubTaskConfig, 0, len(subTaskCfgs))
+	for name := range subTaskCfgs {
+		if cfg := subTaskCfgs[name]; cfg.UseRelay {
+			cfgs = append(cfgs, &cfg)
+		}
+	}
+	w.setRelayedTables(cfgs)
 
 	// 3. get relay stage from etcd and check if need starting
 	// we get the newest relay stages directly which will omit the relay stage PUT/DELETE event
 	// because triggering these events is useless now
-	relayStage, revRelay, err := ha.GetRelayStage(w.etcdClient, w.cfg.SourceID)
+	relayStage, revRelay, err := w.getRelayStage()
 	if err != nil {
-		// TODO: need retry
 		return err
 	}
 	startImmediately := !relayStage.IsDeleted && relayStage.Expect == pb.Stage_Running
@@ -226,16 +369,37 @@ func (w *Worker) EnableRelay() error {
 	}
 
 	// 4. watch relay stage
+	w.goroutines.expect(goroutineRelayObserver, 1)
+	w.goroutines.expect(goroutineRelayWatcher, 1)
 	w.wg.Add(1)
-	go func() {
+	w.goroutines.run(goroutineRelayObserver, func() {
 		defer w.wg.Done()
 		// TODO: handle fatal error from observeRelayStage
 		//nolint:errcheck
-		w.observeRelayStage(w.ctx, w.etcdClient, revRelay)
-	}()
+		w.observeRelayStage(w.ctx, w.etcdWatchClient, revRelay)
+	})
 	return nil
 }
 
+// getRelayStage gets the relay stage of the source from etcd, it retries with backoff if the error is retryable.
+func (w *Worker) getRelayStage() (ha.Stage, int64, error) {
+	// the arguments are valid, so no error will be returned.
+	bf, _ := backoff.NewBackoff(retryConnectBackoffFactor, false, retryConnectSleepTime, retryConnectMaxSleepTime)
+	for retryNum := 1; ; retryNum++ {
+		stage, rev, err := ha.GetRelayStage(w.etcdClient, w.cfg.SourceID)
+		if err == nil || !etcdutil.IsRetryableError(err) || retryNum >= retryGetRelayStage {
+			return stage, rev, err
+		}
+		log.L().Warn("fail to get relay stage from etcd, will retry later", zap.Error(err), zap.Int("retryNum", retryNum))
+		select {
+		case <-w.ctx.Done():
+			return stage, rev, err
+		case <-time.After(bf.Current()):
+			bf.BoundaryForward()
+		}
+	}
+}
+
 // EnableHandleSubtasks enables the functionality of start/watch/handle subtasks
 func (w *Worker) EnableHandleSubtasks() error {
 	subTaskStages, subTaskCfgM, revSubTask, err := w.fetchSubTasksAndAdjust()
@@ -243,7 +407,12 @@ func (w *Worker) EnableHandleSubtasks() error {
 		return err
 	}
 
-	log.L().Info("starting to handle mysql source", zap.String("sourceCfg", w.cfg.String()), zap.Any("subTasks", subTaskCfgM))
+	// use `String` of the config rather than reflect it, so the values can be masked by the redaction level.
+	subTaskCfgStrs := make(map[string]string, len(subTaskCfgM))
+	for name, subTaskCfg := range subTaskCfgM {
+		subTaskCfgStrs[name] = subTaskCfg.String()
+	}
+	log.L().Info("starting to handle mysql source", zap.String("sourceCfg", w.cfg.String()), zap.Any("subTasks", subTaskCfgStrs))
 
 	for _, subTaskCfg := range subTaskCfgM {
 		expectStage := subTaskStages[subTaskCfg.Name]
@@ -258,13 +427,15 @@ func (w *Worker) EnableHandleSubtasks() error {
 		}
 	}
 
+	w.goroutines.expect(goroutineSubTaskObserver, 1)
+	w.goroutines.expect(goroutineSubTaskWatcher, 1)
 	w.wg.Add(1)
-	go func() {
+	w.goroutines.run(goroutineSubTaskObserver, func() {
 		defer w.wg.Done()
 		// TODO: handle fatal error from observeSubtaskStage
 		//nolint:errcheck
-		w.observeSubtaskStage(w.ctx, w.etcdClient, revSubTask)
-	}()
+		w.observeSubtaskStage(w.ctx, w.etcdWatchClient, revSubTask)
+	})
 
 	return nil
 }
@@ -287,20 +458,41 @@ func (w *Worker) fetchSubTasksAndAdjust() (map[string]ha.Stage, map[string]confi
 }
 
 // StartSubTask creates a sub task an run it
-func (w *Worker) StartSubTask(cfg *config.SubTaskConfig, expectStage pb.Stage) error {
+func (w *Worker) StartSubTask(cfg *config.SubTaskConfig, expectStage pb.Stage) (err error) {
 	w.Lock()
 	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "StartSubTask", auditArgs(map[string]interface{}{"config": cfg, "stage": expectStage.String()}), err)
+	}()
+
+	if !isStartableSubTaskStage(expectStage) {
+		return terror.ErrWorkerInvalidSubTaskStage.Generate(cfg.Name, expectStage)
+	}
 
 	// copy some config item from dm-worker's source config
-	err := copyConfigFromSource(cfg, w.cfg)
-	if err != nil {
+	err = copyConfigFromSource(cfg, w.cfg)
+	if terror.ErrWorkerFlavorMismatch.Equal(err) {
+		// only this subtask can't run on the source, record it as failed so others are not blocked.
+		st := NewSubTask(cfg, w.etcdClient)
+		w.subTaskHolder.recordSubTask(st)
+		st.fail(err)
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	deps := w.subTaskDependencies()
+	deps[cfg.Name] = cfg.Dependencies
+	if cycle := findDependencyCycle(cfg.Name, deps); len(cycle) > 0 {
+		return terror.ErrWorkerSubTaskDependencyCycle.Generate(cfg.Name, strings.Join(cycle, " -> "))
+	}
+
 	// directly put cfg into subTaskHolder
 	// the unique of subtask should be assured by etcd
+	w.checkDiscardedTables(cfg)
 	st := NewSubTask(cfg, w.etcdClient)
 	w.subTaskHolder.recordSubTask(st)
+	w.updateRelayedTables()
 	if w.closed.Get() == closedTrue {
 		st.fail(terror.ErrWorkerAlreadyClosed.Generate())
 		return nil
@@ -314,20 +506,139 @@ func (w *Worker) StartSubTask(cfg *config.SubTaskConfig, expectStage pb.Stage) e
 	st.cfg = cfg2
 
 	if w.relayPurger != nil && w.relayPurger.Purging() {
-		// TODO: retry until purged finished
-		st.fail(terror.ErrWorkerRelayIsPurging.Generate(cfg.Name))
-		return nil
+		w.l.Info("relay log is purging, wait for it before starting the subtask", zap.String("task", cfg.Name))
+		purged := w.waitRelayPurged(w.relayPurger)
+		switch {
+		case w.subTaskHolder.findSubTask(cfg.Name) != st:
+			// the subtask is stopped or replaced when waiting.
+			w.l.Info("subtask is removed when waiting for relay log purged", zap.String("task", cfg.Name))
+			return nil
+		case w.closed.Get() == closedTrue:
+			st.fail(terror.ErrWorkerAlreadyClosed.Generate())
+			return nil
+		case !purged:
+			st.fail(terror.ErrWorkerRelayIsPurging.Generate(cfg.Name))
+			return nil
+		}
 	}
 
 	w.l.Info("subtask created", zap.Stringer("config", cfg2))
+	st.SetReadOnly(w.readOnly.Get())
+	st.setDBProvider(w.dbProvider)
+	st.setErrorClassifiers(w.errClassifiers)
+	st.setResourceShare(w.resourceShare(st))
 	st.Run(expectStage)
 	return nil
 }
 
+// isStartableSubTaskStage returns whether a subtask could be started in the stage. a subtask started in Finished
+// stage initializes its units, e.g. the sync unit loads its checkpoint, but doesn't process them, which is used by
+// the verification-only tasks.
+func isStartableSubTaskStage(stage pb.Stage) bool {
+	switch stage {
+	case pb.Stage_Running, pb.Stage_Paused, pb.Stage_Finished:
+		return true
+	default:
+		return false
+	}
+}
+
+// defaultRelayPurgeWaitTimeout is the default max time to wait for the relay purging finished when starting a subtask.
+const defaultRelayPurgeWaitTimeout = 30 * time.Second
+
+// waitRelayPurgedInterval is the interval to check whether the relay purging is finished.
+var waitRelayPurgedInterval = time.Second
+
+// waitRelayPurged waits until the purging of relay log is finished or the wait timeout is reached, it returns whether
+// the purging is finished. the lock of the worker should be held, and it's released when waiting so other operations
+// aren't blocked, the caller should check the state of the worker again after it returns.
+func (w *Worker) waitRelayPurged(p purger.Purger) bool {
+	timeout := w.relayPurgeWaitTimeout.Get()
+	if timeout <= 0 {
+		return false
+	}
+
+	w.Unlock()
+	defer w.Lock()
+	ctx, cancel := context.WithTimeout(w.ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(waitRelayPurgedInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return !p.Purging()
+		case <-ticker.C:
+			if !p.Purging() {
+				return true
+			}
+		}
+	}
+}
+
+// SetRelayPurgeWaitTimeout sets the max time to wait for the purging of relay log finished when starting a subtask,
+// the subtask fails if the purging is not finished in time. a non-positive d means failing the subtask at once.
+func (w *Worker) SetRelayPurgeWaitTimeout(d time.Duration) {
+	w.relayPurgeWaitTimeout.Set(d)
+}
+
+// GetSubTaskDependencies returns the declared dependencies of all subtasks.
+// return map{task name -> names of depended tasks}.
+func (w *Worker) GetSubTaskDependencies() map[string][]string {
+	w.RLock()
+	defer w.RUnlock()
+	return w.subTaskDependencies()
+}
+
+func (w *Worker) subTaskDependencies() map[string][]string {
+	sts := w.subTaskHolder.getAllSubTasks()
+	deps := make(map[string][]string, len(sts))
+	for name, st := range sts {
+		deps[name] = append([]string{}, st.cfg.Dependencies...)
+	}
+	return deps
+}
+
+// findDependencyCycle finds a dependency cycle which starts from `name`,
+// returns the task names in the cycle (the first one and the last one are both `name`), or nil if no cycle found.
+func findDependencyCycle(name string, deps map[string][]string) []string {
+	var (
+		visited = make(map[string]bool)
+		path    []string
+		dfs     func(curr string) bool
+	)
+	dfs = func(curr string) bool {
+		path = append(path, curr)
+		for _, dep := range deps[curr] {
+			if dep == name {
+				path = append(path, dep)
+				return true
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if dfs(dep) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if dfs(name) {
+		return path
+	}
+	return nil
+}
+
 // UpdateSubTask update config for a sub task
-func (w *Worker) UpdateSubTask(cfg *config.SubTaskConfig) error {
+func (w *Worker) UpdateSubTask(cfg *config.SubTaskConfig) (err error) {
 	w.Lock()
 	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "UpdateSubTask", auditArgs(map[string]interface{}{"config": cfg}), err)
+	}()
 
 	if w.closed.Get() == closedTrue {
 		return terror.ErrWorkerAlreadyClosed.Generate()
@@ -339,32 +650,178 @@ func (w *Worker) UpdateSubTask(cfg *config.SubTaskConfig) error {
 	}
 
 	w.l.Info("update sub task", zap.String("task", cfg.Name))
-	return st.Update(cfg)
+	if err = st.Update(cfg); err != nil {
+		return err
+	}
+	w.updateRelayedTables()
+	return nil
 }
 
 // OperateSubTask stop/resume/pause  sub task
-func (w *Worker) OperateSubTask(name string, op pb.TaskOp) error {
+func (w *Worker) OperateSubTask(name string, op pb.TaskOp) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "OperateSubTask", auditArgs(map[string]interface{}{"task": name, "op": op.String()}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	return w.operateSubTask(name, op)
+}
+
+// OperateSubTasks operates the subtasks in one go with the lock of the worker acquired once, all subtasks held by
+// the worker are operated if names is empty. it returns the errors of the subtasks failed to be operated keyed by
+// their names, so the operation may be partially done.
+func (w *Worker) OperateSubTasks(names []string, op pb.TaskOp) map[string]error {
+	w.Lock()
+	defer w.Unlock()
+
+	if len(names) == 0 {
+		for name := range w.subTaskHolder.getAllSubTasks() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	return w.operateSubTasks(names, op)
+}
+
+// operateSubTasks operates the subtasks one by one like OperateSubTasks, the lock of the worker should be held.
+func (w *Worker) operateSubTasks(names []string, op pb.TaskOp) map[string]error {
+	errs := make(map[string]error)
+	for _, name := range names {
+		var err error
+		if w.closed.Get() == closedTrue {
+			err = terror.ErrWorkerAlreadyClosed.Generate()
+		} else {
+			err = w.operateSubTask(name, op)
+		}
+		w.auditor.emit(context.Background(), "OperateSubTask", auditArgs(map[string]interface{}{"task": name, "op": op.String()}), err)
+		if err != nil {
+			opErrCounter.WithLabelValues(w.name, op.String()).Inc()
+			w.l.Error("fail to operate sub task", zap.String("task", name), zap.Stringer("op", op), zap.Error(err))
+			errs[name] = err
+		}
+	}
+	return errs
+}
+
+// PauseAll pauses all running subtasks of the worker by operateSubTasks, the subtasks already paused are skipped. the
+// subtasks in other stages, e.g. not started or finished, are reported in the log but not treated as failures. it
+// returns an error of all subtasks failed to pause after trying the others.
+func (w *Worker) PauseAll(ctx context.Context) error {
+	return w.operateAll(ctx, pb.TaskOp_Pause, pb.Stage_Running, pb.Stage_Paused)
+}
+
+// ResumeAll resumes all paused subtasks of the worker like PauseAll, the subtasks already running are skipped.
+func (w *Worker) ResumeAll(ctx context.Context) error {
+	return w.operateAll(ctx, pb.TaskOp_Resume, pb.Stage_Paused, pb.Stage_Running)
+}
+
+// operateAll operates all subtasks in fromStage to toStage, the subtasks are selected and operated with the lock of
+// the worker held, so none of them is stopped in between.
+func (w *Worker) operateAll(ctx context.Context, op pb.TaskOp, fromStage, toStage pb.Stage) (err error) {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
 	w.Lock()
 	defer w.Unlock()
 
+	var (
+		operated   []string
+		skipped    []string
+		unexpected = make(map[string]string) // task name -> stage
+		failed     = make(map[string]string) // task name -> error message
+	)
+	defer func() {
+		w.auditor.emit(ctx, op.String()+"All", auditArgs(map[string]interface{}{"operated": operated, "failed": failed}), err)
+	}()
+
 	if w.closed.Get() == closedTrue {
 		return terror.ErrWorkerAlreadyClosed.Generate()
 	}
 
+	var names []string
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		switch stage := st.Stage(); stage {
+		case toStage:
+			skipped = append(skipped, name)
+		case fromStage:
+			names = append(names, name)
+		default:
+			unexpected[name] = stage.String()
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(skipped)
+
+	errs := w.operateSubTasks(names, op)
+	for _, name := range names {
+		if err2, ok := errs[name]; ok {
+			failed[name] = err2.Error()
+		} else {
+			operated = append(operated, name)
+		}
+	}
+
+	w.l.Info("operate all sub tasks", zap.Stringer("op", op), zap.Strings("operated", operated),
+		zap.Strings("skipped as already "+toStage.String(), skipped), zap.Any("skipped in other stages", unexpected))
+	if len(failed) > 0 {
+		return terror.ErrWorkerOperateAllFailed.Generate(op, failed)
+	}
+	return nil
+}
+
+// operateSubTask operates the subtask with its hooks, the lock of the worker should be held.
+func (w *Worker) operateSubTask(name string, op pb.TaskOp) error {
+	_, err := w.operateSubTaskWithOptions(name, op, subTaskOpOptions{})
+	return err
+}
+
+// subTaskOpOptions are the options of operating a subtask, they're only used by pausing now.
+type subTaskOpOptions struct {
+	// pause at the transaction boundary, see PauseSubTask
+	atTxnBoundary bool
+	// timeout to wait for the transaction boundary
+	txnBoundaryTimeout time.Duration
+}
+
+// operateSubTaskWithOptions operates the subtask with its hooks like operateSubTask, and returns the pause style
+// actually used if pausing. the lock of the worker should be held, and the read lock is enough for pausing.
+func (w *Worker) operateSubTaskWithOptions(name string, op pb.TaskOp, opts subTaskOpOptions) (style PauseStyle, err error) {
+	style = PauseImmediately
 	st := w.subTaskHolder.findSubTask(name)
 	if st == nil {
-		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+		return style, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	hook := w.subTaskOpHooks.get(name)
+	if err = runSubTaskOpHook(hook.pre, "pre", name, op); err != nil {
+		return style, err
 	}
 
-	var err error
 	switch op {
 	case pb.TaskOp_Stop:
 		w.l.Info("stop sub task", zap.String("task", name))
 		st.Close()
 		w.subTaskHolder.removeSubTask(name)
+		delete(w.partialRelayWarnings, name)
+		w.updateRelayedTables()
 	case pb.TaskOp_Pause:
-		w.l.Info("pause sub task", zap.String("task", name))
-		err = st.Pause()
+		if !opts.atTxnBoundary {
+			w.l.Info("pause sub task", zap.String("task", name))
+			err = st.Pause()
+			break
+		}
+		w.l.Info("pause sub task at transaction boundary", zap.String("task", name), zap.Duration("timeout", opts.txnBoundaryTimeout))
+		var paused bool
+		paused, err = st.PauseAtTxnBoundary(opts.txnBoundaryTimeout)
+		if paused {
+			style = PauseAtTxnBoundary
+		}
 	case pb.TaskOp_Resume:
 		w.l.Info("resume sub task", zap.String("task", name))
 		err = st.Resume()
@@ -375,7 +832,154 @@ func (w *Worker) OperateSubTask(name string, op pb.TaskOp) error {
 		err = terror.ErrWorkerUpdateTaskStage.Generatef("invalid operate %s on subtask %v", op, name)
 	}
 
-	return err
+	if err == nil {
+		// the operation is done already, so only log the error of post-hook.
+		if err2 := runSubTaskOpHook(hook.post, "post", name, op); err2 != nil {
+			w.l.Warn("fail to run post-hook of operating sub task", zap.String("task", name), zap.Stringer("op", op), zap.Error(err2))
+		}
+	}
+	return style, err
+}
+
+// RegisterSubTaskOpHook registers hooks which are called before and after operating the subtask by OperateSubTask,
+// e.g. to coordinate with applications before pausing or resuming. a failed pre-hook aborts the operation, and the
+// post-hook is called only if the operation succeeded. hooks not finished in the timeout are treated as failed.
+// the hooks registered before for the subtask are replaced, and are removed if both pre and post are nil.
+func (w *Worker) RegisterSubTaskOpHook(name string, pre, post SubTaskOpHook) error {
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	w.subTaskOpHooks.set(name, pre, post)
+	w.l.Info("register operation hooks of sub task", zap.String("task", name), zap.Bool("pre", pre != nil), zap.Bool("post", post != nil))
+	return nil
+}
+
+// RegisterSubTaskStageObserver registers an observer which is called after a subtask transitions stages by the
+// expected stage in etcd, e.g. to record the transitions in an audit log. multiple observers are called in the order
+// of registration without holding the lock of the worker, and they should return quickly because the following
+// stage changes are handled after they return.
+func (w *Worker) RegisterSubTaskStageObserver(observer SubTaskStageObserver) {
+	if observer == nil {
+		return
+	}
+	w.subTaskStageObservers.add(observer)
+}
+
+// PauseStyle represents how a subtask is paused.
+type PauseStyle string
+
+// pause styles.
+const (
+	// PauseImmediately stops the subtask at once, it may stop in the middle of a transaction,
+	// and events after the checkpoint are replayed in safe mode after resumed.
+	PauseImmediately PauseStyle = "immediately"
+	// PauseAtTxnBoundary stops the subtask after the current transaction finished and the checkpoint flushed.
+	PauseAtTxnBoundary PauseStyle = "transaction-boundary"
+)
+
+// defaultPauseAtTxnBoundaryTimeout is the default timeout to wait for the current transaction finished when pausing.
+var defaultPauseAtTxnBoundaryTimeout = 30 * time.Second
+
+// PauseSubTask pauses the subtask, if atTxnBoundary is true, it waits for the sync unit to finish the current
+// transaction and pauses at the boundary, and falls back to pause immediately if it can't in timeout (use the
+// default timeout if 0). it returns the pause style actually used. the hooks of the subtask are called like
+// OperateSubTask.
+func (w *Worker) PauseSubTask(name string, atTxnBoundary bool, timeout time.Duration) (style PauseStyle, err error) {
+	style = PauseImmediately
+	defer func() {
+		w.auditor.emit(context.Background(), "PauseSubTask", auditArgs(map[string]interface{}{
+			"task": name, "at-txn-boundary": atTxnBoundary, "style": style,
+		}), err)
+	}()
+
+	// only hold the read lock, so other readers are not blocked when waiting for the transaction boundary.
+	w.RLock()
+	defer w.RUnlock()
+	if w.closed.Get() == closedTrue {
+		return style, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	if atTxnBoundary && timeout == 0 {
+		timeout = defaultPauseAtTxnBoundaryTimeout
+	}
+	return w.operateSubTaskWithOptions(name, pb.TaskOp_Pause, subTaskOpOptions{
+		atTxnBoundary:      atTxnBoundary,
+		txnBoundaryTimeout: timeout,
+	})
+}
+
+// PauseSyncKeepRelay pauses the sync of the subtask using relay, writes to downstream are held but the subtask keeps
+// running and relay keeps reading binlog events from upstream, so the buffered events are applied immediately after
+// ResumeSync, without reconnecting to upstream and catching up. it's useful for downstream maintenance.
+func (w *Worker) PauseSyncKeepRelay(name string) (err error) {
+	defer func() {
+		w.auditor.emit(context.Background(), "PauseSyncKeepRelay", auditArgs(map[string]interface{}{"task": name}), err)
+	}()
+
+	w.RLock()
+	defer w.RUnlock()
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	if !st.cfg.UseRelay {
+		return terror.ErrWorkerRelayDisabled.Generate()
+	}
+
+	w.l.Info("pause sync of sub task and keep relay reading", zap.String("task", name))
+	return st.PauseSync()
+}
+
+// ResumeSync resumes the sync of the subtask paused by PauseSyncKeepRelay.
+func (w *Worker) ResumeSync(name string) (err error) {
+	defer func() {
+		w.auditor.emit(context.Background(), "ResumeSync", auditArgs(map[string]interface{}{"task": name}), err)
+	}()
+
+	w.RLock()
+	defer w.RUnlock()
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	w.l.Info("resume sync of sub task", zap.String("task", name))
+	return st.ResumeSync()
+}
+
+// FastForwardSubTask advances the checkpoint of the paused subtask to the current location of upstream master and
+// resumes it. events between the checkpoint and the location are SKIPPED, so it should only be used when the data of
+// these events has been restored to downstream in other ways, e.g. recovering from a catastrophic lag by re-dumping.
+func (w *Worker) FastForwardSubTask(name string) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "FastForwardSubTask", auditArgs(map[string]interface{}{"task": name}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, utils.DefaultDBTimeout)
+	defer cancel()
+	from, to, err := st.FastForward(ctx)
+	if err != nil {
+		return err
+	}
+	w.l.Warn("sub task is fast-forwarded, events between the locations are SKIPPED", zap.String("task", name), zap.Stringer("from", from), zap.Stringer("to", to))
+	return st.Resume()
 }
 
 // QueryStatus query worker's sub tasks' status
@@ -388,7 +992,8 @@ func (w *Worker) QueryStatus(ctx context.Context, name string) []*pb.SubTaskStat
 		return nil
 	}
 
-	// use one timeout for all tasks. increase this value if it's too short.
+	// use one timeout for all tasks, and each task gets its own share of it, see `SetStatusTaskTimeout`.
+	// increase this value if it's too short.
 	ctx2, cancel2 := context.WithTimeout(ctx, utils.DefaultDBTimeout)
 	defer cancel2()
 	return w.Status(ctx2, name)
@@ -399,52 +1004,143 @@ func (w *Worker) resetSubtaskStage() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	w.reconcileSubTaskStages(subTaskStages, subTaskCfgm)
+	return revSubTask, nil
+}
+
+// reconcileSubTaskStages brings the local subtasks in line with the subtask stages and configs fetched from etcd,
+// subtasks without subtask config or subtask stage are stopped. the subtasks already in the expected stage are not
+// operated. it goes on with other subtasks after failing to operate one, and returns the first error.
+func (w *Worker) reconcileSubTaskStages(subTaskStages map[string]ha.Stage, subTaskCfgm map[string]config.SubTaskConfig) (*ReconcileResult, error) {
+	var (
+		result   = &ReconcileResult{OperatedSubTasks: make(map[string]string)}
+		firstErr error
+	)
 	// use sts to check which subtask has no subtaskCfg or subtaskStage now
 	sts := w.subTaskHolder.getAllSubTasks()
 	for name, subtaskCfg := range subTaskCfgm {
 		stage, ok := subTaskStages[name]
-		if ok {
-			// TODO: right operation sequences may get error when we get etcdErrCompact, need to handle it later
-			// For example, Expect: Running -(pause)-> Paused -(resume)-> Running
-			// we get an etcd compact error at the first running. If we try to "resume" it now, we will get an error
-			opType, err2 := w.operateSubTaskStage(stage, subtaskCfg)
-			if err2 != nil {
-				opErrCounter.WithLabelValues(w.name, opType).Inc()
-				log.L().Error("fail to operate subtask stage", zap.Stringer("stage", stage),
-					zap.String("task", subtaskCfg.Name), zap.Error(err2))
-
+		if !ok {
+			continue
+		}
+		st, exist := sts[name]
+		delete(sts, name)
+		if exist && st.Stage() == stage.Expect {
+			continue
+		}
+		// TODO: right operation sequences may get error when we get etcdErrCompact, need to handle it later
+		// For example, Expect: Running -(pause)-> Paused -(resume)-> Running
+		// we get an etcd compact error at the first running. If we try to "resume" it now, we will get an error
+		w.l.Info("reconcile subtask stage", zap.String("task", name), zap.Stringer("stage", stage.Expect), zap.Bool("exist", exist))
+		opType, err := w.operateSubTaskStage(stage, subtaskCfg)
+		if err != nil {
+			opErrCounter.WithLabelValues(w.name, opType).Inc()
+			log.L().Error("fail to operate subtask stage", zap.Stringer("stage", stage),
+				zap.String("task", subtaskCfg.Name), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
 			}
-			delete(sts, name)
+			continue
+		}
+		if exist {
+			result.OperatedSubTasks[name] = opType
+		} else {
+			result.StartedSubTasks = append(result.StartedSubTasks, name)
 		}
 	}
 	// remove subtasks without subtask config or subtask stage
 	for name := range sts {
-		err = w.OperateSubTask(name, pb.TaskOp_Stop)
+		err := w.OperateSubTask(name, pb.TaskOp_Stop)
 		if err != nil {
 			opErrCounter.WithLabelValues(w.name, pb.TaskOp_Stop.String()).Inc()
 			log.L().Error("fail to stop subtask", zap.String("task", name), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
+		result.StoppedSubTasks = append(result.StoppedSubTasks, name)
 	}
-	return revSubTask, nil
+	sort.Strings(result.StartedSubTasks)
+	sort.Strings(result.StoppedSubTasks)
+	return result, firstErr
 }
 
-func (w *Worker) observeSubtaskStage(ctx context.Context, etcdCli *clientv3.Client, rev int64) error {
-	var wg sync.WaitGroup
+// ReconcileResult represents the corrections made by Reconcile.
+type ReconcileResult struct {
+	StartedSubTasks  []string          `json:"started-subtasks"`  // subtasks created because they exist in etcd only
+	StoppedSubTasks  []string          `json:"stopped-subtasks"`  // subtasks stopped because they have no stage or config in etcd
+	OperatedSubTasks map[string]string `json:"operated-subtasks"` // map{task name -> operation applied to fix the stage}
+	RelayOp          string            `json:"relay-op"`          // operation applied to fix the relay stage as labeled in metrics, empty if not changed
+}
 
-	for {
+// Reconcile re-reads subtask and relay stages from etcd and brings the local state in line with them,
+// it's like what we do after the watch of stages failed, but can be triggered manually when the state drifts.
+func (w *Worker) Reconcile(ctx context.Context) (*ReconcileResult, error) {
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	subTaskStages, subTaskCfgM, _, err := w.fetchSubTasksAndAdjust()
+	if err != nil {
+		return nil, err
+	}
+	result, err := w.reconcileSubTaskStages(subTaskStages, subTaskCfgM)
+	if err != nil {
+		return result, err
+	}
+
+	w.RLock()
+	defer w.RUnlock()
+	if w.relayHolder == nil {
+		return result, nil
+	}
+	relayStage, _, err := ha.GetRelayStage(w.etcdClient, w.cfg.SourceID)
+	if err != nil {
+		return result, err
+	}
+	if relayStage.IsEmpty() {
+		relayStage.IsDeleted = true
+	}
+	curr := w.relayHolder.Stage()
+	var needFix bool
+	switch {
+	case relayStage.IsDeleted:
+		needFix = curr != pb.Stage_Stopped
+	case relayStage.Expect == pb.Stage_Running:
+		needFix = curr == pb.Stage_New || curr == pb.Stage_Paused
+	case relayStage.Expect == pb.Stage_Paused:
+		needFix = curr == pb.Stage_Running
+	}
+	if !needFix {
+		return result, nil
+	}
+	w.l.Info("reconcile: operate relay", zap.Stringer("stage", relayStage), zap.Stringer("current stage", curr))
+	op, err := w.operateRelayStage(ctx, relayStage)
+	if err != nil {
+		return result, err
+	}
+	result.RelayOp = op
+	return result, nil
+}
+
+func (w *Worker) observeSubtaskStage(ctx context.Context, etcdCli *clientv3.Client, rev int64) error {
+	var wg sync.WaitGroup
+
+	for {
 		subTaskStageCh := make(chan ha.Stage, 10)
 		subTaskErrCh := make(chan error, 10)
 		wg.Add(1)
 		// use ctx1, cancel1 to make sure old watcher has been released
 		ctx1, cancel1 := context.WithCancel(ctx)
-		go func() {
+		w.goroutines.run(goroutineSubTaskWatcher, func() {
 			defer func() {
 				close(subTaskStageCh)
 				close(subTaskErrCh)
 				wg.Done()
 			}()
 			ha.WatchSubTaskStage(ctx1, etcdCli, w.cfg.SourceID, rev+1, subTaskStageCh, subTaskErrCh)
-		}()
+		})
 		err := w.handleSubTaskStage(ctx1, subTaskStageCh, subTaskErrCh)
 		cancel1()
 		wg.Wait()
@@ -477,10 +1173,14 @@ func (w *Worker) observeSubtaskStage(ctx context.Context, etcdCli *clientv3.Clie
 
 func (w *Worker) handleSubTaskStage(ctx context.Context, stageCh chan ha.Stage, errCh chan error) error {
 	closed := false
+	disconnected := w.watchFaults.disconnectedCh()
 	for {
 		select {
 		case <-ctx.Done():
 			closed = true
+		case <-disconnected:
+			log.L().Warn("WatchSubTaskStage is disconnected by injected fault")
+			return terror.ErrWorkerInjectedFault.Delegate(v3rpc.ErrNoLeader, "etcd watch disconnected")
 		case stage, ok := <-stageCh:
 			if !ok {
 				closed = true
@@ -514,23 +1214,55 @@ func (w *Worker) handleSubTaskStage(ctx context.Context, stageCh chan ha.Stage,
 }
 
 // operateSubTaskStage returns TaskOp.String() additionally to record metrics
-func (w *Worker) operateSubTaskStage(stage ha.Stage, subTaskCfg config.SubTaskConfig) (string, error) {
+func (w *Worker) operateSubTaskStage(stage ha.Stage, subTaskCfg config.SubTaskConfig) (opType string, err error) {
+	from := pb.Stage_InvalidStage
+	if st := w.subTaskHolder.findSubTask(stage.Task); st != nil {
+		from = st.Stage()
+	}
+	defer func() {
+		if err != nil {
+			return
+		}
+		// the lock of the worker is not held here, so the observers can call methods of the worker.
+		to := pb.Stage_Stopped
+		if st := w.subTaskHolder.findSubTask(stage.Task); st != nil {
+			to = st.Stage()
+		}
+		if from != to {
+			w.subTaskStageObservers.notify(stage.Task, from, to, time.Now())
+		}
+	}()
+
 	var op pb.TaskOp
 	switch {
-	case stage.Expect == pb.Stage_Running, stage.Expect == pb.Stage_Paused:
-		if st := w.subTaskHolder.findSubTask(stage.Task); st == nil {
-			// create the subtask for expected running and paused stage.
+	case isStartableSubTaskStage(stage.Expect):
+		st := w.subTaskHolder.findSubTask(stage.Task)
+		if st == nil {
+			// create the subtask for expected running, paused and finished stage.
 			log.L().Info("start to create subtask", zap.String("sourceID", subTaskCfg.SourceID), zap.String("task", subTaskCfg.Name))
 			err := w.StartSubTask(&subTaskCfg, stage.Expect)
 			return opErrTypeBeforeOp, err
 		}
-		if stage.Expect == pb.Stage_Running {
+		switch stage.Expect {
+		case pb.Stage_Running:
 			op = pb.TaskOp_Resume
-		} else if stage.Expect == pb.Stage_Paused {
+		case pb.Stage_Paused:
+			// the subtask may be paused by the worker itself already, e.g. for lagging too much.
+			if st.Stage() == pb.Stage_Paused {
+				return pb.TaskOp_Pause.String(), nil
+			}
 			op = pb.TaskOp_Pause
+		default:
+			// a started subtask can't be operated to finished.
+			if st.Stage() == pb.Stage_Finished {
+				return op.String(), nil
+			}
+			return opErrTypeBeforeOp, terror.ErrWorkerInvalidSubTaskStage.Generate(stage.Task, stage.Expect)
 		}
 	case stage.IsDeleted:
 		op = pb.TaskOp_Stop
+	default:
+		return opErrTypeBeforeOp, terror.ErrWorkerInvalidSubTaskStage.Generate(stage.Task, stage.Expect)
 	}
 	return op.String(), w.OperateSubTask(stage.Task, op)
 }
@@ -538,7 +1270,7 @@ func (w *Worker) operateSubTaskStage(stage ha.Stage, subTaskCfg config.SubTaskCo
 // operateSubTaskStageWithoutConfig returns TaskOp additionally to record metrics
 func (w *Worker) operateSubTaskStageWithoutConfig(stage ha.Stage) (string, error) {
 	var subTaskCfg config.SubTaskConfig
-	if stage.Expect == pb.Stage_Running {
+	if stage.Expect == pb.Stage_Running || stage.Expect == pb.Stage_Finished {
 		if st := w.subTaskHolder.findSubTask(stage.Task); st == nil {
 			tsm, _, err := ha.GetSubTaskCfg(w.etcdClient, stage.Source, stage.Task, stage.Revision)
 			if err != nil {
@@ -562,14 +1294,14 @@ func (w *Worker) observeRelayStage(ctx context.Context, etcdCli *clientv3.Client
 		wg.Add(1)
 		// use ctx1, cancel1 to make sure old watcher has been released
 		ctx1, cancel1 := context.WithCancel(ctx)
-		go func() {
+		w.goroutines.run(goroutineRelayWatcher, func() {
 			defer func() {
 				close(relayStageCh)
 				close(relayErrCh)
 				wg.Done()
 			}()
 			ha.WatchRelayStage(ctx1, etcdCli, w.cfg.SourceID, rev+1, relayStageCh, relayErrCh)
-		}()
+		})
 		err := w.handleRelayStage(ctx1, relayStageCh, relayErrCh)
 		cancel1()
 		wg.Wait()
@@ -582,7 +1314,7 @@ func (w *Worker) observeRelayStage(ctx context.Context, etcdCli *clientv3.Client
 				case <-ctx.Done():
 					return nil
 				case <-time.After(500 * time.Millisecond):
-					stage, rev1, err1 := ha.GetRelayStage(etcdCli, w.cfg.SourceID)
+					stage, rev1, err1 := ha.GetRelayStage(w.etcdClient, w.cfg.SourceID)
 					if err1 != nil {
 						log.L().Error("get source bound from etcd failed, will retry later", zap.Error(err1), zap.Int("retryNum", retryNum))
 						break
@@ -611,11 +1343,15 @@ func (w *Worker) observeRelayStage(ctx context.Context, etcdCli *clientv3.Client
 }
 
 func (w *Worker) handleRelayStage(ctx context.Context, stageCh chan ha.Stage, errCh chan error) error {
+	disconnected := w.watchFaults.disconnectedCh()
 OUTER:
 	for {
 		select {
 		case <-ctx.Done():
 			break OUTER
+		case <-disconnected:
+			log.L().Warn("WatchRelayStage is disconnected by injected fault")
+			return terror.ErrWorkerInjectedFault.Delegate(v3rpc.ErrNoLeader, "etcd watch disconnected")
 		case stage, ok := <-stageCh:
 			if !ok {
 				break OUTER
@@ -660,6 +1396,17 @@ func (w *Worker) operateRelayStage(ctx context.Context, stage ha.Stage) (string,
 	return op.String(), w.operateRelay(ctx, op)
 }
 
+// RelayStage returns the stage of the relay unit, it returns `pb.Stage_InvalidStage` if relay is not enabled.
+func (w *Worker) RelayStage() pb.Stage {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.relayHolder == nil {
+		return pb.Stage_InvalidStage
+	}
+	return w.relayHolder.Stage()
+}
+
 // OperateRelay operates relay unit
 func (w *Worker) operateRelay(ctx context.Context, op pb.RelayOp) error {
 	if w.closed.Get() == closedTrue {
@@ -674,20 +1421,312 @@ func (w *Worker) operateRelay(ctx context.Context, op pb.RelayOp) error {
 	return nil
 }
 
-// PurgeRelay purges relay log files
-func (w *Worker) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest) error {
+// PurgeRelay purges relay log files. if req.DryRun is set, nothing is purged and the relay log files which would be
+// purged are returned in JSON format, so the effect of purging can be confirmed before doing it.
+// if req.GtidSet is set, the relay log files whose GTIDs are all contained in it are purged, and the purged files and
+// the earliest retained GTID set are returned in JSON format. it fails if any subtask is still reading the files.
+func (w *Worker) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest) (msg string, err error) {
+	defer func() {
+		w.auditor.emit(ctx, "PurgeRelay", auditArgs(req), err)
+	}()
+
+	// NOTE: the purging may take a long time, so don't hold the lock when doing it, StartSubTask will wait for it.
+	w.RLock()
+	relayPurger := w.relayPurger
+	flavor := w.cfg.Flavor
+	w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return "", terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	if relayPurger == nil {
+		w.l.Warn("enable-relay is false, ignore purge relay")
+		return "", nil
+	}
+
+	var result interface{}
+	switch {
+	case req.DryRun:
+		result, err = relayPurger.DryRun(ctx, req)
+	case len(req.GtidSet) > 0:
+		gs, err2 := gtid.ParserGTID(flavor, req.GtidSet)
+		if err2 != nil {
+			return "", err2
+		}
+		result, err = relayPurger.PurgeByGTID(ctx, gs)
+	default:
+		return "", relayPurger.Do(ctx, req)
+	}
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", terror.Annotate(err, "marshal result of purging relay log")
+	}
+	return string(data), nil
+}
+
+// RelayFileInfo represents information of a relay log file in the relay directory
+type RelayFileInfo struct {
+	Name       string    `json:"name"`
+	UUID       string    `json:"uuid"`        // name of the sub directory which contains this file
+	UUIDSuffix int       `json:"uuid-suffix"` // suffix of the sub directory, like `000001`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mod-time"`
+	Active     bool      `json:"active"`    // whether this file is being read by any subtask
+	Purgeable  bool      `json:"purgeable"` // whether this file is older than the earliest active relay log
+}
+
+// ListRelayFiles lists all relay log files in the relay directory,
+// with whether they are being read by subtasks and whether they can be purged.
+func (w *Worker) ListRelayFiles() ([]RelayFileInfo, error) {
+	w.RLock()
+	defer w.RUnlock()
+	return w.listRelayFiles()
+}
+
+// listRelayFiles lists the relay log files, the caller should hold the lock of the worker.
+func (w *Worker) listRelayFiles() ([]RelayFileInfo, error) {
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if w.relayHolder == nil {
+		return nil, terror.ErrWorkerRelayDisabled.Generate()
+	}
+
+	relayDir := w.cfg.RelayDir
+	uuids, err := utils.ParseUUIDIndex(filepath.Join(relayDir, utils.UUIDIndexFilename))
+	if err != nil {
+		return nil, terror.Annotatef(err, "parse UUID index file in %s", relayDir)
+	}
+
+	// files being read by subtasks
+	activeLogs := streamer.GetReaderHub().ActiveRelayLogs()
+	active := make(map[string]struct{}, len(activeLogs))
+	for _, info := range activeLogs {
+		active[info.String()] = struct{}{}
+	}
+
+	// same as the relay purger, files before the earliest one of the relay writer and readers can be purged.
+	var earliest *streamer.RelayLogInfo
+	operators := []purger.RelayOperator{streamer.GetReaderHub()}
+	if op, ok := w.relayHolder.(purger.RelayOperator); ok {
+		operators = append(operators, op)
+	}
+	for _, op := range operators {
+		info := op.EarliestActiveRelayLog()
+		if info != nil && (earliest == nil || info.Earlier(earliest)) {
+			earliest = info
+		}
+	}
+
+	files := make([]RelayFileInfo, 0, len(uuids))
+	for _, uuid := range uuids {
+		_, suffix, err := utils.ParseSuffixForUUID(uuid)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(relayDir, uuid)
+		if !utils.IsDirExists(dir) {
+			continue // already purged
+		}
+		names, err := streamer.CollectAllBinlogFiles(dir)
+		if err != nil {
+			return nil, terror.Annotatef(err, "dir %s", dir)
+		}
+		for _, name := range names {
+			fp := filepath.Join(dir, name)
+			fs, err := os.Stat(fp)
+			if err != nil {
+				return nil, terror.ErrGetRelayLogStat.Delegate(err, fp)
+			}
+			info := &streamer.RelayLogInfo{UUID: uuid, UUIDSuffix: suffix, Filename: name}
+			_, isActive := active[info.String()]
+			files = append(files, RelayFileInfo{
+				Name:       name,
+				UUID:       uuid,
+				UUIDSuffix: suffix,
+				Size:       fs.Size(),
+				ModTime:    fs.ModTime(),
+				Active:     isActive,
+				Purgeable:  earliest != nil && info.Earlier(earliest),
+			})
+		}
+	}
+	return files, nil
+}
+
+// RelayFiles lists the relay log files like ListRelayFiles, but returns an empty slice rather than an error if relay
+// is not enabled, so callers like the status API don't need to check whether relay is enabled first.
+func (w *Worker) RelayFiles(ctx context.Context) ([]RelayFileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	w.RLock()
+	defer w.RUnlock()
+
+	files, err := w.listRelayFiles()
+	if terror.ErrWorkerRelayDisabled.Equal(err) {
+		return []RelayFileInfo{}, nil
+	}
+	return files, err
+}
+
+// MoveRelayDir moves the relay log files to newDir and makes the relay unit and subtasks use it, so the relay storage
+// can be migrated without stopping the worker. the relay unit and subtasks using relay are paused while copying files,
+// and they are resumed with the old directory if failed.
+func (w *Worker) MoveRelayDir(ctx context.Context, newDir string) (err error) {
+	w.Lock()
+	defer w.Unlock()
+
+	oldDir := w.cfg.RelayDir
+	defer func() {
+		w.auditor.emit(ctx, "MoveRelayDir", auditArgs(map[string]interface{}{"old-dir": oldDir, "new-dir": newDir}), err)
+	}()
+
 	if w.closed.Get() == closedTrue {
 		return terror.ErrWorkerAlreadyClosed.Generate()
 	}
+	if w.relayHolder == nil {
+		return terror.ErrWorkerRelayDisabled.Generate()
+	}
+
+	if oldDir, err = filepath.Abs(oldDir); err != nil {
+		return terror.ErrWorkerInvalidRelayDir.Delegate(err, oldDir, "can't get the absolute path")
+	}
+	if newDir, err = filepath.Abs(newDir); err != nil {
+		return terror.ErrWorkerInvalidRelayDir.Delegate(err, newDir, "can't get the absolute path")
+	}
+	created, err := checkNewRelayDir(oldDir, newDir)
+	if err != nil {
+		return err
+	}
+	w.l.Info("moving relay directory", zap.String("old", oldDir), zap.String("new", newDir))
+
+	// pause the relay unit and subtasks reading relay log, so no relay log files are changed when copying.
+	var (
+		relaySubTasks  []*SubTask
+		pausedSubTasks []*SubTask
+		relayPaused    bool
+		purgerClosed   bool
+		purgerStarted  = w.relayHolder.Stage() != pb.Stage_New
+	)
+	defer func() {
+		if err != nil {
+			w.l.Error("fail to move relay directory, rollback", zap.String("old", oldDir), zap.String("new", newDir), zap.Error(err))
+			cleanRelayDir(newDir, created)
+		}
+		if purgerClosed {
+			w.resetRelayPurger(purgerStarted)
+		}
+		if relayPaused {
+			if err2 := w.relayHolder.Operate(ctx, pb.RelayOp_ResumeRelay); err2 != nil {
+				w.l.Error("fail to resume relay after moving relay directory", zap.Error(err2))
+			}
+		}
+		for _, st := range pausedSubTasks {
+			if err2 := st.Resume(); err2 != nil {
+				w.l.Error("fail to resume subtask after moving relay directory", zap.String("task", st.cfg.Name), zap.Error(err2))
+			}
+		}
+	}()
 
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		if !st.cfg.UseRelay {
+			continue
+		}
+		relaySubTasks = append(relaySubTasks, st)
+		if st.Stage() != pb.Stage_Running {
+			continue
+		}
+		if err = st.Pause(); err != nil {
+			return err
+		}
+		pausedSubTasks = append(pausedSubTasks, st)
+	}
+	if w.relayHolder.Stage() == pb.Stage_Running {
+		if err = w.relayHolder.Operate(ctx, pb.RelayOp_PauseRelay); err != nil {
+			return err
+		}
+		relayPaused = true
+	}
 	if w.relayPurger != nil {
-		return w.relayPurger.Do(ctx, req)
+		w.relayPurger.Close()
+		purgerClosed = true
+	}
+
+	if err = copyRelayDir(oldDir, newDir); err != nil {
+		return err
+	}
+
+	// persist the new relay directory before removing the old one, otherwise the worker uses the old directory which
+	// is empty after restarting. the old directory is kept if the new one can't be persisted.
+	persisted := false
+	if w.etcdClient != nil {
+		if err = w.persistRelayDir(newDir); err != nil {
+			return err
+		}
+		persisted = true
+	}
+
+	newCfg := w.cfg.Clone()
+	newCfg.RelayDir = newDir
+	if err = w.relayHolder.Update(ctx, newCfg); err != nil {
+		if err2 := w.relayHolder.Update(ctx, w.cfg); err2 != nil {
+			w.l.Error("fail to rollback relay directory", zap.Error(err2))
+		}
+		if persisted {
+			if err2 := w.persistRelayDir(oldDir); err2 != nil {
+				w.l.Error("fail to rollback persisted relay directory", zap.Error(err2))
+			}
+		}
+		return err
 	}
 
-	w.l.Warn("enable-relay is false, ignore purge relay")
+	// relay holder shares the config with the worker
+	w.cfg.RelayDir = newDir
+	for _, st := range relaySubTasks {
+		st.UpdateRelayDir(newDir)
+	}
+	if persisted {
+		cleanRelayDir(oldDir, false)
+	} else {
+		w.l.Warn("new relay directory is not persisted, keep files in the old one", zap.String("old", oldDir))
+	}
+	w.l.Info("relay directory moved", zap.String("old", oldDir), zap.String("new", newDir))
 	return nil
 }
 
+// persistRelayDir updates relay-dir of the source config in etcd, so the worker uses dir after restarting. other
+// items of the source config in etcd are kept, e.g. the encrypted password.
+func (w *Worker) persistRelayDir(dir string) error {
+	scm, _, err := ha.GetSourceCfg(w.etcdClient, w.cfg.SourceID, 0)
+	if err != nil {
+		return err
+	}
+	cfg, ok := scm[w.cfg.SourceID]
+	if !ok {
+		return terror.ErrWorkerFailToGetSourceConfigFromEtcd.Generate(w.cfg.SourceID)
+	}
+	cfg.RelayDir = dir
+	_, err = ha.PutSourceCfg(w.etcdClient, cfg)
+	return err
+}
+
+// resetRelayPurger replaces the closed relay purger with a new one for the current relay directory.
+func (w *Worker) resetRelayPurger(start bool) {
+	operators := []purger.RelayOperator{streamer.GetReaderHub()}
+	if op, ok := w.relayHolder.(purger.RelayOperator); ok {
+		operators = append(operators, op)
+	}
+	w.relayPurger = purger.NewPurger(w.cfg.Purge, w.cfg.RelayDir, operators, []purger.PurgeInterceptor{w})
+	if start {
+		w.relayPurger.Start()
+	}
+}
+
 // ForbidPurge implements PurgeInterceptor.ForbidPurge
 func (w *Worker) ForbidPurge() (bool, string) {
 	if w.closed.Get() == closedTrue {
@@ -710,6 +1749,9 @@ func (w *Worker) ForbidPurge() (bool, string) {
 func (w *Worker) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchemaRequest) (schema string, err error) {
 	w.Lock()
 	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(ctx, "OperateSchema", auditArgs(req), err)
+	}()
 
 	if w.closed.Get() == closedTrue {
 		return "", terror.ErrWorkerAlreadyClosed.Generate()
@@ -723,83 +1765,1400 @@ func (w *Worker) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchemaR
 	return st.OperateSchema(ctx, req)
 }
 
-// copyConfigFromSource copies config items from source config to sub task
-func copyConfigFromSource(cfg *config.SubTaskConfig, sourceCfg *config.SourceConfig) error {
-	cfg.From = sourceCfg.From
+// GetSubTaskTrackedSchema returns the `CREATE TABLE` statement of the table in the schema tracker of the subtask,
+// it can be compared with the one in downstream to diagnose errors caused by the drift of the schema tracker.
+// unlike OperateSchema, the subtask doesn't need to be paused.
+func (w *Worker) GetSubTaskTrackedSchema(name, schema, table string) (string, error) {
+	w.RLock()
+	defer w.RUnlock()
 
-	cfg.Flavor = sourceCfg.Flavor
-	cfg.ServerID = sourceCfg.ServerID
-	cfg.RelayDir = sourceCfg.RelayDir
-	cfg.EnableGTID = sourceCfg.EnableGTID
-	cfg.UseRelay = sourceCfg.EnableRelay
+	if w.closed.Get() == closedTrue {
+		return "", terror.ErrWorkerAlreadyClosed.Generate()
+	}
 
-	// we can remove this from SubTaskConfig later, because syncer will always read from relay
-	cfg.AutoFixGTID = sourceCfg.AutoFixGTID
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return "", terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.TrackedSchema(w.ctx, schema, table)
+}
 
-	if cfg.CaseSensitive != sourceCfg.CaseSensitive {
-		log.L().Warn("different case-sensitive config between task config and source config, use `true` for it.")
+// GetSubTaskBinlogGap returns whether the binlog needed to resume the subtask is still available in upstream, and
+// how many binlog files are missing if it's purged. it helps to diagnose early before resuming fails with errors
+// like "could not find first log file".
+func (w *Worker) GetSubTaskBinlogGap(ctx context.Context, name string) (*syncer.BinlogGapReport, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
 	}
-	cfg.CaseSensitive = cfg.CaseSensitive || sourceCfg.CaseSensitive
-	filter, err := bf.NewBinlogEvent(cfg.CaseSensitive, cfg.FilterRules)
-	if err != nil {
-		return err
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
+
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	// don't hold the lock when querying upstream.
+	return st.BinlogGap(ctx)
+}
+
+// GetTimeSkew returns the clock skews of upstream and downstream related to the worker for the subtask, it helps to
+// realize the clocks are the culprit of negative or impossible replication lag. the replication lag is corrected by
+// the upstream skew if `correct-clock-skew` is set.
+func (w *Worker) GetTimeSkew(ctx context.Context, name string) (*syncer.TimeSkew, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
 	}
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
 
-	for _, filterRule := range sourceCfg.Filters {
-		if err = filter.AddRule(filterRule); err != nil {
-			// task level config has higher priority
-			if errors.IsAlreadyExists(errors.Cause(err)) {
-				log.L().Warn("filter config already exist in source config, overwrite it", log.ShortError(err))
-				continue
-			}
-			return err
-		}
-		cfg.FilterRules = append(cfg.FilterRules, filterRule)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
 	}
-	return nil
+	// don't hold the lock when querying upstream and downstream.
+	return st.TimeSkew(ctx)
 }
 
-// copyConfigFromSourceForEach do copyConfigFromSource for each value in subTaskCfgM and change subTaskCfgM in-place
-func copyConfigFromSourceForEach(subTaskCfgM map[string]config.SubTaskConfig, sourceCfg *config.SourceConfig) error {
-	for k, subTaskCfg := range subTaskCfgM {
-		if err2 := copyConfigFromSource(&subTaskCfg, sourceCfg); err2 != nil {
-			return err2
+// SetStatusConcurrency sets the max number of subtasks collecting status concurrently, a non-positive n means
+// the default value.
+func (w *Worker) SetStatusConcurrency(n int) {
+	if n <= 0 {
+		n = defaultStatusConcurrency
+	}
+	w.statusConcurrency.Set(int32(n))
+}
+
+// SetStatusTaskTimeout sets the timeout of collecting status for each subtask, the subtasks not collected in time
+// report a partial status marked as timed out. a non-positive d means sharing the deadline of the whole query equally
+// by the subtasks.
+func (w *Worker) SetStatusTaskTimeout(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	w.statusTaskTimeout.Set(d)
+}
+
+// GetGoroutineStatus returns the number of goroutines of the worker running and expected by kind, including the ones
+// observing relay and subtask stages in etcd, and the ones running units of subtasks. it's used to detect goroutine
+// leaks, e.g. the goroutine of a canceled watcher doesn't exit.
+func (w *Worker) GetGoroutineStatus() (*GoroutineStatus, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	status := w.goroutines.status()
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		running, expected := st.unitGoroutines()
+		status.Running[goroutineUnit] += running
+		status.Expected[goroutineUnit] += expected
+	}
+	for _, m := range []map[string]int{status.Running, status.Expected} {
+		if m[goroutineUnit] == 0 {
+			delete(m, goroutineUnit)
 		}
-		subTaskCfgM[k] = subTaskCfg
 	}
-	return nil
+	return status, nil
 }
 
-// getAllSubTaskStatus returns all subtask status of this worker, note the field
-// in subtask status is not completed, only includes `Name`, `Stage` and `Result` now
-func (w *Worker) getAllSubTaskStatus() map[string]*pb.SubTaskStatus {
+// GetConfigHash returns a stable hash of the effective source config and configs of all subtasks, see
+// config.WorkerConfigHash. passwords are not hashed, and it's empty if fail to calculate the hash.
+func (w *Worker) GetConfigHash() string {
+	w.RLock()
+	defer w.RUnlock()
+
 	sts := w.subTaskHolder.getAllSubTasks()
-	result := make(map[string]*pb.SubTaskStatus, len(sts))
-	for name, st := range sts {
-		st.RLock()
-		result[name] = &pb.SubTaskStatus{
-			Name:   name,
-			Stage:  st.stage,
-			Result: proto.Clone(st.result).(*pb.ProcessResult),
-		}
-		st.RUnlock()
+	subTaskCfgs := make([]*config.SubTaskConfig, 0, len(sts))
+	for _, st := range sts {
+		subTaskCfgs = append(subTaskCfgs, st.cfg)
 	}
-	return result
+	hash, err := config.WorkerConfigHash(w.cfg, subTaskCfgs)
+	if err != nil {
+		w.l.Error("fail to calculate config hash", zap.Error(err))
+		return ""
+	}
+	return hash
 }
 
-// HandleError handle worker error
-func (w *Worker) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) error {
-	w.Lock()
-	defer w.Unlock()
+// EtcdSnapshot represents the raw etcd state of the source of the DM-worker at a single revision.
+type EtcdSnapshot struct {
+	Source   string  `json:"source"`
+	Worker   string  `json:"worker"`
+	Revision int64   `json:"revision"`
+	KVs      []ha.KV `json:"kvs"`
+}
 
+// SnapshotEtcdState reads all keys about the source of the worker in etcd at a single revision, including configs,
+// stages and bound relationships, see ha.GetSourceSnapshot. it's used to back up the control plane view of the source,
+// the key-value pairs can be put back to etcd as is to restore it.
+func (w *Worker) SnapshotEtcdState(ctx context.Context) (*EtcdSnapshot, error) {
+	w.RLock()
 	if w.closed.Get() == closedTrue {
-		return terror.ErrWorkerAlreadyClosed.Generate()
+		w.RUnlock()
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	source := w.cfg.SourceID
+	w.RUnlock()
+
+	// don't hold the lock when reading from etcd.
+	kvs, rev, err := ha.GetSourceSnapshot(ctx, w.etcdClient, source, w.name)
+	if err != nil {
+		return nil, err
+	}
+	return &EtcdSnapshot{
+		Source:   source,
+		Worker:   w.name,
+		Revision: rev,
+		KVs:      kvs,
+	}, nil
+}
+
+// SetLogRedaction sets the redaction level of configs printed in logs.
+// NOTE: the level is process-wide, it also affects configs which are not held by this worker.
+func (w *Worker) SetLogRedaction(level config.RedactionLevel) {
+	config.SetRedactionLevel(level)
+	w.l.Info("set log redaction level", zap.Stringer("level", level))
+}
+
+// GetLoadTableRestoreStats returns how many tables are skipped, resumed from checkpoint or restored from the beginning
+// by the load unit of the subtask, it's useful to know whether the full import is resumed after a restart.
+func (w *Worker) GetLoadTableRestoreStats(name string) (loader.TableRestoreStats, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return loader.TableRestoreStats{}, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return loader.TableRestoreStats{}, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.TableRestoreStats()
+}
+
+// GetLoadETA returns the estimated time of completion of the load unit of the subtask, with the current rate in a
+// sliding window and how reliable the estimation is. it fails if the subtask is not in the load phase.
+func (w *Worker) GetLoadETA(name string) (*loader.LoadETA, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.LoadETA()
+}
+
+// GetConflictRetryStats returns how many times the sync unit of the subtask retried for write conflicts in downstream.
+func (w *Worker) GetConflictRetryStats(name string) (syncer.ConflictRetryStats, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return syncer.ConflictRetryStats{}, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return syncer.ConflictRetryStats{}, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.ConflictRetryStats()
+}
+
+// GetSubTaskTargetStats returns the results of executing statements in downstream of the subtask,
+// including the failures broken down by error type.
+func (w *Worker) GetSubTaskTargetStats(name string) (*syncer.TargetStats, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.TargetStats()
+}
+
+// GetEffectiveFilterRules returns the binlog event filter rules applied to the subtask, including the rules
+// merged from source config.
+func (w *Worker) GetEffectiveFilterRules(name string) ([]*bf.BinlogEventRule, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.FilterRules(), nil
+}
+
+// GetFilteredEventStats returns the number of events filtered by each binlog event filter rule of the subtask since
+// it started, keyed by syncer.FilterRuleKey of the rules, to find the rules filtering nothing or too much.
+func (w *Worker) GetFilteredEventStats(name string) (map[string]uint64, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.FilteredEventStats()
+}
+
+// GetSubTaskColumnTransforms returns the column transforms applied to the subtask, including the transforms merged
+// from source config.
+func (w *Worker) GetSubTaskColumnTransforms(name string) ([]*config.ColumnTransform, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.ColumnTransforms(), nil
+}
+
+// bounds of the number of dump threads set at runtime.
+const (
+	minDumpThreads = 1
+	maxDumpThreads = 128
+)
+
+// SetSubTaskDumpConcurrency sets the number of dump threads and the rows of chunks which tables are split into to dump
+// concurrently (0 means not split) for the subtask. it's applied when dumping starts, if the subtask is dumping now,
+// pause and resume it to restart dumping with the new concurrency.
+func (w *Worker) SetSubTaskDumpConcurrency(name string, threads, chunkRows int) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetSubTaskDumpConcurrency", auditArgs(map[string]interface{}{
+			"task": name, "threads": threads, "chunk-rows": chunkRows,
+		}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if threads < minDumpThreads || threads > maxDumpThreads || chunkRows < 0 {
+		return terror.ErrWorkerInvalidDumpConcurrency.Generate(threads, minDumpThreads, maxDumpThreads, chunkRows)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	w.l.Info("set dump concurrency", zap.String("task", name), zap.Int("threads", threads), zap.Int("chunk rows", chunkRows))
+	return st.SetDumpConcurrency(dumpling.Concurrency{Threads: threads, ChunkRows: uint64(chunkRows)})
+}
+
+// GetSubTaskDumpConcurrency returns the dump concurrency of the subtask, see SetSubTaskDumpConcurrency.
+func (w *Worker) GetSubTaskDumpConcurrency(name string) (*dumpling.Concurrency, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	concurrency, err := st.DumpConcurrency()
+	if err != nil {
+		return nil, err
+	}
+	return &concurrency, nil
+}
+
+// GetCheckpointFlushInterval returns the current checkpoint flush interval of the subtask.
+func (w *Worker) GetCheckpointFlushInterval(name string) (time.Duration, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return 0, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return 0, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.CheckpointFlushInterval()
+}
+
+// GetUpstreamBinlogSettings gets `binlog_format` and `binlog_row_image` of the upstream source, the sync unit
+// requires ROW and FULL to replicate the full rows.
+func (w *Worker) GetUpstreamBinlogSettings(ctx context.Context) (utils.BinlogSettings, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return utils.BinlogSettings{}, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	dbProvider, dbCfg := w.dbProvider, w.cfg.GenerateDBConfig()
+	w.RUnlock()
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+
+	db, err := dbProvider.Apply(*dbCfg)
+	if err != nil {
+		return utils.BinlogSettings{}, err
+	}
+	defer db.Close()
+	return utils.GetBinlogSettings(ctx, db.DB)
+}
+
+// GetCheckpointAge returns how long since the subtask flushed its checkpoint last time, a growing age means the
+// subtask may be stuck even if it's still running without errors. it's 0 if the checkpoint has caught up with the
+// upstream, as nothing needs to be checkpointed when upstream is idle.
+func (w *Worker) GetCheckpointAge(name string) (time.Duration, error) {
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return 0, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
+	if st == nil {
+		return 0, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultDBTimeout)
+	defer cancel()
+	return st.CheckpointAge(ctx)
+}
+
+// SetCheckpointFlushInterval changes the checkpoint flush interval of the subtask at runtime.
+// the change takes effect immediately, but is not persisted, so it's reset to the config value when the subtask restarts.
+func (w *Worker) SetCheckpointFlushInterval(name string, interval time.Duration) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetCheckpointFlushInterval", auditArgs(map[string]interface{}{"task": name, "interval": interval.String()}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if interval < minCheckpointFlushInterval {
+		return terror.ErrWorkerInvalidCheckpointFlushInterval.Generate(interval, minCheckpointFlushInterval)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SetCheckpointFlushInterval(interval)
+}
+
+// GetSubTaskBatchSize returns the current batch size of downstream transactions of the subtask.
+func (w *Worker) GetSubTaskBatchSize(name string) (int, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return 0, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return 0, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.BatchSize()
+}
+
+// SetSubTaskBatchSize changes the batch size of downstream transactions of the subtask at runtime.
+// larger batches improve throughput but increase lock contention and the cost of replaying after restarting.
+// the change is not persisted, so it's reset to the config value when the config is updated or the subtask restarts.
+func (w *Worker) SetSubTaskBatchSize(name string, n int) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetSubTaskBatchSize", auditArgs(map[string]interface{}{"task": name, "batch": n}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if n < minBatchSize || n > maxBatchSize {
+		return terror.ErrWorkerInvalidBatchSize.Generate(n, minBatchSize, maxBatchSize)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SetBatchSize(n)
+}
+
+// SetDDLBatchSize changes the max number of consecutive DDL events applied to downstream as one batch for the subtask
+// at runtime, only the DDLs which are safe to replay are batched, and the pending DDLs are shown as the blocking DDLs in
+// the status. the change is not persisted, so it's reset to the config value when the config is updated or the
+// subtask restarts.
+func (w *Worker) SetDDLBatchSize(name string, n int) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetDDLBatchSize", auditArgs(map[string]interface{}{"task": name, "batch": n}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if n < config.MinDDLBatchSize || n > config.MaxDDLBatchSize {
+		return terror.ErrWorkerInvalidBatchSize.Generate(n, config.MinDDLBatchSize, config.MaxDDLBatchSize)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SetDDLBatchSize(n)
+}
+
+// WriteMode is the write mode of DML jobs in downstream.
+type WriteMode string
+
+// write modes of DML jobs in downstream, they are the same as `write-mode` in config.
+const (
+	// WriteModeTransactional executes a batch of DML jobs in one transaction.
+	WriteModeTransactional WriteMode = config.WriteModeTransactional
+	// WriteModeBulk executes DML jobs one by one without a transaction.
+	WriteModeBulk WriteMode = config.WriteModeBulk
+)
+
+// GetSubTaskWriteMode returns the active write mode of downstream of the subtask.
+func (w *Worker) GetSubTaskWriteMode(name string) (WriteMode, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return "", terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return "", terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.WriteMode()
+}
+
+// SetSubTaskWriteMode changes the write mode of downstream of the subtask at runtime.
+// `bulk` mode improves throughput, but a batch failed in the middle is partially applied in downstream, so the
+// atomicity of upstream transactions isn't kept and the downstream may be inconsistent until the batch is replayed.
+// the change is not persisted, so it's reset to the config value when the config is updated or the subtask restarts.
+func (w *Worker) SetSubTaskWriteMode(name string, mode WriteMode) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetSubTaskWriteMode", auditArgs(map[string]interface{}{"task": name, "mode": mode}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if mode != WriteModeTransactional && mode != WriteModeBulk {
+		return terror.ErrWorkerInvalidWriteMode.Generate(mode, WriteModeTransactional, WriteModeBulk)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	if err = st.SetWriteMode(mode); err != nil {
+		return err
+	}
+	if mode == WriteModeBulk {
+		w.l.Warn("sub task writes downstream in bulk mode, upstream transactions may be partially applied if failed", zap.String("task", name))
+	}
+	return nil
+}
+
+// SubTaskLag represents the replication lag of a subtask and the max allowed lag of it.
+type SubTaskLag struct {
+	Lag           time.Duration `json:"lag"`
+	MaxAllowedLag time.Duration `json:"max-allowed-lag"` // 0 means no limit
+}
+
+// GetSubTaskLag returns the replication lag calculated from the timestamp of binlog events and the max allowed lag
+// of each subtask.
+func (w *Worker) GetSubTaskLag() (map[string]SubTaskLag, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	lags := make(map[string]SubTaskLag)
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		lags[name] = SubTaskLag{Lag: st.ReplicationLag(), MaxAllowedLag: st.MaxAllowedLag()}
+	}
+	return lags, nil
+}
+
+// SetMaxAllowedLag sets the max allowed replication lag of the subtask, the subtask is paused with an unresumable
+// error if its lag exceeds it, to avoid buffering events unboundedly for an overwhelmed downstream. 0 means no limit.
+func (w *Worker) SetMaxAllowedLag(name string, lag time.Duration) (err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetMaxAllowedLag", auditArgs(map[string]interface{}{"task": name, "max-allowed-lag": lag.String()}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if lag < 0 {
+		return terror.ErrWorkerInvalidMaxAllowedLag.Generate(lag)
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	st.SetMaxAllowedLag(lag)
+	return nil
+}
+
+// pauseLaggingSubTasks pauses the running subtasks whose replication lag exceeds their max allowed lag, the subtasks
+// running or resumed in lagCheckGracePeriod are skipped to catch up. the paused stage is put into etcd, so the subtask
+// keeps paused after the worker restarts, until it's resumed by the user.
+func (w *Worker) pauseLaggingSubTasks() {
+	w.RLock()
+	defer w.RUnlock()
+
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		maxLag := st.MaxAllowedLag()
+		if maxLag == 0 || st.Stage() != pb.Stage_Running || st.inLagCheckGracePeriod() {
+			continue
+		}
+		lag := st.ReplicationLag()
+		if lag <= maxLag {
+			continue
+		}
+
+		w.l.Error("replication lag exceeds the max allowed lag, pause the subtask", zap.String("task", name), zap.Duration("lag", lag), zap.Duration("max allowed lag", maxLag))
+		lagExceededCounter.WithLabelValues(name, w.cfg.SourceID).Inc()
+		if err := st.pauseWithError(terror.ErrWorkerLagThresholdExceeded.Generate(lag, name, maxLag)); err != nil {
+			w.l.Warn("fail to pause the lagging subtask", zap.String("task", name), zap.Error(err))
+			continue
+		}
+		if w.etcdClient == nil {
+			continue
+		}
+		if _, err := ha.PutSubTaskStage(w.etcdClient, ha.NewSubTaskStage(pb.Stage_Paused, w.cfg.SourceID, name)); err != nil {
+			w.l.Warn("fail to put the paused stage of the lagging subtask", zap.String("task", name), zap.Error(err))
+		}
+	}
+}
+
+// PauseAtPosition lets the listed subtasks sync up to the given locations and then pause there, subtasks not listed
+// keep running. it blocks until all listed subtasks reached their locations and paused, or ctx is done, and in latter case
+// barriers of subtasks not reached yet are cleared.
+func (w *Worker) PauseAtPosition(ctx context.Context, positions map[string]*binlog.Location) (err error) {
+	defer func() {
+		w.auditor.emit(ctx, "PauseAtPosition", auditArgs(positions), err)
+	}()
+
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	sts := make(map[string]*SubTask, len(positions))
+	for name, location := range positions {
+		if location == nil {
+			w.RUnlock()
+			return terror.ErrWorkerPauseBarrierNotSpecified.Generate(name)
+		}
+		st := w.subTaskHolder.findSubTask(name)
+		if st == nil {
+			w.RUnlock()
+			return terror.ErrWorkerSubTaskNotFound.Generate(name)
+		}
+		if _, err = st.syncUnit(); err != nil {
+			w.RUnlock()
+			return err
+		}
+		sts[name] = st
+	}
+	w.RUnlock()
+
+	type pauseResult struct {
+		name string
+		err  error
+	}
+	resultCh := make(chan pauseResult, len(sts))
+	for name, st := range sts {
+		name := name
+		if err = st.SetPauseBarrier(positions[name], func(err2 error) {
+			resultCh <- pauseResult{name: name, err: err2}
+		}); err != nil {
+			break
+		}
+		w.l.Info("set pause barrier for sub task", zap.String("task", name), zap.Stringer("location", positions[name]))
+	}
+
+	clearBarriers := func() {
+		for name, st := range sts {
+			if err2 := st.SetPauseBarrier(nil, nil); err2 != nil {
+				w.l.Warn("fail to clear pause barrier", zap.String("task", name), zap.Error(err2))
+			}
+		}
+	}
+	if err != nil {
+		clearBarriers()
+		return err
+	}
+
+	for len(sts) > 0 {
+		select {
+		case <-ctx.Done():
+			clearBarriers()
+			names := make([]string, 0, len(sts))
+			for name := range sts {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return terror.ErrWorkerPauseBarrierNotReached.Delegate(ctx.Err(), names)
+		case res := <-resultCh:
+			delete(sts, res.name)
+			if res.err != nil {
+				w.l.Error("fail to pause sub task at barrier", zap.String("task", res.name), zap.Error(res.err))
+				if err == nil {
+					err = res.err
+				}
+				continue
+			}
+			w.l.Info("sub task reached pause barrier and paused", zap.String("task", res.name), zap.Int("remaining", len(sts)))
+		}
+	}
+	return err
+}
+
+// ReplayFromRelayArchive replays the archived relay log in archiveDir into downstream through the paused subtask, from
+// its checkpoint up to stopAt, then pauses the subtask there. it's used for point-in-time recovery. the archive is
+// validated to be contiguous and cover the needed range before replaying, and the subtask reads binlog as before after
+// it returns. it blocks until stopAt is reached, or the subtask is paused by error, or ctx is done.
+func (w *Worker) ReplayFromRelayArchive(ctx context.Context, name string, archiveDir string, stopAt *binlog.Location) (err error) {
+	defer func() {
+		w.auditor.emit(ctx, "ReplayFromRelayArchive", auditArgs(map[string]interface{}{"task": name, "archive-dir": archiveDir, "stop-at": stopAt}), err)
+	}()
+
+	w.RLock()
+	if w.closed.Get() == closedTrue {
+		w.RUnlock()
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+	if stopAt == nil {
+		w.RUnlock()
+		return terror.ErrWorkerPauseBarrierNotSpecified.Generate(name)
+	}
+	st := w.subTaskHolder.findSubTask(name)
+	w.RUnlock()
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+
+	if err = st.SetRelayArchive(archiveDir, *stopAt); err != nil {
+		return err
+	}
+	defer st.ClearRelayArchive()
+
+	pausedCh := make(chan error, 1)
+	if err = st.SetPauseBarrier(stopAt, func(err2 error) {
+		pausedCh <- err2
+	}); err != nil {
+		return err
+	}
+	clearBarrier := func() {
+		if err2 := st.SetPauseBarrier(nil, nil); err2 != nil {
+			w.l.Warn("fail to clear pause barrier", zap.String("task", name), zap.Error(err2))
+		}
+	}
+
+	w.l.Info("replay relay archive", zap.String("task", name), zap.String("archive dir", archiveDir), zap.Stringer("stop at", stopAt))
+	if err = st.Resume(); err != nil {
+		clearBarrier()
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case err = <-pausedCh:
+			if err == nil {
+				w.l.Info("relay archive replayed, sub task paused", zap.String("task", name), zap.Stringer("stop at", stopAt))
+			}
+			return err
+		case <-ctx.Done():
+			clearBarrier()
+			if err2 := st.Pause(); err2 != nil {
+				w.l.Warn("fail to pause sub task after replaying relay archive is canceled", zap.String("task", name), zap.Error(err2))
+			}
+			return terror.ErrWorkerReplayArchiveNotFinished.Delegate(ctx.Err(), name, stopAt, "it's canceled")
+		case <-ticker.C:
+			if result := st.Result(); st.Stage() == pb.Stage_Paused && result != nil && len(result.Errors) > 0 {
+				clearBarrier()
+				return terror.ErrWorkerReplayArchiveNotFinished.Generate(name, stopAt, "the subtask is paused by error "+result.Errors[0].String())
+			}
+		}
+	}
+}
+
+// GetSubTaskLatencyBreakdown returns the time spent in each stage of the sync pipeline of the subtask,
+// it's used to find out where the replication lag comes from.
+func (w *Worker) GetSubTaskLatencyBreakdown(name string) (*syncer.LatencyBreakdown, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.LatencyBreakdown(), nil
+}
+
+// GetGTIDMode returns how the subtask handles GTID, especially whether it pauses in `strict-gtid` mode or skips in
+// `auto-fix-gtid` mode when the needed binlog events have been purged in upstream.
+func (w *Worker) GetGTIDMode(name string) (syncer.GTIDMode, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return "", terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return "", terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.GTIDMode(), nil
+}
+
+// GetGTIDSwitchStatus returns whether the subtask has switched from position-based replication to GTID-based by
+// `switch-to-gtid` and the checkpoint when switched, GetGTIDMode reports the GTID mode after switched.
+func (w *Worker) GetGTIDSwitchStatus(name string) (*syncer.GTIDSwitchStatus, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.GTIDSwitchStatus()
+}
+
+// GetWriteConflictHotspots returns at most top tables or keys with the most write conflicts or deadlocks in downstream
+// in the recent window, to direct fixing the schema or indexes of hot tables. it's empty if no conflicts occurred.
+func (w *Worker) GetWriteConflictHotspots(name string, top int) ([]syncer.ConflictHotspot, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.WriteConflictHotspots(top), nil
+}
+
+// GetLagByTable returns at most top target tables of the subtask with the most lag, pending DML events and DML events
+// executed recently, to find whether a write-heavy table dominates the lag, e.g. to split it into its own subtask.
+// all tables are returned if top <= 0, and it's empty if the subtask is not in the sync phase.
+func (w *Worker) GetLagByTable(name string, top int) ([]syncer.TableLag, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.LagByTable(top), nil
+}
+
+// GetFrozenDDL returns the DDL of the subtask paused by freeze-on-ddl and waiting to be reviewed, or nil if there is
+// none. after reviewing, resuming the subtask applies the DDL, and `handle-error` skips or replaces it.
+func (w *Worker) GetFrozenDDL(name string) (*syncer.FrozenDDL, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.FrozenDDL(), nil
+}
+
+// GetSubTaskMemoryBreakdown returns the approximate memory used by the binlog read buffer, the transform buffer,
+// the pending-write jobs and the schema tracker of the subtask, to help tuning the buffer sizes.
+func (w *Worker) GetSubTaskMemoryBreakdown(name string) (*syncer.MemoryBreakdown, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.MemoryBreakdown()
+}
+
+// GetSubTaskQueueDepths returns the fill levels of the queues between the read and write stages of the sync pipeline
+// of the subtask, to find out whether upstream or downstream is the bottleneck.
+func (w *Worker) GetSubTaskQueueDepths(name string) (*syncer.QueueDepths, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.QueueDepths()
+}
+
+// GetSubTaskSafeModeRemaining returns whether safe mode of the subtask is active, and the time or the location it ends
+// at, to know when the full throughput returns after resuming the subtask.
+func (w *Worker) GetSubTaskSafeModeRemaining(name string) (*syncer.SafeModeRemaining, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SafeModeRemaining()
+}
+
+// GetSkippedUnsupportedDDLs returns the recent unsupported DDLs skipped by the subtask,
+// they are only recorded when `unsupported-ddl-policy` is `skip`.
+func (w *Worker) GetSkippedUnsupportedDDLs(name string) ([]syncer.SkippedDDL, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SkippedUnsupportedDDLs()
+}
+
+// GetDuplicateKeyStatus returns the duplicate keys skipped or overwritten by the subtask after safe mode,
+// they are only recorded when `duplicate-key-policy` is `skip` or `overwrite`.
+func (w *Worker) GetDuplicateKeyStatus(name string) (*syncer.DuplicateKeyStatus, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.DuplicateKeyStatus()
+}
+
+// GetDDLHistory returns at most limit recent DDLs applied to downstream by the subtask with the time and upstream
+// location of them, from the oldest to the newest. all recorded DDLs are returned if limit <= 0.
+func (w *Worker) GetDDLHistory(name string, limit int) ([]syncer.DDLRecord, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.DDLHistory(limit)
+}
+
+// GetEventRateHistory returns the rates of events processed by the subtask sampled in the recent window, from the
+// oldest to the newest, to detect anomalies like the rate falling to zero while upstream is active. all samples kept
+// are returned if window <= 0.
+func (w *Worker) GetEventRateHistory(name string, window time.Duration) ([]syncer.RateSample, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.EventRateHistory(window)
+}
+
+// GetActiveTransactionCount returns the number of downstream transactions being executed by each subtask,
+// which will be rolled back and replayed if the worker is closed now.
+func (w *Worker) GetActiveTransactionCount() map[string]int {
+	w.RLock()
+	defer w.RUnlock()
+
+	counts := make(map[string]int)
+	if w.closed.Get() == closedTrue {
+		return counts
+	}
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		counts[name] = st.ActiveTransactionCount()
+	}
+	return counts
+}
+
+// StartEventSampling starts sampling binlog events processed by the subtask for debugging, it keeps sampling until
+// StopEventSampling is called or the subtask is restarted, and only the recent `MaxEvents` events are kept.
+func (w *Worker) StartEventSampling(name string, opts syncer.SamplingOpts) (err error) {
+	w.RLock()
+	defer w.RUnlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "StartEventSampling", auditArgs(map[string]interface{}{"task": name, "opts": opts}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.StartEventSampling(opts)
+}
+
+// StopEventSampling stops sampling binlog events of the subtask, the sampled events can still be got by GetSampledEvents.
+func (w *Worker) StopEventSampling(name string) (err error) {
+	w.RLock()
+	defer w.RUnlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "StopEventSampling", auditArgs(map[string]interface{}{"task": name}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.StopEventSampling()
+}
+
+// GetSampledEvents returns the binlog events sampled from the subtask, from the oldest to the newest.
+func (w *Worker) GetSampledEvents(name string) ([]syncer.SampledEvent, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	st := w.subTaskHolder.findSubTask(name)
+	if st == nil {
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(name)
+	}
+	return st.SampledEvents()
+}
+
+// SetReadOnly sets whether writes to downstream of all subtasks are held, e.g. during downstream maintenance.
+// in read-only mode, subtasks keep reading binlog events and buffering them in memory until the buffer is full,
+// and the buffered events are written to downstream after read-only mode cleared.
+func (w *Worker) SetReadOnly(readOnly bool) (err error) {
+	w.RLock()
+	defer w.RUnlock()
+	defer func() {
+		w.auditor.emit(context.Background(), "SetReadOnly", auditArgs(map[string]interface{}{"read-only": readOnly}), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	w.readOnly.Set(readOnly)
+	for _, st := range w.subTaskHolder.getAllSubTasks() {
+		st.SetReadOnly(readOnly)
+	}
+	w.l.Info("set read-only mode", zap.Bool("read-only", readOnly))
+	return nil
+}
+
+// IsReadOnly returns whether writes to downstream of all subtasks are held.
+func (w *Worker) IsReadOnly() bool {
+	return w.readOnly.Get()
+}
+
+// GetHeartbeatLag returns the replication lag of each subtask calculated from the heartbeat written in upstream,
+// which is more accurate than the lag calculated from event timestamp when the upstream is idle.
+// the lag is syncer.HeartbeatLagDisabled if heartbeat is not enabled for the subtask,
+// or syncer.HeartbeatLagUnknown if it's not calculated yet.
+func (w *Worker) GetHeartbeatLag() (map[string]time.Duration, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	lags := make(map[string]time.Duration)
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		lags[name] = st.HeartbeatLag()
+	}
+	return lags, nil
+}
+
+// GetBufferedJobCount returns the number of jobs buffered by each subtask but not written to downstream yet.
+func (w *Worker) GetBufferedJobCount() map[string]int {
+	w.RLock()
+	defer w.RUnlock()
+
+	counts := make(map[string]int)
+	if w.closed.Get() == closedTrue {
+		return counts
+	}
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		counts[name] = st.BufferedJobCount()
+	}
+	return counts
+}
+
+// ShardDDLProgress represents the progress of sharding DDLs pending to be resolved in the worker.
+type ShardDDLProgress struct {
+	Source string                   `json:"source"`
+	DDLs   []syncer.PendingShardDDL `json:"ddls"` // sorted by task and target table
+}
+
+// GetShardDDLProgress returns the sharding DDLs pending to be resolved by all subtasks, including which upstream tables
+// have reached the DDL and which haven't, to help finding out why a sharding DDL lock is not resolved.
+func (w *Worker) GetShardDDLProgress() (*ShardDDLProgress, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	progress := &ShardDDLProgress{
+		Source: w.cfg.SourceID,
+		DDLs:   make([]syncer.PendingShardDDL, 0),
+	}
+	sts := w.subTaskHolder.getAllSubTasks()
+	names := make([]string, 0, len(sts))
+	for name := range sts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		progress.DDLs = append(progress.DDLs, sts[name].PendingShardDDLs()...)
+	}
+	return progress, nil
+}
+
+// PendingShardDDLs returns the sharding DDLs pending to be resolved by the subtasks, task name -> DDLs. the subtasks
+// not waiting for sharding DDL locks are not included, and the result is partial if ctx is done when collecting.
+func (w *Worker) PendingShardDDLs(ctx context.Context) map[string][]string {
+	w.RLock()
+	defer w.RUnlock()
+
+	pending := make(map[string][]string)
+	if w.closed.Get() == closedTrue {
+		return pending
+	}
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		if ctx.Err() != nil {
+			w.l.Warn("stop collecting pending sharding DDLs", zap.Error(ctx.Err()))
+			break
+		}
+		for _, ddl := range st.PendingShardDDLs() {
+			pending[name] = append(pending[name], ddl.DDLs...)
+		}
+	}
+	return pending
+}
+
+// GetDownstreamReadOnlySubTasks returns names of subtasks paused because the downstream is read-only.
+func (w *Worker) GetDownstreamReadOnlySubTasks() []string {
+	w.RLock()
+	defer w.RUnlock()
+
+	names := make([]string, 0)
+	for name, st := range w.subTaskHolder.getAllSubTasks() {
+		if st.PausedByDownstreamReadOnly() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetEtcdKeyPrefixes returns the etcd keys and prefixes of the worker's source, see ha.SourceKeyPrefixes for details.
+func (w *Worker) GetEtcdKeyPrefixes() []string {
+	return ha.SourceKeyPrefixes(w.cfg.SourceID, w.name)
+}
+
+// RegisterAuditSink registers a sink to receive audit events of mutating operations on the worker.
+func (w *Worker) RegisterAuditSink(sink AuditSink) {
+	w.auditor.register(sink)
+}
+
+// AuditEventsDropped returns how many audit events are dropped because the sinks can't catch up.
+func (w *Worker) AuditEventsDropped() int64 {
+	return w.auditor.dropped.Get()
+}
+
+// copyConfigFromSource copies config items from source config to sub task
+func copyConfigFromSource(cfg *config.SubTaskConfig, sourceCfg *config.SourceConfig) error {
+	cfg.From = sourceCfg.From
+
+	// the binlog of the source can't be parsed with the flavor of another kind of database.
+	if cfg.Flavor != "" && sourceCfg.Flavor != "" && !strings.EqualFold(cfg.Flavor, sourceCfg.Flavor) {
+		return terror.ErrWorkerFlavorMismatch.Generate(cfg.Flavor, cfg.Name, sourceCfg.Flavor, sourceCfg.SourceID)
+	}
+	cfg.Flavor = sourceCfg.Flavor
+	cfg.ServerID = sourceCfg.ServerID
+	cfg.RelayDir = sourceCfg.RelayDir
+	cfg.EnableGTID = sourceCfg.EnableGTID
+	cfg.UseRelay = sourceCfg.EnableRelay
+
+	// we can remove this from SubTaskConfig later, because syncer will always read from relay
+	// `strict-gtid` of the task has higher priority
+	cfg.AutoFixGTID = sourceCfg.AutoFixGTID && !cfg.StrictGTID
+	if sourceCfg.AutoFixGTID && cfg.StrictGTID {
+		log.L().Warn("`strict-gtid` is set in task config, ignore `auto-fix-gtid` in source config", zap.String("task", cfg.Name))
+	}
+
+	// task level retry policy has higher priority
+	if cfg.ConflictRetryCount == 0 {
+		cfg.ConflictRetryCount = sourceCfg.ConflictRetryCount
+	}
+	if cfg.ConflictRetryInterval == 0 {
+		cfg.ConflictRetryInterval = sourceCfg.ConflictRetryInterval
+	}
+
+	if cfg.CaseSensitive != sourceCfg.CaseSensitive {
+		log.L().Warn("different case-sensitive config between task config and source config, use `true` for it.")
+	}
+	cfg.CaseSensitive = cfg.CaseSensitive || sourceCfg.CaseSensitive
+	filter, err := bf.NewBinlogEvent(cfg.CaseSensitive, cfg.FilterRules)
+	if err != nil {
+		return err
+	}
+
+	// the source rules are added in a stable order, so the effective filter doesn't change across restarts.
+	// a source rule with the same patterns as a task rule is always ignored because task level config has higher priority.
+	for _, filterRule := range sortFilterRules(sourceCfg.Filters) {
+		if err := filter.AddRule(filterRule); err != nil {
+			// task level config has higher priority
+			if errors.IsAlreadyExists(errors.Cause(err)) {
+				log.L().Warn("filter config already exist in source config, overwrite it", log.ShortError(err))
+				continue
+			}
+			return err
+		}
+		cfg.FilterRules = append(cfg.FilterRules, filterRule)
+	}
+
+	cfg.ColumnTransforms = mergeColumnTransforms(cfg.ColumnTransforms, sourceCfg.ColumnTransforms)
+
+	if ignoredRule := sourceCfg.IgnoredEventsRule(); ignoredRule != nil {
+		cfg.FilterRules, err = mergeIgnoredEventsRule(cfg.FilterRules, ignoredRule)
+	}
+	return err
+}
+
+// sortFilterRules returns a copy of the binlog event filter rules sorted by schema pattern and table pattern, the
+// order of rules with the same patterns is kept.
+func sortFilterRules(rules []*bf.BinlogEventRule) []*bf.BinlogEventRule {
+	sorted := append([]*bf.BinlogEventRule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SchemaPattern != sorted[j].SchemaPattern {
+			return sorted[i].SchemaPattern < sorted[j].SchemaPattern
+		}
+		return sorted[i].TablePattern < sorted[j].TablePattern
+	})
+	return sorted
+}
+
+// mergeColumnTransforms appends the column transforms of source config to the ones of task config, a transform of
+// the same column in task config has higher priority.
+func mergeColumnTransforms(taskTransforms, sourceTransforms []*config.ColumnTransform) []*config.ColumnTransform {
+	merged := append([]*config.ColumnTransform{}, taskTransforms...)
+	for _, st := range sourceTransforms {
+		overwritten := false
+		for _, tt := range taskTransforms {
+			if tt.SchemaPattern == st.SchemaPattern && tt.TablePattern == st.TablePattern && strings.EqualFold(tt.Column, st.Column) {
+				overwritten = true
+				break
+			}
+		}
+		if overwritten {
+			log.L().Warn("column transform already exist in task config, ignore the one in source config", zap.Reflect("transform", st))
+			continue
+		}
+		merged = append(merged, st)
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// mergeIgnoredEventsRule puts the rule for events ignored by source config ahead of the fine-grained rules,
+// a rule with the same patterns is merged into it, or conflicts with it if the rule is not an ignore rule.
+func mergeIgnoredEventsRule(rules []*bf.BinlogEventRule, ignoredRule *bf.BinlogEventRule) ([]*bf.BinlogEventRule, error) {
+	merged := make([]*bf.BinlogEventRule, 0, len(rules)+1)
+	merged = append(merged, ignoredRule)
+	for _, rule := range rules {
+		if rule.SchemaPattern != ignoredRule.SchemaPattern || rule.TablePattern != ignoredRule.TablePattern {
+			merged = append(merged, rule)
+			continue
+		}
+		if rule.Action != bf.Ignore {
+			return nil, terror.ErrConfigBinlogEventFilter.Delegate(
+				errors.NotValidf("rule %+v with the same patterns as events ignored by source config", rule))
+		}
+		for _, event := range rule.Events {
+			if !containsEventType(ignoredRule.Events, event) {
+				ignoredRule.Events = append(ignoredRule.Events, event)
+			}
+		}
+		ignoredRule.SQLPattern = append(ignoredRule.SQLPattern, rule.SQLPattern...)
+	}
+	return merged, nil
+}
+
+func containsEventType(events []bf.EventType, event bf.EventType) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// copyConfigFromSourceForEach do copyConfigFromSource for each value in subTaskCfgM and change subTaskCfgM in-place
+func copyConfigFromSourceForEach(subTaskCfgM map[string]config.SubTaskConfig, sourceCfg *config.SourceConfig) error {
+	for k, subTaskCfg := range subTaskCfgM {
+		err2 := copyConfigFromSource(&subTaskCfg, sourceCfg)
+		if terror.ErrWorkerFlavorMismatch.Equal(err2) {
+			// keep the subtask config unadjusted, it's failed when starting the subtask.
+			log.L().Warn("skip adjusting subtask config", zap.String("task", k), log.ShortError(err2))
+			continue
+		} else if err2 != nil {
+			return err2
+		}
+		subTaskCfgM[k] = subTaskCfg
+	}
+	return nil
+}
+
+// getAllSubTaskStatus returns all subtask status of this worker, note the field
+// in subtask status is not completed, only includes `Name`, `Stage` and `Result` now
+func (w *Worker) getAllSubTaskStatus() map[string]*pb.SubTaskStatus {
+	sts := w.subTaskHolder.getAllSubTasks()
+	result := make(map[string]*pb.SubTaskStatus, len(sts))
+	for name, st := range sts {
+		st.RLock()
+		result[name] = &pb.SubTaskStatus{
+			Name:   name,
+			Stage:  st.stage,
+			Result: proto.Clone(st.result).(*pb.ProcessResult),
+		}
+		st.RUnlock()
+	}
+	return result
+}
+
+// HandleError handle worker error
+func (w *Worker) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) error {
+	_, err := w.HandleErrorWithResult(ctx, req)
+	return err
+}
+
+// HandleErrorWithResult handles worker error like HandleError, and returns whether the error is found at the binlog
+// position, the binlog position the operation applies at, and the DDLs still blocking the subtask, so the operators
+// handling errors of many sources know which ones still have unresolved DDLs.
+func (w *Worker) HandleErrorWithResult(ctx context.Context, req *pb.HandleWorkerErrorRequest) (result *syncer.HandleErrorResult, err error) {
+	w.Lock()
+	defer w.Unlock()
+	defer func() {
+		w.auditor.emit(ctx, "HandleError", auditArgs(req), err)
+	}()
+
+	if w.closed.Get() == closedTrue {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
 	}
 
 	st := w.subTaskHolder.findSubTask(req.Task)
 	if st == nil {
-		return terror.ErrWorkerSubTaskNotFound.Generate(req.Task)
+		return nil, terror.ErrWorkerSubTaskNotFound.Generate(req.Task)
 	}
 
-	return st.HandleError(ctx, req)
+	return st.HandleErrorWithResult(ctx, req)
 }
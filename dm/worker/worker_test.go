@@ -21,15 +21,20 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/failpoint"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/siddontang/go-mysql/mysql"
 	"github.com/tikv/pd/pkg/tempurl"
 	"go.etcd.io/etcd/clientv3"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/binlog"
 	"github.com/pingcap/dm/pkg/ha"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
+	"github.com/pingcap/dm/syncer"
 )
 
 var emptyWorkerStatusInfoJSONLength = 25
@@ -72,8 +77,20 @@ func (t *testServer) testWorker(c *C) {
 	w, err := NewWorker(&cfg, etcdCli, "")
 	c.Assert(err, IsNil)
 	c.Assert(w.EnableRelay(), ErrorMatches, "init error")
-
+	// the relay holder is cleaned up, and the relay can be enabled again.
+	c.Assert(w.relayHolder, IsNil)
+	c.Assert(w.relayPurger, IsNil)
 	NewRelayHolder = NewDummyRelayHolder
+	c.Assert(w.EnableRelay(), IsNil)
+	holder := w.relayHolder
+	c.Assert(holder, NotNil)
+	c.Assert(w.EnableRelay(), IsNil)
+	c.Assert(w.relayHolder, Equals, holder)
+	// the worker is not started, so stop watching relay stage manually.
+	w.cancel()
+	w.wg.Wait()
+	holder.Close()
+
 	w, err = NewWorker(&cfg, etcdCli, "")
 	c.Assert(err, IsNil)
 	c.Assert(w.StatusJSON(context.Background(), ""), HasLen, emptyWorkerStatusInfoJSONLength)
@@ -105,6 +122,204 @@ func (t *testServer) testWorker(c *C) {
 
 	err = w.OperateSubTask("testSubTask", pb.TaskOp_Stop)
 	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.PauseAtPosition(context.Background(), map[string]*binlog.Location{"testSubTask": {}})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.MoveRelayDir(context.Background(), c.MkDir())
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{Inactive: true, DryRun: true})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.PurgeRelay(context.Background(), &pb.PurgeRelayRequest{GtidSet: "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.RegisterSubTaskOpHook("testSubTask", nil, nil)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetLoadETA("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetRebalanceHints()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetMaintenanceWindow(time.Now(), time.Now().Add(time.Hour))
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetMaintenanceStatus()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetTimeSkew(context.Background(), "testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetRelayInfo()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.ReplayFromRelayArchive(context.Background(), "testSubTask", c.MkDir(), &binlog.Location{})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetEffectiveConfig("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetWriteConflictHotspots("testSubTask", 10)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetGTIDMode("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.ListActiveConnections()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskMemoryBreakdown("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetSubTaskQueueDepths("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetSubTaskSafeModeRemaining("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetDuplicateKeyStatus("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.DetectRelayWriteStall(context.Background())
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetFilteredEventStats("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.RollingPauseAll(context.Background(), time.Second)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.RollingResumeAll(context.Background(), time.Second)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetCheckpointAge("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GatherSubTaskMetrics("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.ValidateRoutingRules("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.EnterSubTaskStepping("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.StepSubTask("testSubTask", 1)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.ExitSubTaskStepping("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetUpstreamBinlogSettings(context.Background())
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetSubTaskWriteMode("testSubTask", WriteModeBulk)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskWriteMode("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetDDLHistory("testSubTask", 10)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetGTIDSwitchStatus("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.DiffSchemaWithUpstream(context.Background(), "testSubTask", "db", "tbl")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetRelayReaderPositions()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetErrorClassifications()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetEventRateHistory("testSubTask", time.Minute)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, _, err = w.GetConsistentSnapshotPosition(context.Background())
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.SetSubTaskPriorityClass("testSubTask", config.PriorityHigh), ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetSubTaskPriorityClasses()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	_, err = w.ExportSubTaskCheckpoint("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.ImportSubTaskCheckpoint("testSubTask", nil), ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetLagByTable("testSubTask", 10)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	_, err = w.GetFrozenDDL("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.StartEventSampling("testSubTask", syncer.SamplingOpts{})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetReadOnly(true)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.IsReadOnly(), IsFalse)
+	c.Assert(w.GetBufferedJobCount(), HasLen, 0)
+	c.Assert(w.PauseSyncKeepRelay("testSubTask"), ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.ResumeSync("testSubTask"), ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetSubTaskBatchSize("testSubTask", 1000)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	err = w.SetDDLBatchSize("testSubTask", 10)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.GetEffectiveServerID("testSubTask"), Equals, uint32(0))
+	result, err := w.HandleErrorWithResult(context.Background(), &pb.HandleWorkerErrorRequest{Task: "testSubTask"})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(result, IsNil)
+
+	_, err = w.GetShardDDLProgress()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+	c.Assert(w.PendingShardDDLs(context.Background()), HasLen, 0)
+
+	err = w.InjectFault(FaultSpec{Kind: FaultEtcdWatchDisconnect})
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetHeartbeatLag()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskLag()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetMaxAllowedLag("testSubTask", time.Minute)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.FastForwardSubTask("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskTargetStats("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetEffectiveFilterRules("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.SnapshotEtcdState(context.Background())
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.PauseSubTask("testSubTask", true, time.Second)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskTrackedSchema("testSubTask", "db", "tbl")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	err = w.SetSubTaskDumpConcurrency("testSubTask", 8, 0)
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskDumpConcurrency("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskColumnTransforms("testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetSubTaskBinlogGap(context.Background(), "testSubTask")
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	_, err = w.GetGoroutineStatus()
+	c.Assert(err, ErrorMatches, ".*worker already closed.*")
+
+	// config hash is still available after closed
+	c.Assert(w.GetConfigHash(), HasLen, 64)
 }
 
 type testServer2 struct{}
@@ -122,6 +337,211 @@ func (t *testServer2) TearDownSuite(c *C) {
 	getMinLocForSubTaskFunc = getMinLocForSubTask
 }
 
+func (t *testServer2) TestCopyConfigFromSourceIgnoredEvents(c *C) {
+	sourceCfg := loadSourceConfigWithoutPassword(c)
+	sourceCfg.IgnoreDDL = true
+	sourceCfg.IgnoreDelete = true
+	userRule := &bf.BinlogEventRule{SchemaPattern: "db", TablePattern: "tbl", Events: []bf.EventType{bf.UpdateEvent}, Action: bf.Ignore}
+	cfg := &config.SubTaskConfig{
+		FilterRules: []*bf.BinlogEventRule{
+			userRule,
+			{SchemaPattern: "*", Events: []bf.EventType{bf.InsertEvent, bf.DeleteEvent}, Action: bf.Ignore},
+		},
+	}
+
+	// the rule with the same patterns is merged, and ignored events are put ahead
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	c.Assert(cfg.FilterRules, DeepEquals, []*bf.BinlogEventRule{
+		{SchemaPattern: "*", Events: []bf.EventType{bf.AllDDL, bf.DeleteEvent, bf.InsertEvent}, Action: bf.Ignore},
+		userRule,
+	})
+	// copy again
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	c.Assert(cfg.FilterRules, HasLen, 2)
+	c.Assert(cfg.FilterRules[0].Events, DeepEquals, []bf.EventType{bf.AllDDL, bf.DeleteEvent, bf.InsertEvent})
+
+	filter, err := bf.NewBinlogEvent(cfg.CaseSensitive, cfg.FilterRules)
+	c.Assert(err, IsNil)
+	action, err := filter.Filter("db2", "tbl2", bf.CreateTable, "CREATE TABLE tbl2 (c INT)")
+	c.Assert(err, IsNil)
+	c.Assert(action, Equals, bf.Ignore)
+	action, err = filter.Filter("db2", "tbl2", bf.UpdateEvent, "")
+	c.Assert(err, IsNil)
+	c.Assert(action, Equals, bf.Do)
+
+	// conflict with a do rule
+	cfg.FilterRules = []*bf.BinlogEventRule{{SchemaPattern: "*", Events: []bf.EventType{bf.AllDML}, Action: bf.Do}}
+	c.Assert(terror.ErrConfigBinlogEventFilter.Equal(copyConfigFromSource(cfg, &sourceCfg)), IsTrue)
+}
+
+func (t *testServer2) TestCopyConfigFromSourceColumnTransforms(c *C) {
+	sourceCfg := loadSourceConfigWithoutPassword(c)
+	cfg := &config.SubTaskConfig{}
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	c.Assert(cfg.ColumnTransforms, IsNil)
+
+	taskTransform := &config.ColumnTransform{SchemaPattern: "db", TablePattern: "tbl", Column: "email", Func: config.TransformHash}
+	sourceTransform1 := &config.ColumnTransform{SchemaPattern: "db", TablePattern: "tbl", Column: "EMAIL", Func: config.TransformNullify}
+	sourceTransform2 := &config.ColumnTransform{SchemaPattern: "db", TablePattern: "tbl", Column: "phone", Func: config.TransformMask}
+	sourceCfg.ColumnTransforms = []*config.ColumnTransform{sourceTransform1, sourceTransform2}
+	cfg.ColumnTransforms = []*config.ColumnTransform{taskTransform}
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	// the transform of the same column in task config has higher priority.
+	c.Assert(cfg.ColumnTransforms, DeepEquals, []*config.ColumnTransform{taskTransform, sourceTransform2})
+}
+
+func (t *testServer2) TestCopyConfigFromSourceFlavor(c *C) {
+	sourceCfg := loadSourceConfigWithoutPassword(c)
+	sourceCfg.Flavor = mysql.MySQLFlavor
+	cfg := &config.SubTaskConfig{Name: "task"}
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	c.Assert(cfg.Flavor, Equals, mysql.MySQLFlavor)
+
+	cfg = &config.SubTaskConfig{Name: "task", Flavor: "MySQL"}
+	c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+	c.Assert(cfg.Flavor, Equals, mysql.MySQLFlavor)
+
+	// the flavor is not overwritten silently.
+	cfg = &config.SubTaskConfig{Name: "task", Flavor: mysql.MariaDBFlavor}
+	err := copyConfigFromSource(cfg, &sourceCfg)
+	c.Assert(terror.ErrWorkerFlavorMismatch.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*flavor mariadb of subtask task is different from flavor mysql of source.*")
+	c.Assert(cfg.Flavor, Equals, mysql.MariaDBFlavor)
+
+	// only the subtask with mismatched flavor is left unadjusted in the bulk path.
+	cfgM := map[string]config.SubTaskConfig{
+		"task-1": {Name: "task-1"},
+		"task-2": {Name: "task-2", Flavor: mysql.MariaDBFlavor},
+	}
+	c.Assert(copyConfigFromSourceForEach(cfgM, &sourceCfg), IsNil)
+	c.Assert(cfgM["task-1"].Flavor, Equals, mysql.MySQLFlavor)
+	c.Assert(cfgM["task-2"].Flavor, Equals, mysql.MariaDBFlavor)
+
+	// only the subtask with mismatched flavor is failed when starting.
+	defer func() {
+		createUnits = createRealUnits
+	}()
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{NewMockUnit(pb.UnitType_Sync)}
+	}
+	w := &Worker{cfg: &sourceCfg, subTaskHolder: newSubTaskHolder(), l: log.L()}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	defer w.cancel()
+	w.closed.Set(closedFalse)
+	task1, task2 := cfgM["task-1"], cfgM["task-2"]
+	c.Assert(w.StartSubTask(&task2, pb.Stage_Paused), IsNil)
+	c.Assert(w.StartSubTask(&task1, pb.Stage_Paused), IsNil)
+	c.Assert(w.subTaskHolder.findSubTask("task-2").Result().String(), Matches, ".*flavor mariadb of subtask task-2 is different.*")
+	st := w.subTaskHolder.findSubTask("task-1")
+	c.Assert(st.Result(), IsNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	w.subTaskHolder.closeAllSubTasks()
+}
+
+func (t *testServer2) TestCopyConfigFromSourceFilterOrder(c *C) {
+	sourceRules := func() []*bf.BinlogEventRule {
+		return []*bf.BinlogEventRule{
+			{SchemaPattern: "db*", TablePattern: "tbl*", Events: []bf.EventType{bf.InsertEvent}, Action: bf.Ignore},
+			{SchemaPattern: "db1", TablePattern: "tbl*", Events: []bf.EventType{bf.DeleteEvent}, Action: bf.Ignore},
+			{SchemaPattern: "db1", TablePattern: "tbl1", Events: []bf.EventType{bf.UpdateEvent}, Action: bf.Ignore},
+			{SchemaPattern: "db1", Events: []bf.EventType{bf.AllDDL}, Action: bf.Ignore},
+		}
+	}
+	permutations := [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {2, 0, 3, 1}, {1, 3, 0, 2}}
+
+	var expected []*bf.BinlogEventRule
+	for _, perm := range permutations {
+		rules := sourceRules()
+		sourceCfg := loadSourceConfigWithoutPassword(c)
+		sourceCfg.Filters = make([]*bf.BinlogEventRule, 0, len(perm))
+		for _, i := range perm {
+			sourceCfg.Filters = append(sourceCfg.Filters, rules[i])
+		}
+		taskRule := &bf.BinlogEventRule{SchemaPattern: "db1", TablePattern: "tbl1", Events: []bf.EventType{bf.AllDML}, Action: bf.Do}
+		cfg := &config.SubTaskConfig{FilterRules: []*bf.BinlogEventRule{taskRule}}
+		c.Assert(copyConfigFromSource(cfg, &sourceCfg), IsNil)
+
+		// the task rule has higher priority than the source rule with the same patterns.
+		c.Assert(cfg.FilterRules[0], Equals, taskRule)
+		if expected == nil {
+			expected = cfg.FilterRules
+			c.Assert(expected, HasLen, 4)
+		} else {
+			c.Assert(cfg.FilterRules, DeepEquals, expected)
+		}
+
+		filter, err := bf.NewBinlogEvent(cfg.CaseSensitive, cfg.FilterRules)
+		c.Assert(err, IsNil)
+		for _, tc := range []struct {
+			schema, table string
+			event         bf.EventType
+			action        bf.ActionType
+		}{
+			{"db1", "tbl1", bf.UpdateEvent, bf.Do},
+			{"db1", "tbl2", bf.DeleteEvent, bf.Ignore},
+			{"db2", "tbl2", bf.InsertEvent, bf.Ignore},
+			{"db2", "tbl2", bf.DeleteEvent, bf.Do},
+		} {
+			action, err := filter.Filter(tc.schema, tc.table, tc.event, "")
+			c.Assert(err, IsNil)
+			c.Assert(action, Equals, tc.action, Commentf("%s.%s %s", tc.schema, tc.table, tc.event))
+		}
+	}
+}
+
+func (t *testServer2) TestStartSubTaskInStage(c *C) {
+	defer func() {
+		createUnits = createRealUnits
+	}()
+	createUnits = func(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) []unit.Unit {
+		return []unit.Unit{NewMockUnit(pb.UnitType_Sync)}
+	}
+
+	w := &Worker{
+		cfg:           &config.SourceConfig{},
+		subTaskHolder: newSubTaskHolder(),
+		l:             log.L(),
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	defer w.cancel()
+	w.closed.Set(closedFalse)
+	defer w.subTaskHolder.closeAllSubTasks()
+
+	// every stage either starts the subtask in it, or is rejected.
+	startable := map[pb.Stage]bool{
+		pb.Stage_Running:  true,
+		pb.Stage_Paused:   true,
+		pb.Stage_Finished: true,
+	}
+	for value := range pb.Stage_name {
+		stage := pb.Stage(value)
+		name := "task-" + stage.String()
+		err := w.StartSubTask(&config.SubTaskConfig{Name: name}, stage)
+		st := w.subTaskHolder.findSubTask(name)
+		if startable[stage] {
+			c.Assert(err, IsNil, Commentf("stage %s", stage))
+			c.Assert(st, NotNil, Commentf("stage %s", stage))
+			c.Assert(st.Stage(), Equals, stage)
+			// the units are initialized but not processed if not running.
+			c.Assert(st.initialized.Get(), IsTrue)
+		} else {
+			c.Assert(terror.ErrWorkerInvalidSubTaskStage.Equal(err), IsTrue, Commentf("stage %s", stage))
+			c.Assert(st, IsNil, Commentf("stage %s", stage))
+		}
+	}
+
+	// a started subtask can't be operated to finished, and unknown expect stages are rejected.
+	_, err := w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_Finished, "source", "task-Finished"), config.SubTaskConfig{})
+	c.Assert(err, IsNil)
+	c.Assert(w.subTaskHolder.findSubTask("task-Finished").Stage(), Equals, pb.Stage_Finished)
+	_, err = w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_Finished, "source", "task-Paused"), config.SubTaskConfig{})
+	c.Assert(terror.ErrWorkerInvalidSubTaskStage.Equal(err), IsTrue)
+	c.Assert(w.subTaskHolder.findSubTask("task-Paused").Stage(), Equals, pb.Stage_Paused)
+	_, err = w.operateSubTaskStage(ha.NewSubTaskStage(pb.Stage_New, "source", "task-Paused"), config.SubTaskConfig{})
+	c.Assert(terror.ErrWorkerInvalidSubTaskStage.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*subtask task-Paused can't be started in or transited to stage New.*")
+}
+
 func (t *testServer2) TestTaskAutoResume(c *C) {
 	var (
 		taskName = "sub-task-name"
@@ -427,3 +847,81 @@ func (t *testWorkerEtcdCompact) TestWatchRelayStageEtcdCompact(c *C) {
 		return w.relayHolder.Stage() == pb.Stage_Stopped
 	}), IsTrue)
 }
+
+func (t *testWorkerEtcdCompact) TestReconcile(c *C) {
+	var (
+		masterAddr   = tempurl.Alloc()[len("http://"):]
+		keepAliveTTL = int64(1)
+	)
+	etcdDir := c.MkDir()
+	ETCD, err := createMockETCD(etcdDir, "http://"+masterAddr)
+	c.Assert(err, IsNil)
+	defer ETCD.Close()
+	cfg := NewConfig()
+	c.Assert(cfg.Parse([]string{"-config=./dm-worker.toml"}), IsNil)
+	cfg.Join = masterAddr
+	cfg.KeepAliveTTL = keepAliveTTL
+	cfg.RelayKeepAliveTTL = keepAliveTTL
+
+	etcdCli, err := clientv3.New(clientv3.Config{
+		Endpoints:            GetJoinURLs(cfg.Join),
+		DialTimeout:          dialTimeout,
+		DialKeepAliveTime:    keepaliveTime,
+		DialKeepAliveTimeout: keepaliveTimeout,
+	})
+	c.Assert(err, IsNil)
+	sourceCfg := loadSourceConfigWithoutPassword(c)
+	sourceCfg.EnableRelay = false
+
+	w, err := NewWorker(&sourceCfg, etcdCli, "")
+	c.Assert(err, IsNil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer w.Close()
+	go func() {
+		w.Start()
+	}()
+	c.Assert(utils.WaitSomething(50, 100*time.Millisecond, func() bool {
+		return w.closed.Get() == closedFalse
+	}), IsTrue)
+
+	subtaskCfg := config.SubTaskConfig{}
+	err = subtaskCfg.DecodeFile(subtaskSampleFile, true)
+	c.Assert(err, IsNil)
+	subtaskCfg.MydumperPath = mydumperPath
+
+	// subtask not in etcd is stopped
+	clone := subtaskCfg
+	c.Assert(w.StartSubTask(&clone, pb.Stage_Running), IsNil)
+	result, err := w.Reconcile(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(result.StoppedSubTasks, DeepEquals, []string{subtaskCfg.Name})
+	c.Assert(w.subTaskHolder.findSubTask(subtaskCfg.Name), IsNil)
+
+	// subtask in etcd is started
+	_, err = ha.PutSubTaskCfgStage(etcdCli, []config.SubTaskConfig{subtaskCfg},
+		[]ha.Stage{ha.NewSubTaskStage(pb.Stage_Running, sourceCfg.SourceID, subtaskCfg.Name)})
+	c.Assert(err, IsNil)
+	result, err = w.Reconcile(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(result.StartedSubTasks, DeepEquals, []string{subtaskCfg.Name})
+	c.Assert(result.StoppedSubTasks, HasLen, 0)
+	st := w.subTaskHolder.findSubTask(subtaskCfg.Name)
+	c.Assert(st, NotNil)
+	c.Assert(st.Stage(), Equals, pb.Stage_Running)
+
+	// nothing to correct
+	result, err = w.Reconcile(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(result.StartedSubTasks, HasLen, 0)
+	c.Assert(result.OperatedSubTasks, HasLen, 0)
+
+	// stage is fixed
+	_, err = ha.PutSubTaskStage(etcdCli, ha.NewSubTaskStage(pb.Stage_Paused, sourceCfg.SourceID, subtaskCfg.Name))
+	c.Assert(err, IsNil)
+	result, err = w.Reconcile(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(result.OperatedSubTasks, DeepEquals, map[string]string{subtaskCfg.Name: pb.TaskOp_Pause.String()})
+	c.Assert(st.Stage(), Equals, pb.Stage_Paused)
+	c.Assert(result.RelayOp, Equals, "")
+}
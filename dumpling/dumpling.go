@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/dumpling/v4/export"
@@ -44,6 +45,19 @@ type Dumpling struct {
 
 	dumpConfig *export.Config
 	closed     sync2.AtomicBool
+
+	// concurrency set at runtime, it overwrites the one in config when dumping starts
+	concurrency struct {
+		sync.Mutex
+		set bool
+		Concurrency
+	}
+}
+
+// Concurrency represents the concurrency of dumping.
+type Concurrency struct {
+	Threads   int    `json:"threads"`    // number of goroutines dumping tables
+	ChunkRows uint64 `json:"chunk-rows"` // split tables into chunks of these rows to dump concurrently, 0 means not split
 }
 
 // NewDumpling creates a new Dumpling
@@ -57,8 +71,10 @@ func NewDumpling(cfg *config.SubTaskConfig) *Dumpling {
 
 // Init implements Unit.Init
 func (m *Dumpling) Init(ctx context.Context) error {
-	var err error
-	m.dumpConfig, err = m.constructArgs()
+	dumpConfig, err := m.constructArgs()
+	m.concurrency.Lock() // dumpConfig may be read by Concurrency concurrently
+	m.dumpConfig = dumpConfig
+	m.concurrency.Unlock()
 	m.detectSQLMode(ctx)
 	m.logger.Info("create dumpling", zap.Stringer("config", m.dumpConfig))
 	return err
@@ -108,9 +124,11 @@ func (m *Dumpling) Process(ctx context.Context, pr chan pb.ProcessResult) {
 		<-ctx.Done()
 	})
 
+	dumpConfig := m.dumpConfigWithConcurrency()
+	m.logger.Info("start dumping", zap.Int("threads", dumpConfig.Threads), zap.Uint64("chunk rows", dumpConfig.Rows))
 	newCtx, cancel := context.WithCancel(ctx)
 	var dumpling *export.Dumper
-	if dumpling, err = export.NewDumper(newCtx, m.dumpConfig); err == nil {
+	if dumpling, err = export.NewDumper(newCtx, dumpConfig); err == nil {
 		err = dumpling.Dump()
 		dumpling.Close()
 	}
@@ -197,6 +215,50 @@ func (m *Dumpling) IsFreshTask(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// SetConcurrency sets the concurrency of dumping, it takes effect when dumping starts next time, that is when the
+// dump unit runs for the first time or resumed, because dumping always starts from the beginning.
+func (m *Dumpling) SetConcurrency(concurrency Concurrency) {
+	m.concurrency.Lock()
+	defer m.concurrency.Unlock()
+	m.concurrency.set = true
+	m.concurrency.Concurrency = concurrency
+	m.logger.Info("set dump concurrency", zap.Int("threads", concurrency.Threads), zap.Uint64("chunk rows", concurrency.ChunkRows))
+}
+
+// Concurrency returns the concurrency of dumping, it's the one set at runtime or the one in config.
+func (m *Dumpling) Concurrency() Concurrency {
+	m.concurrency.Lock()
+	defer m.concurrency.Unlock()
+	if m.concurrency.set {
+		return m.concurrency.Concurrency
+	}
+	if m.dumpConfig != nil {
+		return Concurrency{Threads: m.dumpConfig.Threads, ChunkRows: m.dumpConfig.Rows}
+	}
+	return ConcurrencyFromConfig(m.cfg)
+}
+
+// ConcurrencyFromConfig returns the concurrency of dumping in the subtask config.
+func ConcurrencyFromConfig(cfg *config.SubTaskConfig) Concurrency {
+	concurrency := Concurrency{Threads: export.DefaultConfig().Threads, ChunkRows: cfg.Rows}
+	if cfg.Threads > 0 {
+		concurrency.Threads = cfg.Threads
+	}
+	return concurrency
+}
+
+// dumpConfigWithConcurrency returns a copy of the dump config, with the concurrency set at runtime.
+func (m *Dumpling) dumpConfigWithConcurrency() *export.Config {
+	m.concurrency.Lock()
+	defer m.concurrency.Unlock()
+	dumpConfig := *m.dumpConfig
+	if m.concurrency.set {
+		dumpConfig.Threads = m.concurrency.Threads
+		dumpConfig.Rows = m.concurrency.ChunkRows
+	}
+	return &dumpConfig
+}
+
 // constructArgs constructs arguments for exec.Command
 func (m *Dumpling) constructArgs() (*export.Config, error) {
 	cfg := m.cfg
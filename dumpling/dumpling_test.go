@@ -128,3 +128,30 @@ func (d *testDumplingSuite) TestDefaultConfig(c *C) {
 	c.Assert(dumpling.Init(ctx), IsNil)
 	c.Assert(dumpling.dumpConfig.StatementSize, Not(Equals), export.UnspecifiedSize)
 }
+
+func (d *testDumplingSuite) TestConcurrency(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name:           "dumpling_concurrency",
+		MydumperConfig: config.MydumperConfig{Threads: 8, Rows: 1000},
+	}
+	dumpling := NewDumpling(cfg)
+	c.Assert(dumpling.Concurrency(), Equals, Concurrency{Threads: 8, ChunkRows: 1000})
+	cfg.Threads = 0
+	c.Assert(dumpling.Concurrency().Threads, Equals, export.DefaultConfig().Threads)
+
+	var err error
+	dumpling.dumpConfig, err = dumpling.constructArgs()
+	c.Assert(err, IsNil)
+	dumpConfig := dumpling.dumpConfigWithConcurrency()
+	c.Assert(dumpConfig.Threads, Equals, export.DefaultConfig().Threads)
+	c.Assert(dumpConfig.Rows, Equals, uint64(1000))
+
+	// set at runtime.
+	dumpling.SetConcurrency(Concurrency{Threads: 16, ChunkRows: 0})
+	c.Assert(dumpling.Concurrency(), Equals, Concurrency{Threads: 16, ChunkRows: 0})
+	dumpConfig = dumpling.dumpConfigWithConcurrency()
+	c.Assert(dumpConfig.Threads, Equals, 16)
+	c.Assert(dumpConfig.Rows, Equals, uint64(0))
+	// the original config is not changed.
+	c.Assert(dumpling.dumpConfig.Rows, Equals, uint64(1000))
+}
@@ -25,6 +25,7 @@ require (
 	github.com/pingcap/tidb v1.1.0-beta.0.20210319021734-e79ac3d978cf
 	github.com/pingcap/tidb-tools v5.0.0-rc.0.20210310030049-c82efd92f571+incompatible
 	github.com/prometheus/client_golang v1.5.1
+	github.com/prometheus/client_model v0.2.0
 	github.com/rakyll/statik v0.1.6
 	github.com/siddontang/go v0.0.0-20180604090527-bdc77568d726
 	github.com/siddontang/go-mysql v1.1.1-0.20200824131207-0c5789dd0bd3
@@ -0,0 +1,37 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+// ActiveConnections returns the connections held by the loader to downstream.
+func (l *Loader) ActiveConnections() []conn.ConnInfo {
+	to := conn.ConnAddr(l.cfg.To)
+	infos := l.toDB.ConnInfos(conn.ConnPurposeLoad, conn.ConnSideDownstream, to)
+	if cp, ok := l.checkPoint.(*RemoteCheckPoint); ok {
+		infos = append(infos, cp.db.ConnInfos(conn.ConnPurposeCheckpoint, conn.ConnSideDownstream, to)...)
+	}
+	return infos
+}
+
+// PoolStats returns the stats of the connection pool of the loader, the loader has no pool to upstream.
+func (l *Loader) PoolStats() (upstream, downstream conn.PoolStats) {
+	downstream = l.toDB.PoolStats()
+	if cp, ok := l.checkPoint.(*RemoteCheckPoint); ok {
+		downstream.Add(cp.db.PoolStats())
+	}
+	return upstream, downstream
+}
@@ -99,8 +99,8 @@ type RemoteCheckPoint struct {
 	logger         log.Logger
 }
 
-func newRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, id string) (CheckPoint, error) {
-	db, dbConns, err := createConns(tctx, cfg, 1)
+func newRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbProvider conn.DBProvider, id string) (CheckPoint, error) {
+	db, dbConns, err := createConns(tctx, cfg, dbProvider, 1)
 	if err != nil {
 		return nil, err
 	}
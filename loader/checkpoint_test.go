@@ -80,7 +80,7 @@ func (t *testCheckPointSuite) TestForDB(c *C) {
 
 	id := "test_for_db"
 	tctx := tcontext.Background()
-	cp, err := newRemoteCheckPoint(tctx, t.cfg, id)
+	cp, err := newRemoteCheckPoint(tctx, t.cfg, nil, id)
 	c.Assert(err, IsNil)
 	defer cp.Close()
 
@@ -135,7 +135,7 @@ func (t *testCheckPointSuite) TestForDB(c *C) {
 	c.Assert(count, Equals, len(cases))
 
 	// update checkpoints
-	db, conns, err := createConns(tctx, t.cfg, 1)
+	db, conns, err := createConns(tctx, t.cfg, nil, 1)
 	c.Assert(err, IsNil)
 	conn := conns[0]
 	defer func() {
@@ -22,7 +22,6 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/pingcap/failpoint"
 	tmysql "github.com/pingcap/parser/mysql"
-	"github.com/pingcap/tidb-tools/pkg/dbutil"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/config"
@@ -39,6 +38,8 @@ import (
 type DBConn struct {
 	cfg      *config.SubTaskConfig
 	baseConn *conn.BaseConn
+	// decide whether the errors are retryable, maybe shared by multiple connections, nil means the default rules
+	errClassifiers *retry.Classifiers
 
 	// generate new BaseConn and close old one
 	resetBaseConnFn func(*tcontext.Context, *conn.BaseConn) (*conn.BaseConn, error)
@@ -49,6 +50,7 @@ func (conn *DBConn) querySQL(ctx *tcontext.Context, query string, args ...interf
 		return nil, terror.ErrDBUnExpect.Generate("database connection not valid")
 	}
 
+	var pausedBy string
 	params := retry.Params{
 		RetryCount:         10,
 		FirstRetryDuration: time.Second,
@@ -65,7 +67,7 @@ func (conn *DBConn) querySQL(ctx *tcontext.Context, query string, args ...interf
 				}
 				return true
 			}
-			if dbutil.IsRetryableError(err) {
+			if conn.isRetryableError(err, &pausedBy) {
 				ctx.L().Warn("query statement", zap.Int("retry", retryTime),
 					zap.String("query", utils.TruncateString(query, -1)),
 					zap.String("argument", utils.TruncateInterface(args, -1)),
@@ -95,6 +97,7 @@ func (conn *DBConn) querySQL(ctx *tcontext.Context, query string, args ...interf
 			return ret, err
 		})
 	if err != nil {
+		err = retry.PausedByClassifier(err, pausedBy)
 		ctx.L().ErrorFilterContextCanceled("query statement failed after retry",
 			zap.String("query", utils.TruncateString(query, -1)),
 			zap.String("argument", utils.TruncateInterface(args, -1)),
@@ -113,6 +116,7 @@ func (conn *DBConn) executeSQL(ctx *tcontext.Context, queries []string, args ...
 		return terror.ErrDBUnExpect.Generate("database connection not valid")
 	}
 
+	var pausedBy string
 	params := retry.Params{
 		RetryCount:         10,
 		FirstRetryDuration: 2 * time.Second,
@@ -130,7 +134,7 @@ func (conn *DBConn) executeSQL(ctx *tcontext.Context, queries []string, args ...
 				}
 				return true
 			}
-			if dbutil.IsRetryableError(err) {
+			if conn.isRetryableError(err, &pausedBy) {
 				ctx.L().Warn("execute statements", zap.Int("retry", retryTime),
 					zap.String("queries", utils.TruncateInterface(queries, -1)),
 					zap.String("arguments", utils.TruncateInterface(args, -1)),
@@ -172,6 +176,7 @@ func (conn *DBConn) executeSQL(ctx *tcontext.Context, queries []string, args ...
 		})
 
 	if err != nil {
+		err = retry.PausedByClassifier(err, pausedBy)
 		ctx.L().ErrorFilterContextCanceled("execute statements failed after retry",
 			zap.String("queries", utils.TruncateInterface(queries, -1)),
 			zap.String("arguments", utils.TruncateInterface(args, -1)),
@@ -191,8 +196,24 @@ func (conn *DBConn) resetConn(tctx *tcontext.Context) error {
 	return nil
 }
 
-func createConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, workerCount int) (*conn.BaseDB, []*DBConn, error) {
-	baseDB, err := conn.DefaultDBProvider.Apply(cfg.To)
+// isRetryableError decides whether the error is retryable by the error classifiers, pausedBy is set to the name of the
+// classifier deciding the subtask should be paused by the error.
+func (conn *DBConn) isRetryableError(err error, pausedBy *string) bool {
+	cls := conn.errClassifiers.Classify(conn.cfg.Name, err)
+	*pausedBy = ""
+	if cls.Class == retry.ErrClassPause {
+		*pausedBy = cls.Classifier
+	}
+	return cls.Class == retry.ErrClassRetryable
+}
+
+// createConns creates the DB of downstream by the DB provider and workerCount connections of it,
+// conn.DefaultDBProvider is used if dbProvider is nil.
+func createConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbProvider conn.DBProvider, workerCount int) (*conn.BaseDB, []*DBConn, error) {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	baseDB, err := dbProvider.Apply(cfg.To)
 	if err != nil {
 		return nil, nil, terror.WithScope(err, terror.ScopeDownstream)
 	}
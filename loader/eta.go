@@ -0,0 +1,165 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// throughputWindowSize is the duration of throughput history used to estimate the rate of loading.
+var throughputWindowSize = 5 * time.Minute
+
+// ETAConfidence represents how reliable the estimated time of completion is.
+type ETAConfidence string
+
+// confidences of ETA.
+const (
+	// ETAConfidenceNone means no ETA can be estimated, e.g. nothing loaded in the window yet.
+	ETAConfidenceNone ETAConfidence = "none"
+	// ETAConfidenceLow means the rate is estimated from a short history or it varies a lot.
+	ETAConfidenceLow ETAConfidence = "low"
+	// ETAConfidenceMedium and ETAConfidenceHigh mean the window is mostly covered and the rate is relatively stable.
+	ETAConfidenceMedium ETAConfidence = "medium"
+	ETAConfidenceHigh   ETAConfidence = "high"
+)
+
+// LoadETA represents the estimated time of completion of the load unit.
+type LoadETA struct {
+	FinishedBytes  int64 `json:"finished-bytes"`
+	TotalBytes     int64 `json:"total-bytes"`
+	RemainingBytes int64 `json:"remaining-bytes"`
+	// Rate is the bytes loaded per second in the sliding window.
+	Rate       float64       `json:"rate"`
+	ETA        time.Duration `json:"eta"` // 0 if Confidence is none
+	Confidence ETAConfidence `json:"confidence"`
+}
+
+type throughputSample struct {
+	t     time.Time
+	bytes int64
+}
+
+// throughputWindow records the finished bytes in a sliding window to estimate the rate.
+type throughputWindow struct {
+	mu      sync.Mutex
+	size    time.Duration
+	samples []throughputSample
+}
+
+func newThroughputWindow(size time.Duration) *throughputWindow {
+	return &throughputWindow{size: size}
+}
+
+// add records the finished bytes at t, samples out of the window are dropped.
+// the history is reset if the finished bytes go back, e.g. restored again from checkpoint.
+func (w *throughputWindow) add(t time.Time, bytes int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if n := len(w.samples); n > 0 && (bytes < w.samples[n-1].bytes || t.Before(w.samples[n-1].t)) {
+		w.samples = w.samples[:0]
+	}
+	w.samples = append(w.samples, throughputSample{t: t, bytes: bytes})
+
+	// keep one sample earlier than the window, so the window is fully covered.
+	drop := 0
+	for drop+1 < len(w.samples) && !w.samples[drop+1].t.After(t.Add(-w.size)) {
+		drop++
+	}
+	w.samples = w.samples[drop:]
+}
+
+func (w *throughputWindow) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.samples = w.samples[:0]
+}
+
+// rate returns the bytes per second in the window, and the confidence of it.
+func (w *throughputWindow) rate() (float64, ETAConfidence) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := len(w.samples)
+	if n < 2 {
+		return 0, ETAConfidenceNone
+	}
+	first, last := w.samples[0], w.samples[n-1]
+	span := last.t.Sub(first.t).Seconds()
+	if span <= 0 || last.bytes == first.bytes {
+		return 0, ETAConfidenceNone
+	}
+	rate := float64(last.bytes-first.bytes) / span
+
+	// the confidence is decided by how much of the window is covered, and how stable the rates between samples are.
+	if n < 3 || span < w.size.Seconds()/2 {
+		return rate, ETAConfidenceLow
+	}
+	var sum, sumSquare float64
+	for i := 1; i < n; i++ {
+		r := 0.0
+		if d := w.samples[i].t.Sub(w.samples[i-1].t).Seconds(); d > 0 {
+			r = float64(w.samples[i].bytes-w.samples[i-1].bytes) / d
+		}
+		sum += r
+		sumSquare += r * r
+	}
+	mean := sum / float64(n-1)
+	cv := math.Sqrt(math.Max(sumSquare/float64(n-1)-mean*mean, 0)) / mean // coefficient of variation
+	switch {
+	case cv < 0.25:
+		return rate, ETAConfidenceHigh
+	case cv < 0.5:
+		return rate, ETAConfidenceMedium
+	default:
+		return rate, ETAConfidenceLow
+	}
+}
+
+// estimateETA estimates the time to load the remaining bytes in the rate.
+func estimateETA(finished, total int64, rate float64, confidence ETAConfidence) *LoadETA {
+	eta := &LoadETA{
+		FinishedBytes: finished,
+		TotalBytes:    total,
+		Rate:          rate,
+		Confidence:    confidence,
+	}
+	if total > finished {
+		eta.RemainingBytes = total - finished
+	}
+	if total == 0 {
+		// data files are not scanned yet.
+		eta.Confidence = ETAConfidenceNone
+		return eta
+	}
+	if eta.RemainingBytes == 0 {
+		eta.Confidence = ETAConfidenceHigh
+		return eta
+	}
+	if confidence != ETAConfidenceNone && rate > 0 {
+		eta.ETA = time.Duration(float64(eta.RemainingBytes) / rate * float64(time.Second))
+	}
+	return eta
+}
+
+// ETA returns the estimated time of completion, according to the rate in the throughput history and the remaining bytes.
+// the throughput history is sampled when printing status.
+func (l *Loader) ETA() *LoadETA {
+	finished, total := l.finishedDataSize.Get(), l.totalDataSize.Get()
+	if l.finish.Get() {
+		return &LoadETA{FinishedBytes: total, TotalBytes: total, Confidence: ETAConfidenceHigh}
+	}
+	rate, confidence := l.throughput.rate()
+	return estimateETA(finished, total, rate, confidence)
+}
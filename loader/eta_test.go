@@ -0,0 +1,93 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testETASuite{})
+
+type testETASuite struct{}
+
+func (t *testETASuite) TestThroughputWindow(c *C) {
+	var (
+		w     = newThroughputWindow(time.Minute)
+		start = time.Now()
+	)
+	rate, confidence := w.rate()
+	c.Assert(rate, Equals, 0.0)
+	c.Assert(confidence, Equals, ETAConfidenceNone)
+
+	// nothing loaded yet
+	w.add(start, 0)
+	w.add(start.Add(10*time.Second), 0)
+	_, confidence = w.rate()
+	c.Assert(confidence, Equals, ETAConfidenceNone)
+
+	// short history
+	w.add(start.Add(20*time.Second), 1000)
+	rate, confidence = w.rate()
+	c.Assert(rate, Equals, 50.0)
+	c.Assert(confidence, Equals, ETAConfidenceLow)
+
+	// stable rate, samples out of the window are dropped
+	for i := 3; i <= 12; i++ {
+		w.add(start.Add(time.Duration(i)*10*time.Second), int64(i-2)*1000)
+	}
+	c.Assert(w.samples, HasLen, 7)
+	c.Assert(w.samples[0].t, Equals, start.Add(60*time.Second))
+	rate, confidence = w.rate()
+	c.Assert(rate, Equals, 100.0)
+	c.Assert(confidence, Equals, ETAConfidenceHigh)
+
+	// unstable rate
+	w.add(start.Add(130*time.Second), 20000)
+	_, confidence = w.rate()
+	c.Assert(confidence, Equals, ETAConfidenceLow)
+
+	// reset if finished bytes go back
+	w.add(start.Add(140*time.Second), 100)
+	c.Assert(w.samples, HasLen, 1)
+	w.reset()
+	c.Assert(w.samples, HasLen, 0)
+}
+
+func (t *testETASuite) TestEstimateETA(c *C) {
+	eta := estimateETA(1000, 3000, 100, ETAConfidenceMedium)
+	c.Assert(eta, DeepEquals, &LoadETA{
+		FinishedBytes:  1000,
+		TotalBytes:     3000,
+		RemainingBytes: 2000,
+		Rate:           100,
+		ETA:            20 * time.Second,
+		Confidence:     ETAConfidenceMedium,
+	})
+
+	// no rate
+	eta = estimateETA(1000, 3000, 0, ETAConfidenceNone)
+	c.Assert(eta.ETA, Equals, time.Duration(0))
+	c.Assert(eta.RemainingBytes, Equals, int64(2000))
+
+	// all loaded
+	eta = estimateETA(3000, 3000, 0, ETAConfidenceNone)
+	c.Assert(eta.ETA, Equals, time.Duration(0))
+	c.Assert(eta.Confidence, Equals, ETAConfidenceHigh)
+
+	// not scanned
+	eta = estimateETA(0, 0, 0, ETAConfidenceNone)
+	c.Assert(eta.Confidence, Equals, ETAConfidenceNone)
+}
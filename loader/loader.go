@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/dm/pkg/dumpling"
 	fr "github.com/pingcap/dm/pkg/func-rollback"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
 	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
 
@@ -411,13 +412,26 @@ type Loader struct {
 
 	toDB      *conn.BaseDB
 	toDBConns []*DBConn
+	// dbProvider creates the DBs of downstream, conn.DefaultDBProvider is used if it's nil
+	dbProvider conn.DBProvider
+	// errClassifiers decides whether the errors of downstream are retryable, the default rules are used if it's nil
+	errClassifiers *retry.Classifiers
+	// poolSize is the number of concurrent workers and connections restoring data, pool-size in config is used if
+	// it's not positive
+	poolSize int
 
 	totalDataSize    sync2.AtomicInt64
 	totalFileCount   sync2.AtomicInt64 // schema + table + data
 	finishedDataSize sync2.AtomicInt64
+	throughput       *throughputWindow // history of finishedDataSize to estimate ETA
 	metaBinlog       sync2.AtomicString
 	metaBinlogGTID   sync2.AtomicString
 
+	// count of tables in the current round of restoring, classified by their checkpoint
+	finishedTableCount sync2.AtomicInt64 // finished before, skipped
+	resumedTableCount  sync2.AtomicInt64 // partially restored before, resumed from checkpoint
+	freshTableCount    sync2.AtomicInt64 // restored from the beginning
+
 	// record process error rather than log.Fatal
 	runFatalChan chan *pb.ProcessError
 
@@ -431,6 +445,7 @@ func NewLoader(cfg *config.SubTaskConfig) *Loader {
 		db2Tables:  make(map[string]Tables2DataFiles),
 		tableInfos: make(map[string]*tableInfo),
 		workerWg:   new(sync.WaitGroup),
+		throughput: newThroughputWindow(throughputWindowSize),
 		logger:     log.With(zap.String("task", cfg.Name), zap.String("unit", "load")),
 	}
 	loader.fileJobQueueClosed.Set(true) // not open yet
@@ -442,6 +457,31 @@ func (l *Loader) Type() pb.UnitType {
 	return pb.UnitType_Load
 }
 
+// SetDBProvider sets the provider to create the DBs of downstream, it should be called before Init.
+func (l *Loader) SetDBProvider(dbProvider conn.DBProvider) {
+	l.dbProvider = dbProvider
+}
+
+// SetErrorClassifiers sets the classifiers deciding whether the errors of downstream are retryable, it should be
+// called before Init.
+func (l *Loader) SetErrorClassifiers(classifiers *retry.Classifiers) {
+	l.errClassifiers = classifiers
+}
+
+// SetPoolSize sets the number of concurrent workers and connections restoring data instead of pool-size in config,
+// it should be called before Init.
+func (l *Loader) SetPoolSize(poolSize int) {
+	l.poolSize = poolSize
+}
+
+// workerPoolSize returns the number of concurrent workers and connections restoring data.
+func (l *Loader) workerPoolSize() int {
+	if l.poolSize > 0 {
+		return l.poolSize
+	}
+	return l.cfg.PoolSize
+}
+
 // Init initializes loader for a load task, but not start Process.
 // if fail, it should not call l.Close.
 func (l *Loader) Init(ctx context.Context) (err error) {
@@ -454,7 +494,7 @@ func (l *Loader) Init(ctx context.Context) (err error) {
 
 	tctx := tcontext.NewContext(ctx, l.logger)
 
-	checkpoint, err := newRemoteCheckPoint(tctx, l.cfg, l.checkpointID())
+	checkpoint, err := newRemoteCheckPoint(tctx, l.cfg, l.dbProvider, l.checkpointID())
 	failpoint.Inject("ignoreLoadCheckpointErr", func(_ failpoint.Value) {
 		l.logger.Info("", zap.String("failpoint", "ignoreLoadCheckpointErr"))
 		err = nil
@@ -484,7 +524,7 @@ func (l *Loader) Init(ctx context.Context) (err error) {
 
 	dbCfg := l.cfg.To
 	dbCfg.RawDBCfg = config.DefaultRawDBConfig().
-		SetMaxIdleConns(l.cfg.PoolSize)
+		SetMaxIdleConns(l.workerPoolSize())
 
 	// used to change loader's specified DB settings, currently SQL Mode
 	lcfg, err := l.cfg.Clone()
@@ -508,10 +548,13 @@ func (l *Loader) Init(ctx context.Context) (err error) {
 		lcfg.To.Session["sql_mode"] = l.cfg.LoaderConfig.SQLMode
 	}
 
-	l.toDB, l.toDBConns, err = createConns(tctx, lcfg, l.cfg.PoolSize)
+	l.toDB, l.toDBConns, err = createConns(tctx, lcfg, l.dbProvider, l.workerPoolSize())
 	if err != nil {
 		return err
 	}
+	for _, c := range l.toDBConns {
+		c.errClassifiers = l.errClassifiers
+	}
 
 	return nil
 }
@@ -532,7 +575,7 @@ func (l *Loader) Process(ctx context.Context, pr chan pb.ProcessResult) {
 		return
 	}
 
-	l.runFatalChan = make(chan *pb.ProcessError, 2*l.cfg.PoolSize)
+	l.runFatalChan = make(chan *pb.ProcessError, 2*l.workerPoolSize())
 	errs := make([]*pb.ProcessError, 0, 2)
 
 	var wg sync.WaitGroup
@@ -651,6 +694,7 @@ func (l *Loader) Restore(ctx context.Context) error {
 	// reset some counter used to calculate progress
 	l.totalDataSize.Set(0)
 	l.finishedDataSize.Set(0) // reset before load from checkpoint
+	l.throughput.reset()
 
 	if err := l.prepare(); err != nil {
 		l.logger.Error("scan directory failed", zap.String("directory", l.cfg.Dir), log.ShortError(err))
@@ -874,7 +918,7 @@ func (l *Loader) genRouter(rules []*router.TableRule) error {
 }
 
 func (l *Loader) initAndStartWorkerPool(ctx context.Context) error {
-	for i := 0; i < l.cfg.PoolSize; i++ {
+	for i := 0; i < l.workerPoolSize(); i++ {
 		worker := NewWorker(l, i)
 		l.workerWg.Add(1) // for every worker goroutine, Add(1)
 		go func() {
@@ -1203,8 +1247,9 @@ func (l *Loader) restoreData(ctx context.Context) error {
 	}()
 
 	dbConn := &DBConn{
-		cfg:      l.cfg,
-		baseConn: baseConn,
+		cfg:            l.cfg,
+		baseConn:       baseConn,
+		errClassifiers: l.errClassifiers,
 		resetBaseConnFn: func(*tcontext.Context, *conn.BaseConn) (*conn.BaseConn, error) {
 			return nil, terror.ErrDBBadConn.Generate("bad connection error restoreData")
 		},
@@ -1212,6 +1257,10 @@ func (l *Loader) restoreData(ctx context.Context) error {
 
 	dispatchMap := make(map[string]*fileJob)
 
+	l.finishedTableCount.Set(0)
+	l.resumedTableCount.Set(0)
+	l.freshTableCount.Set(0)
+
 	// restore db in sort
 	dbs := make([]string, 0, len(l.db2Tables))
 	for db := range l.db2Tables {
@@ -1248,8 +1297,14 @@ func (l *Loader) restoreData(ctx context.Context) error {
 
 			if l.checkPoint.IsTableFinished(db, table) {
 				l.logger.Info("table has finished, skip it.", zap.String("schema", db), zap.String("table", table))
+				l.finishedTableCount.Add(1)
 				continue
 			}
+			if l.checkPoint.IsTableCreated(db, table) {
+				l.resumedTableCount.Add(1)
+			} else {
+				l.freshTableCount.Add(1)
+			}
 
 			// create table
 			l.logger.Info("start to create table", zap.String("table file", tableFile))
@@ -32,16 +32,36 @@ func (l *Loader) Status(ctx context.Context) interface{} {
 	finishedSize := l.finishedDataSize.Get()
 	totalSize := l.totalDataSize.Get()
 	progress := percent(finishedSize, totalSize, l.finish.Get())
+	tableStats := l.TableRestoreStats()
 	s := &pb.LoadStatus{
 		FinishedBytes:  finishedSize,
 		TotalBytes:     totalSize,
 		Progress:       progress,
 		MetaBinlog:     l.metaBinlog.Get(),
 		MetaBinlogGTID: l.metaBinlogGTID.Get(),
+		FinishedTables: tableStats.Finished,
+		ResumedTables:  tableStats.Resumed,
+		FreshTables:    tableStats.Fresh,
 	}
 	return s
 }
 
+// TableRestoreStats represents how tables are restored by the load unit, according to the checkpoint.
+type TableRestoreStats struct {
+	Finished int64 `json:"finished"` // tables finished before, skipped in this round
+	Resumed  int64 `json:"resumed"`  // tables partially restored before, resumed from checkpoint
+	Fresh    int64 `json:"fresh"`    // tables restored from the beginning
+}
+
+// TableRestoreStats returns the statistics of tables in the current round of restoring.
+func (l *Loader) TableRestoreStats() TableRestoreStats {
+	return TableRestoreStats{
+		Finished: l.finishedTableCount.Get(),
+		Resumed:  l.resumedTableCount.Get(),
+		Fresh:    l.freshTableCount.Get(),
+	}
+}
+
 // PrintStatus prints status like progress percentage.
 func (l *Loader) PrintStatus(ctx context.Context) {
 	failpoint.Inject("PrintStatusCheckSeconds", func(val failpoint.Value) {
@@ -57,6 +77,7 @@ func (l *Loader) PrintStatus(ctx context.Context) {
 	newCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	l.throughput.add(time.Now(), l.finishedDataSize.Get())
 	var done bool
 	for {
 		select {
@@ -66,12 +87,14 @@ func (l *Loader) PrintStatus(ctx context.Context) {
 		}
 
 		finishedSize := l.finishedDataSize.Get()
+		l.throughput.add(time.Now(), finishedSize)
 		totalSize := l.totalDataSize.Get()
 		totalFileCount := l.totalFileCount.Get()
 		l.logger.Info("progress status of load",
 			zap.Int64("finished_bytes", finishedSize),
 			zap.Int64("total_bytes", totalSize),
 			zap.Int64("total_file_count", totalFileCount),
+			zap.Reflect("table_restore_stats", l.TableRestoreStats()),
 			zap.String("progress", percent(finishedSize, totalSize, l.finish.Get())))
 		progressGauge.WithLabelValues(l.cfg.Name, l.cfg.SourceID).Set(progress(finishedSize, totalSize, l.finish.Get()))
 		if done {
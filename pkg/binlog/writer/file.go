@@ -136,6 +136,60 @@ func (w *FileWriter) Write(rawData []byte) error {
 	return terror.ErrBinlogWriterWriteDataLen.Delegate(err, len(rawData))
 }
 
+// sparseHoleMinSize is the min size of a run of zero bytes written as a sparse hole by WriteSparse,
+// it's the block size of most file systems so the hole can be deallocated.
+const sparseHoleMinSize = 4096
+
+// WriteSparse writes/appends a binlog event's rawData like Write, but the longest run of zero bytes in it is written
+// as a sparse hole (by extending the file) if it's long enough, to save the disk space on file systems supporting
+// sparse files. the data read back is the same as written by Write.
+func (w *FileWriter) WriteSparse(rawData []byte) error {
+	start, end := longestZeroRun(rawData)
+	if end-start < sparseHoleMinSize {
+		return w.Write(rawData)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.stage != common.StagePrepared {
+		return terror.ErrBinlogWriterNeedStart.Generate(w.stage, common.StagePrepared)
+	}
+
+	n, err := w.file.Write(rawData[:start])
+	w.offset.Add(int64(n))
+	if err != nil {
+		return terror.ErrBinlogWriterWriteDataLen.Delegate(err, len(rawData))
+	}
+	// the file is opened with O_APPEND, so the following write appends after the extended size.
+	if err = w.file.Truncate(w.offset.Get() + int64(end-start)); err != nil {
+		return terror.ErrBinlogWriterWriteDataLen.Delegate(err, len(rawData))
+	}
+	w.offset.Add(int64(end - start))
+	n, err = w.file.Write(rawData[end:])
+	w.offset.Add(int64(n))
+	return terror.ErrBinlogWriterWriteDataLen.Delegate(err, len(rawData))
+}
+
+// longestZeroRun returns the range [start, end) of the longest run of zero bytes in data.
+func longestZeroRun(data []byte) (start, end int) {
+	for i := 0; i < len(data); {
+		if data[i] != 0 {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(data) && data[j] == 0 {
+			j++
+		}
+		if j-i > end-start {
+			start, end = i, j
+		}
+		i = j
+	}
+	return start, end
+}
+
 // Flush implements Writer.Flush.
 func (w *FileWriter) Flush() error {
 	w.mu.RLock()
@@ -122,3 +122,27 @@ func (t *testFileWriterSuite) TestWrite(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(dataInFile, DeepEquals, allData.Bytes())
 }
+
+func (t *testFileWriterSuite) TestWriteSparse(c *C) {
+	c.Assert(fmt.Sprint(longestZeroRun([]byte{1, 0, 0, 1, 0, 0, 0, 1})), Equals, "4 7")
+	c.Assert(fmt.Sprint(longestZeroRun([]byte{1, 2})), Equals, "0 0")
+
+	filename := filepath.Join(c.MkDir(), "test-mysql-bin.000001")
+	w := NewFileWriter(log.L(), &FileWriterConfig{Filename: filename}).(*FileWriter)
+	c.Assert(w.WriteSparse([]byte("test-data")), NotNil) // not prepared
+	c.Assert(w.Start(), IsNil)
+
+	var allData bytes.Buffer
+	data1 := []byte("short-zeros\x00\x00\x00")
+	data2 := append(append([]byte("head"), make([]byte, 3*sparseHoleMinSize)...), []byte("tail")...)
+	for _, data := range [][]byte{data1, data2, data1} {
+		c.Assert(w.WriteSparse(data), IsNil)
+		allData.Write(data)
+		c.Assert(w.Status().(*FileWriterStatus).Offset, Equals, int64(allData.Len()))
+	}
+	c.Assert(w.Close(), IsNil)
+
+	dataInFile, err := ioutil.ReadFile(filename)
+	c.Assert(err, IsNil)
+	c.Assert(dataInFile, DeepEquals, allData.Bytes())
+}
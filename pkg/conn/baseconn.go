@@ -23,6 +23,7 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/pingcap/failpoint"
 	gmysql "github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 
 	tcontext "github.com/pingcap/dm/pkg/context"
@@ -72,6 +73,9 @@ type BaseConn struct {
 	DBConn *sql.Conn
 
 	RetryStrategy retry.Strategy
+
+	createTime time.Time
+	lastActive sync2.AtomicInt64 // unix nano of the last statement executed
 }
 
 // NewBaseConn builds BaseConn to connect real DB
@@ -79,7 +83,13 @@ func NewBaseConn(conn *sql.Conn, strategy retry.Strategy) *BaseConn {
 	if strategy == nil {
 		strategy = &retry.FiniteRetryStrategy{}
 	}
-	return &BaseConn{conn, strategy}
+	baseConn := &BaseConn{DBConn: conn, RetryStrategy: strategy, createTime: time.Now()}
+	baseConn.lastActive.Set(baseConn.createTime.UnixNano())
+	return baseConn
+}
+
+func (conn *BaseConn) touch() {
+	conn.lastActive.Set(time.Now().UnixNano())
 }
 
 // SetRetryStrategy set retry strategy for baseConn
@@ -100,6 +110,7 @@ func (conn *BaseConn) QuerySQL(tctx *tcontext.Context, query string, args ...int
 		zap.String("query", utils.TruncateString(query, -1)),
 		zap.String("argument", utils.TruncateInterface(args, -1)))
 
+	conn.touch()
 	rows, err := conn.DBConn.QueryContext(tctx.Context(), query, args...)
 
 	if err != nil {
@@ -142,6 +153,7 @@ func (conn *BaseConn) ExecuteSQLWithIgnoreError(tctx *tcontext.Context, hVec *me
 		return 0, terror.ErrDBUnExpect.Generate("database connection not valid")
 	}
 
+	conn.touch()
 	startTime := time.Now()
 	txn, err := conn.DBConn.BeginTx(tctx.Context(), nil)
 
@@ -211,6 +223,47 @@ func (conn *BaseConn) ExecuteSQLWithIgnoreError(tctx *tcontext.Context, hVec *me
 	return l, nil
 }
 
+// ExecuteSQLWithoutTxn executes sqls one by one on real DB without a transaction, the executed ones are kept if one
+// of them failed.
+// return
+// 1. failed: (the index of sqls executed error, error)
+// 2. succeed: (len(sqls), nil)
+func (conn *BaseConn) ExecuteSQLWithoutTxn(tctx *tcontext.Context, hVec *metricsproxy.HistogramVecProxy, task string, queries []string, args ...[]interface{}) (int, error) {
+	if len(queries) == 0 {
+		return 0, nil
+	}
+	if conn == nil || conn.DBConn == nil {
+		return 0, terror.ErrDBUnExpect.Generate("database connection not valid")
+	}
+
+	conn.touch()
+	for i, query := range queries {
+		var arg []interface{}
+		if len(args) > i {
+			arg = args[i]
+		}
+
+		// avoid use TruncateInterface for all log level which will slow the speed of DML
+		if tctx.L().Core().Enabled(zap.DebugLevel) {
+			tctx.L().Debug("execute statement without transaction",
+				zap.String("query", utils.TruncateString(query, -1)),
+				zap.String("argument", utils.TruncateInterface(arg, -1)))
+		}
+
+		startTime := time.Now()
+		if _, err := conn.DBConn.ExecContext(tctx.Context(), query, arg...); err != nil {
+			tctx.L().ErrorFilterContextCanceled("execute statement failed",
+				zap.String("query", utils.TruncateString(query, -1)),
+				zap.String("argument", utils.TruncateInterface(arg, -1)), log.ShortError(err))
+			return i, terror.ErrDBExecuteFailed.Delegate(err, utils.TruncateString(query, -1))
+		}
+		if hVec != nil {
+			hVec.WithLabelValues("stmt", task).Observe(time.Since(startTime).Seconds())
+		}
+	}
+	return len(queries), nil
+}
+
 // ExecuteSQL executes sql on real DB,
 // return
 // 1. failed: (the index of sqls executed error, error)
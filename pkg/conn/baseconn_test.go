@@ -66,7 +66,7 @@ func (t *testBaseConnSuite) TestBaseConn(c *C) {
 	dbConn, err := db.Conn(tctx.Context())
 	c.Assert(err, IsNil)
 
-	baseConn = &BaseConn{dbConn, nil}
+	baseConn = &BaseConn{DBConn: dbConn}
 
 	err = baseConn.SetRetryStrategy(&retry.FiniteRetryStrategy{})
 	c.Assert(err, IsNil)
@@ -120,6 +120,17 @@ func (t *testBaseConnSuite) TestBaseConn(c *C) {
 	c.Assert(strings.Contains(err.Error(), "don't ignore me"), IsTrue)
 	c.Assert(affected, Equals, 1)
 
+	// executed one by one without transaction, the executed ones are kept.
+	mock.ExpectExec("create database test1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("create database test2").WillReturnError(errors.New("invalid connection"))
+	affected, err = baseConn.ExecuteSQLWithoutTxn(tctx, testStmtHistogram, "test", []string{"create database test1", "create database test2", "create database test3"})
+	c.Assert(terror.ErrDBExecuteFailed.Equal(err), IsTrue)
+	c.Assert(affected, Equals, 1)
+	mock.ExpectExec("create database test2").WillReturnResult(sqlmock.NewResult(1, 1))
+	affected, err = baseConn.ExecuteSQLWithoutTxn(tctx, testStmtHistogram, "test", []string{"create database test2"})
+	c.Assert(err, IsNil)
+	c.Assert(affected, Equals, 1)
+
 	if err = mock.ExpectationsWereMet(); err != nil {
 		c.Fatal("there were unexpected:", err)
 	}
@@ -189,6 +189,7 @@ func (d *BaseDB) Close() error {
 			err = terr
 		}
 	}
+	d.conns = make(map[*BaseConn]struct{})
 	terr := d.DB.Close()
 	d.doFuncInClose()
 
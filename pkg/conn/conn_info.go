@@ -0,0 +1,200 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/siddontang/go/sync2"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+// ConnPurpose represents what a connection is used for.
+type ConnPurpose string
+
+// purposes of connections.
+const (
+	// ConnPurposeBinlogRead reads binlog events from upstream by the replication protocol.
+	ConnPurposeBinlogRead ConnPurpose = "binlog-read"
+	// ConnPurposeQuery queries schemas and status of upstream.
+	ConnPurposeQuery ConnPurpose = "query"
+	// ConnPurposeDump dumps data from upstream.
+	ConnPurposeDump ConnPurpose = "dump"
+	// ConnPurposeLoad loads dumped data into downstream.
+	ConnPurposeLoad ConnPurpose = "load"
+	// ConnPurposeSyncWrite writes DMLs and DDLs of binlog events into downstream.
+	ConnPurposeSyncWrite ConnPurpose = "sync-write"
+	// ConnPurposeCheckpoint saves checkpoints in downstream.
+	ConnPurposeCheckpoint ConnPurpose = "checkpoint"
+	// ConnPurposeMeta saves metadata like online DDL and sharding groups in downstream.
+	ConnPurposeMeta ConnPurpose = "meta"
+)
+
+// sides of connections.
+const (
+	ConnSideUpstream   = "upstream"
+	ConnSideDownstream = "downstream"
+)
+
+// ConnInfo represents a connection held to upstream or downstream.
+type ConnInfo struct {
+	Purpose ConnPurpose `json:"purpose"`
+	Side    string      `json:"side"`
+	Addr    string      `json:"addr"`
+	// Pooled means it's an open connection in a pool not held by a BaseConn, its age and last activity are unknown.
+	Pooled     bool          `json:"pooled"`
+	CreateTime time.Time     `json:"create-time,omitempty"`
+	LastActive time.Time     `json:"last-active,omitempty"`
+	Age        time.Duration `json:"age,omitempty"`
+}
+
+// ConnAddr returns the address of the database in connection infos.
+func ConnAddr(cfg config.DBConfig) string {
+	return fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+}
+
+// ConnInfos returns the infos of BaseConns held by the BaseDB sorted by create time, followed by the other open
+// connections in the pool.
+func (d *BaseDB) ConnInfos(purpose ConnPurpose, side, addr string) []ConnInfo {
+	if d == nil || d.DB == nil {
+		return nil
+	}
+	now := time.Now()
+	d.mu.Lock()
+	infos := make([]ConnInfo, 0, len(d.conns))
+	for c := range d.conns {
+		infos = append(infos, ConnInfo{
+			Purpose:    purpose,
+			Side:       side,
+			Addr:       addr,
+			CreateTime: c.createTime,
+			LastActive: time.Unix(0, c.lastActive.Get()),
+			Age:        now.Sub(c.createTime),
+		})
+	}
+	d.mu.Unlock()
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreateTime.Before(infos[j].CreateTime)
+	})
+	return append(infos, pooledConnInfos(d.DB, len(infos), purpose, side, addr)...)
+}
+
+// PoolConnInfos returns the infos of open connections in the pool of the DB.
+func PoolConnInfos(db *sql.DB, purpose ConnPurpose, side, addr string) []ConnInfo {
+	if db == nil {
+		return nil
+	}
+	return pooledConnInfos(db, 0, purpose, side, addr)
+}
+
+// pooledConnInfos returns the infos of open connections in the pool except the held ones.
+func pooledConnInfos(db *sql.DB, held int, purpose ConnPurpose, side, addr string) []ConnInfo {
+	n := db.Stats().OpenConnections - held
+	if n <= 0 {
+		return nil
+	}
+	infos := make([]ConnInfo, 0, n)
+	for i := 0; i < n; i++ {
+		infos = append(infos, ConnInfo{Purpose: purpose, Side: side, Addr: addr, Pooled: true})
+	}
+	return infos
+}
+
+// ConnActivity tracks the activity of a connection not managed by a pool, like the binlog dump connection.
+type ConnActivity struct {
+	createTime sync2.AtomicInt64 // unix nano, 0 means no connection
+	lastActive sync2.AtomicInt64
+}
+
+// Start records a new connection.
+func (a *ConnActivity) Start() {
+	now := time.Now().UnixNano()
+	a.lastActive.Set(now)
+	a.createTime.Set(now)
+}
+
+// Touch records the activity of the connection.
+func (a *ConnActivity) Touch() {
+	a.lastActive.Set(time.Now().UnixNano())
+}
+
+// Stop records the connection is closed.
+func (a *ConnActivity) Stop() {
+	a.createTime.Set(0)
+}
+
+// ConnInfos returns the info of the connection, it's empty if no connection.
+func (a *ConnActivity) ConnInfos(purpose ConnPurpose, side, addr string) []ConnInfo {
+	createTime := a.createTime.Get()
+	if createTime == 0 {
+		return nil
+	}
+	info := ConnInfo{
+		Purpose:    purpose,
+		Side:       side,
+		Addr:       addr,
+		CreateTime: time.Unix(0, createTime),
+		LastActive: time.Unix(0, a.lastActive.Get()),
+	}
+	info.Age = time.Since(info.CreateTime)
+	return []ConnInfo{info}
+}
+
+// PoolStats represents the stats of connection pools, it's the sum of the pools if aggregated.
+type PoolStats struct {
+	// MaxOpen is the configured max number of open connections, 0 means unlimited.
+	MaxOpen int `json:"max-open"`
+	InUse   int `json:"in-use"`
+	Idle    int `json:"idle"`
+	// WaitCount and WaitDuration are the total number of and the time blocked waiting for a new connection, the
+	// rising of them indicates the pool is undersized.
+	WaitCount    int64         `json:"wait-count"`
+	WaitDuration time.Duration `json:"wait-duration"`
+}
+
+// Add adds the stats of another pool.
+func (s *PoolStats) Add(other PoolStats) {
+	s.MaxOpen += other.MaxOpen
+	s.InUse += other.InUse
+	s.Idle += other.Idle
+	s.WaitCount += other.WaitCount
+	s.WaitDuration += other.WaitDuration
+}
+
+// DBPoolStats returns the stats of the connection pool of the DB.
+func DBPoolStats(db *sql.DB) PoolStats {
+	if db == nil {
+		return PoolStats{}
+	}
+	st := db.Stats()
+	return PoolStats{
+		MaxOpen:      st.MaxOpenConnections,
+		InUse:        st.InUse,
+		Idle:         st.Idle,
+		WaitCount:    st.WaitCount,
+		WaitDuration: st.WaitDuration,
+	}
+}
+
+// PoolStats returns the stats of the connection pool of the BaseDB.
+func (d *BaseDB) PoolStats() PoolStats {
+	if d == nil {
+		return PoolStats{}
+	}
+	return DBPoolStats(d.DB)
+}
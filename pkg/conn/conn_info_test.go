@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	tcontext "github.com/pingcap/dm/pkg/context"
+)
+
+var _ = Suite(&testConnInfoSuite{})
+
+type testConnInfoSuite struct{}
+
+func (t *testConnInfoSuite) TestConnInfos(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	baseDB := NewBaseDB(db, func() {})
+	tctx := tcontext.Background()
+	addr := ConnAddr(config.DBConfig{Host: "127.0.0.1", Port: 4000})
+	c.Assert(addr, Equals, "127.0.0.1:4000")
+	// the connection opened by sqlmock is in the pool.
+	infos := baseDB.ConnInfos(ConnPurposeSyncWrite, ConnSideDownstream, addr)
+	c.Assert(infos, HasLen, 1)
+	c.Assert(infos[0].Pooled, IsTrue)
+
+	baseConn, err := baseDB.GetBaseConn(tctx.Context())
+	c.Assert(err, IsNil)
+	// a connection in the pool not held by a BaseConn.
+	sqlConn, err := db.Conn(tctx.Context())
+	c.Assert(err, IsNil)
+
+	infos = baseDB.ConnInfos(ConnPurposeSyncWrite, ConnSideDownstream, addr)
+	c.Assert(infos, HasLen, 2)
+	c.Assert(infos[0].Purpose, Equals, ConnPurposeSyncWrite)
+	c.Assert(infos[0].Side, Equals, ConnSideDownstream)
+	c.Assert(infos[0].Addr, Equals, addr)
+	c.Assert(infos[0].Pooled, IsFalse)
+	c.Assert(infos[0].CreateTime, Equals, baseConn.createTime)
+	c.Assert(infos[0].LastActive.Equal(baseConn.createTime), IsTrue)
+	c.Assert(infos[1].Pooled, IsTrue)
+	c.Assert(infos[1].CreateTime.IsZero(), IsTrue)
+
+	time.Sleep(10 * time.Millisecond)
+	mock.ExpectQuery("select 1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	rows, err := baseConn.QuerySQL(tctx, "select 1")
+	c.Assert(err, IsNil)
+	c.Assert(rows.Close(), IsNil)
+	infos = baseDB.ConnInfos(ConnPurposeSyncWrite, ConnSideDownstream, addr)
+	c.Assert(infos[0].LastActive.After(infos[0].CreateTime), IsTrue)
+	c.Assert(infos[0].Age >= 10*time.Millisecond, IsTrue)
+
+	stats := baseDB.PoolStats()
+	c.Assert(stats.InUse, Equals, 2)
+	c.Assert(stats.Idle, Equals, 0)
+	c.Assert(stats.MaxOpen, Equals, 0)
+
+	c.Assert(sqlConn.Close(), IsNil)
+	c.Assert(baseDB.CloseBaseConn(baseConn), IsNil)
+	c.Assert(PoolConnInfos(nil, ConnPurposeQuery, ConnSideUpstream, addr), HasLen, 0)
+	stats.Add(baseDB.PoolStats())
+	c.Assert(stats.InUse, Equals, 2)
+	c.Assert(stats.Idle, Equals, 1)
+	c.Assert(DBPoolStats(nil), Equals, PoolStats{})
+	c.Assert((*BaseDB)(nil).PoolStats(), Equals, PoolStats{})
+
+	mock.ExpectClose()
+	c.Assert(baseDB.Close(), IsNil)
+	c.Assert(baseDB.ConnInfos(ConnPurposeSyncWrite, ConnSideDownstream, addr), HasLen, 0)
+}
+
+func (t *testConnInfoSuite) TestConnActivity(c *C) {
+	var a ConnActivity
+	c.Assert(a.ConnInfos(ConnPurposeBinlogRead, ConnSideUpstream, "127.0.0.1:3306"), HasLen, 0)
+
+	a.Start()
+	infos := a.ConnInfos(ConnPurposeBinlogRead, ConnSideUpstream, "127.0.0.1:3306")
+	c.Assert(infos, HasLen, 1)
+	c.Assert(infos[0].Purpose, Equals, ConnPurposeBinlogRead)
+	c.Assert(infos[0].LastActive, Equals, infos[0].CreateTime)
+
+	time.Sleep(10 * time.Millisecond)
+	a.Touch()
+	infos = a.ConnInfos(ConnPurposeBinlogRead, ConnSideUpstream, "127.0.0.1:3306")
+	c.Assert(infos[0].LastActive.After(infos[0].CreateTime), IsTrue)
+
+	a.Stop()
+	c.Assert(a.ConnInfos(ConnPurposeBinlogRead, ConnSideUpstream, "127.0.0.1:3306"), HasLen, 0)
+}
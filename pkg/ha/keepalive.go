@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/dm/dm/common"
 	"github.com/pingcap/dm/pkg/etcdutil"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
 )
 
 var (
@@ -33,6 +34,9 @@ var (
 	CurrentKeepAliveTTL int64
 	// KeepAliveUpdateCh is used to notify keepalive TTL changing, in order to let watcher not see a DELETE of old key
 	KeepAliveUpdateCh = make(chan int64, 10)
+	// leaseCheckInterval is the interval to check whether the lease has expired locally,
+	// so a long pause of the process (like GC) can be detected without waiting for etcd's response.
+	leaseCheckInterval = 500 * time.Millisecond
 )
 
 // WorkerEvent represents the PUT/DELETE keepalive event of DM-worker.
@@ -132,14 +136,27 @@ func KeepAlive(ctx context.Context, cli *clientv3.Client, workerName string, kee
 	if err != nil {
 		return err
 	}
+
+	// leaseDeadline is the latest time the lease is known to be alive,
+	// if we can't refresh it before this time, the lease may have been expired in etcd.
+	leaseDeadline := time.Now().Add(time.Duration(keepAliveTTL) * time.Second)
+	ticker := time.NewTicker(leaseCheckInterval)
+	defer ticker.Stop()
 	for {
 		select {
-		case _, ok := <-ch:
+		case resp, ok := <-ch:
 			if !ok {
 				log.L().Info("keep alive channel is closed")
 				keepAliveCancel() // make go vet happy
 				return nil
 			}
+			leaseDeadline = time.Now().Add(time.Duration(resp.TTL) * time.Second)
+		case <-ticker.C:
+			if time.Now().After(leaseDeadline) {
+				log.L().Warn("keepalive lease may have expired", zap.Time("deadline", leaseDeadline))
+				keepAliveCancel() // make go vet happy
+				return terror.ErrWorkerSourceBindingLost.Generate(workerName)
+			}
 		case <-ctx.Done():
 			log.L().Info("ctx is canceled, keepalive will exit now")
 			keepAliveCancel() // make go vet happy
@@ -162,6 +179,7 @@ func KeepAlive(ctx context.Context, cli *clientv3.Client, workerName string, kee
 				return err
 			}
 			log.L().Info("dynamically changed keepalive TTL to", zap.Int64("ttl in seconds", newTTL))
+			leaseDeadline = time.Now().Add(time.Duration(newTTL) * time.Second)
 
 			// after new keepalive is succeed, we cancel the old keepalive
 			oldCancel()
@@ -14,13 +14,22 @@
 package ha
 
 import (
+	"context"
+
 	"go.etcd.io/etcd/clientv3"
 	"go.etcd.io/etcd/mvcc/mvccpb"
 
+	"github.com/pingcap/dm/dm/common"
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/pkg/etcdutil"
 )
 
+// KV represents a raw key-value pair in etcd.
+type KV struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // PutRelayStageSourceBound puts the following data in one txn.
 // - relay stage.
 // - source bound relationship.
@@ -98,3 +107,36 @@ func opSubTaskCfgStage(cli *clientv3.Client, evType mvccpb.Event_EventType,
 	_, rev, err := etcdutil.DoOpsInOneTxnWithRetry(cli, ops...)
 	return rev, err
 }
+
+// GetSourceSnapshot gets the following data in one txn, so they are at the same revision.
+// - upstream source config.
+// - relay stage.
+// - subtask configs and stages of the source.
+// - source bound relationship and the last one of the DM-worker.
+// - relay config of the DM-worker.
+// the raw key-value pairs are returned, so they can be put back to etcd as is.
+func GetSourceSnapshot(ctx context.Context, cli *clientv3.Client, source, worker string) ([]KV, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Txn(ctx).Then(
+		clientv3.OpGet(common.UpstreamConfigKeyAdapter.Encode(source)),
+		clientv3.OpGet(common.StageRelayKeyAdapter.Encode(source)),
+		clientv3.OpGet(common.UpstreamSubTaskKeyAdapter.Encode(source), clientv3.WithPrefix()),
+		clientv3.OpGet(common.StageSubTaskKeyAdapter.Encode(source), clientv3.WithPrefix()),
+		clientv3.OpGet(common.UpstreamBoundWorkerKeyAdapter.Encode(worker)),
+		clientv3.OpGet(common.UpstreamLastBoundWorkerKeyAdapter.Encode(worker)),
+		clientv3.OpGet(common.UpstreamRelayWorkerKeyAdapter.Encode(worker)),
+	).Commit()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var kvs []KV
+	for _, opResp := range resp.Responses {
+		for _, kv := range opResp.GetResponseRange().Kvs {
+			kvs = append(kvs, KV{Key: string(kv.Key), Value: string(kv.Value)})
+		}
+	}
+	return kvs, resp.Header.Revision, nil
+}
@@ -14,10 +14,14 @@
 package ha
 
 import (
+	"context"
+
 	. "github.com/pingcap/check"
+	"go.etcd.io/etcd/clientv3"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/etcdutil"
 )
 
 func (t *testForEtcd) TestOpsEtcd(c *C) {
@@ -130,3 +134,70 @@ func (t *testForEtcd) TestOpsEtcd(c *C) {
 	c.Assert(rev9, Equals, rev8)
 	c.Assert(stsm, HasLen, 0)
 }
+
+func (t *testForEtcd) TestGetSourceSnapshot(c *C) {
+	defer clearTestInfoOperation(c)
+
+	var (
+		source     = "mysql-replica-1"
+		worker     = "dm-worker-1"
+		task       = "task-1"
+		relayStage = NewRelayStage(pb.Stage_Running, source)
+		stage      = NewSubTaskStage(pb.Stage_Running, source, task)
+		bound      = NewSourceBound(source, worker)
+		sourceCfg  config.SourceConfig
+		subtaskCfg config.SubTaskConfig
+	)
+	c.Assert(sourceCfg.LoadFromFile(sourceSampleFile), IsNil)
+	sourceCfg.SourceID = source
+	c.Assert(subtaskCfg.DecodeFile(subTaskSampleFile, true), IsNil)
+	subtaskCfg.SourceID = source
+	subtaskCfg.Name = task
+	c.Assert(subtaskCfg.Adjust(true), IsNil)
+
+	// nothing in etcd.
+	kvs, rev1, err := GetSourceSnapshot(context.Background(), etcdTestCli, source, worker)
+	c.Assert(err, IsNil)
+	c.Assert(rev1, Greater, int64(0))
+	c.Assert(kvs, HasLen, 0)
+
+	_, err = PutRelayStageSourceBound(etcdTestCli, relayStage, bound)
+	c.Assert(err, IsNil)
+	_, err = PutSourceCfg(etcdTestCli, sourceCfg)
+	c.Assert(err, IsNil)
+	_, err = PutRelayConfig(etcdTestCli, source, worker)
+	c.Assert(err, IsNil)
+	defer func() {
+		_, err2 := DeleteRelayConfig(etcdTestCli, worker)
+		c.Assert(err2, IsNil)
+	}()
+	rev2, err := PutSubTaskCfgStage(etcdTestCli, []config.SubTaskConfig{subtaskCfg}, []Stage{stage})
+	c.Assert(err, IsNil)
+	// keys of other sources are not included.
+	_, err = PutRelayStage(etcdTestCli, NewRelayStage(pb.Stage_Running, "mysql-replica-2"))
+	c.Assert(err, IsNil)
+
+	kvs, rev3, err := GetSourceSnapshot(context.Background(), etcdTestCli, source, worker)
+	c.Assert(err, IsNil)
+	c.Assert(rev3, Greater, rev2)
+	// source config, relay stage, subtask config and stage, bound and last bound, relay config.
+	c.Assert(kvs, HasLen, 7)
+
+	// restore the snapshot after deleted.
+	c.Assert(ClearTestInfoOperation(etcdTestCli), IsNil)
+	ops := make([]clientv3.Op, 0, len(kvs))
+	for _, kv := range kvs {
+		ops = append(ops, clientv3.OpPut(kv.Key, kv.Value))
+	}
+	_, _, err = etcdutil.DoOpsInOneTxnWithRetry(etcdTestCli, ops...)
+	c.Assert(err, IsNil)
+	kvs2, _, err := GetSourceSnapshot(context.Background(), etcdTestCli, source, worker)
+	c.Assert(err, IsNil)
+	c.Assert(kvs2, DeepEquals, kvs)
+	scm, _, err := GetSourceCfg(etcdTestCli, source, 0)
+	c.Assert(err, IsNil)
+	c.Assert(scm[source], DeepEquals, sourceCfg)
+	stcm, _, err := GetSubTaskCfg(etcdTestCli, source, task, 0)
+	c.Assert(err, IsNil)
+	c.Assert(stcm[task], DeepEquals, subtaskCfg)
+}
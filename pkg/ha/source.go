@@ -97,6 +97,28 @@ func sourceCfgFromResp(source string, resp *clientv3.GetResponse) (map[string]co
 	return scm, nil
 }
 
+// SourceKeyPrefixes returns the etcd keys which are read or written for the source and the worker bound to it.
+// a key ends with "/" is a prefix which covers keys of all subtasks in the source, others are exact keys.
+// this is mainly used to inspect or clean up etcd keys of a source by tools like `etcdctl`.
+func SourceKeyPrefixes(source, worker string) []string {
+	keys := []string{
+		common.UpstreamConfigKeyAdapter.Encode(source),
+		common.StageRelayKeyAdapter.Encode(source),
+		common.UpstreamSubTaskKeyAdapter.Encode(source) + "/",
+		common.StageSubTaskKeyAdapter.Encode(source) + "/",
+	}
+	if worker != "" {
+		keys = append(keys,
+			common.WorkerRegisterKeyAdapter.Encode(worker),
+			common.WorkerKeepAliveKeyAdapter.Encode(worker),
+			common.UpstreamBoundWorkerKeyAdapter.Encode(worker),
+			common.UpstreamLastBoundWorkerKeyAdapter.Encode(worker),
+			common.UpstreamRelayWorkerKeyAdapter.Encode(worker),
+		)
+	}
+	return keys
+}
+
 // ClearTestInfoOperation is used to clear all DM-HA relative etcd keys' information
 // this function shouldn't be used in development environment
 func ClearTestInfoOperation(cli *clientv3.Client) error {
@@ -15,6 +15,7 @@ package ha
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	. "github.com/pingcap/check"
@@ -22,6 +23,7 @@ import (
 	"go.etcd.io/etcd/integration"
 
 	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
 )
 
 const (
@@ -105,3 +107,38 @@ func (t *testForEtcd) TestSourceEtcd(c *C) {
 	c.Assert(rev4, Equals, deleteResp.Header.Revision)
 	c.Assert(scm3, HasLen, 0)
 }
+
+func (t *testForEtcd) TestSourceKeyPrefixes(c *C) {
+	defer clearTestInfoOperation(c)
+
+	var cfg config.SourceConfig
+	c.Assert(cfg.LoadFromFile(sourceSampleFile), IsNil)
+	source := cfg.SourceID
+	worker := "dm-worker-1"
+
+	// put keys of the source and another source whose ID has the same prefix.
+	_, err := PutSourceCfg(etcdTestCli, cfg)
+	c.Assert(err, IsNil)
+	_, err = PutRelayStage(etcdTestCli, NewRelayStage(pb.Stage_Running, source))
+	c.Assert(err, IsNil)
+	_, err = PutSubTaskStage(etcdTestCli, NewSubTaskStage(pb.Stage_Running, source, "task-1"),
+		NewSubTaskStage(pb.Stage_Running, source+"0", "task-1"))
+	c.Assert(err, IsNil)
+	_, err = PutSourceBound(etcdTestCli, NewSourceBound(source, worker))
+	c.Assert(err, IsNil)
+
+	keys := SourceKeyPrefixes(source, worker)
+	c.Assert(SourceKeyPrefixes(source, ""), HasLen, 4)
+	var count int64
+	for _, key := range keys {
+		opts := []clientv3.OpOption{clientv3.WithCountOnly()}
+		if strings.HasSuffix(key, "/") {
+			opts = append(opts, clientv3.WithPrefix())
+		}
+		resp, err2 := etcdTestCli.Get(context.Background(), key, opts...)
+		c.Assert(err2, IsNil)
+		count += resp.Count
+	}
+	// source config, relay stage, subtask stage, bound and last bound.
+	c.Assert(count, Equals, int64(5))
+}
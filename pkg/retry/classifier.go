@@ -0,0 +1,137 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// ErrorClass is the class of a DB error decided by an ErrorClassifier.
+type ErrorClass string
+
+// the classes of DB errors.
+const (
+	// ErrClassUndecided means the classifier doesn't know the error, and leaves it to the next one.
+	ErrClassUndecided ErrorClass = ""
+	// ErrClassRetryable means the operation is retried by the unit.
+	ErrClassRetryable ErrorClass = "retryable"
+	// ErrClassPermanent means the operation is not retried, the subtask is paused and may be auto resumed.
+	ErrClassPermanent ErrorClass = "permanent"
+	// ErrClassPause means the operation is not retried, the subtask is paused and not auto resumed.
+	ErrClassPause ErrorClass = "pause"
+)
+
+// DefaultClassifierName is the name of the classifier deciding the errors not known by the registered ones.
+const DefaultClassifierName = "default"
+
+// maxClassifications is the max number of the recent classifications kept.
+const maxClassifications = 50
+
+// ErrorClassifier decides whether a DB error met by the units is retryable, permanent or should pause the subtask.
+// the connection errors always reset the connection and retry before consulting the classifiers.
+type ErrorClassifier interface {
+	// Name returns the name of the classifier shown in the classifications.
+	Name() string
+	// Classify returns the class of the error, or ErrClassUndecided if it doesn't know the error.
+	Classify(err error) ErrorClass
+}
+
+// Classification represents the decision on a DB error met by a subtask.
+type Classification struct {
+	Time       time.Time  `json:"time"`
+	Task       string     `json:"task"`
+	Error      string     `json:"error"`
+	Class      ErrorClass `json:"class"`
+	Classifier string     `json:"classifier"`
+}
+
+// Classifiers consults the registered classifiers in order, and decides by the default rules if none of them knows
+// the error. it records the recent classifications, and it's safe for concurrent use. a nil *Classifiers only
+// decides by the default rules.
+type Classifiers struct {
+	sync.RWMutex
+	classifiers []ErrorClassifier
+	recent      []Classification
+}
+
+// NewClassifiers creates Classifiers without any registered classifier.
+func NewClassifiers() *Classifiers {
+	return &Classifiers{}
+}
+
+// Register appends a classifier, which is consulted after the ones registered before.
+func (c *Classifiers) Register(classifier ErrorClassifier) {
+	c.Lock()
+	defer c.Unlock()
+	c.classifiers = append(c.classifiers, classifier)
+}
+
+// Classify decides the class of the error met by the subtask.
+func (c *Classifiers) Classify(task string, err error) Classification {
+	if c == nil {
+		return defaultClassify(task, err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	cls := Classification{Task: task, Error: err.Error()}
+	for _, classifier := range c.classifiers {
+		if class := classifier.Classify(err); class != ErrClassUndecided {
+			cls.Class, cls.Classifier = class, classifier.Name()
+			break
+		}
+	}
+	if cls.Class == ErrClassUndecided {
+		cls = defaultClassify(task, err)
+	}
+	cls.Time = time.Now()
+	c.recent = append(c.recent, cls)
+	if len(c.recent) > maxClassifications {
+		c.recent = c.recent[len(c.recent)-maxClassifications:]
+	}
+	return cls
+}
+
+// Recent returns the recent classifications, the latest one is the last.
+func (c *Classifiers) Recent() []Classification {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	return append([]Classification{}, c.recent...)
+}
+
+// defaultClassify retries the errors retryable by dbutil.IsRetryableError, others are permanent.
+func defaultClassify(task string, err error) Classification {
+	cls := Classification{Task: task, Error: err.Error(), Class: ErrClassPermanent, Classifier: DefaultClassifierName}
+	if dbutil.IsRetryableError(err) {
+		cls.Class = ErrClassRetryable
+	}
+	return cls
+}
+
+// PausedByClassifier wraps the error to pause the subtask without auto resuming it, if the classifier decided it as
+// ErrClassPause. the error is returned as is if the classifier is empty.
+func PausedByClassifier(err error, classifier string) error {
+	if err == nil || classifier == "" {
+		return err
+	}
+	return terror.ErrDBPausedByClassifier.Delegate(err, classifier)
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/errno"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testClassifierSuite{})
+
+type testClassifierSuite struct{}
+
+type mockClassifier struct {
+	name   string
+	target error
+	class  ErrorClass
+}
+
+func (m *mockClassifier) Name() string {
+	return m.name
+}
+
+func (m *mockClassifier) Classify(err error) ErrorClass {
+	if err == m.target {
+		return m.class
+	}
+	return ErrClassUndecided
+}
+
+func (t *testClassifierSuite) TestClassifiers(c *C) {
+	var (
+		errProxy   = errors.New("proxy error")
+		errUnknown = errors.New("unknown error")
+		nilCls     *Classifiers
+	)
+
+	// nil classifiers decide by the default rules.
+	cls := nilCls.Classify("task", &mysql.MySQLError{Number: errno.ErrLockDeadlock})
+	c.Assert(cls.Class, Equals, ErrClassRetryable)
+	c.Assert(cls.Classifier, Equals, DefaultClassifierName)
+	c.Assert(nilCls.Classify("task", errProxy).Class, Equals, ErrClassPermanent)
+	c.Assert(nilCls.Recent(), HasLen, 0)
+
+	classifiers := NewClassifiers()
+	classifiers.Register(&mockClassifier{name: "first", target: errProxy, class: ErrClassRetryable})
+	classifiers.Register(&mockClassifier{name: "second", target: errProxy, class: ErrClassPause})
+	cls = classifiers.Classify("task", errProxy)
+	c.Assert(cls.Class, Equals, ErrClassRetryable)
+	c.Assert(cls.Classifier, Equals, "first")
+	cls = classifiers.Classify("task", errUnknown)
+	c.Assert(cls.Class, Equals, ErrClassPermanent)
+	c.Assert(cls.Classifier, Equals, DefaultClassifierName)
+
+	recent := classifiers.Recent()
+	c.Assert(recent, HasLen, 2)
+	c.Assert(recent[0].Task, Equals, "task")
+	c.Assert(recent[0].Error, Equals, errProxy.Error())
+	c.Assert(recent[0].Time.IsZero(), IsFalse)
+	c.Assert(recent[1].Error, Equals, errUnknown.Error())
+
+	for i := 0; i < maxClassifications; i++ {
+		classifiers.Classify("task", errProxy)
+	}
+	recent = classifiers.Recent()
+	c.Assert(recent, HasLen, maxClassifications)
+	c.Assert(recent[0].Error, Equals, errProxy.Error())
+}
+
+func (t *testClassifierSuite) TestPausedByClassifier(c *C) {
+	err := errors.New("proxy error")
+	c.Assert(PausedByClassifier(err, ""), Equals, err)
+	c.Assert(PausedByClassifier(nil, "proxy"), IsNil)
+	err = PausedByClassifier(err, "proxy")
+	c.Assert(terror.ErrDBPausedByClassifier.Equal(err), IsTrue)
+	_, ok := UnresumableErrCodes[int32(terror.ErrDBPausedByClassifier.Code())]
+	c.Assert(ok, IsTrue)
+}
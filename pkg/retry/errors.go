@@ -60,6 +60,14 @@ var (
 		"get event err EOF",
 	}
 
+	// DownstreamReadOnlyErrMsgs list the error messages of writing to a read-only downstream, the task should wait
+	// for the downstream becoming writable rather than auto resuming repeatedly.
+	DownstreamReadOnlyErrMsgs = []string{
+		"running with the --read-only option",
+		"running with the --super-read-only option",
+		"Running in read-only mode",
+	}
+
 	// UnresumableErrCodes is a set of unresumeable err codes.
 	UnresumableErrCodes = map[int32]struct{}{
 		int32(terror.ErrSyncUnitDDLWrongSequence.Code()):    {},
@@ -67,6 +75,11 @@ var (
 		int32(terror.ErrDumpUnitGlobalLock.Code()):          {},
 		int32(terror.ErrDumpUnitRuntime.Code()):             {},
 		int32(terror.ErrSyncerUnitDMLColumnNotMatch.Code()): {},
+		int32(terror.ErrWorkerLagThresholdExceeded.Code()):  {},
+		int32(terror.ErrSyncerGTIDGapDetected.Code()):       {},
+		int32(terror.ErrWorkerNoForwardProgress.Code()):     {},
+		int32(terror.ErrDBPausedByClassifier.Code()):        {},
+		int32(terror.ErrSyncerFrozenDDL.Code()):             {},
 	}
 
 	// UnresumableRelayErrCodes is a set of unresumeable relay unit err codes.
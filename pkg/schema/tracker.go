@@ -15,6 +15,7 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -152,9 +153,24 @@ func (tr *Tracker) GetTable(db, table string) (*model.TableInfo, error) {
 
 // GetCreateTable returns the `CREATE TABLE` statement of the table.
 func (tr *Tracker) GetCreateTable(ctx context.Context, db, table string) (string, error) {
+	return getCreateTable(ctx, tr.se, db, table)
+}
+
+// GetCreateTableInNewSession is the same as GetCreateTable, but it uses a new session, so it can be called
+// concurrently with the methods using the session of the tracker, like Exec.
+func (tr *Tracker) GetCreateTableInNewSession(ctx context.Context, db, table string) (string, error) {
+	se, err := session.CreateSession(tr.store)
+	if err != nil {
+		return "", err
+	}
+	defer se.Close()
+	return getCreateTable(ctx, se, db, table)
+}
+
+func getCreateTable(ctx context.Context, se session.Session, db, table string) (string, error) {
 	name := dbutil.TableName(db, table)
 	// use `SHOW CREATE TABLE` now, another method maybe `executor.ConstructResultOfShowCreateTable`.
-	rs, err := tr.se.Execute(ctx, fmt.Sprintf("SHOW CREATE TABLE %s", name))
+	rs, err := se.Execute(ctx, fmt.Sprintf("SHOW CREATE TABLE %s", name))
 	if err != nil {
 		return "", err
 	} else if len(rs) != 1 {
@@ -191,6 +207,21 @@ func (tr *Tracker) AllSchemas() []*model.DBInfo {
 	return filteredSchemas
 }
 
+// EstimatedSize returns the approximate bytes of the table infos tracked, estimated by their JSON encoded sizes.
+func (tr *Tracker) EstimatedSize() int64 {
+	is := tr.dom.InfoSchema()
+	var size int64
+	for _, db := range tr.AllSchemas() {
+		for _, tbl := range is.SchemaTables(db.Name) {
+			data, err := json.Marshal(tbl.Meta())
+			if err == nil {
+				size += int64(len(data))
+			}
+		}
+	}
+	return size
+}
+
 // GetSingleColumnIndices returns indices of input column if input column only has single-column indices
 // returns nil if input column has no indices, or has multi-column indices.
 func (tr *Tracker) GetSingleColumnIndices(db, tbl, col string) ([]*model.IndexInfo, error) {
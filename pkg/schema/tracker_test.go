@@ -211,6 +211,12 @@ func (s *trackerSuite) TestDDL(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(cts, Equals, "CREATE TABLE `foo` ( `a` varchar(255) NOT NULL, `c` int(11) DEFAULT NULL, PRIMARY KEY (`a`) /*T![clustered_index] NONCLUSTERED */) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin")
 
+	// the same result in a new session.
+	cts2, err := tracker.GetCreateTableInNewSession(context.Background(), "testdb", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(cts2, Equals, cts)
+	_, err = tracker.GetCreateTableInNewSession(context.Background(), "testdb", "bar")
+	c.Assert(IsTableNotExists(err), IsTrue)
 }
 
 func (s *trackerSuite) TestGetSingleColumnIndices(c *C) {
@@ -112,6 +112,16 @@ func (h *relayLogInfoHub) earliest() (taskName string, earliest *RelayLogInfo) {
 	return
 }
 
+func (h *relayLogInfoHub) all() []RelayLogInfo {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	logs := make([]RelayLogInfo, 0, len(h.logs))
+	for _, info := range h.logs {
+		logs = append(logs, info)
+	}
+	return logs
+}
+
 // ReaderHub holds information for all active Readers
 type ReaderHub struct {
 	rlih *relayLogInfoHub
@@ -143,6 +153,11 @@ func (h *ReaderHub) EarliestActiveRelayLog() *RelayLogInfo {
 	return rli
 }
 
+// ActiveRelayLogs returns active relay logs of all tasks
+func (h *ReaderHub) ActiveRelayLogs() []RelayLogInfo {
+	return h.rlih.all()
+}
+
 // RelayMetaHub holds information for relay metas
 type RelayMetaHub struct {
 	mu   sync.RWMutex
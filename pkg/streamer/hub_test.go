@@ -155,6 +155,9 @@ func (t *testHubSuite) TestReaderHub(c *C) {
 	c.Assert(erli.UUID, Equals, "c6ae5afe-c7a3-11e8-a19d-0242ac130006.000002")
 	c.Assert(erli.Filename, Equals, "mysql-bin.000002")
 
+	// all active relay logs
+	c.Assert(h.ActiveRelayLogs(), HasLen, 2)
+
 	// remove the earlier one
 	h.RemoveActiveRelayLog("task-2")
 
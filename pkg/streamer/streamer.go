@@ -78,6 +78,16 @@ func (s *LocalStreamer) GetEvent(ctx context.Context) (*replication.BinlogEvent,
 	}
 }
 
+// Len returns the number of binlog events parsed and buffered in the streamer.
+func (s *LocalStreamer) Len() int {
+	return len(s.ch)
+}
+
+// Cap returns the max number of binlog events can be buffered in the streamer.
+func (s *LocalStreamer) Cap() int {
+	return cap(s.ch)
+}
+
 func (s *LocalStreamer) close() {
 	s.closeWithError(terror.ErrSyncClosed.Generate())
 }
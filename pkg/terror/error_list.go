@@ -21,6 +21,7 @@ const (
 	codeDBUnExpect
 	codeDBQueryFailed
 	codeDBExecuteFailed
+	codeDBPausedByClassifier
 )
 
 // Functional error code list
@@ -227,6 +228,16 @@ const (
 	codeConfigMissingForBound
 	codeConfigBinlogEventFilter
 	codeConfigGlobalConfigsUnused
+	codeConfigUnsupportedDDLPolicyNotSupport
+	codeConfigInvalidRelaySource
+	codeConfigHash
+	codeConfigColumnTransformNotFound
+	codeConfigInvalidColumnTransform
+	codeConfigDuplicateKeyPolicyNotSupport
+	codeConfigWriteModeNotSupport
+	codeConfigPriorityClassNotSupport
+	codeConfigInvalidFreezeOnDDL
+	codeConfigInvalidDDLBatchSize
 )
 
 // Binlog operation error code list
@@ -234,6 +245,7 @@ const (
 	codeBinlogExtractPosition ErrCode = iota + 22001
 	codeBinlogInvalidFilename
 	codeBinlogParsePosFromStr
+	codeBinlogSettingsNotSupported
 )
 
 // Checkpoint error code
@@ -312,6 +324,10 @@ const (
 	codeRelayPurgeArgsNotValid
 	codePreviousGTIDsNotValid
 	codeRotateEventWithDifferentServerID
+	codeRelayRemoteRequest
+	codeRelayRemoteFileCorrupted
+	codeRelayPurgeByGTIDNotSafe
+	codeRelayPartialRelayFilter
 )
 
 // Dump unit error code
@@ -411,6 +427,17 @@ const (
 	codeSyncerOperatorNotExist
 	codeSyncerReplaceEventNotExist
 	codeSyncerParseDDL
+	codeSyncerWriteConflictExhausted
+	codeSyncerInvalidSamplingOpts
+	codeSyncerInvalidFastForward
+	codeSyncerUnitGenColumnTransform
+	codeSyncerCheckBinlogGap
+	codeSyncerCheckpointCorrupted
+	codeSyncerMeasureTimeSkew
+	codeSyncerGTIDGapDetected
+	codeSyncerNotInSteppingMode
+	codeSyncerInvalidPortableCheckpoint
+	codeSyncerFrozenDDL
 )
 
 // DM-master error code
@@ -552,6 +579,46 @@ const (
 	codeWorkerFailConnectMaster
 	codeWorkerWaitRelayCatchupGTID
 	codeWorkerRelayConfigChanging
+	codeWorkerSourceBindingLost
+	codeWorkerOperLoadUnitOnly
+	codeWorkerRelayDisabled
+	codeWorkerSubTaskDependencyCycle
+	codeWorkerInvalidCheckpointFlushInterval
+	codeWorkerPauseBarrierNotReached
+	codeWorkerPauseBarrierNotSpecified
+	codeWorkerInvalidRelayDir
+	codeWorkerRelayDirNotWritable
+	codeWorkerRelayDirNoEnoughSpace
+	codeWorkerMoveRelayDir
+	codeWorkerInvalidBatchSize
+	codeWorkerFaultInjectionDisabled
+	codeWorkerInvalidFaultSpec
+	codeWorkerInjectedFault
+	codeWorkerInvalidMaxAllowedLag
+	codeWorkerLagThresholdExceeded
+	codeWorkerInvalidDumpConcurrency
+	codeWorkerDumpConcurrencyNotApplicable
+	codeWorkerSubTaskOpHookFailed
+	codeWorkerSubTaskOpHookTimeout
+	codeWorkerInvalidMaintenanceWindow
+	codeWorkerInvalidRelayArchive
+	codeWorkerReplayArchiveNotFinished
+	codeWorkerNoForwardProgress
+	codeWorkerRollingOpInProgress
+	codeWorkerRollingOpFailed
+	codeWorkerGatherMetrics
+	codeWorkerInvalidStepCount
+	codeWorkerInvalidWriteMode
+	codeWorkerParseCreateTable
+	codeWorkerSyncNotPaused
+	codeWorkerServerIDCollision
+	codeWorkerOperateAllFailed
+	codeWorkerDrainFailed
+	codeWorkerFlavorMismatch
+	codeWorkerInvalidSubTaskStage
+	codeWorkerSubTaskDependencyNotFound
+	codeWorkerSubTaskDependencyNotReady
+	codeWorkerRelayFilesNeedTLS
 )
 
 // DM-tracer error code
@@ -625,9 +692,10 @@ var (
 	ErrDBBadConn     = New(codeDBBadConn, ClassDatabase, ScopeNotSet, LevelHigh, "database driver", "Please check the database connection, then use `pause-task` to pause the task and then use `resume-task` to resume the task.")
 	ErrDBInvalidConn = New(codeDBInvalidConn, ClassDatabase, ScopeNotSet, LevelHigh, "database driver", "Please check the database connection, then use `pause-task` to stop the task and then use `resume-task` to resume the task.")
 
-	ErrDBUnExpect      = New(codeDBUnExpect, ClassDatabase, ScopeNotSet, LevelHigh, "unexpect database error: %s", "")
-	ErrDBQueryFailed   = New(codeDBQueryFailed, ClassDatabase, ScopeNotSet, LevelHigh, "query statement failed: %s", "")
-	ErrDBExecuteFailed = New(codeDBExecuteFailed, ClassDatabase, ScopeNotSet, LevelHigh, "execute statement failed: %s", "")
+	ErrDBUnExpect           = New(codeDBUnExpect, ClassDatabase, ScopeNotSet, LevelHigh, "unexpect database error: %s", "")
+	ErrDBQueryFailed        = New(codeDBQueryFailed, ClassDatabase, ScopeNotSet, LevelHigh, "query statement failed: %s", "")
+	ErrDBExecuteFailed      = New(codeDBExecuteFailed, ClassDatabase, ScopeNotSet, LevelHigh, "execute statement failed: %s", "")
+	ErrDBPausedByClassifier = New(codeDBPausedByClassifier, ClassDatabase, ScopeNotSet, LevelHigh, "the error is classified to pause the task by error classifier %s", "Please fix the error, then use `resume-task` to resume the task.")
 
 	// Functional error
 	ErrParseMydumperMeta      = New(codeParseMydumperMeta, ClassFunctional, ScopeInternal, LevelHigh, "parse mydumper metadata error: %s, metadata: %s", "")
@@ -791,49 +859,60 @@ var (
 	ErrPreviousGTIDNotExist = New(codePreviousGTIDNotExist, ClassFunctional, ScopeInternal, LevelHigh, "no previous gtid event from binlog %s", "")
 
 	// Config related error
-	ErrConfigCheckItemNotSupport    = New(codeConfigCheckItemNotSupport, ClassConfig, ScopeInternal, LevelMedium, "checking item %s is not supported\n%s", "Please check `ignore-checking-items` config in task configuration file, which can be set including `all`/`dump_privilege`/`replication_privilege`/`version`/`binlog_enable`/`binlog_format`/`binlog_row_image`/`table_schema`/`schema_of_shard_tables`/`auto_increment_ID`.")
-	ErrConfigTomlTransform          = New(codeConfigTomlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct TOML format.")
-	ErrConfigYamlTransform          = New(codeConfigYamlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct YAML format.")
-	ErrConfigTaskNameEmpty          = New(codeConfigTaskNameEmpty, ClassConfig, ScopeInternal, LevelMedium, "task name should not be empty", "Please check the `name` config in task configuration file.")
-	ErrConfigEmptySourceID          = New(codeConfigEmptySourceID, ClassConfig, ScopeInternal, LevelMedium, "empty source-id not valid", "Please check the `source-id` config in configuration file.")
-	ErrConfigTooLongSourceID        = New(codeConfigTooLongSourceID, ClassConfig, ScopeInternal, LevelMedium, "too long source-id not valid", "Please check the `source-id` config in configuration file. The max source id length is 32.")
-	ErrConfigOnlineSchemeNotSupport = New(codeConfigOnlineSchemeNotSupport, ClassConfig, ScopeInternal, LevelMedium, "online scheme %s not supported", "Please check the `online-ddl-scheme` config in task configuration file. Only `ghost` and `pt` are currently supported.")
-	ErrConfigInvalidTimezone        = New(codeConfigInvalidTimezone, ClassConfig, ScopeInternal, LevelMedium, "invalid timezone string: %s", "Please check the `timezone` config in task configuration file.")
-	ErrConfigParseFlagSet           = New(codeConfigParseFlagSet, ClassConfig, ScopeInternal, LevelMedium, "parse subtask config flag set", "")
-	ErrConfigDecryptDBPassword      = New(codeConfigDecryptDBPassword, ClassConfig, ScopeInternal, LevelMedium, "decrypt DB password %s failed", "")
-	ErrConfigMetaInvalid            = New(codeConfigMetaInvalid, ClassConfig, ScopeInternal, LevelMedium, "must specify `binlog-name` without GTID enabled for the source or specify `binlog-gtid` with GTID enabled for the source", "Please check the `meta` config in task configuration file.")
-	ErrConfigMySQLInstNotFound      = New(codeConfigMySQLInstNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql instance config must specify", "Please check the `mysql-instances` config in task configuration file.")
-	ErrConfigMySQLInstsAtLeastOne   = New(codeConfigMySQLInstsAtLeastOne, ClassConfig, ScopeInternal, LevelMedium, "must specify at least one mysql-instances", "Please check the `mysql-instances` config in task configuration file.")
-	ErrConfigMySQLInstSameSourceID  = New(codeConfigMySQLInstSameSourceID, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance (%d) and (%d) have same source-id (%s)", "Please check the `mysql-instances` config in task configuration file.")
-	ErrConfigMydumperCfgConflict    = New(codeConfigMydumperCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "mydumper-config-name and mydumper should only specify one", "Please check the `mydumper-config-name` and `mydumper` config in task configuration file.")
-	ErrConfigLoaderCfgConflict      = New(codeConfigLoaderCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "loader-config-name and loader should only specify one", "Please check the `loader-config-name` and `loader` config in task configuration file.")
-	ErrConfigSyncerCfgConflict      = New(codeConfigSyncerCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "syncer-config-name and syncer should only specify one", "Please check the `syncer-config-name` and `syncer` config in task configuration file.")
-	ErrConfigReadCfgFromFile        = New(codeConfigReadCfgFromFile, ClassConfig, ScopeInternal, LevelMedium, "read config file %v", "")
-	ErrConfigNeedUniqueTaskName     = New(codeConfigNeedUniqueTaskName, ClassConfig, ScopeInternal, LevelMedium, "must specify a unique task name", "Please check the `name` config in task configuration file.")
-	ErrConfigInvalidTaskMode        = New(codeConfigInvalidTaskMode, ClassConfig, ScopeInternal, LevelMedium, "please specify right task-mode, support `full`, `incremental`, `all`", "Please check the `task-mode` config in task configuration file.")
-	ErrConfigNeedTargetDB           = New(codeConfigNeedTargetDB, ClassConfig, ScopeInternal, LevelMedium, "must specify target-database", "Please check the `target-database` config in task configuration file.")
-	ErrConfigMetadataNotSet         = New(codeConfigMetadataNotSet, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d) must set meta for task-mode %s", "Please check the `meta` config in task configuration file.")
-	ErrConfigRouteRuleNotFound      = New(codeConfigRouteRuleNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s route-rules %s not exist in routes", "Please check the `route-rules` config in task configuration file.")
-	ErrConfigFilterRuleNotFound     = New(codeConfigFilterRuleNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s filter-rules %s not exist in filters", "Please check the `filter-rules` config in task configuration file.")
-	ErrConfigColumnMappingNotFound  = New(codeConfigColumnMappingNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s column-mapping-rules %s not exist in column-mapping", "Please check the `column-mapping-rules` config in task configuration file.")
-	ErrConfigBAListNotFound         = New(codeConfigBAListNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s list %s not exist in block allow list", "Please check the `block-allow-list` config in task configuration file.")
-	ErrConfigMydumperCfgNotFound    = New(codeConfigMydumperCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s mydumper config %s not exist in mydumpers", "Please check the `mydumper-config-name` config in task configuration file.")
-	ErrConfigMydumperPathNotValid   = New(codeConfigMydumperPathNotValid, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s mydumper-path must specify a valid path to mydumper binary when task-mode is all or full", "Please check the `mydumper-path` config in task configuration file.")
-	ErrConfigLoaderCfgNotFound      = New(codeConfigLoaderCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s loader config %s not exist in loaders", "Please check the `loader-config-name` config in task configuration file.")
-	ErrConfigSyncerCfgNotFound      = New(codeConfigSyncerCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s syncer config %s not exist in syncer", "Please check the `syncer-config-name` config in task configuration file.")
-	ErrConfigSourceIDNotFound       = New(codeConfigSourceIDNotFound, ClassConfig, ScopeInternal, LevelMedium, "source %s in deployment configuration not found", "Please use `operate-source create source-config-file-path` to add source.")
-	ErrConfigDuplicateCfgItem       = New(codeConfigDuplicateCfgItem, ClassConfig, ScopeInternal, LevelMedium, "the following mysql configs have duplicate items, please remove the duplicates:\n%s", "Please check the `mysql-instances` config in task configuration file.")
-	ErrConfigShardModeNotSupport    = New(codeConfigShardModeNotSupport, ClassConfig, ScopeInternal, LevelMedium, "shard mode %s not supported", "Please check the `shard-mode` config in task configuration file, which can be set to `pessimistic`/`optimistic`.")
-	ErrConfigMoreThanOne            = New(codeConfigMoreThanOne, ClassConfig, ScopeInternal, LevelHigh, "found %d %s for %s which should <= 1", "")
-	ErrConfigEtcdParse              = New(codeConfigEtcdParse, ClassConfig, ScopeInternal, LevelHigh, "incapable config of %s from etcd", "")
-	ErrConfigMissingForBound        = New(codeConfigMissingForBound, ClassConfig, ScopeInternal, LevelHigh, "source bound %s doesn't have related source config in etcd", "")
-	ErrConfigBinlogEventFilter      = New(codeConfigBinlogEventFilter, ClassConfig, ScopeInternal, LevelHigh, "generate binlog event filter", "Please check the `filters` config in source and task configuration files.")
-	ErrConfigGlobalConfigsUnused    = New(codeConfigGlobalConfigsUnused, ClassConfig, ScopeInternal, LevelHigh, "The configurations as following %v are set in global configuration but instances don't use them", "Please check the configuration files.")
+	ErrConfigCheckItemNotSupport            = New(codeConfigCheckItemNotSupport, ClassConfig, ScopeInternal, LevelMedium, "checking item %s is not supported\n%s", "Please check `ignore-checking-items` config in task configuration file, which can be set including `all`/`dump_privilege`/`replication_privilege`/`version`/`binlog_enable`/`binlog_format`/`binlog_row_image`/`table_schema`/`schema_of_shard_tables`/`auto_increment_ID`.")
+	ErrConfigTomlTransform                  = New(codeConfigTomlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct TOML format.")
+	ErrConfigYamlTransform                  = New(codeConfigYamlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct YAML format.")
+	ErrConfigTaskNameEmpty                  = New(codeConfigTaskNameEmpty, ClassConfig, ScopeInternal, LevelMedium, "task name should not be empty", "Please check the `name` config in task configuration file.")
+	ErrConfigEmptySourceID                  = New(codeConfigEmptySourceID, ClassConfig, ScopeInternal, LevelMedium, "empty source-id not valid", "Please check the `source-id` config in configuration file.")
+	ErrConfigTooLongSourceID                = New(codeConfigTooLongSourceID, ClassConfig, ScopeInternal, LevelMedium, "too long source-id not valid", "Please check the `source-id` config in configuration file. The max source id length is 32.")
+	ErrConfigOnlineSchemeNotSupport         = New(codeConfigOnlineSchemeNotSupport, ClassConfig, ScopeInternal, LevelMedium, "online scheme %s not supported", "Please check the `online-ddl-scheme` config in task configuration file. Only `ghost` and `pt` are currently supported.")
+	ErrConfigInvalidTimezone                = New(codeConfigInvalidTimezone, ClassConfig, ScopeInternal, LevelMedium, "invalid timezone string: %s", "Please check the `timezone` config in task configuration file.")
+	ErrConfigParseFlagSet                   = New(codeConfigParseFlagSet, ClassConfig, ScopeInternal, LevelMedium, "parse subtask config flag set", "")
+	ErrConfigDecryptDBPassword              = New(codeConfigDecryptDBPassword, ClassConfig, ScopeInternal, LevelMedium, "decrypt DB password %s failed", "")
+	ErrConfigMetaInvalid                    = New(codeConfigMetaInvalid, ClassConfig, ScopeInternal, LevelMedium, "must specify `binlog-name` without GTID enabled for the source or specify `binlog-gtid` with GTID enabled for the source", "Please check the `meta` config in task configuration file.")
+	ErrConfigMySQLInstNotFound              = New(codeConfigMySQLInstNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql instance config must specify", "Please check the `mysql-instances` config in task configuration file.")
+	ErrConfigMySQLInstsAtLeastOne           = New(codeConfigMySQLInstsAtLeastOne, ClassConfig, ScopeInternal, LevelMedium, "must specify at least one mysql-instances", "Please check the `mysql-instances` config in task configuration file.")
+	ErrConfigMySQLInstSameSourceID          = New(codeConfigMySQLInstSameSourceID, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance (%d) and (%d) have same source-id (%s)", "Please check the `mysql-instances` config in task configuration file.")
+	ErrConfigMydumperCfgConflict            = New(codeConfigMydumperCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "mydumper-config-name and mydumper should only specify one", "Please check the `mydumper-config-name` and `mydumper` config in task configuration file.")
+	ErrConfigLoaderCfgConflict              = New(codeConfigLoaderCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "loader-config-name and loader should only specify one", "Please check the `loader-config-name` and `loader` config in task configuration file.")
+	ErrConfigSyncerCfgConflict              = New(codeConfigSyncerCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "syncer-config-name and syncer should only specify one", "Please check the `syncer-config-name` and `syncer` config in task configuration file.")
+	ErrConfigReadCfgFromFile                = New(codeConfigReadCfgFromFile, ClassConfig, ScopeInternal, LevelMedium, "read config file %v", "")
+	ErrConfigNeedUniqueTaskName             = New(codeConfigNeedUniqueTaskName, ClassConfig, ScopeInternal, LevelMedium, "must specify a unique task name", "Please check the `name` config in task configuration file.")
+	ErrConfigInvalidTaskMode                = New(codeConfigInvalidTaskMode, ClassConfig, ScopeInternal, LevelMedium, "please specify right task-mode, support `full`, `incremental`, `all`", "Please check the `task-mode` config in task configuration file.")
+	ErrConfigNeedTargetDB                   = New(codeConfigNeedTargetDB, ClassConfig, ScopeInternal, LevelMedium, "must specify target-database", "Please check the `target-database` config in task configuration file.")
+	ErrConfigMetadataNotSet                 = New(codeConfigMetadataNotSet, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d) must set meta for task-mode %s", "Please check the `meta` config in task configuration file.")
+	ErrConfigRouteRuleNotFound              = New(codeConfigRouteRuleNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s route-rules %s not exist in routes", "Please check the `route-rules` config in task configuration file.")
+	ErrConfigFilterRuleNotFound             = New(codeConfigFilterRuleNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s filter-rules %s not exist in filters", "Please check the `filter-rules` config in task configuration file.")
+	ErrConfigColumnMappingNotFound          = New(codeConfigColumnMappingNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s column-mapping-rules %s not exist in column-mapping", "Please check the `column-mapping-rules` config in task configuration file.")
+	ErrConfigBAListNotFound                 = New(codeConfigBAListNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s list %s not exist in block allow list", "Please check the `block-allow-list` config in task configuration file.")
+	ErrConfigMydumperCfgNotFound            = New(codeConfigMydumperCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s mydumper config %s not exist in mydumpers", "Please check the `mydumper-config-name` config in task configuration file.")
+	ErrConfigMydumperPathNotValid           = New(codeConfigMydumperPathNotValid, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s mydumper-path must specify a valid path to mydumper binary when task-mode is all or full", "Please check the `mydumper-path` config in task configuration file.")
+	ErrConfigLoaderCfgNotFound              = New(codeConfigLoaderCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s loader config %s not exist in loaders", "Please check the `loader-config-name` config in task configuration file.")
+	ErrConfigSyncerCfgNotFound              = New(codeConfigSyncerCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s syncer config %s not exist in syncer", "Please check the `syncer-config-name` config in task configuration file.")
+	ErrConfigSourceIDNotFound               = New(codeConfigSourceIDNotFound, ClassConfig, ScopeInternal, LevelMedium, "source %s in deployment configuration not found", "Please use `operate-source create source-config-file-path` to add source.")
+	ErrConfigDuplicateCfgItem               = New(codeConfigDuplicateCfgItem, ClassConfig, ScopeInternal, LevelMedium, "the following mysql configs have duplicate items, please remove the duplicates:\n%s", "Please check the `mysql-instances` config in task configuration file.")
+	ErrConfigShardModeNotSupport            = New(codeConfigShardModeNotSupport, ClassConfig, ScopeInternal, LevelMedium, "shard mode %s not supported", "Please check the `shard-mode` config in task configuration file, which can be set to `pessimistic`/`optimistic`.")
+	ErrConfigMoreThanOne                    = New(codeConfigMoreThanOne, ClassConfig, ScopeInternal, LevelHigh, "found %d %s for %s which should <= 1", "")
+	ErrConfigEtcdParse                      = New(codeConfigEtcdParse, ClassConfig, ScopeInternal, LevelHigh, "incapable config of %s from etcd", "")
+	ErrConfigMissingForBound                = New(codeConfigMissingForBound, ClassConfig, ScopeInternal, LevelHigh, "source bound %s doesn't have related source config in etcd", "")
+	ErrConfigBinlogEventFilter              = New(codeConfigBinlogEventFilter, ClassConfig, ScopeInternal, LevelHigh, "generate binlog event filter", "Please check the `filters` config in source and task configuration files.")
+	ErrConfigGlobalConfigsUnused            = New(codeConfigGlobalConfigsUnused, ClassConfig, ScopeInternal, LevelHigh, "The configurations as following %v are set in global configuration but instances don't use them", "Please check the configuration files.")
+	ErrConfigUnsupportedDDLPolicyNotSupport = New(codeConfigUnsupportedDDLPolicyNotSupport, ClassConfig, ScopeInternal, LevelMedium, "unsupported DDL policy %s not supported", "Please check the `unsupported-ddl-policy` config in task configuration file, which can be set to `pause`/`skip`/`apply-verbatim`.")
+	ErrConfigInvalidRelaySource             = New(codeConfigInvalidRelaySource, ClassConfig, ScopeInternal, LevelHigh, "invalid relay-source %s, %s", "Please use the HTTPS address set by `relay-files-addr` of the DM-worker which pulls relay log of the same source, like `https://127.0.0.1:8264`.")
+	ErrConfigHash                           = New(codeConfigHash, ClassConfig, ScopeInternal, LevelHigh, "calculate hash of config", "")
+	ErrConfigColumnTransformNotFound        = New(codeConfigColumnTransformNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s column-transform-rules %s not exist in column-transforms", "Please check the `column-transform-rules` config in task configuration file.")
+	ErrConfigInvalidColumnTransform         = New(codeConfigInvalidColumnTransform, ClassConfig, ScopeInternal, LevelMedium, "invalid column transform %+v, %s", "Please check the `column-transforms` config, `func` should be one of hash, mask and nullify.")
+	ErrConfigDuplicateKeyPolicyNotSupport   = New(codeConfigDuplicateKeyPolicyNotSupport, ClassConfig, ScopeInternal, LevelMedium, "duplicate key policy %s not supported", "Please check the `duplicate-key-policy` config in task configuration file, which can be set to `pause`/`skip`/`overwrite`.")
+	ErrConfigWriteModeNotSupport            = New(codeConfigWriteModeNotSupport, ClassConfig, ScopeInternal, LevelMedium, "write mode %s not supported", "Please check the `write-mode` config in task configuration file, which can be set to `transactional`/`bulk`.")
+	ErrConfigPriorityClassNotSupport        = New(codeConfigPriorityClassNotSupport, ClassConfig, ScopeInternal, LevelMedium, "priority class %s not supported", "Please check the `priority-class` config in task configuration file, which can be set to `high`/`normal`/`low`.")
+	ErrConfigInvalidFreezeOnDDL             = New(codeConfigInvalidFreezeOnDDL, ClassConfig, ScopeInternal, LevelMedium, "invalid freeze-on-ddl pattern %s", "Please check the `freeze-on-ddl` config in task configuration file, both `db-name` and `tbl-name` are required.")
+	ErrConfigInvalidDDLBatchSize            = New(codeConfigInvalidDDLBatchSize, ClassConfig, ScopeInternal, LevelMedium, "ddl-batch-size %d is out of range [%d, %d]", "Please check the `ddl-batch-size` config in task configuration file.")
 
 	// Binlog operation error
-	ErrBinlogExtractPosition = New(codeBinlogExtractPosition, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
-	ErrBinlogInvalidFilename = New(codeBinlogInvalidFilename, ClassBinlogOp, ScopeInternal, LevelHigh, "invalid binlog filename", "")
-	ErrBinlogParsePosFromStr = New(codeBinlogParsePosFromStr, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
+	ErrBinlogExtractPosition      = New(codeBinlogExtractPosition, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
+	ErrBinlogInvalidFilename      = New(codeBinlogInvalidFilename, ClassBinlogOp, ScopeInternal, LevelHigh, "invalid binlog filename", "")
+	ErrBinlogParsePosFromStr      = New(codeBinlogParsePosFromStr, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
+	ErrBinlogSettingsNotSupported = New(codeBinlogSettingsNotSupported, ClassBinlogOp, ScopeInternal, LevelHigh, "upstream %s is %s, but %s is required to replicate the full rows", "Please set it in upstream and resume the task, or add it to `ignore-checking-items` in task configuration file if it's expected.")
 
 	// Checkpoint error
 	ErrCheckpointInvalidTaskMode     = New(codeCheckpointInvalidTaskMode, ClassCheckpoint, ScopeInternal, LevelMedium, "invalid task mode: %s", "")
@@ -904,6 +983,10 @@ var (
 	ErrRelayPurgeArgsNotValid            = New(codeRelayPurgeArgsNotValid, ClassRelayUnit, ScopeInternal, LevelHigh, "args (%T) %+v not valid", "")
 	ErrPreviousGTIDsNotValid             = New(codePreviousGTIDsNotValid, ClassRelayUnit, ScopeInternal, LevelHigh, "previousGTIDs %s not valid", "")
 	ErrRotateEventWithDifferentServerID  = New(codeRotateEventWithDifferentServerID, ClassRelayUnit, ScopeInternal, LevelHigh, "receive fake rotate event with different server_id", "Please use `resume-relay` command if upstream database has changed")
+	ErrRelayRemoteRequest                = New(codeRelayRemoteRequest, ClassRelayUnit, ScopeInternal, LevelHigh, "request relay source %s", "Please check the relay source is reachable and its relay is enabled.")
+	ErrRelayRemoteFileCorrupted          = New(codeRelayRemoteFileCorrupted, ClassRelayUnit, ScopeInternal, LevelHigh, "relay log file %s fetched from relay source is corrupted, %s", "Please check the relay log in the relay source.")
+	ErrRelayPurgeByGTIDNotSafe           = New(codeRelayPurgeByGTIDNotSafe, ClassRelayUnit, ScopeInternal, LevelHigh, "can't purge relay log files before %s for GTID set %s, the relay log %s is still needed by %s", "Please wait for the subtask to replicate the events in the relay log, or purge with a smaller GTID set.")
+	ErrRelayPartialRelayFilter           = New(codeRelayPartialRelayFilter, ClassRelayUnit, ScopeInternal, LevelHigh, "generate the block-allow list of subtask %s for partial relay", "Please check the `block-allow-list` config in task configuration file.")
 
 	// Dump unit error
 	ErrDumpUnitRuntime        = New(codeDumpUnitRuntime, ClassDumpUnit, ScopeInternal, LevelHigh, "mydumper/dumpling runs with error, with output (may empty): %s", "")
@@ -998,6 +1081,17 @@ var (
 	ErrSyncerOperatorNotExist               = New(codeSyncerOperatorNotExist, ClassSyncUnit, ScopeInternal, LevelLow, "error operator not exist, position: %s", "")
 	ErrSyncerReplaceEventNotExist           = New(codeSyncerReplaceEventNotExist, ClassSyncUnit, ScopeInternal, LevelHigh, "replace event not exist, location: %s", "")
 	ErrSyncerParseDDL                       = New(codeSyncerParseDDL, ClassSyncUnit, ScopeInternal, LevelHigh, "parse DDL: %s", "Please confirm your DDL statement is correct and needed. For TiDB compatible DDL, see https://docs.pingcap.com/tidb/stable/mysql-compatibility#ddl. You can use `handle-error` command to skip or replace the DDL or add a binlog filter rule to ignore it if the DDL is not needed.")
+	ErrSyncerWriteConflictExhausted         = New(codeSyncerWriteConflictExhausted, ClassSyncUnit, ScopeDownstream, LevelHigh, "write conflict retries exhausted after %d retries", "Please check whether other applications are writing the same tables in downstream, or increase `conflict-retry-count` and `conflict-retry-interval`.")
+	ErrSyncerInvalidSamplingOpts            = New(codeSyncerInvalidSamplingOpts, ClassSyncUnit, ScopeInternal, LevelMedium, "invalid event sampling options %+v, %s", "Please check the sampling interval and the max number of events.")
+	ErrSyncerInvalidFastForward             = New(codeSyncerInvalidFastForward, ClassSyncUnit, ScopeInternal, LevelHigh, "can't fast-forward from %s to %s, %s", "Please check the checkpoint in downstream and the binlog in upstream.")
+	ErrSyncerUnitGenColumnTransform         = New(codeSyncerUnitGenColumnTransform, ClassSyncUnit, ScopeInternal, LevelHigh, "generate column transform", "Please check the `column-transforms` config in task configuration file.")
+	ErrSyncerCheckBinlogGap                 = New(codeSyncerCheckBinlogGap, ClassSyncUnit, ScopeInternal, LevelMedium, "can't check the gap of binlog, %s", "")
+	ErrSyncerCheckpointCorrupted            = New(codeSyncerCheckpointCorrupted, ClassSyncUnit, ScopeInternal, LevelHigh, "checkpoint of subtask %s is corrupted: %s", "Please check the checkpoint table in downstream, fix or remove the corrupted rows, or set `reset-corrupted-checkpoint: true` in the syncer config to remove them automatically.")
+	ErrSyncerMeasureTimeSkew                = New(codeSyncerMeasureTimeSkew, ClassSyncUnit, ScopeInternal, LevelMedium, "can't measure the time skew, %s", "")
+	ErrSyncerGTIDGapDetected                = New(codeSyncerGTIDGapDetected, ClassSyncUnit, ScopeInternal, LevelHigh, "GTID gap detected, expected to continue from GTID set %s, but upstream has purged GTID set %s", "Please investigate the missing transactions, restore them to downstream and resume the task, or disable `strict-gtid` in task config and enable `auto-fix-gtid` in source config to skip them.")
+	ErrSyncerNotInSteppingMode              = New(codeSyncerNotInSteppingMode, ClassSyncUnit, ScopeInternal, LevelLow, "the sync unit is not in stepping mode", "Please enter stepping mode of the subtask before stepping.")
+	ErrSyncerInvalidPortableCheckpoint      = New(codeSyncerInvalidPortableCheckpoint, ClassSyncUnit, ScopeInternal, LevelHigh, "invalid portable checkpoint, %s", "Please check the exported checkpoint and the binlog in upstream of the subtask importing it.")
+	ErrSyncerFrozenDDL                      = New(codeSyncerFrozenDDL, ClassSyncUnit, ScopeInternal, LevelHigh, "DDL %s on %v is frozen by freeze-on-ddl, the subtask is paused until it's reviewed", "Please review the DDL, then resume the subtask to apply it, or use `handle-error` to skip or replace it.")
 
 	// DM-master error
 	ErrMasterSQLOpNilRequest        = New(codeMasterSQLOpNilRequest, ClassDMMaster, ScopeInternal, LevelMedium, "nil request not valid", "")
@@ -1141,6 +1235,46 @@ var (
 	ErrWorkerTLSConfigNotValid              = New(codeWorkerTLSConfigNotValid, ClassDMWorker, ScopeInternal, LevelHigh, "TLS config not valid", "Please check the `ssl-ca`, `ssl-cert` and `ssl-key` config in worker configuration file.")
 	ErrWorkerFailConnectMaster              = New(codeWorkerFailConnectMaster, ClassDMWorker, ScopeInternal, LevelHigh, "cannot connect with master endpoints: %v", "Please check network connection of worker")
 	ErrWorkerRelayConfigChanging            = New(codeWorkerRelayConfigChanging, ClassDMWorker, ScopeInternal, LevelLow, "relay config of worker %s is changed too frequently, last relay source %s:, new relay source %s", "Please try again later")
+	ErrWorkerSourceBindingLost              = New(codeWorkerSourceBindingLost, ClassDMWorker, ScopeInternal, LevelHigh, "keepalive lease of worker %s has expired, the source binding is lost", "DM-worker will stop handling the source and try to re-acquire the binding later, please check the network and the load of DM-worker.")
+	ErrWorkerOperLoadUnitOnly               = New(codeWorkerOperLoadUnitOnly, ClassDMWorker, ScopeInternal, LevelHigh, "such operation is only available for loader, but now loader is not running. current unit is %s", "")
+	ErrWorkerRelayDisabled                  = New(codeWorkerRelayDisabled, ClassDMWorker, ScopeInternal, LevelLow, "relay log is not enabled for this worker", "Please enable relay for the source first.")
+	ErrWorkerSubTaskDependencyCycle         = New(codeWorkerSubTaskDependencyCycle, ClassDMWorker, ScopeInternal, LevelHigh, "dependencies of subtask %s form a cycle: %s", "Please check the `dependencies` in task config.")
+	ErrWorkerInvalidCheckpointFlushInterval = New(codeWorkerInvalidCheckpointFlushInterval, ClassDMWorker, ScopeInternal, LevelHigh, "checkpoint flush interval %s is less than the minimum %s", "Please use a larger interval.")
+	ErrWorkerPauseBarrierNotReached         = New(codeWorkerPauseBarrierNotReached, ClassDMWorker, ScopeInternal, LevelHigh, "subtasks %v have not reached their pause barriers", "Please check the sync progress of these subtasks, and retry if needed.")
+	ErrWorkerPauseBarrierNotSpecified       = New(codeWorkerPauseBarrierNotSpecified, ClassDMWorker, ScopeInternal, LevelMedium, "the location to pause subtask %s at is not specified", "Please specify a valid binlog location.")
+	ErrWorkerInvalidRelayDir                = New(codeWorkerInvalidRelayDir, ClassDMWorker, ScopeInternal, LevelMedium, "relay directory %s is not valid, %s", "Please choose an empty directory outside the current relay directory.")
+	ErrWorkerRelayDirNotWritable            = New(codeWorkerRelayDirNotWritable, ClassDMWorker, ScopeInternal, LevelHigh, "relay directory %s is not writable", "Please check the permission of the directory.")
+	ErrWorkerRelayDirNoEnoughSpace          = New(codeWorkerRelayDirNoEnoughSpace, ClassDMWorker, ScopeInternal, LevelHigh, "no enough space in relay directory %s, required %d bytes, available %d bytes", "Please choose a directory with enough space.")
+	ErrWorkerMoveRelayDir                   = New(codeWorkerMoveRelayDir, ClassDMWorker, ScopeInternal, LevelHigh, "fail to move relay directory from %s to %s", "")
+	ErrWorkerInvalidBatchSize               = New(codeWorkerInvalidBatchSize, ClassDMWorker, ScopeInternal, LevelHigh, "batch size %d is out of range [%d, %d]", "Please use a batch size in the range.")
+	ErrWorkerFaultInjectionDisabled         = New(codeWorkerFaultInjectionDisabled, ClassDMWorker, ScopeInternal, LevelHigh, "fault injection is disabled", "Please use a build with failpoint enabled, and activate failpoint `github.com/pingcap/dm/dm/worker/FaultInjection` explicitly.")
+	ErrWorkerInvalidFaultSpec               = New(codeWorkerInvalidFaultSpec, ClassDMWorker, ScopeInternal, LevelHigh, "invalid fault spec %+v, %s", "Please check the fault spec.")
+	ErrWorkerInjectedFault                  = New(codeWorkerInjectedFault, ClassDMWorker, ScopeInternal, LevelHigh, "injected fault: %s", "It's injected for chaos testing.")
+	ErrWorkerInvalidMaxAllowedLag           = New(codeWorkerInvalidMaxAllowedLag, ClassDMWorker, ScopeInternal, LevelHigh, "max allowed lag %s should not be negative", "Please use a positive duration, or 0 to disable the check.")
+	ErrWorkerLagThresholdExceeded           = New(codeWorkerLagThresholdExceeded, ClassDMWorker, ScopeInternal, LevelHigh, "replication lag %s of subtask %s exceeds the max allowed lag %s", "Please check whether the downstream can keep up with the upstream, and resume the subtask after that, or increase the max allowed lag.")
+	ErrWorkerInvalidDumpConcurrency         = New(codeWorkerInvalidDumpConcurrency, ClassDMWorker, ScopeInternal, LevelHigh, "invalid dump concurrency, threads %d should be in [%d, %d], and chunk rows %d should not be negative", "Please check the threads and chunk rows.")
+	ErrWorkerDumpConcurrencyNotApplicable   = New(codeWorkerDumpConcurrencyNotApplicable, ClassDMWorker, ScopeInternal, LevelHigh, "can't set dump concurrency of subtask %s, %s", "Please set it before the dump phase finished.")
+	ErrWorkerSubTaskOpHookFailed            = New(codeWorkerSubTaskOpHookFailed, ClassDMWorker, ScopeInternal, LevelHigh, "%s-hook of operation %s on subtask %s failed", "Please check the hook registered for the subtask, the operation is aborted if the pre-hook failed.")
+	ErrWorkerSubTaskOpHookTimeout           = New(codeWorkerSubTaskOpHookTimeout, ClassDMWorker, ScopeInternal, LevelHigh, "%s-hook of operation %s on subtask %s is not finished in %s", "Please check whether the hook registered for the subtask is hung, the operation is aborted if the pre-hook timed out.")
+	ErrWorkerInvalidMaintenanceWindow       = New(codeWorkerInvalidMaintenanceWindow, ClassDMWorker, ScopeInternal, LevelHigh, "invalid maintenance window from %s to %s, %s", "Please check the start and end time of the maintenance window.")
+	ErrWorkerInvalidRelayArchive            = New(codeWorkerInvalidRelayArchive, ClassDMWorker, ScopeInternal, LevelHigh, "relay archive %s is invalid, %s", "Please check the relay log files in the archive directory are contiguous and cover the binlog to replay.")
+	ErrWorkerReplayArchiveNotFinished       = New(codeWorkerReplayArchiveNotFinished, ClassDMWorker, ScopeInternal, LevelHigh, "replaying the relay archive for subtask %s stopped before reaching %s, %s", "Please check the status of the subtask, and retry if needed.")
+	ErrWorkerNoForwardProgress              = New(codeWorkerNoForwardProgress, ClassDMWorker, ScopeInternal, LevelHigh, "subtask %s made no forward progress from the checkpoint %s after %d auto resumes", "Please check the errors which paused the subtask, fix them and resume the subtask manually.")
+	ErrWorkerRollingOpInProgress            = New(codeWorkerRollingOpInProgress, ClassDMWorker, ScopeInternal, LevelLow, "rolling %s of subtasks is in progress", "Please wait for it to finish or cancel it.")
+	ErrWorkerRollingOpFailed                = New(codeWorkerRollingOpFailed, ClassDMWorker, ScopeInternal, LevelMedium, "rolling %s failed on subtasks %v", "Please check the log of the worker and operate the failed subtasks manually.")
+	ErrWorkerGatherMetrics                  = New(codeWorkerGatherMetrics, ClassDMWorker, ScopeInternal, LevelMedium, "fail to gather metrics of subtask %s", "")
+	ErrWorkerInvalidStepCount               = New(codeWorkerInvalidStepCount, ClassDMWorker, ScopeInternal, LevelLow, "the number of events to step %d should be positive", "")
+	ErrWorkerInvalidWriteMode               = New(codeWorkerInvalidWriteMode, ClassDMWorker, ScopeInternal, LevelHigh, "invalid write mode %s, it should be %s or %s", "Please use a supported write mode.")
+	ErrWorkerParseCreateTable               = New(codeWorkerParseCreateTable, ClassDMWorker, ScopeInternal, LevelHigh, "fail to parse the table schema of %s", "Please check whether the table schema is supported by TiDB parser.")
+	ErrWorkerSyncNotPaused                  = New(codeWorkerSyncNotPaused, ClassDMWorker, ScopeInternal, LevelLow, "the sync phase of subtask %s is not paused", "")
+	ErrWorkerServerIDCollision              = New(codeWorkerServerIDCollision, ClassDMWorker, ScopeInternal, LevelHigh, "server-id %d of subtask %s is also used by subtask %s to replicate binlog from upstream %s", "Please resume the subtask, it replicates binlog with a new random server-id after resuming.")
+	ErrWorkerOperateAllFailed               = New(codeWorkerOperateAllFailed, ClassDMWorker, ScopeInternal, LevelMedium, "%s failed on subtasks %v", "Please check the errors of the subtasks and operate them manually.")
+	ErrWorkerDrainFailed                    = New(codeWorkerDrainFailed, ClassDMWorker, ScopeInternal, LevelMedium, "subtasks %v are not paused at safe points", "Please check the subtasks, they may replay some events in safe mode after resuming.")
+	ErrWorkerFlavorMismatch                 = New(codeWorkerFlavorMismatch, ClassDMWorker, ScopeInternal, LevelMedium, "flavor %s of subtask %s is different from flavor %s of source %s", "Please remove `flavor` from the subtask config or make it the same as the source config.")
+	ErrWorkerInvalidSubTaskStage            = New(codeWorkerInvalidSubTaskStage, ClassDMWorker, ScopeInternal, LevelMedium, "subtask %s can't be started in or transited to stage %s", "Please use stage Running, Paused or Finished.")
+	ErrWorkerSubTaskDependencyNotFound      = New(codeWorkerSubTaskDependencyNotFound, ClassDMWorker, ScopeInternal, LevelHigh, "dependency %s of subtask %s is not found", "Please start the dependency on the same source, or remove it from `dependencies` in task config.")
+	ErrWorkerSubTaskDependencyNotReady      = New(codeWorkerSubTaskDependencyNotReady, ClassDMWorker, ScopeInternal, LevelMedium, "dependency %s of subtask %s has not finished load unit after waiting %s", "Please resume the subtask after the dependency finishes load unit.")
+	ErrWorkerRelayFilesNeedTLS              = New(codeWorkerRelayFilesNeedTLS, ClassDMWorker, ScopeInternal, LevelHigh, "relay-files-addr %s is set without TLS client certificate authentication", "Please set `ssl-ca`, `ssl-cert` and `ssl-key` in worker configuration file, or unset `relay-files-addr`.")
 
 	// DM-tracer error
 	ErrTracerParseFlagSet        = New(codeTracerParseFlagSet, ClassDMTracer, ScopeInternal, LevelMedium, "parse dm-tracer config flag set", "")
@@ -456,6 +456,70 @@ func GetGTIDMode(ctx context.Context, db *sql.DB) (string, error) {
 	return val, err
 }
 
+// BinlogSettings represents the binlog settings of upstream which decide whether row events contain the full rows.
+type BinlogSettings struct {
+	Format string `json:"binlog-format"`
+	// RowImage is empty if the server doesn't support `binlog_row_image`, which is always FULL.
+	RowImage string `json:"binlog-row-image"`
+}
+
+// GetBinlogSettings gets `binlog_format` and `binlog_row_image` of the server.
+func GetBinlogSettings(ctx context.Context, db *sql.DB) (BinlogSettings, error) {
+	var settings BinlogSettings
+	format, err := GetGlobalVariable(ctx, db, "binlog_format")
+	if err != nil {
+		return settings, err
+	}
+	settings.Format = format
+	rowImage, err := GetGlobalVariable(ctx, db, "binlog_row_image")
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return settings, err
+	}
+	settings.RowImage = rowImage
+	return settings, nil
+}
+
+// CheckFormat checks `binlog_format` is ROW.
+func (s BinlogSettings) CheckFormat() error {
+	if !strings.EqualFold(s.Format, "ROW") {
+		return terror.ErrBinlogSettingsNotSupported.Generate("binlog_format", s.Format, "ROW")
+	}
+	return nil
+}
+
+// CheckRowImage checks `binlog_row_image` is FULL if supported.
+func (s BinlogSettings) CheckRowImage() error {
+	if s.RowImage != "" && !strings.EqualFold(s.RowImage, "FULL") {
+		return terror.ErrBinlogSettingsNotSupported.Generate("binlog_row_image", s.RowImage, "FULL")
+	}
+	return nil
+}
+
+// readOnlyVariables are the global variables which make MySQL or TiDB read-only when set to ON.
+var readOnlyVariables = []string{"read_only", "super_read_only", "tidb_restricted_read_only", "tidb_super_read_only"}
+
+// IsReadOnly checks whether the server is read-only, variables not supported by the server are ignored.
+func IsReadOnly(ctx context.Context, db *sql.DB) (bool, error) {
+	query := fmt.Sprintf("SHOW GLOBAL VARIABLES WHERE Variable_name IN ('%s')", strings.Join(readOnlyVariables, "','"))
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return false, terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+	}
+	defer rows.Close()
+
+	var readOnly bool
+	for rows.Next() {
+		var variable, value string
+		if err = rows.Scan(&variable, &value); err != nil {
+			return false, terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+		}
+		if strings.EqualFold(value, "ON") || value == "1" {
+			readOnly = true
+		}
+	}
+	return readOnly, terror.DBErrorAdapt(rows.Err(), terror.ErrDBDriverError)
+}
+
 // ExtractTiDBVersion extract tidb's version
 // version format: "5.7.25-TiDB-v3.0.0-beta-211-g09beefbe0-dirty"
 //                               ^~~~~~~~~^
@@ -15,6 +15,7 @@ package utils
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
@@ -57,6 +58,59 @@ func (t *testDBSuite) TestGetFlavor(c *C) {
 	c.Assert(mock.ExpectationsWereMet(), IsNil)
 }
 
+func (t *testDBSuite) TestIsReadOnly(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	query := "SHOW GLOBAL VARIABLES WHERE Variable_name IN .*"
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("read_only", "OFF").AddRow("super_read_only", "OFF"))
+	readOnly, err := IsReadOnly(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(readOnly, IsFalse)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("read_only", "OFF").AddRow("tidb_restricted_read_only", "ON"))
+	readOnly, err = IsReadOnly(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(readOnly, IsTrue)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
+func (t *testDBSuite) TestGetBinlogSettings(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	mockVariable := func(variable, value string) {
+		mock.ExpectQuery(fmt.Sprintf("SHOW GLOBAL VARIABLES LIKE '%s'", variable)).WillReturnRows(
+			sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow(variable, value))
+	}
+
+	mockVariable("binlog_format", "ROW")
+	mockVariable("binlog_row_image", "FULL")
+	settings, err := GetBinlogSettings(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(settings, DeepEquals, BinlogSettings{Format: "ROW", RowImage: "FULL"})
+	c.Assert(settings.CheckFormat(), IsNil)
+	c.Assert(settings.CheckRowImage(), IsNil)
+
+	mockVariable("binlog_format", "MIXED")
+	mockVariable("binlog_row_image", "minimal")
+	settings, err = GetBinlogSettings(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(settings.CheckFormat(), ErrorMatches, ".*upstream binlog_format is MIXED, but ROW is required.*")
+	c.Assert(settings.CheckRowImage(), ErrorMatches, ".*upstream binlog_row_image is minimal, but FULL is required.*")
+
+	// binlog_row_image is not supported.
+	mockVariable("binlog_format", "row")
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'binlog_row_image'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}))
+	settings, err = GetBinlogSettings(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(settings, DeepEquals, BinlogSettings{Format: "row"})
+	c.Assert(settings.CheckFormat(), IsNil)
+	c.Assert(settings.CheckRowImage(), IsNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
 func (t *testDBSuite) TestGetRandomServerID(c *C) {
 	db, mock, err := sqlmock.New()
 	c.Assert(err, IsNil)
@@ -15,6 +15,7 @@ package relay
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/pkg/log"
@@ -30,6 +31,12 @@ type Config struct {
 	Flavor      string          `toml:"flavor" json:"flavor"`
 	Charset     string          `toml:"charset" json:"charset"`
 	From        config.DBConfig `toml:"data-source" json:"data-source"`
+	// fetch relay log from the DM-worker at this HTTPS address rather than pulling from upstream if not empty
+	RelaySource string `toml:"relay-source" json:"relay-source"`
+	// the TLS config used to fetch relay log from `RelaySource`
+	RelaySourceSecurity config.Security `toml:"relay-source-security" json:"relay-source-security"`
+	// only relay row events of tables needed by subtasks, see RelayedTables
+	PartialRelay bool `toml:"partial-relay" json:"partial-relay"`
 
 	// synchronous start point (if no meta saved before)
 	// do not need to specify binlog-pos, because relay will fetch the whole file
@@ -39,6 +46,9 @@ type Config struct {
 
 	// for binlog reader retry
 	ReaderRetry retry.ReaderRetryConfig `toml:"reader-retry" json:"reader-retry"`
+	// for restarting relay after upstream disconnected, see reconnector
+	ReconnectRetries int           `toml:"reconnect-retries" json:"reconnect-retries"`
+	ReconnectBackoff time.Duration `toml:"reconnect-backoff" json:"reconnect-backoff"`
 }
 
 func (c *Config) String() string {
@@ -53,16 +63,18 @@ func (c *Config) String() string {
 func FromSourceCfg(sourceCfg *config.SourceConfig) *Config {
 	clone := sourceCfg.DecryptPassword()
 	cfg := &Config{
-		EnableGTID:  clone.EnableGTID,
-		AutoFixGTID: clone.AutoFixGTID,
-		Flavor:      clone.Flavor,
-		RelayDir:    clone.RelayDir,
-		ServerID:    clone.ServerID,
-		Charset:     clone.Charset,
-		From:        clone.From,
-		BinLogName:  clone.RelayBinLogName,
-		BinlogGTID:  clone.RelayBinlogGTID,
-		UUIDSuffix:  clone.UUIDSuffix,
+		EnableGTID:   clone.EnableGTID,
+		AutoFixGTID:  clone.AutoFixGTID,
+		Flavor:       clone.Flavor,
+		RelayDir:     clone.RelayDir,
+		ServerID:     clone.ServerID,
+		Charset:      clone.Charset,
+		From:         clone.From,
+		RelaySource:  clone.RelaySource,
+		PartialRelay: clone.PartialRelay,
+		BinLogName:   clone.RelayBinLogName,
+		BinlogGTID:   clone.RelayBinlogGTID,
+		UUIDSuffix:   clone.UUIDSuffix,
 		ReaderRetry: retry.ReaderRetryConfig{ // we use config from TaskChecker now
 			BackoffRollback: clone.Checker.BackoffRollback.Duration,
 			BackoffMax:      clone.Checker.BackoffMax.Duration,
@@ -70,6 +82,10 @@ func FromSourceCfg(sourceCfg *config.SourceConfig) *Config {
 			BackoffJitter:   clone.Checker.BackoffJitter,
 			BackoffFactor:   clone.Checker.BackoffFactor,
 		},
+		ReconnectRetries: clone.RelayReconnectRetries,
+		ReconnectBackoff: clone.RelayReconnectBackoff.Duration,
+
+		RelaySourceSecurity: clone.RelaySourceSecurity,
 	}
 	return cfg
 }
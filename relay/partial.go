@@ -0,0 +1,277 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// maxDiscardedTables is the max number of tables recorded as discarded by partial relay.
+const maxDiscardedTables = 1000
+
+// RelayedTables decides the tables whose row events are relayed when partial relay is enabled, it's the union of the
+// block-allow lists of subtasks using relay. a nil RelayedTables relays all tables.
+type RelayedTables struct {
+	rules   map[string]*filter.Rules // task name -> block-allow list, nil means all tables
+	filters []*filter.Filter
+}
+
+// NewRelayedTables creates RelayedTables from configs of subtasks using relay, it returns nil if no subtasks,
+// so all tables are relayed before any subtask is known.
+func NewRelayedTables(cfgs []*config.SubTaskConfig) (*RelayedTables, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	rt := &RelayedTables{
+		rules:   make(map[string]*filter.Rules, len(cfgs)),
+		filters: make([]*filter.Filter, 0, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		f, err := filter.New(cfg.CaseSensitive, cfg.BAList)
+		if err != nil {
+			return nil, terror.ErrRelayPartialRelayFilter.Delegate(err, cfg.Name)
+		}
+		rt.rules[cfg.Name] = cfg.BAList
+		rt.filters = append(rt.filters, f)
+	}
+	return rt, nil
+}
+
+// Match returns whether row events of the table should be relayed.
+func (rt *RelayedTables) Match(schema, table string) bool {
+	if rt == nil || strings.EqualFold(schema, filter.DMHeartbeatSchema) {
+		return true
+	}
+	tb := &filter.Table{Schema: schema, Name: table}
+	for _, f := range rt.filters {
+		if f.Match(tb) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscardedTable represents a table whose row events are discarded by partial relay.
+type DiscardedTable struct {
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	// the relay log position and the time of the first discarded event
+	Since     mysql.Position `json:"since"`
+	SinceTime time.Time      `json:"since-time"`
+}
+
+// PartialRelayStatus represents the status of partial relay.
+type PartialRelayStatus struct {
+	Enabled bool `json:"enabled"`
+	// block-allow lists of subtasks by task name, all tables are relayed if it's empty
+	Tables          map[string]*filter.Rules `json:"tables"`
+	DiscardedTables []DiscardedTable         `json:"discarded-tables"` // sorted by schema and table
+	DiscardedEvents int64                    `json:"discarded-events"`
+	DiscardedBytes  int64                    `json:"discarded-bytes"`
+}
+
+// partialRelay discards row events (and their table map events) of tables not in RelayedTables.
+// the writer fills the holes with dummy events, so the positions in relay log are the same as upstream.
+type partialRelay struct {
+	mu          sync.Mutex
+	enabled     bool
+	tables      *RelayedTables
+	tableIDSize int
+	// table ID -> whether it's relayed, decided by its table map event, so the row events following a table map
+	// event are relayed or discarded together with it even the RelayedTables changes between them.
+	tableIDs  map[uint64]bool
+	discarded map[string]*DiscardedTable
+
+	discardedEvents int64
+	discardedBytes  int64
+}
+
+func newPartialRelay(enabled bool) *partialRelay {
+	return &partialRelay{
+		enabled:     enabled,
+		tableIDSize: 6,
+		tableIDs:    make(map[uint64]bool),
+		discarded:   make(map[string]*DiscardedTable),
+	}
+}
+
+func (p *partialRelay) setEnabled(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.enabled = enabled
+}
+
+func (p *partialRelay) setTables(tables *RelayedTables) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tables = tables
+	// tables relayed now are not discarded anymore.
+	for key, d := range p.discarded {
+		if tables.Match(d.Schema, d.Table) {
+			delete(p.discarded, key)
+		}
+	}
+}
+
+// skip returns whether the event should be discarded, pos is the position of the event in relay log.
+func (p *partialRelay) skip(e *replication.BinlogEvent, pos mysql.Position) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e.Header.EventType {
+	case replication.FORMAT_DESCRIPTION_EVENT:
+		// table IDs are only valid in a binlog file.
+		p.tableIDs = make(map[uint64]bool)
+		p.tableIDSize = 6
+		if fde, ok := e.Event.(*replication.FormatDescriptionEvent); ok &&
+			len(fde.EventTypeHeaderLengths) >= int(replication.TABLE_MAP_EVENT) &&
+			fde.EventTypeHeaderLengths[replication.TABLE_MAP_EVENT-1] == 6 {
+			p.tableIDSize = 4
+		}
+		return false
+	case replication.TABLE_MAP_EVENT:
+		id, schema, table, ok := p.decodeTableMap(e)
+		if !ok {
+			return false
+		}
+		relayed := !p.enabled || p.tables.Match(schema, table)
+		p.tableIDs[id] = relayed
+		if !relayed {
+			p.discard(e, schema, table, pos)
+		}
+		return !relayed
+	case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2,
+		replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2,
+		replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
+		id, ok := p.decodeRowsTableID(e)
+		if !ok {
+			return false
+		}
+		// the table map event decides whether its row events are relayed, even partial relay is disabled after it.
+		relayed, ok := p.tableIDs[id]
+		if ok && !relayed {
+			p.discardedEvents++
+			p.discardedBytes += int64(e.Header.EventSize)
+			return true
+		}
+	}
+	return false
+}
+
+func (p *partialRelay) discard(e *replication.BinlogEvent, schema, table string, pos mysql.Position) {
+	p.discardedEvents++
+	p.discardedBytes += int64(e.Header.EventSize)
+	key := dbutil.TableName(schema, table)
+	if _, ok := p.discarded[key]; ok || len(p.discarded) >= maxDiscardedTables {
+		return
+	}
+	p.discarded[key] = &DiscardedTable{
+		Schema:    schema,
+		Table:     table,
+		Since:     mysql.Position{Name: pos.Name, Pos: e.Header.LogPos - e.Header.EventSize},
+		SinceTime: time.Now(),
+	}
+}
+
+// decodeTableMap returns the table ID, schema and table of a table map event, the event is not parsed in raw mode.
+func (p *partialRelay) decodeTableMap(e *replication.BinlogEvent) (uint64, string, string, bool) {
+	if ev, ok := e.Event.(*replication.TableMapEvent); ok {
+		return ev.TableID, string(ev.Schema), string(ev.Table), true
+	}
+	if len(e.RawData) < replication.EventHeaderSize {
+		return 0, "", "", false
+	}
+	// table_id, flags (2 bytes), schema length (1 byte), schema, 0x00, table length (1 byte), table, 0x00, ...
+	data := e.RawData[replication.EventHeaderSize:]
+	pos := p.tableIDSize + 2
+	if len(data) < pos+1 {
+		return 0, "", "", false
+	}
+	id := mysql.FixedLengthInt(data[:p.tableIDSize])
+	schemaLen := int(data[pos])
+	pos++
+	if len(data) < pos+schemaLen+2 {
+		return 0, "", "", false
+	}
+	schema := string(data[pos : pos+schemaLen])
+	pos += schemaLen + 1
+	tableLen := int(data[pos])
+	pos++
+	if len(data) < pos+tableLen {
+		return 0, "", "", false
+	}
+	return id, schema, string(data[pos : pos+tableLen]), true
+}
+
+// decodeRowsTableID returns the table ID of a row event, the event is not parsed in raw mode.
+func (p *partialRelay) decodeRowsTableID(e *replication.BinlogEvent) (uint64, bool) {
+	if ev, ok := e.Event.(*replication.RowsEvent); ok {
+		return ev.TableID, true
+	}
+	if len(e.RawData) < replication.EventHeaderSize+p.tableIDSize {
+		return 0, false
+	}
+	data := e.RawData[replication.EventHeaderSize:]
+	return mysql.FixedLengthInt(data[:p.tableIDSize]), true
+}
+
+func (p *partialRelay) status() *PartialRelayStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := &PartialRelayStatus{
+		Enabled:         p.enabled,
+		Tables:          make(map[string]*filter.Rules),
+		DiscardedTables: make([]DiscardedTable, 0, len(p.discarded)),
+		DiscardedEvents: p.discardedEvents,
+		DiscardedBytes:  p.discardedBytes,
+	}
+	if p.tables != nil {
+		for name, rules := range p.tables.rules {
+			status.Tables[name] = rules
+		}
+	}
+	for _, d := range p.discarded {
+		status.DiscardedTables = append(status.DiscardedTables, *d)
+	}
+	sort.Slice(status.DiscardedTables, func(i, j int) bool {
+		ti, tj := status.DiscardedTables[i], status.DiscardedTables[j]
+		if ti.Schema != tj.Schema {
+			return ti.Schema < tj.Schema
+		}
+		return ti.Table < tj.Table
+	})
+	return status
+}
+
+// SetRelayedTables sets the tables whose row events are relayed when partial relay is enabled, it takes effect from
+// the next table map event.
+func (r *Relay) SetRelayedTables(tables *RelayedTables) {
+	r.partial.setTables(tables)
+}
+
+// PartialRelayStatus returns the relayed tables and the tables discarded by partial relay.
+func (r *Relay) PartialRelayStatus() *PartialRelayStatus {
+	return r.partial.status()
+}
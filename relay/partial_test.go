@@ -0,0 +1,130 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog/event"
+)
+
+var _ = Suite(&testPartialRelaySuite{})
+
+type testPartialRelaySuite struct{}
+
+func genTableMapAndRows(c *C, tableID uint64, schema, table string) (*replication.BinlogEvent, *replication.BinlogEvent) {
+	header := &replication.EventHeader{ServerID: 11}
+	columnType := []byte{mysql.MYSQL_TYPE_LONG}
+	tableMapEv, err := event.GenTableMapEvent(header, 1000, tableID, []byte(schema), []byte(table), columnType)
+	c.Assert(err, IsNil)
+	rowsEv, err := event.GenRowsEvent(header, tableMapEv.Header.LogPos, replication.WRITE_ROWS_EVENTv2, tableID,
+		event.RowFlagsEndOfStatement, [][]interface{}{{int32(1)}}, columnType, tableMapEv)
+	c.Assert(err, IsNil)
+	return tableMapEv, rowsEv
+}
+
+// rawEvent returns the event as received in raw mode, only the header and the raw data are available.
+func rawEvent(e *replication.BinlogEvent) *replication.BinlogEvent {
+	return &replication.BinlogEvent{Header: e.Header, RawData: e.RawData, Event: &replication.GenericEvent{}}
+}
+
+func (t *testPartialRelaySuite) TestRelayedTables(c *C) {
+	rt, err := NewRelayedTables(nil)
+	c.Assert(err, IsNil)
+	c.Assert(rt, IsNil)
+	c.Assert(rt.Match("db", "tbl"), IsTrue)
+
+	rt, err = NewRelayedTables([]*config.SubTaskConfig{
+		{Name: "task1", BAList: &filter.Rules{DoDBs: []string{"db1"}}},
+		{Name: "task2", BAList: &filter.Rules{DoTables: []*filter.Table{{Schema: "db2", Name: "tbl"}}}},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(rt.Match("db1", "any"), IsTrue)
+	c.Assert(rt.Match("db2", "tbl"), IsTrue)
+	c.Assert(rt.Match("db2", "other"), IsFalse)
+	c.Assert(rt.Match("db3", "tbl"), IsFalse)
+	c.Assert(rt.Match("dm_heartbeat", "heartbeat"), IsTrue)
+
+	// a subtask without block-allow list needs all tables.
+	rt, err = NewRelayedTables([]*config.SubTaskConfig{{Name: "task1", BAList: &filter.Rules{DoDBs: []string{"db1"}}}, {Name: "task2"}})
+	c.Assert(err, IsNil)
+	c.Assert(rt.Match("db3", "tbl"), IsTrue)
+}
+
+func (t *testPartialRelaySuite) TestSkip(c *C) {
+	rt, err := NewRelayedTables([]*config.SubTaskConfig{{Name: "task1", BAList: &filter.Rules{DoDBs: []string{"db1"}}}})
+	c.Assert(err, IsNil)
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 4}
+
+	keptMap, keptRows := genTableMapAndRows(c, 100, "db1", "tbl")
+	discardedMap, discardedRows := genTableMapAndRows(c, 101, "db2", "tbl")
+	fde, err := event.GenFormatDescriptionEvent(&replication.EventHeader{ServerID: 11}, 4)
+	c.Assert(err, IsNil)
+	query, err := event.GenQueryEvent(&replication.EventHeader{ServerID: 11}, 1000, 0, 0, 0, nil, []byte("db2"), []byte("ALTER TABLE tbl ADD COLUMN c INT"))
+	c.Assert(err, IsNil)
+
+	for _, raw := range []bool{false, true} {
+		conv := func(e *replication.BinlogEvent) *replication.BinlogEvent {
+			if raw {
+				return rawEvent(e)
+			}
+			return e
+		}
+
+		// disabled.
+		p := newPartialRelay(false)
+		p.setTables(rt)
+		c.Assert(p.skip(fde, pos), IsFalse)
+		c.Assert(p.skip(conv(discardedMap), pos), IsFalse)
+		c.Assert(p.skip(conv(discardedRows), pos), IsFalse)
+
+		// enabled, DDLs are always relayed.
+		p.setEnabled(true)
+		c.Assert(p.skip(conv(query), pos), IsFalse)
+		c.Assert(p.skip(conv(keptMap), pos), IsFalse)
+		c.Assert(p.skip(conv(keptRows), pos), IsFalse)
+		c.Assert(p.skip(conv(discardedMap), pos), IsTrue)
+		c.Assert(p.skip(conv(discardedRows), pos), IsTrue)
+
+		status := p.status()
+		c.Assert(status.Enabled, IsTrue)
+		c.Assert(status.Tables, HasLen, 1)
+		c.Assert(status.DiscardedEvents, Equals, int64(2))
+		c.Assert(status.DiscardedBytes, Equals, int64(discardedMap.Header.EventSize+discardedRows.Header.EventSize))
+		c.Assert(status.DiscardedTables, HasLen, 1)
+		c.Assert(status.DiscardedTables[0].Schema, Equals, "db2")
+		c.Assert(status.DiscardedTables[0].Table, Equals, "tbl")
+		c.Assert(status.DiscardedTables[0].Since, Equals, mysql.Position{Name: pos.Name, Pos: discardedMap.Header.LogPos - discardedMap.Header.EventSize})
+
+		// row events follow the decision of their table map event even the relayed tables changed.
+		c.Assert(p.skip(conv(discardedMap), pos), IsTrue)
+		rt2, err := NewRelayedTables([]*config.SubTaskConfig{{Name: "task1"}})
+		c.Assert(err, IsNil)
+		p.setTables(rt2)
+		c.Assert(p.status().DiscardedTables, HasLen, 0)
+		c.Assert(p.skip(conv(discardedRows), pos), IsTrue)
+		c.Assert(p.skip(conv(discardedMap), pos), IsFalse)
+		c.Assert(p.skip(conv(discardedRows), pos), IsFalse)
+
+		// table IDs are reset by a new binlog file.
+		p.setTables(rt)
+		c.Assert(p.skip(conv(discardedMap), pos), IsTrue)
+		c.Assert(p.skip(fde, pos), IsFalse)
+		c.Assert(p.skip(conv(discardedRows), pos), IsFalse)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purger
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/replication"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog/event"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/streamer"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// GTIDPurgeResult represents the relay log files purged by a GTID set
+type GTIDPurgeResult struct {
+	Files []string `json:"files"` // paths of purged relay log files
+	// the earliest retained relay log file, and the GTID set before it (in its PreviousGTIDsEvent)
+	EarliestRetainedFile string `json:"earliest-retained-file"`
+	EarliestRetainedGTID string `json:"earliest-retained-gtid"`
+}
+
+// relayFileGTIDs represents a relay log file and the GTID set before it
+type relayFileGTIDs struct {
+	info *streamer.RelayLogInfo
+	path string
+	prev gtid.Set
+}
+
+// PurgeByGTID purges all relay log files whose GTIDs are all contained in gs, i.e. the files before the latest file
+// whose previous GTID set is contained in gs. it fails if the files are still needed by the active relay logs.
+func (p *RelayPurger) PurgeByGTID(ctx context.Context, gs gtid.Set) (*GTIDPurgeResult, error) {
+	uuids, err := utils.ParseUUIDIndex(p.indexPath)
+	if err != nil {
+		return nil, terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
+	}
+	files, err := collectRelayFileGTIDs(ctx, p.baseRelayDir, uuids)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, terror.ErrRelayNoActiveRelayLog.Generate()
+	}
+
+	// the boundary is the earliest file retained, the files before it only contain GTIDs in its previous GTID set.
+	boundary := files[0]
+	for _, f := range files[1:] {
+		if !gs.Contain(f.prev) {
+			break
+		}
+		boundary = f
+	}
+	result := &GTIDPurgeResult{
+		Files:                make([]string, 0),
+		EarliestRetainedFile: boundary.path,
+		EarliestRetainedGTID: boundary.prev.String(),
+	}
+	if boundary == files[0] {
+		p.logger.Info("no relay log files need to purge by GTID set", zap.Stringer("gtid set", gs))
+		return result, nil
+	}
+
+	args := &filenameArgs{
+		relayBaseDir: p.baseRelayDir,
+		filename:     boundary.info.Filename,
+		subDir:       boundary.info.UUID,
+		uuids:        uuids,
+	}
+	// check the safe relay log is not moved ahead by the active relay logs, so no subtask needs the files to purge.
+	if err = p.prepare(args); err != nil {
+		return nil, err
+	}
+	if args.safeRelayLog.Earlier(boundary.info) {
+		return nil, terror.ErrRelayPurgeByGTIDNotSafe.Generate(boundary.info, gs, args.safeRelayLog, args.safeRelayLog.TaskName)
+	}
+
+	ps := p.strategies[strategyFilename]
+	subFiles, err := ps.Preview(args)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.doPurge(ps, args); err != nil {
+		return nil, err
+	}
+	for _, sub := range subFiles {
+		result.Files = append(result.Files, sub.files...)
+	}
+	p.logger.Info("purged relay log files by GTID set", zap.Stringer("gtid set", gs), zap.Int("files", len(result.Files)), zap.String("earliest retained file", result.EarliestRetainedFile))
+	return result, nil
+}
+
+// collectRelayFileGTIDs collects relay log files in all sub directories in order, with the GTID set before them.
+func collectRelayFileGTIDs(ctx context.Context, relayBaseDir string, uuids []string) ([]*relayFileGTIDs, error) {
+	var result []*relayFileGTIDs
+	for _, uuid := range uuids {
+		_, suffix, err := utils.ParseSuffixForUUID(uuid)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(relayBaseDir, uuid)
+		names, err := streamer.CollectAllBinlogFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			select {
+			case <-ctx.Done():
+				return nil, terror.Annotatef(ctx.Err(), "collect relay log files in %s", dir)
+			default:
+			}
+			path := filepath.Join(dir, name)
+			prev, err := previousGTIDsOfFile(path)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &relayFileGTIDs{
+				info: &streamer.RelayLogInfo{
+					TaskName:   fakeTaskName,
+					UUID:       uuid,
+					UUIDSuffix: suffix,
+					Filename:   name,
+				},
+				path: path,
+				prev: prev,
+			})
+		}
+	}
+	return result, nil
+}
+
+var errPreviousGTIDsFound = errors.New("previous GTIDs found")
+
+// previousGTIDsOfFile returns the GTID set in the PreviousGTIDsEvent (or MariadbGTIDListEvent) of the relay log file.
+func previousGTIDsOfFile(path string) (gtid.Set, error) {
+	var gs gtid.Set
+	parser := replication.NewBinlogParser()
+	parser.SetVerifyChecksum(true)
+	err := parser.ParseFile(path, 4, func(e *replication.BinlogEvent) error {
+		var err error
+		switch e.Header.EventType {
+		case replication.PREVIOUS_GTIDS_EVENT:
+			gs, err = event.GTIDsFromPreviousGTIDsEvent(e)
+		case replication.MARIADB_GTID_LIST_EVENT:
+			gs, err = event.GTIDsFromMariaDBGTIDListEvent(e)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return errPreviousGTIDsFound
+	})
+	if err != nil && errors.Cause(err) != errPreviousGTIDsFound {
+		return nil, terror.ErrParserParseRelayLog.Delegate(err, path)
+	}
+	if gs == nil {
+		return nil, terror.ErrPreviousGTIDNotExist.Generate(path)
+	}
+	return gs, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purger
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	gmysql "github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/ioutil2"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog/event"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/streamer"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var _ = Suite(&testPurgeByGTIDSuite{})
+
+type testPurgeByGTIDSuite struct {
+	activeRelayLog *streamer.RelayLogInfo
+}
+
+func (t *testPurgeByGTIDSuite) EarliestActiveRelayLog() *streamer.RelayLogInfo {
+	return t.activeRelayLog
+}
+
+func (t *testPurgeByGTIDSuite) TestPurgeByGTID(c *C) {
+	var (
+		uuids = []string{
+			"c6ae5afe-c7a3-11e8-a19d-0242ac130006.000001",
+			"e9540a0d-f16d-11e8-8cb7-0242ac130008.000002",
+		}
+		filenames = []string{"mysql-bin.000001", "mysql-bin.000002", "mysql-bin.000003"}
+		// previous GTID sets of relay log files
+		prevGTIDs = [][]string{
+			{"", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-10", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-20"},
+			{"3ccc475b-2343-11e7-be21-6c0b84d59f30:1-30", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-40", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-50"},
+		}
+		baseDir        = c.MkDir()
+		relayFilesPath = make([][]string, 0, len(uuids))
+		index          bytes.Buffer
+	)
+	for i, uuid := range uuids {
+		dir := filepath.Join(baseDir, uuid)
+		c.Assert(os.Mkdir(dir, 0700), IsNil)
+		relayFilesPath = append(relayFilesPath, []string{})
+		for j, fn := range filenames {
+			gs, err := gtid.ParserGTID(gmysql.MySQLFlavor, prevGTIDs[i][j])
+			c.Assert(err, IsNil)
+			_, data, err := event.GenCommonFileHeader(gmysql.MySQLFlavor, 11, gs)
+			c.Assert(err, IsNil)
+			fp := filepath.Join(dir, fn)
+			c.Assert(ioutil.WriteFile(fp, data, 0644), IsNil)
+			relayFilesPath[i] = append(relayFilesPath[i], fp)
+		}
+		index.WriteString(uuid)
+		index.WriteString("\n")
+	}
+	c.Assert(ioutil2.WriteFileAtomic(filepath.Join(baseDir, utils.UUIDIndexFilename), index.Bytes(), 0644), IsNil)
+
+	purger := NewPurger(config.PurgeConfig{}, baseDir, []RelayOperator{t}, nil)
+	purgeByGTID := func(set string) (*GTIDPurgeResult, error) {
+		gs, err := gtid.ParserGTID(gmysql.MySQLFlavor, set)
+		c.Assert(err, IsNil)
+		return purger.PurgeByGTID(context.Background(), gs)
+	}
+
+	// no files to purge, the GTID set is before the second file.
+	t.activeRelayLog = &streamer.RelayLogInfo{TaskName: fakeTaskName, UUID: uuids[1], UUIDSuffix: 2, Filename: filenames[0]}
+	result, err := purgeByGTID("3ccc475b-2343-11e7-be21-6c0b84d59f30:1-5")
+	c.Assert(err, IsNil)
+	c.Assert(result.Files, HasLen, 0)
+	c.Assert(result.EarliestRetainedFile, Equals, relayFilesPath[0][0])
+	c.Assert(result.EarliestRetainedGTID, Equals, "")
+
+	// files before the active relay log are still needed.
+	t.activeRelayLog = &streamer.RelayLogInfo{TaskName: fakeTaskName, UUID: uuids[0], UUIDSuffix: 1, Filename: filenames[2]}
+	_, err = purgeByGTID("3ccc475b-2343-11e7-be21-6c0b84d59f30:1-45")
+	c.Assert(terror.ErrRelayPurgeByGTIDNotSafe.Equal(err), IsTrue)
+	for _, files := range relayFilesPath {
+		for _, fp := range files {
+			c.Assert(utils.IsFileExists(fp), IsTrue)
+		}
+	}
+
+	// purge the files before the latest file whose previous GTID set is contained.
+	t.activeRelayLog = &streamer.RelayLogInfo{TaskName: fakeTaskName, UUID: uuids[1], UUIDSuffix: 2, Filename: filenames[0]}
+	result, err = purgeByGTID("3ccc475b-2343-11e7-be21-6c0b84d59f30:1-25")
+	c.Assert(err, IsNil)
+	c.Assert(result.Files, DeepEquals, relayFilesPath[0][:2])
+	c.Assert(result.EarliestRetainedFile, Equals, relayFilesPath[0][2])
+	c.Assert(result.EarliestRetainedGTID, Equals, prevGTIDs[0][2])
+	c.Assert(utils.IsFileExists(relayFilesPath[0][0]), IsFalse)
+	c.Assert(utils.IsFileExists(relayFilesPath[0][1]), IsFalse)
+	c.Assert(utils.IsFileExists(relayFilesPath[0][2]), IsTrue)
+}
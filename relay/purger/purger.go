@@ -15,6 +15,7 @@ package purger
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/streamer"
 	"github.com/pingcap/dm/pkg/terror"
@@ -58,6 +60,18 @@ type Purger interface {
 	Purging() bool
 	// Do does the purge process one time
 	Do(ctx context.Context, req *pb.PurgeRelayRequest) error
+	// DryRun returns the relay log files which would be purged by the request, without purging them
+	DryRun(ctx context.Context, req *pb.PurgeRelayRequest) (*DryRunResult, error)
+	// PurgeByGTID purges the relay log files whose GTIDs are all contained in the GTID set
+	PurgeByGTID(ctx context.Context, gs gtid.Set) (*GTIDPurgeResult, error)
+}
+
+// DryRunResult represents the relay log files which would be purged by a purge request
+type DryRunResult struct {
+	DryRun bool     `json:"dry-run"` // always true, nothing is purged
+	Files  []string `json:"files"`   // paths of relay log files which would be removed
+	Dirs   []string `json:"dirs"`    // sub directories which would be removed entirely, including relay.meta
+	Bytes  int64    `json:"bytes"`   // total size of the files would be removed
 }
 
 // NewPurger creates a new purger
@@ -165,9 +179,54 @@ func (p *RelayPurger) Purging() bool {
 
 // Do does the purge process one time
 func (p *RelayPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
+	ps, args, err := p.parseRequest(req)
+	if err != nil {
+		return err
+	}
+	return p.doPurge(ps, args)
+}
+
+// DryRun returns the relay log files which would be purged by the request, without purging them
+func (p *RelayPurger) DryRun(ctx context.Context, req *pb.PurgeRelayRequest) (*DryRunResult, error) {
+	ps, args, err := p.parseRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.prepare(args); err != nil {
+		return nil, err
+	}
+
+	files, err := ps.Preview(args)
+	if err != nil {
+		return nil, err
+	}
+	result := &DryRunResult{
+		DryRun: true,
+		Files:  make([]string, 0),
+		Dirs:   make([]string, 0),
+	}
+	for _, subRelay := range files {
+		for _, f := range subRelay.files {
+			fs, err := os.Stat(f)
+			if err != nil {
+				return nil, terror.ErrGetRelayLogStat.Delegate(err, f)
+			}
+			result.Files = append(result.Files, f)
+			result.Bytes += fs.Size()
+		}
+		if subRelay.hasAll {
+			result.Dirs = append(result.Dirs, subRelay.dir)
+		}
+	}
+	p.logger.Info("dry run purging relay log files", zap.Stringer("type", ps.Type()), zap.Reflect("args", args), zap.Int("files", len(result.Files)), zap.Int64("bytes", result.Bytes))
+	return result, nil
+}
+
+// parseRequest returns the strategy and its args for a purge request
+func (p *RelayPurger) parseRequest(req *pb.PurgeRelayRequest) (PurgeStrategy, StrategyArgs, error) {
 	uuids, err := utils.ParseUUIDIndex(p.indexPath)
 	if err != nil {
-		return terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
+		return nil, nil, terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
 	}
 
 	if req.Inactive {
@@ -176,7 +235,7 @@ func (p *RelayPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
 			relayBaseDir: p.baseRelayDir,
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return ps, args, nil
 	} else if req.Time > 0 {
 		ps := p.strategies[strategyTime]
 		args := &timeArgs{
@@ -184,7 +243,7 @@ func (p *RelayPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
 			safeTime:     time.Unix(req.Time, 0),
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return ps, args, nil
 	} else if len(req.Filename) > 0 {
 		ps := p.strategies[strategyFilename]
 		args := &filenameArgs{
@@ -193,9 +252,9 @@ func (p *RelayPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
 			subDir:       req.SubDir,
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return ps, args, nil
 	}
-	return terror.ErrRelayPurgeRequestNotValid.Generate(req)
+	return nil, nil, terror.ErrRelayPurgeRequestNotValid.Generate(req)
 }
 
 // tryPurge tries to do purge by check condition first
@@ -221,6 +280,17 @@ func (p *RelayPurger) doPurge(ps PurgeStrategy, args StrategyArgs) error {
 	}
 	defer p.purgingStrategy.Set(uint32(strategyNone))
 
+	// set ActiveRelayLog lazily to make it can be protected by purgingStrategy
+	if err := p.prepare(args); err != nil {
+		return err
+	}
+
+	p.logger.Info("start purging relay log files", zap.Stringer("type", ps.Type()), zap.Reflect("args", args))
+	return ps.Do(args)
+}
+
+// prepare checks whether purging is forbidden by interceptors, and sets the earliest active relay log in args
+func (p *RelayPurger) prepare(args StrategyArgs) error {
 	for _, inter := range p.interceptors {
 		forbidden, msg := inter.ForbidPurge()
 		if forbidden {
@@ -228,15 +298,12 @@ func (p *RelayPurger) doPurge(ps PurgeStrategy, args StrategyArgs) error {
 		}
 	}
 
-	// set ActiveRelayLog lazily to make it can be protected by purgingStrategy
 	earliest := p.earliestActiveRelayLog()
 	if earliest == nil {
 		return terror.ErrRelayNoActiveRelayLog.Generate()
 	}
 	args.SetActiveRelayLog(earliest)
-
-	p.logger.Info("start purging relay log files", zap.Stringer("type", ps.Type()), zap.Reflect("args", args))
-	return ps.Do(args)
+	return nil
 }
 
 func (p *RelayPurger) check() (PurgeStrategy, StrategyArgs, error) {
@@ -326,3 +393,13 @@ func (d *dummyPurger) Purging() bool {
 func (d *dummyPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
 	return nil
 }
+
+// DryRun implements interface of Purger
+func (d *dummyPurger) DryRun(ctx context.Context, req *pb.PurgeRelayRequest) (*DryRunResult, error) {
+	return &DryRunResult{DryRun: true}, nil
+}
+
+// PurgeByGTID implements interface of Purger
+func (d *dummyPurger) PurgeByGTID(ctx context.Context, gs gtid.Set) (*GTIDPurgeResult, error) {
+	return &GTIDPurgeResult{Files: make([]string, 0)}, nil
+}
@@ -186,6 +186,50 @@ func (t *testPurgerSuite) TestPurgeManuallyFilename(c *C) {
 	}
 }
 
+func (t *testPurgerSuite) TestPurgeDryRun(c *C) {
+	// create relay log dir
+	baseDir, err := ioutil.TempDir("", "test_purge_dry_run")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(baseDir)
+
+	// prepare files and directories
+	relayDirsPath, relayFilesPath, _ := t.genRelayLogFiles(c, baseDir, -1, -1)
+	err = t.genUUIDIndexFile(baseDir)
+	c.Assert(err, IsNil)
+
+	purger := NewPurger(config.PurgeConfig{}, baseDir, []RelayOperator{t}, nil)
+
+	req := &pb.PurgeRelayRequest{
+		Inactive: true,
+	}
+	result, err := purger.DryRun(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(result.DryRun, IsTrue)
+	expectedFiles := append(append([]string{}, relayFilesPath[0]...), relayFilesPath[1][:2]...)
+	c.Assert(result.Files, DeepEquals, expectedFiles)
+	c.Assert(result.Dirs, DeepEquals, []string{relayDirsPath[0]})
+	var bytes int64
+	for _, fp := range expectedFiles {
+		fs, err2 := os.Stat(fp)
+		c.Assert(err2, IsNil)
+		bytes += fs.Size()
+	}
+	c.Assert(result.Bytes, Equals, bytes)
+
+	// nothing purged
+	for _, files := range relayFilesPath {
+		for _, fp := range files {
+			c.Assert(utils.IsFileExists(fp), IsTrue)
+		}
+	}
+
+	// forbidden by interceptor
+	interceptor := newFakeInterceptor()
+	purger = NewPurger(config.PurgeConfig{}, baseDir, []RelayOperator{t}, []PurgeInterceptor{interceptor})
+	_, err = purger.DryRun(context.Background(), req)
+	c.Assert(err, ErrorMatches, ".*"+interceptor.msg+".*")
+}
+
 func (t *testPurgerSuite) TestPurgeAutomaticallyTime(c *C) {
 	// create relay log dir
 	baseDir, err := ioutil.TempDir("", "test_purge_automatically_time")
@@ -273,6 +317,37 @@ func (t *testPurgerSuite) TestPurgeAutomaticallySpace(c *C) {
 	}
 }
 
+func (t *testPurgerSuite) TestPreviewSpace(c *C) {
+	// create relay log dir
+	baseDir, err := ioutil.TempDir("", "test_preview_space")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(baseDir)
+
+	// prepare files and directories
+	_, relayFilesPath, _ := t.genRelayLogFiles(c, baseDir, -1, -1)
+
+	storageSize, err := utils.GetStorageSize(baseDir)
+	c.Assert(err, IsNil)
+
+	args := &spaceArgs{
+		relayBaseDir:   baseDir,
+		remainSpace:    0, // remain space is always enough
+		uuids:          t.uuids,
+		activeRelayLog: t.activeRelayLog,
+	}
+	ps := newSpaceStrategy()
+	files, err := ps.Preview(args)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+
+	args.remainSpace = int64(storageSize.Available)/1024/1024/1024 + 1024 // remain space is never enough
+	files, err = ps.Preview(args)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 2)
+	c.Assert(files[0].files, DeepEquals, relayFilesPath[0])
+	c.Assert(files[1].files, DeepEquals, relayFilesPath[1][:2])
+}
+
 func (t *testPurgerSuite) genRelayLogFiles(c *C, baseDir string, safeTimeIdxI, safeTimeIdxJ int) ([]string, [][]string, time.Time) {
 	var (
 		relayDirsPath  = make([]string, 0, 3)
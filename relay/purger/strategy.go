@@ -52,6 +52,9 @@ type PurgeStrategy interface {
 	// Do does the purge process one time
 	Do(args interface{}) error
 
+	// Preview returns the relay log files which would be purged by Do, without purging them
+	Preview(args interface{}) ([]*subRelayFiles, error)
+
 	// Purging indicates whether is doing purge
 	Purging() bool
 
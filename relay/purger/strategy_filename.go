@@ -111,6 +111,15 @@ func (s *filenameStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFile(s.logger, fa.relayBaseDir, fa.uuids, fa.safeRelayLog)
 }
 
+func (s *filenameStrategy) Preview(args interface{}) ([]*subRelayFiles, error) {
+	fa, ok := args.(*filenameArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	return getRelayFilesBeforeFile(s.logger, fa.relayBaseDir, fa.uuids, fa.safeRelayLog)
+}
+
 func (s *filenameStrategy) Purging() bool {
 	return s.purging.Get() > 0
 }
@@ -87,6 +87,20 @@ func (s *spaceStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFile(s.logger, sa.relayBaseDir, sa.uuids, sa.activeRelayLog)
 }
 
+func (s *spaceStrategy) Preview(args interface{}) ([]*subRelayFiles, error) {
+	sa, ok := args.(*spaceArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	// nothing would be purged if the remain space is still enough
+	need, err := s.Check(sa)
+	if err != nil || !need {
+		return nil, err
+	}
+	return getRelayFilesBeforeFile(s.logger, sa.relayBaseDir, sa.uuids, sa.activeRelayLog)
+}
+
 func (s *spaceStrategy) Purging() bool {
 	return s.purging.Get() > 0
 }
@@ -0,0 +1,149 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/backoff"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
+)
+
+// maxReconnectEvents is the max number of recent reconnect events kept.
+const maxReconnectEvents = 10
+
+// maxReconnectBackoff is the max backoff before restarting the relay, the backoff is doubled after every retry until
+// reaching it, unless `relay-reconnect-backoff` is larger.
+var maxReconnectBackoff = 5 * time.Minute
+
+// ReconnectEvent represents an automatic restart of the relay after upstream disconnected.
+type ReconnectEvent struct {
+	Time time.Time `json:"time"`
+	// Attempt is the number of retries since the relay read binlog last time, starting from 1.
+	Attempt int           `json:"attempt"`
+	Backoff time.Duration `json:"backoff"`
+	Error   string        `json:"error"`
+	// Exhausted means the retries are exhausted and the relay stopped with the error.
+	Exhausted bool `json:"exhausted"`
+}
+
+// ReconnectStats represents the automatic restarts of the relay after upstream disconnected.
+type ReconnectStats struct {
+	// Count is the total number of restarts.
+	Count int64 `json:"count"`
+	// Exhausted is the number of times the retries exhausted.
+	Exhausted int64            `json:"exhausted"`
+	Recent    []ReconnectEvent `json:"recent"`
+}
+
+// reconnector records the restarts of the relay, it's safe for concurrent use.
+type reconnector struct {
+	sync.Mutex
+	stats ReconnectStats
+}
+
+func (rc *reconnector) record(ev ReconnectEvent) {
+	rc.Lock()
+	defer rc.Unlock()
+	if ev.Exhausted {
+		rc.stats.Exhausted++
+	} else {
+		rc.stats.Count++
+	}
+	rc.stats.Recent = append(rc.stats.Recent, ev)
+	if len(rc.stats.Recent) > maxReconnectEvents {
+		rc.stats.Recent = rc.stats.Recent[len(rc.stats.Recent)-maxReconnectEvents:]
+	}
+}
+
+func (rc *reconnector) get() ReconnectStats {
+	rc.Lock()
+	defer rc.Unlock()
+	stats := rc.stats
+	stats.Recent = append([]ReconnectEvent{}, rc.stats.Recent...)
+	return stats
+}
+
+// isDisconnectError returns whether err is caused by the connection to upstream broken or refused.
+func isDisconnectError(err error) bool {
+	if retry.IsConnectionError(err) {
+		return true
+	}
+	cause := errors.Cause(err)
+	if cause == io.EOF || cause == io.ErrUnexpectedEOF {
+		return true
+	}
+	_, ok := cause.(net.Error)
+	return ok
+}
+
+// processWithReconnect runs process, and restarts it after backoff if it fails because upstream disconnected, the
+// relay log is continued from the relayed position in meta. it returns the error when the retries are exhausted,
+// and the retries are counted again once the relay reads binlog after restarted.
+func (r *Relay) processWithReconnect(ctx context.Context, process func(context.Context) error) error {
+	maxBackoff := maxReconnectBackoff
+	if maxBackoff < r.cfg.ReconnectBackoff {
+		maxBackoff = r.cfg.ReconnectBackoff
+	}
+	bf, err := backoff.NewBackoff(2, false, r.cfg.ReconnectBackoff, maxBackoff)
+	if err != nil {
+		return err
+	}
+
+	attempt := 0
+	for {
+		start := time.Now()
+		// each run has its own context to stop the background operations started by it.
+		runCtx, cancel := context.WithCancel(ctx)
+		err := process(runCtx)
+		cancel()
+		if err == nil || ctx.Err() != nil || !isDisconnectError(err) {
+			return err
+		}
+		if r.lastWrite.Get() >= start.UnixNano() {
+			attempt = 0
+			bf.Reset()
+		}
+
+		attempt++
+		wait := bf.Duration()
+		ev := ReconnectEvent{Time: time.Now(), Attempt: attempt, Backoff: wait, Error: err.Error()}
+		if attempt > r.cfg.ReconnectRetries {
+			ev.Backoff, ev.Exhausted = 0, true
+			r.reconnects.record(ev)
+			r.logger.Error("retries to restart relay exhausted", zap.Int("retries", r.cfg.ReconnectRetries), log.ShortError(err))
+			return err
+		}
+		r.reconnects.record(ev)
+		r.logger.Warn("upstream disconnected, restart relay after backoff", zap.Int("attempt", attempt), zap.Duration("backoff", wait), log.ShortError(err))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// ReconnectStats returns the automatic restarts of the relay after upstream disconnected.
+func (r *Relay) ReconnectStats() ReconnectStats {
+	return r.reconnects.get()
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testReconnectSuite{})
+
+type testReconnectSuite struct{}
+
+func (t *testReconnectSuite) TestIsDisconnectError(c *C) {
+	c.Assert(isDisconnectError(driver.ErrBadConn), IsTrue)
+	c.Assert(isDisconnectError(terror.ErrDBDriverError.Delegate(&net.OpError{Op: "dial", Err: errors.New("connection refused")})), IsTrue)
+	c.Assert(isDisconnectError(errors.New("invalid binlog")), IsFalse)
+}
+
+func (t *testReconnectSuite) TestProcessWithReconnect(c *C) {
+	r := &Relay{
+		cfg:    &Config{ReconnectRetries: 2, ReconnectBackoff: time.Millisecond},
+		logger: log.L(),
+	}
+	ctx := context.Background()
+
+	// not a disconnection.
+	invalid := errors.New("invalid binlog")
+	calls := 0
+	err := r.processWithReconnect(ctx, func(context.Context) error {
+		calls++
+		return invalid
+	})
+	c.Assert(err, Equals, invalid)
+	c.Assert(calls, Equals, 1)
+	c.Assert(r.ReconnectStats().Count, Equals, int64(0))
+
+	// reconnected after one retry.
+	calls = 0
+	err = r.processWithReconnect(ctx, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	c.Assert(err, IsNil)
+	c.Assert(calls, Equals, 2)
+	stats := r.ReconnectStats()
+	c.Assert(stats.Count, Equals, int64(1))
+	c.Assert(stats.Recent, HasLen, 1)
+	c.Assert(stats.Recent[0].Attempt, Equals, 1)
+
+	// retries exhausted.
+	calls = 0
+	err = r.processWithReconnect(ctx, func(context.Context) error {
+		calls++
+		return driver.ErrBadConn
+	})
+	c.Assert(err, Equals, driver.ErrBadConn)
+	c.Assert(calls, Equals, 3)
+	stats = r.ReconnectStats()
+	c.Assert(stats.Count, Equals, int64(3))
+	c.Assert(stats.Exhausted, Equals, int64(1))
+	c.Assert(stats.Recent, HasLen, 4)
+	c.Assert(stats.Recent[2].Backoff, Equals, 2*time.Millisecond)
+	c.Assert(stats.Recent[3].Exhausted, IsTrue)
+
+	// the retries are counted again after the relay read binlog, so 4 retries are not exhausted.
+	calls = 0
+	err = r.processWithReconnect(ctx, func(context.Context) error {
+		calls++
+		if calls == 3 {
+			r.lastWrite.Set(time.Now().UnixNano())
+		}
+		if calls < 5 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	c.Assert(err, IsNil)
+	c.Assert(calls, Equals, 5)
+	stats = r.ReconnectStats()
+	c.Assert(stats.Count, Equals, int64(7))
+	c.Assert(stats.Exhausted, Equals, int64(1))
+	c.Assert(stats.Recent[len(stats.Recent)-2].Attempt, Equals, 1)
+
+	// no retry if disabled.
+	r.cfg.ReconnectRetries = 0
+	calls = 0
+	err = r.processWithReconnect(ctx, func(context.Context) error {
+		calls++
+		return driver.ErrBadConn
+	})
+	c.Assert(err, Equals, driver.ErrBadConn)
+	c.Assert(calls, Equals, 1)
+}
+
+func (t *testReconnectSuite) TestReconnectBackoffCapped(c *C) {
+	defer func(d time.Duration) {
+		maxReconnectBackoff = d
+	}(maxReconnectBackoff)
+	maxReconnectBackoff = 4 * time.Millisecond
+
+	// the backoff doesn't overflow after many retries.
+	r := &Relay{
+		cfg:    &Config{ReconnectRetries: 80, ReconnectBackoff: time.Millisecond},
+		logger: log.L(),
+	}
+	calls := 0
+	err := r.processWithReconnect(context.Background(), func(context.Context) error {
+		calls++
+		return driver.ErrBadConn
+	})
+	c.Assert(err, Equals, driver.ErrBadConn)
+	c.Assert(calls, Equals, 81)
+	stats := r.ReconnectStats()
+	c.Assert(stats.Recent, HasLen, maxReconnectEvents)
+	for _, ev := range stats.Recent[:len(stats.Recent)-1] {
+		c.Assert(ev.Backoff, Equals, 4*time.Millisecond)
+	}
+
+	// the configured backoff is used if it's larger than the max backoff.
+	r = &Relay{
+		cfg:    &Config{ReconnectRetries: 2, ReconnectBackoff: 5 * time.Millisecond},
+		logger: log.L(),
+	}
+	err = r.processWithReconnect(context.Background(), func(context.Context) error {
+		return driver.ErrBadConn
+	})
+	c.Assert(err, Equals, driver.ErrBadConn)
+	stats = r.ReconnectStats()
+	c.Assert(stats.Recent[0].Backoff, Equals, 5*time.Millisecond)
+	c.Assert(stats.Recent[1].Backoff, Equals, 5*time.Millisecond)
+}
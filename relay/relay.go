@@ -120,17 +120,33 @@ type Relay struct {
 	}
 
 	relayMetaHub *pkgstreamer.RelayMetaHub
+
+	partial *partialRelay
+	// binlogConn tracks the binlog dump connection of the reader
+	binlogConn conn.ConnActivity
+	// lastWrite is the time of the last event written in unix nano, it's reset when starting to read binlog
+	lastWrite sync2.AtomicInt64
+	// dbProvider creates the DB of upstream, conn.DefaultDBProvider is used if it's nil
+	dbProvider conn.DBProvider
+	// reconnects records the restarts after upstream disconnected
+	reconnects reconnector
 }
 
 // NewRealRelay creates an instance of Relay.
 func NewRealRelay(cfg *Config) Process {
 	return &Relay{
-		cfg:    cfg,
-		meta:   NewLocalMeta(cfg.Flavor, cfg.RelayDir),
-		logger: log.With(zap.String("component", "relay log")),
+		cfg:     cfg,
+		meta:    NewLocalMeta(cfg.Flavor, cfg.RelayDir),
+		logger:  log.With(zap.String("component", "relay log")),
+		partial: newPartialRelay(cfg.PartialRelay),
 	}
 }
 
+// SetDBProvider sets the provider to create the DB of upstream, it should be called before Init.
+func (r *Relay) SetDBProvider(dbProvider conn.DBProvider) {
+	r.dbProvider = dbProvider
+}
+
 // Init implements the dm.Unit interface.
 func (r *Relay) Init(ctx context.Context) (err error) {
 	rollbackHolder := fr.NewRollbackHolder("relay")
@@ -145,7 +161,11 @@ func (r *Relay) Init(ctx context.Context) (err error) {
 		return err
 	}
 
-	db, err := conn.DefaultDBProvider.Apply(r.cfg.From)
+	dbProvider := r.dbProvider
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	db, err := dbProvider.Apply(r.cfg.From)
 	if err != nil {
 		return terror.WithScope(err, terror.ScopeUpstream)
 	}
@@ -171,7 +191,7 @@ func (r *Relay) Init(ctx context.Context) (err error) {
 // Process implements the dm.Unit interface.
 func (r *Relay) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	errs := make([]*pb.ProcessError, 0, 1)
-	err := r.process(ctx)
+	err := r.processWithReconnect(ctx, r.process)
 	if err != nil && errors.Cause(err) != replication.ErrSyncClosed {
 		relayExitWithErrorCounter.Inc()
 		r.logger.Error("process exit", zap.Error(err))
@@ -261,6 +281,7 @@ func (r *Relay) process(ctx context.Context) error {
 	}
 	defer func() {
 		if reader2 != nil {
+			r.binlogConn.Stop()
 			err = reader2.Close()
 			if err != nil {
 				r.logger.Error("fail to close binlog event reader", zap.Error(err))
@@ -299,6 +320,7 @@ func (r *Relay) process(ctx context.Context) error {
 		}
 
 		r.logger.Warn("receive retryable error for binlog reader", log.ShortError(err))
+		r.binlogConn.Stop()
 		err = reader2.Close() // close the previous reader
 		if err != nil {
 			r.logger.Error("fail to close binlog event reader", zap.Error(err))
@@ -427,6 +449,7 @@ func (r *Relay) handleEvents(ctx context.Context, reader2 reader.Reader, transfo
 		}
 	}
 
+	r.lastWrite.Set(time.Now().UnixNano())
 	for {
 		// 1. read events from upstream server
 		readTimer := time.Now()
@@ -456,6 +479,7 @@ func (r *Relay) handleEvents(ctx context.Context, reader2 reader.Reader, transfo
 			return err
 		}
 
+		r.binlogConn.Touch()
 		binlogReadDurationHistogram.Observe(time.Since(readTimer).Seconds())
 		failpoint.Inject("BlackholeReadBinlog", func(_ failpoint.Value) {
 			//r.logger.Info("back hole read binlog takes effects")
@@ -496,6 +520,11 @@ func (r *Relay) handleEvents(ctx context.Context, reader2 reader.Reader, transfo
 			}
 		}
 
+		// discard row events of tables not needed by subtasks, the writer fills the hole with a dummy event.
+		if r.partial.skip(e, lastPos) {
+			continue
+		}
+
 		// 3. save events into file
 		writeTimer := time.Now()
 		r.logger.Debug("writing binlog event", zap.Reflect("header", e.Header))
@@ -511,6 +540,7 @@ func (r *Relay) handleEvents(ctx context.Context, reader2 reader.Reader, transfo
 			continue
 		}
 		relayLogWriteDurationHistogram.Observe(time.Since(writeTimer).Seconds())
+		r.lastWrite.Set(time.Now().UnixNano())
 		r.tryUpdateActiveRelayLog(e, lastPos.Name) // wrote a event, try update the current active relay log.
 
 		// 4. update meta and metrics
@@ -747,6 +777,7 @@ func (r *Relay) setUpReader(ctx context.Context) (reader.Reader, error) {
 		return nil, terror.Annotatef(err, "start reader for UUID %s", uuid)
 	}
 
+	r.binlogConn.Start()
 	r.logger.Info("started underlying reader", zap.String("UUID", uuid))
 	return reader2, nil
 }
@@ -755,8 +786,9 @@ func (r *Relay) setUpReader(ctx context.Context) (reader.Reader, error) {
 func (r *Relay) setUpWriter(parser2 *parser.Parser) (writer.Writer, error) {
 	uuid, pos := r.meta.Pos()
 	cfg := &writer.FileConfig{
-		RelayDir: r.meta.Dir(),
-		Filename: pos.Name,
+		RelayDir:   r.meta.Dir(),
+		Filename:   pos.Name,
+		SparseHole: r.cfg.PartialRelay,
 	}
 	writer2 := writer.NewFileWriter(r.logger, cfg, parser2)
 	err := writer2.Start()
@@ -804,6 +836,15 @@ func (r *Relay) stopSync() {
 	}
 }
 
+// ActiveConnections returns the connections held by the relay to upstream.
+func (r *Relay) ActiveConnections() []conn.ConnInfo {
+	r.RLock()
+	defer r.RUnlock()
+	from := conn.ConnAddr(r.cfg.From)
+	infos := r.binlogConn.ConnInfos(conn.ConnPurposeBinlogRead, conn.ConnSideUpstream, from)
+	return append(infos, conn.PoolConnInfos(r.db, conn.ConnPurposeQuery, conn.ConnSideUpstream, from)...)
+}
+
 func (r *Relay) closeDB() {
 	if r.db != nil {
 		r.db.Close()
@@ -909,6 +950,20 @@ func (r *Relay) Reload(newCfg *Config) error {
 	// Update Charset
 	r.cfg.Charset = newCfg.Charset
 
+	// Update PartialRelay, holes are written as sparse holes after the writer restarted
+	r.cfg.PartialRelay = newCfg.PartialRelay
+	r.partial.setEnabled(newCfg.PartialRelay)
+
+	// Update RelayDir, the relay log files should have been moved to the new directory
+	if len(newCfg.RelayDir) > 0 && newCfg.RelayDir != r.cfg.RelayDir {
+		meta := NewLocalMeta(r.cfg.Flavor, newCfg.RelayDir)
+		if err := meta.Load(); err != nil {
+			return err
+		}
+		r.cfg.RelayDir = newCfg.RelayDir
+		r.meta = meta
+	}
+
 	r.closeDB()
 	cfg := r.cfg.From
 	dbDSN := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8mb4&interpolateParams=true&readTimeout=%s", cfg.User, cfg.Password, cfg.Host, cfg.Port, showStatusConnectionTimeout)
@@ -949,6 +1004,11 @@ func (r *Relay) ActiveRelayLog() *pkgstreamer.RelayLogInfo {
 	return r.activeRelayLog.info
 }
 
+// WritePos returns the relay sub directory and the position in it the relay log has been written to.
+func (r *Relay) WritePos() (string, mysql.Position) {
+	return r.meta.Pos()
+}
+
 func (r *Relay) setSyncConfig() error {
 	var tlsConfig *tls.Config
 	var err error
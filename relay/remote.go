@@ -0,0 +1,539 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+	"github.com/siddontang/go/ioutil2"
+	"github.com/siddontang/go/sync2"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/log"
+	pkgstreamer "github.com/pingcap/dm/pkg/streamer"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+const (
+	// RemoteRelayFilesPath is the HTTP path under which DM-worker serves its relay log files to other DM-workers.
+	// a GET of it lists the files, and a GET of `RemoteRelayFilesPath/<path>` reads a file with `Range` supported.
+	RemoteRelayFilesPath = "/relay/files"
+
+	remoteRelayFetchInterval  = time.Second
+	remoteRelayRequestTimeout = time.Minute
+)
+
+// RemoteRelayFile represents a relay log file served to other DM-workers.
+type RemoteRelayFile struct {
+	Path string `json:"path"` // relative to the relay directory, separated by `/`
+	Size int64  `json:"size"`
+}
+
+// ListRemoteRelayFiles lists the UUID index file, relay meta files and binlog files in the relay directory.
+func ListRemoteRelayFiles(relayDir string) ([]RemoteRelayFile, error) {
+	files := make([]RemoteRelayFile, 0)
+	addFile := func(relPath string) error {
+		fi, err := os.Stat(filepath.Join(relayDir, filepath.FromSlash(relPath)))
+		if os.IsNotExist(err) {
+			return nil // purged just now
+		} else if err != nil {
+			return terror.ErrGetRelayLogStat.Delegate(err, relPath)
+		}
+		files = append(files, RemoteRelayFile{Path: relPath, Size: fi.Size()})
+		return nil
+	}
+
+	uuids, err := utils.ParseUUIDIndex(filepath.Join(relayDir, utils.UUIDIndexFilename))
+	if err != nil {
+		return nil, err
+	}
+	if len(uuids) == 0 {
+		return files, nil
+	}
+	if err = addFile(utils.UUIDIndexFilename); err != nil {
+		return nil, err
+	}
+	for _, uuid := range uuids {
+		dir := filepath.Join(relayDir, uuid)
+		if !utils.IsDirExists(dir) {
+			continue
+		}
+		if err = addFile(path.Join(uuid, utils.MetaFilename)); err != nil {
+			return nil, err
+		}
+		names, err := pkgstreamer.CollectAllBinlogFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if err = addFile(path.Join(uuid, name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// remoteRelay fetches relay log from the DM-worker which pulls relay log of the same source, rather than pulling
+// binlog from upstream again. the fetched files are cached in the local relay directory, and later only the appended
+// data is fetched, so subtasks read relay log from the local relay directory as usual.
+type remoteRelay struct {
+	cfg    *Config
+	client *http.Client
+
+	meta   Meta
+	closed sync2.AtomicBool
+	sync.RWMutex
+
+	logger log.Logger
+
+	activeRelayLog struct {
+		sync.RWMutex
+		info *pkgstreamer.RelayLogInfo
+	}
+
+	relayMetaHub *pkgstreamer.RelayMetaHub
+}
+
+// NewRemoteRelay creates a relay log process unit which fetches relay log from `cfg.RelaySource`.
+func NewRemoteRelay(cfg *Config) Process {
+	return &remoteRelay{
+		cfg:    cfg,
+		meta:   NewLocalMeta(cfg.Flavor, cfg.RelayDir),
+		logger: log.With(zap.String("component", "remote relay log"), zap.String("relay source", cfg.RelaySource)),
+	}
+}
+
+// Init implements Process.Init.
+func (r *remoteRelay) Init(ctx context.Context) error {
+	// the relay source authenticates the DM-worker by the client certificate if TLS is enabled.
+	sec := r.cfg.RelaySourceSecurity
+	tlsCfg, err := toolutils.ToTLSConfig(sec.SSLCA, sec.SSLCert, sec.SSLKey)
+	if err != nil {
+		return terror.ErrWorkerTLSConfigNotValid.Delegate(err)
+	}
+	r.client = toolutils.ClientWithTLS(tlsCfg)
+	r.client.Timeout = remoteRelayRequestTimeout
+
+	if err := os.MkdirAll(r.cfg.RelayDir, 0755); err != nil {
+		return terror.ErrRelayMkdir.Delegate(err)
+	}
+	if err := r.meta.Load(); err != nil {
+		return err
+	}
+
+	r.relayMetaHub = pkgstreamer.GetRelayMetaHub()
+	r.relayMetaHub.ClearMeta()
+
+	return reportRelayLogSpaceInBackground(r.cfg.RelayDir)
+}
+
+// Process implements Process.Process.
+func (r *remoteRelay) Process(ctx context.Context, pr chan pb.ProcessResult) {
+	errs := make([]*pb.ProcessError, 0, 1)
+	if err := r.process(ctx); err != nil {
+		relayExitWithErrorCounter.Inc()
+		r.logger.Error("process exit", zap.Error(err))
+		errs = append(errs, unit.NewProcessError(err))
+	}
+
+	isCanceled := false
+	if len(errs) == 0 {
+		select {
+		case <-ctx.Done():
+			isCanceled = true
+		default:
+		}
+	}
+	pr <- pb.ProcessResult{
+		IsCanceled: isCanceled,
+		Errors:     errs,
+	}
+}
+
+func (r *remoteRelay) process(ctx context.Context) error {
+	ticker := time.NewTicker(remoteRelayFetchInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.fetch(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetch fetches the relay log files appended in the relay source since the last fetch.
+func (r *remoteRelay) fetch(ctx context.Context) error {
+	r.RLock()
+	relayDir := r.cfg.RelayDir
+	r.RUnlock()
+
+	// read the UUID index and relay meta files before listing binlog files, so the local meta never goes beyond
+	// the binlog data fetched.
+	index, found, err := r.readFile(ctx, utils.UUIDIndexFilename)
+	if err != nil || !found {
+		return err // relay source has not pulled any relay log yet if not found
+	}
+	uuids := make([]string, 0, 5)
+	for _, line := range strings.Split(string(index), "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			uuids = append(uuids, line)
+		}
+	}
+	metas := make(map[string][]byte, len(uuids))
+	for _, uuid := range uuids {
+		data, found, err2 := r.readFile(ctx, path.Join(uuid, utils.MetaFilename))
+		if err2 != nil {
+			return err2
+		}
+		if found {
+			metas[uuid] = data
+		}
+	}
+
+	files, err := r.listFiles(ctx)
+	if err != nil {
+		return err
+	}
+	latest := r.ActiveRelayLog()
+	for _, file := range files {
+		uuid, filename := path.Split(file.Path)
+		uuid = strings.TrimSuffix(uuid, "/")
+		if len(uuid) == 0 || !binlog.VerifyFilename(filename) {
+			continue // index or meta file
+		}
+		_, suffix, err2 := utils.ParseSuffixForUUID(uuid)
+		if err2 != nil {
+			return err2
+		}
+		info := &pkgstreamer.RelayLogInfo{TaskName: fakeTaskName, UUID: uuid, UUIDSuffix: suffix, Filename: filename}
+		// files earlier than the latest fetched one which don't exist locally have been purged, do not fetch them again.
+		fetched, err2 := r.fetchBinlogFile(ctx, relayDir, file, latest != nil && info.Earlier(latest))
+		if err2 != nil {
+			return err2
+		}
+		if fetched && (latest == nil || latest.Earlier(info)) {
+			latest = info
+		}
+	}
+
+	for uuid, data := range metas {
+		if err = os.MkdirAll(filepath.Join(relayDir, uuid), 0755); err != nil {
+			return terror.ErrRelayMkdir.Delegate(err)
+		}
+		if err = ioutil2.WriteFileAtomic(filepath.Join(relayDir, uuid, utils.MetaFilename), data, 0644); err != nil {
+			return terror.ErrRelayLoadMetaData.Delegate(err)
+		}
+	}
+	if err = ioutil2.WriteFileAtomic(filepath.Join(relayDir, utils.UUIDIndexFilename), index, 0644); err != nil {
+		return terror.ErrRelayLoadMetaData.Delegate(err)
+	}
+
+	meta := NewLocalMeta(r.cfg.Flavor, relayDir)
+	if err = meta.Load(); err != nil {
+		return err
+	}
+	r.Lock()
+	r.meta = meta
+	r.Unlock()
+	uuid, pos := meta.Pos()
+	_, gs := meta.GTID()
+	r.relayMetaHub.SetMeta(uuid, pos, gs)
+
+	if latest != nil {
+		r.activeRelayLog.Lock()
+		r.activeRelayLog.info = latest
+		r.activeRelayLog.Unlock()
+	}
+	return nil
+}
+
+// fetchBinlogFile fetches the data of the binlog file appended in the relay source, returns whether the file exists locally.
+func (r *remoteRelay) fetchBinlogFile(ctx context.Context, relayDir string, file RemoteRelayFile, skipIfNotExist bool) (bool, error) {
+	localPath := filepath.Join(relayDir, filepath.FromSlash(file.Path))
+	var offset int64
+	fi, err := os.Stat(localPath)
+	switch {
+	case err == nil:
+		offset = fi.Size()
+	case os.IsNotExist(err):
+		if skipIfNotExist {
+			return false, nil
+		}
+		if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return false, terror.ErrRelayMkdir.Delegate(err)
+		}
+	default:
+		return false, terror.ErrRelayWriterGetFileStat.Delegate(err, localPath)
+	}
+
+	if offset > file.Size {
+		// the relay source truncated the incomplete tail of the file when recovering it, do the same.
+		r.logger.Warn("relay log file is truncated in relay source", zap.String("file", file.Path), zap.Int64("local size", offset), zap.Int64("remote size", file.Size))
+		if err = os.Truncate(localPath, file.Size); err != nil {
+			return false, terror.ErrRelayWriterFileOperate.Delegate(err)
+		}
+		return true, nil
+	}
+	if offset == file.Size {
+		return true, nil
+	}
+
+	resp, err := r.request(ctx, RemoteRelayFilesPath+"/"+file.Path, fmt.Sprintf("bytes=%d-%d", offset, file.Size-1))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil // purged in relay source just now
+	}
+	if resp.StatusCode != http.StatusPartialContent && !(resp.StatusCode == http.StatusOK && offset == 0) {
+		return false, terror.ErrRelayRemoteRequest.Generate(fmt.Sprintf("%s, status %s", file.Path, resp.Status))
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		var start, end, total int64
+		contentRange := resp.Header.Get("Content-Range")
+		if _, err = fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total); err != nil || start != offset {
+			return false, terror.ErrRelayRemoteFileCorrupted.Generate(file.Path, fmt.Sprintf("expect data from offset %d, but got range %q", offset, contentRange))
+		}
+	}
+
+	f, err := os.OpenFile(localPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return false, terror.ErrRelayWriterFileOperate.Delegate(err)
+	}
+	defer f.Close()
+
+	body := io.Reader(resp.Body)
+	if offset == 0 {
+		header := make([]byte, len(replication.BinLogFileHeader))
+		if _, err = io.ReadFull(body, header); err != nil || !bytes.Equal(header, replication.BinLogFileHeader) {
+			return false, terror.ErrRelayRemoteFileCorrupted.Generate(file.Path, "invalid binlog file header")
+		}
+		body = io.MultiReader(bytes.NewReader(header), body)
+	}
+	// data in the relay source is only appended, so the data written is always a valid prefix of the file even if
+	// the copy is interrupted, and it continues from there in the next fetch.
+	n, err := io.CopyN(f, body, file.Size-offset)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, terror.ErrRelayRemoteFileCorrupted.Generate(file.Path, fmt.Sprintf("expect %d bytes, but got %d bytes, %v", file.Size-offset, n, err))
+	}
+
+	// verify the whole local file rather than the data fetched this time, so a file modified locally is found too.
+	if fi, err = f.Stat(); err != nil {
+		return false, terror.ErrRelayWriterGetFileStat.Delegate(err, localPath)
+	}
+	if fi.Size() != file.Size {
+		return false, terror.ErrRelayRemoteFileCorrupted.Generate(file.Path, fmt.Sprintf("expect size %d, but got %d", file.Size, fi.Size()))
+	}
+	return true, nil
+}
+
+func (r *remoteRelay) listFiles(ctx context.Context) ([]RemoteRelayFile, error) {
+	data, _, err := r.readFile(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	files := make([]RemoteRelayFile, 0)
+	if err = json.Unmarshal(data, &files); err != nil {
+		return nil, terror.ErrRelayRemoteRequest.Delegate(err, RemoteRelayFilesPath)
+	}
+	return files, nil
+}
+
+// readFile reads the whole file in the relay source, or lists the files if relPath is empty.
+func (r *remoteRelay) readFile(ctx context.Context, relPath string) ([]byte, bool, error) {
+	p := RemoteRelayFilesPath
+	if len(relPath) > 0 {
+		p += "/" + relPath
+	}
+	resp, err := r.request(ctx, p, "")
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, terror.ErrRelayRemoteRequest.Generate(fmt.Sprintf("%s, status %s", p, resp.Status))
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, terror.ErrRelayRemoteRequest.Delegate(err, p)
+	}
+	return data, true, nil
+}
+
+func (r *remoteRelay) request(ctx context.Context, p string, byteRange string) (*http.Response, error) {
+	r.RLock()
+	url := strings.TrimSuffix(r.cfg.RelaySource, "/") + p
+	r.RUnlock()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, terror.ErrRelayRemoteRequest.Delegate(err, url)
+	}
+	if len(byteRange) > 0 {
+		req.Header.Set("Range", byteRange)
+	}
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, terror.ErrRelayRemoteRequest.Delegate(err, url)
+	}
+	return resp, nil
+}
+
+// ActiveRelayLog implements Process.ActiveRelayLog.
+func (r *remoteRelay) ActiveRelayLog() *pkgstreamer.RelayLogInfo {
+	r.activeRelayLog.RLock()
+	defer r.activeRelayLog.RUnlock()
+	return r.activeRelayLog.info
+}
+
+// Reload implements Process.Reload.
+func (r *remoteRelay) Reload(newCfg *Config) error {
+	r.Lock()
+	defer r.Unlock()
+	r.cfg.RelaySource = newCfg.RelaySource
+
+	// Update RelayDir, the relay log files should have been moved to the new directory
+	if len(newCfg.RelayDir) > 0 && newCfg.RelayDir != r.cfg.RelayDir {
+		meta := NewLocalMeta(r.cfg.Flavor, newCfg.RelayDir)
+		if err := meta.Load(); err != nil {
+			return err
+		}
+		r.cfg.RelayDir = newCfg.RelayDir
+		r.meta = meta
+	}
+	r.logger.Info("remote relay unit is updated")
+	return nil
+}
+
+// Update implements Process.Update.
+func (r *remoteRelay) Update(cfg *config.SubTaskConfig) error {
+	return nil
+}
+
+// Resume implements Process.Resume.
+func (r *remoteRelay) Resume(ctx context.Context, pr chan pb.ProcessResult) {
+	// do nothing now, re-process called `Process` from outer directly
+}
+
+// Pause implements Process.Pause.
+func (r *remoteRelay) Pause() {
+	// do nothing, the fetched data is persisted already
+}
+
+// Error implements Process.Error.
+func (r *remoteRelay) Error() interface{} {
+	return &pb.RelayError{}
+}
+
+// Status implements Process.Status.
+func (r *remoteRelay) Status(ctx context.Context) interface{} {
+	r.RLock()
+	defer r.RUnlock()
+	uuid, relayPos := r.meta.Pos()
+	_, relayGTIDSet := r.meta.GTID()
+	rs := &pb.RelayStatus{
+		RelaySubDir: uuid,
+		RelayBinlog: relayPos.String(),
+		RelaySource: r.cfg.RelaySource,
+	}
+	if relayGTIDSet != nil {
+		rs.RelayBinlogGtid = relayGTIDSet.String()
+	}
+	return rs
+}
+
+// Close implements Process.Close.
+func (r *remoteRelay) Close() {
+	r.closed.Set(true)
+	r.logger.Info("remote relay unit closed")
+}
+
+// IsClosed implements Process.IsClosed.
+func (r *remoteRelay) IsClosed() bool {
+	return r.closed.Get()
+}
+
+// SaveMeta implements Process.SaveMeta.
+func (r *remoteRelay) SaveMeta(pos mysql.Position, gset gtid.Set) error {
+	r.RLock()
+	defer r.RUnlock()
+	if err := r.meta.Save(pos, gset); err != nil {
+		return err
+	}
+	r.relayMetaHub.SetMeta(r.meta.UUID(), pos, gset)
+	return nil
+}
+
+// ResetMeta implements Process.ResetMeta.
+func (r *remoteRelay) ResetMeta() {
+	r.Lock()
+	defer r.Unlock()
+	r.meta = NewLocalMeta(r.cfg.Flavor, r.cfg.RelayDir)
+	r.relayMetaHub.ClearMeta()
+}
+
+// PurgeRelayDir implements Process.PurgeRelayDir.
+func (r *remoteRelay) PurgeRelayDir() error {
+	r.RLock()
+	dir := r.cfg.RelayDir
+	r.RUnlock()
+	names, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, fi := range names {
+		if err = os.RemoveAll(filepath.Join(dir, fi.Name())); err != nil {
+			return err
+		}
+	}
+	r.logger.Info("relay dir is purged", zap.String("relayDir", dir))
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var _ = Suite(&testRemoteRelaySuite{})
+
+type testRemoteRelaySuite struct{}
+
+// newRelayFilesServer serves relay log files in relayDir like DM-worker does.
+func newRelayFilesServer(c *C, relayDir string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		relPath := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, RemoteRelayFilesPath), "/")
+		if len(relPath) == 0 {
+			files, err := ListRemoteRelayFiles(relayDir)
+			c.Assert(err, IsNil)
+			c.Assert(json.NewEncoder(w).Encode(files), IsNil)
+			return
+		}
+		http.ServeFile(w, req, filepath.Join(relayDir, relPath))
+	}))
+}
+
+func appendFile(c *C, name string, data []byte) {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	c.Assert(err, IsNil)
+	defer f.Close()
+	_, err = f.Write(data)
+	c.Assert(err, IsNil)
+}
+
+func (t *testRemoteRelaySuite) TestRemoteRelay(c *C) {
+	var (
+		srcDir   = c.MkDir()
+		localDir = c.MkDir()
+		uuid     = "server-a-uuid.000001"
+		filename = "mysql-bin.000001"
+		ctx      = context.Background()
+	)
+
+	srv := newRelayFilesServer(c, srcDir)
+	defer srv.Close()
+	r := NewRemoteRelay(&Config{Flavor: mysql.MySQLFlavor, RelayDir: localDir, RelaySource: srv.URL}).(*remoteRelay)
+	c.Assert(r.Init(ctx), IsNil)
+
+	// nothing in relay source yet.
+	c.Assert(r.fetch(ctx), IsNil)
+	c.Assert(r.ActiveRelayLog(), IsNil)
+	files, err := ListRemoteRelayFiles(localDir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+
+	gs, err := gtid.ParserGTID(mysql.MySQLFlavor, "")
+	c.Assert(err, IsNil)
+	srcMeta := NewLocalMeta(mysql.MySQLFlavor, srcDir)
+	c.Assert(srcMeta.Load(), IsNil)
+	c.Assert(srcMeta.AddDir("server-a-uuid", &mysql.Position{Name: filename, Pos: 4}, gs, 0), IsNil)
+	c.Assert(srcMeta.Flush(), IsNil)
+	binlogPath := filepath.Join(srcDir, uuid, filename)
+	appendFile(c, binlogPath, replication.BinLogFileHeader)
+	appendFile(c, binlogPath, []byte("data"))
+
+	// initial fetch, all files are mirrored.
+	c.Assert(r.fetch(ctx), IsNil)
+	srcFiles, err := ListRemoteRelayFiles(srcDir)
+	c.Assert(err, IsNil)
+	c.Assert(srcFiles, HasLen, 3)
+	files, err = ListRemoteRelayFiles(localDir)
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, srcFiles)
+	c.Assert(r.ActiveRelayLog().String(), Equals, filepath.Join(uuid, filename))
+	c.Assert(r.meta.UUID(), Equals, uuid)
+	c.Assert(r.Status(ctx).(*pb.RelayStatus).RelaySource, Equals, srv.URL)
+
+	// only the appended data is fetched.
+	appendFile(c, binlogPath, []byte("more data"))
+	c.Assert(srcMeta.Save(mysql.Position{Name: filename, Pos: 17}, gs), IsNil)
+	c.Assert(srcMeta.Flush(), IsNil)
+	c.Assert(r.fetch(ctx), IsNil)
+	data, err := ioutil.ReadFile(filepath.Join(localDir, uuid, filename))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, string(replication.BinLogFileHeader)+"datamore data")
+	_, pos := r.meta.Pos()
+	c.Assert(pos.Pos, Equals, uint32(17))
+
+	// truncated in relay source.
+	c.Assert(os.Truncate(binlogPath, int64(len(replication.BinLogFileHeader))+4), IsNil)
+	c.Assert(r.fetch(ctx), IsNil)
+	data, err = ioutil.ReadFile(filepath.Join(localDir, uuid, filename))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, string(replication.BinLogFileHeader)+"data")
+
+	// purged locally, not fetched again.
+	filename2 := "mysql-bin.000002"
+	appendFile(c, filepath.Join(srcDir, uuid, filename2), replication.BinLogFileHeader)
+	c.Assert(r.fetch(ctx), IsNil)
+	c.Assert(r.ActiveRelayLog().Filename, Equals, filename2)
+	c.Assert(os.Remove(filepath.Join(localDir, uuid, filename)), IsNil)
+	c.Assert(r.fetch(ctx), IsNil)
+	c.Assert(utils.IsFileExists(filepath.Join(localDir, uuid, filename)), IsFalse)
+
+	// corrupted file header.
+	appendFile(c, filepath.Join(srcDir, uuid, "mysql-bin.000003"), []byte("invalid header"))
+	err = r.fetch(ctx)
+	c.Assert(err, ErrorMatches, ".*invalid binlog file header.*")
+
+	// relay source unreachable.
+	srv.Close()
+	c.Assert(r.fetch(ctx), ErrorMatches, ".*request relay source.*")
+}
+
+func (t *testRemoteRelaySuite) TestRemoteRelayVerifyFile(c *C) {
+	var (
+		localDir = c.MkDir()
+		uuid     = "server-a-uuid.000001"
+		filename = "mysql-bin.000001"
+		ctx      = context.Background()
+		file     = RemoteRelayFile{Path: uuid + "/" + filename, Size: int64(len(replication.BinLogFileHeader)) + 4}
+	)
+	// the relay source returns data not starting from the requested offset.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-3/%d", file.Size))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer srv.Close()
+	r := NewRemoteRelay(&Config{Flavor: mysql.MySQLFlavor, RelayDir: localDir, RelaySource: srv.URL}).(*remoteRelay)
+	c.Assert(r.Init(ctx), IsNil)
+
+	localPath := filepath.Join(localDir, uuid, filename)
+	c.Assert(os.MkdirAll(filepath.Dir(localPath), 0755), IsNil)
+	appendFile(c, localPath, replication.BinLogFileHeader)
+	_, err := r.fetchBinlogFile(ctx, localDir, file, false)
+	c.Assert(err, ErrorMatches, ".*expect data from offset 4, but got range.*")
+
+	// invalid TLS config.
+	r = NewRemoteRelay(&Config{RelayDir: localDir, RelaySource: srv.URL, RelaySourceSecurity: config.Security{SSLCA: "not-exist-ca.pem"}}).(*remoteRelay)
+	c.Assert(terror.ErrWorkerTLSConfigNotValid.Equal(r.Init(ctx)), IsTrue)
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// writeStallThreshold is how long the relay hasn't written any event while upstream has new binlog to be regarded
+// as stalled.
+var writeStallThreshold = 30 * time.Second
+
+// StallReport represents whether the relay stops writing while upstream has new binlog.
+type StallReport struct {
+	Stalled bool `json:"stalled"`
+	// Behind means upstream has binlog not relayed yet.
+	Behind           bool   `json:"behind"`
+	MasterBinlog     string `json:"master-binlog"`
+	MasterBinlogGtid string `json:"master-binlog-gtid"`
+	RelayBinlog      string `json:"relay-binlog"`
+	RelayBinlogGtid  string `json:"relay-binlog-gtid"`
+	// LastWriteTime is the time of the last written event, or the time the relay started to read binlog if none.
+	LastWriteTime  time.Time     `json:"last-write-time"`
+	SinceLastWrite time.Duration `json:"since-last-write"`
+}
+
+// LastWriteTime returns the time of the last event written into relay log, or the time the relay started to read
+// binlog if none written, it's zero if the relay never started.
+func (r *Relay) LastWriteTime() time.Time {
+	if t := r.lastWrite.Get(); t != 0 {
+		return time.Unix(0, t)
+	}
+	return time.Time{}
+}
+
+// DetectStall compares the relay log with the binlog of upstream, and reports a stall if upstream has new binlog but
+// the relay hasn't written any event for a while.
+func (r *Relay) DetectStall(ctx context.Context) (*StallReport, error) {
+	r.RLock()
+	db := r.db
+	r.RUnlock()
+	if db == nil {
+		return nil, terror.ErrDBUnExpect.Generate("database connection of relay not valid")
+	}
+	masterPos, masterGTID, err := utils.GetMasterStatus(ctx, db, r.cfg.Flavor)
+	if err != nil {
+		return nil, err
+	}
+	_, relayPos := r.meta.Pos()
+	_, relayGTID := r.meta.GTID()
+	return newStallReport(r.cfg.EnableGTID, masterPos, masterGTID, relayPos, relayGTID, r.LastWriteTime()), nil
+}
+
+func newStallReport(enableGTID bool, masterPos mysql.Position, masterGTID gtid.Set, relayPos mysql.Position, relayGTID gtid.Set, lastWrite time.Time) *StallReport {
+	report := &StallReport{
+		MasterBinlog:  masterPos.String(),
+		RelayBinlog:   relayPos.String(),
+		LastWriteTime: lastWrite,
+	}
+	if masterGTID != nil { // masterGTID maybe a nil interface
+		report.MasterBinlogGtid = masterGTID.String()
+	}
+	if relayGTID != nil {
+		report.RelayBinlogGtid = relayGTID.String()
+	}
+	if enableGTID {
+		report.Behind = masterGTID != nil && (relayGTID == nil || !relayGTID.Contain(masterGTID))
+	} else {
+		report.Behind = masterPos.Compare(relayPos) > 0
+	}
+	if !lastWrite.IsZero() {
+		report.SinceLastWrite = time.Since(lastWrite)
+		report.Stalled = report.Behind && report.SinceLastWrite >= writeStallThreshold
+	}
+	return report
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
+)
+
+var _ = Suite(&testStallSuite{})
+
+type testStallSuite struct{}
+
+func (t *testStallSuite) TestLastWriteTime(c *C) {
+	r := &Relay{}
+	c.Assert(r.LastWriteTime().IsZero(), IsTrue)
+	now := time.Now()
+	r.lastWrite.Set(now.UnixNano())
+	c.Assert(r.LastWriteTime().Equal(now), IsTrue)
+}
+
+func (t *testStallSuite) TestNewStallReport(c *C) {
+	var (
+		relayPos  = mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+		masterPos = mysql.Position{Name: "mysql-bin.000001", Pos: 2345}
+		longAgo   = time.Now().Add(-2 * writeStallThreshold)
+		recently  = time.Now()
+	)
+
+	// position mode.
+	report := newStallReport(false, masterPos, nil, relayPos, nil, longAgo)
+	c.Assert(report.Behind, IsTrue)
+	c.Assert(report.Stalled, IsTrue)
+	c.Assert(report.MasterBinlog, Equals, masterPos.String())
+	c.Assert(report.RelayBinlog, Equals, relayPos.String())
+	c.Assert(report.SinceLastWrite >= 2*writeStallThreshold, IsTrue)
+
+	// wrote recently.
+	report = newStallReport(false, masterPos, nil, relayPos, nil, recently)
+	c.Assert(report.Behind, IsTrue)
+	c.Assert(report.Stalled, IsFalse)
+
+	// caught up with upstream.
+	report = newStallReport(false, relayPos, nil, relayPos, nil, longAgo)
+	c.Assert(report.Behind, IsFalse)
+	c.Assert(report.Stalled, IsFalse)
+
+	// never started.
+	report = newStallReport(false, masterPos, nil, relayPos, nil, time.Time{})
+	c.Assert(report.Behind, IsTrue)
+	c.Assert(report.Stalled, IsFalse)
+	c.Assert(report.SinceLastWrite, Equals, time.Duration(0))
+
+	// GTID mode, the position is not used.
+	relayGTID, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	c.Assert(err, IsNil)
+	masterGTID, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-15")
+	c.Assert(err, IsNil)
+	report = newStallReport(true, relayPos, masterGTID, relayPos, relayGTID, longAgo)
+	c.Assert(report.Behind, IsTrue)
+	c.Assert(report.Stalled, IsTrue)
+	c.Assert(report.MasterBinlogGtid, Equals, masterGTID.String())
+	c.Assert(report.RelayBinlogGtid, Equals, relayGTID.String())
+
+	report = newStallReport(true, masterPos, masterGTID, relayPos, masterGTID.Clone(), longAgo)
+	c.Assert(report.Behind, IsFalse)
+	c.Assert(report.Stalled, IsFalse)
+}
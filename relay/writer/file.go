@@ -38,6 +38,8 @@ import (
 type FileConfig struct {
 	RelayDir string // directory to store relay log files.
 	Filename string // the startup relay log filename, if not set then a fake RotateEvent must be the first event.
+	// whether to write the dummy events filling holes as sparse holes, holes are common when partial relay is enabled.
+	SparseHole bool
 }
 
 // FileWriter implements Writer interface.
@@ -360,7 +362,11 @@ func (w *FileWriter) handleFileHoleExist(ev *replication.BinlogEvent) (bool, err
 	}
 
 	// 3. write the dummy event
-	err = w.out.Write(dummyEv.RawData)
+	if w.cfg.SparseHole {
+		err = w.out.WriteSparse(dummyEv.RawData)
+	} else {
+		err = w.out.Write(dummyEv.RawData)
+	}
 	return false, terror.Annotatef(err, "write dummy event %+v to fill the hole", dummyEv.Header)
 }
 
@@ -0,0 +1,76 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+// ActiveConnections returns the connections held by the syncer to upstream and downstream.
+func (s *Syncer) ActiveConnections() []conn.ConnInfo {
+	from, to := conn.ConnAddr(s.cfg.From), conn.ConnAddr(s.cfg.To)
+	var infos []conn.ConnInfo
+	if s.streamerController != nil {
+		infos = append(infos, s.streamerController.BinlogConnInfos(from)...)
+	}
+	if s.fromDB != nil {
+		infos = append(infos, s.fromDB.BaseDB.ConnInfos(conn.ConnPurposeQuery, conn.ConnSideUpstream, from)...)
+	}
+	infos = append(infos, s.toDB.ConnInfos(conn.ConnPurposeSyncWrite, conn.ConnSideDownstream, to)...)
+	infos = append(infos, s.ddlDB.ConnInfos(conn.ConnPurposeSyncWrite, conn.ConnSideDownstream, to)...)
+	if cp, ok := s.checkpoint.(*RemoteCheckPoint); ok {
+		infos = append(infos, cp.db.ConnInfos(conn.ConnPurposeCheckpoint, conn.ConnSideDownstream, to)...)
+	}
+	var storage *OnlineDDLStorage
+	switch p := s.onlineDDL.(type) {
+	case *PT:
+		storage = p.storge
+	case *Ghost:
+		storage = p.storge
+	}
+	if storage != nil {
+		infos = append(infos, storage.db.ConnInfos(conn.ConnPurposeMeta, conn.ConnSideDownstream, to)...)
+	}
+	if s.sgk != nil {
+		infos = append(infos, s.sgk.db.ConnInfos(conn.ConnPurposeMeta, conn.ConnSideDownstream, to)...)
+	}
+	return infos
+}
+
+// PoolStats returns the stats of the connection pools of the syncer to upstream and downstream, the downstream one is
+// the sum of the pools writing binlog events, checkpoints and metadata.
+func (s *Syncer) PoolStats() (upstream, downstream conn.PoolStats) {
+	if s.fromDB != nil {
+		upstream = s.fromDB.BaseDB.PoolStats()
+	}
+	downstream = s.toDB.PoolStats()
+	downstream.Add(s.ddlDB.PoolStats())
+	if cp, ok := s.checkpoint.(*RemoteCheckPoint); ok {
+		downstream.Add(cp.db.PoolStats())
+	}
+	var storage *OnlineDDLStorage
+	switch p := s.onlineDDL.(type) {
+	case *PT:
+		storage = p.storge
+	case *Ghost:
+		storage = p.storge
+	}
+	if storage != nil {
+		downstream.Add(storage.db.PoolStats())
+	}
+	if s.sgk != nil {
+		downstream.Add(s.sgk.db.PoolStats())
+	}
+	return upstream, downstream
+}
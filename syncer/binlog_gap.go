@@ -0,0 +1,96 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// BinlogGapReport reports whether the binlog needed to resume the syncer is still available in upstream.
+type BinlogGapReport struct {
+	// NeededBinlog is the binlog position the syncer resumes from, i.e. the flushed global checkpoint.
+	NeededBinlog string `json:"needed-binlog"`
+	// EarliestBinlog and LatestBinlog are the binlog files available in upstream.
+	EarliestBinlog string `json:"earliest-binlog"`
+	LatestBinlog   string `json:"latest-binlog"`
+	Available      bool   `json:"available"`
+	// MissingFiles is the number of binlog files needed but purged in upstream, it's 0 if the needed binlog is
+	// available or newer than the latest binlog.
+	MissingFiles int64 `json:"missing-files"`
+}
+
+// BinlogGap compares the binlog position the syncer resumes from with the binlog files available in upstream
+// (SHOW BINARY LOGS), to detect binlog purged in upstream but still needed before resuming fails.
+// NOTE: position is compared even if GTID is enabled, and for syncers reading relay log, the result only matters if
+// the needed binlog isn't pulled by relay yet.
+func (s *Syncer) BinlogGap(ctx context.Context) (*BinlogGapReport, error) {
+	if s.fromDB == nil {
+		return nil, terror.ErrSyncerCheckBinlogGap.Generate("the syncer is not initialized")
+	}
+	needed, err := binlog.RealMySQLPos(s.checkpoint.FlushedGlobalPoint().Position)
+	if err != nil {
+		return nil, err
+	}
+	if len(needed.Name) == 0 {
+		return nil, terror.ErrSyncerCheckBinlogGap.Generate("the syncer has no checkpoint yet")
+	}
+	files, err := getBinaryLogs(ctx, s.fromDB.BaseDB.DB)
+	if err != nil {
+		return nil, err
+	}
+	return binlogGap(needed, files)
+}
+
+func binlogGap(needed mysql.Position, files []binlogSize) (*BinlogGapReport, error) {
+	if len(files) == 0 {
+		return nil, terror.ErrSyncerCheckBinlogGap.Generate("no binlog files in upstream")
+	}
+	report := &BinlogGapReport{
+		NeededBinlog:   needed.String(),
+		EarliestBinlog: files[0].name,
+		LatestBinlog:   files[len(files)-1].name,
+	}
+
+	neededFile, err := binlog.ParseFilename(needed.Name)
+	if err != nil {
+		return nil, err
+	}
+	earliestFile, err := binlog.ParseFilename(report.EarliestBinlog)
+	if err != nil {
+		return nil, err
+	}
+	latestFile, err := binlog.ParseFilename(report.LatestBinlog)
+	if err != nil {
+		return nil, err
+	}
+	// base names are different if upstream is switched to another instance.
+	if neededFile.BaseName != earliestFile.BaseName {
+		return nil, terror.ErrSyncerCheckBinlogGap.Generate(fmt.Sprintf("base name of the needed binlog %s is different from the binlog %s in upstream", needed.Name, report.EarliestBinlog))
+	}
+
+	switch {
+	case neededFile.LessThan(earliestFile):
+		report.MissingFiles = earliestFile.SeqInt64 - neededFile.SeqInt64
+	case neededFile.GreaterThan(latestFile):
+	default:
+		report.Available = true
+	}
+	return report, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testBinlogGapSuite{})
+
+type testBinlogGapSuite struct{}
+
+func (t *testBinlogGapSuite) TestBinlogGap(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	mock.ExpectQuery("SHOW BINARY LOGS").WillReturnRows(
+		sqlmock.NewRows([]string{"Log_name", "File_size"}).
+			AddRow("mysql-bin.000004", 1024).
+			AddRow("mysql-bin.000005", 2048))
+	files, err := getBinaryLogs(context.Background(), db)
+	c.Assert(err, IsNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+
+	// available.
+	report, err := binlogGap(mysql.Position{Name: "mysql-bin.000004", Pos: 512}, files)
+	c.Assert(err, IsNil)
+	c.Assert(report, DeepEquals, &BinlogGapReport{
+		NeededBinlog:   "(mysql-bin.000004, 512)",
+		EarliestBinlog: "mysql-bin.000004",
+		LatestBinlog:   "mysql-bin.000005",
+		Available:      true,
+	})
+
+	// purged.
+	report, err = binlogGap(mysql.Position{Name: "mysql-bin.000001", Pos: 4}, files)
+	c.Assert(err, IsNil)
+	c.Assert(report.Available, IsFalse)
+	c.Assert(report.MissingFiles, Equals, int64(3))
+
+	// newer than the latest binlog, e.g. upstream is reset.
+	report, err = binlogGap(mysql.Position{Name: "mysql-bin.000006", Pos: 4}, files)
+	c.Assert(err, IsNil)
+	c.Assert(report.Available, IsFalse)
+	c.Assert(report.MissingFiles, Equals, int64(0))
+
+	_, err = binlogGap(mysql.Position{Name: "other-bin.000004", Pos: 4}, files)
+	c.Assert(terror.ErrSyncerCheckBinlogGap.Equal(err), IsTrue)
+	_, err = binlogGap(mysql.Position{Name: "mysql-bin.000004", Pos: 4}, nil)
+	c.Assert(terror.ErrSyncerCheckBinlogGap.Equal(err), IsTrue)
+
+	// not initialized.
+	_, err = (&Syncer{}).BinlogGap(context.Background())
+	c.Assert(terror.ErrSyncerCheckBinlogGap.Equal(err), IsTrue)
+}
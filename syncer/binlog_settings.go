@@ -0,0 +1,54 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// checkBinlogSettings checks the binlog settings of upstream make row events contain the full rows, the checks can be
+// skipped by `binlog_format` and `binlog_row_image` in `ignore-checking-items`. it's called when the syncer starts
+// and reconnects to upstream, and only warns if the settings can't be got to not block the replication.
+func (s *Syncer) checkBinlogSettings(ctx context.Context) error {
+	items := config.FilterCheckingItems(s.cfg.IgnoreCheckingItems)
+	_, checkFormat := items[config.BinlogFormatChecking]
+	_, checkRowImage := items[config.BinlogRowImageChecking]
+	if !checkFormat && !checkRowImage {
+		return nil
+	}
+
+	settings, err := utils.GetBinlogSettings(ctx, s.fromDB.BaseDB.DB)
+	if err != nil {
+		s.tctx.L().Warn("fail to get binlog settings of upstream, skip checking them", log.ShortError(err))
+		return nil
+	}
+	if checkFormat {
+		if err = settings.CheckFormat(); err != nil {
+			return err
+		}
+	}
+	if checkRowImage {
+		if err = settings.CheckRowImage(); err != nil {
+			return err
+		}
+	}
+	s.tctx.L().Debug("binlog settings of upstream checked", zap.String("binlog format", settings.Format), zap.String("binlog row image", settings.RowImage))
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testBinlogSettingsSuite{})
+
+type testBinlogSettingsSuite struct{}
+
+func (t *testBinlogSettingsSuite) TestCheckBinlogSettings(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	cfg := &config.SubTaskConfig{Name: "test-binlog-settings"}
+	syncer := NewSyncer(cfg, nil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	ctx := context.Background()
+
+	mockSettings := func(format, rowImage string) {
+		for _, kv := range [][2]string{{"binlog_format", format}, {"binlog_row_image", rowImage}} {
+			mock.ExpectQuery(fmt.Sprintf("SHOW GLOBAL VARIABLES LIKE '%s'", kv[0])).WillReturnRows(
+				sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow(kv[0], kv[1]))
+		}
+	}
+
+	mockSettings("ROW", "FULL")
+	c.Assert(syncer.checkBinlogSettings(ctx), IsNil)
+
+	mockSettings("STATEMENT", "FULL")
+	err = syncer.checkBinlogSettings(ctx)
+	c.Assert(terror.ErrBinlogSettingsNotSupported.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*binlog_format is STATEMENT.*")
+
+	mockSettings("ROW", "NOBLOB")
+	err = syncer.checkBinlogSettings(ctx)
+	c.Assert(terror.ErrBinlogSettingsNotSupported.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*binlog_row_image is NOBLOB.*")
+
+	// only warns if fail to get the settings.
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'binlog_format'").WillReturnError(errors.New("access denied"))
+	c.Assert(syncer.checkBinlogSettings(ctx), IsNil)
+
+	// ignored checking items.
+	syncer.cfg.IgnoreCheckingItems = []string{config.BinlogRowImageChecking}
+	mockSettings("ROW", "MINIMAL")
+	c.Assert(syncer.checkBinlogSettings(ctx), IsNil)
+	syncer.cfg.IgnoreCheckingItems = []string{config.AllChecking}
+	c.Assert(syncer.checkBinlogSettings(ctx), IsNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
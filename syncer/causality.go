@@ -14,6 +14,8 @@
 package syncer
 
 import (
+	"github.com/siddontang/go/sync2"
+
 	"github.com/pingcap/dm/pkg/terror"
 )
 
@@ -23,6 +25,8 @@ import (
 // this mechanism meets quiescent consistency to ensure correctness.
 type causality struct {
 	relations map[string]string
+	// approximate bytes of relations, it can be read concurrently
+	bytes sync2.AtomicInt64
 }
 
 func newCausality() *causality {
@@ -52,6 +56,7 @@ func (c *causality) add(keys []string) error {
 	// set causal relations for those non-exist keys
 	for _, key := range nonExistKeys {
 		c.relations[key] = selectedRelation
+		c.bytes.Add(int64(len(key) + len(selectedRelation)))
 	}
 	return nil
 }
@@ -62,6 +67,7 @@ func (c *causality) get(key string) string {
 
 func (c *causality) reset() {
 	c.relations = make(map[string]string)
+	c.bytes.Set(0)
 }
 
 // detectConflict detects whether there is a conflict
@@ -133,7 +133,11 @@ func (b *binlogPoint) outOfDate() bool {
 	b.RLock()
 	defer b.RUnlock()
 
-	return binlog.CompareLocation(b.location, b.flushedLocation, b.enableGTID) > 0
+	if cmp := binlog.CompareLocation(b.location, b.flushedLocation, b.enableGTID); cmp != 0 {
+		return cmp > 0
+	}
+	// the GTID set of the same position is got after switched to GTID mode by `switch-to-gtid`.
+	return !b.enableGTID && b.location.GTIDSetStr() != b.flushedLocation.GTIDSetStr()
 }
 
 // MySQLLocation returns point as binlog.Location
@@ -189,6 +193,12 @@ type CheckPoint interface {
 	// LoadMeta loads checkpoints from meta config item or file
 	LoadMeta() error
 
+	// Validate validates checkpoints saved by CheckPoint, and returns the corrupted ones
+	Validate(tctx *tcontext.Context) ([]CheckpointCorruption, error)
+
+	// ResetCorrupted removes the corrupted checkpoints from storage
+	ResetCorrupted(tctx *tcontext.Context, corruptions []CheckpointCorruption) error
+
 	// SaveTablePoint saves checkpoint for specified table in memory
 	SaveTablePoint(sourceSchema, sourceTable string, point binlog.Location, ti *model.TableInfo)
 
@@ -233,10 +243,22 @@ type CheckPoint interface {
 	// corresponding to to Meta.Pos and gtid
 	FlushedGlobalPoint() binlog.Location
 
+	// PersistedGlobalPoint queries the global checkpoint persisted in downstream, the checkpoint in memory is not changed
+	PersistedGlobalPoint(tctx *tcontext.Context) (binlog.Location, error)
+
+	// FlushInterval returns the interval of flushing global checkpoint
+	FlushInterval() time.Duration
+
+	// SetFlushInterval sets the interval of flushing global checkpoint
+	SetFlushInterval(interval time.Duration)
+
 	// CheckGlobalPoint checks whether we should save global checkpoint
 	// corresponding to Meta.Check
 	CheckGlobalPoint() bool
 
+	// LastFlushTime returns the time of the last successful flush of checkpoints, it's zero if not flushed yet
+	LastFlushTime() time.Time
+
 	// GetFlushedTableInfo gets flushed table info
 	// use for lazy create table in schemaTracker
 	GetFlushedTableInfo(schema string, table string) *model.TableInfo
@@ -256,6 +278,8 @@ type RemoteCheckPoint struct {
 	sync.RWMutex
 
 	cfg *config.SubTaskConfig
+	// dbProvider creates the DB of checkpoint, conn.DefaultDBProvider is used if it's nil
+	dbProvider conn.DBProvider
 
 	db        *conn.BaseDB
 	dbConn    *DBConn
@@ -274,6 +298,8 @@ type RemoteCheckPoint struct {
 	//   this global checkpoint is next-binlog-pos
 	globalPoint         *binlogPoint
 	globalPointSaveTime time.Time
+	// flushInterval is initialized from cfg.CheckpointFlushInterval, and could be changed at runtime
+	flushInterval time.Duration
 
 	// safeModeExitPoint is set in RemoteCheckPoint.Load (from downstream DB) and LoadMeta (from metadata file).
 	// it is unset (set nil) in RemoteCheckPoint.Clear, and when syncer's stream pass its location.
@@ -289,12 +315,13 @@ type RemoteCheckPoint struct {
 // NewRemoteCheckPoint creates a new RemoteCheckPoint
 func NewRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, id string) CheckPoint {
 	cp := &RemoteCheckPoint{
-		cfg:         cfg,
-		tableName:   dbutil.TableName(cfg.MetaSchema, cputil.SyncerCheckpoint(cfg.Name)),
-		id:          id,
-		points:      make(map[string]map[string]*binlogPoint),
-		globalPoint: newBinlogPoint(binlog.NewLocation(cfg.Flavor), binlog.NewLocation(cfg.Flavor), nil, nil, cfg.EnableGTID),
-		logCtx:      tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("component", "remote checkpoint"))),
+		cfg:           cfg,
+		tableName:     dbutil.TableName(cfg.MetaSchema, cputil.SyncerCheckpoint(cfg.Name)),
+		id:            id,
+		points:        make(map[string]map[string]*binlogPoint),
+		globalPoint:   newBinlogPoint(binlog.NewLocation(cfg.Flavor), binlog.NewLocation(cfg.Flavor), nil, nil, cfg.EnableGTID),
+		flushInterval: time.Duration(cfg.CheckpointFlushInterval) * time.Second,
+		logCtx:        tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("component", "remote checkpoint"))),
 	}
 
 	return cp
@@ -304,7 +331,7 @@ func NewRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, id s
 func (cp *RemoteCheckPoint) Init(tctx *tcontext.Context) error {
 	checkPointDB := cp.cfg.To
 	checkPointDB.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
-	db, dbConns, err := createConns(tctx, cp.cfg, checkPointDB, 1)
+	db, dbConns, err := createConns(tctx, cp.cfg, cp.dbProvider, checkPointDB, 1)
 	if err != nil {
 		return err
 	}
@@ -625,6 +652,37 @@ func (cp *RemoteCheckPoint) FlushedGlobalPoint() binlog.Location {
 	return cp.globalPoint.FlushedMySQLLocation()
 }
 
+// PersistedGlobalPoint implements CheckPoint.PersistedGlobalPoint
+func (cp *RemoteCheckPoint) PersistedGlobalPoint(tctx *tcontext.Context) (binlog.Location, error) {
+	query := `SELECT binlog_name, binlog_pos, binlog_gtid FROM ` + cp.tableName + ` WHERE id = ? AND is_global = 1`
+	rows, err := cp.dbConn.querySQL(tctx, query, cp.id)
+	if err != nil {
+		return binlog.Location{}, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	defer rows.Close()
+
+	location := binlog.NewLocation(cp.cfg.Flavor)
+	for rows.Next() {
+		var (
+			binlogName    string
+			binlogPos     uint32
+			binlogGTIDSet sql.NullString
+		)
+		if err = rows.Scan(&binlogName, &binlogPos, &binlogGTIDSet); err != nil {
+			return binlog.Location{}, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
+		}
+		gset, err := gtid.ParserGTID(cp.cfg.Flavor, binlogGTIDSet.String)
+		if err != nil {
+			return binlog.Location{}, err
+		}
+		location = binlog.InitLocation(mysql.Position{Name: binlogName, Pos: binlogPos}, gset)
+	}
+	if err = rows.Err(); err != nil {
+		return binlog.Location{}, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
+	}
+	return location, nil
+}
+
 // String implements CheckPoint.String
 func (cp *RemoteCheckPoint) String() string {
 	cp.RLock()
@@ -633,11 +691,33 @@ func (cp *RemoteCheckPoint) String() string {
 	return cp.globalPoint.String()
 }
 
+// FlushInterval implements CheckPoint.FlushInterval
+func (cp *RemoteCheckPoint) FlushInterval() time.Duration {
+	cp.RLock()
+	defer cp.RUnlock()
+	return cp.flushInterval
+}
+
+// SetFlushInterval implements CheckPoint.SetFlushInterval
+func (cp *RemoteCheckPoint) SetFlushInterval(interval time.Duration) {
+	cp.Lock()
+	defer cp.Unlock()
+	cp.logCtx.L().Info("change checkpoint flush interval", zap.Duration("from", cp.flushInterval), zap.Duration("to", interval))
+	cp.flushInterval = interval
+}
+
 // CheckGlobalPoint implements CheckPoint.CheckGlobalPoint
 func (cp *RemoteCheckPoint) CheckGlobalPoint() bool {
 	cp.RLock()
 	defer cp.RUnlock()
-	return time.Since(cp.globalPointSaveTime) >= time.Duration(cp.cfg.CheckpointFlushInterval)*time.Second
+	return time.Since(cp.globalPointSaveTime) >= cp.flushInterval
+}
+
+// LastFlushTime implements CheckPoint.LastFlushTime
+func (cp *RemoteCheckPoint) LastFlushTime() time.Time {
+	cp.RLock()
+	defer cp.RUnlock()
+	return cp.globalPointSaveTime
 }
 
 // Rollback implements CheckPoint.Rollback
@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+var _ = Suite(&testCheckpointAgeSuite{})
+
+type testCheckpointAgeSuite struct{}
+
+func (t *testCheckpointAgeSuite) TestCheckpointAge(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	cfg := &config.SubTaskConfig{Name: "test-checkpoint-age", Flavor: mysql.MySQLFlavor}
+	syncer := NewSyncer(cfg, nil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	cp := syncer.checkpoint.(*RemoteCheckPoint)
+	ctx := context.Background()
+
+	mockMasterStatus := func(pos mysql.Position) {
+		mock.ExpectQuery("SHOW MASTER STATUS").WillReturnRows(
+			sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}).AddRow(pos.Name, pos.Pos, "", "", ""))
+	}
+
+	// not flushed yet.
+	age, err := syncer.CheckpointAge(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(age, Equals, time.Duration(0))
+
+	flushedPos := mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+	loc := binlog.InitLocation(flushedPos, nil)
+	cp.globalPoint = newBinlogPoint(loc, loc, nil, nil, false)
+	cp.globalPointSaveTime = time.Now().Add(-time.Minute)
+
+	// upstream is idle.
+	mockMasterStatus(flushedPos)
+	age, err = syncer.CheckpointAge(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(age, Equals, time.Duration(0))
+
+	// upstream has new events.
+	mockMasterStatus(mysql.Position{Name: "mysql-bin.000001", Pos: 4567})
+	age, err = syncer.CheckpointAge(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(age >= time.Minute, IsTrue)
+
+	// fail to get master status.
+	mock.ExpectQuery("SHOW MASTER STATUS").WillReturnError(context.DeadlineExceeded)
+	_, err = syncer.CheckpointAge(ctx)
+	c.Assert(err, NotNil)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
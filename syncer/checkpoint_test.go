@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/pkg/binlog"
@@ -138,6 +139,23 @@ func (s *testCheckpointSuite) TestCheckPoint(c *C) {
 	s.testTableCheckPoint(c, cp)
 }
 
+func (s *testCheckpointSuite) TestCheckPointFlushInterval(c *C) {
+	cfg := *s.cfg
+	cfg.CheckpointFlushInterval = 30
+	cp := NewRemoteCheckPoint(tcontext.Background(), &cfg, cpid)
+	c.Assert(cp.FlushInterval(), Equals, 30*time.Second)
+
+	cp.(*RemoteCheckPoint).globalPointSaveTime = time.Now()
+	c.Assert(cp.CheckGlobalPoint(), IsFalse)
+
+	// flush interval changed at runtime
+	cp.SetFlushInterval(time.Millisecond)
+	c.Assert(cp.FlushInterval(), Equals, time.Millisecond)
+	c.Assert(cfg.CheckpointFlushInterval, Equals, 30)
+	time.Sleep(2 * time.Millisecond)
+	c.Assert(cp.CheckGlobalPoint(), IsTrue)
+}
+
 func (s *testCheckpointSuite) testGlobalCheckPoint(c *C, cp CheckPoint) {
 	tctx := tcontext.Background()
 
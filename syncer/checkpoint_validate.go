@@ -0,0 +1,172 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// CheckpointCorruption represents a corrupted row in the checkpoint table.
+type CheckpointCorruption struct {
+	Schema   string `json:"schema"`
+	Table    string `json:"table"`
+	IsGlobal bool   `json:"is-global"`
+	Reason   string `json:"reason"`
+}
+
+func (c CheckpointCorruption) String() string {
+	if c.IsGlobal {
+		return fmt.Sprintf("global checkpoint: %s", c.Reason)
+	}
+	return fmt.Sprintf("checkpoint of table `%s`.`%s`: %s", c.Schema, c.Table, c.Reason)
+}
+
+// checkpointRow is a row of the checkpoint table, nullable columns are kept as is to be validated.
+type checkpointRow struct {
+	schema       string
+	table        string
+	binlogName   sql.NullString
+	binlogPos    sql.NullInt64
+	binlogGTID   sql.NullString
+	exitSafeName sql.NullString
+	exitSafePos  sql.NullInt64
+	exitSafeGTID sql.NullString
+	tableInfo    []byte
+	isGlobal     sql.NullBool
+}
+
+// validateLocation checks whether the binlog name, pos and GTID set of a location are well-formed and self-consistent.
+func validateLocation(flavor, kind string, name sql.NullString, pos sql.NullInt64, gs sql.NullString) string {
+	switch {
+	case name.String == "":
+		// the location is not set yet, e.g. the checkpoint is created before any binlog is synced.
+		if pos.Int64 > int64(binlog.MinPosition.Pos) {
+			return fmt.Sprintf("%s position %d is set without binlog name", kind, pos.Int64)
+		}
+	case !binlog.VerifyFilename(name.String):
+		return fmt.Sprintf("%s binlog name %s is invalid", kind, name.String)
+	case !pos.Valid:
+		return fmt.Sprintf("%s position of binlog %s is NULL", kind, name.String)
+	case pos.Int64 < int64(binlog.MinPosition.Pos):
+		return fmt.Sprintf("%s position %d of binlog %s is less than %d", kind, pos.Int64, name.String, binlog.MinPosition.Pos)
+	}
+	if _, err := gtid.ParserGTID(flavor, gs.String); err != nil {
+		return fmt.Sprintf("%s GTID set %s is invalid", kind, gs.String)
+	}
+	return ""
+}
+
+// validateCheckpointRow returns the reasons why the row is corrupted, or nil if it's valid.
+func validateCheckpointRow(flavor string, row *checkpointRow) []string {
+	var reasons []string
+	if !row.isGlobal.Valid {
+		reasons = append(reasons, "is_global is NULL")
+	} else if row.isGlobal.Bool != (row.schema == "" && row.table == "") {
+		// the global checkpoint uses empty schema and table, see `globalCpSchema` and `globalCpTable`.
+		reasons = append(reasons, fmt.Sprintf("is_global is %v but schema is `%s` and table is `%s`", row.isGlobal.Bool, row.schema, row.table))
+	}
+	if r := validateLocation(flavor, "binlog", row.binlogName, row.binlogPos, row.binlogGTID); r != "" {
+		reasons = append(reasons, r)
+	}
+	if row.exitSafeName.String != "" || row.exitSafePos.Int64 > 0 || row.exitSafeGTID.String != "" {
+		if r := validateLocation(flavor, "safe mode exit", row.exitSafeName, row.exitSafePos, row.exitSafeGTID); r != "" {
+			reasons = append(reasons, r)
+		}
+	}
+	if len(row.tableInfo) > 0 && !json.Valid(row.tableInfo) {
+		reasons = append(reasons, "table_info is not a valid JSON")
+	}
+	return reasons
+}
+
+// Validate implements CheckPoint.Validate.
+func (cp *RemoteCheckPoint) Validate(tctx *tcontext.Context) ([]CheckpointCorruption, error) {
+	cp.RLock()
+	defer cp.RUnlock()
+
+	query := `SELECT cp_schema, cp_table, binlog_name, binlog_pos, binlog_gtid, exit_safe_binlog_name, exit_safe_binlog_pos, exit_safe_binlog_gtid, table_info, is_global FROM ` + cp.tableName + ` WHERE id = ?`
+	rows, err := cp.dbConn.querySQL(tctx, query, cp.id)
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	defer rows.Close()
+
+	var corruptions []CheckpointCorruption
+	for rows.Next() {
+		var row checkpointRow
+		err = rows.Scan(&row.schema, &row.table, &row.binlogName, &row.binlogPos, &row.binlogGTID,
+			&row.exitSafeName, &row.exitSafePos, &row.exitSafeGTID, &row.tableInfo, &row.isGlobal)
+		if err != nil {
+			return nil, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
+		}
+		reasons := validateCheckpointRow(cp.cfg.Flavor, &row)
+		if len(reasons) == 0 {
+			continue
+		}
+		corruptions = append(corruptions, CheckpointCorruption{
+			Schema:   row.schema,
+			Table:    row.table,
+			IsGlobal: row.isGlobal.Bool || (row.schema == "" && row.table == ""),
+			Reason:   strings.Join(reasons, ", "),
+		})
+	}
+	return corruptions, terror.WithScope(terror.DBErrorAdapt(rows.Err(), terror.ErrDBDriverError), terror.ScopeDownstream)
+}
+
+// ResetCorrupted implements CheckPoint.ResetCorrupted.
+func (cp *RemoteCheckPoint) ResetCorrupted(tctx *tcontext.Context, corruptions []CheckpointCorruption) error {
+	if len(corruptions) == 0 {
+		return nil
+	}
+	cp.Lock()
+	defer cp.Unlock()
+
+	sqls := make([]string, 0, len(corruptions))
+	args := make([][]interface{}, 0, len(corruptions))
+	for _, c := range corruptions {
+		sqls = append(sqls, `DELETE FROM `+cp.tableName+` WHERE id = ? AND cp_schema = ? AND cp_table = ?`)
+		args = append(args, []interface{}{cp.id, c.Schema, c.Table})
+	}
+	_, err := cp.dbConn.executeSQL(tctx, sqls, args...)
+	return err
+}
+
+// validateCheckpoint validates the checkpoint table before loading it. if it's corrupted, an error is returned,
+// or the corrupted rows are removed if `reset-corrupted-checkpoint` is set.
+func (s *Syncer) validateCheckpoint(tctx *tcontext.Context) error {
+	corruptions, err := s.checkpoint.Validate(tctx)
+	if err != nil || len(corruptions) == 0 {
+		return err
+	}
+
+	reasons := make([]string, 0, len(corruptions))
+	for _, c := range corruptions {
+		reasons = append(reasons, c.String())
+	}
+	if !s.cfg.ResetCorruptedCheckpoint {
+		return terror.ErrSyncerCheckpointCorrupted.Generate(s.cfg.Name, strings.Join(reasons, "; "))
+	}
+	s.tctx.L().Warn("remove corrupted checkpoints", zap.Strings("corruptions", reasons))
+	return s.checkpoint.ResetCorrupted(tctx, corruptions)
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/conn"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/cputil"
+	"github.com/pingcap/dm/pkg/retry"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testCheckpointValidateSuite{})
+
+type testCheckpointValidateSuite struct{}
+
+var checkpointColumns = []string{
+	"cp_schema", "cp_table", "binlog_name", "binlog_pos", "binlog_gtid",
+	"exit_safe_binlog_name", "exit_safe_binlog_pos", "exit_safe_binlog_gtid", "table_info", "is_global",
+}
+
+func (t *testCheckpointValidateSuite) TestValidateCheckpointRow(c *C) {
+	cases := []struct {
+		row     []driver.Value
+		reasons []string
+	}{
+		{
+			[]driver.Value{"", "", "mysql-bin.000003", 1234, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14", "", 0, "", "null", true},
+			nil,
+		},
+		{
+			[]driver.Value{"db", "tbl", "", 0, "", "", 0, nil, `{"id":1}`, false},
+			nil,
+		},
+		{
+			[]driver.Value{"db", "tbl", "mysql-bin", 3, "abc", "", 0, nil, `{`, nil},
+			[]string{"is_global is NULL", "binlog binlog name mysql-bin is invalid", "table_info is not a valid JSON"},
+		},
+		{
+			[]driver.Value{"db", "", "mysql-bin.000003", 3, "", "", 10, "", "null", true},
+			[]string{
+				"is_global is true but schema is `db` and table is ``",
+				"binlog position 3 of binlog mysql-bin.000003 is less than 4",
+				"safe mode exit position 10 is set without binlog name",
+			},
+		},
+		{
+			[]driver.Value{"", "", "mysql-bin.000003", nil, "xxx", "", 0, "", "null", true},
+			[]string{"binlog position of binlog mysql-bin.000003 is NULL"},
+		},
+		{
+			[]driver.Value{"", "", "mysql-bin.000003", 4, "xxx", "", 0, "", "null", true},
+			[]string{"binlog GTID set xxx is invalid"},
+		},
+	}
+
+	for i, cs := range cases {
+		db, mock, err := sqlmock.New()
+		c.Assert(err, IsNil)
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(checkpointColumns).AddRow(cs.row...))
+		rows, err := db.Query("SELECT")
+		c.Assert(err, IsNil)
+		c.Assert(rows.Next(), IsTrue)
+		var row checkpointRow
+		c.Assert(rows.Scan(&row.schema, &row.table, &row.binlogName, &row.binlogPos, &row.binlogGTID,
+			&row.exitSafeName, &row.exitSafePos, &row.exitSafeGTID, &row.tableInfo, &row.isGlobal), IsNil)
+		c.Assert(validateCheckpointRow(mysql.MySQLFlavor, &row), DeepEquals, cs.reasons, Commentf("case %d", i))
+		c.Assert(rows.Close(), IsNil)
+		mock.ExpectClose()
+		c.Assert(db.Close(), IsNil)
+	}
+}
+
+func (t *testCheckpointValidateSuite) TestValidateCheckpoint(c *C) {
+	cfg := &config.SubTaskConfig{
+		ServerID:   101,
+		MetaSchema: "test",
+		Name:       "syncer_checkpoint_validate_ut",
+		Flavor:     mysql.MySQLFlavor,
+	}
+	tctx := tcontext.Background()
+	cp := NewRemoteCheckPoint(tctx, cfg, cpid)
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(tctx.Context())
+	c.Assert(err, IsNil)
+	cp.(*RemoteCheckPoint).dbConn = &DBConn{cfg: cfg, baseConn: conn.NewBaseConn(dbConn, &retry.FiniteRetryStrategy{})}
+	syncer := NewSyncer(cfg, nil)
+	syncer.checkpoint = cp
+
+	tableName := dbutil.TableName(cfg.MetaSchema, cputil.SyncerCheckpoint(cfg.Name))
+	querySQL := fmt.Sprintf("SELECT .* FROM %s WHERE id = \\?", tableName)
+	deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE id = \\? AND cp_schema = \\? AND cp_table = \\?", tableName)
+	corruptedRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(checkpointColumns).
+			AddRow("", "", "mysql-bin.000003", 1234, "", "", 0, "", "null", true).
+			AddRow("db", "tbl", "mysql-bin.000003", 1234, "", "", 0, "", "{", false)
+	}
+
+	// valid checkpoints.
+	mock.ExpectQuery(querySQL).WithArgs(cpid).WillReturnRows(sqlmock.NewRows(checkpointColumns).
+		AddRow("", "", "mysql-bin.000003", 1234, "", "", 0, "", "null", true))
+	c.Assert(syncer.validateCheckpoint(tctx), IsNil)
+
+	// corrupted checkpoints fail to start.
+	mock.ExpectQuery(querySQL).WithArgs(cpid).WillReturnRows(corruptedRows())
+	err = syncer.validateCheckpoint(tctx)
+	c.Assert(terror.ErrSyncerCheckpointCorrupted.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*checkpoint of table `db`.`tbl`: table_info is not a valid JSON.*")
+
+	// corrupted checkpoints are removed.
+	syncer.cfg.ResetCorruptedCheckpoint = true
+	mock.ExpectQuery(querySQL).WithArgs(cpid).WillReturnRows(corruptedRows())
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteSQL).WithArgs(cpid, "db", "tbl").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	c.Assert(syncer.validateCheckpoint(tctx), IsNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
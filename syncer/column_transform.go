@@ -0,0 +1,143 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/parser/model"
+	selector "github.com/pingcap/tidb-tools/pkg/table-rule-selector"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// columnTransformer applies column transforms to rows of binlog events.
+type columnTransformer struct {
+	caseSensitive bool
+	transforms    []*config.ColumnTransform
+	// indexes of transforms are stored in the selector
+	selector selector.Selector
+}
+
+func newColumnTransformer(caseSensitive bool, transforms []*config.ColumnTransform) (*columnTransformer, error) {
+	type pattern struct {
+		schema string
+		table  string
+	}
+	indexes := make(map[pattern][]int)
+	patterns := make([]pattern, 0, len(transforms))
+	for i, t := range transforms {
+		if err := t.Valid(); err != nil {
+			return nil, terror.ErrSyncerUnitGenColumnTransform.Delegate(err)
+		}
+		p := pattern{schema: t.SchemaPattern, table: t.TablePattern}
+		if !caseSensitive {
+			p.schema, p.table = strings.ToLower(p.schema), strings.ToLower(p.table)
+		}
+		if _, ok := indexes[p]; !ok {
+			patterns = append(patterns, p)
+		}
+		indexes[p] = append(indexes[p], i)
+	}
+
+	ct := &columnTransformer{
+		caseSensitive: caseSensitive,
+		transforms:    transforms,
+		selector:      selector.NewTrieSelector(),
+	}
+	for _, p := range patterns {
+		if err := ct.selector.Insert(p.schema, p.table, indexes[p], selector.Insert); err != nil {
+			return nil, terror.ErrSyncerUnitGenColumnTransform.Delegate(err)
+		}
+	}
+	return ct, nil
+}
+
+// transformRows returns the rows with values of matched columns transformed, rows are not modified in place.
+// if multiple transforms match the same column, the first one in config is applied.
+func (ct *columnTransformer) transformRows(schema, table string, ti *model.TableInfo, rows [][]interface{}) [][]interface{} {
+	if !ct.caseSensitive {
+		schema, table = strings.ToLower(schema), strings.ToLower(table)
+	}
+	var matched []int
+	for _, rule := range ct.selector.Match(schema, table) {
+		matched = append(matched, rule.([]int)...)
+	}
+	if len(matched) == 0 {
+		return rows
+	}
+	sort.Ints(matched)
+
+	funcs := make(map[int]config.TransformFunc) // column offset -> transform func
+	for _, i := range matched {
+		t := ct.transforms[i]
+		for offset, col := range ti.Columns {
+			// column names are case-insensitive in MySQL.
+			if _, ok := funcs[offset]; !ok && strings.EqualFold(col.Name.O, t.Column) {
+				funcs[offset] = t.Func
+			}
+		}
+	}
+	if len(funcs) == 0 {
+		return rows
+	}
+
+	transformed := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		transformed[i] = append([]interface{}{}, row...)
+		for offset, fn := range funcs {
+			if offset < len(row) {
+				transformed[i][offset] = transformValue(fn, row[offset])
+			}
+		}
+	}
+	return transformed
+}
+
+func transformValue(fn config.TransformFunc, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch fn {
+	case config.TransformHash:
+		var sum [sha256.Size]byte
+		switch vv := v.(type) {
+		case []byte:
+			sum = sha256.Sum256(vv)
+		case string:
+			sum = sha256.Sum256([]byte(vv))
+		default:
+			sum = sha256.Sum256([]byte(fmt.Sprintf("%v", vv)))
+		}
+		return hex.EncodeToString(sum[:])
+	case config.TransformMask:
+		switch vv := v.(type) {
+		case []byte:
+			return strings.Repeat("*", len(vv))
+		case string:
+			return strings.Repeat("*", utf8.RuneCountInString(vv))
+		default:
+			return strings.Repeat("*", len(fmt.Sprintf("%v", vv)))
+		}
+	case config.TransformNullify:
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/model"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testColumnTransformSuite{})
+
+type testColumnTransformSuite struct{}
+
+func (t *testColumnTransformSuite) TestColumnTransformer(c *C) {
+	_, err := newColumnTransformer(false, []*config.ColumnTransform{{SchemaPattern: "db", Column: "c", Func: "upper"}})
+	c.Assert(terror.ErrSyncerUnitGenColumnTransform.Equal(err), IsTrue)
+
+	ct, err := newColumnTransformer(false, []*config.ColumnTransform{
+		{SchemaPattern: "db*", TablePattern: "tbl*", Column: "Email", Func: config.TransformHash},
+		{SchemaPattern: "db*", Column: "email", Func: config.TransformNullify},
+		{SchemaPattern: "db*", TablePattern: "tbl1", Column: "name", Func: config.TransformMask},
+		{SchemaPattern: "db*", TablePattern: "tbl1", Column: "age", Func: config.TransformMask},
+	})
+	c.Assert(err, IsNil)
+
+	ti := &model.TableInfo{Columns: []*model.ColumnInfo{
+		{Name: model.NewCIStr("id")},
+		{Name: model.NewCIStr("email")},
+		{Name: model.NewCIStr("name")},
+		{Name: model.NewCIStr("age")},
+	}}
+	rows := [][]interface{}{
+		{int32(1), "a@b.com", "张三", int32(18)},
+		{int32(2), nil, []byte("bob"), nil},
+	}
+	transformed := ct.transformRows("DB1", "TBL1", ti, rows)
+	c.Assert(transformed, DeepEquals, [][]interface{}{
+		// the first transform of the column is applied.
+		{int32(1), "fb98d44ad7501a959f3f4f4a3f004fe2d9e581ea6207e218c4b02c08a4d75adf", "**", "**"},
+		{int32(2), nil, "***", nil},
+	})
+	// rows are not transformed in place.
+	c.Assert(rows[0][1], Equals, "a@b.com")
+
+	// only schema pattern matched.
+	transformed = ct.transformRows("db1", "other", ti, rows)
+	c.Assert(transformed[0], DeepEquals, []interface{}{int32(1), nil, "张三", int32(18)})
+
+	// not matched.
+	c.Assert(ct.transformRows("other", "tbl1", ti, rows), DeepEquals, rows)
+
+	// case-sensitive.
+	ct, err = newColumnTransformer(true, []*config.ColumnTransform{{SchemaPattern: "db", TablePattern: "tbl", Column: "email", Func: config.TransformNullify}})
+	c.Assert(err, IsNil)
+	c.Assert(ct.transformRows("DB", "tbl", ti, rows), DeepEquals, rows)
+	c.Assert(ct.transformRows("db", "tbl", ti, rows)[0][1], IsNil)
+}
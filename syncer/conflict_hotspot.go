@@ -0,0 +1,174 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+)
+
+const (
+	// conflictHotspotWindow is the window of write conflicts used to find hotspots.
+	conflictHotspotWindow = 10 * time.Minute
+	// maxConflictRecords is the max number of write conflicts recorded in the window.
+	maxConflictRecords = 10000
+)
+
+var (
+	// the table of DML statements generated by the syncer, like "INSERT INTO `db`.`tbl` ...".
+	dmlTableRegex = regexp.MustCompile("^(?:INSERT INTO|INSERT IGNORE INTO|REPLACE INTO|UPDATE|DELETE FROM) (`(?:[^`]|``)+`\\.`(?:[^`]|``)+`)")
+	// the conflicted key in the write conflict error of TiDB, like "key={tableID=45, handle=1}" or
+	// "key={tableID=45, tableName=db.tbl, indexID=1, indexValues={1, }}".
+	conflictKeyRegex       = regexp.MustCompile(`key=(\{[^{}]*(?:\{[^{}]*\}[^{}]*)?\})`)
+	conflictTableNameRegex = regexp.MustCompile(`tableName=([^,}\s]+)`)
+)
+
+// ConflictHotspot represents a table or a key of it with write conflicts or deadlocks in downstream.
+type ConflictHotspot struct {
+	// Table is the target table, it's the one in the conflicted key if reported by downstream, otherwise every table
+	// written by the conflicted transaction is counted.
+	Table string `json:"table"`
+	// Key is the conflicted key reported by downstream, it's empty if not determinable.
+	Key       string    `json:"key,omitempty"`
+	Conflicts int64     `json:"conflicts"` // including deadlocks
+	Deadlocks int64     `json:"deadlocks"`
+	LastSeen  time.Time `json:"last-seen"`
+}
+
+type conflictRecord struct {
+	time     time.Time
+	table    string
+	key      string
+	deadlock bool
+}
+
+// conflictHotspots records the write conflicts in downstream in a recent window, it's safe for concurrent use.
+type conflictHotspots struct {
+	sync.Mutex
+	records []conflictRecord
+}
+
+// record records a write conflict or deadlock of executing queries.
+func (h *conflictHotspots) record(err error, queries []string) {
+	var msg string
+	if mysqlErr, ok := errors.Cause(err).(*mysql.MySQLError); ok {
+		msg = mysqlErr.Message
+	}
+	deadlock := isDeadlockError(err)
+
+	var tables []string
+	key := ""
+	if m := conflictKeyRegex.FindStringSubmatch(msg); m != nil {
+		key = m[1]
+		if m2 := conflictTableNameRegex.FindStringSubmatch(key); m2 != nil {
+			tables = []string{m2[1]}
+		}
+	}
+	if len(tables) == 0 {
+		tables = dmlTables(queries)
+		if len(tables) != 1 {
+			// can't tell which table the key belongs to.
+			key = ""
+		}
+	}
+
+	now := time.Now()
+	h.Lock()
+	defer h.Unlock()
+	for _, table := range tables {
+		h.records = append(h.records, conflictRecord{time: now, table: table, key: key, deadlock: deadlock})
+	}
+	h.prune(now)
+}
+
+// prune removes the records out of the window or the capacity, the caller should hold the lock.
+func (h *conflictHotspots) prune(now time.Time) {
+	i := sort.Search(len(h.records), func(i int) bool {
+		return now.Sub(h.records[i].time) <= conflictHotspotWindow
+	})
+	if n := len(h.records) - maxConflictRecords; n > i {
+		i = n
+	}
+	if i > 0 {
+		h.records = append(h.records[:0:0], h.records[i:]...)
+	}
+}
+
+// hotspots returns at most top hotspots in the window with the most conflicts, all hotspots are returned if top <= 0.
+func (h *conflictHotspots) hotspots(top int) []ConflictHotspot {
+	h.Lock()
+	h.prune(time.Now())
+	type hotspotKey struct{ table, key string }
+	byKey := make(map[hotspotKey]*ConflictHotspot)
+	for _, r := range h.records {
+		k := hotspotKey{table: r.table, key: r.key}
+		hs, ok := byKey[k]
+		if !ok {
+			hs = &ConflictHotspot{Table: r.table, Key: r.key}
+			byKey[k] = hs
+		}
+		hs.Conflicts++
+		if r.deadlock {
+			hs.Deadlocks++
+		}
+		hs.LastSeen = r.time
+	}
+	h.Unlock()
+
+	result := make([]ConflictHotspot, 0, len(byKey))
+	for _, hs := range byKey {
+		result = append(result, *hs)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Conflicts != result[j].Conflicts {
+			return result[i].Conflicts > result[j].Conflicts
+		}
+		if result[i].Table != result[j].Table {
+			return result[i].Table < result[j].Table
+		}
+		return result[i].Key < result[j].Key
+	})
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
+
+// dmlTables returns the distinct tables of the DML statements in order.
+func dmlTables(queries []string) []string {
+	tables := make([]string, 0, 1)
+	seen := make(map[string]struct{})
+	for _, query := range queries {
+		m := dmlTableRegex.FindStringSubmatch(query)
+		if m == nil {
+			continue
+		}
+		if _, ok := seen[m[1]]; !ok {
+			seen[m[1]] = struct{}{}
+			tables = append(tables, m[1])
+		}
+	}
+	return tables
+}
+
+// WriteConflictHotspots returns at most top tables or keys with the most write conflicts or deadlocks in downstream in
+// the recent 10 minutes, it's empty if no conflicts.
+func (s *Syncer) WriteConflictHotspots(top int) []ConflictHotspot {
+	return s.conflictHotspots.hotspots(top)
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/errno"
+)
+
+var _ = Suite(&testConflictHotspotSuite{})
+
+type testConflictHotspotSuite struct{}
+
+func (t *testConflictHotspotSuite) TestDMLTables(c *C) {
+	c.Assert(dmlTables([]string{
+		"INSERT INTO `db`.`tb1` (`id`) VALUES (?)",
+		"REPLACE INTO `db`.`tb2` (`id`) VALUES (?)",
+		"UPDATE `db`.`tb1` SET `id` = ? WHERE `id` = ? LIMIT 1",
+		"DELETE FROM `d``b`.`tb3` WHERE `id` = ? LIMIT 1",
+		"INSERT IGNORE INTO `db`.`tb4` (`id`) VALUES (?)",
+		"ALTER TABLE `db`.`tb5` ADD COLUMN c INT",
+	}), DeepEquals, []string{"`db`.`tb1`", "`db`.`tb2`", "`d``b`.`tb3`", "`db`.`tb4`"})
+}
+
+func (t *testConflictHotspotSuite) TestHotspots(c *C) {
+	var h conflictHotspots
+	c.Assert(h.hotspots(10), HasLen, 0)
+
+	insert1 := []string{"INSERT INTO `db`.`tb1` (`id`) VALUES (?)"}
+	insert12 := []string{"INSERT INTO `db`.`tb1` (`id`) VALUES (?)", "INSERT INTO `db`.`tb2` (`id`) VALUES (?)"}
+	conflictKey := "{tableID=45, handle=1}"
+	conflict := newMysqlErr(errno.ErrWriteConflictInTiDB,
+		"Write conflict, txnStartTS=1, conflictStartTS=2, conflictCommitTS=3, key="+conflictKey+" primary={tableID=45, handle=1} [try again later]")
+	indexKey := "{tableID=46, tableName=db.tb3, indexID=1, indexValues={1, }}"
+	indexConflict := newMysqlErr(errno.ErrWriteConflict, "Write conflict, txnStartTS=1, conflictStartTS=2, conflictCommitTS=3, key="+indexKey+" primary={}")
+	deadlock := newMysqlErr(errno.ErrLockDeadlock, "Deadlock found when trying to get lock; try restarting transaction")
+
+	// the key is attributed to the only table in the transaction.
+	h.record(conflict, insert1)
+	h.record(conflict, insert1)
+	h.record(deadlock, insert1)
+	// the key is not determinable for multiple tables.
+	h.record(conflict, insert12)
+	// the table name in the key is used.
+	h.record(indexConflict, insert12)
+
+	hotspots := h.hotspots(0)
+	c.Assert(hotspots, HasLen, 4)
+	c.Assert(hotspots[0].Table, Equals, "`db`.`tb1`")
+	c.Assert(hotspots[0].Key, Equals, "")
+	c.Assert(hotspots[0].Conflicts, Equals, int64(2))
+	c.Assert(hotspots[0].Deadlocks, Equals, int64(1))
+	c.Assert(hotspots[1].Table, Equals, "`db`.`tb1`")
+	c.Assert(hotspots[1].Key, Equals, conflictKey)
+	c.Assert(hotspots[1].Conflicts, Equals, int64(2))
+	c.Assert(hotspots[1].Deadlocks, Equals, int64(0))
+	c.Assert(hotspots[2].Table, Equals, "`db`.`tb2`")
+	c.Assert(hotspots[2].Key, Equals, "")
+	c.Assert(hotspots[3].Table, Equals, "db.tb3")
+	c.Assert(hotspots[3].Key, Equals, indexKey)
+	c.Assert(h.hotspots(1), DeepEquals, hotspots[:1])
+
+	// records out of the window are removed.
+	for i := range h.records {
+		h.records[i].time = h.records[i].time.Add(-conflictHotspotWindow - time.Second)
+	}
+	h.record(deadlock, insert12)
+	hotspots = h.hotspots(10)
+	c.Assert(hotspots, HasLen, 2)
+	c.Assert(hotspots[0].Deadlocks, Equals, int64(1))
+	c.Assert(hotspots[1].Deadlocks, Equals, int64(1))
+}
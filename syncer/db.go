@@ -31,9 +31,9 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/parser"
 	tmysql "github.com/pingcap/parser/mysql"
-	"github.com/pingcap/tidb-tools/pkg/dbutil"
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 )
 
@@ -51,8 +51,12 @@ type binlogSize struct {
 	size int64
 }
 
-func createBaseDB(dbCfg config.DBConfig) (*conn.BaseDB, error) {
-	db, err := conn.DefaultDBProvider.Apply(dbCfg)
+// createBaseDB creates a BaseDB by the DB provider, conn.DefaultDBProvider is used if it's nil.
+func createBaseDB(dbProvider conn.DBProvider, dbCfg config.DBConfig) (*conn.BaseDB, error) {
+	if dbProvider == nil {
+		dbProvider = conn.DefaultDBProvider
+	}
+	db, err := dbProvider.Apply(dbCfg)
 	if err != nil {
 		return nil, terror.WithScope(err, terror.ScopeDownstream)
 	}
@@ -108,8 +112,8 @@ func (conn *UpStreamConn) countBinaryLogsSize(pos mysql.Position) (int64, error)
 	return countBinaryLogsSize(pos, conn.BaseDB.DB)
 }
 
-func createUpStreamConn(dbCfg config.DBConfig) (*UpStreamConn, error) {
-	baseDB, err := createBaseDB(dbCfg)
+func createUpStreamConn(dbProvider conn.DBProvider, dbCfg config.DBConfig) (*UpStreamConn, error) {
+	baseDB, err := createBaseDB(dbProvider, dbCfg)
 	if err != nil {
 		return nil, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeUpstream)
 	}
@@ -128,6 +132,19 @@ type DBConn struct {
 	cfg      *config.SubTaskConfig
 	baseConn *conn.BaseConn
 
+	// count of write conflict retries, maybe shared by multiple connections, nil means not counting
+	conflictRetries *sync2.AtomicInt64
+	// accumulated nanoseconds of executing SQLs, maybe shared by multiple connections, nil means not counting
+	writeDuration *sync2.AtomicInt64
+	// count of transactions being executed, maybe shared by multiple connections, nil means not counting
+	activeTxns *sync2.AtomicInt64
+	// results of executing statements, maybe shared by multiple connections, nil means not counting
+	targetStats *targetStats
+	// write conflicts in the recent window, maybe shared by multiple connections, nil means not recording
+	conflictHotspots *conflictHotspots
+	// decide whether the errors are retryable, maybe shared by multiple connections, nil means the default rules
+	errClassifiers *retry.Classifiers
+
 	// generate new BaseConn and close old one
 	resetBaseConnFn func(*tcontext.Context, *conn.BaseConn) (*conn.BaseConn, error)
 }
@@ -146,6 +163,7 @@ func (conn *DBConn) querySQL(tctx *tcontext.Context, query string, args ...inter
 	if conn == nil || conn.baseConn == nil {
 		return nil, terror.ErrDBUnExpect.Generate("database base connection not valid")
 	}
+	var pausedBy string
 	params := retry.Params{
 		RetryCount:         10,
 		FirstRetryDuration: retryTimeout,
@@ -163,7 +181,7 @@ func (conn *DBConn) querySQL(tctx *tcontext.Context, query string, args ...inter
 				sqlRetriesTotal.WithLabelValues("query", conn.cfg.Name).Add(1)
 				return true
 			}
-			if dbutil.IsRetryableError(err) {
+			if conn.isRetryableError(err, &pausedBy) {
 				tctx.L().Warn("query statement", zap.Int("retry", retryTime),
 					zap.String("query", utils.TruncateString(query, -1)),
 					zap.String("argument", utils.TruncateInterface(args, -1)),
@@ -196,6 +214,7 @@ func (conn *DBConn) querySQL(tctx *tcontext.Context, query string, args ...inter
 	)
 
 	if err != nil {
+		err = retry.PausedByClassifier(err, pausedBy)
 		tctx.L().ErrorFilterContextCanceled("query statement failed after retry",
 			zap.String("query", utils.TruncateString(query, -1)),
 			zap.String("argument", utils.TruncateInterface(args, -1)),
@@ -206,6 +225,16 @@ func (conn *DBConn) querySQL(tctx *tcontext.Context, query string, args ...inter
 }
 
 func (conn *DBConn) executeSQLWithIgnore(tctx *tcontext.Context, ignoreError func(error) bool, queries []string, args ...[]interface{}) (int, error) {
+	return conn.executeSQLInMode(tctx, ignoreError, false, queries, args...)
+}
+
+// executeSQLWithoutTxn executes statements one by one without a transaction for `bulk` write mode, the statements
+// executed are kept if one failed, and aren't executed again when retrying.
+func (conn *DBConn) executeSQLWithoutTxn(tctx *tcontext.Context, queries []string, args ...[]interface{}) (int, error) {
+	return conn.executeSQLInMode(tctx, nil, true, queries, args...)
+}
+
+func (conn *DBConn) executeSQLInMode(tctx *tcontext.Context, ignoreError func(error) bool, bulk bool, queries []string, args ...[]interface{}) (int, error) {
 	failpoint.Inject("ExecuteSQLWithIgnoreFailed", func(val failpoint.Value) {
 		queryPattern := val.(string)
 		if len(queries) == 1 && strings.Contains(queries[0], queryPattern) {
@@ -222,6 +251,7 @@ func (conn *DBConn) executeSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 		return 0, terror.ErrDBUnExpect.Generate("database base connection not valid")
 	}
 
+	var pausedBy string
 	params := retry.Params{
 		RetryCount:         100,
 		FirstRetryDuration: retryTimeout,
@@ -239,7 +269,10 @@ func (conn *DBConn) executeSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 				sqlRetriesTotal.WithLabelValues("stmt_exec", conn.cfg.Name).Add(1)
 				return true
 			}
-			if dbutil.IsRetryableError(err) {
+			if isWriteConflictError(err) {
+				return false // retry with the conflict retry policy below
+			}
+			if conn.isRetryableError(err, &pausedBy) {
 				tctx.L().Warn("execute statements", zap.Int("retry", retryTime),
 					zap.String("queries", utils.TruncateInterface(queries, -1)),
 					zap.String("arguments", utils.TruncateInterface(args, -1)),
@@ -251,26 +284,86 @@ func (conn *DBConn) executeSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 		},
 	}
 
-	ret, _, err := conn.baseConn.ApplyRetryStrategy(
-		tctx,
-		params,
-		func(ctx *tcontext.Context) (interface{}, error) {
-			startTime := time.Now()
-			ret, err := conn.baseConn.ExecuteSQLWithIgnoreError(ctx, stmtHistogram, conn.cfg.Name, ignoreError, queries, args...)
-			if err == nil {
-				cost := time.Since(startTime)
-				txnHistogram.WithLabelValues(conn.cfg.Name).Observe(cost.Seconds())
-				if cost.Seconds() > 1 {
-					ctx.L().Warn("execute transaction",
-						zap.String("query", utils.TruncateInterface(queries, -1)),
-						zap.String("argument", utils.TruncateInterface(args, -1)),
-						zap.Duration("cost time", cost))
+	var (
+		ret           interface{}
+		err           error
+		retryCount    = conn.conflictRetryCount()
+		retryInterval = conn.conflictRetryInterval()
+		// the number of statements executed in bulk mode
+		executed int
+	)
+	for i := 0; ; i++ {
+		ret, _, err = conn.baseConn.ApplyRetryStrategy(
+			tctx,
+			params,
+			func(ctx *tcontext.Context) (interface{}, error) {
+				if conn.activeTxns != nil {
+					conn.activeTxns.Add(1)
+					defer conn.activeTxns.Add(-1)
 				}
-			}
-			return ret, err
-		})
+				startTime := time.Now()
+				var (
+					ret int
+					err error
+				)
+				if bulk {
+					var restArgs [][]interface{}
+					if len(args) > executed {
+						restArgs = args[executed:]
+					}
+					ret, err = conn.baseConn.ExecuteSQLWithoutTxn(ctx, stmtHistogram, conn.cfg.Name, queries[executed:], restArgs...)
+					executed += ret
+					ret = executed
+				} else {
+					ret, err = conn.baseConn.ExecuteSQLWithIgnoreError(ctx, stmtHistogram, conn.cfg.Name, ignoreError, queries, args...)
+				}
+				if conn.targetStats != nil {
+					conn.targetStats.record(len(queries), err)
+				}
+				if err == nil {
+					cost := time.Since(startTime)
+					txnHistogram.WithLabelValues(conn.cfg.Name).Observe(cost.Seconds())
+					if conn.writeDuration != nil {
+						conn.writeDuration.Add(int64(cost))
+					}
+					if cost.Seconds() > 1 {
+						ctx.L().Warn("execute transaction",
+							zap.String("query", utils.TruncateInterface(queries, -1)),
+							zap.String("argument", utils.TruncateInterface(args, -1)),
+							zap.Duration("cost time", cost))
+					}
+				}
+				return ret, err
+			})
+		if err == nil || !isWriteConflictError(err) {
+			break
+		}
+		if conn.conflictHotspots != nil {
+			conn.conflictHotspots.record(err, queries)
+		}
+		if i >= retryCount {
+			err = terror.ErrSyncerWriteConflictExhausted.Delegate(err, i)
+			break
+		}
+
+		tctx.L().Warn("write conflict in downstream, will retry later", zap.Int("retry", i),
+			zap.String("queries", utils.TruncateInterface(queries, -1)),
+			zap.String("arguments", utils.TruncateInterface(args, -1)),
+			zap.Duration("interval", retryInterval),
+			log.ShortError(err))
+		sqlRetriesTotal.WithLabelValues("write_conflict", conn.cfg.Name).Add(1)
+		if conn.conflictRetries != nil {
+			conn.conflictRetries.Add(1)
+		}
+		select {
+		case <-tctx.Context().Done():
+			return ret.(int), err
+		case <-time.After(retryInterval):
+		}
+	}
 
 	if err != nil {
+		err = retry.PausedByClassifier(err, pausedBy)
 		tctx.L().ErrorFilterContextCanceled("execute statements failed after retry",
 			zap.String("queries", utils.TruncateInterface(queries, -1)),
 			zap.String("arguments", utils.TruncateInterface(args, -1)),
@@ -280,13 +373,40 @@ func (conn *DBConn) executeSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 	return ret.(int), nil
 }
 
+// isRetryableError decides whether the error is retryable by the error classifiers, pausedBy is set to the name of the
+// classifier deciding the subtask should be paused by the error.
+func (conn *DBConn) isRetryableError(err error, pausedBy *string) bool {
+	cls := conn.errClassifiers.Classify(conn.cfg.Name, err)
+	*pausedBy = ""
+	if cls.Class == retry.ErrClassPause {
+		*pausedBy = cls.Classifier
+	}
+	return cls.Class == retry.ErrClassRetryable
+}
+
+// conflictRetryCount returns the max retry count for write conflict errors
+func (conn *DBConn) conflictRetryCount() int {
+	if conn.cfg.ConflictRetryCount > 0 {
+		return conn.cfg.ConflictRetryCount
+	}
+	return maxRetryCount
+}
+
+// conflictRetryInterval returns the wait interval between two retries for write conflict errors
+func (conn *DBConn) conflictRetryInterval() time.Duration {
+	if conn.cfg.ConflictRetryInterval > 0 {
+		return time.Duration(conn.cfg.ConflictRetryInterval) * time.Second
+	}
+	return retryTimeout
+}
+
 func (conn *DBConn) executeSQL(tctx *tcontext.Context, queries []string, args ...[]interface{}) (int, error) {
 	return conn.executeSQLWithIgnore(tctx, nil, queries, args...)
 }
 
-func createConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbCfg config.DBConfig, count int) (*conn.BaseDB, []*DBConn, error) {
+func createConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbProvider conn.DBProvider, dbCfg config.DBConfig, count int) (*conn.BaseDB, []*DBConn, error) {
 	conns := make([]*DBConn, 0, count)
-	baseDB, err := createBaseDB(dbCfg)
+	baseDB, err := createBaseDB(dbProvider, dbCfg)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -309,7 +429,7 @@ func createConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbCfg config
 }
 
 func countBinaryLogsSize(fromFile mysql.Position, db *sql.DB) (int64, error) {
-	files, err := getBinaryLogs(db)
+	files, err := getBinaryLogs(context.Background(), db)
 	if err != nil {
 		return 0, err
 	}
@@ -330,9 +450,9 @@ func countBinaryLogsSize(fromFile mysql.Position, db *sql.DB) (int64, error) {
 	return total, nil
 }
 
-func getBinaryLogs(db *sql.DB) ([]binlogSize, error) {
+func getBinaryLogs(ctx context.Context, db *sql.DB) ([]binlogSize, error) {
 	query := "SHOW BINARY LOGS"
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, terror.DBErrorAdapt(err, terror.ErrDBDriverError)
 	}
@@ -17,15 +17,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	gouuid "github.com/google/uuid"
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/siddontang/go-mysql/mysql"
 	"github.com/siddontang/go-mysql/replication"
+	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/config"
@@ -33,6 +36,7 @@ import (
 	tcontext "github.com/pingcap/dm/pkg/context"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/retry"
+	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
 )
 
@@ -114,7 +118,7 @@ func (s *testDBSuite) TestGetServerID(c *C) {
 }
 
 func (s *testDBSuite) TestBinaryLogs(c *C) {
-	files, err := getBinaryLogs(s.db)
+	files, err := getBinaryLogs(context.Background(), s.db)
 	c.Assert(err, IsNil)
 	c.Assert(files, Not(HasLen), 0)
 
@@ -130,7 +134,7 @@ func (s *testDBSuite) TestBinaryLogs(c *C) {
 
 	_, err = s.db.Exec("FLUSH BINARY LOGS")
 	c.Assert(err, IsNil)
-	files, err = getBinaryLogs(s.db)
+	files, err = getBinaryLogs(context.Background(), s.db)
 	c.Assert(err, IsNil)
 	c.Assert(files, HasLen, fileNum+1)
 
@@ -184,6 +188,254 @@ func (s *testSyncerSuite) TestExecuteSQLSWithIgnore(c *C) {
 	c.Assert(mock.ExpectationsWereMet(), IsNil)
 }
 
+func (s *testSyncerSuite) TestExecuteSQLWriteConflictRetry(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(context.Background())
+	c.Assert(err, IsNil)
+	var (
+		retries       sync2.AtomicInt64
+		writeDuration sync2.AtomicInt64
+	)
+	conn := &DBConn{
+		baseConn: &conn.BaseConn{
+			DBConn:        dbConn,
+			RetryStrategy: &retry.FiniteRetryStrategy{},
+		},
+		cfg: &config.SubTaskConfig{
+			Name: "test",
+			SyncerConfig: config.SyncerConfig{
+				ConflictRetryCount:    1,
+				ConflictRetryInterval: 1,
+			},
+		},
+		conflictRetries: &retries,
+		writeDuration:   &writeDuration,
+	}
+
+	sqls := []string{"insert into t1 values (1)"}
+	tctx := tcontext.Background().WithLogger(log.With(zap.String("test", "TestExecuteSQLWriteConflictRetry")))
+
+	// succeed after retry
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillReturnError(newMysqlErr(errno.ErrWriteConflict, "Write conflict"))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	n, err := conn.executeSQL(tctx, sqls)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+	c.Assert(retries.Get(), Equals, int64(1))
+	// only the succeeded execution is counted
+	duration := writeDuration.Get()
+	c.Assert(duration, Greater, int64(0))
+
+	// retries exhausted
+	for i := 0; i < 2; i++ {
+		mock.ExpectBegin()
+		mock.ExpectExec("insert into t1 values").WillReturnError(newMysqlErr(errno.ErrWriteConflict, "Write conflict"))
+		mock.ExpectRollback()
+	}
+	_, err = conn.executeSQL(tctx, sqls)
+	c.Assert(terror.ErrSyncerWriteConflictExhausted.Equal(err), IsTrue)
+	c.Assert(retries.Get(), Equals, int64(2))
+	c.Assert(writeDuration.Get(), Equals, duration)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
+func (s *testSyncerSuite) TestExecuteSQLWithoutTxnRetry(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(context.Background())
+	c.Assert(err, IsNil)
+	var (
+		retries       sync2.AtomicInt64
+		writeDuration sync2.AtomicInt64
+	)
+	conn := &DBConn{
+		baseConn: &conn.BaseConn{
+			DBConn:        dbConn,
+			RetryStrategy: &retry.FiniteRetryStrategy{},
+		},
+		cfg: &config.SubTaskConfig{
+			Name: "test",
+			SyncerConfig: config.SyncerConfig{
+				ConflictRetryCount:    1,
+				ConflictRetryInterval: 1,
+			},
+		},
+		conflictRetries: &retries,
+		writeDuration:   &writeDuration,
+	}
+
+	sqls := []string{"insert into t1 values (1)", "insert into t1 values (2)"}
+	tctx := tcontext.Background().WithLogger(log.With(zap.String("test", "TestExecuteSQLWithoutTxnRetry")))
+
+	// the statement executed isn't executed again after retry
+	mock.ExpectExec("insert into t1 values \\(1\\)").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("insert into t1 values \\(2\\)").WillReturnError(newMysqlErr(errno.ErrWriteConflict, "Write conflict"))
+	mock.ExpectExec("insert into t1 values \\(2\\)").WillReturnResult(sqlmock.NewResult(1, 1))
+	n, err := conn.executeSQLWithoutTxn(tctx, sqls)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 2)
+	c.Assert(retries.Get(), Equals, int64(1))
+
+	// the number of statements executed is returned if failed
+	mock.ExpectExec("insert into t1 values \\(1\\)").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("insert into t1 values \\(2\\)").WillReturnError(newMysqlErr(errno.ErrDupEntry, "Duplicate entry '2' for key 'PRIMARY'"))
+	n, err = conn.executeSQLWithoutTxn(tctx, sqls)
+	c.Assert(err, NotNil)
+	c.Assert(n, Equals, 1)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
+type proxyErrClassifier struct{}
+
+func (proxyErrClassifier) Name() string {
+	return "proxy"
+}
+
+func (proxyErrClassifier) Classify(err error) retry.ErrorClass {
+	if strings.Contains(err.Error(), "proxy is restarting") {
+		return retry.ErrClassPause
+	}
+	return retry.ErrClassUndecided
+}
+
+func (s *testSyncerSuite) TestExecuteSQLErrorClassifier(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(context.Background())
+	c.Assert(err, IsNil)
+	classifiers := retry.NewClassifiers()
+	classifiers.Register(proxyErrClassifier{})
+	conn := &DBConn{
+		baseConn: &conn.BaseConn{
+			DBConn:        dbConn,
+			RetryStrategy: &retry.FiniteRetryStrategy{},
+		},
+		cfg:            &config.SubTaskConfig{Name: "test"},
+		errClassifiers: classifiers,
+	}
+	tctx := tcontext.Background().WithLogger(log.With(zap.String("test", "TestExecuteSQLErrorClassifier")))
+	sqls := []string{"insert into t1 values (1)"}
+
+	// decided by the registered classifier.
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values \\(1\\)").WillReturnError(newMysqlErr(1105, "proxy is restarting"))
+	mock.ExpectRollback()
+	_, err = conn.executeSQL(tctx, sqls)
+	c.Assert(terror.ErrDBPausedByClassifier.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*classifier proxy.*")
+
+	// decided by the default rules.
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values \\(1\\)").WillReturnError(newMysqlErr(errno.ErrDupEntry, "Duplicate entry '1' for key 'PRIMARY'"))
+	mock.ExpectRollback()
+	_, err = conn.executeSQL(tctx, sqls)
+	c.Assert(err, NotNil)
+	c.Assert(terror.ErrDBPausedByClassifier.Equal(err), IsFalse)
+
+	recent := classifiers.Recent()
+	c.Assert(recent, HasLen, 2)
+	c.Assert(recent[0].Classifier, Equals, "proxy")
+	c.Assert(recent[0].Class, Equals, retry.ErrClassPause)
+	c.Assert(recent[1].Classifier, Equals, retry.DefaultClassifierName)
+	c.Assert(recent[1].Class, Equals, retry.ErrClassPermanent)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
+func (s *testSyncerSuite) TestExecuteSQLTargetStats(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(context.Background())
+	c.Assert(err, IsNil)
+	syncer := &Syncer{}
+	stats := &syncer.targetStats
+	conn := &DBConn{
+		baseConn: &conn.BaseConn{
+			DBConn:        dbConn,
+			RetryStrategy: &retry.FiniteRetryStrategy{},
+		},
+		cfg: &config.SubTaskConfig{
+			Name: "test",
+			SyncerConfig: config.SyncerConfig{
+				ConflictRetryCount:    1,
+				ConflictRetryInterval: 1,
+			},
+		},
+		targetStats: stats,
+	}
+	sqls := []string{"insert into t1 values (1)", "insert into t1 values (2)"}
+
+	// succeed after retrying the deadlock
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillReturnError(newMysqlErr(errno.ErrLockDeadlock, "Deadlock found"))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("insert into t1 values").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	_, err = conn.executeSQL(tcontext.Background(), sqls)
+	c.Assert(err, IsNil)
+
+	// duplicate entry
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillReturnError(newMysqlErr(errno.ErrDupEntry, "Duplicate entry"))
+	mock.ExpectRollback()
+	_, err = conn.executeSQL(tcontext.Background(), sqls)
+	c.Assert(err, NotNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+
+	stats.record(1, newMysqlErr(errno.ErrLockWaitTimeout, "Lock wait timeout exceeded"))
+	stats.record(1, context.DeadlineExceeded)
+	stats.record(1, newMysqlErr(errno.ErrNoSuchTable, "Table doesn't exist"))
+	stats.record(1, context.Canceled) // not counted
+
+	c.Assert(syncer.TargetStats(), DeepEquals, TargetStats{
+		Succeeded:    2,
+		Failed:       5,
+		Deadlock:     1,
+		DuplicateKey: 1,
+		Timeout:      2,
+	})
+}
+
+func (s *testSyncerSuite) TestExecuteSQLActiveTxns(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(context.Background())
+	c.Assert(err, IsNil)
+	var activeTxns sync2.AtomicInt64
+	conn := &DBConn{
+		baseConn: &conn.BaseConn{
+			DBConn:        dbConn,
+			RetryStrategy: &retry.FiniteRetryStrategy{},
+		},
+		cfg:        &config.SubTaskConfig{Name: "test"},
+		activeTxns: &activeTxns,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into t1 values").WillDelayFor(500 * time.Millisecond).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	errCh := make(chan error, 1)
+	go func() {
+		_, err2 := conn.executeSQL(tcontext.Background(), []string{"insert into t1 values (1)"})
+		errCh <- err2
+	}()
+
+	c.Assert(utils.WaitSomething(50, 10*time.Millisecond, func() bool {
+		return activeTxns.Get() == 1
+	}), IsTrue)
+	c.Assert(<-errCh, IsNil)
+	c.Assert(activeTxns.Get(), Equals, int64(0))
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
 func (s *testDBSuite) TestTimezone(c *C) {
 	s.cfg.BAList = &filter.Rules{
 		DoDBs:     []string{"~^tztest_.*"},
@@ -0,0 +1,148 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/siddontang/go-mysql/replication"
+	"go.uber.org/zap"
+)
+
+// isBatchableDDL returns whether the DDL could be applied to downstream in a batch with other DDLs.
+// the checkpoint is only saved after the whole batch is applied, so the DDLs applied before a failure in the batch
+// are replayed after resuming, only the DDLs whose errors of replaying are ignored by `ignoreDDLError` are batchable.
+func isBatchableDDL(stmt ast.StmtNode) bool {
+	switch v := stmt.(type) {
+	case *ast.CreateDatabaseStmt, *ast.CreateTableStmt, *ast.CreateIndexStmt, *ast.DropIndexStmt:
+		return true
+	case *ast.AlterTableStmt:
+		for _, spec := range v.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns, ast.AlterTableDropColumn, ast.AlterTableDropIndex:
+			case ast.AlterTableAddConstraint:
+				switch spec.Constraint.Tp {
+				case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+				default:
+					return false
+				}
+			default:
+				return false
+			}
+		}
+		return len(v.Specs) > 0
+	default:
+		return false
+	}
+}
+
+// keepDDLBatch returns whether the event could be received without applying the pending DDL batch, all events other
+// than DDLs and the events without data changes apply the pending batch first to keep the order in downstream.
+func keepDDLBatch(e *replication.BinlogEvent) bool {
+	switch e.Event.(type) {
+	case *replication.QueryEvent, *replication.RotateEvent, *replication.FormatDescriptionEvent,
+		*replication.GTIDEvent, *replication.MariadbGTIDEvent, *replication.PreviousGTIDsEvent, *replication.MariadbGTIDListEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// ddlBatch holds the DDL events which are tracked but not applied to downstream yet, they're merged into one DDL job
+// and applied when the batch is full or an event which can't be batched is received.
+type ddlBatch struct {
+	sync.RWMutex
+	job   *job
+	count int // number of events in the batch
+}
+
+// add merges the DDL job of an event into the batch, and returns the number of events in the batch.
+func (b *ddlBatch) add(j *job) int {
+	b.Lock()
+	defer b.Unlock()
+	if b.job == nil {
+		b.job = j
+		b.count = 1
+		return b.count
+	}
+	b.job.ddls = append(b.job.ddls, j.ddls...)
+	b.job.location = j.location
+	b.job.currentLocation = j.currentLocation
+	b.job.originSQL = strings.Join([]string{b.job.originSQL, j.originSQL}, "; ")
+	if b.job.sourceTbl == nil {
+		b.job.sourceTbl = make(map[string][]string, len(j.sourceTbl))
+	}
+	for schema, tables := range j.sourceTbl {
+		b.job.sourceTbl[schema] = append(b.job.sourceTbl[schema], tables...)
+	}
+	b.count++
+	return b.count
+}
+
+// take removes the merged DDL job from the batch and returns it, it returns nil if the batch is empty.
+func (b *ddlBatch) take() *job {
+	b.Lock()
+	defer b.Unlock()
+	j := b.job
+	b.job = nil
+	b.count = 0
+	return j
+}
+
+// len returns the number of events in the batch.
+func (b *ddlBatch) len() int {
+	b.RLock()
+	defer b.RUnlock()
+	return b.count
+}
+
+// pending returns the DDLs in the batch.
+func (b *ddlBatch) pending() []string {
+	b.RLock()
+	defer b.RUnlock()
+	if b.job == nil {
+		return nil
+	}
+	return append([]string(nil), b.job.ddls...)
+}
+
+// flushDDLBatch applies the pending DDL batch to downstream. like applying a single DDL event, the error of executing
+// the DDLs is set to `s.execError` and reported by `runFatalChan`.
+func (s *Syncer) flushDDLBatch() error {
+	j := s.ddlBatch.take()
+	if j == nil {
+		return nil
+	}
+	s.tctx.L().Info("apply DDL batch", zap.Strings("ddls", j.ddls), zap.Stringer("start location", j.startLocation), zap.Stringer("end location", j.currentLocation))
+	return s.addJobFunc(j)
+}
+
+// DDLBatchSize returns the current max number of DDL events applied to downstream as one batch.
+func (s *Syncer) DDLBatchSize() int {
+	return int(s.ddlBatchSize.Get())
+}
+
+// SetDDLBatchSize changes the max number of DDL events applied to downstream as one batch at runtime, it takes effect
+// from the next DDL event. the change is not persisted and will be reset to `ddl-batch-size` in config when the config
+// is updated or the subtask restarts.
+func (s *Syncer) SetDDLBatchSize(n int) {
+	s.ddlBatchSize.Set(int32(n))
+}
+
+// DDLBacklog returns the DDLs tracked but not applied to downstream yet because they're waiting in a batch.
+func (s *Syncer) DDLBacklog() []string {
+	return s.ddlBatch.pending()
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/pkg/binlog"
+)
+
+var _ = Suite(&testDDLBatchSuite{})
+
+type testDDLBatchSuite struct{}
+
+func (t *testDDLBatchSuite) TestIsBatchableDDL(c *C) {
+	cases := []struct {
+		sql       string
+		batchable bool
+	}{
+		{"CREATE DATABASE db", true},
+		{"CREATE TABLE db.tb (id INT PRIMARY KEY)", true},
+		{"CREATE INDEX idx ON db.tb (c)", true},
+		{"DROP INDEX idx ON db.tb", true},
+		{"ALTER TABLE db.tb ADD COLUMN c INT", true},
+		{"ALTER TABLE db.tb ADD UNIQUE KEY uk (c), DROP COLUMN d", true},
+		{"ALTER TABLE db.tb ADD PRIMARY KEY (c)", false},
+		{"ALTER TABLE db.tb MODIFY COLUMN c BIGINT", false},
+		{"ALTER TABLE db.tb RENAME TO db.tb2", false},
+		{"RENAME TABLE db.tb TO db.tb2", false},
+		{"TRUNCATE TABLE db.tb", false},
+		{"DROP TABLE db.tb", false},
+		{"DROP DATABASE db", false},
+	}
+	p := parser.New()
+	for _, cs := range cases {
+		stmt, err := p.ParseOneStmt(cs.sql, "", "")
+		c.Assert(err, IsNil)
+		c.Assert(isBatchableDDL(stmt), Equals, cs.batchable, Commentf("sql: %s", cs.sql))
+	}
+}
+
+func (t *testDDLBatchSuite) TestKeepDDLBatch(c *C) {
+	c.Assert(keepDDLBatch(&replication.BinlogEvent{Event: &replication.QueryEvent{}}), IsTrue)
+	c.Assert(keepDDLBatch(&replication.BinlogEvent{Event: &replication.GTIDEvent{}}), IsTrue)
+	c.Assert(keepDDLBatch(&replication.BinlogEvent{Event: &replication.RowsEvent{}}), IsFalse)
+	c.Assert(keepDDLBatch(&replication.BinlogEvent{Event: &replication.XIDEvent{}}), IsFalse)
+	c.Assert(keepDDLBatch(&replication.BinlogEvent{Event: &replication.GenericEvent{}}), IsFalse)
+}
+
+func (t *testDDLBatchSuite) TestDDLBatch(c *C) {
+	var b ddlBatch
+	c.Assert(b.take(), IsNil)
+	c.Assert(b.pending(), IsNil)
+	c.Assert(b.len(), Equals, 0)
+
+	loc := func(pos uint32) binlog.Location {
+		location := binlog.NewLocation(mysql.MySQLFlavor)
+		location.Position = mysql.Position{Name: "mysql-bin.000001", Pos: pos}
+		return location
+	}
+	j1 := newDDLJob(nil, []string{"CREATE TABLE `db`.`tb1` (`id` INT)"}, loc(200), loc(100), loc(200),
+		map[string]map[string]struct{}{"db": {"tb1": {}}}, "CREATE TABLE tb1 (id INT)")
+	j2 := newDDLJob(nil, []string{"CREATE TABLE `db`.`tb2` (`id` INT)", "CREATE INDEX `idx` ON `db`.`tb2` (`id`)"}, loc(400), loc(300), loc(400),
+		map[string]map[string]struct{}{"db": {"tb2": {}}}, "CREATE TABLE tb2 (id INT); CREATE INDEX idx ON tb2 (id)")
+
+	c.Assert(b.add(j1), Equals, 1)
+	c.Assert(b.add(j2), Equals, 2)
+	c.Assert(b.len(), Equals, 2)
+	c.Assert(b.pending(), DeepEquals, []string{"CREATE TABLE `db`.`tb1` (`id` INT)", "CREATE TABLE `db`.`tb2` (`id` INT)", "CREATE INDEX `idx` ON `db`.`tb2` (`id`)"})
+
+	// the merged job starts at the first event and ends at the last event.
+	j := b.take()
+	c.Assert(j, NotNil)
+	c.Assert(j.tp, Equals, ddl)
+	c.Assert(j.ddls, HasLen, 3)
+	c.Assert(j.startLocation.Position.Pos, Equals, uint32(100))
+	c.Assert(j.currentLocation.Position.Pos, Equals, uint32(400))
+	c.Assert(j.location.Position.Pos, Equals, uint32(400))
+	c.Assert(j.sourceTbl, DeepEquals, map[string][]string{"db": {"tb1", "tb2"}})
+	c.Assert(j.originSQL, Equals, "CREATE TABLE tb1 (id INT); CREATE TABLE tb2 (id INT); CREATE INDEX idx ON tb2 (id)")
+
+	c.Assert(b.take(), IsNil)
+	c.Assert(b.len(), Equals, 0)
+}
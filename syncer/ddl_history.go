@@ -0,0 +1,78 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sync"
+	"time"
+)
+
+// maxDDLRecords is the max number of DDLs applied to downstream kept in memory.
+const maxDDLRecords = 200
+
+// DDLRecord represents the DDLs of a binlog event applied to downstream.
+type DDLRecord struct {
+	// OriginDDL is the DDL in upstream, DDLs are the statements executed in downstream after routed and split.
+	OriginDDL string   `json:"origin-ddl"`
+	DDLs      []string `json:"ddls"`
+	// StartLocation and EndLocation are the upstream locations of the DDL event.
+	StartLocation string    `json:"start-location"`
+	EndLocation   string    `json:"end-location"`
+	Time          time.Time `json:"time"`
+}
+
+// ddlHistory records the recent DDLs applied to downstream, it's safe for concurrent use.
+type ddlHistory struct {
+	sync.RWMutex
+	records []DDLRecord
+}
+
+func (h *ddlHistory) record(r DDLRecord) {
+	h.Lock()
+	defer h.Unlock()
+	h.records = append(h.records, r)
+	if len(h.records) > maxDDLRecords {
+		h.records = h.records[len(h.records)-maxDDLRecords:]
+	}
+}
+
+// recent returns at most limit recent records from the oldest to the newest, all records are returned if limit <= 0.
+func (h *ddlHistory) recent(limit int) []DDLRecord {
+	h.RLock()
+	defer h.RUnlock()
+	records := h.records
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	ret := make([]DDLRecord, len(records))
+	copy(ret, records)
+	return ret
+}
+
+// recordDDL records the DDLs of the job after they're applied to downstream.
+func (s *Syncer) recordDDL(j *job) {
+	s.ddlHistory.record(DDLRecord{
+		OriginDDL:     j.originSQL,
+		DDLs:          append([]string(nil), j.ddls...),
+		StartLocation: j.startLocation.String(),
+		EndLocation:   j.currentLocation.String(),
+		Time:          time.Now(),
+	})
+}
+
+// DDLHistory returns at most limit recent DDLs applied to downstream from the oldest to the newest, all recorded DDLs
+// are returned if limit <= 0. the history is only kept in memory, and is lost when the subtask restarts.
+func (s *Syncer) DDLHistory(limit int) []DDLRecord {
+	return s.ddlHistory.recent(limit)
+}
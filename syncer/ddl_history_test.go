@@ -0,0 +1,57 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+)
+
+var _ = Suite(&testDDLHistorySuite{})
+
+type testDDLHistorySuite struct{}
+
+func (t *testDDLHistorySuite) TestDDLHistory(c *C) {
+	s := &Syncer{}
+	c.Assert(s.DDLHistory(10), HasLen, 0)
+
+	for i := 0; i < maxDDLRecords+10; i++ {
+		s.recordDDL(&job{
+			originSQL:       fmt.Sprintf("ALTER TABLE t ADD COLUMN c%d INT", i),
+			ddls:            []string{fmt.Sprintf("ALTER TABLE `db`.`t` ADD COLUMN `c%d` INT", i)},
+			startLocation:   binlog.Location{Position: mysql.Position{Name: "mysql-bin.000001", Pos: uint32(i * 100)}},
+			currentLocation: binlog.Location{Position: mysql.Position{Name: "mysql-bin.000001", Pos: uint32(i*100 + 50)}},
+		})
+	}
+
+	// bounded, from the oldest to the newest.
+	records := s.DDLHistory(0)
+	c.Assert(records, HasLen, maxDDLRecords)
+	c.Assert(records[0].OriginDDL, Equals, "ALTER TABLE t ADD COLUMN c10 INT")
+	c.Assert(records[maxDDLRecords-1].DDLs, DeepEquals, []string{fmt.Sprintf("ALTER TABLE `db`.`t` ADD COLUMN `c%d` INT", maxDDLRecords+9)})
+
+	records = s.DDLHistory(2)
+	c.Assert(records, HasLen, 2)
+	c.Assert(records[1].OriginDDL, Equals, fmt.Sprintf("ALTER TABLE t ADD COLUMN c%d INT", maxDDLRecords+9))
+	c.Assert(records[1].StartLocation, Matches, ".*mysql-bin.000001.*")
+	c.Assert(records[1].Time.IsZero(), IsFalse)
+
+	// the returned records are copied.
+	records[1].OriginDDL = ""
+	c.Assert(s.DDLHistory(1)[0].OriginDDL, Not(Equals), "")
+}
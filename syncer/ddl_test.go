@@ -393,7 +393,7 @@ func (s *testSyncerSuite) TestResolveOnlineDDL(c *C) {
 
 	for _, ca := range cases {
 		fn := OnlineDDLSchemes[ca.onlineType]
-		plugin, err := fn(tctx, s.cfg)
+		plugin, err := fn(tctx, s.cfg, nil)
 		c.Assert(err, IsNil)
 		syncer := NewSyncer(s.cfg, nil)
 		syncer.onlineDDL = plugin
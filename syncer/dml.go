@@ -505,6 +505,9 @@ func genWhere(buf *strings.Builder, columns []*model.ColumnInfo, data []interfac
 }
 
 func (s *Syncer) mappingDML(schema, table string, ti *model.TableInfo, data [][]interface{}) ([][]interface{}, error) {
+	if s.columnTransformer != nil {
+		data = s.columnTransformer.transformRows(schema, table, ti, data)
+	}
 	if s.columnMapping == nil {
 		return data, nil
 	}
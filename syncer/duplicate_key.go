@@ -0,0 +1,152 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// maxDuplicateKeyRecords is the max number of handled duplicate keys kept in memory.
+const maxDuplicateKeyRecords = 100
+
+// actions taken on duplicate keys.
+const (
+	DuplicateKeySkipped     = "skipped"
+	DuplicateKeyOverwritten = "overwritten"
+)
+
+// the duplicate entry error of downstream, like "Duplicate entry '1' for key 'PRIMARY'".
+var dupEntryRegex = regexp.MustCompile(`Duplicate entry '(.*)' for key '([^']*)'`)
+
+// DuplicateKeyRecord represents a duplicate key of a DML handled by `duplicate-key-policy`.
+type DuplicateKeyRecord struct {
+	Table string `json:"table"`
+	// Key is the name of the duplicate key and Entry is its value, reported by downstream.
+	Key       string    `json:"key"`
+	Entry     string    `json:"entry"`
+	Action    string    `json:"action"`
+	Statement string    `json:"statement"`
+	Args      string    `json:"args"`
+	Location  string    `json:"location"`
+	Time      time.Time `json:"time"`
+}
+
+// DuplicateKeyStatus represents the duplicate keys handled by `duplicate-key-policy`.
+type DuplicateKeyStatus struct {
+	Policy      string `json:"policy"`
+	Skipped     int64  `json:"skipped"`
+	Overwritten int64  `json:"overwritten"`
+	// Records are the recent handled duplicate keys, from the oldest to the newest.
+	Records []DuplicateKeyRecord `json:"records"`
+}
+
+// duplicateKeys records the duplicate keys handled by `duplicate-key-policy`, it's safe for concurrent use.
+type duplicateKeys struct {
+	sync.RWMutex
+	skipped     int64
+	overwritten int64
+	records     []DuplicateKeyRecord
+}
+
+func (d *duplicateKeys) record(r DuplicateKeyRecord) {
+	d.Lock()
+	defer d.Unlock()
+	if r.Action == DuplicateKeySkipped {
+		d.skipped++
+	} else {
+		d.overwritten++
+	}
+	d.records = append(d.records, r)
+	if len(d.records) > maxDuplicateKeyRecords {
+		d.records = d.records[len(d.records)-maxDuplicateKeyRecords:]
+	}
+}
+
+// failedOnCommit returns whether the error of executing a transaction is returned by committing it, the failed
+// statement can't be known then.
+func failedOnCommit(err error) bool {
+	terr, ok := err.(*terror.Error)
+	return ok && terr.Code() == terror.ErrDBExecuteFailed.Code() && strings.HasSuffix(terr.Message(), ": commit")
+}
+
+// handleDuplicateKey handles the duplicate-key error of the affected job in the failed transaction of jobs according to
+// `duplicate-key-policy`, it returns the jobs to retry and whether the error is handled. the affected job is removed
+// for `skip`, and the INSERT of it is changed to REPLACE for `overwrite`, the other DMLs are not handled.
+func (s *Syncer) handleDuplicateKey(tctx *tcontext.Context, jobs []*job, affected int, err error) ([]*job, bool) {
+	policy := s.cfg.DuplicateKeyPolicy
+	if policy != config.DuplicateKeySkip && policy != config.DuplicateKeyOverwrite {
+		return jobs, false
+	}
+	if !isDupEntryError(err) || failedOnCommit(err) || affected < 0 || affected >= len(jobs) {
+		return jobs, false
+	}
+	j := jobs[affected]
+	r := DuplicateKeyRecord{
+		Table:     dbutil.TableName(j.targetSchema, j.targetTable),
+		Statement: j.sql,
+		Args:      utils.TruncateInterface(j.args, -1),
+		Location:  j.currentLocation.String(),
+		Time:      time.Now(),
+	}
+	if mysqlErr, ok := errors.Cause(err).(*mysql.MySQLError); ok {
+		if m := dupEntryRegex.FindStringSubmatch(mysqlErr.Message); m != nil {
+			r.Entry, r.Key = m[1], m[2]
+		}
+	}
+
+	if policy == config.DuplicateKeySkip {
+		r.Action = DuplicateKeySkipped
+		jobs = append(jobs[:affected], jobs[affected+1:]...)
+	} else {
+		if j.tp != insert || !strings.HasPrefix(j.sql, "INSERT INTO") {
+			return jobs, false
+		}
+		r.Action = DuplicateKeyOverwritten
+		j.sql = "REPLACE INTO" + strings.TrimPrefix(j.sql, "INSERT INTO")
+	}
+	s.duplicateKeys.record(r)
+	tctx.L().Warn("handle duplicate key by duplicate-key-policy", zap.String("action", r.Action),
+		zap.String("table", r.Table), zap.String("key", r.Key), zap.String("entry", r.Entry),
+		zap.String("statement", utils.TruncateString(r.Statement, -1)), zap.String("arguments", r.Args),
+		zap.Stringer("location", j.currentLocation), log.ShortError(err))
+	return jobs, true
+}
+
+// DuplicateKeyStatus returns the duplicate keys handled by `duplicate-key-policy`.
+func (s *Syncer) DuplicateKeyStatus() DuplicateKeyStatus {
+	s.duplicateKeys.RLock()
+	defer s.duplicateKeys.RUnlock()
+	status := DuplicateKeyStatus{
+		Policy:      s.cfg.DuplicateKeyPolicy,
+		Skipped:     s.duplicateKeys.skipped,
+		Overwritten: s.duplicateKeys.overwritten,
+		Records:     make([]DuplicateKeyRecord, len(s.duplicateKeys.records)),
+	}
+	copy(status.Records, s.duplicateKeys.records)
+	return status
+}
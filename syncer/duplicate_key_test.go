@@ -0,0 +1,108 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/go-sql-driver/mysql"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/errno"
+
+	"github.com/pingcap/dm/dm/config"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testDuplicateKeySuite{})
+
+type testDuplicateKeySuite struct{}
+
+func (t *testDuplicateKeySuite) TestHandleDuplicateKey(c *C) {
+	tctx := tcontext.Background()
+	cfg := &config.SubTaskConfig{Name: "test-duplicate-key"}
+	cfg.DuplicateKeyPolicy = config.DuplicateKeyPause
+	syncer := NewSyncer(cfg, nil)
+
+	genJobs := func() []*job {
+		return []*job{
+			{tp: insert, targetSchema: "db", targetTable: "tbl", sql: "INSERT INTO `db`.`tbl` (`id`) VALUES (?)", args: []interface{}{1}},
+			{tp: insert, targetSchema: "db", targetTable: "tbl", sql: "INSERT INTO `db`.`tbl` (`id`) VALUES (?)", args: []interface{}{2}},
+			{tp: update, targetSchema: "db", targetTable: "tbl", sql: "UPDATE `db`.`tbl` SET `id` = ? WHERE `id` = ? LIMIT 1", args: []interface{}{2, 3}},
+		}
+	}
+	dupErr := &mysql.MySQLError{Number: errno.ErrDupEntry, Message: "Duplicate entry '2' for key 'PRIMARY'"}
+	execErr := terror.ErrDBExecuteFailed.Delegate(dupErr, "INSERT INTO `db`.`tbl` (`id`) VALUES (?)")
+
+	// pause.
+	jobs := genJobs()
+	remained, handled := syncer.handleDuplicateKey(tctx, jobs, 1, execErr)
+	c.Assert(handled, IsFalse)
+	c.Assert(remained, HasLen, 3)
+
+	// skip.
+	syncer.cfg.DuplicateKeyPolicy = config.DuplicateKeySkip
+	for _, err := range []error{
+		terror.ErrDBExecuteFailed.Delegate(&mysql.MySQLError{Number: errno.ErrLockDeadlock}, "INSERT"),
+		terror.ErrDBExecuteFailed.Delegate(dupErr, "commit"),
+		errors.New("other error"),
+	} {
+		_, handled = syncer.handleDuplicateKey(tctx, genJobs(), 1, err)
+		c.Assert(handled, IsFalse)
+	}
+	remained, handled = syncer.handleDuplicateKey(tctx, genJobs(), 1, execErr)
+	c.Assert(handled, IsTrue)
+	c.Assert(remained, HasLen, 2)
+	c.Assert(remained[0].args, DeepEquals, []interface{}{1})
+	c.Assert(remained[1].tp, Equals, update)
+
+	status := syncer.DuplicateKeyStatus()
+	c.Assert(status.Policy, Equals, config.DuplicateKeySkip)
+	c.Assert(status.Skipped, Equals, int64(1))
+	c.Assert(status.Overwritten, Equals, int64(0))
+	c.Assert(status.Records, HasLen, 1)
+	r := status.Records[0]
+	c.Assert(r.Table, Equals, "`db`.`tbl`")
+	c.Assert(r.Key, Equals, "PRIMARY")
+	c.Assert(r.Entry, Equals, "2")
+	c.Assert(r.Action, Equals, DuplicateKeySkipped)
+	c.Assert(r.Statement, Equals, "INSERT INTO `db`.`tbl` (`id`) VALUES (?)")
+	c.Assert(r.Args, Equals, "[2]")
+
+	// overwrite, only INSERTs are overwritten.
+	syncer.cfg.DuplicateKeyPolicy = config.DuplicateKeyOverwrite
+	jobs = genJobs()
+	remained, handled = syncer.handleDuplicateKey(tctx, jobs, 2, execErr)
+	c.Assert(handled, IsFalse)
+	remained, handled = syncer.handleDuplicateKey(tctx, jobs, 1, execErr)
+	c.Assert(handled, IsTrue)
+	c.Assert(remained, HasLen, 3)
+	c.Assert(remained[1].sql, Equals, "REPLACE INTO `db`.`tbl` (`id`) VALUES (?)")
+	// a REPLACE is not overwritten again.
+	_, handled = syncer.handleDuplicateKey(tctx, remained, 1, execErr)
+	c.Assert(handled, IsFalse)
+
+	status = syncer.DuplicateKeyStatus()
+	c.Assert(status.Skipped, Equals, int64(1))
+	c.Assert(status.Overwritten, Equals, int64(1))
+	c.Assert(status.Records, HasLen, 2)
+	c.Assert(status.Records[1].Action, Equals, DuplicateKeyOverwritten)
+
+	// only the recent ones are kept.
+	for i := 0; i < maxDuplicateKeyRecords; i++ {
+		syncer.duplicateKeys.record(DuplicateKeyRecord{Action: DuplicateKeySkipped})
+	}
+	status = syncer.DuplicateKeyStatus()
+	c.Assert(status.Skipped, Equals, int64(maxDuplicateKeyRecords+1))
+	c.Assert(status.Records, HasLen, maxDuplicateKeyRecords)
+}
@@ -14,8 +14,10 @@
 package syncer
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
@@ -65,6 +67,46 @@ func isDropColumnWithIndexError(err error) bool {
 		strings.Contains(mysqlErr.Message, "with index")
 }
 
+// isWriteConflictError checks whether the error is caused by write conflict in downstream,
+// these errors are retried with the conflict retry policy of the subtask.
+func isWriteConflictError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*mysql.MySQLError)
+	if !ok {
+		return false
+	}
+	switch mysqlErr.Number {
+	case errno.ErrWriteConflict, errno.ErrWriteConflictInTiDB, errno.ErrLockDeadlock:
+		return true
+	}
+	return false
+}
+
+// isDeadlockError checks whether the error is a deadlock detected by downstream.
+func isDeadlockError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*mysql.MySQLError)
+	return ok && mysqlErr.Number == errno.ErrLockDeadlock
+}
+
+// isDupEntryError checks whether the error is caused by a duplicate entry for a PK or UK in downstream.
+func isDupEntryError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*mysql.MySQLError)
+	return ok && mysqlErr.Number == errno.ErrDupEntry
+}
+
+// isTimeoutError checks whether the error is caused by timeout, either waiting for locks in downstream or
+// reading from/writing to the network.
+func isTimeoutError(err error) bool {
+	err = errors.Cause(err)
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok {
+		return mysqlErr.Number == errno.ErrLockWaitTimeout
+	}
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
 // handleSpecialDDLError handles special errors for DDL execution.
 func (s *Syncer) handleSpecialDDLError(tctx *tcontext.Context, err error, ddls []string, index int, conn *DBConn) error {
 	// We use default parser because ddls are came from *Syncer.handleDDL, which is StringSingleQuotes, KeyWordUppercase and NameBackQuotes
@@ -0,0 +1,121 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// eventRateInterval is the interval of sampling the rate of events processed.
+	eventRateInterval = 5 * time.Second
+	// maxEventRateSamples is the max number of samples kept, it's one hour by eventRateInterval.
+	maxEventRateSamples = 720
+)
+
+// RateSample is the rate of events processed in the interval ending at Time.
+type RateSample struct {
+	Time            time.Time `json:"time"`
+	EventsPerSecond float64   `json:"events-per-second"`
+}
+
+// eventRates keeps the recent samples of the rate of events processed in a fixed-size ring buffer,
+// it's safe for concurrent use.
+type eventRates struct {
+	sync.RWMutex
+	samples []RateSample
+	next    int // the index to write the next sample
+	full    bool
+
+	lastCount int64
+	lastTime  time.Time
+}
+
+func newEventRates(size int) *eventRates {
+	return &eventRates{samples: make([]RateSample, size)}
+}
+
+// start records the total count of events processed at t as the start of the next sample, without a sample of the
+// rate since the last call, e.g. when the syncer was paused.
+func (r *eventRates) start(count int64, t time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	r.lastCount, r.lastTime = count, t
+}
+
+// record records the sample of the rate since the last call by the total count of events processed at t.
+// it only records the count as the start of the next sample if not started or the count is reset.
+func (r *eventRates) record(count int64, t time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	lastCount, lastTime := r.lastCount, r.lastTime
+	r.lastCount, r.lastTime = count, t
+	if lastTime.IsZero() || count < lastCount || !t.After(lastTime) {
+		return
+	}
+
+	r.samples[r.next] = RateSample{Time: t, EventsPerSecond: float64(count-lastCount) / t.Sub(lastTime).Seconds()}
+	r.next++
+	if r.next == len(r.samples) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// since returns the samples after t, the earliest one is the first.
+func (r *eventRates) since(t time.Time) []RateSample {
+	r.RLock()
+	defer r.RUnlock()
+	samples := make([]RateSample, 0, len(r.samples))
+	if r.full {
+		samples = append(samples, r.samples[r.next:]...)
+	}
+	samples = append(samples, r.samples[:r.next]...)
+
+	for i, sample := range samples {
+		if sample.Time.After(t) {
+			return samples[i:]
+		}
+	}
+	return samples[:0]
+}
+
+// sampleEventRate samples the rate of events processed until ctx is done.
+func (s *Syncer) sampleEventRate(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(eventRateInterval)
+	defer ticker.Stop()
+	s.eventRates.start(s.count.Get(), time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.eventRates.record(s.count.Get(), now)
+		}
+	}
+}
+
+// EventRateHistory returns the rates of events processed sampled in the recent window, the earliest one is the first.
+// all samples kept are returned if window is not positive.
+func (s *Syncer) EventRateHistory(window time.Duration) []RateSample {
+	var since time.Time
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+	return s.eventRates.since(since)
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testEventRateSuite{})
+
+type testEventRateSuite struct{}
+
+func (t *testEventRateSuite) TestEventRates(c *C) {
+	r := newEventRates(3)
+	base := time.Now()
+	at := func(seconds int) time.Time {
+		return base.Add(time.Duration(seconds) * time.Second)
+	}
+
+	// not started.
+	r.record(100, at(0))
+	c.Assert(r.since(time.Time{}), HasLen, 0)
+
+	r.record(150, at(5))
+	r.record(150, at(10))
+	c.Assert(r.since(time.Time{}), DeepEquals, []RateSample{
+		{Time: at(5), EventsPerSecond: 10},
+		{Time: at(10), EventsPerSecond: 0},
+	})
+
+	// the count is reset.
+	r.record(20, at(15))
+	c.Assert(r.since(time.Time{}), HasLen, 2)
+
+	// restarted after paused, the ring buffer overwrites the oldest samples.
+	r.start(1000, at(100))
+	r.record(1100, at(110))
+	r.record(1400, at(115))
+	c.Assert(r.since(time.Time{}), DeepEquals, []RateSample{
+		{Time: at(10), EventsPerSecond: 0},
+		{Time: at(110), EventsPerSecond: 10},
+		{Time: at(115), EventsPerSecond: 60},
+	})
+	c.Assert(r.since(at(10)), DeepEquals, []RateSample{
+		{Time: at(110), EventsPerSecond: 10},
+		{Time: at(115), EventsPerSecond: 60},
+	})
+	c.Assert(r.since(at(115)), HasLen, 0)
+}
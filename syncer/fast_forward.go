@@ -0,0 +1,73 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// FastForward advances the global checkpoint of the paused syncer to the current location of upstream master and
+// flushes it, so events between them are SKIPPED after resumed. it's used when the data of these events has been
+// restored to downstream in other ways, e.g. re-dumped out-of-band after a long pause.
+// the checkpoint persisted in downstream is validated before and after advancing, to avoid a silent gap caused by
+// checkpoints changed by others.
+func (s *Syncer) FastForward(ctx context.Context) (from, to binlog.Location, err error) {
+	from = s.checkpoint.FlushedGlobalPoint()
+	if ddls := s.PendingShardDDLs(); len(ddls) > 0 {
+		return from, to, terror.ErrSyncerInvalidFastForward.Generate(from, to, "there are pending sharding DDLs")
+	}
+
+	tctx := s.tctx.WithContext(ctx)
+	persisted, err := s.checkpoint.PersistedGlobalPoint(tctx)
+	if err != nil {
+		return from, to, err
+	}
+	if binlog.CompareLocation(persisted, from, s.enableGTID()) != 0 {
+		return from, to, terror.ErrSyncerInvalidFastForward.Generate(from, to, "the checkpoint in downstream "+persisted.String()+" is different from the flushed checkpoint")
+	}
+
+	pos, gs, err := s.getMasterStatus(ctx)
+	if err != nil {
+		return from, to, err
+	}
+	to = binlog.InitLocation(pos, gs)
+	if binlog.CompareLocation(to, from, s.enableGTID()) < 0 {
+		return from, to, terror.ErrSyncerInvalidFastForward.Generate(from, to, "the location of upstream master is earlier than the checkpoint")
+	}
+	if s.enableGTID() && from.GetGTID() != nil && gs != nil && !gs.Contain(from.GetGTID()) {
+		return from, to, terror.ErrSyncerInvalidFastForward.Generate(from, to, "the GTID sets of upstream master don't contain the checkpoint")
+	}
+
+	s.tctx.L().Warn("fast-forward the checkpoint to the location of upstream master, events between them are SKIPPED and not replicated to downstream",
+		zap.Stringer("from", from), zap.Stringer("to", to))
+	s.checkpoint.SaveGlobalPoint(to)
+	if err = s.checkpoint.FlushPointsExcept(tctx, nil, nil, nil); err != nil {
+		s.checkpoint.Rollback(s.schemaTracker)
+		return from, to, err
+	}
+
+	persisted, err = s.checkpoint.PersistedGlobalPoint(tctx)
+	if err != nil {
+		return from, to, err
+	}
+	if binlog.CompareLocation(persisted, to, s.enableGTID()) != 0 {
+		return from, to, terror.ErrSyncerInvalidFastForward.Generate(from, to, "the checkpoint in downstream "+persisted.String()+" is not updated")
+	}
+	return from, to, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/retry"
+)
+
+func (s *testSyncerSuite) TestFastForward(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	checkPointDB, checkPointMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	checkPointDBConn, err := checkPointDB.Conn(context.Background())
+	c.Assert(err, IsNil)
+
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.EnableGTID = false
+	syncer := NewSyncer(cfg, nil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	syncer.checkpoint.(*RemoteCheckPoint).dbConn = &DBConn{cfg: cfg, baseConn: conn.NewBaseConn(checkPointDBConn, &retry.FiniteRetryStrategy{})}
+
+	gs, err := gtid.ParserGTID(cfg.Flavor, "")
+	c.Assert(err, IsNil)
+	loc1 := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 1234}, gs)
+	loc2 := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000003", Pos: 4567}, gs)
+	checkPointMock.ExpectBegin()
+	checkPointMock.ExpectExec("INSERT INTO .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	checkPointMock.ExpectCommit()
+	syncer.checkpoint.SaveGlobalPoint(loc1)
+	c.Assert(syncer.checkpoint.FlushPointsExcept(tcontext.Background(), nil, nil, nil), IsNil)
+
+	mockPersisted := func(loc binlog.Location) {
+		checkPointMock.ExpectQuery("SELECT binlog_name, binlog_pos, binlog_gtid FROM .*").WillReturnRows(
+			sqlmock.NewRows([]string{"binlog_name", "binlog_pos", "binlog_gtid"}).AddRow(loc.Position.Name, loc.Position.Pos, ""))
+	}
+	mockMasterStatus := func(loc binlog.Location) {
+		mock.ExpectQuery("SHOW MASTER STATUS").WillReturnRows(
+			sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}).AddRow(loc.Position.Name, loc.Position.Pos, "", "", ""))
+	}
+	ctx := context.Background()
+
+	// checkpoint in downstream is changed by others.
+	mockPersisted(loc2)
+	_, _, err = syncer.FastForward(ctx)
+	c.Assert(err, ErrorMatches, ".*is different from the flushed checkpoint.*")
+
+	// upstream master is earlier.
+	mockPersisted(loc1)
+	mockMasterStatus(binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 4}, gs))
+	_, _, err = syncer.FastForward(ctx)
+	c.Assert(err, ErrorMatches, ".*earlier than the checkpoint.*")
+	c.Assert(syncer.checkpoint.GlobalPoint().Position, DeepEquals, loc1.Position)
+
+	// fast-forwarded.
+	mockPersisted(loc1)
+	mockMasterStatus(loc2)
+	checkPointMock.ExpectBegin()
+	checkPointMock.ExpectExec("INSERT INTO .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	checkPointMock.ExpectCommit()
+	mockPersisted(loc2)
+	from, to, err := syncer.FastForward(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(from.Position, DeepEquals, loc1.Position)
+	c.Assert(to.Position, DeepEquals, loc2.Position)
+	c.Assert(syncer.checkpoint.GlobalPoint().Position, DeepEquals, loc2.Position)
+	c.Assert(syncer.checkpoint.FlushedGlobalPoint().Position, DeepEquals, loc2.Position)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+	c.Assert(checkPointMock.ExpectationsWereMet(), IsNil)
+}
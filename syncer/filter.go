@@ -56,6 +56,7 @@ func (s *Syncer) skipQuery(tables []*filter.Table, stmt ast.StmtNode, sql string
 		}
 
 		if action == bf.Ignore {
+			s.filterStats.record(s.binlogFilter, s.cfg.CaseSensitive, "", "", et, sql)
 			return true, nil
 		}
 	}
@@ -67,6 +68,7 @@ func (s *Syncer) skipQuery(tables []*filter.Table, stmt ast.StmtNode, sql string
 		}
 
 		if action == bf.Ignore {
+			s.filterStats.record(s.binlogFilter, s.cfg.CaseSensitive, table.Schema, table.Name, et, sql)
 			return true, nil
 		}
 	}
@@ -113,5 +115,9 @@ func (s *Syncer) skipDMLEvent(schema string, table string, eventType replication
 		return false, terror.Annotatef(terror.ErrSyncerUnitBinlogEventFilter.New(err.Error()), "skip row event %s on `%s`.`%s`", eventType, schema, table)
 	}
 
-	return action == bf.Ignore, nil
+	if action == bf.Ignore {
+		s.filterStats.record(s.binlogFilter, s.cfg.CaseSensitive, schema, table, et, "")
+		return true, nil
+	}
+	return false, nil
 }
@@ -0,0 +1,88 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+)
+
+// FilterRuleKey returns the identity of a binlog event filter rule used in the filtered event stats.
+func FilterRuleKey(rule *bf.BinlogEventRule) string {
+	return fmt.Sprintf("schema-pattern=%s table-pattern=%s events=%v sql-pattern=%v action=%s",
+		rule.SchemaPattern, rule.TablePattern, rule.Events, rule.SQLPattern, rule.Action)
+}
+
+// filterStats counts the events filtered by each binlog event filter rule, it's safe for concurrent use.
+type filterStats struct {
+	sync.RWMutex
+	// rule -> the filter with only the rule, to find which rule filtered an event
+	ruleFilters map[*bf.BinlogEventRule]*bf.BinlogEvent
+	// rule key -> count
+	counts map[string]uint64
+}
+
+// record finds the rule filtering the event by binlogFilter and counts it, the rules are checked in the same order as
+// binlogFilter does, and the first one ignoring the event is counted.
+func (f *filterStats) record(binlogFilter *bf.BinlogEvent, caseSensitive bool, schema, table string, et bf.EventType, sql string) {
+	schemaL, tableL := schema, table
+	if !caseSensitive {
+		schemaL, tableL = strings.ToLower(schema), strings.ToLower(table)
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	if f.ruleFilters == nil {
+		f.ruleFilters = make(map[*bf.BinlogEventRule]*bf.BinlogEvent)
+		f.counts = make(map[string]uint64)
+	}
+	for _, r := range binlogFilter.Match(schemaL, tableL) {
+		rule, ok := r.(*bf.BinlogEventRule)
+		if !ok {
+			continue
+		}
+		ruleFilter, ok := f.ruleFilters[rule]
+		if !ok {
+			var err error
+			// the rule has been validated by binlogFilter.
+			if ruleFilter, err = bf.NewBinlogEvent(caseSensitive, []*bf.BinlogEventRule{rule}); err != nil {
+				continue
+			}
+			f.ruleFilters[rule] = ruleFilter
+		}
+		if action, err := ruleFilter.Filter(schema, table, et, sql); err == nil && action == bf.Ignore {
+			f.counts[FilterRuleKey(rule)]++
+			return
+		}
+	}
+}
+
+func (f *filterStats) stats() map[string]uint64 {
+	f.RLock()
+	defer f.RUnlock()
+	stats := make(map[string]uint64, len(f.counts))
+	for key, count := range f.counts {
+		stats[key] = count
+	}
+	return stats
+}
+
+// FilteredEventStats returns the number of events filtered by each binlog event filter rule since the syncer created,
+// keyed by FilterRuleKey, the rules filtering nothing are not included.
+func (s *Syncer) FilteredEventStats() map[string]uint64 {
+	return s.filterStats.stats()
+}
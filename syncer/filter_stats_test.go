@@ -0,0 +1,71 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testFilterStatsSuite{})
+
+type testFilterStatsSuite struct{}
+
+func (t *testFilterStatsSuite) TestFilteredEventStats(c *C) {
+	ignoreDelete := &bf.BinlogEventRule{SchemaPattern: "db*", TablePattern: "tbl*", Events: []bf.EventType{bf.DeleteEvent}, Action: bf.Ignore}
+	ignoreDrop := &bf.BinlogEventRule{SchemaPattern: "db*", SQLPattern: []string{"^DROP\\s+TABLE"}, Action: bf.Ignore}
+	doInsert := &bf.BinlogEventRule{SchemaPattern: "db2", TablePattern: "tbl", Events: []bf.EventType{bf.InsertEvent}, Action: bf.Do}
+	unused := &bf.BinlogEventRule{SchemaPattern: "other", Events: []bf.EventType{bf.AllEvent}, Action: bf.Ignore}
+
+	cfg := &config.SubTaskConfig{
+		Name:        "test-filter-stats",
+		FilterRules: []*bf.BinlogEventRule{ignoreDelete, ignoreDrop, doInsert, unused},
+	}
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.FilteredEventStats(), HasLen, 0)
+	var err error
+	syncer.baList, err = filter.New(cfg.CaseSensitive, nil)
+	c.Assert(err, IsNil)
+	syncer.binlogFilter, err = bf.NewBinlogEvent(cfg.CaseSensitive, cfg.FilterRules)
+	c.Assert(err, IsNil)
+
+	for i := 0; i < 3; i++ {
+		skip, err2 := syncer.skipDMLEvent("db1", "tbl1", replication.DELETE_ROWS_EVENTv2)
+		c.Assert(err2, IsNil)
+		c.Assert(skip, IsTrue)
+	}
+	skip, err := syncer.skipDMLEvent("db1", "tbl1", replication.WRITE_ROWS_EVENTv2)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsFalse)
+	// updates are not in the events of the `Do` rule.
+	skip, err = syncer.skipDMLEvent("db2", "tbl", replication.UPDATE_ROWS_EVENTv2)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsTrue)
+
+	sql := "DROP TABLE `db1`.`tbl2`"
+	skip, err = syncer.skipQuery([]*filter.Table{{Schema: "db1", Name: "tbl2"}}, nil, sql)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsTrue)
+
+	c.Assert(syncer.FilteredEventStats(), DeepEquals, map[string]uint64{
+		FilterRuleKey(ignoreDelete): 3,
+		FilterRuleKey(doInsert):     1,
+		FilterRuleKey(ignoreDrop):   1,
+	})
+	c.Assert(FilterRuleKey(ignoreDelete), Equals, "schema-pattern=db* table-pattern=tbl* events=[delete] sql-pattern=[] action=Ignore")
+}
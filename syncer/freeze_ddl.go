@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// FrozenDDL is a DDL on the tables matching freeze-on-ddl, which pauses the subtask until it's reviewed.
+type FrozenDDL struct {
+	// Tables are the upstream tables the DDL is on, like "`db`.`tbl`".
+	Tables []string `json:"tables"`
+	// DDLs are the DDLs to be executed in downstream after the DDL is applied.
+	DDLs []string `json:"ddls"`
+	// RawDDL is the DDL statement in upstream binlog.
+	RawDDL string `json:"raw-ddl"`
+	// Location is the binlog location of the DDL.
+	Location string    `json:"location"`
+	FrozenAt time.Time `json:"frozen-at"`
+}
+
+// frozenDDL records the DDL frozen by freeze-on-ddl, it's safe for concurrent use.
+type frozenDDL struct {
+	sync.Mutex
+	ddl *FrozenDDL
+	pos mysql.Position
+	// approved is set when the subtask is resumed after the DDL is frozen, then the DDL is applied.
+	approved bool
+}
+
+// freeze records the DDL at pos and returns false, or returns true if it's the approved one.
+func (f *frozenDDL) freeze(pos mysql.Position, ddl *FrozenDDL) bool {
+	f.Lock()
+	defer f.Unlock()
+	// the DDL replaced by `handle-error` may be split to several events sharing the position, so the approval is kept.
+	if f.approved && f.pos == pos {
+		return true
+	}
+	f.ddl, f.pos, f.approved = ddl, pos, false
+	return false
+}
+
+func (f *frozenDDL) approve() {
+	f.Lock()
+	defer f.Unlock()
+	if f.ddl != nil {
+		f.approved = true
+	}
+}
+
+// get returns the DDL frozen and not approved yet, or nil.
+func (f *frozenDDL) get() *FrozenDDL {
+	f.Lock()
+	defer f.Unlock()
+	if f.ddl == nil || f.approved {
+		return nil
+	}
+	clone := *f.ddl
+	return &clone
+}
+
+// newFreezeFilter returns a filter matching the tables in freeze-on-ddl and their schemas, or nil if there is none.
+func newFreezeFilter(caseSensitive bool, patterns []*filter.Table) (*filter.Filter, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	rules := &filter.Rules{DoTables: patterns}
+	for _, tb := range patterns {
+		rules.DoDBs = append(rules.DoDBs, tb.Schema)
+	}
+	return filter.New(caseSensitive, rules)
+}
+
+// checkFreezeOnDDL returns ErrSyncerFrozenDDL if the DDLs are on the tables matching freeze-on-ddl and aren't approved
+// by resuming the subtask after they're frozen.
+func (s *Syncer) checkFreezeOnDDL(location binlog.Location, rawDDL string, ddls []string, trackDDLs []trackedDDL) error {
+	if s.freezeFilter == nil {
+		return nil
+	}
+	var tables []string
+	for _, td := range trackDDLs {
+		for _, tb := range td.tableNames[0] {
+			if s.freezeFilter.Match(tb) {
+				tables = append(tables, tb.String())
+			}
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	ddl := &FrozenDDL{
+		Tables:   tables,
+		DDLs:     ddls,
+		RawDDL:   rawDDL,
+		Location: location.String(),
+		FrozenAt: time.Now(),
+	}
+	if s.frozenDDL.freeze(location.Position, ddl) {
+		s.tctx.L().Info("apply the DDL reviewed after frozen", zap.Strings("tables", tables), zap.String("raw statement", rawDDL), zap.Stringer("location", location))
+		return nil
+	}
+	s.tctx.L().Warn("freeze the DDL on tables in freeze-on-ddl", zap.Strings("tables", tables), zap.String("raw statement", rawDDL), zap.Stringer("location", location))
+	return terror.ErrSyncerFrozenDDL.Generate(rawDDL, tables)
+}
+
+// FrozenDDL returns the DDL paused by freeze-on-ddl and not reviewed yet, or nil.
+func (s *Syncer) FrozenDDL() *FrozenDDL {
+	return s.frozenDDL.get()
+}
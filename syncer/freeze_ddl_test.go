@@ -0,0 +1,69 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testFreezeDDLSuite{})
+
+type testFreezeDDLSuite struct{}
+
+func (t *testFreezeDDLSuite) TestCheckFreezeOnDDL(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-freeze", Mode: config.ModeIncrement}
+	cfg.FreezeOnDDL = []*filter.Table{{Schema: "db", Name: "sensitive*"}}
+	s := NewSyncer(cfg, nil)
+	var err error
+	s.freezeFilter, err = newFreezeFilter(cfg.CaseSensitive, cfg.FreezeOnDDL)
+	c.Assert(err, IsNil)
+
+	track := func(schema, table string) []trackedDDL {
+		return []trackedDDL{{tableNames: [][]*filter.Table{{{Schema: schema, Name: table}}, {{Schema: schema, Name: table}}}}}
+	}
+	location := binlog.NewLocation(mysql.MySQLFlavor)
+	location.Position = mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+
+	// not matched.
+	c.Assert(s.checkFreezeOnDDL(location, "ALTER TABLE db.other ADD c INT", nil, track("db", "other")), IsNil)
+	c.Assert(s.checkFreezeOnDDL(location, "ALTER TABLE db2.sensitive ADD c INT", nil, track("db2", "sensitive")), IsNil)
+	c.Assert(s.FrozenDDL(), IsNil)
+
+	// matched, the DDL is frozen until the subtask is resumed.
+	ddls := []string{"ALTER TABLE `db`.`sensitive_1` ADD COLUMN `c` INT"}
+	err = s.checkFreezeOnDDL(location, "ALTER TABLE db.sensitive_1 ADD c INT", ddls, track("db", "sensitive_1"))
+	c.Assert(terror.ErrSyncerFrozenDDL.Equal(err), IsTrue)
+	frozen := s.FrozenDDL()
+	c.Assert(frozen, NotNil)
+	c.Assert(frozen.Tables, DeepEquals, []string{"`db`.`sensitive_1`"})
+	c.Assert(frozen.DDLs, DeepEquals, ddls)
+	err = s.checkFreezeOnDDL(location, "ALTER TABLE db.sensitive_1 ADD c INT", ddls, track("db", "sensitive_1"))
+	c.Assert(terror.ErrSyncerFrozenDDL.Equal(err), IsTrue)
+
+	s.frozenDDL.approve()
+	c.Assert(s.FrozenDDL(), IsNil)
+	c.Assert(s.checkFreezeOnDDL(location, "ALTER TABLE db.sensitive_1 ADD c INT", ddls, track("db", "sensitive_1")), IsNil)
+
+	// a later DDL is frozen again.
+	location.Position.Pos = 5678
+	err = s.checkFreezeOnDDL(location, "ALTER TABLE db.sensitive_1 DROP c", nil, track("db", "sensitive_1"))
+	c.Assert(terror.ErrSyncerFrozenDDL.Equal(err), IsTrue)
+	c.Assert(s.FrozenDDL(), NotNil)
+}
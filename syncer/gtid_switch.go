@@ -0,0 +1,124 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// GTIDSwitchStatus represents the status of switching from position-based replication to GTID-based by `switch-to-gtid`.
+type GTIDSwitchStatus struct {
+	Enabled  bool `json:"enabled"`
+	Switched bool `json:"switched"`
+	// Location is the checkpoint when switched, Time is zero if switched before the subtask restarted.
+	Location string    `json:"location,omitempty"`
+	Time     time.Time `json:"time,omitempty"`
+}
+
+// gtidSwitch records the location and time of the switch to GTID mode by `switch-to-gtid`, it's safe for concurrent use.
+type gtidSwitch struct {
+	sync.RWMutex
+	location string
+	time     time.Time
+}
+
+func (g *gtidSwitch) record(location binlog.Location, t time.Time) {
+	g.Lock()
+	defer g.Unlock()
+	g.location = location.String()
+	g.time = t
+}
+
+// enableGTID returns whether the syncer replicates binlog in GTID mode, which is enabled in config or switched to by
+// `switch-to-gtid`. the config isn't changed when switching, because it's read concurrently, e.g. by Status.
+func (s *Syncer) enableGTID() bool {
+	return s.cfg.EnableGTID || s.gtidSwitched.Get()
+}
+
+// needSwitchToGTID returns whether the syncer replicates by binlog positions and should switch to GTID mode.
+func (s *Syncer) needSwitchToGTID() bool {
+	return s.cfg.SwitchToGTID && !s.enableGTID()
+}
+
+// useSwitchedGTID enables GTID mode when starting if the subtask has switched to it before, which is known by the GTID
+// set of the global checkpoint, because it's always empty when replicating by binlog positions.
+func (s *Syncer) useSwitchedGTID(tctx *tcontext.Context) error {
+	if !s.needSwitchToGTID() {
+		return nil
+	}
+	location := s.checkpoint.GlobalPoint()
+	if location.GTIDSetStr() == "" {
+		return nil
+	}
+	tctx.L().Info("replicate binlog in GTID mode switched by switch-to-gtid before", zap.Stringer("checkpoint", location))
+	s.gtidSwitch.record(location, time.Time{})
+	s.gtidSwitched.Set(true)
+	return s.streamerController.SwitchToGTID(tctx, location)
+}
+
+// switchToGTID switches to GTID mode if the syncer has caught up with upstream, it's called after the jobs are flushed
+// when meeting heartbeat events, which are only sent when upstream is idle. the GTID set of the flushed checkpoint is
+// exactly the executed GTID set of upstream master when their positions are the same, then the checkpoint with the
+// GTID set is flushed and the streamer is reset to read binlog by GTID sets from it.
+func (s *Syncer) switchToGTID(tctx *tcontext.Context) (binlog.Location, bool, error) {
+	location := s.checkpoint.FlushedGlobalPoint()
+	ctx, cancel := context.WithTimeout(tctx.Context(), utils.DefaultDBTimeout)
+	defer cancel()
+	pos, gs, err := s.getMasterStatus(ctx)
+	if err != nil {
+		tctx.L().Warn("fail to get master status, will switch to GTID mode later", log.ShortError(err))
+		return location, false, nil
+	}
+	if gs == nil || gs.String() == "" {
+		tctx.L().Debug("GTID isn't enabled in upstream, can't switch to GTID mode")
+		return location, false, nil
+	}
+	if binlog.ComparePosition(pos, location.Position) != 0 {
+		tctx.L().Debug("not caught up with upstream yet, will switch to GTID mode later",
+			zap.Stringer("master position", pos), zap.Stringer("checkpoint", location))
+		return location, false, nil
+	}
+
+	switched := binlog.InitLocation(location.Position, gs)
+	s.checkpoint.SaveGlobalPoint(switched)
+	if err = s.flushCheckPoints(); err != nil {
+		s.checkpoint.Rollback(s.schemaTracker)
+		return location, false, err
+	}
+	s.gtidSwitch.record(switched, time.Now())
+	s.gtidSwitched.Set(true)
+	tctx.L().Info("switched to GTID mode by switch-to-gtid", zap.Stringer("checkpoint", switched))
+	return switched, true, s.streamerController.SwitchToGTID(tctx, switched)
+}
+
+// GTIDSwitchStatus returns the status of switching to GTID mode by `switch-to-gtid`.
+func (s *Syncer) GTIDSwitchStatus() GTIDSwitchStatus {
+	s.gtidSwitch.RLock()
+	defer s.gtidSwitch.RUnlock()
+	return GTIDSwitchStatus{
+		Enabled:  s.cfg.SwitchToGTID,
+		Switched: s.gtidSwitched.Get(),
+		Location: s.gtidSwitch.location,
+		Time:     s.gtidSwitch.time,
+	}
+}
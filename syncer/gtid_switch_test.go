@@ -0,0 +1,128 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/retry"
+)
+
+var _ = Suite(&testGTIDSwitchSuite{})
+
+type testGTIDSwitchSuite struct{}
+
+func (t *testGTIDSwitchSuite) TestSwitchToGTID(c *C) {
+	fromDB, fromMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	cpDB, cpMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	cpDBConn, err := cpDB.Conn(context.Background())
+	c.Assert(err, IsNil)
+
+	cfg := &config.SubTaskConfig{Name: "test-switch-to-gtid", Flavor: mysql.MySQLFlavor}
+	cfg.SwitchToGTID = true
+	syncer := NewSyncer(cfg, nil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(fromDB, func() {})}
+	syncer.streamerController = NewStreamerController(syncer.syncCfg, false, syncer.fromDB, RemoteBinlog, "", nil)
+	cp := syncer.checkpoint.(*RemoteCheckPoint)
+	cp.dbConn = &DBConn{cfg: cfg, baseConn: conn.NewBaseConn(cpDBConn, &retry.FiniteRetryStrategy{})}
+	tctx := tcontext.Background()
+
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+	loc := binlog.InitLocation(pos, nil)
+	cp.globalPoint = newBinlogPoint(loc, loc, nil, nil, false)
+	gtidStr := "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"
+	mockMasterStatus := func(pos mysql.Position, gtidStr string) {
+		fromMock.ExpectQuery("SHOW MASTER STATUS").WillReturnRows(
+			sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}).AddRow(pos.Name, pos.Pos, "", "", gtidStr))
+	}
+	c.Assert(syncer.needSwitchToGTID(), IsTrue)
+
+	// not caught up with upstream.
+	mockMasterStatus(mysql.Position{Name: "mysql-bin.000001", Pos: 4567}, gtidStr)
+	_, switched, err := syncer.switchToGTID(tctx)
+	c.Assert(err, IsNil)
+	c.Assert(switched, IsFalse)
+
+	// GTID isn't enabled in upstream.
+	mockMasterStatus(pos, "")
+	_, switched, err = syncer.switchToGTID(tctx)
+	c.Assert(err, IsNil)
+	c.Assert(switched, IsFalse)
+	c.Assert(syncer.GTIDSwitchStatus().Switched, IsFalse)
+
+	// switched, and the checkpoint with the GTID set is flushed.
+	mockMasterStatus(pos, gtidStr)
+	cpMock.ExpectBegin()
+	cpMock.ExpectExec("INSERT INTO").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), pos.Name, pos.Pos, gtidStr,
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), true).WillReturnResult(sqlmock.NewResult(1, 1))
+	cpMock.ExpectCommit()
+	location, switched, err := syncer.switchToGTID(tctx)
+	c.Assert(err, IsNil)
+	c.Assert(switched, IsTrue)
+	c.Assert(location.GTIDSetStr(), Equals, gtidStr)
+	c.Assert(cp.FlushedGlobalPoint().GTIDSetStr(), Equals, gtidStr)
+	c.Assert(syncer.cfg.EnableGTID, IsFalse)
+	c.Assert(syncer.enableGTID(), IsTrue)
+	c.Assert(syncer.GTIDMode(), Equals, GTIDModeEnabled)
+	c.Assert(syncer.needSwitchToGTID(), IsFalse)
+	c.Assert(syncer.streamerController.enableGTID, IsTrue)
+	status := syncer.GTIDSwitchStatus()
+	c.Assert(status.Enabled, IsTrue)
+	c.Assert(status.Switched, IsTrue)
+	c.Assert(status.Location, Equals, location.String())
+	c.Assert(status.Time.IsZero(), IsFalse)
+
+	c.Assert(fromMock.ExpectationsWereMet(), IsNil)
+	c.Assert(cpMock.ExpectationsWereMet(), IsNil)
+}
+
+func (t *testGTIDSwitchSuite) TestUseSwitchedGTID(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-use-switched-gtid", Flavor: mysql.MySQLFlavor}
+	cfg.SwitchToGTID = true
+	syncer := NewSyncer(cfg, nil)
+	syncer.streamerController = NewStreamerController(syncer.syncCfg, false, nil, RemoteBinlog, "", nil)
+	cp := syncer.checkpoint.(*RemoteCheckPoint)
+	tctx := tcontext.Background()
+
+	// not switched before.
+	loc := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 1234}, nil)
+	cp.globalPoint = newBinlogPoint(loc, loc, nil, nil, false)
+	c.Assert(syncer.useSwitchedGTID(tctx), IsNil)
+	c.Assert(syncer.enableGTID(), IsFalse)
+	c.Assert(syncer.GTIDMode(), Equals, GTIDModeDisabled)
+
+	// switched before.
+	gs, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	c.Assert(err, IsNil)
+	loc = binlog.InitLocation(loc.Position, gs)
+	cp.globalPoint = newBinlogPoint(loc, loc, nil, nil, false)
+	c.Assert(syncer.useSwitchedGTID(tctx), IsNil)
+	c.Assert(syncer.cfg.EnableGTID, IsFalse)
+	c.Assert(syncer.enableGTID(), IsTrue)
+	c.Assert(syncer.streamerController.enableGTID, IsTrue)
+	status := syncer.GTIDSwitchStatus()
+	c.Assert(status.Switched, IsTrue)
+	c.Assert(status.Time.IsZero(), IsTrue)
+}
@@ -28,25 +28,44 @@ import (
 	"github.com/pingcap/dm/pkg/terror"
 )
 
+// HandleErrorResult is the result of handling an error of the syncer.
+type HandleErrorResult struct {
+	// whether the syncer met the error at the binlog position, so the error is resolved after resuming.
+	// it's false if the operation is set in advance for an error not met yet.
+	Found bool `json:"found"`
+	// the binlog position the operation applies at
+	BinlogPos string `json:"binlog-pos"`
+	// the DDLs still blocking the replication, e.g. the sharding DDLs waiting for other sources
+	PendingDDLs []string `json:"pending-ddls,omitempty"`
+}
+
 // HandleError handle error for syncer
 func (s *Syncer) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) error {
+	_, err := s.HandleErrorWithResult(ctx, req)
+	return err
+}
+
+// HandleErrorWithResult handles error for syncer like HandleError, and returns the details of the operation.
+func (s *Syncer) HandleErrorWithResult(ctx context.Context, req *pb.HandleWorkerErrorRequest) (*HandleErrorResult, error) {
 	pos := req.BinlogPos
+	errLocation, isQueryEvent := s.getErrLocation()
+	found := errLocation != nil
 
 	if len(pos) == 0 {
-		startLocation, isQueryEvent := s.getErrLocation()
-		if startLocation == nil {
-			return fmt.Errorf("source '%s' has no error", s.cfg.SourceID)
+		if errLocation == nil {
+			return nil, fmt.Errorf("source '%s' has no error", s.cfg.SourceID)
 		}
 		if !isQueryEvent {
-			return fmt.Errorf("only support to handle ddl error currently, see https://docs.pingcap.com/tidb-data-migration/stable/error-handling for other errors")
+			return nil, fmt.Errorf("only support to handle ddl error currently, see https://docs.pingcap.com/tidb-data-migration/stable/error-handling for other errors")
 		}
-		pos = startLocation.Position.String()
+		pos = errLocation.Position.String()
 	} else {
 		startLocation, err := binlog.VerifyBinlogPos(pos)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		pos = startLocation.String()
+		found = found && errLocation.Position.String() == pos
 	}
 
 	events := make([]*replication.BinlogEvent, 0)
@@ -54,22 +73,26 @@ func (s *Syncer) HandleError(ctx context.Context, req *pb.HandleWorkerErrorReque
 	if req.Op == pb.ErrorOp_Replace {
 		events, err = s.genEvents(ctx, req.Sqls)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	// remove outdated operators when add operator
 	err = s.errOperatorHolder.RemoveOutdated(s.checkpoint.FlushedGlobalPoint())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = s.errOperatorHolder.Set(pos, req.Op, events)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &HandleErrorResult{
+		Found:       found,
+		BinlogPos:   pos,
+		PendingDDLs: s.blockingDDLs(),
+	}, nil
 }
 
 func (s *Syncer) genEvents(ctx context.Context, sqls []string) ([]*replication.BinlogEvent, error) {
@@ -18,7 +18,10 @@ import (
 	"fmt"
 
 	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
 	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/binlog"
 )
 
 func (s *testSyncerSuite) TestHandleError(c *C) {
@@ -62,7 +65,7 @@ func (s *testSyncerSuite) TestHandleError(c *C) {
 	)
 
 	var err error
-	syncer.fromDB, err = createUpStreamConn(s.cfg.From) // used to get parser
+	syncer.fromDB, err = createUpStreamConn(nil, s.cfg.From) // used to get parser
 	c.Assert(err, IsNil)
 
 	for _, cs := range cases {
@@ -75,3 +78,44 @@ func (s *testSyncerSuite) TestHandleError(c *C) {
 		}
 	}
 }
+
+func (s *testSyncerSuite) TestHandleErrorWithResult(c *C) {
+	var (
+		syncer = NewSyncer(s.cfg, nil)
+		ctx    = context.Background()
+		req    = &pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Skip, Task: "test"}
+	)
+
+	// set in advance for an error not met yet.
+	req.BinlogPos = "mysql-bin.000001:2345"
+	result, err := syncer.HandleErrorWithResult(ctx, req)
+	c.Assert(err, IsNil)
+	c.Assert(result.Found, IsFalse)
+	c.Assert(result.BinlogPos, Equals, "(mysql-bin.000001, 2345)")
+	c.Assert(result.PendingDDLs, HasLen, 0)
+
+	// the error is met.
+	startLocation := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 2345}, nil)
+	endLocation := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 2400}, nil)
+	syncer.setErrLocation(&startLocation, &endLocation, true)
+	syncer.ddlBatch.add(&job{ddls: []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"}})
+	result, err = syncer.HandleErrorWithResult(ctx, req)
+	c.Assert(err, IsNil)
+	c.Assert(result.Found, IsTrue)
+	c.Assert(result.BinlogPos, Equals, "(mysql-bin.000001, 2345)")
+	c.Assert(result.PendingDDLs, DeepEquals, []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"})
+
+	// use the location of the error.
+	req.BinlogPos = ""
+	result, err = syncer.HandleErrorWithResult(ctx, req)
+	c.Assert(err, IsNil)
+	c.Assert(result.Found, IsTrue)
+	c.Assert(result.BinlogPos, Equals, "(mysql-bin.000001, 2345)")
+
+	// another position.
+	req.BinlogPos = "mysql-bin.000001:4567"
+	result, err = syncer.HandleErrorWithResult(ctx, req)
+	c.Assert(err, IsNil)
+	c.Assert(result.Found, IsFalse)
+	c.Assert(result.BinlogPos, Equals, "(mysql-bin.000001, 4567)")
+}
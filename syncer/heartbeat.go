@@ -46,6 +46,14 @@ var (
 	reportLagFunc = reportLag
 )
 
+// special values of replication lag calculated from heartbeat.
+const (
+	// HeartbeatLagDisabled means heartbeat is not enabled for the task.
+	HeartbeatLagDisabled time.Duration = -1
+	// HeartbeatLagUnknown means the lag is not calculated yet, e.g. the task is not in the sync phase or no heartbeat received.
+	HeartbeatLagUnknown time.Duration = -2
+)
+
 // HeartbeatConfig represents Heartbeat configurations.
 type HeartbeatConfig struct {
 	updateInterval int64 // in second
@@ -85,6 +93,7 @@ type Heartbeat struct {
 
 	primary     *sql.DB
 	secondaryTs map[string]float64 // task-name => secondary (syncer) ts
+	lags        map[string]float64 // task-name => the latest calculated lag, in second
 
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -101,6 +110,7 @@ func GetHeartbeat(cfg *HeartbeatConfig) (*Heartbeat, error) {
 			schema:      strings.ToUpper(filter.DMHeartbeatSchema),
 			table:       strings.ToUpper(filter.DMHeartbeatTable),
 			secondaryTs: make(map[string]float64),
+			lags:        make(map[string]float64),
 			logger:      log.With(zap.String("component", "heartbeat")),
 		}
 	})
@@ -167,6 +177,7 @@ func (h *Heartbeat) RemoveTask(name string) error {
 		return terror.ErrSyncerUnitHeartbeatRecordNotFound.Generate(name)
 	}
 	delete(h.secondaryTs, name)
+	delete(h.lags, name)
 
 	if len(h.secondaryTs) == 0 {
 		// cancel work
@@ -227,6 +238,20 @@ func (h *Heartbeat) TryUpdateTaskTs(taskName, schema, table string, data [][]int
 	}
 }
 
+// Lag returns the latest replication lag of the task calculated from heartbeat,
+// returns false if no valid lag calculated yet, e.g. no heartbeat received by the task.
+func (h *Heartbeat) Lag(taskName string) (time.Duration, bool) {
+	h.lock <- struct{}{}
+	defer func() {
+		<-h.lock
+	}()
+	lag, ok := h.lags[taskName]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(lag * float64(time.Second)), true
+}
+
 func (h *Heartbeat) init() error {
 	err := h.createDatabase()
 	if err != nil {
@@ -315,6 +340,7 @@ func (h *Heartbeat) calculateLag(ctx context.Context) error {
 				continue // do not update metrics if no valid secondary TS exists.
 			}
 			lag := primaryTS - ts
+			h.lags[taskName] = lag
 			reportLagFunc(taskName, lag)
 		}
 		<-h.lock
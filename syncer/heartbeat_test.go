@@ -15,6 +15,7 @@ package syncer
 
 import (
 	"context"
+	"time"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb-tools/pkg/filter"
@@ -100,13 +101,36 @@ func (t *testHeartbeatSuite) TestHeartbeat(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(t.lag["heartbeat_test_1"], Not(Equals), oldlag1)
 	c.Assert(t.lag["heartbeat_test_2"], Equals, oldlag2)
+	lag, ok := heartbeat.Lag("heartbeat_test_1")
+	c.Assert(ok, IsTrue)
+	c.Assert(lag, Equals, time.Duration(t.lag["heartbeat_test_1"]*float64(time.Second)))
 
 	err = heartbeat.RemoveTask("wrong")
 	c.Assert(err, ErrorMatches, ".*not found.*")
 
 	err = heartbeat.RemoveTask("heartbeat_test_1")
 	c.Assert(err, IsNil)
+	_, ok = heartbeat.Lag("heartbeat_test_1")
+	c.Assert(ok, IsFalse)
 
 	err = heartbeat.RemoveTask("heartbeat_test_2")
 	c.Assert(err, IsNil)
 }
+
+func (t *testHeartbeatSuite) TestHeartbeatLag(c *C) {
+	cfg := &config.SubTaskConfig{Name: "heartbeat_test_lag"}
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.HeartbeatLag(), Equals, HeartbeatLagDisabled)
+
+	cfg.EnableHeartbeat = true
+	c.Assert(syncer.HeartbeatLag(), Equals, HeartbeatLagUnknown)
+
+	syncer.heartbeat = &Heartbeat{
+		lock:        make(chan struct{}, 1),
+		secondaryTs: map[string]float64{cfg.Name: 0},
+		lags:        make(map[string]float64),
+	}
+	c.Assert(syncer.HeartbeatLag(), Equals, HeartbeatLagUnknown)
+	syncer.heartbeat.lags[cfg.Name] = 1.5
+	c.Assert(syncer.HeartbeatLag(), Equals, 1500*time.Millisecond)
+}
@@ -73,6 +73,7 @@ type job struct {
 	currentLocation binlog.Location // end location of the sql in binlog, for user to skip sql manually by changing checkpoint
 	ddls            []string
 	originSQL       string // show origin sql when error, only DDL now
+	eventTS         int64  // timestamp of the binlog event, only DML now
 }
 
 func (j *job) String() string {
@@ -80,6 +81,25 @@ func (j *job) String() string {
 	return fmt.Sprintf("tp: %s, sql: %s, args: %v, key: %s, ddls: %s, last_location: %s, start_location: %s, current_location: %s", j.tp, j.sql, j.args, j.key, j.ddls, j.location, j.startLocation, j.currentLocation)
 }
 
+// size returns the approximate bytes of the SQL statements and arguments of the job.
+func (j *job) size() int64 {
+	size := len(j.sql) + len(j.key)
+	for _, arg := range j.args {
+		switch v := arg.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		default:
+			size += 8
+		}
+	}
+	for _, ddl := range j.ddls {
+		size += len(ddl)
+	}
+	return int64(size)
+}
+
 func newJob(tp opType, sourceSchema, sourceTable, targetSchema, targetTable, sql string, args []interface{}, key string, location, startLocation, cmdLocation binlog.Location) *job {
 	return &job{
 		tp:              tp,
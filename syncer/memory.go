@@ -0,0 +1,78 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"time"
+)
+
+// memoryBreakdownInterval is the min interval of updating the memory breakdown, estimating the memory of the schema
+// tracker encodes all tracked tables, so it's not updated on every call.
+const memoryBreakdownInterval = 10 * time.Second
+
+// MemoryBreakdown represents the approximate bytes of memory used by components of the sync unit.
+type MemoryBreakdown struct {
+	// BinlogRead is the binlog events read and buffered in the streamer, it's only measured when reading relay log.
+	BinlogRead int64 `json:"binlog-read"`
+	// Transform is the keys of DMLs transformed from binlog events, kept to detect causality between them.
+	Transform int64 `json:"transform"`
+	// PendingWrite is the DML and DDL jobs queued or batched by workers but not written to downstream yet.
+	PendingWrite int64 `json:"pending-write"`
+	// SchemaTracker is the table infos tracked by the schema tracker.
+	SchemaTracker int64 `json:"schema-tracker"`
+
+	Total      int64     `json:"total"`
+	UpdateTime time.Time `json:"update-time"`
+}
+
+// MemoryBreakdown returns the approximate memory used by components of the syncer, it's updated at most once per
+// 10 seconds to avoid the overhead.
+func (s *Syncer) MemoryBreakdown() MemoryBreakdown {
+	s.memory.Lock()
+	defer s.memory.Unlock()
+	if s.memory.breakdown != nil && time.Since(s.memory.breakdown.UpdateTime) < memoryBreakdownInterval {
+		return *s.memory.breakdown
+	}
+
+	mb := &MemoryBreakdown{UpdateTime: time.Now()}
+	if s.streamerController != nil {
+		mb.BinlogRead = s.streamerController.BufferedEventBytes()
+	}
+	if s.c != nil {
+		mb.Transform = s.c.bytes.Get()
+	}
+	if jobs := s.addedJobs.Get(); jobs > 0 {
+		mb.PendingWrite = s.pendingJobCount() * (s.addedJobBytes.Get() / jobs)
+	}
+	if s.schemaTracker != nil {
+		mb.SchemaTracker = s.schemaTracker.EstimatedSize()
+	}
+	mb.Total = mb.BinlogRead + mb.Transform + mb.PendingWrite + mb.SchemaTracker
+	s.memory.breakdown = mb
+	return *mb
+}
+
+// pendingJobCount returns the number of jobs queued in job channels or batched by workers.
+func (s *Syncer) pendingJobCount() int64 {
+	count := s.batchedJobs.Get()
+	s.jobsChanLock.Lock()
+	defer s.jobsChanLock.Unlock()
+	if s.jobsClosed.Get() {
+		return count
+	}
+	for _, ch := range s.jobs {
+		count += int64(len(ch))
+	}
+	return count
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/schema"
+)
+
+var _ = Suite(&testMemoryBreakdownSuite{})
+
+type testMemoryBreakdownSuite struct{}
+
+func (t *testMemoryBreakdownSuite) TestJobSize(c *C) {
+	j := &job{sql: "INSERT INTO `db`.`tbl` (`id`,`name`) VALUES (?,?)", key: "1", args: []interface{}{int64(1), "abc"}}
+	c.Assert(j.size(), Equals, int64(len(j.sql)+1+8+3))
+	j = &job{tp: ddl, ddls: []string{"ALTER TABLE `db`.`tbl` ADD COLUMN `c` INT"}}
+	c.Assert(j.size(), Equals, int64(len(j.ddls[0])))
+}
+
+func (t *testMemoryBreakdownSuite) TestMemoryBreakdown(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-memory"}
+	cfg.QueueSize = 10
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.MemoryBreakdown(), DeepEquals, MemoryBreakdown{UpdateTime: syncer.memory.breakdown.UpdateTime})
+
+	syncer.newJobChans(2)
+	defer syncer.closeJobChans()
+	jobs := []*job{
+		{tp: insert, sql: "INSERT INTO `db`.`tbl` VALUES (?)", args: []interface{}{"0123456789"}},
+		{tp: insert, sql: "INSERT INTO `db`.`tbl` VALUES (?)", args: []interface{}{"9876543210"}},
+	}
+	for i, j := range jobs {
+		syncer.jobs[i] <- j
+		syncer.addedJobs.Add(1)
+		syncer.addedJobBytes.Add(j.size())
+	}
+	syncer.batchedJobs.Add(1)
+	c.Assert(syncer.c.add([]string{"key1", "key2"}), IsNil)
+
+	var err error
+	syncer.schemaTracker, err = schema.NewTracker(context.Background(), cfg.Name, defaultTestSessionCfg, nil)
+	c.Assert(err, IsNil)
+	defer syncer.schemaTracker.Close()
+	c.Assert(syncer.schemaTracker.CreateSchemaIfNotExists("db"), IsNil)
+	c.Assert(syncer.schemaTracker.Exec(context.Background(), "db", "CREATE TABLE tbl (id INT PRIMARY KEY, name VARCHAR(20))"), IsNil)
+
+	// not updated in the interval.
+	c.Assert(syncer.MemoryBreakdown().Total, Equals, int64(0))
+
+	syncer.memory.breakdown.UpdateTime = time.Now().Add(-memoryBreakdownInterval)
+	mb := syncer.MemoryBreakdown()
+	c.Assert(mb.BinlogRead, Equals, int64(0))
+	c.Assert(mb.Transform, Equals, int64(len("key1key1key2key1")))
+	c.Assert(mb.PendingWrite, Equals, 3*jobs[0].size())
+	c.Assert(mb.SchemaTracker > 0, IsTrue)
+	c.Assert(mb.Total, Equals, mb.Transform+mb.PendingWrite+mb.SchemaTracker)
+
+	syncer.c.reset()
+	c.Assert(syncer.c.bytes.Get(), Equals, int64(0))
+}
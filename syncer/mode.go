@@ -14,16 +14,78 @@
 package syncer
 
 import (
+	"sync"
 	"time"
 
 	"github.com/pingcap/failpoint"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/unit"
+	"github.com/pingcap/dm/pkg/binlog"
 	tcontext "github.com/pingcap/dm/pkg/context"
 	sm "github.com/pingcap/dm/syncer/safe-mode"
 )
 
+// SafeModeRemaining represents whether safe mode is active and when it ends, the throughput is lower in safe mode.
+// safe mode ends when none of the reasons below holds.
+type SafeModeRemaining struct {
+	Active bool `json:"active"`
+	// ByConfig means safe mode is enabled by `safe-mode` in task config, so it never ends.
+	ByConfig bool `json:"by-config"`
+	// InitPhase is the time left of the initialization phase after the sync started or resumed, 0 if it's over.
+	InitPhase time.Duration `json:"init-phase"`
+	// ExitLocation is the location safe mode exits at to recover from an inconsistent dump, empty if passed or none.
+	ExitLocation string `json:"exit-location,omitempty"`
+	// ShardingTables are the tables re-syncing binlog events of a synced sharding DDL in safe mode.
+	ShardingTables []string `json:"sharding-tables,omitempty"`
+}
+
+// safeModeState tracks the safe mode of the running syncer, it's safe for concurrent use.
+type safeModeState struct {
+	sync.RWMutex
+	mode         *sm.SafeMode
+	initPhaseEnd time.Time
+	exitLocation *binlog.Location
+}
+
+func (st *safeModeState) set(mode *sm.SafeMode, initPhaseEnd time.Time, exitLocation *binlog.Location) {
+	st.Lock()
+	defer st.Unlock()
+	st.mode, st.initPhaseEnd, st.exitLocation = mode, initPhaseEnd, exitLocation
+}
+
+func (st *safeModeState) exited() {
+	st.Lock()
+	defer st.Unlock()
+	st.exitLocation = nil
+}
+
+func (st *safeModeState) remaining(byConfig bool) SafeModeRemaining {
+	st.RLock()
+	defer st.RUnlock()
+	if st.mode == nil || !st.mode.Enable() {
+		return SafeModeRemaining{}
+	}
+	r := SafeModeRemaining{
+		Active:         true,
+		ByConfig:       byConfig,
+		ShardingTables: st.mode.Tables(),
+	}
+	if d := time.Until(st.initPhaseEnd); d > 0 {
+		r.InitPhase = d
+	}
+	if st.exitLocation != nil {
+		r.ExitLocation = st.exitLocation.String()
+	}
+	return r
+}
+
+// SafeModeRemaining returns whether safe mode is active and when it ends, to know when the full throughput returns
+// after the sync resumed.
+func (s *Syncer) SafeModeRemaining() SafeModeRemaining {
+	return s.safeModeState.remaining(s.cfg.SafeMode)
+}
+
 func (s *Syncer) enableSafeModeInitializationPhase(tctx *tcontext.Context, safeMode *sm.SafeMode) {
 	safeMode.Reset(tctx) // in initialization phase, reset first
 	//nolint:errcheck
@@ -34,12 +96,23 @@ func (s *Syncer) enableSafeModeInitializationPhase(tctx *tcontext.Context, safeM
 		safeMode.Add(tctx, 1) // add 1 but should no corresponding -1, so keeps enabled
 		s.tctx.L().Info("enable safe-mode by config")
 	}
+	var exitLocation *binlog.Location
 	if s.checkpoint.SafeModeExitPoint() != nil {
 		//nolint:errcheck
 		safeMode.Add(tctx, 1) // enable and will revert after pass SafeModeExitLoc
 		s.tctx.L().Info("enable safe-mode because of inconsistent dump, will exit at", zap.Stringer("location", *s.checkpoint.SafeModeExitPoint()))
+		clone := s.checkpoint.SafeModeExitPoint().Clone()
+		exitLocation = &clone
 	}
 
+	initPhaseSeconds := 300
+	failpoint.Inject("SafeModeInitPhaseSeconds", func(val failpoint.Value) {
+		seconds, _ := val.(int)
+		initPhaseSeconds = seconds
+		s.tctx.L().Info("set initPhaseSeconds", zap.String("failpoint", "SafeModeInitPhaseSeconds"), zap.Int("value", seconds))
+	})
+	s.safeModeState.set(safeMode, time.Now().Add(time.Duration(initPhaseSeconds)*time.Second), exitLocation)
+
 	go func() {
 		defer func() {
 			err := safeMode.Add(tctx, -1) // try to disable after 5 minutes
@@ -49,13 +122,6 @@ func (s *Syncer) enableSafeModeInitializationPhase(tctx *tcontext.Context, safeM
 			}
 		}()
 
-		initPhaseSeconds := 300
-
-		failpoint.Inject("SafeModeInitPhaseSeconds", func(val failpoint.Value) {
-			seconds, _ := val.(int)
-			initPhaseSeconds = seconds
-			s.tctx.L().Info("set initPhaseSeconds", zap.String("failpoint", "SafeModeInitPhaseSeconds"), zap.Int("value", seconds))
-		})
 		select {
 		case <-tctx.Context().Done():
 		case <-time.After(time.Duration(initPhaseSeconds) * time.Second):
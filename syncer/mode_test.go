@@ -0,0 +1,64 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	sm "github.com/pingcap/dm/syncer/safe-mode"
+)
+
+var _ = Suite(&testSafeModeSuite{})
+
+type testSafeModeSuite struct{}
+
+func (t *testSafeModeSuite) TestSafeModeRemaining(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-safe-mode", Flavor: mysql.MySQLFlavor}
+	s := NewSyncer(cfg, nil)
+	c.Assert(s.SafeModeRemaining(), DeepEquals, SafeModeRemaining{})
+
+	exit := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000002", Pos: 4}, nil)
+	s.checkpoint.SaveSafeModeExitPoint(&exit)
+	ctx, cancel := context.WithCancel(context.Background())
+	tctx := tcontext.NewContext(ctx, s.tctx.L())
+	safeMode := sm.NewSafeMode()
+	s.enableSafeModeInitializationPhase(tctx, safeMode)
+
+	r := s.SafeModeRemaining()
+	c.Assert(r.Active, IsTrue)
+	c.Assert(r.ByConfig, IsFalse)
+	c.Assert(r.InitPhase > 4*time.Minute && r.InitPhase <= 5*time.Minute, IsTrue)
+	c.Assert(r.ExitLocation, Equals, exit.String())
+	c.Assert(r.ShardingTables, HasLen, 0)
+
+	cancel()
+
+	// the initialization phase is over and the exit location is passed, but a sharding group is re-syncing.
+	var st safeModeState
+	safeMode = sm.NewSafeMode()
+	st.set(safeMode, time.Now().Add(-time.Second), &exit)
+	c.Assert(st.remaining(false), DeepEquals, SafeModeRemaining{})
+	c.Assert(safeMode.IncrForTable(tctx, "db", "tbl"), IsNil)
+	st.exited()
+	c.Assert(st.remaining(true), DeepEquals, SafeModeRemaining{Active: true, ByConfig: true, ShardingTables: []string{"`db`.`tbl`"}})
+	c.Assert(safeMode.DescForTable(tctx, "db", "tbl"), IsNil)
+	c.Assert(st.remaining(true), DeepEquals, SafeModeRemaining{})
+}
@@ -33,7 +33,7 @@ import (
 
 var (
 	// OnlineDDLSchemes is scheme name => online ddl handler
-	OnlineDDLSchemes = map[string]func(*tcontext.Context, *config.SubTaskConfig) (OnlinePlugin, error){
+	OnlineDDLSchemes = map[string]func(*tcontext.Context, *config.SubTaskConfig, conn.DBProvider) (OnlinePlugin, error){
 		config.PT:    NewPT,
 		config.GHOST: NewGhost,
 	}
@@ -82,7 +82,8 @@ type GhostDDLInfo struct {
 type OnlineDDLStorage struct {
 	sync.RWMutex
 
-	cfg *config.SubTaskConfig
+	cfg        *config.SubTaskConfig
+	dbProvider conn.DBProvider
 
 	db        *conn.BaseDB
 	dbConn    *DBConn
@@ -96,15 +97,16 @@ type OnlineDDLStorage struct {
 	logCtx *tcontext.Context
 }
 
-// NewOnlineDDLStorage creates a new online ddl storager
-func NewOnlineDDLStorage(logCtx *tcontext.Context, cfg *config.SubTaskConfig) *OnlineDDLStorage {
+// NewOnlineDDLStorage creates a new online ddl storager, conn.DefaultDBProvider is used if dbProvider is nil
+func NewOnlineDDLStorage(logCtx *tcontext.Context, cfg *config.SubTaskConfig, dbProvider conn.DBProvider) *OnlineDDLStorage {
 	s := &OnlineDDLStorage{
-		cfg:       cfg,
-		schema:    dbutil.ColumnName(cfg.MetaSchema),
-		tableName: dbutil.TableName(cfg.MetaSchema, cputil.SyncerOnlineDDL(cfg.Name)),
-		id:        cfg.SourceID,
-		ddls:      make(map[string]map[string]*GhostDDLInfo),
-		logCtx:    logCtx,
+		cfg:        cfg,
+		dbProvider: dbProvider,
+		schema:     dbutil.ColumnName(cfg.MetaSchema),
+		tableName:  dbutil.TableName(cfg.MetaSchema, cputil.SyncerOnlineDDL(cfg.Name)),
+		id:         cfg.SourceID,
+		ddls:       make(map[string]map[string]*GhostDDLInfo),
+		logCtx:     logCtx,
 	}
 
 	return s
@@ -114,7 +116,7 @@ func NewOnlineDDLStorage(logCtx *tcontext.Context, cfg *config.SubTaskConfig) *O
 func (s *OnlineDDLStorage) Init(tctx *tcontext.Context) error {
 	onlineDB := s.cfg.To
 	onlineDB.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
-	db, dbConns, err := createConns(tctx, s.cfg, onlineDB, 1)
+	db, dbConns, err := createConns(tctx, s.cfg, s.dbProvider, onlineDB, 1)
 	if err != nil {
 		return terror.WithScope(err, terror.ScopeDownstream)
 	}
@@ -0,0 +1,191 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/siddontang/go-mysql/mysql"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// PortableCheckpointVersion is the version of the format of PortableCheckpoint, it's increased when the format is
+// changed incompatibly, and the checkpoints of other versions are rejected on import.
+const PortableCheckpointVersion = 1
+
+// PortableLocation is a binlog location without the relay log sub directory suffix, so it's meaningful in any cluster.
+type PortableLocation struct {
+	BinlogName string `json:"binlog-name"`
+	BinlogPos  uint32 `json:"binlog-pos"`
+	BinlogGTID string `json:"binlog-gtid"`
+}
+
+// PortableCheckpoint is the global checkpoint of a syncer exported to be imported by a subtask in another DM cluster,
+// to move a migration between clusters without re-dumping. table checkpoints are not exported, so all tables are
+// replicated from the global checkpoint after imported.
+type PortableCheckpoint struct {
+	Version    int              `json:"version"`
+	Task       string           `json:"task"`
+	SourceID   string           `json:"source-id"`
+	Flavor     string           `json:"flavor"`
+	EnableGTID bool             `json:"enable-gtid"`
+	Location   PortableLocation `json:"location"`
+	// SafeModeExitLocation is the location the syncer exits safe mode at, nil if it's not in safe mode for recovery.
+	SafeModeExitLocation *PortableLocation `json:"safe-mode-exit-location,omitempty"`
+}
+
+func toPortableLocation(location binlog.Location) (PortableLocation, error) {
+	pos, err := binlog.RealMySQLPos(location.Position)
+	if err != nil {
+		return PortableLocation{}, err
+	}
+	return PortableLocation{BinlogName: pos.Name, BinlogPos: pos.Pos, BinlogGTID: location.GTIDSetStr()}, nil
+}
+
+func (l PortableLocation) location(flavor string) (binlog.Location, error) {
+	gs, err := gtid.ParserGTID(flavor, l.BinlogGTID)
+	if err != nil {
+		return binlog.Location{}, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("GTID set %s is invalid for flavor %s", l.BinlogGTID, flavor))
+	}
+	return binlog.InitLocation(mysql.Position{Name: l.BinlogName, Pos: l.BinlogPos}, gs), nil
+}
+
+// ExportCheckpoint exports the flushed global checkpoint and the safe mode exit point of the syncer in the portable
+// format, which is JSON of PortableCheckpoint.
+func (s *Syncer) ExportCheckpoint() ([]byte, error) {
+	flushed := s.checkpoint.FlushedGlobalPoint()
+	if len(flushed.Position.Name) == 0 {
+		return nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate("the syncer has no checkpoint yet")
+	}
+	location, err := toPortableLocation(flushed)
+	if err != nil {
+		return nil, err
+	}
+	cp := PortableCheckpoint{
+		Version:    PortableCheckpointVersion,
+		Task:       s.cfg.Name,
+		SourceID:   s.cfg.SourceID,
+		Flavor:     s.cfg.Flavor,
+		EnableGTID: s.enableGTID(),
+		Location:   location,
+	}
+	if exitPoint := s.checkpoint.SafeModeExitPoint(); exitPoint != nil {
+		exitLocation, err2 := toPortableLocation(*exitPoint)
+		if err2 != nil {
+			return nil, err2
+		}
+		cp.SafeModeExitLocation = &exitLocation
+	}
+	return json.Marshal(cp)
+}
+
+// parsePortableCheckpoint parses the portable checkpoint and validates it's compatible with the syncer.
+func (s *Syncer) parsePortableCheckpoint(data []byte) (location binlog.Location, exitLocation *binlog.Location, err error) {
+	var cp PortableCheckpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return location, nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate("fail to decode it: " + err.Error())
+	}
+	switch {
+	case cp.Version != PortableCheckpointVersion:
+		return location, nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("version %d is not supported, only version %d is supported", cp.Version, PortableCheckpointVersion))
+	case len(cp.Location.BinlogName) == 0:
+		return location, nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate("binlog name is empty")
+	case cp.Flavor != s.cfg.Flavor:
+		return location, nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("it's exported from upstream of flavor %s, but the flavor of upstream is %s", cp.Flavor, s.cfg.Flavor))
+	case s.enableGTID() && len(cp.Location.BinlogGTID) == 0:
+		return location, nil, terror.ErrSyncerInvalidPortableCheckpoint.Generate("GTID is enabled but the checkpoint has no GTID set")
+	}
+
+	if location, err = cp.Location.location(s.cfg.Flavor); err != nil {
+		return location, nil, err
+	}
+	if cp.SafeModeExitLocation != nil {
+		exit, err2 := cp.SafeModeExitLocation.location(s.cfg.Flavor)
+		if err2 != nil {
+			return location, nil, err2
+		}
+		exitLocation = &exit
+	}
+	return location, exitLocation, nil
+}
+
+// ImportCheckpoint replaces the global checkpoint and the safe mode exit point of the paused syncer with the ones
+// exported by ExportCheckpoint and flushes them, the syncer replicates from the imported checkpoint after resumed.
+// the imported checkpoint is validated to be compatible with upstream, that is the flavor is the same, the location
+// isn't later than upstream master, and its binlog isn't purged in upstream (or its GTID set is contained by the one
+// of upstream master if GTID is enabled).
+func (s *Syncer) ImportCheckpoint(ctx context.Context, data []byte) (from, to binlog.Location, err error) {
+	from = s.checkpoint.FlushedGlobalPoint()
+	to, exitLocation, err := s.parsePortableCheckpoint(data)
+	if err != nil {
+		return from, to, err
+	}
+	if s.fromDB == nil {
+		return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate("the syncer is not initialized")
+	}
+	if ddls := s.PendingShardDDLs(); len(ddls) > 0 {
+		return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate("there are pending sharding DDLs")
+	}
+	for schema, tables := range s.checkpoint.TablePoint() {
+		for table, point := range tables {
+			if binlog.CompareLocation(point, to, s.enableGTID()) > 0 {
+				return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf(
+					"the checkpoint of table `%s`.`%s` %s is later than the imported one %s", schema, table, point, to))
+			}
+		}
+	}
+
+	pos, gs, err := s.getMasterStatus(ctx)
+	if err != nil {
+		return from, to, err
+	}
+	master := binlog.InitLocation(pos, gs)
+	if binlog.CompareLocation(to, master, s.enableGTID()) > 0 {
+		return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("the imported one %s is later than upstream master %s", to, master))
+	}
+	if s.enableGTID() {
+		if gs != nil && !gs.Contain(to.GetGTID()) {
+			return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("the GTID sets of upstream master %s don't contain the imported one %s", master.GTIDSetStr(), to.GTIDSetStr()))
+		}
+	} else {
+		files, err2 := getBinaryLogs(ctx, s.fromDB.BaseDB.DB)
+		if err2 != nil {
+			return from, to, err2
+		}
+		report, err2 := binlogGap(to.Position, files)
+		if err2 != nil {
+			return from, to, err2
+		}
+		if !report.Available {
+			return from, to, terror.ErrSyncerInvalidPortableCheckpoint.Generate(fmt.Sprintf("the binlog %s is not available in upstream, the earliest one is %s", to.Position.Name, report.EarliestBinlog))
+		}
+	}
+
+	s.tctx.L().Warn("import the checkpoint exported in portable format", zap.Stringer("from", from), zap.Stringer("to", to), zap.Reflect("safe mode exit location", exitLocation))
+	oldExitLocation := s.checkpoint.SafeModeExitPoint()
+	s.checkpoint.SaveGlobalPoint(to)
+	s.checkpoint.SaveSafeModeExitPoint(exitLocation)
+	if err = s.checkpoint.FlushPointsExcept(s.tctx.WithContext(ctx), nil, nil, nil); err != nil {
+		s.checkpoint.Rollback(s.schemaTracker)
+		s.checkpoint.SaveSafeModeExitPoint(oldExitLocation)
+		return from, to, err
+	}
+	return from, to, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/retry"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+func (s *testSyncerSuite) TestExportImportCheckpoint(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	checkPointDB, checkPointMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	checkPointDBConn, err := checkPointDB.Conn(context.Background())
+	c.Assert(err, IsNil)
+
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.EnableGTID = false
+	syncer := NewSyncer(cfg, nil)
+	syncer.checkpoint.(*RemoteCheckPoint).dbConn = &DBConn{cfg: cfg, baseConn: conn.NewBaseConn(checkPointDBConn, &retry.FiniteRetryStrategy{})}
+
+	// no checkpoint yet.
+	_, err = syncer.ExportCheckpoint()
+	c.Assert(terror.ErrSyncerInvalidPortableCheckpoint.Equal(err), IsTrue)
+
+	gs, err := gtid.ParserGTID(cfg.Flavor, "")
+	c.Assert(err, IsNil)
+	loc1 := binlog.InitLocation(mysql.Position{Name: "mysql-bin|000001.000002", Pos: 1234}, gs)
+	exitLoc := binlog.InitLocation(mysql.Position{Name: "mysql-bin|000001.000002", Pos: 2345}, gs)
+	checkPointMock.ExpectBegin()
+	checkPointMock.ExpectExec("INSERT INTO .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	checkPointMock.ExpectCommit()
+	syncer.checkpoint.SaveGlobalPoint(loc1)
+	syncer.checkpoint.SaveSafeModeExitPoint(&exitLoc)
+	c.Assert(syncer.checkpoint.FlushPointsExcept(tcontext.Background(), nil, nil, nil), IsNil)
+
+	// the relay log sub directory suffix is removed.
+	data, err := syncer.ExportCheckpoint()
+	c.Assert(err, IsNil)
+	var cp PortableCheckpoint
+	c.Assert(json.Unmarshal(data, &cp), IsNil)
+	c.Assert(cp, DeepEquals, PortableCheckpoint{
+		Version:              PortableCheckpointVersion,
+		Task:                 cfg.Name,
+		SourceID:             cfg.SourceID,
+		Flavor:               cfg.Flavor,
+		Location:             PortableLocation{BinlogName: "mysql-bin.000002", BinlogPos: 1234},
+		SafeModeExitLocation: &PortableLocation{BinlogName: "mysql-bin.000002", BinlogPos: 2345},
+	})
+
+	// incompatible.
+	ctx := context.Background()
+	encode := func(cp PortableCheckpoint) []byte {
+		data, err2 := json.Marshal(cp)
+		c.Assert(err2, IsNil)
+		return data
+	}
+	cp2 := cp
+	cp2.Version = PortableCheckpointVersion + 1
+	_, _, err = syncer.ImportCheckpoint(ctx, encode(cp2))
+	c.Assert(err, ErrorMatches, ".*version 2 is not supported.*")
+	cp2 = cp
+	cp2.Flavor = mysql.MariaDBFlavor
+	_, _, err = syncer.ImportCheckpoint(ctx, encode(cp2))
+	c.Assert(err, ErrorMatches, ".*flavor mariadb.*")
+	_, _, err = syncer.ImportCheckpoint(ctx, []byte("{"))
+	c.Assert(err, ErrorMatches, ".*fail to decode.*")
+	_, _, err = syncer.ImportCheckpoint(ctx, data)
+	c.Assert(err, ErrorMatches, ".*the syncer is not initialized.*")
+
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	mockMasterStatus := func(name string, pos uint32) {
+		mock.ExpectQuery("SHOW MASTER STATUS").WillReturnRows(
+			sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}).AddRow(name, pos, "", "", ""))
+	}
+	mockBinaryLogs := func(names ...string) {
+		rows := sqlmock.NewRows([]string{"Log_name", "File_size"})
+		for _, name := range names {
+			rows.AddRow(name, 10000)
+		}
+		mock.ExpectQuery("SHOW BINARY LOGS").WillReturnRows(rows)
+	}
+
+	// later than upstream master.
+	mockMasterStatus("mysql-bin.000002", 1000)
+	_, _, err = syncer.ImportCheckpoint(ctx, data)
+	c.Assert(err, ErrorMatches, ".*later than upstream master.*")
+
+	// the binlog is purged.
+	mockMasterStatus("mysql-bin.000004", 1000)
+	mockBinaryLogs("mysql-bin.000003", "mysql-bin.000004")
+	_, _, err = syncer.ImportCheckpoint(ctx, data)
+	c.Assert(err, ErrorMatches, ".*mysql-bin.000002 is not available in upstream.*")
+	c.Assert(syncer.checkpoint.GlobalPoint().Position, DeepEquals, loc1.Position)
+
+	// imported.
+	cp2 = cp
+	cp2.Location = PortableLocation{BinlogName: "mysql-bin.000003", BinlogPos: 4567}
+	cp2.SafeModeExitLocation = nil
+	mockMasterStatus("mysql-bin.000004", 1000)
+	mockBinaryLogs("mysql-bin.000002", "mysql-bin.000003", "mysql-bin.000004")
+	checkPointMock.ExpectBegin()
+	checkPointMock.ExpectExec("INSERT INTO .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	checkPointMock.ExpectCommit()
+	from, to, err := syncer.ImportCheckpoint(ctx, encode(cp2))
+	c.Assert(err, IsNil)
+	c.Assert(from.Position, DeepEquals, loc1.Position)
+	c.Assert(to.Position, DeepEquals, mysql.Position{Name: "mysql-bin.000003", Pos: 4567})
+	c.Assert(syncer.checkpoint.FlushedGlobalPoint().Position, DeepEquals, to.Position)
+	c.Assert(syncer.checkpoint.SafeModeExitPoint(), IsNil)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+	c.Assert(checkPointMock.ExpectationsWereMet(), IsNil)
+}
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/conn"
 	tcontext "github.com/pingcap/dm/pkg/context"
 	parserpkg "github.com/pingcap/dm/pkg/parser"
 	"github.com/pingcap/dm/pkg/terror"
@@ -35,9 +36,9 @@ type PT struct {
 }
 
 // NewPT returns pt online schema changes plugin
-func NewPT(tctx *tcontext.Context, cfg *config.SubTaskConfig) (OnlinePlugin, error) {
+func NewPT(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbProvider conn.DBProvider) (OnlinePlugin, error) {
 	g := &PT{
-		storge: NewOnlineDDLStorage(tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("online ddl", "pt-ost"))), cfg), // create a context for logger
+		storge: NewOnlineDDLStorage(tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("online ddl", "pt-ost"))), cfg, dbProvider), // create a context for logger
 	}
 
 	return g, g.storge.Init(tctx)
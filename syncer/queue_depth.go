@@ -0,0 +1,64 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+// QueueDepth represents the fill level of a queue in the sync pipeline.
+type QueueDepth struct {
+	Length   int `json:"length"`
+	Capacity int `json:"capacity"`
+	// Fill is Length / Capacity, it's 0 if the capacity is unknown.
+	Fill float64 `json:"fill"`
+}
+
+func newQueueDepth(length, capacity int) QueueDepth {
+	d := QueueDepth{Length: length, Capacity: capacity}
+	if capacity > 0 {
+		d.Fill = float64(length) / float64(capacity)
+	}
+	return d
+}
+
+// QueueDepths represents the fill levels of the queues between the stages of the sync pipeline.
+// the write queues being full consistently means downstream is the bottleneck, and the read queue being empty means
+// upstream is the bottleneck.
+type QueueDepths struct {
+	// Read is the binlog events read and buffered in the streamer, it's only measured when reading relay log.
+	Read QueueDepth `json:"read"`
+	// DML are the DML jobs dispatched to each worker but not written to downstream yet.
+	DML []QueueDepth `json:"dml"`
+	// DDL is the DDL jobs not written to downstream yet.
+	DDL QueueDepth `json:"ddl"`
+}
+
+// QueueDepths returns the current fill levels of the queues in the sync pipeline.
+func (s *Syncer) QueueDepths() QueueDepths {
+	qd := QueueDepths{DML: make([]QueueDepth, 0, s.cfg.WorkerCount)}
+	if s.streamerController != nil {
+		qd.Read = newQueueDepth(s.streamerController.BufferedEvents())
+	}
+
+	s.jobsChanLock.Lock()
+	defer s.jobsChanLock.Unlock()
+	if s.jobsClosed.Get() {
+		return qd
+	}
+	for i, ch := range s.jobs {
+		if i == s.cfg.WorkerCount {
+			qd.DDL = newQueueDepth(len(ch), cap(ch))
+		} else {
+			qd.DML = append(qd.DML, newQueueDepth(len(ch), cap(ch)))
+		}
+	}
+	return qd
+}
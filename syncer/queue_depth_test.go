@@ -0,0 +1,49 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testQueueDepthSuite{})
+
+type testQueueDepthSuite struct{}
+
+func (t *testQueueDepthSuite) TestQueueDepths(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-queue-depth"}
+	cfg.WorkerCount, cfg.QueueSize = 2, 4
+	s := NewSyncer(cfg, nil)
+	qd := s.QueueDepths()
+	c.Assert(qd.DML, HasLen, 0)
+	c.Assert(qd.DDL, Equals, QueueDepth{})
+
+	s.newJobChans(cfg.WorkerCount + 1)
+	defer s.closeJobChans()
+	for i := 0; i < 4; i++ {
+		s.jobs[0] <- &job{tp: insert}
+	}
+	s.jobs[1] <- &job{tp: insert}
+	s.jobs[2] <- &job{tp: ddl}
+
+	qd = s.QueueDepths()
+	c.Assert(qd.Read, Equals, QueueDepth{})
+	c.Assert(qd.DML, DeepEquals, []QueueDepth{
+		{Length: 4, Capacity: 4, Fill: 1},
+		{Length: 1, Capacity: 4, Fill: 0.25},
+	})
+	c.Assert(qd.DDL, Equals, QueueDepth{Length: 1, Capacity: 4, Fill: 0.25})
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+)
+
+// SetReadOnly sets whether the syncer is in read-only mode.
+// in read-only mode, the syncer keeps reading binlog events and buffers jobs in its job channels, but holds all writes
+// (including checkpoint flushing) to downstream, reading is paused when the job channels are full.
+// the held writes are executed after read-only mode cleared.
+func (s *Syncer) SetReadOnly(readOnly bool) {
+	s.writeHold.Lock()
+	defer s.writeHold.Unlock()
+	if readOnly {
+		if s.writeHold.released == nil {
+			s.writeHold.released = make(chan struct{})
+			s.tctx.L().Info("enter read-only mode, writes to downstream are held")
+		}
+		return
+	}
+	if s.writeHold.released != nil {
+		close(s.writeHold.released)
+		s.writeHold.released = nil
+		s.tctx.L().Info("leave read-only mode, held writes will be executed")
+	}
+}
+
+// IsReadOnly returns whether the syncer is in read-only mode.
+func (s *Syncer) IsReadOnly() bool {
+	s.writeHold.RLock()
+	defer s.writeHold.RUnlock()
+	return s.writeHold.released != nil
+}
+
+// waitWritable blocks until read-only mode cleared or ctx done, jobCount is the number of jobs held by the caller.
+func (s *Syncer) waitWritable(ctx context.Context, jobCount int) error {
+	s.writeHold.RLock()
+	released := s.writeHold.released
+	s.writeHold.RUnlock()
+	if released == nil {
+		return nil
+	}
+
+	s.heldJobs.Add(int64(jobCount))
+	defer s.heldJobs.Add(-int64(jobCount))
+	select {
+	case <-released:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+func (s *testSyncerSuite) TestReadOnly(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.QueueSize = 10
+	syncer := NewSyncer(cfg, nil)
+	syncer.newJobChans(2)
+	defer syncer.closeJobChans()
+
+	// not in read-only mode by default
+	c.Assert(syncer.IsReadOnly(), IsFalse)
+	c.Assert(syncer.waitWritable(context.Background(), 1), IsNil)
+	c.Assert(syncer.BufferedJobCount(), Equals, 0)
+
+	syncer.jobs[0] <- &job{tp: insert}
+	syncer.jobs[1] <- &job{tp: insert}
+	c.Assert(syncer.BufferedJobCount(), Equals, 2)
+
+	// writes are held until read-only mode cleared
+	syncer.SetReadOnly(true)
+	syncer.SetReadOnly(true) // set again has no effect
+	c.Assert(syncer.IsReadOnly(), IsTrue)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- syncer.waitWritable(context.Background(), 3)
+	}()
+	c.Assert(utils.WaitSomething(30, 10*time.Millisecond, func() bool {
+		return syncer.BufferedJobCount() == 5
+	}), IsTrue)
+	select {
+	case <-errCh:
+		c.Fatal("writes should be held in read-only mode")
+	default:
+	}
+
+	syncer.SetReadOnly(false)
+	c.Assert(syncer.IsReadOnly(), IsFalse)
+	c.Assert(<-errCh, IsNil)
+	c.Assert(syncer.BufferedJobCount(), Equals, 2)
+
+	// context done when holding writes
+	syncer.SetReadOnly(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.Assert(syncer.waitWritable(ctx, 1), Equals, context.DeadlineExceeded)
+	c.Assert(syncer.BufferedJobCount(), Equals, 2)
+	syncer.SetReadOnly(false)
+
+	// no buffered jobs in channels after closed
+	syncer.closeJobChans()
+	c.Assert(syncer.BufferedJobCount(), Equals, 0)
+}
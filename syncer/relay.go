@@ -133,3 +133,27 @@ func (s *Syncer) removeActiveRelayLog() {
 	s.readerHub.RemoveActiveRelayLog(s.cfg.Name)
 	s.tctx.L().Info("current earliest active relay log", log.WrapStringerField("active relay log", s.readerHub.EarliestActiveRelayLog()))
 }
+
+// RelayReadPos returns the relay sub directory and the position in it the syncer has read to, the sub directory is
+// empty if the syncer doesn't read binlog from relay log or hasn't started to read.
+func (s *Syncer) RelayReadPos() (string, mysql.Position, error) {
+	pos := s.CurrentLocation().Position
+	if s.binlogType != LocalBinlog || len(pos.Name) == 0 {
+		return "", mysql.Position{}, nil
+	}
+
+	indexPath := filepath.Join(s.cfg.RelayDir, utils.UUIDIndexFilename)
+	uuids, err := utils.ParseUUIDIndex(indexPath)
+	if err != nil {
+		return "", mysql.Position{}, terror.Annotatef(err, "UUID index file path %s", indexPath)
+	}
+	if len(uuids) == 0 {
+		return "", mysql.Position{}, terror.ErrRelayNoValidRelaySubDir.Generate(s.cfg.RelayDir)
+	}
+
+	uuid, _, pos, err := binlog.ExtractPos(pos, uuids)
+	if err != nil {
+		return "", mysql.Position{}, err
+	}
+	return uuid, pos, nil
+}
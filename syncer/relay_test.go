@@ -0,0 +1,74 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var _ = Suite(&testRelaySuite{})
+
+type testRelaySuite struct{}
+
+func (t *testRelaySuite) TestRelayReadPos(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-relay-read-pos", Flavor: mysql.MySQLFlavor, RelayDir: c.MkDir()}
+	syncer := NewSyncer(cfg, nil)
+	setCurrentPos := func(pos mysql.Position) {
+		syncer.currentLocationMu.Lock()
+		syncer.currentLocationMu.currentLocation = binlog.InitLocation(pos, nil)
+		syncer.currentLocationMu.Unlock()
+	}
+	pos := mysql.Position{Name: "mysql-bin|000001.000003", Pos: 1234}
+	setCurrentPos(pos)
+
+	// not read from relay log.
+	uuid, readPos, err := syncer.RelayReadPos()
+	c.Assert(err, IsNil)
+	c.Assert(uuid, Equals, "")
+	c.Assert(readPos, DeepEquals, mysql.Position{})
+
+	syncer.binlogType = LocalBinlog
+	_, _, err = syncer.RelayReadPos()
+	c.Assert(terror.ErrRelayNoValidRelaySubDir.Equal(err), IsTrue)
+
+	uuids := []string{"3ccc475b-2343-11e7-be21-6c0b84d59f30.000001", "53ea0ed1-9bf8-11e6-8bea-64006a897c73.000002"}
+	err = ioutil.WriteFile(filepath.Join(cfg.RelayDir, utils.UUIDIndexFilename), []byte(uuids[0]+"\n"+uuids[1]+"\n"), 0644)
+	c.Assert(err, IsNil)
+	uuid, readPos, err = syncer.RelayReadPos()
+	c.Assert(err, IsNil)
+	c.Assert(uuid, Equals, uuids[0])
+	c.Assert(readPos, DeepEquals, mysql.Position{Name: "mysql-bin.000003", Pos: 1234})
+
+	// without UUID suffix, the latest sub directory is used.
+	setCurrentPos(mysql.Position{Name: "mysql-bin.000004", Pos: 4})
+	uuid, readPos, err = syncer.RelayReadPos()
+	c.Assert(err, IsNil)
+	c.Assert(uuid, Equals, uuids[1])
+	c.Assert(readPos, DeepEquals, mysql.Position{Name: "mysql-bin.000004", Pos: 4})
+
+	// hasn't started to read.
+	setCurrentPos(mysql.Position{})
+	uuid, _, err = syncer.RelayReadPos()
+	c.Assert(err, IsNil)
+	c.Assert(uuid, Equals, "")
+}
@@ -15,6 +15,7 @@ package mode
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"go.uber.org/zap"
@@ -89,6 +90,18 @@ func (m *SafeMode) Enable() bool {
 	return m.count != 0
 }
 
+// Tables returns the tables enabling safe mode by IncrForTable, sorted by name.
+func (m *SafeMode) Tables() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tables := make([]string, 0, len(m.tables))
+	for t := range m.tables {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
 // setCount sets the count, called internal
 func (m *SafeMode) setCount(tctx *tcontext.Context, n int32) error {
 	if n < 0 {
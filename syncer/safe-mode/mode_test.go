@@ -62,9 +62,11 @@ func (t *testModeSuite) TestMode(c *C) {
 	err = m.IncrForTable(tctx, schema, table) // re-Add
 	c.Assert(err, IsNil)
 	c.Assert(m.Enable(), IsTrue)
+	c.Assert(m.Tables(), DeepEquals, []string{"`schema`.`table`"})
 	err = m.DescForTable(tctx, schema, table)
 	c.Assert(err, IsNil)
 	c.Assert(m.Enable(), IsFalse)
+	c.Assert(m.Tables(), HasLen, 0)
 
 	// Add n + IncrForTable
 	err = m.Add(tctx, 100)
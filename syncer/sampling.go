@@ -0,0 +1,150 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/siddontang/go-mysql/replication"
+	"github.com/siddontang/go/sync2"
+
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+const (
+	defaultMaxSampledEvents = 100
+	maxSampledEventsLimit   = 10000
+)
+
+// SamplingOpts represents options of sampling binlog events processed by the syncer.
+type SamplingOpts struct {
+	Interval  int    `json:"interval"`   // sample every Nth matched event, 0 or 1 means every event
+	Schema    string `json:"schema"`     // only sample events of this upstream schema if not empty
+	Table     string `json:"table"`      // only sample row events of this upstream table if not empty
+	MaxEvents int    `json:"max-events"` // the max number of sampled events kept, 100 by default
+}
+
+// SampledEvent represents a sampled binlog event.
+type SampledEvent struct {
+	Time     time.Time       `json:"time"`
+	Location string          `json:"location"`
+	Type     string          `json:"type"`
+	Schema   string          `json:"schema"`
+	Table    string          `json:"table,omitempty"`
+	Query    string          `json:"query,omitempty"` // for query events
+	Rows     [][]interface{} `json:"rows,omitempty"`  // for row events, the full row data
+}
+
+// eventSampler samples row and query events into a bounded buffer, it does nothing until enabled.
+type eventSampler struct {
+	enabled sync2.AtomicBool
+
+	sync.Mutex
+	opts   SamplingOpts
+	count  int
+	events []SampledEvent
+}
+
+func (es *eventSampler) start(opts SamplingOpts) error {
+	if opts.Interval < 0 {
+		return terror.ErrSyncerInvalidSamplingOpts.Generate(opts, "interval should not be negative")
+	}
+	if opts.MaxEvents < 0 || opts.MaxEvents > maxSampledEventsLimit {
+		return terror.ErrSyncerInvalidSamplingOpts.Generate(opts, fmt.Sprintf("max events should be in [0, %d]", maxSampledEventsLimit))
+	}
+	if opts.Interval == 0 {
+		opts.Interval = 1
+	}
+	if opts.MaxEvents == 0 {
+		opts.MaxEvents = defaultMaxSampledEvents
+	}
+
+	es.Lock()
+	defer es.Unlock()
+	es.opts = opts
+	es.count = 0
+	es.events = make([]SampledEvent, 0, opts.MaxEvents)
+	es.enabled.Set(true)
+	return nil
+}
+
+// stop stops sampling, the sampled events are kept until the next start.
+func (es *eventSampler) stop() {
+	es.enabled.Set(false)
+}
+
+func (es *eventSampler) sample(e *replication.BinlogEvent, location binlog.Location) {
+	sampled := SampledEvent{Type: e.Header.EventType.String()}
+	switch ev := e.Event.(type) {
+	case *replication.RowsEvent:
+		sampled.Schema = string(ev.Table.Schema)
+		sampled.Table = string(ev.Table.Table)
+		sampled.Rows = ev.Rows
+	case *replication.QueryEvent:
+		sampled.Schema = string(ev.Schema)
+		sampled.Query = string(ev.Query)
+	default:
+		return
+	}
+
+	es.Lock()
+	defer es.Unlock()
+	if !es.enabled.Get() {
+		return // stopped after checked
+	}
+	if len(es.opts.Schema) > 0 && es.opts.Schema != sampled.Schema {
+		return
+	}
+	if len(es.opts.Table) > 0 && es.opts.Table != sampled.Table {
+		return
+	}
+	es.count++
+	if es.count%es.opts.Interval != 0 {
+		return
+	}
+
+	sampled.Time = time.Now()
+	sampled.Location = location.String()
+	if len(es.events) >= es.opts.MaxEvents {
+		copy(es.events, es.events[1:])
+		es.events = es.events[:len(es.events)-1]
+	}
+	es.events = append(es.events, sampled)
+}
+
+func (es *eventSampler) sampledEvents() []SampledEvent {
+	es.Lock()
+	defer es.Unlock()
+	events := make([]SampledEvent, len(es.events))
+	copy(events, es.events)
+	return events
+}
+
+// StartEventSampling starts sampling binlog events processed by the syncer, the previous sampled events are discarded.
+func (s *Syncer) StartEventSampling(opts SamplingOpts) error {
+	return s.sampler.start(opts)
+}
+
+// StopEventSampling stops sampling binlog events, the sampled events can still be got.
+func (s *Syncer) StopEventSampling() {
+	s.sampler.stop()
+}
+
+// SampledEvents returns the sampled binlog events, from the oldest to the newest.
+func (s *Syncer) SampledEvents() []SampledEvent {
+	return s.sampler.sampledEvents()
+}
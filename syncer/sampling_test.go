@@ -0,0 +1,78 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/pkg/binlog"
+)
+
+func (s *testSyncerSuite) TestEventSampling(c *C) {
+	syncer := NewSyncer(s.cfg, nil)
+	loc := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 4}, nil)
+	rowsEvent := func(table string, id int) *replication.BinlogEvent {
+		return &replication.BinlogEvent{
+			Header: &replication.EventHeader{EventType: replication.WRITE_ROWS_EVENTv2},
+			Event: &replication.RowsEvent{
+				Table: &replication.TableMapEvent{Schema: []byte("db1"), Table: []byte(table)},
+				Rows:  [][]interface{}{{id, "test"}},
+			},
+		}
+	}
+	queryEvent := &replication.BinlogEvent{
+		Header: &replication.EventHeader{EventType: replication.QUERY_EVENT},
+		Event:  &replication.QueryEvent{Schema: []byte("db1"), Query: []byte("ALTER TABLE tb1 ADD COLUMN c INT")},
+	}
+
+	// disabled by default
+	c.Assert(syncer.sampler.enabled.Get(), IsFalse)
+	c.Assert(syncer.SampledEvents(), HasLen, 0)
+
+	// invalid options
+	c.Assert(syncer.StartEventSampling(SamplingOpts{Interval: -1}), ErrorMatches, ".*interval should not be negative.*")
+	c.Assert(syncer.StartEventSampling(SamplingOpts{MaxEvents: maxSampledEventsLimit + 1}), ErrorMatches, ".*max events should be in.*")
+	c.Assert(syncer.sampler.enabled.Get(), IsFalse)
+
+	// sample every 2nd row event of db1.tb1, and keep 2 events at most
+	c.Assert(syncer.StartEventSampling(SamplingOpts{Interval: 2, Schema: "db1", Table: "tb1", MaxEvents: 2}), IsNil)
+	for i := 1; i <= 6; i++ {
+		syncer.sampler.sample(rowsEvent("tb1", i), loc)
+		syncer.sampler.sample(rowsEvent("tb2", i), loc)
+	}
+	syncer.sampler.sample(queryEvent, loc)
+	events := syncer.SampledEvents()
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].Rows, DeepEquals, [][]interface{}{{4, "test"}})
+	c.Assert(events[1].Rows, DeepEquals, [][]interface{}{{6, "test"}})
+	c.Assert(events[1].Schema, Equals, "db1")
+	c.Assert(events[1].Table, Equals, "tb1")
+	c.Assert(events[1].Type, Equals, replication.WRITE_ROWS_EVENTv2.String())
+	c.Assert(events[1].Location, Equals, loc.String())
+
+	// sample all events of the schema
+	c.Assert(syncer.StartEventSampling(SamplingOpts{Schema: "db1"}), IsNil)
+	syncer.sampler.sample(rowsEvent("tb2", 1), loc)
+	syncer.sampler.sample(queryEvent, loc)
+	events = syncer.SampledEvents()
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[1].Query, Equals, "ALTER TABLE tb1 ADD COLUMN c INT")
+
+	// stop sampling, the sampled events are kept
+	syncer.StopEventSampling()
+	syncer.sampler.sample(rowsEvent("tb1", 1), loc)
+	c.Assert(syncer.SampledEvents(), HasLen, 2)
+}
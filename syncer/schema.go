@@ -30,6 +30,16 @@ import (
 	"github.com/pingcap/dm/pkg/terror"
 )
 
+// TrackedCreateTable returns the `CREATE TABLE` statement of the table in the schema tracker, it's read-only and
+// can be called when the syncer is running. it's used to diagnose the drift between the tracker and downstream.
+func (s *Syncer) TrackedCreateTable(ctx context.Context, db, table string) (string, error) {
+	createTableStr, err := s.schemaTracker.GetCreateTableInNewSession(ctx, db, table)
+	if err != nil {
+		return "", terror.ErrSchemaTrackerCannotGetTable.Delegate(err, db, table)
+	}
+	return createTableStr, nil
+}
+
 // OperateSchema operates schema for an upstream table.
 func (s *Syncer) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchemaRequest) (createTableStr string, err error) {
 	switch req.Op {
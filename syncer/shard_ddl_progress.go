@@ -0,0 +1,117 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sort"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/shardddl/optimism"
+)
+
+// stages of coordinating a pending sharding DDL.
+const (
+	// ShardDDLWaitingTables means waiting for other upstream tables in this source to reach the DDL.
+	ShardDDLWaitingTables = "waiting-tables"
+	// ShardDDLWaitingLock means the DDL info has been put into etcd, and waiting for DM-master to resolve the lock.
+	ShardDDLWaitingLock = "waiting-lock"
+	// ShardDDLExecuting means DM-master asked this source to execute the DDL.
+	ShardDDLExecuting = "executing"
+	// ShardDDLSkipping means DM-master asked this source to skip the DDL, it's executed by the owner of the lock.
+	ShardDDLSkipping = "skipping"
+	// ShardDDLConflict means DM-master detected a conflict of the DDL with other upstream tables.
+	ShardDDLConflict = "conflict"
+)
+
+// PendingShardDDL represents a sharding DDL which is pending to be resolved in the syncer.
+type PendingShardDDL struct {
+	Task          string   `json:"task"`
+	Strategy      string   `json:"strategy"` // the shard mode, `pessimistic` or `optimistic`
+	Target        string   `json:"target"`   // the downstream table
+	DDLs          []string `json:"ddls"`
+	FirstLocation string   `json:"first-location,omitempty"` // the location of the first upstream table reached the DDL, only in pessimistic mode
+	Reached       []string `json:"reached"`                  // upstream tables which have reached the DDL
+	// upstream tables which haven't reached the DDL, only known in pessimistic mode.
+	// in optimistic mode, tables are coordinated by DM-master and the DDL is handled table by table.
+	Unreached []string `json:"unreached"`
+	Stage     string   `json:"stage"`
+}
+
+// PendingShardDDLs returns the sharding DDLs pending to be resolved, it's empty if not in sharding mode.
+func (s *Syncer) PendingShardDDLs() []PendingShardDDL {
+	ddls := make([]PendingShardDDL, 0)
+	switch s.cfg.ShardMode {
+	case config.ShardPessimistic:
+		info := s.pessimist.PendingInfo()
+		op := s.pessimist.PendingOperation()
+		for _, group := range s.sgk.UnresolvedGroups() {
+			ddl := PendingShardDDL{
+				Task:          s.cfg.Name,
+				Strategy:      s.cfg.ShardMode,
+				Target:        group.Target,
+				DDLs:          group.DDLs,
+				FirstLocation: group.FirstLocation,
+				Reached:       group.Synced,
+				Unreached:     group.Unsynced,
+				Stage:         ShardDDLWaitingTables,
+			}
+			if info != nil {
+				if target, _ := GenTableID(info.Schema, info.Table); target == group.Target {
+					switch {
+					case op == nil:
+						ddl.Stage = ShardDDLWaitingLock
+					case op.Exec:
+						ddl.Stage = ShardDDLExecuting
+					default:
+						ddl.Stage = ShardDDLSkipping
+					}
+				}
+			}
+			sort.Strings(ddl.Reached)
+			sort.Strings(ddl.Unreached)
+			ddls = append(ddls, ddl)
+		}
+	case config.ShardOptimistic:
+		info := s.optimist.PendingInfo()
+		if info == nil {
+			break
+		}
+		target, _ := GenTableID(info.DownSchema, info.DownTable)
+		upTable, _ := GenTableID(info.UpSchema, info.UpTable)
+		ddl := PendingShardDDL{
+			Task:     s.cfg.Name,
+			Strategy: s.cfg.ShardMode,
+			Target:   target,
+			DDLs:     info.DDLs,
+			Reached:  []string{upTable},
+			Stage:    ShardDDLWaitingLock,
+		}
+		if op := s.optimist.PendingOperation(); op != nil {
+			switch {
+			case op.ConflictStage == optimism.ConflictDetected:
+				ddl.Stage = ShardDDLConflict
+			case len(op.DDLs) == 0:
+				ddl.Stage = ShardDDLSkipping
+			default:
+				ddl.Stage = ShardDDLExecuting
+			}
+		}
+		ddls = append(ddls, ddl)
+	}
+
+	sort.Slice(ddls, func(i, j int) bool {
+		return ddls[i].Target < ddls[j].Target
+	})
+	return ddls
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+)
+
+func (s *testSyncerSuite) TestPendingShardDDLs(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+
+	// not in sharding mode
+	cfg.ShardMode = ""
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.PendingShardDDLs(), HasLen, 0)
+
+	// no pending DDL in optimistic mode
+	cfg.ShardMode = config.ShardOptimistic
+	syncer = NewSyncer(cfg, nil)
+	c.Assert(syncer.PendingShardDDLs(), HasLen, 0)
+
+	cfg.ShardMode = config.ShardPessimistic
+	syncer = NewSyncer(cfg, nil)
+	c.Assert(syncer.PendingShardDDLs(), HasLen, 0)
+
+	var (
+		source1  = "`db1`.`tbl1`"
+		source2  = "`db1`.`tbl2`"
+		source3  = "`db1`.`tbl3`"
+		ddls     = []string{"ALTER TABLE `db`.`tbl` ADD COLUMN c INT"}
+		location = binlog.Location{Position: mysql.Position{Name: "mysql-bin.000001", Pos: 123}}
+		endLoc   = binlog.Location{Position: mysql.Position{Name: "mysql-bin.000001", Pos: 456}}
+	)
+	_, _, _, _, err = syncer.sgk.AddGroup("db", "tbl", []string{source1, source2, source3}, nil, false)
+	c.Assert(err, IsNil)
+	c.Assert(syncer.PendingShardDDLs(), HasLen, 0)
+
+	_, _, _, _, _, err = syncer.sgk.TrySync("db", "tbl", source2, location, endLoc, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(syncer.PendingShardDDLs(), DeepEquals, []PendingShardDDL{{
+		Task:          cfg.Name,
+		Strategy:      config.ShardPessimistic,
+		Target:        "`db`.`tbl`",
+		DDLs:          ddls,
+		FirstLocation: location.String(),
+		Reached:       []string{source2},
+		Unreached:     []string{source1, source3},
+		Stage:         ShardDDLWaitingTables,
+	}})
+}
@@ -392,6 +392,8 @@ type ShardingGroupKeeper struct {
 
 	db     *conn.BaseDB
 	dbConn *DBConn
+	// dbProvider creates the DB of sharding meta, conn.DefaultDBProvider is used if it's nil
+	dbProvider conn.DBProvider
 
 	tctx *tcontext.Context
 }
@@ -446,7 +448,7 @@ func (k *ShardingGroupKeeper) Init() error {
 	k.clear()
 	sgkDB := k.cfg.To
 	sgkDB.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
-	db, dbConns, err := createConns(k.tctx, k.cfg, sgkDB, 1)
+	db, dbConns, err := createConns(k.tctx, k.cfg, k.dbProvider, sgkDB, 1)
 	if err != nil {
 		return err
 	}
@@ -17,7 +17,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/failpoint"
@@ -66,19 +68,29 @@ func (s *Syncer) Status(ctx context.Context) interface{} {
 		st.BinlogType = binlogTypeToString(s.streamerController.GetBinlogType())
 	}
 
-	if s.cfg.EnableGTID {
-		if masterGTIDSet != nil && syncerLocation.GetGTID() != nil && masterGTIDSet.Equal(syncerLocation.GetGTID()) {
-			st.Synced = true
-		}
-	} else {
-		// If a syncer unit is waiting for relay log catch up, it has not executed
-		// LoadMeta and will return a parsed binlog name error. As we can find mysql
-		// position in syncer status, we record this error only in debug level.
-		realPos, err := binlog.RealMySQLPos(syncerLocation.Position)
-		if err != nil {
-			s.tctx.L().Debug("fail to parse real mysql position", zap.Stringer("position", syncerLocation.Position), log.ShortError(err))
-		}
-		st.Synced = utils.CompareBinlogPos(masterPos, realPos, 0) == 0
+	st.Synced = s.caughtUp(masterPos, masterGTIDSet, syncerLocation)
+	st.BatchSize = int32(s.BatchSize())
+	st.WriteMode = s.WriteMode()
+	st.GtidMode = string(s.GTIDMode())
+	st.BufferedJobCount = int64(s.BufferedJobCount())
+	conflictRetries := s.ConflictRetryStats()
+	st.TotalConflictRetries = conflictRetries.Total
+	st.RecentConflictRetries = conflictRetries.Recent
+	for _, ddl := range s.SkippedUnsupportedDDLs() {
+		st.SkippedUnsupportedDDLs = append(st.SkippedUnsupportedDDLs, &pb.SkippedDDL{
+			Schema:    ddl.Schema,
+			Statement: ddl.Statement,
+			Location:  ddl.Location,
+			Time:      ddl.Time.Unix(),
+		})
+	}
+	for _, t := range s.cfg.ColumnTransforms {
+		st.ColumnTransforms = append(st.ColumnTransforms, &pb.ColumnTransform{
+			SchemaPattern: t.SchemaPattern,
+			TablePattern:  t.TablePattern,
+			Column:        t.Column,
+			Func:          string(t.Func),
+		})
 	}
 
 	// only support to show `UnresolvedGroups` in pessimistic mode now.
@@ -86,10 +98,7 @@ func (s *Syncer) Status(ctx context.Context) interface{} {
 		st.UnresolvedGroups = s.sgk.UnresolvedGroups()
 	}
 
-	pendingShardInfo := s.pessimist.PendingInfo()
-	if pendingShardInfo != nil {
-		st.BlockingDDLs = pendingShardInfo.DDLs
-	}
+	st.BlockingDDLs = s.blockingDDLs()
 
 	failpoint.Inject("BlockSyncStatus", func(val failpoint.Value) {
 		interval, err := time.ParseDuration(val.(string))
@@ -102,3 +111,217 @@ func (s *Syncer) Status(ctx context.Context) interface{} {
 	})
 	return st
 }
+
+// caughtUp returns whether the location of syncer has caught up with the binlog of upstream master.
+func (s *Syncer) caughtUp(masterPos mysql.Position, masterGTIDSet gtid.Set, syncerLocation binlog.Location) bool {
+	if s.enableGTID() {
+		return masterGTIDSet != nil && syncerLocation.GetGTID() != nil && masterGTIDSet.Equal(syncerLocation.GetGTID())
+	}
+	// If a syncer unit is waiting for relay log catch up, it has not executed
+	// LoadMeta and will return a parsed binlog name error. As we can find mysql
+	// position in syncer status, we record this error only in debug level.
+	realPos, err := binlog.RealMySQLPos(syncerLocation.Position)
+	if err != nil {
+		s.tctx.L().Debug("fail to parse real mysql position", zap.Stringer("position", syncerLocation.Position), log.ShortError(err))
+	}
+	return utils.CompareBinlogPos(masterPos, realPos, 0) == 0
+}
+
+// CheckpointAge returns how long since the checkpoint was flushed last time. it's 0 if the flushed checkpoint has
+// caught up with upstream, which means upstream is idle and there is nothing to checkpoint, or the checkpoint is not
+// flushed yet.
+func (s *Syncer) CheckpointAge(ctx context.Context) (time.Duration, error) {
+	flushedTime := s.checkpoint.LastFlushTime()
+	if flushedTime.IsZero() {
+		return 0, nil
+	}
+	masterPos, masterGTIDSet, err := s.getMasterStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if s.caughtUp(masterPos, masterGTIDSet, s.checkpoint.FlushedGlobalPoint()) {
+		return 0, nil
+	}
+	return time.Since(flushedTime), nil
+}
+
+// RecentTPS returns the number of binlog events processed per second in the last status interval.
+func (s *Syncer) RecentTPS() int64 {
+	return s.tps.Get()
+}
+
+// ConflictRetryStats represents the write conflict retries in downstream.
+type ConflictRetryStats struct {
+	Total  int64 `json:"total"`  // since the syncer started
+	Recent int64 `json:"recent"` // in the last status interval
+}
+
+// ConflictRetryStats returns the write conflict retries in downstream.
+func (s *Syncer) ConflictRetryStats() ConflictRetryStats {
+	return ConflictRetryStats{
+		Total:  s.conflictRetries.Get(),
+		Recent: s.recentConflictRetries.Get(),
+	}
+}
+
+// TargetStats represents the results of executing statements in downstream since the syncer started.
+// a failed execution is a transaction rolled back, it's counted again when the transaction is retried.
+type TargetStats struct {
+	Succeeded    int64 `json:"succeeded"`     // statements committed
+	Failed       int64 `json:"failed"`        // failed executions, including the following ones
+	Deadlock     int64 `json:"deadlock"`      // failed for deadlock
+	DuplicateKey int64 `json:"duplicate-key"` // failed for duplicate entry of PK or UK
+	Timeout      int64 `json:"timeout"`       // failed for lock wait timeout or network timeout
+}
+
+// targetStats counts the results of executing statements in downstream, it's safe for concurrent use.
+type targetStats struct {
+	succeeded    sync2.AtomicInt64
+	failed       sync2.AtomicInt64
+	deadlock     sync2.AtomicInt64
+	duplicateKey sync2.AtomicInt64
+	timeout      sync2.AtomicInt64
+}
+
+// record records the result of executing a transaction with the number of statements.
+// the execution is not counted if it's canceled, because it's not a failure of downstream.
+func (ts *targetStats) record(stmts int, err error) {
+	switch {
+	case err == nil:
+		ts.succeeded.Add(int64(stmts))
+		return
+	case errors.Cause(err) == context.Canceled:
+		return
+	}
+	ts.failed.Add(1)
+	switch {
+	case isDeadlockError(err):
+		ts.deadlock.Add(1)
+	case isDupEntryError(err):
+		ts.duplicateKey.Add(1)
+	case isTimeoutError(err):
+		ts.timeout.Add(1)
+	}
+}
+
+// TargetStats returns the results of executing statements in downstream.
+func (s *Syncer) TargetStats() TargetStats {
+	return TargetStats{
+		Succeeded:    s.targetStats.succeeded.Get(),
+		Failed:       s.targetStats.failed.Get(),
+		Deadlock:     s.targetStats.deadlock.Get(),
+		DuplicateKey: s.targetStats.duplicateKey.Get(),
+		Timeout:      s.targetStats.timeout.Get(),
+	}
+}
+
+// ActiveTransactionCount returns the number of transactions being executed in downstream,
+// they will be rolled back and replayed after restarting if the syncer is closed now.
+func (s *Syncer) ActiveTransactionCount() int {
+	return int(s.activeTxns.Get())
+}
+
+// HeartbeatLag returns the replication lag calculated from the heartbeat written in upstream,
+// it's HeartbeatLagDisabled if heartbeat is not enabled, or HeartbeatLagUnknown if the lag is not calculated yet.
+func (s *Syncer) HeartbeatLag() time.Duration {
+	if !s.cfg.EnableHeartbeat {
+		return HeartbeatLagDisabled
+	}
+	if s.heartbeat == nil {
+		return HeartbeatLagUnknown
+	}
+	lag, ok := s.heartbeat.Lag(s.cfg.Name)
+	if !ok {
+		return HeartbeatLagUnknown
+	}
+	return lag
+}
+
+// BufferedJobCount returns the number of jobs buffered in job channels or held by read-only mode, which are not executed in downstream yet.
+func (s *Syncer) BufferedJobCount() int {
+	count := int(s.heldJobs.Get())
+	s.jobsChanLock.Lock()
+	defer s.jobsChanLock.Unlock()
+	if s.jobsClosed.Get() {
+		return count
+	}
+	for _, ch := range s.jobs {
+		count += len(ch)
+	}
+	return count
+}
+
+// ReplicationLag returns the replication lag calculated from the timestamp of the latest binlog event received.
+// it's 0 if no jobs are buffered, so an idle upstream is not treated as lagging.
+// the clock skew of upstream is corrected if `correct-clock-skew` is set, see TimeSkew.
+func (s *Syncer) ReplicationLag() time.Duration {
+	ts := s.lastEventTS.Get()
+	if ts == 0 || s.BufferedJobCount() == 0 {
+		return 0
+	}
+	lag := time.Since(time.Unix(ts, 0)) + time.Duration(s.upstreamClockSkew.Get())
+	if lag < 0 { // the clock of upstream is ahead
+		return 0
+	}
+	return lag
+}
+
+// LatencyBreakdown represents the accumulated time spent in each stage of the sync pipeline since the syncer started.
+// stages except Read and Write are only measured for DML events.
+type LatencyBreakdown struct {
+	Syncing   bool          `json:"syncing"`   // false if the subtask is not in the sync phase, and other fields are zero
+	Read      time.Duration `json:"read"`      // reading and decoding binlog events from relay log or upstream
+	Filter    time.Duration `json:"filter"`    // checking block-allow-list and binlog event filter
+	Route     time.Duration `json:"route"`     // routing upstream tables to downstream tables
+	Transform time.Duration `json:"transform"` // column mapping and generating SQLs
+	Dispatch  time.Duration `json:"dispatch"`  // dispatching jobs to DML workers, it grows when the workers are busy
+	Write     time.Duration `json:"write"`     // executing SQLs in downstream, it's the sum of all connections
+}
+
+// LatencyBreakdown returns the time spent in each stage of the sync pipeline.
+func (s *Syncer) LatencyBreakdown() LatencyBreakdown {
+	return LatencyBreakdown{
+		Syncing:   true,
+		Read:      time.Duration(s.readDuration.Get()),
+		Filter:    time.Duration(s.filterDuration.Get()),
+		Route:     time.Duration(s.routeDuration.Get()),
+		Transform: time.Duration(s.transformDuration.Get()),
+		Dispatch:  time.Duration(s.dispatchDuration.Get()),
+		Write:     time.Duration(s.writeDuration.Get()),
+	}
+}
+
+// maxSkippedDDLs is the max number of skipped unsupported DDLs kept in memory.
+const maxSkippedDDLs = 100
+
+// SkippedDDL represents an unsupported DDL skipped by `unsupported-ddl-policy: skip`.
+type SkippedDDL struct {
+	Schema    string    `json:"schema"`
+	Statement string    `json:"statement"`
+	Location  string    `json:"location"`
+	Time      time.Time `json:"time"`
+}
+
+// SkippedUnsupportedDDLs returns the recent skipped unsupported DDLs, from the oldest to the newest.
+func (s *Syncer) SkippedUnsupportedDDLs() []SkippedDDL {
+	s.skippedDDLs.RLock()
+	defer s.skippedDDLs.RUnlock()
+	ddls := make([]SkippedDDL, len(s.skippedDDLs.ddls))
+	copy(ddls, s.skippedDDLs.ddls)
+	return ddls
+}
+
+// blockingDDLs returns the DDLs blocking the replication, including the sharding DDL waiting for other sources, the
+// frozen DDL and the DDLs waiting in a batch to be applied to downstream.
+func (s *Syncer) blockingDDLs() []string {
+	if pendingShardInfo := s.pessimist.PendingInfo(); pendingShardInfo != nil {
+		return pendingShardInfo.DDLs
+	}
+	if frozen := s.frozenDDL.get(); frozen != nil {
+		return frozen.DDLs
+	}
+	if backlog := s.DDLBacklog(); len(backlog) > 0 {
+		return backlog
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+)
+
+var _ = Suite(&testStatusSuite{})
+
+type testStatusSuite struct{}
+
+func (t *testStatusSuite) TestStatus(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	cfg := &config.SubTaskConfig{Name: "test-status", Flavor: mysql.MySQLFlavor}
+	syncer := NewSyncer(cfg, nil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	mock.ExpectQuery("SHOW MASTER STATUS").WillReturnRows(
+		sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}).AddRow("mysql-bin.000001", 1234, "", "", ""))
+
+	syncer.conflictRetries.Set(3)
+	syncer.recentConflictRetries.Set(1)
+	syncer.heldJobs.Set(2)
+	loc := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 4}, nil)
+	syncer.recordSkippedDDL("db", "CREATE TRIGGER tr BEFORE INSERT ON tb FOR EACH ROW SET NEW.c = 1", loc)
+
+	st := syncer.Status(context.Background()).(*pb.SyncStatus)
+	c.Assert(st.MasterBinlog, Equals, "(mysql-bin.000001, 1234)")
+	c.Assert(st.BufferedJobCount, Equals, int64(2))
+	c.Assert(st.TotalConflictRetries, Equals, int64(3))
+	c.Assert(st.RecentConflictRetries, Equals, int64(1))
+	c.Assert(st.SkippedUnsupportedDDLs, HasLen, 1)
+	c.Assert(st.SkippedUnsupportedDDLs[0].Schema, Equals, "db")
+	c.Assert(st.SkippedUnsupportedDDLs[0].Location, Equals, loc.String())
+	c.Assert(st.SkippedUnsupportedDDLs[0].Time, Equals, syncer.SkippedUnsupportedDDLs()[0].Time.Unix())
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// stepper halts the sync loop before processing each binlog event in stepping mode, until the event is allowed by
+// step. it's used to single-step the events for debugging, and is disabled by default.
+type stepper struct {
+	mu      sync.Mutex
+	enabled bool
+	// allowed is the number of events allowed to process before halting again.
+	allowed int
+	// notify is closed and recreated when stepping mode is exited or more events are allowed.
+	notify chan struct{}
+}
+
+func (st *stepper) setEnabled(enabled bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.enabled == enabled {
+		return
+	}
+	st.enabled = enabled
+	st.allowed = 0
+	st.broadcast()
+}
+
+func (st *stepper) isEnabled() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.enabled
+}
+
+func (st *stepper) step(n int) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if !st.enabled {
+		return terror.ErrSyncerNotInSteppingMode.Generate()
+	}
+	st.allowed += n
+	st.broadcast()
+	return nil
+}
+
+// broadcast wakes up the waiting sync loop, the lock should be held.
+func (st *stepper) broadcast() {
+	if st.notify != nil {
+		close(st.notify)
+	}
+	st.notify = make(chan struct{})
+}
+
+// wait waits until the next event is allowed to process or stepping mode is exited, it returns the error of ctx if
+// ctx is done before that.
+func (st *stepper) wait(ctx context.Context) error {
+	for {
+		st.mu.Lock()
+		if !st.enabled {
+			st.mu.Unlock()
+			return nil
+		}
+		if st.allowed > 0 {
+			st.allowed--
+			st.mu.Unlock()
+			return nil
+		}
+		if st.notify == nil {
+			st.notify = make(chan struct{})
+		}
+		notify := st.notify
+		st.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// SetStepping enters or exits stepping mode. in stepping mode, the syncer halts before processing each binlog event
+// (including heartbeat and rotate events), and processes the number of events allowed by Step. the mode is kept
+// after pausing and resuming until exited.
+func (s *Syncer) SetStepping(enabled bool) {
+	s.stepper.setEnabled(enabled)
+}
+
+// Stepping returns whether the syncer is in stepping mode.
+func (s *Syncer) Stepping() bool {
+	return s.stepper.isEnabled()
+}
+
+// Step allows n more binlog events to be processed in stepping mode.
+func (s *Syncer) Step(n int) error {
+	return s.stepper.step(n)
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testStepperSuite{})
+
+type testStepperSuite struct{}
+
+func (t *testStepperSuite) TestStepper(c *C) {
+	var st stepper
+	ctx := context.Background()
+
+	// not halted if not in stepping mode.
+	c.Assert(st.wait(ctx), IsNil)
+	c.Assert(terror.ErrSyncerNotInSteppingMode.Equal(st.step(1)), IsTrue)
+
+	st.setEnabled(true)
+	c.Assert(st.isEnabled(), IsTrue)
+	waited := make(chan error, 10)
+	waitOne := func() {
+		go func() {
+			waited <- st.wait(ctx)
+		}()
+	}
+	assertHalted := func() {
+		select {
+		case <-waited:
+			c.Fatal("should be halted")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	assertWaited := func() {
+		select {
+		case err := <-waited:
+			c.Assert(err, IsNil)
+		case <-time.After(time.Second):
+			c.Fatal("should not be halted")
+		}
+	}
+
+	// halted until stepped.
+	waitOne()
+	assertHalted()
+	c.Assert(st.step(2), IsNil)
+	assertWaited()
+	waitOne()
+	assertWaited()
+	waitOne()
+	assertHalted()
+
+	// released after exited.
+	st.setEnabled(false)
+	assertWaited()
+	c.Assert(st.wait(ctx), IsNil)
+
+	// steps allowed are reset after entered again.
+	st.setEnabled(true)
+	c.Assert(st.step(1), IsNil)
+	st.setEnabled(false)
+	st.setEnabled(true)
+	waitOne()
+	assertHalted()
+
+	// canceled.
+	ctx2, cancel := context.WithCancel(ctx)
+	cancel()
+	c.Assert(st.wait(ctx2), Equals, context.Canceled)
+	st.setEnabled(false)
+	assertWaited()
+}
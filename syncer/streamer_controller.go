@@ -22,10 +22,12 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/siddontang/go-mysql/mysql"
 	"github.com/siddontang/go-mysql/replication"
+	"github.com/siddontang/go/sync2"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/dm/pkg/binlog"
 	"github.com/pingcap/dm/pkg/binlog/common"
+	"github.com/pingcap/dm/pkg/conn"
 	tcontext "github.com/pingcap/dm/pkg/context"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/retry"
@@ -99,9 +101,16 @@ type StreamerController struct {
 	enableGTID     bool
 	localBinlogDir string
 	timezone       *time.Location
+	// archiveDir is the directory of archived relay log to read binlog from instead, see SetArchiveDir
+	archiveDir string
 
 	streamer         streamer.Streamer
 	streamerProducer StreamerProducer
+	// binlogConn tracks the binlog dump connection of the remote binlog reader
+	binlogConn conn.ConnActivity
+	// events and bytes of binlog events got, to estimate the memory of events buffered in the streamer
+	gotEvents     sync2.AtomicInt64
+	gotEventBytes sync2.AtomicInt64
 
 	// meetError means meeting error when get binlog event
 	// if binlogType is local and meetError is true, then need to create remote binlog stream
@@ -156,6 +165,18 @@ func (c *StreamerController) Start(tctx *tcontext.Context, location binlog.Locat
 	return nil
 }
 
+// SwitchToGTID makes the streamer read binlog by GTID sets, it resets the replication to the location if started.
+func (c *StreamerController) SwitchToGTID(tctx *tcontext.Context, location binlog.Location) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.enableGTID = true
+	if c.closed {
+		return nil
+	}
+	return c.resetReplicationSyncer(tctx, location)
+}
+
 // ResetReplicationSyncer reset the replication
 func (c *StreamerController) ResetReplicationSyncer(tctx *tcontext.Context, location binlog.Location) (err error) {
 	c.Lock()
@@ -191,6 +212,14 @@ func (c *StreamerController) resetReplicationSyncer(tctx *tcontext.Context, loca
 		}
 	}
 
+	if c.archiveDir != "" {
+		// never switch to remote binlog when replaying the archive, the events may have been purged in upstream.
+		c.currentBinlogType = LocalBinlog
+		c.streamerProducer = &localBinlogReader{streamer.NewBinlogReader(tctx.L(), &streamer.BinlogReaderConfig{RelayDir: c.archiveDir, Timezone: c.timezone, Flavor: c.syncCfg.Flavor}), c.enableGTID}
+		c.streamer, err = c.streamerProducer.generateStreamer(location)
+		return err
+	}
+
 	if c.initBinlogType == LocalBinlog && c.meetError {
 		// meetError is true means meets error when get binlog event, in this case use remote binlog as default
 		if !uuidSameWithUpstream {
@@ -209,9 +238,61 @@ func (c *StreamerController) resetReplicationSyncer(tctx *tcontext.Context, loca
 	}
 
 	c.streamer, err = c.streamerProducer.generateStreamer(location)
+	if err == nil && c.currentBinlogType == RemoteBinlog {
+		c.binlogConn.Start()
+	}
 	return err
 }
 
+// BinlogConnInfos returns the info of the binlog dump connection to upstream, it's empty if reading local relay log.
+func (c *StreamerController) BinlogConnInfos(addr string) []conn.ConnInfo {
+	return c.binlogConn.ConnInfos(conn.ConnPurposeBinlogRead, conn.ConnSideUpstream, addr)
+}
+
+// BufferedEventBytes returns the approximate bytes of binlog events buffered in the streamer by the average event size,
+// it's only measurable for the local relay log, and it's 0 for the remote binlog.
+func (c *StreamerController) BufferedEventBytes() int64 {
+	c.RLock()
+	s := c.streamer
+	c.RUnlock()
+	buffered, ok := s.(interface{ Len() int })
+	events := c.gotEvents.Get()
+	if !ok || events == 0 {
+		return 0
+	}
+	return int64(buffered.Len()) * (c.gotEventBytes.Get() / events)
+}
+
+// BufferedEvents returns the number of binlog events buffered in the streamer and the capacity of the buffer, both are
+// 0 if the streamer doesn't expose its buffer, like the one reading binlog from upstream.
+func (c *StreamerController) BufferedEvents() (length, capacity int) {
+	c.RLock()
+	s := c.streamer
+	c.RUnlock()
+	if buffered, ok := s.(interface {
+		Len() int
+		Cap() int
+	}); ok {
+		return buffered.Len(), buffered.Cap()
+	}
+	return 0, 0
+}
+
+// UpdateRelayDir updates the directory of local relay log, it takes effect after the streamer is reset.
+func (c *StreamerController) UpdateRelayDir(relayDir string) {
+	c.Lock()
+	defer c.Unlock()
+	c.localBinlogDir = relayDir
+}
+
+// SetArchiveDir makes the streamer read binlog from the archived relay log in dir instead of the relay log or upstream,
+// it takes effect after the streamer is reset, and binlog is read as before if dir is empty.
+func (c *StreamerController) SetArchiveDir(dir string) {
+	c.Lock()
+	defer c.Unlock()
+	c.archiveDir = dir
+}
+
 // RedirectStreamer redirects the streamer's begin position or gtid
 func (c *StreamerController) RedirectStreamer(tctx *tcontext.Context, location binlog.Location) error {
 	c.Lock()
@@ -258,6 +339,9 @@ func (c *StreamerController) GetEvent(tctx *tcontext.Context) (event *replicatio
 
 		return nil, err
 	}
+	c.binlogConn.Touch()
+	c.gotEvents.Add(1)
+	c.gotEventBytes.Add(int64(event.Header.EventSize))
 
 	switch ev := event.Event.(type) {
 	case *replication.RotateEvent:
@@ -309,6 +393,7 @@ func (c *StreamerController) closeBinlogSyncer(logtctx *tcontext.Context, binlog
 	if binlogSyncer == nil {
 		return nil
 	}
+	c.binlogConn.Stop()
 
 	lastSlaveConnectionID := binlogSyncer.LastConnectionID()
 	defer binlogSyncer.Close()
@@ -408,12 +493,26 @@ func (c *StreamerController) GetBinlogType() BinlogType {
 	return c.currentBinlogType
 }
 
+// ServerID returns the server id used to replicate binlog from upstream.
+func (c *StreamerController) ServerID() uint32 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.syncCfg.ServerID
+}
+
+// RenewServerID lets the streamer controller get a new random server id when it's started next time.
+func (c *StreamerController) RenewServerID() {
+	c.Lock()
+	defer c.Unlock()
+	c.serverIDUpdated = false
+}
+
 // CanRetry returns true if can switch from local to remote and retry again
 func (c *StreamerController) CanRetry() bool {
 	c.RLock()
 	defer c.RUnlock()
 
-	if c.initBinlogType == LocalBinlog && c.currentBinlogType == LocalBinlog {
+	if c.initBinlogType == LocalBinlog && c.currentBinlogType == LocalBinlog && c.archiveDir == "" {
 		return true
 	}
 
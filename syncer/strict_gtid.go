@@ -0,0 +1,83 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	"github.com/siddontang/go-mysql/mysql"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/terror"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// GTIDMode represents how the syncer handles GTID.
+type GTIDMode string
+
+// GTID modes of the syncer.
+const (
+	// GTIDModeDisabled means the syncer replicates by binlog positions.
+	GTIDModeDisabled GTIDMode = "disabled"
+	// GTIDModeEnabled means the syncer replicates by GTID sets, and fails if the needed binlog events are purged.
+	GTIDModeEnabled GTIDMode = "enabled"
+	// GTIDModeAutoFix means the purged binlog events are skipped by `auto-fix-gtid`.
+	GTIDModeAutoFix GTIDMode = "auto-fix"
+	// GTIDModeStrict means the syncer pauses with a GTID gap error if the needed binlog events are purged.
+	GTIDModeStrict GTIDMode = "strict"
+)
+
+// GTIDModeOf returns how the syncer of the subtask handles GTID according to the config.
+func GTIDModeOf(cfg *config.SubTaskConfig) GTIDMode {
+	return gtidModeOf(cfg, cfg.EnableGTID)
+}
+
+// GTIDMode returns how the syncer handles GTID, GTID mode is enabled if switched to it by `switch-to-gtid`.
+func (s *Syncer) GTIDMode() GTIDMode {
+	return gtidModeOf(s.cfg, s.enableGTID())
+}
+
+func gtidModeOf(cfg *config.SubTaskConfig, enableGTID bool) GTIDMode {
+	switch {
+	case !enableGTID:
+		return GTIDModeDisabled
+	case cfg.StrictGTID:
+		return GTIDModeStrict
+	case cfg.AutoFixGTID:
+		return GTIDModeAutoFix
+	default:
+		return GTIDModeEnabled
+	}
+}
+
+// gtidGapError returns the error of a GTID gap when the binlog events after location have been purged in upstream,
+// it contains the GTID set the syncer expected to continue from and the GTID set purged in upstream.
+func (s *Syncer) gtidGapError(tctx *tcontext.Context, location binlog.Location, err error) error {
+	purged := "unknown"
+	if s.cfg.Flavor == mysql.MySQLFlavor {
+		ctx, cancel := context.WithTimeout(tctx.Context(), utils.DefaultDBTimeout)
+		defer cancel()
+		value, err2 := utils.GetGlobalVariable(ctx, s.fromDB.BaseDB.DB, "gtid_purged")
+		if err2 != nil {
+			tctx.L().Warn("fail to get purged GTID set of upstream", zap.Error(err2))
+		} else {
+			purged = value
+		}
+	}
+	tctx.L().Error("GTID gap detected in strict GTID mode", zap.Stringer("location", location), zap.String("upstream purged GTID set", purged))
+	return terror.ErrSyncerGTIDGapDetected.Delegate(err, location.GTIDSetStr(), purged)
+}
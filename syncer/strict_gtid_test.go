@@ -0,0 +1,81 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/binlog"
+	"github.com/pingcap/dm/pkg/conn"
+	tcontext "github.com/pingcap/dm/pkg/context"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testStrictGTIDSuite{})
+
+type testStrictGTIDSuite struct{}
+
+func (t *testStrictGTIDSuite) TestGTIDMode(c *C) {
+	cases := []struct {
+		enableGTID  bool
+		autoFixGTID bool
+		strictGTID  bool
+		mode        GTIDMode
+	}{
+		{false, false, false, GTIDModeDisabled},
+		{false, true, true, GTIDModeDisabled},
+		{true, false, false, GTIDModeEnabled},
+		{true, true, false, GTIDModeAutoFix},
+		{true, false, true, GTIDModeStrict},
+		{true, true, true, GTIDModeStrict},
+	}
+	for _, cs := range cases {
+		cfg := &config.SubTaskConfig{}
+		cfg.EnableGTID = cs.enableGTID
+		cfg.AutoFixGTID = cs.autoFixGTID
+		cfg.StrictGTID = cs.strictGTID
+		c.Assert(GTIDModeOf(cfg), Equals, cs.mode)
+	}
+}
+
+func (t *testStrictGTIDSuite) TestGTIDGapError(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	gset, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	c.Assert(err, IsNil)
+	purgedErr := errors.New("ERROR 1236 (HY000): The slave is connecting using CHANGE MASTER TO MASTER_AUTO_POSITION = 1, but the master has purged binary logs containing GTIDs that the slave requires.")
+	location := binlog.InitLocation(mysql.Position{Name: "mysql-bin.000003", Pos: 1024}, gset)
+
+	syncer := &Syncer{cfg: &config.SubTaskConfig{Flavor: mysql.MySQLFlavor}}
+	syncer.cfg.EnableGTID = true
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(db, func() {})}
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'gtid_purged'").WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("gtid_purged", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-20"))
+	err = syncer.gtidGapError(tcontext.Background(), location, purgedErr)
+	c.Assert(terror.ErrSyncerGTIDGapDetected.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*expected to continue from GTID set 3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14, "+
+		"but upstream has purged GTID set 3ccc475b-2343-11e7-be21-6c0b84d59f30:1-20.*")
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+
+	// the purged GTID set is unknown for MariaDB.
+	syncer.cfg.Flavor = mysql.MariaDBFlavor
+	err = syncer.gtidGapError(tcontext.Background(), location, purgedErr)
+	c.Assert(terror.ErrSyncerGTIDGapDetected.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*has purged GTID set unknown.*")
+}
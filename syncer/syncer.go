@@ -54,6 +54,7 @@ import (
 	fr "github.com/pingcap/dm/pkg/func-rollback"
 	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/retry"
 	"github.com/pingcap/dm/pkg/schema"
 	"github.com/pingcap/dm/pkg/shardddl/pessimism"
 	"github.com/pingcap/dm/pkg/streamer"
@@ -133,6 +134,9 @@ type Syncer struct {
 	columnMapping *cm.Mapping
 	baList        *filter.Filter
 
+	// columnTransformer is nil if no column transforms are configured
+	columnTransformer *columnTransformer
+
 	closed sync2.AtomicBool
 
 	start    time.Time
@@ -151,6 +155,46 @@ type Syncer struct {
 	totalTps  sync2.AtomicInt64
 	tps       sync2.AtomicInt64
 
+	// write conflict retries in downstream
+	conflictRetries       sync2.AtomicInt64
+	lastConflictRetries   sync2.AtomicInt64
+	recentConflictRetries sync2.AtomicInt64
+
+	// accumulated nanoseconds spent in each stage of the sync pipeline, see LatencyBreakdown
+	readDuration      sync2.AtomicInt64
+	filterDuration    sync2.AtomicInt64
+	routeDuration     sync2.AtomicInt64
+	transformDuration sync2.AtomicInt64
+	dispatchDuration  sync2.AtomicInt64
+	writeDuration     sync2.AtomicInt64
+
+	// count of transactions being executed in downstream
+	activeTxns sync2.AtomicInt64
+	// results of executing statements in downstream
+	targetStats targetStats
+	// write conflicts in downstream in the recent window
+	conflictHotspots conflictHotspots
+	// DML events dispatched and executed of each target table
+	tableLags tableLags
+	// the DDL paused by freeze-on-ddl to be reviewed
+	frozenDDL frozenDDL
+	// matches the tables in freeze-on-ddl
+	freezeFilter *filter.Filter
+	// the safe mode of the running syncer
+	safeModeState safeModeState
+
+	// batch is the max number of DML jobs executed in one downstream transaction,
+	// it's initialized from cfg.Batch and could be changed at runtime
+	batch sync2.AtomicInt32
+	// bulkWrite is whether DML jobs are executed without a transaction (`bulk` write mode),
+	// it's initialized from cfg.WriteMode and could be changed at runtime
+	bulkWrite sync2.AtomicBool
+	// ddlBatchSize is the max number of DDL events applied to downstream as one batch,
+	// it's initialized from cfg.DDLBatchSize and could be changed at runtime
+	ddlBatchSize sync2.AtomicInt32
+	// ddlBatch holds the DDL events waiting to be applied to downstream as one batch
+	ddlBatch ddlBatch
+
 	done chan struct{}
 
 	checkpoint CheckPoint
@@ -177,6 +221,65 @@ type Syncer struct {
 		currentLocation binlog.Location // use to calc remain binlog size
 	}
 
+	// pauseBarrier is the location which the syncer should stop at, and onReached is called after all jobs before it flushed.
+	pauseBarrier struct {
+		sync.Mutex
+		location  *binlog.Location
+		onReached func(location binlog.Location)
+	}
+
+	// sampler samples binlog events for debugging, it's disabled by default
+	sampler eventSampler
+
+	// stepper halts the sync loop to single-step binlog events for debugging, it's disabled by default
+	stepper stepper
+
+	// writeHold holds writes to downstream in read-only mode, released is nil when not in read-only mode.
+	writeHold struct {
+		sync.RWMutex
+		released chan struct{}
+	}
+	// count of jobs fetched from job channels but held by read-only mode
+	heldJobs sync2.AtomicInt64
+	// count of DML jobs fetched from job channels and batched by workers
+	batchedJobs sync2.AtomicInt64
+	// count and bytes of jobs added, to estimate the memory of jobs pending to write
+	addedJobs     sync2.AtomicInt64
+	addedJobBytes sync2.AtomicInt64
+	// memory is the latest memory breakdown, it's updated on demand at most once per memoryBreakdownInterval
+	memory struct {
+		sync.Mutex
+		breakdown *MemoryBreakdown
+	}
+
+	// the timestamp of the latest binlog event received, used to calculate the replication lag
+	lastEventTS sync2.AtomicInt64
+	// the clock of upstream minus the clock of the worker in nanoseconds, used to correct the replication lag
+	upstreamClockSkew sync2.AtomicInt64
+
+	// skippedDDLs records the recent unsupported DDLs skipped by `unsupported-ddl-policy: skip`.
+	skippedDDLs struct {
+		sync.RWMutex
+		ddls []SkippedDDL
+	}
+	// duplicateKeys records the duplicate keys handled by `duplicate-key-policy`.
+	duplicateKeys duplicateKeys
+	// ddlHistory records the recent DDLs applied to downstream.
+	ddlHistory ddlHistory
+	// eventRates keeps the recent rates of events processed.
+	eventRates *eventRates
+	// gtidSwitch records the switch to GTID mode by `switch-to-gtid`, and gtidSwitched is set after switched.
+	gtidSwitch   gtidSwitch
+	gtidSwitched sync2.AtomicBool
+
+	// dbProvider creates the DBs of upstream and downstream, conn.DefaultDBProvider is used if it's nil
+	dbProvider conn.DBProvider
+	// errClassifiers decides whether the errors of downstream are retryable, the default rules are used if it's nil
+	errClassifiers *retry.Classifiers
+
+	// filterStats counts the events filtered by each binlog event filter rule
+	filterStats filterStats
+
 	errLocation struct {
 		sync.RWMutex
 		startLocation *binlog.Location
@@ -202,6 +305,9 @@ func NewSyncer(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) *Syncer {
 	syncer.binlogSizeCount.Set(0)
 	syncer.lastCount.Set(0)
 	syncer.count.Set(0)
+	syncer.batch.Set(int32(cfg.Batch))
+	syncer.bulkWrite.Set(cfg.WriteMode == config.WriteModeBulk)
+	syncer.ddlBatchSize.Set(int32(cfg.DDLBatchSize))
 	syncer.c = newCausality()
 	syncer.done = nil
 	syncer.setTimezone()
@@ -211,6 +317,7 @@ func NewSyncer(cfg *config.SubTaskConfig, etcdClient *clientv3.Client) *Syncer {
 	syncer.checkpoint = NewRemoteCheckPoint(syncer.tctx, cfg, syncer.checkpointID())
 
 	syncer.binlogType = toBinlogType(cfg.UseRelay)
+	syncer.eventRates = newEventRates(maxEventRateSamples)
 	syncer.errOperatorHolder = operator.NewHolder(&logger)
 	syncer.readerHub = streamer.GetReaderHub()
 
@@ -272,19 +379,25 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 		return err
 	}
 	rollbackHolder.Add(fr.FuncRollback{Name: "close-DBs", Fn: s.closeDBs})
+	s.initClockSkew(ctx)
 
 	s.schemaTracker, err = schema.NewTracker(ctx, s.cfg.Name, s.cfg.To.Session, s.ddlDBConn.baseConn)
 	if err != nil {
 		return terror.ErrSchemaTrackerInit.Delegate(err)
 	}
 
-	s.streamerController = NewStreamerController(s.syncCfg, s.cfg.EnableGTID, s.fromDB, s.binlogType, s.cfg.RelayDir, s.timezone)
+	s.streamerController = NewStreamerController(s.syncCfg, s.enableGTID(), s.fromDB, s.binlogType, s.cfg.RelayDir, s.timezone)
 
 	s.baList, err = filter.New(s.cfg.CaseSensitive, s.cfg.BAList)
 	if err != nil {
 		return terror.ErrSyncerUnitGenBAList.Delegate(err)
 	}
 
+	s.freezeFilter, err = newFreezeFilter(s.cfg.CaseSensitive, s.cfg.FreezeOnDDL)
+	if err != nil {
+		return terror.ErrSyncerUnitGenBAList.Delegate(err)
+	}
+
 	s.binlogFilter, err = bf.NewBinlogEvent(s.cfg.CaseSensitive, s.cfg.FilterRules)
 	if err != nil {
 		return terror.ErrSyncerUnitGenBinlogEventFilter.Delegate(err)
@@ -297,12 +410,19 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 		}
 	}
 
+	if len(s.cfg.ColumnTransforms) > 0 {
+		s.columnTransformer, err = newColumnTransformer(s.cfg.CaseSensitive, s.cfg.ColumnTransforms)
+		if err != nil {
+			return err
+		}
+	}
+
 	if s.cfg.OnlineDDLScheme != "" {
 		fn, ok := OnlineDDLSchemes[s.cfg.OnlineDDLScheme]
 		if !ok {
 			return terror.ErrSyncerUnitOnlineDDLSchemeNotSupport.Generate(s.cfg.OnlineDDLScheme)
 		}
-		s.onlineDDL, err = fn(tctx, s.cfg)
+		s.onlineDDL, err = fn(tctx, s.cfg, s.dbProvider)
 		if err != nil {
 			return err
 		}
@@ -339,10 +459,19 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 	}
 	rollbackHolder.Add(fr.FuncRollback{Name: "close-checkpoint", Fn: s.checkpoint.Close})
 
+	err = s.validateCheckpoint(tctx)
+	if err != nil {
+		return err
+	}
+
 	err = s.checkpoint.Load(tctx)
 	if err != nil {
 		return err
 	}
+	err = s.useSwitchedGTID(tctx)
+	if err != nil {
+		return err
+	}
 	if s.cfg.EnableHeartbeat {
 		s.heartbeat, err = GetHeartbeat(&HeartbeatConfig{
 			serverID:       s.cfg.ServerID,
@@ -400,7 +529,7 @@ func (s *Syncer) initShardingGroups(ctx context.Context) error {
 		}
 	}
 
-	loadMeta, err2 := s.sgk.LoadShardMeta(s.cfg.Flavor, s.cfg.EnableGTID)
+	loadMeta, err2 := s.sgk.LoadShardMeta(s.cfg.Flavor, s.enableGTID())
 	if err2 != nil {
 		return err2
 	}
@@ -426,7 +555,7 @@ func (s *Syncer) initShardingGroups(ctx context.Context) error {
 func (s *Syncer) IsFreshTask(ctx context.Context) (bool, error) {
 	globalPoint := s.checkpoint.GlobalPoint()
 	tablePoint := s.checkpoint.TablePoint()
-	return binlog.CompareLocation(globalPoint, binlog.NewLocation(s.cfg.Flavor), s.cfg.EnableGTID) <= 0 && len(tablePoint) == 0, nil
+	return binlog.CompareLocation(globalPoint, binlog.NewLocation(s.cfg.Flavor), s.enableGTID()) <= 0 && len(tablePoint) == 0, nil
 }
 
 func (s *Syncer) reset() {
@@ -435,6 +564,7 @@ func (s *Syncer) reset() {
 	}
 	// create new job chans
 	s.newJobChans(s.cfg.WorkerCount + 1)
+	s.tableLags.resetPending()
 
 	s.execError.Set(nil)
 	s.setErrLocation(nil, nil, false)
@@ -565,7 +695,7 @@ func (s *Syncer) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	prePos := s.checkpoint.GlobalPoint()
 	s.checkpoint.Rollback(s.schemaTracker)
 	currPos := s.checkpoint.GlobalPoint()
-	if binlog.CompareLocation(prePos, currPos, s.cfg.EnableGTID) != 0 {
+	if binlog.CompareLocation(prePos, currPos, s.enableGTID()) != 0 {
 		s.tctx.L().Warn("something wrong with rollback global checkpoint", zap.Stringer("previous position", prePos), zap.Stringer("current position", currPos))
 	}
 
@@ -713,7 +843,8 @@ func (s *Syncer) checkWait(job *job) bool {
 		return true
 	}
 
-	if s.checkpoint.CheckGlobalPoint() {
+	// checkpoint can't be flushed in read-only mode, keep reading until job channels are full
+	if s.checkpoint.CheckGlobalPoint() && !s.IsReadOnly() {
 		return true
 	}
 
@@ -765,11 +896,17 @@ func (s *Syncer) addJob(job *job) error {
 		s.jobWg.Add(1)
 		queueBucket = int(utils.GenHashKey(job.key)) % s.cfg.WorkerCount
 		s.addCount(false, s.queueBucketMapping[queueBucket], job.tp, 1)
+		job.eventTS = s.lastEventTS.Get()
+		s.tableLags.dispatched(job)
 		startTime := time.Now()
 		s.tctx.L().Debug("queue for key", zap.Int("queue", queueBucket), zap.String("key", job.key))
 		s.jobs[queueBucket] <- job
 		addJobDurationHistogram.WithLabelValues(job.tp.String(), s.cfg.Name, s.queueBucketMapping[queueBucket], s.cfg.SourceID).Observe(time.Since(startTime).Seconds())
 	}
+	if job.tp == ddl || job.tp == insert || job.tp == update || job.tp == del {
+		s.addedJobs.Add(1)
+		s.addedJobBytes.Add(job.size())
+	}
 
 	wait := s.checkWait(job)
 	if wait {
@@ -885,6 +1022,9 @@ func (s *Syncer) flushCheckPoints() error {
 		s.tctx.L().Info("prepare flush sqls", zap.Strings("shard meta sqls", shardMetaSQLs), zap.Reflect("shard meta arguments", shardMetaArgs))
 	}
 
+	// used by fault injection of DM-worker to delay flushing checkpoint.
+	failpoint.Inject("DelayFlushCheckpoint", nil)
+
 	err := s.checkpoint.FlushPointsExcept(s.tctx, exceptTables, shardMetaSQLs, shardMetaArgs)
 	if err != nil {
 		return terror.Annotatef(err, "flush checkpoint %s", s.checkpoint)
@@ -928,12 +1068,18 @@ func (s *Syncer) syncDDL(tctx *tcontext.Context, queueBucket string, db *DBConn,
 			}
 		}
 		if !ignore {
+			err = s.waitWritable(tctx.Ctx, 1)
+		}
+		if !ignore && err == nil {
 			var affected int
 			affected, err = db.executeSQLWithIgnore(tctx, ignoreDDLError, sqlJob.ddls)
 			if err != nil {
 				err = s.handleSpecialDDLError(tctx, err, sqlJob.ddls, affected, db)
 				err = terror.WithScope(err, terror.ScopeDownstream)
 			}
+			if err == nil {
+				s.recordDDL(sqlJob)
+			}
 		}
 		// If downstream has error (which may cause by tracker is more compatible than downstream), we should stop handling
 		// this job, set `s.execError` to let caller of `addJob` discover error
@@ -991,9 +1137,13 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 	defer s.wg.Done()
 
 	idx := 0
-	count := s.cfg.Batch
-	jobs := make([]*job, 0, count)
+	jobs := make([]*job, 0, s.BatchSize())
+	// batch is the jobs being executed, jobs may be changed when executing
+	batch := make([]*job, 0, s.BatchSize())
 	tpCnt := make(map[opType]int64)
+	defer func() {
+		s.batchedJobs.Add(-int64(len(jobs)))
+	}()
 
 	clearF := func() {
 		for i := 0; i < idx; i++ {
@@ -1001,6 +1151,7 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 		}
 
 		idx = 0
+		s.batchedJobs.Add(-int64(len(jobs)))
 		jobs = jobs[0:0]
 		for tpName, v := range tpCnt {
 			s.addCount(true, queueBucket, tpName, v)
@@ -1018,6 +1169,7 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 	}
 
 	executeSQLs := func() (int, error) {
+		batch = append(batch[:0], jobs...)
 		if len(jobs) == 0 {
 			return 0, nil
 		}
@@ -1033,17 +1185,52 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 		default:
 		}
 
-		queries := make([]string, 0, len(jobs))
-		args := make([][]interface{}, 0, len(jobs))
-		for _, j := range jobs {
-			queries = append(queries, j.sql)
-			args = append(args, j.args)
+		if err := s.waitWritable(tctx.Ctx, len(jobs)); err != nil {
+			return 0, err
 		}
+
 		failpoint.Inject("WaitUserCancel", func(v failpoint.Value) {
 			t := v.(int)
 			time.Sleep(time.Duration(t) * time.Second)
 		})
-		return db.executeSQL(tctx, queries, args...)
+		for {
+			queries := make([]string, 0, len(jobs))
+			args := make([][]interface{}, 0, len(jobs))
+			for _, j := range jobs {
+				queries = append(queries, j.sql)
+				args = append(args, j.args)
+			}
+			var (
+				affected int
+				err      error
+			)
+			bulk := s.bulkWrite.Get()
+			if bulk {
+				affected, err = db.executeSQLWithoutTxn(tctx, queries, args...)
+			} else {
+				affected, err = db.executeSQL(tctx, queries, args...)
+			}
+			if err == nil {
+				return affected, nil
+			}
+			if bulk && affected > 0 {
+				// the statements executed are kept in bulk mode, don't execute them again.
+				s.batchedJobs.Add(-int64(affected))
+				jobs = jobs[affected:]
+				affected = 0
+			}
+			// the transaction is rolled back (or the executed statements are dropped in bulk mode),
+			// retry it after the duplicate key is handled.
+			remained, handled := s.handleDuplicateKey(tctx, jobs, affected, err)
+			if !handled {
+				return affected, err
+			}
+			s.batchedJobs.Add(int64(len(remained) - len(jobs)))
+			jobs = remained
+			if len(jobs) == 0 {
+				return 0, nil
+			}
+		}
 	}
 
 	var err error
@@ -1059,15 +1246,17 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 
 			if sqlJob.tp != flush && len(sqlJob.sql) > 0 {
 				jobs = append(jobs, sqlJob)
+				s.batchedJobs.Add(1)
 				tpCnt[sqlJob.tp]++
 			}
 
-			if idx >= count || sqlJob.tp == flush {
+			if idx >= s.BatchSize() || sqlJob.tp == flush {
 				affect, err = executeSQLs()
 				if err != nil {
 					fatalF(affect, err)
 					continue
 				}
+				s.tableLags.executed(batch, time.Now())
 				clearF()
 			}
 
@@ -1078,6 +1267,7 @@ func (s *Syncer) sync(tctx *tcontext.Context, queueBucket string, db *DBConn, jo
 					fatalF(affect, err)
 					continue
 				}
+				s.tableLags.executed(batch, time.Now())
 				clearF()
 			}
 		}
@@ -1094,6 +1284,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		}
 	}()
 
+	if err = s.checkBinlogSettings(ctx); err != nil {
+		return err
+	}
+
 	fresh, err := s.IsFreshTask(ctx)
 	if err != nil {
 		return err
@@ -1170,12 +1364,19 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		cancel()
 	}()
 
+	s.wg.Add(1)
+	go s.sampleEventRate(ctx)
+
 	defer func() {
 		if err1 := recover(); err1 != nil {
 			tctx.L().Error("panic log", zap.Reflect("error message", err1), zap.Stack("statck"))
 			err = terror.ErrSyncerUnitPanic.Generate(err1)
 		}
 
+		// the DDLs in the pending batch are replicated again after restarting because the checkpoint isn't saved
+		if j := s.ddlBatch.take(); j != nil {
+			tctx.L().Info("discard pending DDL batch when exit task", zap.Strings("ddls", j.ddls))
+		}
 		s.jobWg.Wait()
 		if err2 := s.flushCheckPoints(); err2 != nil {
 			tctx.L().Warn("fail to flush check points when exit task", zap.Error(err2))
@@ -1246,11 +1447,30 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		return nil
 	}
 
+	// atTxnBoundary is true if currentLocation is not in the middle of a transaction
+	atTxnBoundary := true
+
 	for {
 		s.currentLocationMu.Lock()
 		s.currentLocationMu.currentLocation = currentLocation
 		s.currentLocationMu.Unlock()
 
+		// stop at the pause barrier, only check at boundaries of transactions to keep downstream consistent
+		if atTxnBoundary && shardingReSync == nil {
+			if onReached := s.reachPauseBarrier(currentLocation); onReached != nil {
+				tctx.L().Info("reach pause barrier, flush jobs and wait to be paused", zap.Stringer("location", currentLocation))
+				if err = s.flushDDLBatch(); err != nil {
+					return err
+				}
+				if err = s.flushJobs(); err != nil {
+					return err
+				}
+				onReached(currentLocation)
+				<-ctx.Done()
+				return nil
+			}
+		}
+
 		// fetch from sharding resync channel if needed, and redirect global
 		// stream to current binlog position recorded by ShardingReSync
 		if shardingReSync == nil && len(shardingReSyncCh) > 0 {
@@ -1283,6 +1503,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			return nil
 		} else if err == context.DeadlineExceeded {
 			tctx.L().Info("deadline exceeded when fetching binlog event")
+			// upstream is idle, don't keep the DDLs waiting for the batch to be full
+			if err = s.flushDDLBatch(); err != nil {
+				return err
+			}
 			continue
 		} else if isDuplicateServerIDError(err) {
 			// if the server id is already used, need to use a new server id
@@ -1302,6 +1526,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			}
 
 			if s.streamerController.CanRetry() {
+				// the binlog settings may be changed when upstream is unavailable
+				if err = s.checkBinlogSettings(ctx); err != nil {
+					return err
+				}
 				err = s.streamerController.ResetReplicationSyncer(tctx, lastLocation)
 				if err != nil {
 					return err
@@ -1309,8 +1537,13 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				continue
 			}
 
+			// stop instead of skipping the purged events in strict gtid mode
+			if s.enableGTID() && s.cfg.StrictGTID && utils.IsErrBinlogPurged(err) {
+				return s.gtidGapError(tctx, lastLocation, err)
+			}
+
 			// try to re-sync in gtid mode
-			if tryReSync && s.cfg.EnableGTID && utils.IsErrBinlogPurged(err) && s.cfg.AutoFixGTID {
+			if tryReSync && s.enableGTID() && utils.IsErrBinlogPurged(err) && s.cfg.AutoFixGTID {
 				time.Sleep(retryTimeout)
 				err = s.reSyncBinlog(*tctx, lastLocation)
 				if err != nil {
@@ -1324,7 +1557,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		}
 
 		// time duration for reading an event from relay log or upstream master.
-		binlogReadDurationHistogram.WithLabelValues(s.cfg.Name, s.cfg.SourceID).Observe(time.Since(startTime).Seconds())
+		readCost := time.Since(startTime)
+		binlogReadDurationHistogram.WithLabelValues(s.cfg.Name, s.cfg.SourceID).Observe(readCost.Seconds())
+		s.readDuration.Add(int64(readCost))
 		startTime = time.Now() // reset start time for the next metric.
 
 		// get binlog event, reset tryReSync, so we can re-sync binlog while syncer meets errors next time
@@ -1337,10 +1572,18 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			binlogFileGauge.WithLabelValues("syncer", s.cfg.Name, s.cfg.SourceID).Set(float64(index))
 		}
 		s.binlogSizeCount.Add(int64(e.Header.EventSize))
+		if e.Header.Timestamp > 0 { // not a fake event
+			s.lastEventTS.Set(int64(e.Header.Timestamp))
+		}
 		binlogEventSizeHistogram.WithLabelValues(s.cfg.Name, s.cfg.SourceID).Observe(float64(e.Header.EventSize))
 
 		failpoint.Inject("ProcessBinlogSlowDown", nil)
 
+		if err = s.stepper.wait(tctx.Ctx); err != nil {
+			tctx.L().Info("binlog replication main routine quit(context canceled) in stepping mode!", zap.Stringer("last location", lastLocation))
+			return nil
+		}
+
 		tctx.L().Debug("receive binlog event", zap.Reflect("header", e.Header))
 
 		// TODO: support all event
@@ -1406,8 +1649,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		// check pass SafeModeExitLoc and try disable safe mode, but not in sharding or replacing error
 		safeModeExitLoc := s.checkpoint.SafeModeExitPoint()
 		if safeModeExitLoc != nil && !s.isReplacingErr && shardingReSync == nil {
-			if binlog.CompareLocation(currentLocation, *safeModeExitLoc, s.cfg.EnableGTID) >= 0 {
+			if binlog.CompareLocation(currentLocation, *safeModeExitLoc, s.enableGTID()) >= 0 {
 				s.checkpoint.SaveSafeModeExitPoint(nil)
+				s.safeModeState.exited()
 				err = safeMode.Add(tctx, -1)
 				if err != nil {
 					return err
@@ -1432,15 +1676,31 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 		var originSQL string // show origin sql when error, only ddl now
 		var err2 error
+		if s.sampler.enabled.Get() {
+			eventLocation := currentLocation.Clone()
+			eventLocation.Position.Pos = e.Header.LogPos
+			s.sampler.sample(e, eventLocation)
+		}
+
+		// apply the pending DDL batch before the events changing data or checkpoint
+		if !keepDDLBatch(e) {
+			if err = s.flushDDLBatch(); err != nil {
+				return err
+			}
+		}
+
 		switch ev := e.Event.(type) {
 		case *replication.RotateEvent:
 			err2 = s.handleRotateEvent(ev, ec)
 		case *replication.RowsEvent:
+			atTxnBoundary = false
 			err2 = s.handleRowsEvent(ev, ec)
 		case *replication.QueryEvent:
 			originSQL = strings.TrimSpace(string(ev.Query))
+			atTxnBoundary = originSQL != "BEGIN"
 			err2 = s.handleQueryEvent(ev, ec, originSQL)
 		case *replication.XIDEvent:
+			atTxnBoundary = true
 			if shardingReSync != nil {
 				shardingReSync.currLocation.Position.Pos = e.Header.LogPos
 				shardingReSync.currLocation.Suffix = currentLocation.Suffix
@@ -1451,7 +1711,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 				// only need compare binlog position?
 				lastLocation = shardingReSync.currLocation
-				if binlog.CompareLocation(shardingReSync.currLocation, shardingReSync.latestLocation, s.cfg.EnableGTID) >= 0 {
+				if binlog.CompareLocation(shardingReSync.currLocation, shardingReSync.latestLocation, s.enableGTID()) >= 0 {
 					tctx.L().Info("re-replicate shard group was completed", zap.String("event", "XID"), zap.Stringer("re-shard", shardingReSync))
 					err = closeShardingResync()
 					if err != nil {
@@ -1479,11 +1739,24 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		case *replication.GenericEvent:
 			switch e.Header.EventType {
 			case replication.HEARTBEAT_EVENT:
-				// flush checkpoint even if there are no real binlog events
-				if s.checkpoint.CheckGlobalPoint() {
+				// flush checkpoint even if there are no real binlog events,
+				// and the jobs must be flushed before switching to GTID mode.
+				switchGTID := s.needSwitchToGTID() && !s.IsReadOnly()
+				if (s.checkpoint.CheckGlobalPoint() && !s.IsReadOnly()) || switchGTID {
 					tctx.L().Info("meet heartbeat event and then flush jobs")
 					err2 = s.flushJobs()
 				}
+				if err2 == nil && switchGTID {
+					var (
+						switchedLocation binlog.Location
+						switched         bool
+					)
+					if switchedLocation, switched, err2 = s.switchToGTID(tctx); switched {
+						lastLocation = switchedLocation
+						currentLocation = switchedLocation.Clone()
+						startLocation = switchedLocation.Clone()
+					}
+				}
 			}
 		}
 		if err2 != nil {
@@ -1538,16 +1811,16 @@ func (s *Syncer) handleRotateEvent(ev *replication.RotateEvent, ec eventContext)
 		ec.currentLocation.GetGTID(),
 	)
 
-	if binlog.CompareLocation(*ec.currentLocation, *ec.lastLocation, s.cfg.EnableGTID) >= 0 {
+	if binlog.CompareLocation(*ec.currentLocation, *ec.lastLocation, s.enableGTID()) >= 0 {
 		*ec.lastLocation = *ec.currentLocation
 	}
 
 	if ec.shardingReSync != nil {
-		if binlog.CompareLocation(*ec.currentLocation, ec.shardingReSync.currLocation, s.cfg.EnableGTID) > 0 {
+		if binlog.CompareLocation(*ec.currentLocation, ec.shardingReSync.currLocation, s.enableGTID()) > 0 {
 			ec.shardingReSync.currLocation = *ec.currentLocation
 		}
 
-		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.cfg.EnableGTID) >= 0 {
+		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.enableGTID()) >= 0 {
 			ec.tctx.L().Info("re-replicate shard group was completed", zap.String("event", "rotate"), zap.Stringer("re-shard", ec.shardingReSync))
 			err := ec.closeShardingResync()
 			if err != nil {
@@ -1565,7 +1838,9 @@ func (s *Syncer) handleRotateEvent(ev *replication.RotateEvent, ec eventContext)
 
 func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) error {
 	originSchema, originTable := string(ev.Table.Schema), string(ev.Table.Table)
+	stageTime := time.Now()
 	schemaName, tableName := s.renameShardingSchema(originSchema, originTable)
+	s.routeDuration.Add(int64(time.Since(stageTime)))
 
 	*ec.currentLocation = binlog.InitLocation(
 		mysql.Position{
@@ -1577,7 +1852,7 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 
 	if ec.shardingReSync != nil {
 		ec.shardingReSync.currLocation = *ec.currentLocation
-		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.cfg.EnableGTID) >= 0 {
+		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.enableGTID()) >= 0 {
 			ec.tctx.L().Info("re-replicate shard group was completed", zap.String("event", "row"), zap.Stringer("re-shard", ec.shardingReSync))
 			return ec.closeShardingResync()
 		}
@@ -1589,7 +1864,7 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 	}
 
 	// DML position before table checkpoint, ignore it
-	if !s.checkpoint.IsNewerTablePoint(originSchema, originTable, *ec.currentLocation, s.cfg.EnableGTID) {
+	if !s.checkpoint.IsNewerTablePoint(originSchema, originTable, *ec.currentLocation, s.enableGTID()) {
 		ec.tctx.L().Debug("ignore obsolete event that is old than table checkpoint", zap.String("event", "row"), log.WrapStringerField("location", ec.currentLocation), zap.String("origin schema", originSchema), zap.String("origin table", originTable))
 		return nil
 	}
@@ -1600,7 +1875,9 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 		s.heartbeat.TryUpdateTaskTs(s.cfg.Name, originSchema, originTable, ev.Rows)
 	}
 
+	stageTime = time.Now()
 	ignore, err := s.skipDMLEvent(originSchema, originTable, ec.header.EventType)
+	s.filterDuration.Add(int64(time.Since(stageTime)))
 	if err != nil {
 		return err
 	}
@@ -1620,6 +1897,7 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 		}
 	}
 
+	stageTime = time.Now()
 	// TODO(csuzhangxc): check performance of `getTabel` from schema tracker.
 	ti, err := s.getTable(ec.tctx, originSchema, originTable, schemaName, tableName)
 	if err != nil {
@@ -1688,6 +1966,7 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 		ec.tctx.L().Debug("ignoring unrecognized event", zap.String("event", "row"), zap.Stringer("type", ec.header.EventType))
 		return nil
 	}
+	s.transformDuration.Add(int64(time.Since(stageTime)))
 
 	startTime := time.Now()
 	for i := range sqls {
@@ -1704,10 +1983,49 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 			return err
 		}
 	}
-	dispatchBinlogDurationHistogram.WithLabelValues(jobType.String(), s.cfg.Name, s.cfg.SourceID).Observe(time.Since(startTime).Seconds())
+	dispatchCost := time.Since(startTime)
+	dispatchBinlogDurationHistogram.WithLabelValues(jobType.String(), s.cfg.Name, s.cfg.SourceID).Observe(dispatchCost.Seconds())
+	s.dispatchDuration.Add(int64(dispatchCost))
 	return nil
 }
 
+// handleUnsupportedDDL handles the DDL which can't be parsed according to `unsupported-ddl-policy`,
+// parseErr is returned to pause the subtask if the policy is `pause`.
+func (s *Syncer) handleUnsupportedDDL(ec eventContext, usedSchema, originSQL string, parseErr error) error {
+	switch s.cfg.UnsupportedDDLPolicy {
+	case config.UnsupportedDDLSkip:
+		ec.tctx.L().Warn("skip unsupported DDL", zap.String("event", "query"), zap.String("statement", originSQL), zap.String("schema", usedSchema), log.WrapStringerField("location", ec.currentLocation))
+		s.recordSkippedDDL(usedSchema, originSQL, *ec.currentLocation)
+		*ec.lastLocation = *ec.currentLocation // before record skip location, update lastLocation
+		return s.recordSkipSQLsLocation(*ec.lastLocation)
+	case config.UnsupportedDDLApplyVerbatim:
+		// we can't know which tables the DDL changes, so can't coordinate it with other shard tables.
+		if s.cfg.IsSharding {
+			ec.tctx.L().Warn("can't apply unsupported DDL verbatim in sharding mode", zap.String("event", "query"), zap.String("statement", originSQL), zap.String("schema", usedSchema))
+			return parseErr
+		}
+		*ec.lastLocation = *ec.currentLocation
+		ddls := make([]string, 0, 2)
+		if len(usedSchema) > 0 {
+			ddls = append(ddls, "USE "+dbutil.ColumnName(usedSchema))
+		}
+		ddls = append(ddls, originSQL)
+		ec.tctx.L().Warn("apply unsupported DDL verbatim", zap.String("event", "query"), zap.Strings("ddls", ddls), log.WrapStringerField("location", ec.currentLocation))
+
+		job := newDDLJob(nil, ddls, *ec.lastLocation, *ec.startLocation, *ec.currentLocation, nil, originSQL)
+		if err := s.addJobFunc(job); err != nil {
+			return err
+		}
+		// the same as DDLs in normal mode, return nil here to avoid duplicate error message
+		if err := s.execError.Get(); err != nil {
+			ec.tctx.L().Error("error detected when executing SQL job", log.ShortError(err))
+		}
+		return nil
+	default:
+		return parseErr
+	}
+}
+
 func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, originSQL string) error {
 	if originSQL == "BEGIN" {
 		return nil
@@ -1722,6 +2040,9 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 	parseResult, err := s.parseDDLSQL(originSQL, parser2, usedSchema)
 	if err != nil {
 		ec.tctx.L().Error("fail to parse statement", zap.String("event", "query"), zap.String("statement", originSQL), zap.String("schema", usedSchema), zap.Stringer("last location", ec.lastLocation), log.WrapStringerField("location", ec.currentLocation), log.ShortError(err))
+		if terror.ErrSyncerParseDDL.Equal(err) {
+			return s.handleUnsupportedDDL(ec, usedSchema, originSQL, err)
+		}
 		return err
 	}
 
@@ -1738,7 +2059,7 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 
 	if ec.shardingReSync != nil {
 		ec.shardingReSync.currLocation = *ec.currentLocation
-		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.cfg.EnableGTID) >= 0 {
+		if binlog.CompareLocation(ec.shardingReSync.currLocation, ec.shardingReSync.latestLocation, s.enableGTID()) >= 0 {
 			ec.tctx.L().Info("re-replicate shard group was completed", zap.String("event", "query"), zap.String("statement", originSQL), zap.Stringer("re-shard", ec.shardingReSync))
 			err2 := ec.closeShardingResync()
 			if err2 != nil {
@@ -1794,6 +2115,8 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 		needHandleDDLs []string
 		needTrackDDLs  []trackedDDL
 		sourceTbls     = make(map[string]map[string]struct{}) // db name -> tb name
+		// whether the DDLs could be applied to downstream in a batch with the DDLs of other events
+		batchable = s.cfg.ShardMode == "" && len(onlineDDLTableNames) == 0 && !s.isReplacingErr && s.DDLBatchSize() > 1
 	)
 	for _, sql := range sqls {
 		// We use default parser because sqls are came from above *Syncer.resolveDDLSQL, which is StringSingleQuotes, KeyWordUppercase and NameBackQuotes
@@ -1861,6 +2184,7 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 
 		needHandleDDLs = append(needHandleDDLs, sqlDDL)
 		needTrackDDLs = append(needTrackDDLs, trackedDDL{rawSQL: sql, stmt: stmt, tableNames: tableNames})
+		batchable = batchable && isBatchableDDL(stmt)
 		// TODO: current table checkpoints will be deleted in track ddls, but created and updated in flush checkpoints,
 		//       we should use a better mechanism to combine these operations
 		recordSourceTbls(sourceTbls, stmt, tableNames[0][0])
@@ -1872,6 +2196,20 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 		return s.recordSkipSQLsLocation(*ec.lastLocation)
 	}
 
+	// apply the pending DDL batch before handling the DDLs which can't be batched
+	if !batchable {
+		if err = s.flushDDLBatch(); err != nil {
+			return err
+		}
+		if s.execError.Get() != nil {
+			return nil
+		}
+	}
+
+	if err = s.checkFreezeOnDDL(*ec.currentLocation, string(ev.Query), needHandleDDLs, needTrackDDLs); err != nil {
+		return err
+	}
+
 	// interrupted before flush old checkpoint.
 	failpoint.Inject("FlushCheckpointStage", func(val failpoint.Value) {
 		err = handleFlushCheckpointStage(0, val.(int), "before flush old checkpoint")
@@ -1880,10 +2218,12 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 		}
 	})
 
-	// flush previous DMLs and checkpoint if needing to handle the DDL.
+	// flush previous DMLs and checkpoint if needing to handle the DDL, no DMLs are received after the pending DDL batch.
 	// NOTE: do this flush before operations on shard groups which may lead to skip a table caused by `UnresolvedTables`.
-	if err = s.flushJobs(); err != nil {
-		return err
+	if s.ddlBatch.len() == 0 {
+		if err = s.flushJobs(); err != nil {
+			return err
+		}
 	}
 
 	if s.cfg.ShardMode == "" {
@@ -1913,7 +2253,15 @@ func (s *Syncer) handleQueryEvent(ev *replication.QueryEvent, ec eventContext, o
 		})
 
 		job := newDDLJob(nil, needHandleDDLs, *ec.lastLocation, *ec.startLocation, *ec.currentLocation, sourceTbls, originSQL)
-		err = s.addJobFunc(job)
+		if batchable {
+			if n := s.ddlBatch.add(job); n < s.DDLBatchSize() {
+				ec.tctx.L().Info("add ddls to batch", zap.String("event", "query"), zap.Strings("ddls", needHandleDDLs), zap.Int("batched events", n), log.WrapStringerField("location", ec.currentLocation))
+				return nil
+			}
+			err = s.flushDDLBatch()
+		} else {
+			err = s.addJobFunc(job)
+		}
 		if err != nil {
 			return err
 		}
@@ -2349,16 +2697,22 @@ func (s *Syncer) printStatus(ctx context.Context) {
 				}
 			}
 
+			totalConflictRetries := s.conflictRetries.Get()
+			recentConflictRetries := totalConflictRetries - s.lastConflictRetries.Get()
+
 			s.tctx.L().Info("binlog replication status",
 				zap.Int64("total_events", total),
 				zap.Int64("total_tps", totalTps),
 				zap.Int64("tps", tps),
+				zap.Int64("write_conflict_retries", recentConflictRetries),
 				zap.Stringer("master_position", latestMasterPos),
 				log.WrapStringerField("master_gtid", latestmasterGTIDSet),
 				zap.Stringer("checkpoint", s.checkpoint))
 
 			s.lastCount.Set(total)
 			s.lastBinlogSizeCount.Set(totalBinlogSize)
+			s.lastConflictRetries.Set(totalConflictRetries)
+			s.recentConflictRetries.Set(recentConflictRetries)
 			s.lastTime.Lock()
 			s.lastTime.t = time.Now()
 			s.lastTime.Unlock()
@@ -2372,7 +2726,7 @@ func (s *Syncer) createDBs(ctx context.Context) error {
 	var err error
 	dbCfg := s.cfg.From
 	dbCfg.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxDMLConnectionTimeout)
-	s.fromDB, err = createUpStreamConn(dbCfg)
+	s.fromDB, err = createUpStreamConn(s.dbProvider, dbCfg)
 	if err != nil {
 		return err
 	}
@@ -2403,23 +2757,36 @@ func (s *Syncer) createDBs(ctx context.Context) error {
 		SetReadTimeout(maxDMLConnectionTimeout).
 		SetMaxIdleConns(s.cfg.WorkerCount)
 
-	s.toDB, s.toDBConns, err = createConns(s.tctx, s.cfg, dbCfg, s.cfg.WorkerCount)
+	s.toDB, s.toDBConns, err = createConns(s.tctx, s.cfg, s.dbProvider, dbCfg, s.cfg.WorkerCount)
 	if err != nil {
 		closeUpstreamConn(s.tctx, s.fromDB) // release resources acquired before return with error
 		return err
 	}
+	for _, c := range s.toDBConns {
+		c.conflictRetries = &s.conflictRetries
+		c.writeDuration = &s.writeDuration
+		c.activeTxns = &s.activeTxns
+		c.targetStats = &s.targetStats
+		c.conflictHotspots = &s.conflictHotspots
+		c.errClassifiers = s.errClassifiers
+	}
 	// baseConn for ddl
 	dbCfg = s.cfg.To
 	dbCfg.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxDDLConnectionTimeout)
 
 	var ddlDBConns []*DBConn
-	s.ddlDB, ddlDBConns, err = createConns(s.tctx, s.cfg, dbCfg, 1)
+	s.ddlDB, ddlDBConns, err = createConns(s.tctx, s.cfg, s.dbProvider, dbCfg, 1)
 	if err != nil {
 		closeUpstreamConn(s.tctx, s.fromDB)
 		closeBaseDB(s.tctx, s.toDB)
 		return err
 	}
 	s.ddlDBConn = ddlDBConns[0]
+	s.ddlDBConn.conflictRetries = &s.conflictRetries
+	s.ddlDBConn.writeDuration = &s.writeDuration
+	s.ddlDBConn.activeTxns = &s.activeTxns
+	s.ddlDBConn.targetStats = &s.targetStats
+	s.ddlDBConn.errClassifiers = s.errClassifiers
 
 	return nil
 }
@@ -2562,6 +2929,9 @@ func (s *Syncer) Resume(ctx context.Context, pr chan pb.ProcessResult) {
 		return
 	}
 
+	// resuming after the frozen DDL is reviewed applies it.
+	s.frozenDDL.approve()
+
 	// continue the processing
 	s.reset()
 	// reset database conns
@@ -2595,6 +2965,8 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		oldTableRouter   *router.Table
 		oldBinlogFilter  *bf.BinlogEvent
 		oldColumnMapping *cm.Mapping
+
+		oldColumnTransformer *columnTransformer
 	)
 
 	defer func() {
@@ -2613,6 +2985,9 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		if oldColumnMapping != nil {
 			s.columnMapping = oldColumnMapping
 		}
+		if oldColumnTransformer != nil {
+			s.columnTransformer = oldColumnTransformer
+		}
 	}()
 
 	// update block-allow-list
@@ -2643,6 +3018,17 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		return terror.ErrSyncerUnitGenColumnMapping.Delegate(err)
 	}
 
+	// update column-transforms
+	oldColumnTransformer = s.columnTransformer
+	if len(cfg.ColumnTransforms) > 0 {
+		s.columnTransformer, err = newColumnTransformer(cfg.CaseSensitive, cfg.ColumnTransforms)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.columnTransformer = nil
+	}
+
 	switch s.cfg.ShardMode {
 	case config.ShardPessimistic:
 		// re-init sharding group
@@ -2667,7 +3053,16 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 	s.cfg.RouteRules = cfg.RouteRules
 	s.cfg.FilterRules = cfg.FilterRules
 	s.cfg.ColumnMappingRules = cfg.ColumnMappingRules
+	s.cfg.ColumnTransforms = cfg.ColumnTransforms
 	s.cfg.Timezone = cfg.Timezone
+	s.cfg.Batch = cfg.Batch
+	s.cfg.WriteMode = cfg.WriteMode
+	s.cfg.DDLBatchSize = cfg.DDLBatchSize
+
+	// reset the batch sizes and write mode changed at runtime
+	s.batch.Set(int32(cfg.Batch))
+	s.bulkWrite.Set(cfg.WriteMode == config.WriteModeBulk)
+	s.ddlBatchSize.Set(int32(cfg.DDLBatchSize))
 
 	// update timezone
 	s.setTimezone()
@@ -2692,6 +3087,166 @@ func (s *Syncer) checkpointID() string {
 	return strconv.FormatUint(uint64(s.cfg.ServerID), 10)
 }
 
+// ServerID returns the server id used to replicate binlog from upstream, and whether the syncer is replicating binlog
+// from upstream by the server id now, it's false if the syncer is reading relay log or not started.
+func (s *Syncer) ServerID() (uint32, bool) {
+	if s.streamerController == nil {
+		return s.cfg.ServerID, false
+	}
+	remote := !s.streamerController.IsClosed() && s.streamerController.GetBinlogType() == RemoteBinlog
+	return s.streamerController.ServerID(), remote
+}
+
+// RenewServerID lets the syncer replicate binlog from upstream with a new random server id after restarting.
+func (s *Syncer) RenewServerID() {
+	if s.streamerController != nil {
+		s.streamerController.RenewServerID()
+	}
+}
+
+// SetPauseBarrier sets a location which the syncer should stop at, onReached is called when all binlog events before
+// the location are synced and the checkpoint is flushed, then the syncer waits to be paused without processing more events.
+// onReached should not block. the barrier is cleared if location is nil.
+func (s *Syncer) SetPauseBarrier(location *binlog.Location, onReached func(location binlog.Location)) {
+	s.pauseBarrier.Lock()
+	defer s.pauseBarrier.Unlock()
+	if location == nil {
+		s.pauseBarrier.location = nil
+		s.pauseBarrier.onReached = nil
+		return
+	}
+	loc := location.Clone()
+	s.pauseBarrier.location = &loc
+	s.pauseBarrier.onReached = onReached
+	s.tctx.L().Info("set pause barrier", zap.Stringer("location", loc))
+}
+
+// CurrentLocation returns the location of the binlog event being processed, it's the end of the last transaction if
+// the syncer is at a transaction boundary.
+func (s *Syncer) CurrentLocation() binlog.Location {
+	s.currentLocationMu.RLock()
+	defer s.currentLocationMu.RUnlock()
+	return s.currentLocationMu.currentLocation.Clone()
+}
+
+// reachPauseBarrier returns the callback if the location reaches the pause barrier, and the barrier is cleared.
+func (s *Syncer) reachPauseBarrier(location binlog.Location) func(location binlog.Location) {
+	s.pauseBarrier.Lock()
+	defer s.pauseBarrier.Unlock()
+	if s.pauseBarrier.location == nil || binlog.CompareLocation(location, *s.pauseBarrier.location, s.enableGTID()) < 0 {
+		return nil
+	}
+	onReached := s.pauseBarrier.onReached
+	if onReached == nil {
+		onReached = func(binlog.Location) {}
+	}
+	s.pauseBarrier.location = nil
+	s.pauseBarrier.onReached = nil
+	return onReached
+}
+
+// recordSkippedDDL records an unsupported DDL skipped by `unsupported-ddl-policy: skip`, only the recent ones are kept.
+func (s *Syncer) recordSkippedDDL(schema, statement string, location binlog.Location) {
+	s.skippedDDLs.Lock()
+	defer s.skippedDDLs.Unlock()
+	s.skippedDDLs.ddls = append(s.skippedDDLs.ddls, SkippedDDL{
+		Schema:    schema,
+		Statement: statement,
+		Location:  location.String(),
+		Time:      time.Now(),
+	})
+	if len(s.skippedDDLs.ddls) > maxSkippedDDLs {
+		s.skippedDDLs.ddls = s.skippedDDLs.ddls[len(s.skippedDDLs.ddls)-maxSkippedDDLs:]
+	}
+}
+
+// SetDBProvider sets the provider to create the DBs of upstream and downstream, including the ones of checkpoint and
+// sharding meta, it should be called before Init.
+func (s *Syncer) SetDBProvider(dbProvider conn.DBProvider) {
+	s.dbProvider = dbProvider
+	if cp, ok := s.checkpoint.(*RemoteCheckPoint); ok {
+		cp.dbProvider = dbProvider
+	}
+	if s.sgk != nil {
+		s.sgk.dbProvider = dbProvider
+	}
+}
+
+// SetErrorClassifiers sets the classifiers deciding whether the errors of downstream are retryable, it should be
+// called before Init.
+func (s *Syncer) SetErrorClassifiers(classifiers *retry.Classifiers) {
+	s.errClassifiers = classifiers
+}
+
+// UpdateRelayDir updates the directory of relay log after it's moved, the syncer should be paused before calling it.
+func (s *Syncer) UpdateRelayDir(relayDir string) {
+	s.cfg.RelayDir = relayDir
+	if s.streamerController != nil {
+		s.streamerController.UpdateRelayDir(relayDir)
+	}
+}
+
+// SetRelayArchive makes the syncer read binlog from the archived relay log in dir instead of the relay log or upstream
+// after resumed, it's used to replay the archive for point-in-time recovery. the syncer should be paused before calling
+// it, and binlog is read as before if dir is empty.
+func (s *Syncer) SetRelayArchive(dir string) {
+	if s.streamerController != nil {
+		s.streamerController.SetArchiveDir(dir)
+	}
+}
+
+// FlushedGlobalPoint returns the flushed global checkpoint, the syncer starts from it after resumed.
+func (s *Syncer) FlushedGlobalPoint() binlog.Location {
+	return s.checkpoint.FlushedGlobalPoint()
+}
+
+// CheckpointFlushInterval returns the current interval of flushing checkpoint.
+func (s *Syncer) CheckpointFlushInterval() time.Duration {
+	return s.checkpoint.FlushInterval()
+}
+
+// CheckpointFlushedTime returns when the checkpoint was flushed last time, it's zero if not flushed yet.
+func (s *Syncer) CheckpointFlushedTime() time.Time {
+	return s.checkpoint.LastFlushTime()
+}
+
+// SetCheckpointFlushInterval changes the interval of flushing checkpoint at runtime,
+// the change is not persisted and will be reset to `checkpoint-flush-interval` in config when the subtask restarts.
+func (s *Syncer) SetCheckpointFlushInterval(interval time.Duration) {
+	s.checkpoint.SetFlushInterval(interval)
+}
+
+// BatchSize returns the current max number of DML jobs executed in one downstream transaction.
+func (s *Syncer) BatchSize() int {
+	return int(s.batch.Get())
+}
+
+// SetBatchSize changes the batch size of downstream transactions at runtime, it takes effect from the next transaction.
+// the change is not persisted and will be reset to `batch` in config when the config is updated or the subtask restarts.
+func (s *Syncer) SetBatchSize(n int) {
+	s.batch.Set(int32(n))
+}
+
+// WriteMode returns the current write mode of DML jobs in downstream, `transactional` or `bulk`.
+func (s *Syncer) WriteMode() string {
+	if s.bulkWrite.Get() {
+		return config.WriteModeBulk
+	}
+	return config.WriteModeTransactional
+}
+
+// SetWriteMode changes the write mode of DML jobs in downstream at runtime, it takes effect from the next batch.
+// in `bulk` mode the jobs in a batch are executed one by one without a transaction, so a failed batch is partially
+// applied in downstream and the atomicity of upstream transactions isn't kept.
+// the change is not persisted and will be reset to `write-mode` in config when the config is updated or the subtask restarts.
+func (s *Syncer) SetWriteMode(mode string) {
+	bulk := mode == config.WriteModeBulk
+	if bulk && !s.bulkWrite.Get() {
+		s.tctx.L().Warn("write mode changed to bulk, DML jobs are executed without transaction and upstream transactions may be partially applied in downstream")
+	}
+	s.bulkWrite.Set(bulk)
+}
+
 // UpdateFromConfig updates config for `From`
 func (s *Syncer) UpdateFromConfig(cfg *config.SubTaskConfig) error {
 	s.Lock()
@@ -2702,7 +3257,7 @@ func (s *Syncer) UpdateFromConfig(cfg *config.SubTaskConfig) error {
 
 	var err error
 	s.cfg.From.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxDMLConnectionTimeout)
-	s.fromDB, err = createUpStreamConn(s.cfg.From)
+	s.fromDB, err = createUpStreamConn(s.dbProvider, s.cfg.From)
 	if err != nil {
 		s.tctx.L().Error("fail to create baseConn connection", log.ShortError(err))
 		return err
@@ -2776,13 +3331,13 @@ func (s *Syncer) setErrLocation(startLocation, endLocation *binlog.Location, isQ
 	s.errLocation.isQueryEvent = isQueryEventEvent
 	if s.errLocation.startLocation == nil || startLocation == nil {
 		s.errLocation.startLocation = startLocation
-	} else if binlog.CompareLocation(*startLocation, *s.errLocation.startLocation, s.cfg.EnableGTID) < 0 {
+	} else if binlog.CompareLocation(*startLocation, *s.errLocation.startLocation, s.enableGTID()) < 0 {
 		s.errLocation.startLocation = startLocation
 	}
 
 	if s.errLocation.endLocation == nil || endLocation == nil {
 		s.errLocation.endLocation = endLocation
-	} else if binlog.CompareLocation(*endLocation, *s.errLocation.endLocation, s.cfg.EnableGTID) < 0 {
+	} else if binlog.CompareLocation(*endLocation, *s.errLocation.endLocation, s.enableGTID()) < 0 {
 		s.errLocation.endLocation = endLocation
 	}
 }
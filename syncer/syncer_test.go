@@ -42,6 +42,7 @@ import (
 	"github.com/pingcap/dm/pkg/retry"
 	"github.com/pingcap/dm/pkg/schema"
 	streamer2 "github.com/pingcap/dm/pkg/streamer"
+	"github.com/pingcap/dm/pkg/terror"
 	"github.com/pingcap/dm/pkg/utils"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -951,6 +952,104 @@ func (s *testSyncerSuite) TestcheckpointID(c *C) {
 	c.Assert(checkpointID, Equals, "101")
 }
 
+func (s *testSyncerSuite) TestPauseBarrier(c *C) {
+	syncer := NewSyncer(s.cfg, nil)
+	loc := func(pos uint32) binlog.Location {
+		return binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: pos}, nil)
+	}
+
+	// no barrier
+	c.Assert(syncer.reachPauseBarrier(loc(100)), IsNil)
+
+	var reached []binlog.Location
+	barrier := loc(200)
+	syncer.SetPauseBarrier(&barrier, func(location binlog.Location) {
+		reached = append(reached, location)
+	})
+	c.Assert(syncer.reachPauseBarrier(loc(100)), IsNil)
+	onReached := syncer.reachPauseBarrier(loc(300))
+	c.Assert(onReached, NotNil)
+	onReached(loc(300))
+	c.Assert(reached, HasLen, 1)
+	c.Assert(reached[0].Position.Pos, Equals, uint32(300))
+	// the barrier is cleared after reached
+	c.Assert(syncer.reachPauseBarrier(loc(400)), IsNil)
+
+	// clear the barrier
+	syncer.SetPauseBarrier(&barrier, nil)
+	syncer.SetPauseBarrier(nil, nil)
+	c.Assert(syncer.reachPauseBarrier(loc(300)), IsNil)
+}
+
+func (s *testSyncerSuite) TestHandleUnsupportedDDL(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	syncer := NewSyncer(cfg, nil)
+	var jobs []*job
+	syncer.addJobFunc = func(j *job) error {
+		jobs = append(jobs, j)
+		return nil
+	}
+
+	var (
+		sql         = "CREATE TABLE t1 (id INT) UNSUPPORTED_OPTION"
+		parseErr    = terror.ErrSyncerParseDDL.Generate(sql)
+		startLoc    = binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 100}, nil)
+		currentLoc  = binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: 200}, nil)
+		lastLoc     binlog.Location
+		newEventCtx = func() eventContext {
+			lastLoc = startLoc
+			return eventContext{
+				tctx:            tcontext.Background(),
+				startLocation:   &startLoc,
+				currentLocation: &currentLoc,
+				lastLocation:    &lastLoc,
+			}
+		}
+	)
+
+	// pause by default
+	syncer.cfg.UnsupportedDDLPolicy = config.UnsupportedDDLPause
+	c.Assert(syncer.handleUnsupportedDDL(newEventCtx(), "db1", sql, parseErr), Equals, parseErr)
+	c.Assert(jobs, HasLen, 0)
+	c.Assert(syncer.SkippedUnsupportedDDLs(), HasLen, 0)
+
+	// skip and record it
+	syncer.cfg.UnsupportedDDLPolicy = config.UnsupportedDDLSkip
+	c.Assert(syncer.handleUnsupportedDDL(newEventCtx(), "db1", sql, parseErr), IsNil)
+	c.Assert(jobs, HasLen, 1)
+	c.Assert(jobs[0].tp, Equals, skip)
+	c.Assert(lastLoc.Position, Equals, currentLoc.Position)
+	skipped := syncer.SkippedUnsupportedDDLs()
+	c.Assert(skipped, HasLen, 1)
+	c.Assert(skipped[0].Schema, Equals, "db1")
+	c.Assert(skipped[0].Statement, Equals, sql)
+	c.Assert(skipped[0].Location, Equals, currentLoc.String())
+
+	// only the recent skipped DDLs are kept
+	for i := 0; i < maxSkippedDDLs; i++ {
+		syncer.recordSkippedDDL("db2", sql, currentLoc)
+	}
+	skipped = syncer.SkippedUnsupportedDDLs()
+	c.Assert(skipped, HasLen, maxSkippedDDLs)
+	c.Assert(skipped[0].Schema, Equals, "db2")
+
+	// apply verbatim
+	jobs = jobs[:0]
+	syncer.cfg.UnsupportedDDLPolicy = config.UnsupportedDDLApplyVerbatim
+	c.Assert(syncer.handleUnsupportedDDL(newEventCtx(), "db1", sql, parseErr), IsNil)
+	c.Assert(jobs, HasLen, 1)
+	c.Assert(jobs[0].tp, Equals, ddl)
+	c.Assert(jobs[0].ddls, DeepEquals, []string{"USE `db1`", sql})
+	c.Assert(jobs[0].location.Position, Equals, currentLoc.Position)
+
+	// can't apply verbatim in sharding mode
+	jobs = jobs[:0]
+	syncer.cfg.IsSharding = true
+	c.Assert(syncer.handleUnsupportedDDL(newEventCtx(), "db1", sql, parseErr), Equals, parseErr)
+	c.Assert(jobs, HasLen, 0)
+}
+
 func (s *testSyncerSuite) TestCasuality(c *C) {
 	var wg sync.WaitGroup
 	s.cfg.WorkerCount = 1
@@ -1656,3 +1755,49 @@ func (s *Syncer) setupMockCheckpoint(c *C, checkPointDBConn *sql.Conn, checkPoin
 	s.checkpoint.(*RemoteCheckPoint).dbConn = &DBConn{cfg: s.cfg, baseConn: conn.NewBaseConn(checkPointDBConn, &retry.FiniteRetryStrategy{})}
 	c.Assert(s.checkpoint.(*RemoteCheckPoint).prepare(tcontext.Background()), IsNil)
 }
+
+func (s *testSyncerSuite) TestBatchSize(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.Batch = 100
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.BatchSize(), Equals, 100)
+
+	syncer.SetBatchSize(500)
+	c.Assert(syncer.BatchSize(), Equals, 500)
+	c.Assert(syncer.cfg.Batch, Equals, 100)
+}
+
+func (s *testSyncerSuite) TestWriteMode(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.WriteMode = config.WriteModeBulk
+	syncer := NewSyncer(cfg, nil)
+	c.Assert(syncer.WriteMode(), Equals, config.WriteModeBulk)
+
+	syncer.SetWriteMode(config.WriteModeTransactional)
+	c.Assert(syncer.WriteMode(), Equals, config.WriteModeTransactional)
+	c.Assert(syncer.cfg.WriteMode, Equals, config.WriteModeBulk)
+}
+
+func (s *testSyncerSuite) TestReplicationLag(c *C) {
+	cfg, err := s.cfg.Clone()
+	c.Assert(err, IsNil)
+	cfg.QueueSize = 10
+	syncer := NewSyncer(cfg, nil)
+	syncer.newJobChans(1)
+	defer syncer.closeJobChans()
+	c.Assert(syncer.ReplicationLag(), Equals, time.Duration(0))
+
+	// no jobs buffered
+	syncer.lastEventTS.Set(time.Now().Add(-time.Hour).Unix())
+	c.Assert(syncer.ReplicationLag(), Equals, time.Duration(0))
+
+	syncer.jobs[0] <- &job{tp: insert}
+	lag := syncer.ReplicationLag()
+	c.Assert(lag >= time.Hour && lag < time.Hour+time.Minute, IsTrue)
+
+	// upstream clock is ahead
+	syncer.lastEventTS.Set(time.Now().Add(time.Hour).Unix())
+	c.Assert(syncer.ReplicationLag(), Equals, time.Duration(0))
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+)
+
+const (
+	// tableLagBucket is the span of a bucket counting the DML events executed recently.
+	tableLagBucket = time.Minute
+	// tableLagBuckets is the number of buckets, the recent window is tableLagBucket * tableLagBuckets.
+	tableLagBuckets = 5
+)
+
+// TableLag represents the DML events of a target table in the sync unit, to find the write-heavy tables dominating
+// the replication lag.
+type TableLag struct {
+	// Table is the target table, like "`db`.`tbl`".
+	Table string `json:"table"`
+	// Pending is the number of DML events dispatched but not executed in downstream yet.
+	Pending int64 `json:"pending"`
+	// RecentExecuted is the number of DML events executed in downstream in the recent 5 minutes.
+	RecentExecuted int64 `json:"recent-executed"`
+	// TotalExecuted is the number of DML events executed in downstream since the syncer started.
+	TotalExecuted int64 `json:"total-executed"`
+	// Lag is the time since the binlog event of the earliest pending DML if there are pending ones, the lag when the
+	// last DML was executed otherwise. it's approximate because DMLs of a table may be executed out of order by
+	// different workers.
+	Lag time.Duration `json:"lag"`
+}
+
+type tableLagStats struct {
+	pending       int64
+	totalExecuted int64
+	// buckets[i] counts the DMLs executed in the bucket starting at bucketStarts[i]
+	buckets      [tableLagBuckets]int64
+	bucketStarts [tableLagBuckets]int64
+	// pendingSinceTS is the timestamp of the binlog event of the earliest pending DML
+	pendingSinceTS int64
+	// the lag when the last DML was executed
+	lastLag time.Duration
+}
+
+// tableLags counts the DML events of each target table dispatched and executed, it's safe for concurrent use.
+type tableLags struct {
+	sync.Mutex
+	tables map[string]*tableLagStats
+}
+
+func (l *tableLags) statsOf(table string) *tableLagStats {
+	if l.tables == nil {
+		l.tables = make(map[string]*tableLagStats)
+	}
+	stats, ok := l.tables[table]
+	if !ok {
+		stats = &tableLagStats{}
+		l.tables[table] = stats
+	}
+	return stats
+}
+
+// dispatched records a DML job dispatched to the workers.
+func (l *tableLags) dispatched(j *job) {
+	l.Lock()
+	defer l.Unlock()
+	stats := l.statsOf(dbutil.TableName(j.targetSchema, j.targetTable))
+	if stats.pending == 0 || j.eventTS < stats.pendingSinceTS {
+		stats.pendingSinceTS = j.eventTS
+	}
+	stats.pending++
+}
+
+// executed records the DML jobs executed in downstream at now.
+func (l *tableLags) executed(jobs []*job, now time.Time) {
+	bucketStart := now.Truncate(tableLagBucket).Unix()
+	idx := int(bucketStart/int64(tableLagBucket/time.Second)) % tableLagBuckets
+
+	l.Lock()
+	defer l.Unlock()
+	for _, j := range jobs {
+		if j.tp != insert && j.tp != update && j.tp != del {
+			continue
+		}
+		stats := l.statsOf(dbutil.TableName(j.targetSchema, j.targetTable))
+		if stats.pending > 0 {
+			stats.pending--
+		}
+		// the DMLs before it are likely executed too.
+		if j.eventTS > stats.pendingSinceTS {
+			stats.pendingSinceTS = j.eventTS
+		}
+		stats.totalExecuted++
+		if stats.bucketStarts[idx] != bucketStart {
+			stats.bucketStarts[idx] = bucketStart
+			stats.buckets[idx] = 0
+		}
+		stats.buckets[idx]++
+		if j.eventTS > 0 {
+			stats.lastLag = now.Sub(time.Unix(j.eventTS, 0))
+		}
+	}
+}
+
+// resetPending clears the pending DMLs, because the jobs not executed are dropped when the syncer is paused.
+func (l *tableLags) resetPending() {
+	l.Lock()
+	defer l.Unlock()
+	for _, stats := range l.tables {
+		stats.pending = 0
+	}
+}
+
+// lags returns at most top tables ordered by the lag, the pending DMLs and the DMLs executed recently descending.
+// all tables are returned if top <= 0.
+func (l *tableLags) lags(top int, now time.Time) []TableLag {
+	windowStart := now.Add(-tableLagBucket * tableLagBuckets).Unix()
+
+	l.Lock()
+	result := make([]TableLag, 0, len(l.tables))
+	for table, stats := range l.tables {
+		lag := TableLag{Table: table, Pending: stats.pending, TotalExecuted: stats.totalExecuted, Lag: stats.lastLag}
+		for i, start := range stats.bucketStarts {
+			if start > windowStart {
+				lag.RecentExecuted += stats.buckets[i]
+			}
+		}
+		if stats.pending > 0 && stats.pendingSinceTS > 0 {
+			lag.Lag = now.Sub(time.Unix(stats.pendingSinceTS, 0))
+		}
+		if lag.Lag < 0 {
+			lag.Lag = 0
+		}
+		result = append(result, lag)
+	}
+	l.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		switch {
+		case result[i].Lag != result[j].Lag:
+			return result[i].Lag > result[j].Lag
+		case result[i].Pending != result[j].Pending:
+			return result[i].Pending > result[j].Pending
+		case result[i].RecentExecuted != result[j].RecentExecuted:
+			return result[i].RecentExecuted > result[j].RecentExecuted
+		}
+		return result[i].Table < result[j].Table
+	})
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
+
+// LagByTable returns at most top target tables with the most lag, pending DML events and DML events executed recently
+// in downstream, all tables are returned if top <= 0.
+func (s *Syncer) LagByTable(top int) []TableLag {
+	return s.tableLags.lags(top, time.Now())
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testTableLagSuite{})
+
+type testTableLagSuite struct{}
+
+func (t *testTableLagSuite) TestTableLags(c *C) {
+	var l tableLags
+	base := time.Unix(1600000000, 0)
+	c.Assert(l.lags(10, base), HasLen, 0)
+
+	dml := func(table string, ts int64) *job {
+		return &job{tp: insert, targetSchema: "db", targetTable: table, eventTS: ts}
+	}
+	hot1, hot2, hot3 := dml("hot", base.Unix()-30), dml("hot", base.Unix()-20), dml("hot", base.Unix()-10)
+	cold := dml("cold", base.Unix()-5)
+	for _, j := range []*job{hot1, hot2, hot3, cold} {
+		l.dispatched(j)
+	}
+
+	// cold is executed 1s after its event.
+	l.executed([]*job{cold, {tp: ddl, targetSchema: "db", targetTable: "cold"}}, base.Add(-4*time.Second))
+	c.Assert(l.lags(0, base), DeepEquals, []TableLag{
+		{Table: "`db`.`hot`", Pending: 3, Lag: 30 * time.Second},
+		{Table: "`db`.`cold`", RecentExecuted: 1, TotalExecuted: 1, Lag: time.Second},
+	})
+
+	// the earliest pending one is advanced.
+	l.executed([]*job{hot1, hot2}, base)
+	c.Assert(l.lags(1, base), DeepEquals, []TableLag{
+		{Table: "`db`.`hot`", Pending: 1, RecentExecuted: 2, TotalExecuted: 2, Lag: 20 * time.Second},
+	})
+
+	// out of the recent window.
+	l.executed([]*job{hot3}, base)
+	c.Assert(l.lags(0, base.Add(10*time.Minute)), DeepEquals, []TableLag{
+		{Table: "`db`.`hot`", TotalExecuted: 3, Lag: 10 * time.Second},
+		{Table: "`db`.`cold`", TotalExecuted: 1, Lag: time.Second},
+	})
+
+	// the jobs not executed are dropped.
+	l.dispatched(dml("cold", base.Unix()))
+	l.resetPending()
+	c.Assert(l.lags(0, base)[1], DeepEquals, TableLag{Table: "`db`.`cold`", RecentExecuted: 1, TotalExecuted: 1, Lag: time.Second})
+}
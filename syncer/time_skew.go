@@ -0,0 +1,97 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+// ConnectionSkew represents the clock skew between a database and the worker.
+type ConnectionSkew struct {
+	// Skew is the clock of the database minus the clock of the worker, positive if the database is ahead.
+	Skew time.Duration `json:"skew"`
+	// RTT is the round-trip time of the query, the skew is accurate to within RTT/2.
+	RTT time.Duration `json:"rtt"`
+}
+
+// TimeSkew represents the clock skews of upstream and downstream related to the worker.
+type TimeSkew struct {
+	Upstream   ConnectionSkew `json:"upstream"`
+	Downstream ConnectionSkew `json:"downstream"`
+	// Corrected is whether the upstream skew is used to correct the replication lag, see `correct-clock-skew`.
+	Corrected bool `json:"corrected"`
+}
+
+// measureClockSkew queries NOW() of the database, and compares it with the clock of the worker at the middle of the query.
+func measureClockSkew(ctx context.Context, db *sql.DB) (ConnectionSkew, error) {
+	var ts float64
+	before := time.Now()
+	err := db.QueryRowContext(ctx, "SELECT UNIX_TIMESTAMP(NOW(6))").Scan(&ts)
+	after := time.Now()
+	if err != nil {
+		return ConnectionSkew{}, terror.DBErrorAdapt(err, terror.ErrDBDriverError)
+	}
+	sec, frac := math.Modf(ts)
+	remote := time.Unix(int64(sec), int64(frac*float64(time.Second)))
+	rtt := after.Sub(before)
+	return ConnectionSkew{
+		Skew: remote.Sub(before.Add(rtt / 2)),
+		RTT:  rtt,
+	}, nil
+}
+
+// TimeSkew measures the clock skews of upstream and downstream related to the worker. if `correct-clock-skew` is set,
+// the upstream skew is used to correct the replication lag calculated from the timestamp of binlog events.
+func (s *Syncer) TimeSkew(ctx context.Context) (*TimeSkew, error) {
+	if s.fromDB == nil || s.toDB == nil {
+		return nil, terror.ErrSyncerMeasureTimeSkew.Generate("the syncer is not initialized")
+	}
+	upstream, err := measureClockSkew(ctx, s.fromDB.BaseDB.DB)
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeUpstream)
+	}
+	downstream, err := measureClockSkew(ctx, s.toDB.DB)
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	if s.cfg.CorrectClockSkew {
+		s.upstreamClockSkew.Set(int64(upstream.Skew))
+	}
+	return &TimeSkew{
+		Upstream:   upstream,
+		Downstream: downstream,
+		Corrected:  s.cfg.CorrectClockSkew,
+	}, nil
+}
+
+// initClockSkew measures the upstream clock skew when starting if `correct-clock-skew` is set, the lag is not corrected
+// if it fails.
+func (s *Syncer) initClockSkew(ctx context.Context) {
+	if !s.cfg.CorrectClockSkew {
+		return
+	}
+	skew, err := s.TimeSkew(ctx)
+	if err != nil {
+		s.tctx.L().Warn("fail to measure clock skew, the replication lag is not corrected", zap.Error(err))
+		return
+	}
+	s.tctx.L().Info("measured clock skew", zap.Duration("upstream", skew.Upstream.Skew), zap.Duration("downstream", skew.Downstream.Skew))
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/conn"
+	"github.com/pingcap/dm/pkg/terror"
+)
+
+var _ = Suite(&testTimeSkewSuite{})
+
+type testTimeSkewSuite struct{}
+
+func mockNow(mock sqlmock.Sqlmock, t time.Time) {
+	mock.ExpectQuery("SELECT UNIX_TIMESTAMP\\(NOW\\(6\\)\\)").WillReturnRows(
+		sqlmock.NewRows([]string{"UNIX_TIMESTAMP(NOW(6))"}).AddRow(fmt.Sprintf("%.6f", float64(t.UnixNano())/1e9)))
+}
+
+func (t *testTimeSkewSuite) TestTimeSkew(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test-time-skew"}
+	cfg.QueueSize = 10
+	syncer := NewSyncer(cfg, nil)
+	_, err := syncer.TimeSkew(context.Background())
+	c.Assert(terror.ErrSyncerMeasureTimeSkew.Equal(err), IsTrue)
+
+	upDB, upMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	downDB, downMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	syncer.fromDB = &UpStreamConn{BaseDB: conn.NewBaseDB(upDB, func() {})}
+	syncer.toDB = conn.NewBaseDB(downDB, func() {})
+
+	// upstream is ahead and downstream is behind, the lag is not corrected by default.
+	mockNow(upMock, time.Now().Add(time.Hour))
+	mockNow(downMock, time.Now().Add(-time.Minute))
+	skew, err := syncer.TimeSkew(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(skew.Corrected, IsFalse)
+	c.Assert(skew.Upstream.Skew > 59*time.Minute && skew.Upstream.Skew <= time.Hour, IsTrue)
+	c.Assert(skew.Downstream.Skew < -59*time.Second && skew.Downstream.Skew >= -time.Minute-time.Second, IsTrue)
+	c.Assert(skew.Upstream.RTT >= 0 && skew.Upstream.RTT < time.Second, IsTrue)
+	c.Assert(syncer.upstreamClockSkew.Get(), Equals, int64(0))
+
+	// the replication lag is corrected.
+	syncer.cfg.CorrectClockSkew = true
+	mockNow(upMock, time.Now().Add(time.Hour))
+	mockNow(downMock, time.Now())
+	skew, err = syncer.TimeSkew(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(skew.Corrected, IsTrue)
+	c.Assert(syncer.upstreamClockSkew.Get(), Equals, int64(skew.Upstream.Skew))
+
+	syncer.newJobChans(1)
+	defer syncer.closeJobChans()
+	syncer.jobs[0] <- &job{tp: insert}
+	syncer.lastEventTS.Set(time.Now().Add(time.Hour).Add(-time.Minute).Unix())
+	lag := syncer.ReplicationLag()
+	c.Assert(lag > 58*time.Second && lag < 62*time.Second, IsTrue, Commentf("lag %s", lag))
+
+	c.Assert(upMock.ExpectationsWereMet(), IsNil)
+	c.Assert(downMock.ExpectationsWereMet(), IsNil)
+}